@@ -44,18 +44,23 @@ func run() int {
 	// Create context for subscriber that can be canceled on shutdown
 	ctx, cancel := context.WithCancelCause(context.Background())
 
-	// Start the delivery subscriber to consume package assignment events.
-	err = service.DeliverySubscriber.Start(ctx)
-	if err != nil {
-		service.Log.Error("Failed to start delivery subscriber", slog.String("error", err.Error()))
-		cancel(fmt.Errorf("delivery subscriber start failed: %w", err)) //nolint:err113 // startup error should be attached to context cause
-		cleanup()
+	if service.FileReplayer != nil {
+		// Replay mode: publish recorded locations instead of simulating assignments via OSRM.
+		go replayLocations(ctx, service)
+	} else {
+		// Start the delivery subscriber to consume package assignment events.
+		err = service.DeliverySubscriber.Start(ctx)
+		if err != nil {
+			service.Log.Error("Failed to start delivery subscriber", slog.String("error", err.Error()))
+			cancel(fmt.Errorf("delivery subscriber start failed: %w", err)) //nolint:err113 // startup error should be attached to context cause
+			cleanup()
+
+			return 1
+		}
 
-		return 1
+		service.Log.Info("Delivery subscriber started, listening for package assignments")
 	}
 
-	service.Log.Info("Delivery subscriber started, listening for package assignments")
-
 	service.Log.Info("Courier Emulation Service running")
 
 	// Handle SIGINT, SIGQUIT and SIGTERM - blocks until signal received
@@ -64,7 +69,7 @@ func run() int {
 	// Cancel the subscriber context to signal it to stop
 	cancel(fmt.Errorf("shutdown signal received: %s", signal)) //nolint:err113 // dynamic message for shutdown reason
 
-	// Run cleanup (stops simulations and closes publishers)
+	// Run cleanup (drains in-flight deliveries, stops simulations and closes publishers)
 	cleanup()
 
 	service.Log.Info("Courier Emulation Service stopped", slog.String("signal", signal.String()))
@@ -73,3 +78,20 @@ func run() int {
 
 	return gracefulShutdownExitCode
 }
+
+// replayLocations publishes the recorded location file once and logs the outcome.
+func replayLocations(ctx context.Context, service *courier_di.CourierEmulationService) {
+	service.Log.Info("Replaying recorded locations", slog.String("file", service.FileReplayer.Path()))
+
+	published, err := service.FileReplayer.Replay(ctx)
+	if err != nil {
+		service.Log.Error("Location replay stopped",
+			slog.Int("published", published),
+			slog.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	service.Log.Info("Location replay finished", slog.Int("published", published))
+}
@@ -1,34 +1,46 @@
 package pkg_di
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/shortlink-org/go-sdk/config"
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/services"
-	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
 	"github.com/spf13/viper"
 )
 
-// defaultSimulationSpeedKmH is the baseline courier speed for idle route simulation.
-const defaultSimulationSpeedKmH = 30.0
-
 // NewCourierSimulator creates the courier simulator.
-func NewCourierSimulator(cfg *config.Config, routeGen *services.RouteGenerator, publisher *kafka.LocationPublisher) *services.CourierSimulator {
+func NewCourierSimulator(cfg *config.Config, routeGen *services.RouteGenerator, publisher *services.MultiLocationPublisher) (*services.CourierSimulator, error) {
 	viper.SetDefault("SIMULATION_UPDATE_INTERVAL", 5*time.Second)
-	viper.SetDefault("SIMULATION_SPEED_KMH", defaultSimulationSpeedKmH)
+	// The default speed follows the OSRM profile: cyclists and walkers are slower than cars
+	viper.SetDefault("SIMULATION_SPEED_KMH", routeGen.Profile().DefaultSpeedKmH())
 	viper.SetDefault("SIMULATION_TIME_MULTIPLIER", 1.0)
+	viper.SetDefault("SIMULATION_LOOP_ROUTES", false)
+	viper.SetDefault("SIMULATION_GPS_NOISE_METERS", 0.0)
+	viper.SetDefault("SIMULATION_REGION", "berlin")
 
 	updateInterval := cfg.GetDuration("SIMULATION_UPDATE_INTERVAL")
 	speedKmH := cfg.GetFloat64("SIMULATION_SPEED_KMH")
 	timeMultiplier := cfg.GetFloat64("SIMULATION_TIME_MULTIPLIER")
+	loopRoutes := cfg.GetBool("SIMULATION_LOOP_ROUTES")
+	gpsNoise := cfg.GetFloat64("SIMULATION_GPS_NOISE_METERS")
+
+	region, err := vo.BoundingBoxPreset(cfg.GetString("SIMULATION_REGION"))
+	if err != nil {
+		return nil, fmt.Errorf("SIMULATION_REGION: %w", err)
+	}
 
 	return services.NewCourierSimulator(
 		services.CourierSimulatorConfig{
 			UpdateInterval: updateInterval,
 			SpeedKmH:       speedKmH,
 			TimeMultiplier: timeMultiplier,
+			LoopRoutes:     loopRoutes,
+			GPSNoiseMeters: gpsNoise,
+			Region:         region,
 		},
 		routeGen,
 		publisher,
-	)
+	), nil
 }
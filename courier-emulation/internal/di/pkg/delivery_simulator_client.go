@@ -1,39 +1,61 @@
 package pkg_di
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/observability/metrics"
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/services"
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
 	"github.com/spf13/viper"
 )
 
 const (
-	// defaultDeliverySimulationSpeedKmH is the baseline courier speed for delivery simulation.
-	defaultDeliverySimulationSpeedKmH = 30.0
 	// defaultDeliveryFailureRate is the default probability of a simulated failed delivery.
 	defaultDeliveryFailureRate = 0.05
 	// defaultPickupWait is the pause spent at pickup before switching to delivery.
 	defaultPickupWait = 30 * time.Second
 	// defaultDeliveryWait is the pause spent at the destination before completing delivery.
 	defaultDeliveryWait = 60 * time.Second
+	// defaultRetryDelay is the pause before a failed delivery is attempted again.
+	defaultRetryDelay = 10 * time.Minute
 )
 
+// errMalformedFailureReason is returned for a SIMULATION_FAILURE_REASONS entry without "=".
+var errMalformedFailureReason = errors.New("entry must be REASON=weight")
+
+// NewDeliveryMetrics creates the delivery simulator instruments, exported via the monitoring /metrics endpoint.
+func NewDeliveryMetrics(monitoring *metrics.Monitoring) (*services.DeliveryMetrics, error) {
+	return services.NewDeliveryMetrics(monitoring.Metrics.Meter(services.DeliveryMetricsMeterName))
+}
+
 // NewDeliverySimulator creates the delivery simulator with configuration.
 func NewDeliverySimulator(
 	cfg *config.Config,
 	routeGen *services.RouteGenerator,
-	locationPub *kafka.LocationPublisher,
+	locationPub *services.MultiLocationPublisher,
 	statusPub *kafka.KafkaStatusPublisher,
-) *services.DeliverySimulator {
+	deliveryMetrics *services.DeliveryMetrics,
+) (*services.DeliverySimulator, error) {
 	// Set defaults
 	viper.SetDefault("SIMULATION_UPDATE_INTERVAL", 5*time.Second)
-	viper.SetDefault("SIMULATION_SPEED_KMH", defaultDeliverySimulationSpeedKmH)
+	viper.SetDefault("SIMULATION_SPEED_KMH", routeGen.Profile().DefaultSpeedKmH())
 	viper.SetDefault("SIMULATION_TIME_MULTIPLIER", 1.0)
 	viper.SetDefault("SIMULATION_PICKUP_WAIT", defaultPickupWait)
 	viper.SetDefault("SIMULATION_DELIVERY_WAIT", defaultDeliveryWait)
 	viper.SetDefault("SIMULATION_FAILURE_RATE", defaultDeliveryFailureRate)
+	viper.SetDefault("SIMULATION_FAILURE_REASONS", "")
+	viper.SetDefault("SIMULATION_GPS_NOISE_METERS", 0.0)
+	viper.SetDefault("SIMULATION_START_OFFSET_METERS", services.DefaultDeliverySimulatorConfig().StartOffsetMeters)
+	viper.SetDefault("SIMULATION_STRAIGHT_LINE_FALLBACK", false)
+	viper.SetDefault("SIMULATION_DELIVERED_PROXIMITY_METERS", 0.0)
+	viper.SetDefault("SIMULATION_SLA_BREACH_GRACE", time.Duration(0))
+	viper.SetDefault("SIMULATION_RETRY_MAX_ATTEMPTS", 0)
+	viper.SetDefault("SIMULATION_RETRY_DELAY", defaultRetryDelay)
 
 	// Read configuration
 	updateInterval := cfg.GetDuration("SIMULATION_UPDATE_INTERVAL")
@@ -42,15 +64,66 @@ func NewDeliverySimulator(
 	pickupWait := cfg.GetDuration("SIMULATION_PICKUP_WAIT")
 	deliveryWait := cfg.GetDuration("SIMULATION_DELIVERY_WAIT")
 	failureRate := cfg.GetFloat64("SIMULATION_FAILURE_RATE")
+	gpsNoise := cfg.GetFloat64("SIMULATION_GPS_NOISE_METERS")
+	startOffset := cfg.GetFloat64("SIMULATION_START_OFFSET_METERS")
+	straightLineFallback := cfg.GetBool("SIMULATION_STRAIGHT_LINE_FALLBACK")
+	deliveredProximity := cfg.GetFloat64("SIMULATION_DELIVERED_PROXIMITY_METERS")
+	slaBreachGrace := cfg.GetDuration("SIMULATION_SLA_BREACH_GRACE")
+	retryMaxAttempts := cfg.GetInt("SIMULATION_RETRY_MAX_ATTEMPTS")
+	retryDelay := cfg.GetDuration("SIMULATION_RETRY_DELAY")
+
+	failureReasons, err := parseFailureReasons(cfg.GetString("SIMULATION_FAILURE_REASONS"))
+	if err != nil {
+		return nil, err
+	}
 
 	simCfg := services.DeliverySimulatorConfig{
-		UpdateInterval:   updateInterval,
-		SpeedKmH:         speedKmH,
-		TimeMultiplier:   timeMultiplier,
-		PickupWaitTime:   pickupWait,
-		DeliveryWaitTime: deliveryWait,
-		FailureRate:      failureRate,
+		UpdateInterval:           updateInterval,
+		SpeedKmH:                 speedKmH,
+		TimeMultiplier:           timeMultiplier,
+		PickupWaitTime:           pickupWait,
+		DeliveryWaitTime:         deliveryWait,
+		FailureRate:              failureRate,
+		FailureReasons:           failureReasons,
+		GPSNoiseMeters:           gpsNoise,
+		StartOffsetMeters:        startOffset,
+		StraightLineFallback:     straightLineFallback,
+		DeliveredProximityMeters: deliveredProximity,
+		SLABreachGrace:           slaBreachGrace,
+		RetryMaxAttempts:         retryMaxAttempts,
+		RetryDelay:               retryDelay,
+	}
+
+	return services.NewDeliverySimulator(simCfg, routeGen, locationPub, statusPub, deliveryMetrics), nil
+}
+
+// parseFailureReasons parses "REASON=weight,REASON=weight" into a validated distribution.
+// An empty value selects services.DefaultFailureReasonWeights.
+func parseFailureReasons(value string) (services.FailureReasonDistribution, error) {
+	if strings.TrimSpace(value) == "" {
+		return services.DefaultFailureReasonDistribution(), nil
+	}
+
+	weights := make(map[kafka.NotDeliveredReason]float64)
+
+	for entry := range strings.SplitSeq(value, ",") {
+		reason, rawWeight, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return services.FailureReasonDistribution{}, fmt.Errorf("SIMULATION_FAILURE_REASONS: %w: got=%q", errMalformedFailureReason, entry)
+		}
+
+		weight, err := strconv.ParseFloat(strings.TrimSpace(rawWeight), 64)
+		if err != nil {
+			return services.FailureReasonDistribution{}, fmt.Errorf("SIMULATION_FAILURE_REASONS: weight of %s: %w", reason, err)
+		}
+
+		weights[kafka.NotDeliveredReason(strings.TrimSpace(reason))] = weight
+	}
+
+	dist, err := services.NewFailureReasonDistribution(weights)
+	if err != nil {
+		return services.FailureReasonDistribution{}, fmt.Errorf("SIMULATION_FAILURE_REASONS: %w", err)
 	}
 
-	return services.NewDeliverySimulator(simCfg, routeGen, locationPub, statusPub)
+	return dist, nil
 }
@@ -0,0 +1,30 @@
+package pkg_di
+
+import (
+	"time"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/observability/metrics"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
+	"github.com/spf13/viper"
+)
+
+// defaultKafkaHealthTimeout bounds the metadata request of a single readiness check.
+const defaultKafkaHealthTimeout = 2 * time.Second
+
+// NewKafkaHealthChecker creates the Kafka readiness check and serves it on the monitoring port at kafka.ReadinessPath.
+// The monitoring /ready endpoint has no checks, so the readiness probe points here.
+func NewKafkaHealthChecker(cfg *config.Config, monitoring *metrics.Monitoring, subscriber *kafka.DeliverySubscriber) *kafka.HealthChecker {
+	viper.SetDefault("WATERMILL_KAFKA_BROKERS", []string{"localhost:9092"})
+	viper.SetDefault("KAFKA_HEALTH_TIMEOUT", defaultKafkaHealthTimeout)
+
+	brokers := cfg.GetStringSlice("WATERMILL_KAFKA_BROKERS")
+	if len(brokers) == 0 {
+		brokers = []string{"localhost:9092"}
+	}
+
+	checker := kafka.NewHealthChecker(brokers, cfg.GetDuration("KAFKA_HEALTH_TIMEOUT"), subscriber)
+	monitoring.Handler.Handle(kafka.ReadinessPath, checker)
+
+	return checker
+}
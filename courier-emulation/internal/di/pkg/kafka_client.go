@@ -14,6 +14,12 @@ import (
 // NewLocationPublisher creates the Kafka location publisher using go-sdk/watermill.
 func NewLocationPublisher(cfg *config.Config, log logger.Logger) (*kafka.LocationPublisher, func(), error) {
 	viper.SetDefault("WATERMILL_KAFKA_BROKERS", []string{"localhost:9092"})
+	viper.SetDefault("LOCATION_PARTITION_KEY", string(kafka.LocationPartitionByCourier))
+
+	partitionKey, err := kafka.ParseLocationPartitionKey(cfg.GetString("LOCATION_PARTITION_KEY"))
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("location partition key: %w", err)
+	}
 
 	publisher, err := sdkkafka.NewPublisherFromConfig(log, cfg)
 	if err != nil {
@@ -29,5 +35,5 @@ func NewLocationPublisher(cfg *config.Config, log logger.Logger) (*kafka.Locatio
 		}
 	}
 
-	return kafka.NewLocationPublisher(publisher), cleanup, nil
+	return kafka.NewLocationPublisher(publisher, partitionKey), cleanup, nil
 }
@@ -0,0 +1,70 @@
+package pkg_di
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/logger"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/services"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/sse"
+	"github.com/spf13/viper"
+)
+
+const (
+	// defaultLocationStreamPort is the HTTP port of the SSE location stream.
+	defaultLocationStreamPort = 8080
+	// locationStreamShutdownTimeout bounds how long open streams may delay shutdown.
+	locationStreamShutdownTimeout = 5 * time.Second
+)
+
+// NewLocationStream creates the in-process location fan-out consumed by the SSE endpoint.
+func NewLocationStream(cfg *config.Config) *sse.LocationStream {
+	viper.SetDefault("LOCATION_STREAM_BUFFER", sse.DefaultSubscriberBuffer)
+
+	return sse.NewLocationStream(cfg.GetInt("LOCATION_STREAM_BUFFER"))
+}
+
+// NewSimulationLocationPublisher fans simulated locations out to Kafka and the SSE stream.
+// The sinks are closed by their own providers' cleanups, so the composite needs none.
+func NewSimulationLocationPublisher(kafkaPub *kafka.LocationPublisher, stream *sse.LocationStream) *services.MultiLocationPublisher {
+	return services.NewMultiLocationPublisher(kafkaPub, stream)
+}
+
+// NewLocationStreamServer starts the HTTP server exposing the location stream as Server-Sent Events.
+func NewLocationStreamServer(cfg *config.Config, log logger.Logger, stream *sse.LocationStream) (*sse.Server, func()) {
+	viper.SetDefault("LOCATION_STREAM_PORT", defaultLocationStreamPort)
+
+	addr := fmt.Sprintf(":%d", cfg.GetInt("LOCATION_STREAM_PORT"))
+	server := sse.NewServer(addr, stream)
+
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil {
+			log.Error("location stream server stopped", slog.String("error", err.Error()))
+		}
+	}()
+
+	log.Info("location stream server started",
+		slog.String("addr", addr),
+		slog.String("path", sse.StreamPath),
+	)
+
+	cleanup := func() {
+		// Close the stream first so open SSE handlers return and Shutdown does not wait on them.
+		_ = stream.Close() //nolint:errcheck // LocationStream.Close never fails
+
+		ctx, cancel := context.WithTimeout(context.Background(), locationStreamShutdownTimeout)
+		defer cancel()
+
+		err := server.Shutdown(ctx)
+		if err != nil {
+			log.Warn("failed to shut down location stream server", slog.String("error", err.Error()))
+		}
+	}
+
+	return server, cleanup
+}
@@ -10,7 +10,12 @@ import (
 	"github.com/spf13/viper"
 )
 
-const defaultOSRMTimeout = 10 * time.Second
+const (
+	defaultOSRMTimeout        = 10 * time.Second
+	defaultOSRMRequestTimeout = 3 * time.Second
+	defaultOSRMMaxRetries     = 2
+	defaultOSRMRetryBackoff   = 200 * time.Millisecond
+)
 
 var errIncompleteOSRMAuthHeader = errors.New(
 	"OSRM_AUTH_HEADER_NAME and OSRM_AUTH_HEADER_VALUE must be set together",
@@ -19,10 +24,29 @@ var errIncompleteOSRMAuthHeader = errors.New(
 // NewOSRMClient creates the OSRM route generator service.
 func NewOSRMClient(cfg *config.Config) (*services.RouteGenerator, error) {
 	viper.SetDefault("OSRM_URL", "http://localhost:5000")
+	viper.SetDefault("OSRM_PROFILE", string(services.ProfileDriving))
 	viper.SetDefault("OSRM_TIMEOUT", defaultOSRMTimeout)
+	viper.SetDefault("OSRM_REQUEST_TIMEOUT", defaultOSRMRequestTimeout)
+	viper.SetDefault("OSRM_MAX_RETRIES", defaultOSRMMaxRetries)
+	viper.SetDefault("OSRM_RETRY_BACKOFF", defaultOSRMRetryBackoff)
+	viper.SetDefault("OSRM_BATCH_CONCURRENCY", services.DefaultRouteGeneratorConfig().BatchConcurrency)
+
+	defaultCache := services.DefaultRouteCacheConfig()
+	viper.SetDefault("OSRM_CACHE_NUM_COUNTERS", defaultCache.NumCounters)
+	viper.SetDefault("OSRM_CACHE_MAX_COST", defaultCache.MaxCost)
+	viper.SetDefault("OSRM_CACHE_TTL", defaultCache.TTL)
 
 	osrmURL := cfg.GetString("OSRM_URL")
 	timeout := cfg.GetDuration("OSRM_TIMEOUT")
+	requestTimeout := cfg.GetDuration("OSRM_REQUEST_TIMEOUT")
+	maxRetries := cfg.GetInt("OSRM_MAX_RETRIES")
+	retryBackoff := cfg.GetDuration("OSRM_RETRY_BACKOFF")
+
+	profile, err := services.ParseRouteProfile(cfg.GetString("OSRM_PROFILE"))
+	if err != nil {
+		return nil, fmt.Errorf("OSRM_PROFILE: %w", err)
+	}
+
 	authHeaderName := cfg.GetString("OSRM_AUTH_HEADER_NAME")
 	authHeaderValue := cfg.GetString("OSRM_AUTH_HEADER_VALUE")
 
@@ -32,9 +56,19 @@ func NewOSRMClient(cfg *config.Config) (*services.RouteGenerator, error) {
 
 	routeGenerator, err := services.NewRouteGenerator(services.RouteGeneratorConfig{
 		OSRMBaseURL:     osrmURL,
+		Profile:         profile,
 		Timeout:         timeout,
+		RequestTimeout:  requestTimeout,
+		MaxRetries:      maxRetries,
+		RetryBackoff:    retryBackoff,
 		AuthHeaderName:  authHeaderName,
 		AuthHeaderValue: authHeaderValue,
+		Cache: services.RouteCacheConfig{
+			NumCounters: cfg.GetInt64("OSRM_CACHE_NUM_COUNTERS"),
+			MaxCost:     cfg.GetInt64("OSRM_CACHE_MAX_COST"),
+			TTL:         cfg.GetDuration("OSRM_CACHE_TTL"),
+		},
+		BatchConcurrency: cfg.GetInt("OSRM_BATCH_CONCURRENCY"),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("new route generator: %w", err)
@@ -0,0 +1,22 @@
+package pkg_di
+
+import (
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/services"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/replay"
+	"github.com/spf13/viper"
+)
+
+// NewFileReplayer creates the replayer for REPLAY_FILE.
+// It returns nil when REPLAY_FILE is unset, i.e. the service simulates deliveries via OSRM.
+func NewFileReplayer(cfg *config.Config, publisher *services.MultiLocationPublisher) (*replay.FileReplayer, error) {
+	viper.SetDefault("REPLAY_FILE", "")
+	viper.SetDefault("REPLAY_SPEED_MULTIPLIER", 1.0)
+
+	path := cfg.GetString("REPLAY_FILE")
+	if path == "" {
+		return nil, nil //nolint:nilnil // replay mode is disabled
+	}
+
+	return replay.NewFileReplayer(path, cfg.GetFloat64("REPLAY_SPEED_MULTIPLIER"), publisher)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/shortlink-org/go-sdk/config"
 	"github.com/shortlink-org/go-sdk/logger"
+	sdkkafka "github.com/shortlink-org/go-sdk/watermill/backends/kafka"
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/services"
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
 	"github.com/spf13/viper"
@@ -44,9 +45,13 @@ func (w *watermillLoggerAdapter) With(fields watermill.LogFields) watermill.Logg
 func NewDeliverySubscriber(
 	cfg *config.Config,
 	log logger.Logger,
-	deliverySimulator *services.DeliverySimulator,
+	fleet *services.Fleet,
 ) (*kafka.DeliverySubscriber, func(), error) {
 	viper.SetDefault("WATERMILL_KAFKA_BROKERS", []string{"localhost:9092"})
+	viper.SetDefault("DELIVERY_SUBSCRIBER_INITIAL_OFFSET", string(kafka.DefaultDeliverySubscriberConfig().InitialOffset))
+	viper.SetDefault("DELIVERY_SUBSCRIBER_MAX_ATTEMPTS", kafka.DefaultDeliverySubscriberConfig().MaxAttempts)
+	viper.SetDefault("DELIVERY_SUBSCRIBER_DEDUP_TTL", kafka.DefaultDeliverySubscriberConfig().DedupTTL)
+	viper.SetDefault("DELIVERY_SUBSCRIBER_SHUTDOWN_TIMEOUT", kafka.DefaultDeliverySubscriberConfig().ShutdownTimeout)
 
 	brokers := cfg.GetStringSlice("WATERMILL_KAFKA_BROKERS")
 	if len(brokers) == 0 {
@@ -54,18 +59,30 @@ func NewDeliverySubscriber(
 	}
 
 	subscriberConfig := kafka.DeliverySubscriberConfig{
-		Brokers:       brokers,
-		ConsumerGroup: kafka.ConsumerGroupCourierEmulation,
+		Brokers:         brokers,
+		ConsumerGroup:   kafka.ConsumerGroupCourierEmulation,
+		InitialOffset:   kafka.InitialOffset(cfg.GetString("DELIVERY_SUBSCRIBER_INITIAL_OFFSET")),
+		MaxAttempts:     cfg.GetInt("DELIVERY_SUBSCRIBER_MAX_ATTEMPTS"),
+		DedupTTL:        cfg.GetDuration("DELIVERY_SUBSCRIBER_DEDUP_TTL"),
+		ShutdownTimeout: cfg.GetDuration("DELIVERY_SUBSCRIBER_SHUTDOWN_TIMEOUT"),
 	}
 
-	// Create handler that connects to DeliverySimulator
-	handler := kafka.NewCourierEmulationHandler(deliverySimulator)
+	// Create handler that starts deliveries through the fleet, so idle free-roaming couriers can take orders
+	handler := kafka.NewCourierEmulationHandler(fleet)
 
 	// Create Watermill logger adapter
 	wmLogger := &watermillLoggerAdapter{log: log}
 
-	subscriber, err := kafka.NewDeliverySubscriber(subscriberConfig, handler, wmLogger)
+	// Poison and repeatedly failing messages are moved to <topic>.DLQ
+	dlqPublisher, err := sdkkafka.NewPublisherFromConfig(log, cfg)
 	if err != nil {
+		return nil, func() {}, fmt.Errorf("new delivery dlq publisher: %w", err)
+	}
+
+	subscriber, err := kafka.NewDeliverySubscriber(subscriberConfig, handler, wmLogger, dlqPublisher)
+	if err != nil {
+		_ = dlqPublisher.Close() //nolint:errcheck // best-effort cleanup on constructor failure
+
 		return nil, func() {}, fmt.Errorf("new delivery subscriber: %w", err)
 	}
 
@@ -9,6 +9,10 @@ Courier Emulation DI-package
 package courier_di
 
 import (
+	"context"
+	"log/slog"
+	"time"
+
 	"github.com/google/wire"
 	"go.opentelemetry.io/otel/trace"
 
@@ -22,8 +26,13 @@ import (
 	pkg_di "github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/di/pkg"
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/services"
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/replay"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/sse"
 )
 
+// deliveryDrainTimeout bounds how long shutdown lets in-flight deliveries finish before interrupting them.
+const deliveryDrainTimeout = 10 * time.Second
+
 type CourierEmulationService struct {
 	// Common
 	Log    logger.Logger
@@ -43,6 +52,14 @@ type CourierEmulationService struct {
 	LocationPublisher  *kafka.LocationPublisher
 	StatusPublisher    *kafka.KafkaStatusPublisher
 	DeliverySubscriber *kafka.DeliverySubscriber
+	KafkaHealth        *kafka.HealthChecker
+
+	// Streaming
+	LocationStream       *sse.LocationStream
+	LocationStreamServer *sse.Server
+
+	// Replay mode; nil unless REPLAY_FILE is set
+	FileReplayer *replay.FileReplayer
 }
 
 // DefaultSet ==========================================================================================================
@@ -65,11 +82,22 @@ var CourierEmulationSet = wire.NewSet(
 	pkg_di.NewOSRMClient,
 	pkg_di.NewCourierSimulator,
 	pkg_di.NewDeliverySimulator,
+	pkg_di.NewDeliveryMetrics,
+	services.NewFleet,
 
 	// Infrastructure
 	pkg_di.NewLocationPublisher,
 	pkg_di.NewStatusPublisher,
 	pkg_di.NewDeliverySubscriber,
+	pkg_di.NewKafkaHealthChecker,
+
+	// Streaming
+	pkg_di.NewLocationStream,
+	pkg_di.NewLocationStreamServer,
+	pkg_di.NewSimulationLocationPublisher,
+
+	// Replay
+	pkg_di.NewFileReplayer,
 
 	NewCourierEmulationService,
 )
@@ -93,13 +121,28 @@ func NewCourierEmulationService(
 	locationPublisher *kafka.LocationPublisher,
 	statusPublisher *kafka.KafkaStatusPublisher,
 	deliverySubscriber *kafka.DeliverySubscriber,
+	kafkaHealth *kafka.HealthChecker,
+
+	// Streaming
+	locationStream *sse.LocationStream,
+	locationStreamServer *sse.Server,
+
+	// Replay
+	fileReplayer *replay.FileReplayer,
 ) (*CourierEmulationService, func(), error) {
 	cleanup := func() {
 		log.Info("Shutting down courier simulation...")
 
-		// Stop all simulations
+		// Stop all simulations; in-flight deliveries get time to finish, the rest are resolved with a terminal event.
 		simulator.Stop()
-		deliverySimulator.Stop()
+
+		ctx, cancel := context.WithTimeout(context.Background(), deliveryDrainTimeout)
+		defer cancel()
+
+		err := deliverySimulator.Drain(ctx)
+		if err != nil {
+			log.Warn("failed to drain in-flight deliveries", slog.String("error", err.Error()))
+		}
 
 		// Note: Kafka publisher/subscriber cleanup is handled by Wire via the cleanup function
 		// returned by pkg_di.NewLocationPublisher, pkg_di.NewStatusPublisher, and pkg_di.NewDeliverySubscriber
@@ -124,6 +167,14 @@ func NewCourierEmulationService(
 		LocationPublisher:  locationPublisher,
 		StatusPublisher:    statusPublisher,
 		DeliverySubscriber: deliverySubscriber,
+		KafkaHealth:        kafkaHealth,
+
+		// Streaming
+		LocationStream:       locationStream,
+		LocationStreamServer: locationStreamServer,
+
+		// Replay
+		FileReplayer: fileReplayer,
 	}, cleanup, nil
 }
 
@@ -7,6 +7,7 @@
 package courier_di
 
 import (
+	"context"
 	"github.com/google/wire"
 	"github.com/shortlink-org/go-sdk/config"
 	"github.com/shortlink-org/go-sdk/flags"
@@ -17,7 +18,11 @@ import (
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/di/pkg"
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/services"
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/replay"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/sse"
 	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"time"
 )
 
 // Injectors from wire.go:
@@ -74,7 +79,17 @@ func InitializeCourierEmulationService() (*CourierEmulationService, func(), erro
 		cleanup()
 		return nil, nil, err
 	}
-	courierSimulator := pkg_di.NewCourierSimulator(configConfig, routeGenerator, locationPublisher)
+	locationStream := pkg_di.NewLocationStream(configConfig)
+	multiLocationPublisher := pkg_di.NewSimulationLocationPublisher(locationPublisher, locationStream)
+	courierSimulator, err := pkg_di.NewCourierSimulator(configConfig, routeGenerator, multiLocationPublisher)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	kafkaStatusPublisher, cleanup6, err := pkg_di.NewStatusPublisher(configConfig, loggerLogger)
 	if err != nil {
 		cleanup5()
@@ -84,8 +99,17 @@ func InitializeCourierEmulationService() (*CourierEmulationService, func(), erro
 		cleanup()
 		return nil, nil, err
 	}
-	deliverySimulator := pkg_di.NewDeliverySimulator(configConfig, routeGenerator, locationPublisher, kafkaStatusPublisher)
-	deliverySubscriber, cleanup7, err := pkg_di.NewDeliverySubscriber(configConfig, loggerLogger, deliverySimulator)
+	deliveryMetrics, err := pkg_di.NewDeliveryMetrics(monitoring)
+	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	deliverySimulator, err := pkg_di.NewDeliverySimulator(configConfig, routeGenerator, multiLocationPublisher, kafkaStatusPublisher, deliveryMetrics)
 	if err != nil {
 		cleanup6()
 		cleanup5()
@@ -95,8 +119,34 @@ func InitializeCourierEmulationService() (*CourierEmulationService, func(), erro
 		cleanup()
 		return nil, nil, err
 	}
-	courierEmulationService, cleanup8, err := NewCourierEmulationService(loggerLogger, configConfig, monitoring, tracerProvider, pprofEndpoint, routeGenerator, courierSimulator, deliverySimulator, locationPublisher, kafkaStatusPublisher, deliverySubscriber)
+	fleet := services.NewFleet(courierSimulator, deliverySimulator)
+	deliverySubscriber, cleanup7, err := pkg_di.NewDeliverySubscriber(configConfig, loggerLogger, fleet)
 	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	healthChecker := pkg_di.NewKafkaHealthChecker(configConfig, monitoring, deliverySubscriber)
+	server, cleanup8 := pkg_di.NewLocationStreamServer(configConfig, loggerLogger, locationStream)
+	fileReplayer, err := pkg_di.NewFileReplayer(configConfig, multiLocationPublisher)
+	if err != nil {
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	courierEmulationService, cleanup9, err := NewCourierEmulationService(loggerLogger, configConfig, monitoring, tracerProvider, pprofEndpoint, routeGenerator, courierSimulator, deliverySimulator, locationPublisher, kafkaStatusPublisher, deliverySubscriber, healthChecker, locationStream, server, fileReplayer)
+	if err != nil {
+		cleanup8()
 		cleanup7()
 		cleanup6()
 		cleanup5()
@@ -107,6 +157,7 @@ func InitializeCourierEmulationService() (*CourierEmulationService, func(), erro
 		return nil, nil, err
 	}
 	return courierEmulationService, func() {
+		cleanup9()
 		cleanup8()
 		cleanup7()
 		cleanup6()
@@ -120,6 +171,9 @@ func InitializeCourierEmulationService() (*CourierEmulationService, func(), erro
 
 // wire.go:
 
+// deliveryDrainTimeout bounds how long shutdown lets in-flight deliveries finish before interrupting them.
+const deliveryDrainTimeout = 10 * time.Second
+
 type CourierEmulationService struct {
 	// Common
 	Log    logger.Logger
@@ -139,6 +193,14 @@ type CourierEmulationService struct {
 	LocationPublisher  *kafka.LocationPublisher
 	StatusPublisher    *kafka.KafkaStatusPublisher
 	DeliverySubscriber *kafka.DeliverySubscriber
+	KafkaHealth        *kafka.HealthChecker
+
+	// Streaming
+	LocationStream       *sse.LocationStream
+	LocationStreamServer *sse.Server
+
+	// Replay mode; nil unless REPLAY_FILE is set
+	FileReplayer *replay.FileReplayer
 }
 
 // DefaultSet ==========================================================================================================
@@ -149,7 +211,7 @@ var DefaultSet = wire.NewSet(
 // CourierEmulationSet =================================================================================================
 var CourierEmulationSet = wire.NewSet(
 
-	DefaultSet, pkg_di.NewOSRMClient, pkg_di.NewCourierSimulator, pkg_di.NewDeliverySimulator, pkg_di.NewLocationPublisher, pkg_di.NewStatusPublisher, pkg_di.NewDeliverySubscriber, NewCourierEmulationService,
+	DefaultSet, pkg_di.NewOSRMClient, pkg_di.NewCourierSimulator, pkg_di.NewDeliverySimulator, pkg_di.NewDeliveryMetrics, services.NewFleet, pkg_di.NewLocationPublisher, pkg_di.NewStatusPublisher, pkg_di.NewDeliverySubscriber, pkg_di.NewKafkaHealthChecker, pkg_di.NewLocationStream, pkg_di.NewLocationStreamServer, pkg_di.NewSimulationLocationPublisher, pkg_di.NewFileReplayer, NewCourierEmulationService,
 )
 
 func NewCourierEmulationService(
@@ -168,12 +230,25 @@ func NewCourierEmulationService(
 	locationPublisher *kafka.LocationPublisher,
 	statusPublisher *kafka.KafkaStatusPublisher,
 	deliverySubscriber *kafka.DeliverySubscriber,
+	kafkaHealth *kafka.HealthChecker,
+
+	locationStream *sse.LocationStream,
+	locationStreamServer *sse.Server,
+
+	fileReplayer *replay.FileReplayer,
 ) (*CourierEmulationService, func(), error) {
 	cleanup := func() {
 		log.Info("Shutting down courier simulation...")
 
 		simulator.Stop()
-		deliverySimulator.Stop()
+
+		ctx, cancel := context.WithTimeout(context.Background(), deliveryDrainTimeout)
+		defer cancel()
+
+		err := deliverySimulator.Drain(ctx)
+		if err != nil {
+			log.Warn("failed to drain in-flight deliveries", slog.String("error", err.Error()))
+		}
 
 	}
 
@@ -193,5 +268,11 @@ func NewCourierEmulationService(
 		LocationPublisher:  locationPublisher,
 		StatusPublisher:    statusPublisher,
 		DeliverySubscriber: deliverySubscriber,
+		KafkaHealth:        kafkaHealth,
+
+		LocationStream:       locationStream,
+		LocationStreamServer: locationStreamServer,
+
+		FileReplayer: fileReplayer,
 	}, cleanup, nil
 }
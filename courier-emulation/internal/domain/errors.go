@@ -6,8 +6,13 @@ import "errors"
 var (
 	ErrRouteTooShort            = errors.New("route must have at least 2 points")
 	ErrCourierNotFound          = errors.New("courier not found")
+	ErrCourierNotIdle           = errors.New("courier is not idle")
 	ErrRouteCompleted           = errors.New("route completed")
 	ErrCourierHasActiveDelivery = errors.New("courier already has an active delivery")
 	ErrDeliveryNotFound         = errors.New("delivery not found")
+	ErrUnroutable               = errors.New("no route between delivery locations")
+	ErrSimulatorDraining        = errors.New("delivery simulator is shutting down")
 	ErrUnknownPhase             = errors.New("unknown phase")
+	ErrNoFailureReasons         = errors.New("failure reason distribution needs at least one positive weight")
+	ErrInvalidFailureWeight     = errors.New("failure reason weight must be a finite non-negative number")
 )
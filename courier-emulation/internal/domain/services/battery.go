@@ -0,0 +1,25 @@
+package services
+
+import "time"
+
+const (
+	// fullBatteryPercent is the charge a courier's phone starts a simulation with.
+	fullBatteryPercent = 100.0
+
+	// batteryDrainPercentPerHour is how fast the phone battery drains in simulated time,
+	// roughly a full charge over a twelve-hour shift with GPS on.
+	batteryDrainPercentPerHour = 8.0
+)
+
+// drainBattery returns the charge left after the battery drained since drainedAt until now,
+// with elapsed time scaled by timeMultiplier like the courier's movement. The result stays in [0, 100].
+func drainBattery(percent float64, drainedAt, now time.Time, timeMultiplier float64) float64 {
+	elapsed := now.Sub(drainedAt)
+	if elapsed <= 0 {
+		return percent
+	}
+
+	drained := percent - batteryDrainPercentPerHour*elapsed.Hours()*timeMultiplier
+
+	return min(max(drained, 0), fullBatteryPercent)
+}
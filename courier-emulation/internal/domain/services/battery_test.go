@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+)
+
+func TestDrainBattery(t *testing.T) {
+	now := time.Now()
+
+	assert.InDelta(t, 92.0, drainBattery(fullBatteryPercent, now.Add(-time.Hour), now, 1), 1e-9)
+	assert.InDelta(t, 84.0, drainBattery(fullBatteryPercent, now.Add(-time.Hour), now, 2), 1e-9, "time multiplier speeds up the drain")
+	assert.InDelta(t, 50.0, drainBattery(50, now, now, 1), 1e-9, "no time passed")
+	assert.InDelta(t, 50.0, drainBattery(50, now.Add(time.Hour), now, 1), 1e-9, "clock went backwards")
+	assert.Zero(t, drainBattery(5, now.Add(-24*time.Hour), now, 1), "an empty battery stays at 0")
+}
+
+func TestCourierSimulator_BatteryDrainsOverUpdates(t *testing.T) {
+	publisher := newMockLocationPublisher()
+	config := DefaultCourierSimulatorConfig()
+	config.UpdateInterval = 1 * time.Hour // Updates are driven manually below
+	config.LoopRoutes = true
+
+	simulator := NewCourierSimulator(config, nil, publisher)
+	defer simulator.Stop()
+
+	origin := vo.MustNewLocation(52.5200, 13.4050)
+	destination := vo.MustNewLocation(52.5210, 13.4060)
+	route, err := vo.NewRoute("route", origin, destination, vo.MustNewPolyline("_c`|IgpvpAaB{A"), 150, 30*time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, simulator.StartCourierWithRoute(ctx, "courier-1", route))
+
+	// Every update covers one simulated hour of battery drain; 20 hours empty a full battery
+	for range 20 {
+		simulator.mu.Lock()
+		simulator.couriers["courier-1"].batteryDrainedAt = time.Now().Add(-time.Hour)
+		simulator.mu.Unlock()
+
+		require.NoError(t, simulator.updateCourierPosition(ctx, "courier-1"))
+	}
+
+	events := publisher.GetEvents()
+	require.Len(t, events, 20)
+
+	previous := fullBatteryPercent
+	for i, event := range events {
+		assert.GreaterOrEqual(t, event.BatteryPercent, 0.0)
+		assert.LessOrEqual(t, event.BatteryPercent, fullBatteryPercent)
+
+		if previous > 0 {
+			assert.Less(t, event.BatteryPercent, previous, "update %d", i)
+		}
+
+		previous = event.BatteryPercent
+	}
+
+	assert.Zero(t, events[len(events)-1].BatteryPercent)
+}
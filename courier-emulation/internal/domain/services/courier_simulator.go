@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"math/rand"
+	"slices"
 	"sync"
 	"time"
 
@@ -22,15 +24,11 @@ type LocationPublisher interface {
 
 // CourierState represents the current state of a simulated courier.
 type CourierState struct {
-	ID              string
-	CurrentLocation vo.Location
-	CurrentRoute    *vo.Route
-	RoutePoints     []vo.Location
-	CurrentPointIdx int
-	Status          string
-	Speed           float64 // km/h
-	StartedAt       time.Time
-	LastUpdateAt    time.Time
+	Movement
+
+	ID        string
+	Status    string
+	StartedAt time.Time
 
 	// Delivery workflow fields
 	CurrentOrder   *vo.DeliveryOrder // Current order being delivered
@@ -38,21 +36,34 @@ type CourierState struct {
 	PhaseStartedAt time.Time         // When current phase started
 	PickupRoute    *vo.Route         // Route to pickup point
 	DeliveryRoute  *vo.Route         // Route from pickup to customer
+
+	BatteryPercent   float64   // charge left on the courier's phone, 0-100
+	batteryDrainedAt time.Time // when BatteryPercent was last drained
+}
+
+// drainBattery drains the phone battery up to now, scaling elapsed time by timeMultiplier.
+func (s *CourierState) drainBattery(now time.Time, timeMultiplier float64) {
+	s.BatteryPercent = drainBattery(s.BatteryPercent, s.batteryDrainedAt, now, timeMultiplier)
+	s.batteryDrainedAt = now
 }
 
 // CourierSimulatorConfig holds configuration for the courier simulator.
 type CourierSimulatorConfig struct {
-	UpdateInterval time.Duration // how often to publish location updates
-	SpeedKmH       float64       // simulation speed in km/h
-	TimeMultiplier float64       // time acceleration (1.0 = real-time, 2.0 = 2x speed)
+	UpdateInterval time.Duration  // how often to publish location updates
+	SpeedKmH       float64        // simulation speed in km/h
+	TimeMultiplier float64        // time acceleration (1.0 = real-time, 2.0 = 2x speed)
+	LoopRoutes     bool           // reverse the route on completion instead of going idle
+	GPSNoiseMeters float64        // radius of random jitter added to published locations (0 = exact)
+	Region         vo.BoundingBox // area for random routes; zero value means Berlin
 }
 
 // DefaultCourierSimulatorConfig returns default configuration.
 func DefaultCourierSimulatorConfig() CourierSimulatorConfig {
 	return CourierSimulatorConfig{
 		UpdateInterval: 5 * time.Second,
-		SpeedKmH:       30.0,
+		SpeedKmH:       ProfileDriving.DefaultSpeedKmH(),
 		TimeMultiplier: 1.0,
+		Region:         vo.BerlinBoundingBox(),
 	}
 }
 
@@ -62,9 +73,11 @@ type CourierSimulator struct {
 	routeGenerator *RouteGenerator
 	publisher      LocationPublisher
 	couriers       map[string]*CourierState
+	sequences      courierSequences
 	mu             sync.RWMutex
 	stopCh         chan struct{}
 	wg             sync.WaitGroup
+	rng            *rand.Rand
 }
 
 // NewCourierSimulator creates a new courier simulator.
@@ -75,15 +88,26 @@ func NewCourierSimulator(
 	routeGenerator *RouteGenerator,
 	publisher LocationPublisher,
 ) *CourierSimulator {
+	if config.Region == (vo.BoundingBox{}) {
+		config.Region = vo.BerlinBoundingBox()
+	}
+
 	return &CourierSimulator{
 		config:         config,
 		routeGenerator: routeGenerator,
 		publisher:      publisher,
 		couriers:       make(map[string]*CourierState),
+		sequences:      make(courierSequences),
 		stopCh:         make(chan struct{}),
+		rng:            rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec // Simulation randomness is non-security-sensitive.
 	}
 }
 
+// Region returns the configured area for random routes.
+func (cs *CourierSimulator) Region() vo.BoundingBox {
+	return cs.config.Region
+}
+
 // StartCourier starts a new courier simulation with a random route.
 func (cs *CourierSimulator) StartCourier(ctx context.Context, courierID string, bbox vo.BoundingBox) error {
 	// Generate a random route
@@ -109,17 +133,16 @@ func (cs *CourierSimulator) StartCourierWithRoute(ctx context.Context, courierID
 		return domain.ErrRouteTooShort
 	}
 
+	now := time.Now()
+
 	cs.mu.Lock()
 	cs.couriers[courierID] = &CourierState{
-		ID:              courierID,
-		CurrentLocation: points[0],
-		CurrentRoute:    &route,
-		RoutePoints:     points,
-		CurrentPointIdx: 0,
-		Status:          vo.CourierStatusMoving,
-		Speed:           cs.config.SpeedKmH,
-		StartedAt:       time.Now(),
-		LastUpdateAt:    time.Now(),
+		Movement:         newMovement(route, points, cs.config.SpeedKmH, now),
+		ID:               courierID,
+		Status:           vo.CourierStatusMoving,
+		StartedAt:        now,
+		BatteryPercent:   fullBatteryPercent,
+		batteryDrainedAt: now,
 	}
 	cs.mu.Unlock()
 
@@ -164,47 +187,22 @@ func (cs *CourierSimulator) updateCourierPosition(ctx context.Context, courierID
 		return fmt.Errorf("%s: %w", courierID, domain.ErrCourierNotFound)
 	}
 
-	// Calculate distance to travel based on time and speed
-	elapsed := time.Since(courier.LastUpdateAt)
-	distanceToTravel := (courier.Speed / 3600.0) * elapsed.Seconds() * cs.config.TimeMultiplier // km
-
-	// Move along the route
-	for distanceToTravel > 0 && courier.CurrentPointIdx < len(courier.RoutePoints)-1 {
-		nextPoint := courier.RoutePoints[courier.CurrentPointIdx+1]
-		distanceToNext := courier.CurrentLocation.DistanceTo(nextPoint)
-
-		if distanceToTravel >= distanceToNext {
-			// Move to next point
-			courier.CurrentLocation = nextPoint
-			courier.CurrentPointIdx++
-			distanceToTravel -= distanceToNext
-		} else {
-			// Interpolate position
-			ratio := distanceToTravel / distanceToNext
-			courier.CurrentLocation = interpolateLocation(courier.CurrentLocation, nextPoint, ratio)
-			distanceToTravel = 0
-		}
-	}
-
-	// Check if route is completed
-	if courier.CurrentPointIdx >= len(courier.RoutePoints)-1 {
+	// A looping courier turns around at the end of its route instead of going idle
+	if courier.advance(time.Now(), cs.config.TimeMultiplier, cs.config.LoopRoutes) {
 		courier.Status = vo.CourierStatusIdle
-		courier.CurrentLocation = courier.RoutePoints[len(courier.RoutePoints)-1]
 	}
 
-	courier.LastUpdateAt = time.Now()
-
-	// Calculate heading
-	heading := 0.0
-	if courier.CurrentPointIdx < len(courier.RoutePoints)-1 {
-		heading = calculateHeading(courier.CurrentLocation, courier.RoutePoints[courier.CurrentPointIdx+1])
-	}
+	courier.drainBattery(courier.LastUpdateAt, cs.config.TimeMultiplier)
 
-	// Create event
-	event := vo.NewCourierLocationEvent(courierID, courier.CurrentLocation, courier.Status).
+	// Create event; noise only affects the published location, not the progress along the route
+	published := applyGPSNoise(cs.rng, courier.CurrentLocation, cs.config.GPSNoiseMeters)
+	event := vo.NewCourierLocationEvent(courierID, published, courier.Status).
 		WithSpeed(courier.Speed).
-		WithHeading(heading).
-		WithRouteID(courier.CurrentRoute.ID())
+		WithHeading(courier.heading()).
+		WithAccuracy(reportedAccuracy(cs.config.GPSNoiseMeters)).
+		WithBatteryPercent(courier.BatteryPercent).
+		WithRouteID(courier.routeID()).
+		WithSequence(cs.sequences.next(courierID))
 
 	isFinished := courier.Status == vo.CourierStatusIdle
 
@@ -254,6 +252,83 @@ func (cs *CourierSimulator) GetAllCouriers() []string {
 	return ids
 }
 
+// IdleCouriers returns the IDs of couriers that finished their route and are free for assignment, sorted.
+func (cs *CourierSimulator) IdleCouriers() []string {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	ids := make([]string, 0, len(cs.couriers))
+	for id, courier := range cs.couriers {
+		if courier.Status == vo.CourierStatusIdle {
+			ids = append(ids, id)
+		}
+	}
+
+	slices.Sort(ids)
+
+	return ids
+}
+
+// NearestIdleCourier returns the idle courier closest to loc; ties go to the smallest ID.
+// Reports false when no courier is idle.
+func (cs *CourierSimulator) NearestIdleCourier(loc vo.Location) (string, bool) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	nearestID, found := "", false
+	nearestKm := math.Inf(1)
+
+	for id, courier := range cs.couriers {
+		if courier.Status != vo.CourierStatusIdle {
+			continue
+		}
+
+		distanceKm := courier.CurrentLocation.DistanceTo(loc)
+		if !found || distanceKm < nearestKm || (distanceKm == nearestKm && id < nearestID) {
+			nearestID, nearestKm, found = id, distanceKm, true
+		}
+	}
+
+	return nearestID, found
+}
+
+// takeIdleCourier removes an idle courier from the free-roaming simulation and returns its state.
+// It returns domain.ErrCourierNotFound for an unknown courier and domain.ErrCourierNotIdle for one still on its route.
+func (cs *CourierSimulator) takeIdleCourier(courierID string) (*CourierState, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	courier, exists := cs.couriers[courierID]
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", courierID, domain.ErrCourierNotFound)
+	}
+
+	if courier.Status != vo.CourierStatusIdle {
+		return nil, fmt.Errorf("%s: %w", courierID, domain.ErrCourierNotIdle)
+	}
+
+	delete(cs.couriers, courierID)
+
+	return courier, nil
+}
+
+// returnCourier puts a courier taken with takeIdleCourier back into the free-roaming simulation,
+// continuing its event sequence after lastSequence.
+func (cs *CourierSimulator) returnCourier(courier *CourierState, lastSequence uint64) {
+	cs.mu.Lock()
+	cs.couriers[courier.ID] = courier
+	cs.sequences.resume(courier.ID, lastSequence)
+	cs.mu.Unlock()
+}
+
+// lastSequence returns the sequence number of the courier's last event, 0 when it has none.
+func (cs *CourierSimulator) lastSequence(courierID string) uint64 {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	return cs.sequences[courierID]
+}
+
 // StopCourier stops a specific courier simulation.
 func (cs *CourierSimulator) StopCourier(courierID string) {
 	cs.mu.Lock()
@@ -270,28 +345,3 @@ func (cs *CourierSimulator) Stop() {
 	cs.couriers = make(map[string]*CourierState)
 	cs.mu.Unlock()
 }
-
-// interpolateLocation calculates a point between two locations based on ratio (0-1).
-func interpolateLocation(from, to vo.Location, ratio float64) vo.Location {
-	lat := from.Latitude() + (to.Latitude()-from.Latitude())*ratio
-	lon := from.Longitude() + (to.Longitude()-from.Longitude())*ratio
-
-	return vo.MustNewLocation(lat, lon)
-}
-
-// calculateHeading calculates the heading (bearing) between two points in degrees.
-func calculateHeading(from, to vo.Location) float64 {
-	lat1 := from.Latitude() * math.Pi / 180
-	lat2 := to.Latitude() * math.Pi / 180
-	dLon := (to.Longitude() - from.Longitude()) * math.Pi / 180
-
-	x := math.Sin(dLon) * math.Cos(lat2)
-	y := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
-
-	heading := math.Atan2(x, y) * 180 / math.Pi
-	if heading < 0 {
-		heading += 360
-	}
-
-	return heading
-}
@@ -113,6 +113,44 @@ func TestCourierSimulator_GetAllCouriers(t *testing.T) {
 	assert.Len(t, couriers, 3)
 }
 
+func TestCourierSimulator_IdleCouriers(t *testing.T) {
+	simulator := NewCourierSimulator(DefaultCourierSimulatorConfig(), nil, newMockLocationPublisher())
+	defer simulator.Stop()
+
+	alexanderplatz := vo.MustNewLocation(52.5219, 13.4132)
+	simulator.couriers = map[string]*CourierState{
+		"moving-near": {ID: "moving-near", Status: vo.CourierStatusMoving, Movement: Movement{CurrentLocation: alexanderplatz}},
+		"idle-far":    {ID: "idle-far", Status: vo.CourierStatusIdle, Movement: Movement{CurrentLocation: vo.MustNewLocation(52.4500, 13.3000)}},
+		"idle-near":   {ID: "idle-near", Status: vo.CourierStatusIdle, Movement: Movement{CurrentLocation: vo.MustNewLocation(52.5200, 13.4050)}},
+		"delivering":  {ID: "delivering", Status: vo.CourierStatusDelivering, Movement: Movement{CurrentLocation: alexanderplatz}},
+	}
+
+	assert.Equal(t, []string{"idle-far", "idle-near"}, simulator.IdleCouriers())
+
+	nearest, ok := simulator.NearestIdleCourier(alexanderplatz)
+	require.True(t, ok)
+	assert.Equal(t, "idle-near", nearest, "the closer moving and delivering couriers are not available")
+
+	nearest, ok = simulator.NearestIdleCourier(vo.MustNewLocation(52.4400, 13.2900))
+	require.True(t, ok)
+	assert.Equal(t, "idle-far", nearest)
+}
+
+func TestCourierSimulator_NearestIdleCourierNoneIdle(t *testing.T) {
+	simulator := NewCourierSimulator(DefaultCourierSimulatorConfig(), nil, newMockLocationPublisher())
+	defer simulator.Stop()
+
+	location := vo.MustNewLocation(52.5200, 13.4050)
+	simulator.couriers = map[string]*CourierState{
+		"moving": {ID: "moving", Status: vo.CourierStatusMoving, Movement: Movement{CurrentLocation: location}},
+	}
+
+	assert.Empty(t, simulator.IdleCouriers())
+
+	_, ok := simulator.NearestIdleCourier(location)
+	assert.False(t, ok)
+}
+
 func TestCourierSimulator_StopCourier(t *testing.T) {
 	publisher := newMockLocationPublisher()
 	config := DefaultCourierSimulatorConfig()
@@ -143,6 +181,57 @@ func TestCourierSimulator_StopCourier(t *testing.T) {
 	assert.False(t, exists)
 }
 
+func TestCourierSimulator_LoopRoutesKeepsMoving(t *testing.T) {
+	publisher := newMockLocationPublisher()
+	config := DefaultCourierSimulatorConfig()
+	config.UpdateInterval = 1 * time.Hour // Updates are driven manually below
+	config.LoopRoutes = true
+
+	simulator := NewCourierSimulator(config, nil, publisher)
+	defer simulator.Stop()
+
+	origin := vo.MustNewLocation(52.5200, 13.4050)
+	destination := vo.MustNewLocation(52.5210, 13.4060)
+	polyline := vo.MustNewPolyline("_c`|IgpvpAaB{A")
+	route, err := vo.NewRoute("route", origin, destination, polyline, 150, 30*time.Second)
+	require.NoError(t, err)
+
+	points, err := route.Points()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = simulator.StartCourierWithRoute(ctx, "courier-1", route)
+	require.NoError(t, err)
+
+	// Each update covers 60% of the route, so the courier passes the original end on the second one.
+	routeKm := points[0].DistanceTo(points[1])
+	step := time.Duration(0.6 * routeKm / config.SpeedKmH * float64(time.Hour))
+
+	for range 5 {
+		simulator.mu.Lock()
+		simulator.couriers["courier-1"].LastUpdateAt = time.Now().Add(-step)
+		simulator.mu.Unlock()
+
+		require.NoError(t, simulator.updateCourierPosition(ctx, "courier-1"))
+	}
+
+	events := publisher.GetEvents()
+	require.Len(t, events, 5)
+
+	for _, event := range events {
+		assert.Equal(t, vo.CourierStatusMoving, event.Status)
+	}
+
+	// Past the wrap point the courier heads back towards the origin.
+	backHeading := calculateHeading(points[1], points[0])
+	assert.InDelta(t, calculateHeading(points[0], points[1]), events[0].Heading, 1.0)
+	assert.InDelta(t, backHeading, events[1].Heading, 1.0)
+
+	state, exists := simulator.GetCourierState("courier-1")
+	require.True(t, exists)
+	assert.Equal(t, vo.CourierStatusMoving, state.Status)
+}
+
 func TestInterpolateLocation(t *testing.T) {
 	from := vo.MustNewLocation(52.5200, 13.4050)
 	to := vo.MustNewLocation(52.5300, 13.4150)
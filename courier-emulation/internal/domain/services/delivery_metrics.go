@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
+)
+
+// DeliveryMetricsMeterName is the instrumentation scope of the delivery simulator metrics.
+const DeliveryMetricsMeterName = "courier-emulation/delivery-simulator"
+
+// DeliveryMetrics holds the delivery simulator instruments.
+// A nil *DeliveryMetrics records nothing, like a nil publisher publishes nothing.
+type DeliveryMetrics struct {
+	started       metric.Int64Counter
+	completed     metric.Int64Counter
+	failed        metric.Int64Counter
+	phaseDuration metric.Float64Histogram
+	active        metric.Int64ObservableGauge
+	activeSource  atomic.Pointer[func() int]
+}
+
+// NewDeliveryMetrics creates the delivery simulator instruments on the given meter.
+func NewDeliveryMetrics(meter metric.Meter) (*DeliveryMetrics, error) {
+	started, err := meter.Int64Counter("courier_emulation.deliveries.started",
+		metric.WithDescription("Delivery simulations started"),
+		metric.WithUnit("{delivery}"))
+	if err != nil {
+		return nil, fmt.Errorf("deliveries started counter: %w", err)
+	}
+
+	completed, err := meter.Int64Counter("courier_emulation.deliveries.completed",
+		metric.WithDescription("Delivery simulations that ended DELIVERED"),
+		metric.WithUnit("{delivery}"))
+	if err != nil {
+		return nil, fmt.Errorf("deliveries completed counter: %w", err)
+	}
+
+	failed, err := meter.Int64Counter("courier_emulation.deliveries.failed",
+		metric.WithDescription("Delivery simulations that ended NOT_DELIVERED, by reason"),
+		metric.WithUnit("{delivery}"))
+	if err != nil {
+		return nil, fmt.Errorf("deliveries failed counter: %w", err)
+	}
+
+	phaseDuration, err := meter.Float64Histogram("courier_emulation.delivery.phase.duration",
+		metric.WithDescription("Wall-clock time spent in a delivery phase"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, fmt.Errorf("delivery phase duration histogram: %w", err)
+	}
+
+	active, err := meter.Int64ObservableGauge("courier_emulation.deliveries.active",
+		metric.WithDescription("Delivery simulations currently in flight"),
+		metric.WithUnit("{delivery}"))
+	if err != nil {
+		return nil, fmt.Errorf("deliveries active gauge: %w", err)
+	}
+
+	metrics := &DeliveryMetrics{
+		started:       started,
+		completed:     completed,
+		failed:        failed,
+		phaseDuration: phaseDuration,
+		active:        active,
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		source := metrics.activeSource.Load()
+		if source != nil {
+			observer.ObserveInt64(metrics.active, int64((*source)()))
+		}
+
+		return nil
+	}, active)
+	if err != nil {
+		return nil, fmt.Errorf("register active deliveries callback: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// observeActive makes the active deliveries gauge report count() on every collection.
+func (m *DeliveryMetrics) observeActive(count func() int) {
+	if m == nil {
+		return
+	}
+
+	m.activeSource.Store(&count)
+}
+
+func (m *DeliveryMetrics) deliveryStarted(ctx context.Context) {
+	if m == nil {
+		return
+	}
+
+	m.started.Add(ctx, 1)
+}
+
+func (m *DeliveryMetrics) deliveryFinished(ctx context.Context, delivered bool, reason kafka.NotDeliveredReason) {
+	if m == nil {
+		return
+	}
+
+	if delivered {
+		m.completed.Add(ctx, 1)
+
+		return
+	}
+
+	m.failed.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", string(reason))))
+}
+
+func (m *DeliveryMetrics) phaseFinished(ctx context.Context, phase vo.DeliveryPhase, startedAt time.Time) {
+	if m == nil {
+		return
+	}
+
+	m.phaseDuration.Record(ctx, time.Since(startedAt).Seconds(),
+		metric.WithAttributes(attribute.String("phase", string(phase))))
+}
@@ -0,0 +1,152 @@
+//nolint:testifylint // Tests keep direct metric assertions readable.
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
+)
+
+func TestDeliveryMetrics_TrackDeliveryLifecycle(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	deliveryMetrics, err := NewDeliveryMetrics(provider.Meter(DeliveryMetricsMeterName))
+	require.NoError(t, err)
+
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	statusPub := newMockStatusPublisher()
+
+	config := DeliverySimulatorConfig{
+		UpdateInterval:   10 * time.Millisecond,
+		SpeedKmH:         100.0,
+		TimeMultiplier:   100.0,
+		PickupWaitTime:   10 * time.Millisecond,
+		DeliveryWaitTime: 10 * time.Millisecond,
+		FailureRate:      0.0, // Always succeed
+	}
+
+	simulator := NewDeliverySimulator(config, routeGen, newMockLocationPublisher(), statusPub, deliveryMetrics)
+	defer simulator.Stop()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5201, 13.4051)
+	order := vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())
+
+	require.NoError(t, simulator.StartDelivery(context.Background(), "courier-1", order))
+
+	snapshot := collectMetrics(t, reader)
+	assert.Equal(t, int64(1), sumValue(t, snapshot, "courier_emulation.deliveries.started"))
+	assert.Equal(t, int64(1), gaugeValue(t, snapshot, "courier_emulation.deliveries.active"))
+
+	require.Eventually(t, func() bool { return len(statusPub.GetDeliveryEvents()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return len(simulator.GetAllDeliveries()) == 0 }, time.Second, 10*time.Millisecond)
+
+	snapshot = collectMetrics(t, reader)
+	assert.Equal(t, int64(1), sumValue(t, snapshot, "courier_emulation.deliveries.completed"))
+	assert.Equal(t, int64(0), gaugeValue(t, snapshot, "courier_emulation.deliveries.active"))
+	assert.Nil(t, findMetric(snapshot, "courier_emulation.deliveries.failed"), "no delivery failed")
+
+	phases := map[string]uint64{}
+
+	histogram, ok := findMetric(snapshot, "courier_emulation.delivery.phase.duration").(metricdata.Histogram[float64])
+	require.True(t, ok)
+
+	for _, point := range histogram.DataPoints {
+		phase, _ := point.Attributes.Value("phase")
+		phases[phase.AsString()] = point.Count
+	}
+
+	assert.Equal(t, map[string]uint64{
+		string(vo.PhaseHeadingToPickup):   1,
+		string(vo.PhasePickingUp):         1,
+		string(vo.PhaseHeadingToCustomer): 1,
+		string(vo.PhaseDelivering):        1,
+	}, phases)
+}
+
+func TestDeliveryMetrics_CountsFailuresByReason(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	deliveryMetrics, err := NewDeliveryMetrics(provider.Meter(DeliveryMetricsMeterName))
+	require.NoError(t, err)
+
+	deliveryMetrics.deliveryFinished(context.Background(), false, kafka.ReasonWrongAddress)
+	deliveryMetrics.deliveryFinished(context.Background(), false, kafka.ReasonWrongAddress)
+	deliveryMetrics.deliveryFinished(context.Background(), false, kafka.ReasonAccessDenied)
+
+	sum, ok := findMetric(collectMetrics(t, reader), "courier_emulation.deliveries.failed").(metricdata.Sum[int64])
+	require.True(t, ok)
+
+	byReason := map[string]int64{}
+	for _, point := range sum.DataPoints {
+		reason, _ := point.Attributes.Value("reason")
+		byReason[reason.AsString()] = point.Value
+	}
+
+	assert.Equal(t, map[string]int64{
+		string(kafka.ReasonWrongAddress): 2,
+		string(kafka.ReasonAccessDenied): 1,
+	}, byReason)
+}
+
+func collectMetrics(t *testing.T, reader *sdkmetric.ManualReader) metricdata.ResourceMetrics {
+	t.Helper()
+
+	var snapshot metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &snapshot))
+
+	return snapshot
+}
+
+func findMetric(snapshot metricdata.ResourceMetrics, name string) metricdata.Aggregation {
+	for _, scope := range snapshot.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name == name {
+				return m.Data
+			}
+		}
+	}
+
+	return nil
+}
+
+func sumValue(t *testing.T, snapshot metricdata.ResourceMetrics, name string) int64 {
+	t.Helper()
+
+	sum, ok := findMetric(snapshot, name).(metricdata.Sum[int64])
+	require.True(t, ok, name)
+	require.Len(t, sum.DataPoints, 1, name)
+
+	return sum.DataPoints[0].Value
+}
+
+func gaugeValue(t *testing.T, snapshot metricdata.ResourceMetrics, name string) int64 {
+	t.Helper()
+
+	gauge, ok := findMetric(snapshot, name).(metricdata.Gauge[int64])
+	require.True(t, ok, name)
+	require.Len(t, gauge.DataPoints, 1, name)
+
+	return gauge.DataPoints[0].Value
+}
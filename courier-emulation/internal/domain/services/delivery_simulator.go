@@ -3,8 +3,11 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,38 +19,111 @@ import (
 
 // DeliverySimulatorConfig holds configuration for the delivery simulator.
 type DeliverySimulatorConfig struct {
-	UpdateInterval   time.Duration // How often to update courier position
-	SpeedKmH         float64       // Courier speed in km/h
-	TimeMultiplier   float64       // Time acceleration (1.0 = real-time)
-	PickupWaitTime   time.Duration // Time to wait at pickup location
-	DeliveryWaitTime time.Duration // Time to wait at delivery location
-	FailureRate      float64       // Probability of NOT_DELIVERED (0.0 - 1.0)
+	UpdateInterval    time.Duration             // How often to update courier position
+	SpeedKmH          float64                   // Courier speed in km/h
+	TimeMultiplier    float64                   // Time acceleration (1.0 = real-time)
+	PickupWaitTime    time.Duration             // Time to wait at pickup location
+	DeliveryWaitTime  time.Duration             // Time to wait at delivery location
+	FailureRate       float64                   // Probability of NOT_DELIVERED (0.0 - 1.0)
+	FailureReasons    FailureReasonDistribution // Which NOT_DELIVERED reason a failure reports
+	GPSNoiseMeters    float64                   // Radius of random jitter added to published locations (0 = exact)
+	StartOffsetMeters float64                   // Distance the courier starts from pickup so it moves before arriving (0 = starts at pickup)
+	// StraightLineFallback straight-lines legs OSRM has no route for instead of ending the delivery as UNROUTABLE
+	StraightLineFallback bool
+	// DeliveredProximityMeters is how close to the delivery location a DELIVERED outcome must be reported (0 = unchecked)
+	DeliveredProximityMeters float64
+	// SLABreachGrace is how late past its delivery deadline a package may be delivered before DeliverySLABreached is published
+	SLABreachGrace time.Duration
+	// RetryMaxAttempts is how many times a delivery failing for a retryable reason is attempted again (0 = never)
+	RetryMaxAttempts int
+	// RetryDelay is how long the courier waits before attempting a failed delivery again
+	RetryDelay time.Duration
 }
 
 // DefaultDeliverySimulatorConfig returns default configuration.
 func DefaultDeliverySimulatorConfig() DeliverySimulatorConfig {
 	return DeliverySimulatorConfig{
-		UpdateInterval:   5 * time.Second,
-		SpeedKmH:         30.0,
-		TimeMultiplier:   1.0,
-		PickupWaitTime:   30 * time.Second,
-		DeliveryWaitTime: 60 * time.Second,
-		FailureRate:      0.05,
+		UpdateInterval:    5 * time.Second,
+		SpeedKmH:          ProfileDriving.DefaultSpeedKmH(),
+		TimeMultiplier:    1.0,
+		PickupWaitTime:    30 * time.Second,
+		DeliveryWaitTime:  60 * time.Second,
+		FailureRate:       0.05,
+		FailureReasons:    DefaultFailureReasonDistribution(),
+		StartOffsetMeters: defaultStartOffsetMeters,
 	}
 }
 
+// PhaseRecord is one finished phase of a delivery with its start and end time.
+type PhaseRecord struct {
+	Phase     vo.DeliveryPhase
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// Duration returns how long the phase took.
+func (r PhaseRecord) Duration() time.Duration {
+	return r.EndedAt.Sub(r.StartedAt)
+}
+
 // DeliveryState represents the current state of a delivery simulation.
 type DeliveryState struct {
-	CourierID       string
-	CurrentLocation vo.Location
-	CurrentOrder    *vo.DeliveryOrder
-	Phase           vo.DeliveryPhase
-	PhaseStartedAt  time.Time
-	CurrentRoute    *vo.Route
-	RoutePoints     []vo.Location
-	CurrentPointIdx int
-	Speed           float64
-	LastUpdateAt    time.Time
+	Movement
+
+	CourierID      string
+	CurrentOrder   *vo.DeliveryOrder
+	Phase          vo.DeliveryPhase
+	PhaseStartedAt time.Time
+	Timeline       []PhaseRecord // Finished phases in order, for per-phase timing
+	FailedAttempts int           // Delivery attempts that failed and were scheduled for a retry
+
+	BatteryPercent   float64   // charge left on the courier's phone, 0-100
+	batteryDrainedAt time.Time // when BatteryPercent was last drained
+
+	stop chan struct{} // closed when the delivery is taken out of the simulator; ends its simulation loop
+}
+
+// stopSimulation ends the delivery's simulation loop. It must be called with mu held, when the state is
+// removed from or replaced in the simulator, so the channel is closed at most once.
+func (s *DeliveryState) stopSimulation() {
+	if s.stop != nil {
+		close(s.stop)
+	}
+}
+
+// drainBattery drains the phone battery up to now, scaling elapsed time by timeMultiplier.
+func (s *DeliveryState) drainBattery(now time.Time, timeMultiplier float64) {
+	s.BatteryPercent = drainBattery(s.BatteryPercent, s.batteryDrainedAt, now, timeMultiplier)
+	s.batteryDrainedAt = now
+}
+
+// advancePhase closes the current phase in the timeline and starts next at now.
+func (s *DeliveryState) advancePhase(next vo.DeliveryPhase, now time.Time) {
+	s.Timeline = append(s.Timeline, PhaseRecord{
+		Phase:     s.Phase,
+		StartedAt: s.PhaseStartedAt,
+		EndedAt:   now,
+	})
+	s.Phase = next
+	s.PhaseStartedAt = now
+}
+
+// resetToIdle finishes the current phase at now and clears the delivery, leaving the courier idle.
+func (s *DeliveryState) resetToIdle(now time.Time) {
+	s.advancePhase(vo.PhaseIdle, now)
+	s.CurrentOrder = nil
+	s.CurrentRoute = nil
+	s.RoutePoints = nil
+	s.CurrentPointIdx = 0
+}
+
+// orderID returns the ID of the order being delivered, or "" when there is none.
+func (s *DeliveryState) orderID() string {
+	if s.CurrentOrder == nil {
+		return ""
+	}
+
+	return s.CurrentOrder.OrderID()
 }
 
 // DeliverySimulator orchestrates the full delivery workflow simulation.
@@ -56,9 +132,13 @@ type DeliverySimulator struct {
 	routeGenerator *RouteGenerator
 	locationPub    LocationPublisher
 	statusPub      kafka.StatusPublisher
+	metrics        *DeliveryMetrics
 	deliveries     map[string]*DeliveryState
+	sequences      courierSequences
 	mu             sync.RWMutex
+	draining       bool // set by Drain; no new deliveries are accepted
 	stopCh         chan struct{}
+	stopOnce       sync.Once
 	wg             sync.WaitGroup
 	rng            *rand.Rand
 }
@@ -71,79 +151,248 @@ func NewDeliverySimulator(
 	routeGenerator *RouteGenerator,
 	locationPub LocationPublisher,
 	statusPub kafka.StatusPublisher,
+	metrics *DeliveryMetrics,
 ) *DeliverySimulator {
-	return &DeliverySimulator{
+	if config.FailureReasons.IsZero() {
+		config.FailureReasons = DefaultFailureReasonDistribution()
+	}
+
+	ds := &DeliverySimulator{
 		config:         config,
 		routeGenerator: routeGenerator,
 		locationPub:    locationPub,
 		statusPub:      statusPub,
+		metrics:        metrics,
 		deliveries:     make(map[string]*DeliveryState),
+		sequences:      make(courierSequences),
 		stopCh:         make(chan struct{}),
 		rng:            rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec // Simulation randomness is non-security-sensitive.
 	}
+
+	metrics.observeActive(func() int { return len(ds.GetAllDeliveries()) })
+
+	return ds
 }
 
-// StartDelivery starts a delivery simulation for a courier with an assigned order.
+// StartDelivery starts a delivery simulation for a courier with an assigned order; the courier starts
+// StartOffsetMeters away from pickup with a full battery.
+// It is idempotent: repeating the assignment of the courier's active order (a redelivered Kafka
+// message) leaves the running simulation untouched and returns nil, while a different order for a
+// busy courier fails with domain.ErrCourierHasActiveDelivery.
+// When OSRM has no route to pickup and StraightLineFallback is off, the delivery is resolved with a
+// NOT_DELIVERED (ReasonUnroutable) event and StartDelivery fails with domain.ErrUnroutable.
+// A self-pickup order (pickup and delivery at the same location) is picked up and delivered at once.
+// Once Drain has started, new deliveries fail with domain.ErrSimulatorDraining.
 //
 //nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
 func (ds *DeliverySimulator) StartDelivery(ctx context.Context, courierID string, order vo.DeliveryOrder) error {
+	return ds.startDelivery(ctx, courierID, order, nil, fullBatteryPercent)
+}
+
+// StartDeliveryFrom is StartDelivery for a courier that is already out at start with batteryPercent
+// left, e.g. a free-roaming courier taking an order.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func (ds *DeliverySimulator) StartDeliveryFrom(
+	ctx context.Context,
+	courierID string,
+	order vo.DeliveryOrder,
+	start vo.Location,
+	batteryPercent float64,
+) error {
+	return ds.startDelivery(ctx, courierID, order, &start, batteryPercent)
+}
+
+// startDelivery starts a delivery with the courier at start, or near pickup when start is nil.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func (ds *DeliverySimulator) startDelivery(
+	ctx context.Context,
+	courierID string,
+	order vo.DeliveryOrder,
+	start *vo.Location,
+	batteryPercent float64,
+) error {
 	ds.mu.Lock()
 
-	// Check if courier already has an active delivery
-	if existing, exists := ds.deliveries[courierID]; exists && existing.Phase != vo.PhaseIdle {
+	if ds.draining {
 		ds.mu.Unlock()
-		return fmt.Errorf("%s: %w", courierID, domain.ErrCourierHasActiveDelivery)
+		return fmt.Errorf("%s: %w", courierID, domain.ErrSimulatorDraining)
 	}
 
+	duplicate, err := ds.activeDelivery(courierID, order)
+
 	ds.mu.Unlock()
 
-	// Generate route to pickup location
-	// For simplicity, we'll assume courier starts at pickup location or use a default starting point
-	// In a real scenario, we'd get the courier's current location
-	startLocation := order.PickupLocation()
+	if duplicate || err != nil {
+		return err
+	}
 
-	route, err := ds.routeGenerator.GenerateRoute(ctx, startLocation, order.PickupLocation())
-	if err != nil {
-		minRoute, createErr := ds.createMinimalRoute(startLocation, order.PickupLocation())
-		if createErr != nil {
-			return fmt.Errorf("create minimal route: %w", createErr)
+	if isSelfPickup(order) {
+		return ds.completeSelfPickup(ctx, courierID, order, start, batteryPercent)
+	}
+
+	// Generate route to pickup location; without a known position the courier starts near pickup
+	var startLocation vo.Location
+	if start != nil {
+		startLocation = *start
+	} else {
+		startLocation = ds.startLocation(order.PickupLocation())
+	}
+
+	route, points, err := ds.planRoute(ctx, startLocation, order.PickupLocation())
+	if errors.Is(err, domain.ErrUnroutable) {
+		ds.metrics.deliveryStarted(ctx)
+
+		publishErr := ds.publishUnroutable(ctx, courierID, order, startLocation)
+		if publishErr != nil {
+			return publishErr
 		}
 
-		route = minRoute
+		return fmt.Errorf("%s: %w", courierID, err)
 	}
 
-	points, err := route.Points()
-	if err != nil || len(points) < minimalRoutePoints {
-		// Create direct route
-		points = []vo.Location{startLocation, order.PickupLocation()}
+	if err != nil {
+		return err
 	}
 
+	now := time.Now()
 	orderCopy := order
 	state := &DeliveryState{
-		CourierID:       courierID,
-		CurrentLocation: points[0],
-		CurrentOrder:    &orderCopy,
-		Phase:           vo.PhaseHeadingToPickup,
-		PhaseStartedAt:  time.Now(),
-		CurrentRoute:    &route,
-		RoutePoints:     points,
-		CurrentPointIdx: 0,
-		Speed:           ds.config.SpeedKmH,
-		LastUpdateAt:    time.Now(),
+		Movement:         newMovement(route, points, ds.config.SpeedKmH, now),
+		CourierID:        courierID,
+		CurrentOrder:     &orderCopy,
+		Phase:            vo.PhaseHeadingToPickup,
+		PhaseStartedAt:   now,
+		BatteryPercent:   batteryPercent,
+		batteryDrainedAt: now,
+		stop:             make(chan struct{}),
 	}
 
+	// Registering under the lock keeps Drain from waiting on a delivery it did not see
 	ds.mu.Lock()
+
+	if ds.draining {
+		ds.mu.Unlock()
+		return fmt.Errorf("%s: %w", courierID, domain.ErrSimulatorDraining)
+	}
+
+	// A concurrent start may have registered a delivery while the route was planned
+	duplicate, err = ds.activeDelivery(courierID, order)
+	if duplicate || err != nil {
+		ds.mu.Unlock()
+		return err
+	}
+
 	ds.deliveries[courierID] = state
+	ds.wg.Add(1)
 	ds.mu.Unlock()
 
-	// Start simulation goroutine
-	ds.wg.Add(1)
+	ds.metrics.deliveryStarted(ctx)
 
-	go ds.simulateDelivery(ctx, courierID)
+	// Start simulation goroutine; it outlives the request, Stop and Drain end it
+	go ds.simulateDelivery(context.WithoutCancel(ctx), state)
 
 	return nil
 }
 
+// activeDelivery checks the courier's current delivery against order and must be called with mu held.
+// It reports true when the courier is already delivering order, and fails with
+// domain.ErrCourierHasActiveDelivery when the courier is busy with a different one.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func (ds *DeliverySimulator) activeDelivery(courierID string, order vo.DeliveryOrder) (bool, error) {
+	existing, exists := ds.deliveries[courierID]
+	if !exists || existing.Phase == vo.PhaseIdle {
+		return false, nil
+	}
+
+	if existing.CurrentOrder != nil && sameDeliveryOrder(*existing.CurrentOrder, order) {
+		return true, nil
+	}
+
+	return false, fmt.Errorf("%s: %w", courierID, domain.ErrCourierHasActiveDelivery)
+}
+
+// isSelfPickup reports whether order is delivered where it is picked up, i.e. the customer collects it
+// and there is nothing to drive.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func isSelfPickup(order vo.DeliveryOrder) bool {
+	return order.PickupLocation().DistanceTo(order.DeliveryLocation())*metersPerKm < minRouteDistanceMeters
+}
+
+// completeSelfPickup resolves a self-pickup order at once: it publishes the pickup and a DELIVERED event
+// at the pickup location without any movement phases, and leaves the courier idle where it was
+// (at pickup when start is nil).
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func (ds *DeliverySimulator) completeSelfPickup(
+	ctx context.Context,
+	courierID string,
+	order vo.DeliveryOrder,
+	start *vo.Location,
+	batteryPercent float64,
+) error {
+	ds.metrics.deliveryStarted(ctx)
+
+	if ds.statusPub != nil {
+		pickupEvent := kafka.NewPickUpOrderEvent(courierID, order, order.PickupLocation())
+		pickupEvent.Sequence = ds.nextSequence(courierID)
+
+		err := ds.statusPub.PublishPickUp(ctx, pickupEvent)
+		if err != nil {
+			return fmt.Errorf("failed to publish self-pickup pickup event: %w", err)
+		}
+
+		deliverEvent, err := kafka.NewDeliverOrderEvent(courierID, order, order.PickupLocation(), true, "")
+		if err != nil {
+			return fmt.Errorf("build self-pickup delivery event: %w", err)
+		}
+
+		deliverEvent.Sequence = ds.nextSequence(courierID)
+
+		err = ds.statusPub.PublishDelivery(ctx, deliverEvent)
+		if err != nil {
+			return fmt.Errorf("failed to publish self-pickup delivery event: %w", err)
+		}
+
+		err = ds.publishSLABreach(ctx, courierID, order, deliverEvent.DeliveredAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	ds.metrics.deliveryFinished(ctx, true, "")
+
+	location := order.PickupLocation()
+	if start != nil {
+		location = *start
+	}
+
+	now := time.Now()
+
+	ds.mu.Lock()
+	ds.deliveries[courierID] = &DeliveryState{
+		Movement:         Movement{CurrentLocation: location, LastUpdateAt: now},
+		CourierID:        courierID,
+		Phase:            vo.PhaseIdle,
+		PhaseStartedAt:   now,
+		BatteryPercent:   batteryPercent,
+		batteryDrainedAt: now,
+	}
+	ds.mu.Unlock()
+
+	return nil
+}
+
+// sameDeliveryOrder reports whether a and b are the same assignment, i.e. the same order and package.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func sameDeliveryOrder(a, b vo.DeliveryOrder) bool {
+	return a.OrderID() == b.OrderID() && a.PackageID() == b.PackageID()
+}
+
 // minRouteDistanceMeters and minRouteDuration ensure vo.NewRoute accepts the route
 // when from == to (e.g. start at pickup, route "to pickup" in tests without OSRM).
 const (
@@ -163,8 +412,80 @@ const (
 	polylineASCIIShift = 63
 	// minimalRoutePoints is the minimum number of points required for a usable route.
 	minimalRoutePoints = 2
+	// defaultStartOffsetMeters keeps the pickup leg long enough to publish a few moving updates.
+	defaultStartOffsetMeters = 200.0
 )
 
+// planRoute routes the leg from origin to destination. A leg OSRM has no route for fails with
+// domain.ErrUnroutable unless StraightLineFallback is set; any other routing failure (e.g. OSRM
+// being down) falls back to a straight line so the simulation keeps going.
+func (ds *DeliverySimulator) planRoute(ctx context.Context, origin, destination vo.Location) (vo.Route, []vo.Location, error) {
+	route, err := ds.routeGenerator.GenerateRoute(ctx, origin, destination)
+	if err != nil {
+		if errors.Is(err, ErrNoRouteFound) && !ds.config.StraightLineFallback {
+			return vo.Route{}, nil, fmt.Errorf("%w: %w", domain.ErrUnroutable, err)
+		}
+
+		minRoute, createErr := ds.createMinimalRoute(origin, destination)
+		if createErr != nil {
+			return vo.Route{}, nil, fmt.Errorf("create minimal route: %w", createErr)
+		}
+
+		route = minRoute
+	}
+
+	points, err := route.Points()
+	if err != nil || len(points) < minimalRoutePoints {
+		// Create direct route
+		points = []vo.Location{origin, destination}
+	}
+
+	return route, points, nil
+}
+
+// publishUnroutable resolves a delivery that cannot be routed with a NOT_DELIVERED (ReasonUnroutable) event.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func (ds *DeliverySimulator) publishUnroutable(ctx context.Context, courierID string, order vo.DeliveryOrder, location vo.Location) error {
+	if ds.statusPub != nil {
+		event, err := kafka.NewDeliverOrderEvent(courierID, order, location, false, kafka.ReasonUnroutable)
+		if err != nil {
+			return fmt.Errorf("build unroutable delivery event: %w", err)
+		}
+
+		event.Sequence = ds.nextSequence(courierID)
+
+		err = ds.statusPub.PublishDelivery(ctx, event)
+		if err != nil {
+			return fmt.Errorf("failed to publish unroutable delivery event: %w", err)
+		}
+	}
+
+	ds.metrics.deliveryFinished(ctx, false, kafka.ReasonUnroutable)
+
+	return nil
+}
+
+// startLocation returns where a courier heading to pickup starts: StartOffsetMeters away in a random
+// direction, so the HeadingToPickup phase publishes movement instead of arriving on the first tick.
+func (ds *DeliverySimulator) startLocation(pickup vo.Location) vo.Location {
+	if ds.config.StartOffsetMeters <= 0 {
+		return pickup
+	}
+
+	ds.mu.Lock()
+	bearing := 2 * math.Pi * ds.rng.Float64()
+	ds.mu.Unlock()
+
+	start, err := offsetLocation(pickup, ds.config.StartOffsetMeters/metersPerKm, bearing)
+	if err != nil {
+		// Offsetting across a pole or the antimeridian is not worth modelling.
+		return pickup
+	}
+
+	return start
+}
+
 // createMinimalRoute creates a minimal route between two points.
 func (ds *DeliverySimulator) createMinimalRoute(from, destination vo.Location) (vo.Route, error) {
 	// Create a simple polyline with just two points
@@ -234,8 +555,8 @@ func encodeNumber(num int64) string {
 	return encodedNumber.String()
 }
 
-// simulateDelivery runs the simulation loop for a delivery.
-func (ds *DeliverySimulator) simulateDelivery(ctx context.Context, courierID string) {
+// simulateDelivery runs the simulation loop for state until it finishes or is taken out of the simulator.
+func (ds *DeliverySimulator) simulateDelivery(ctx context.Context, state *DeliveryState) {
 	defer ds.wg.Done()
 
 	ticker := time.NewTicker(ds.config.UpdateInterval)
@@ -247,8 +568,10 @@ func (ds *DeliverySimulator) simulateDelivery(ctx context.Context, courierID str
 			return
 		case <-ds.stopCh:
 			return
+		case <-state.stop:
+			return
 		case <-ticker.C:
-			finished, err := ds.updateDelivery(ctx, courierID)
+			finished, err := ds.updateDelivery(ctx, state)
 			if err != nil || finished {
 				return
 			}
@@ -256,14 +579,20 @@ func (ds *DeliverySimulator) simulateDelivery(ctx context.Context, courierID str
 	}
 }
 
-// updateDelivery updates the delivery state and handles phase transitions.
-func (ds *DeliverySimulator) updateDelivery(ctx context.Context, courierID string) (bool, error) {
+// updateDelivery updates the delivery state and handles phase transitions. It finishes when the
+// courier's registered state is no longer state, i.e. the delivery was cancelled or handed over.
+func (ds *DeliverySimulator) updateDelivery(ctx context.Context, state *DeliveryState) (bool, error) {
 	ds.mu.Lock()
 
-	state, exists := ds.deliveries[courierID]
+	current, exists := ds.deliveries[state.CourierID]
 	if !exists {
 		ds.mu.Unlock()
-		return true, fmt.Errorf("%s: %w", courierID, domain.ErrDeliveryNotFound)
+		return true, fmt.Errorf("%s: %w", state.CourierID, domain.ErrDeliveryNotFound)
+	}
+
+	if current != state {
+		ds.mu.Unlock()
+		return true, nil
 	}
 
 	// Handle based on current phase
@@ -289,48 +618,20 @@ func (ds *DeliverySimulator) updateDelivery(ctx context.Context, courierID strin
 
 // handleMovingPhase handles courier movement along a route.
 func (ds *DeliverySimulator) handleMovingPhase(ctx context.Context, state *DeliveryState) (bool, error) {
-	// Calculate distance to travel
-	elapsed := time.Since(state.LastUpdateAt)
-	distanceToTravel := (state.Speed / 3600.0) * elapsed.Seconds() * ds.config.TimeMultiplier // km
-
-	// Move along the route
-	for distanceToTravel > 0 && state.CurrentPointIdx < len(state.RoutePoints)-1 {
-		nextPoint := state.RoutePoints[state.CurrentPointIdx+1]
-		distanceToNext := state.CurrentLocation.DistanceTo(nextPoint)
-
-		if distanceToTravel >= distanceToNext {
-			state.CurrentLocation = nextPoint
-			state.CurrentPointIdx++
-			distanceToTravel -= distanceToNext
-		} else {
-			ratio := distanceToTravel / distanceToNext
-			state.CurrentLocation = interpolateLocation(state.CurrentLocation, nextPoint, ratio)
-			distanceToTravel = 0
-		}
-	}
-
-	state.LastUpdateAt = time.Now()
-
-	// Check if route is completed
-	routeCompleted := state.CurrentPointIdx >= len(state.RoutePoints)-1
-	if routeCompleted {
-		state.CurrentLocation = state.RoutePoints[len(state.RoutePoints)-1]
-	}
+	routeCompleted := state.advance(time.Now(), ds.config.TimeMultiplier, false)
+	state.drainBattery(state.LastUpdateAt, ds.config.TimeMultiplier)
 
-	// Calculate heading
-	heading := 0.0
-	if state.CurrentPointIdx < len(state.RoutePoints)-1 {
-		heading = calculateHeading(state.CurrentLocation, state.RoutePoints[state.CurrentPointIdx+1])
-	}
-
-	// Create and publish location event
-	event := vo.NewCourierLocationEvent(state.CourierID, state.CurrentLocation, state.Phase.ToCourierStatus()).
+	// Create and publish location event; noise only affects the published location, not the progress along the route
+	published := applyGPSNoise(ds.rng, state.CurrentLocation, ds.config.GPSNoiseMeters)
+	event := vo.NewCourierLocationEvent(state.CourierID, published, state.Phase.ToCourierStatus()).
 		WithSpeed(state.Speed).
-		WithHeading(heading)
-
-	if state.CurrentRoute != nil {
-		event = event.WithRouteID(state.CurrentRoute.ID())
-	}
+		WithHeading(state.heading()).
+		WithAccuracy(reportedAccuracy(ds.config.GPSNoiseMeters)).
+		WithBatteryPercent(state.BatteryPercent).
+		WithOrderID(state.orderID()).
+		WithProgress(state.progress()).
+		WithRouteID(state.routeID()).
+		WithSequence(ds.sequences.next(state.CourierID))
 
 	ds.mu.Unlock()
 
@@ -344,7 +645,7 @@ func (ds *DeliverySimulator) handleMovingPhase(ctx context.Context, state *Deliv
 
 	// Handle phase transition if route completed
 	if routeCompleted {
-		return ds.transitionPhase(ctx, state.CourierID)
+		return ds.transitionPhase(ctx, state)
 	}
 
 	return false, nil
@@ -354,9 +655,16 @@ func (ds *DeliverySimulator) handleMovingPhase(ctx context.Context, state *Deliv
 func (ds *DeliverySimulator) handlePickingUpPhase(ctx context.Context, state *DeliveryState) (bool, error) {
 	waitTime := time.Since(state.PhaseStartedAt) * time.Duration(ds.config.TimeMultiplier)
 
-	// Publish stationary location update
+	state.drainBattery(time.Now(), ds.config.TimeMultiplier)
+
+	// Publish stationary location update; the pickup leg is complete, so progress plateaus at 100
 	event := vo.NewCourierLocationEvent(state.CourierID, state.CurrentLocation, vo.CourierStatusPickingUp).
-		WithSpeed(0)
+		WithSpeed(0).
+		WithAccuracy(reportedAccuracy(0)).
+		WithBatteryPercent(state.BatteryPercent).
+		WithOrderID(state.orderID()).
+		WithProgress(state.progress()).
+		WithSequence(ds.sequences.next(state.CourierID))
 
 	ds.mu.Unlock()
 
@@ -369,7 +677,7 @@ func (ds *DeliverySimulator) handlePickingUpPhase(ctx context.Context, state *De
 
 	// Check if wait time is complete
 	if waitTime >= ds.config.PickupWaitTime {
-		return ds.transitionPhase(ctx, state.CourierID)
+		return ds.transitionPhase(ctx, state)
 	}
 
 	return false, nil
@@ -379,9 +687,16 @@ func (ds *DeliverySimulator) handlePickingUpPhase(ctx context.Context, state *De
 func (ds *DeliverySimulator) handleDeliveringPhase(ctx context.Context, state *DeliveryState) (bool, error) {
 	waitTime := time.Since(state.PhaseStartedAt) * time.Duration(ds.config.TimeMultiplier)
 
-	// Publish stationary location update
+	state.drainBattery(time.Now(), ds.config.TimeMultiplier)
+
+	// Publish stationary location update; the customer leg is complete, so progress plateaus at 100
 	event := vo.NewCourierLocationEvent(state.CourierID, state.CurrentLocation, vo.CourierStatusDelivering).
-		WithSpeed(0)
+		WithSpeed(0).
+		WithAccuracy(reportedAccuracy(0)).
+		WithBatteryPercent(state.BatteryPercent).
+		WithOrderID(state.orderID()).
+		WithProgress(state.progress()).
+		WithSequence(ds.sequences.next(state.CourierID))
 
 	ds.mu.Unlock()
 
@@ -392,9 +707,14 @@ func (ds *DeliverySimulator) handleDeliveringPhase(ctx context.Context, state *D
 		}
 	}
 
-	// Check if wait time is complete
-	if waitTime >= ds.config.DeliveryWaitTime {
-		return ds.transitionPhase(ctx, state.CourierID)
+	// Check if wait time is complete; a retried attempt first waits out the retry delay
+	deliveryWait := ds.config.DeliveryWaitTime
+	if state.FailedAttempts > 0 {
+		deliveryWait += ds.config.RetryDelay
+	}
+
+	if waitTime >= deliveryWait {
+		return ds.transitionPhase(ctx, state)
 	}
 
 	return false, nil
@@ -403,23 +723,33 @@ func (ds *DeliverySimulator) handleDeliveringPhase(ctx context.Context, state *D
 // transitionPhase handles phase transitions.
 //
 //nolint:gocognit,funlen,maintidx // Delivery state transitions are kept explicit in one place to make the workflow easier to audit.
-func (ds *DeliverySimulator) transitionPhase(ctx context.Context, courierID string) (bool, error) {
+func (ds *DeliverySimulator) transitionPhase(ctx context.Context, state *DeliveryState) (bool, error) {
+	courierID := state.CourierID
+
 	ds.mu.Lock()
 
-	state, exists := ds.deliveries[courierID]
+	current, exists := ds.deliveries[courierID]
 	if !exists {
 		ds.mu.Unlock()
 		return true, domain.ErrDeliveryNotFound
 	}
 
+	if current != state {
+		ds.mu.Unlock()
+		return true, nil
+	}
+
 	currentPhase := state.Phase
 	order := state.CurrentOrder
 
+	if currentPhase != vo.PhaseIdle {
+		ds.metrics.phaseFinished(ctx, currentPhase, state.PhaseStartedAt)
+	}
+
 	switch currentPhase {
 	case vo.PhaseHeadingToPickup:
 		// Arrived at pickup -> start picking up
-		state.Phase = vo.PhasePickingUp
-		state.PhaseStartedAt = time.Now()
+		state.advancePhase(vo.PhasePickingUp, time.Now())
 
 		ds.mu.Unlock()
 
@@ -432,6 +762,7 @@ func (ds *DeliverySimulator) transitionPhase(ctx context.Context, courierID stri
 		// Publish pickup event
 		if ds.statusPub != nil && order != nil {
 			pickupEvent := kafka.NewPickUpOrderEvent(courierID, *order, state.CurrentLocation)
+			pickupEvent.Sequence = ds.nextSequence(courierID)
 
 			err := ds.statusPub.PublishPickUp(ctx, pickupEvent)
 			if err != nil {
@@ -441,19 +772,22 @@ func (ds *DeliverySimulator) transitionPhase(ctx context.Context, courierID stri
 
 		// Generate route to customer
 		if order != nil {
-			route, err := ds.routeGenerator.GenerateRoute(ctx, state.CurrentLocation, order.DeliveryLocation())
-			if err != nil {
-				minRoute, createErr := ds.createMinimalRoute(state.CurrentLocation, order.DeliveryLocation())
-				if createErr != nil {
-					return false, fmt.Errorf("create minimal route: %w", createErr)
+			route, points, err := ds.planRoute(ctx, state.CurrentLocation, order.DeliveryLocation())
+			if errors.Is(err, domain.ErrUnroutable) {
+				publishErr := ds.publishUnroutable(ctx, courierID, *order, state.CurrentLocation)
+				if publishErr != nil {
+					return false, publishErr
 				}
 
-				route = minRoute
+				ds.mu.Lock()
+				state.resetToIdle(time.Now())
+				ds.mu.Unlock()
+
+				return true, nil
 			}
 
-			points, err := route.Points()
-			if err != nil || len(points) < minimalRoutePoints {
-				points = []vo.Location{state.CurrentLocation, order.DeliveryLocation()}
+			if err != nil {
+				return false, err
 			}
 
 			ds.mu.Lock()
@@ -461,8 +795,7 @@ func (ds *DeliverySimulator) transitionPhase(ctx context.Context, courierID stri
 			state.CurrentRoute = &route
 			state.RoutePoints = points
 			state.CurrentPointIdx = 0
-			state.Phase = vo.PhaseHeadingToCustomer
-			state.PhaseStartedAt = time.Now()
+			state.advancePhase(vo.PhaseHeadingToCustomer, time.Now())
 			state.LastUpdateAt = time.Now()
 
 			ds.mu.Unlock()
@@ -472,8 +805,7 @@ func (ds *DeliverySimulator) transitionPhase(ctx context.Context, courierID stri
 
 	case vo.PhaseHeadingToCustomer:
 		// Arrived at customer -> start delivering
-		state.Phase = vo.PhaseDelivering
-		state.PhaseStartedAt = time.Now()
+		state.advancePhase(vo.PhaseDelivering, time.Now())
 
 		ds.mu.Unlock()
 
@@ -483,43 +815,43 @@ func (ds *DeliverySimulator) transitionPhase(ctx context.Context, courierID stri
 		// Delivery complete -> publish event and return to idle
 		ds.mu.Unlock()
 
-		// Determine if delivery was successful (based on failure rate)
-		delivered := ds.rng.Float64() >= ds.config.FailureRate
-
-		var reason kafka.NotDeliveredReason
+		delivered, reason := ds.drawDeliveryOutcome()
 
-		if !delivered {
-			reasons := []kafka.NotDeliveredReason{
-				kafka.ReasonCustomerNotAvailable,
-				kafka.ReasonWrongAddress,
-				kafka.ReasonCustomerRefused,
-				kafka.ReasonAccessDenied,
+		if !delivered && order != nil {
+			retried, err := ds.scheduleRetry(ctx, courierID, state, *order, reason)
+			if err != nil || retried {
+				return false, err
 			}
-			reason = reasons[ds.rng.Intn(len(reasons))]
 		}
 
 		// Publish delivery event
 		if ds.statusPub != nil && order != nil {
-			deliverEvent, err := kafka.NewDeliverOrderEvent(courierID, *order, state.CurrentLocation, delivered, reason)
+			deliverEvent, err := kafka.NewDeliverOrderEvent(courierID, *order, state.CurrentLocation, delivered, reason,
+				kafka.WithDeliveryProximity(ds.config.DeliveredProximityMeters))
 			if err != nil {
 				return false, fmt.Errorf("build delivery event: %w", err)
 			}
 
+			deliverEvent.Sequence = ds.nextSequence(courierID)
+
 			err = ds.statusPub.PublishDelivery(ctx, deliverEvent)
 			if err != nil {
 				return false, fmt.Errorf("failed to publish delivery event: %w", err)
 			}
+
+			if delivered {
+				err = ds.publishSLABreach(ctx, courierID, *order, deliverEvent.DeliveredAt)
+				if err != nil {
+					return false, err
+				}
+			}
 		}
 
+		ds.metrics.deliveryFinished(ctx, delivered, reason)
+
 		// Reset state to idle
 		ds.mu.Lock()
-
-		state.Phase = vo.PhaseIdle
-		state.CurrentOrder = nil
-		state.CurrentRoute = nil
-		state.RoutePoints = nil
-		state.CurrentPointIdx = 0
-
+		state.resetToIdle(time.Now())
 		ds.mu.Unlock()
 
 		return true, nil
@@ -530,6 +862,111 @@ func (ds *DeliverySimulator) transitionPhase(ctx context.Context, courierID stri
 	}
 }
 
+// publishSLABreach publishes DeliverySLABreached when order, delivered at deliveredAt, is more than
+// SLABreachGrace past its delivery deadline. Orders without a deadline never breach.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func (ds *DeliverySimulator) publishSLABreach(ctx context.Context, courierID string, order vo.DeliveryOrder, deliveredAt time.Time) error {
+	overdue := order.Overdue(deliveredAt)
+	if overdue <= 0 || overdue <= ds.config.SLABreachGrace {
+		return nil
+	}
+
+	event := kafka.NewDeliverySLABreachedEvent(courierID, order, deliveredAt)
+	event.Sequence = ds.nextSequence(courierID)
+
+	err := ds.statusPub.PublishSLABreached(ctx, event)
+	if err != nil {
+		return fmt.Errorf("failed to publish sla breached event: %w", err)
+	}
+
+	return nil
+}
+
+// scheduleRetry keeps the courier at the customer for another attempt after RetryDelay when a delivery
+// failed for a retryable reason and RetryMaxAttempts is not used up, publishing DeliveryRetryScheduled.
+// It reports false when the failure is final and NOT_DELIVERED should be published instead.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func (ds *DeliverySimulator) scheduleRetry(
+	ctx context.Context,
+	courierID string,
+	state *DeliveryState,
+	order vo.DeliveryOrder,
+	reason kafka.NotDeliveredReason,
+) (bool, error) {
+	if !IsRetryableReason(reason) {
+		return false, nil
+	}
+
+	ds.mu.Lock()
+
+	if state.FailedAttempts >= ds.config.RetryMaxAttempts {
+		ds.mu.Unlock()
+		return false, nil
+	}
+
+	now := time.Now()
+	state.FailedAttempts++
+	attempt := state.FailedAttempts
+	// The next attempt is a new delivering phase, so the timeline keeps the failed one
+	state.advancePhase(vo.PhaseDelivering, now)
+
+	ds.mu.Unlock()
+
+	if ds.statusPub == nil {
+		return true, nil
+	}
+
+	retryAt := now
+	if ds.config.TimeMultiplier > 0 {
+		retryAt = now.Add(time.Duration(float64(ds.config.RetryDelay) / ds.config.TimeMultiplier))
+	}
+
+	event := kafka.NewDeliveryRetryScheduledEvent(courierID, order, reason, attempt, retryAt)
+	event.Sequence = ds.nextSequence(courierID)
+
+	err := ds.statusPub.PublishRetryScheduled(ctx, event)
+	if err != nil {
+		return true, fmt.Errorf("failed to publish retry scheduled event: %w", err)
+	}
+
+	return true, nil
+}
+
+// drawDeliveryOutcome decides whether a delivery succeeds (probability 1 - FailureRate)
+// and, on failure, draws the reason from the configured distribution.
+func (ds *DeliverySimulator) drawDeliveryOutcome() (bool, kafka.NotDeliveredReason) {
+	if ds.rng.Float64() >= ds.config.FailureRate {
+		return true, ""
+	}
+
+	return false, ds.config.FailureReasons.Sample(ds.rng)
+}
+
+// nextSequence returns the courier's next event sequence number for an event built outside the lock.
+func (ds *DeliverySimulator) nextSequence(courierID string) uint64 {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	return ds.sequences.next(courierID)
+}
+
+// lastSequence returns the sequence number of the courier's last event, 0 when it has none.
+func (ds *DeliverySimulator) lastSequence(courierID string) uint64 {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	return ds.sequences[courierID]
+}
+
+// resumeSequence continues the courier's event sequence after lastSequence.
+func (ds *DeliverySimulator) resumeSequence(courierID string, lastSequence uint64) {
+	ds.mu.Lock()
+	ds.sequences.resume(courierID, lastSequence)
+	ds.mu.Unlock()
+}
+
 // GetDeliveryState returns the current state of a delivery.
 func (ds *DeliverySimulator) GetDeliveryState(courierID string) (*DeliveryState, bool) {
 	ds.mu.RLock()
@@ -540,8 +977,9 @@ func (ds *DeliverySimulator) GetDeliveryState(courierID string) (*DeliveryState,
 		return nil, false
 	}
 
-	// Return a copy
+	// Return a copy; the timeline is cloned so callers never share it with the simulation loop
 	stateCopy := *state
+	stateCopy.Timeline = slices.Clone(state.Timeline)
 
 	return &stateCopy, true
 }
@@ -561,19 +999,260 @@ func (ds *DeliverySimulator) GetAllDeliveries() []string {
 	return ids
 }
 
+// DeliverySummary is a point-in-time view of one active delivery.
+type DeliverySummary struct {
+	CourierID       string
+	OrderID         string
+	PackageID       string
+	Phase           vo.DeliveryPhase
+	CurrentLocation vo.Location
+	ProgressPercent float64 // Distance covered along the current route leg (0 - 100)
+}
+
+// Snapshot returns a summary of every active delivery, ordered by courier ID.
+// All summaries are taken under a single read lock, so they describe the same instant.
+func (ds *DeliverySimulator) Snapshot() []DeliverySummary {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	summaries := make([]DeliverySummary, 0, len(ds.deliveries))
+	for _, state := range ds.deliveries {
+		if state.Phase == vo.PhaseIdle {
+			continue
+		}
+
+		summary := DeliverySummary{
+			CourierID:       state.CourierID,
+			Phase:           state.Phase,
+			CurrentLocation: state.CurrentLocation,
+			ProgressPercent: state.progress(),
+		}
+		if state.CurrentOrder != nil {
+			summary.OrderID = state.CurrentOrder.OrderID()
+			summary.PackageID = state.CurrentOrder.PackageID()
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	slices.SortFunc(summaries, func(a, b DeliverySummary) int {
+		return strings.Compare(a.CourierID, b.CourierID)
+	})
+
+	return summaries
+}
+
 // StopDelivery stops a specific delivery simulation.
 func (ds *DeliverySimulator) StopDelivery(courierID string) {
 	ds.mu.Lock()
+
+	if state, exists := ds.deliveries[courierID]; exists {
+		state.stopSimulation()
+		delete(ds.deliveries, courierID)
+	}
+
+	ds.mu.Unlock()
+}
+
+// CancelDelivery stops the courier's in-flight delivery of packageID and publishes a terminal
+// NOT_DELIVERED event with ReasonCancelled. It returns domain.ErrDeliveryNotFound when the courier
+// has no active delivery for that package.
+func (ds *DeliverySimulator) CancelDelivery(ctx context.Context, courierID, packageID string) error {
+	ds.mu.Lock()
+
+	state, exists := ds.deliveries[courierID]
+	if !exists || state.Phase == vo.PhaseIdle || state.CurrentOrder == nil || state.CurrentOrder.PackageID() != packageID {
+		ds.mu.Unlock()
+		return fmt.Errorf("%s/%s: %w", courierID, packageID, domain.ErrDeliveryNotFound)
+	}
+
+	state.stopSimulation()
 	delete(ds.deliveries, courierID)
+
+	order := *state.CurrentOrder
+	location := state.CurrentLocation
+
 	ds.mu.Unlock()
+
+	if ds.statusPub != nil {
+		event, err := kafka.NewDeliverOrderEvent(courierID, order, location, false, kafka.ReasonCancelled)
+		if err != nil {
+			return fmt.Errorf("build cancelled delivery event: %w", err)
+		}
+
+		event.Sequence = ds.nextSequence(courierID)
+
+		err = ds.statusPub.PublishDelivery(ctx, event)
+		if err != nil {
+			return fmt.Errorf("failed to publish cancelled delivery event: %w", err)
+		}
+	}
+
+	ds.metrics.deliveryFinished(ctx, false, kafka.ReasonCancelled)
+
+	return nil
+}
+
+// ReassignDelivery hands the in-flight delivery of packageID over from one courier to another, e.g. when
+// the original courier goes offline. The new courier continues from the current location in the same
+// phase and along the same route; the original courier's simulation stops without publishing a
+// delivery outcome. A DeliveryReassigned event records the handover.
+// It returns domain.ErrDeliveryNotFound when fromCourierID has no active delivery for that package and
+// domain.ErrCourierHasActiveDelivery when toCourierID is already delivering. Once Drain has started,
+// it fails with domain.ErrSimulatorDraining.
+func (ds *DeliverySimulator) ReassignDelivery(ctx context.Context, fromCourierID, toCourierID, packageID string) error {
+	ds.mu.Lock()
+
+	if ds.draining {
+		ds.mu.Unlock()
+		return fmt.Errorf("%s: %w", toCourierID, domain.ErrSimulatorDraining)
+	}
+
+	state, exists := ds.deliveries[fromCourierID]
+	if !exists || state.Phase == vo.PhaseIdle || state.CurrentOrder == nil || state.CurrentOrder.PackageID() != packageID {
+		ds.mu.Unlock()
+		return fmt.Errorf("%s/%s: %w", fromCourierID, packageID, domain.ErrDeliveryNotFound)
+	}
+
+	if existing, busy := ds.deliveries[toCourierID]; busy && existing.Phase != vo.PhaseIdle {
+		ds.mu.Unlock()
+		return fmt.Errorf("%s: %w", toCourierID, domain.ErrCourierHasActiveDelivery)
+	}
+
+	now := time.Now()
+	order := *state.CurrentOrder
+	movement := state.Movement
+	movement.LastUpdateAt = now
+
+	reassigned := &DeliveryState{
+		Movement:         movement,
+		CourierID:        toCourierID,
+		CurrentOrder:     &order,
+		Phase:            state.Phase,
+		PhaseStartedAt:   now,
+		FailedAttempts:   state.FailedAttempts,
+		BatteryPercent:   fullBatteryPercent, // the new courier reports from their own phone
+		batteryDrainedAt: now,
+		stop:             make(chan struct{}),
+	}
+
+	state.stopSimulation()
+	delete(ds.deliveries, fromCourierID)
+
+	// Registering under the lock keeps Drain from waiting on a delivery it did not see
+	ds.deliveries[toCourierID] = reassigned
+	ds.wg.Add(1)
+
+	ds.mu.Unlock()
+
+	go ds.simulateDelivery(context.WithoutCancel(ctx), reassigned)
+
+	if ds.statusPub != nil {
+		event := kafka.NewDeliveryReassignedEvent(fromCourierID, toCourierID, order, reassigned.CurrentLocation)
+		event.Sequence = ds.nextSequence(toCourierID)
+
+		err := ds.statusPub.PublishReassigned(ctx, event)
+		if err != nil {
+			return fmt.Errorf("failed to publish reassigned event: %w", err)
+		}
+	}
+
+	return nil
 }
 
-// Stop stops all delivery simulations.
+// ShutdownReason is reported for deliveries interrupted by Drain.
+// The NOT_DELIVERED contract has no dedicated shutdown reason, so OTHER is used.
+const ShutdownReason = kafka.ReasonOther
+
+// drainInterruptReserve is how much of the Drain deadline is kept for interrupting the deliveries
+// that did not finish on their own; at most half of the time left is reserved.
+const drainInterruptReserve = 2 * time.Second
+
+// Stop stops all delivery simulations without publishing anything for in-flight deliveries.
+// Prefer Drain on service shutdown.
 func (ds *DeliverySimulator) Stop() {
-	close(ds.stopCh)
+	ds.stopOnce.Do(func() { close(ds.stopCh) })
 	ds.wg.Wait()
 
 	ds.mu.Lock()
 	ds.deliveries = make(map[string]*DeliveryState)
 	ds.mu.Unlock()
 }
+
+// Drain stops accepting new deliveries and lets the in-flight ones finish with their real outcome
+// until ctx is near its deadline (see drainInterruptReserve); without a deadline it waits for all
+// of them. Deliveries still running then are stopped and resolved with a terminal NOT_DELIVERED
+// event (ShutdownReason) at the courier's last known location, so consumers never see a delivery
+// that silently stops. ctx also bounds the publishing.
+func (ds *DeliverySimulator) Drain(ctx context.Context) error {
+	ds.mu.Lock()
+	ds.draining = true
+	ds.mu.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		ds.wg.Wait()
+		close(done)
+	}()
+
+	waitCtx, cancel := drainWaitContext(ctx)
+	defer cancel()
+
+	select {
+	case <-done:
+	case <-waitCtx.Done():
+		// Out of time: interrupt whatever is still running
+		ds.stopOnce.Do(func() { close(ds.stopCh) })
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return fmt.Errorf("drain deliveries: %w", ctx.Err())
+		}
+	}
+
+	ds.mu.Lock()
+	deliveries := ds.deliveries
+	ds.deliveries = make(map[string]*DeliveryState)
+	ds.mu.Unlock()
+
+	var errs []error
+
+	for courierID, state := range deliveries {
+		if state.Phase == vo.PhaseIdle || state.CurrentOrder == nil || ds.statusPub == nil {
+			continue
+		}
+
+		event, err := kafka.NewDeliverOrderEvent(courierID, *state.CurrentOrder, state.CurrentLocation, false, ShutdownReason)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("build shutdown delivery event for %s: %w", courierID, err))
+			continue
+		}
+
+		event.Sequence = ds.nextSequence(courierID)
+
+		err = ds.statusPub.PublishDelivery(ctx, event)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("publish shutdown delivery event for %s: %w", courierID, err))
+			continue
+		}
+
+		ds.metrics.deliveryFinished(ctx, false, ShutdownReason)
+	}
+
+	return errors.Join(errs...)
+}
+
+// drainWaitContext bounds how long Drain lets deliveries finish on their own: ctx's deadline minus
+// drainInterruptReserve, capped at half of the time left.
+func drainWaitContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+
+	reserve := min(drainInterruptReserve, time.Until(deadline)/2) //nolint:mnd // half of the time left
+
+	return context.WithDeadline(ctx, deadline.Add(-reserve))
+}
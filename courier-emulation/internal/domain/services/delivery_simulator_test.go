@@ -3,22 +3,33 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"slices"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain"
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 )
 
 // mockStatusPublisher is a mock implementation of StatusPublisher.
 type mockStatusPublisher struct {
-	mu             sync.Mutex
-	pickupEvents   []kafka.PickUpOrderEvent
-	deliveryEvents []kafka.DeliverOrderEvent
+	mu               sync.Mutex
+	pickupEvents     []kafka.PickUpOrderEvent
+	deliveryEvents   []kafka.DeliverOrderEvent
+	reassignedEvents []kafka.DeliveryReassignedEvent
+	breachedEvents   []kafka.DeliverySLABreachedEvent
+	retryEvents      []kafka.DeliveryRetryScheduledEvent
 }
 
 func newMockStatusPublisher() *mockStatusPublisher {
@@ -46,6 +57,33 @@ func (m *mockStatusPublisher) PublishDelivery(ctx context.Context, event kafka.D
 	return nil
 }
 
+func (m *mockStatusPublisher) PublishReassigned(ctx context.Context, event kafka.DeliveryReassignedEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.reassignedEvents = append(m.reassignedEvents, event)
+
+	return nil
+}
+
+func (m *mockStatusPublisher) PublishSLABreached(ctx context.Context, event kafka.DeliverySLABreachedEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.breachedEvents = append(m.breachedEvents, event)
+
+	return nil
+}
+
+func (m *mockStatusPublisher) PublishRetryScheduled(ctx context.Context, event kafka.DeliveryRetryScheduledEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.retryEvents = append(m.retryEvents, event)
+
+	return nil
+}
+
 func (m *mockStatusPublisher) Close() error {
 	return nil
 }
@@ -70,6 +108,27 @@ func (m *mockStatusPublisher) GetDeliveryEvents() []kafka.DeliverOrderEvent {
 	return result
 }
 
+func (m *mockStatusPublisher) GetReassignedEvents() []kafka.DeliveryReassignedEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.reassignedEvents)
+}
+
+func (m *mockStatusPublisher) GetBreachedEvents() []kafka.DeliverySLABreachedEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.breachedEvents)
+}
+
+func (m *mockStatusPublisher) GetRetryEvents() []kafka.DeliveryRetryScheduledEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.retryEvents)
+}
+
 func TestDeliveryPhase_ToCourierStatus(t *testing.T) {
 	tests := []struct {
 		phase    vo.DeliveryPhase
@@ -171,7 +230,7 @@ func TestDeliverySimulator_StartDelivery(t *testing.T) {
 		FailureRate:      0.0, // Always succeed
 	}
 
-	simulator := NewDeliverySimulator(config, routeGen, locationPub, statusPub)
+	simulator := NewDeliverySimulator(config, routeGen, locationPub, statusPub, nil)
 	defer simulator.Stop()
 
 	ctx, cancel := context.WithTimeoutCause(context.Background(), 5*time.Second,
@@ -194,6 +253,50 @@ func TestDeliverySimulator_StartDelivery(t *testing.T) {
 	assert.Equal(t, vo.PhaseHeadingToPickup, state.Phase)
 }
 
+func TestDeliverySimulator_StartDeliveryIsIdempotent(t *testing.T) {
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	simulator := NewDeliverySimulator(DefaultDeliverySimulatorConfig(), routeGen, newMockLocationPublisher(), newMockStatusPublisher(), nil)
+	defer simulator.Stop()
+
+	ctx := context.Background()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5300, 13.4150)
+	order := vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())
+
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-1", order))
+
+	first, exists := simulator.GetDeliveryState("courier-1")
+	require.True(t, exists)
+
+	t.Run("same order is a no-op", func(t *testing.T) {
+		redelivered := vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())
+		require.NoError(t, simulator.StartDelivery(ctx, "courier-1", redelivered))
+
+		state, exists := simulator.GetDeliveryState("courier-1")
+		require.True(t, exists)
+		assert.Equal(t, first.PhaseStartedAt, state.PhaseStartedAt, "the running simulation must not restart")
+		assert.Equal(t, first.CurrentRoute, state.CurrentRoute)
+	})
+
+	t.Run("different order fails", func(t *testing.T) {
+		other := vo.NewDeliveryOrder("order-2", "pkg-2", pickup, delivery, time.Now())
+
+		err := simulator.StartDelivery(ctx, "courier-1", other)
+		require.ErrorIs(t, err, domain.ErrCourierHasActiveDelivery)
+
+		state, _ := simulator.GetDeliveryState("courier-1")
+		assert.Equal(t, "pkg-1", state.CurrentOrder.PackageID())
+	})
+}
+
 func TestDeliverySimulator_DoubleStartError(t *testing.T) {
 	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
 		OSRMBaseURL: "http://localhost:5000",
@@ -208,7 +311,7 @@ func TestDeliverySimulator_DoubleStartError(t *testing.T) {
 
 	config := DefaultDeliverySimulatorConfig()
 
-	simulator := NewDeliverySimulator(config, routeGen, locationPub, statusPub)
+	simulator := NewDeliverySimulator(config, routeGen, locationPub, statusPub, nil)
 	defer simulator.Stop()
 
 	ctx := context.Background()
@@ -228,6 +331,104 @@ func TestDeliverySimulator_DoubleStartError(t *testing.T) {
 	assert.Contains(t, err.Error(), "already has an active delivery")
 }
 
+func TestDeliverySimulator_ConcurrentDuplicateStart(t *testing.T) {
+	// A slow OSRM keeps every start planning its route at the same time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: server.URL,
+		Timeout:     time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5300, 13.4150)
+
+	const starts = 8
+
+	t.Run("same order starts once", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+		deliveryMetrics, err := NewDeliveryMetrics(provider.Meter(DeliveryMetricsMeterName))
+		require.NoError(t, err)
+
+		simulator := NewDeliverySimulator(DefaultDeliverySimulatorConfig(), routeGen, newMockLocationPublisher(), newMockStatusPublisher(), deliveryMetrics)
+		defer simulator.Stop()
+
+		errs := make([]error, starts)
+
+		var wg sync.WaitGroup
+		for i := range starts {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				order := vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())
+				errs[i] = simulator.StartDelivery(context.Background(), "courier-1", order)
+			}()
+		}
+
+		wg.Wait()
+
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int64(1), sumValue(t, collectMetrics(t, reader), "courier_emulation.deliveries.started"))
+	})
+
+	t.Run("different orders leave one winner", func(t *testing.T) {
+		simulator := NewDeliverySimulator(DefaultDeliverySimulatorConfig(), routeGen, newMockLocationPublisher(), newMockStatusPublisher(), nil)
+		defer simulator.Stop()
+
+		errs := make([]error, starts)
+
+		var wg sync.WaitGroup
+		for i := range starts {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				order := vo.NewDeliveryOrder(fmt.Sprintf("order-%d", i), fmt.Sprintf("pkg-%d", i), pickup, delivery, time.Now())
+				errs[i] = simulator.StartDelivery(context.Background(), "courier-1", order)
+			}()
+		}
+
+		wg.Wait()
+
+		winner := -1
+
+		for i, err := range errs {
+			if err == nil {
+				require.Equal(t, -1, winner, "only one order may start")
+
+				winner = i
+
+				continue
+			}
+
+			require.ErrorIs(t, err, domain.ErrCourierHasActiveDelivery)
+		}
+
+		require.NotEqual(t, -1, winner)
+
+		state, exists := simulator.GetDeliveryState("courier-1")
+		require.True(t, exists)
+		assert.Equal(t, fmt.Sprintf("pkg-%d", winner), state.CurrentOrder.PackageID())
+	})
+}
+
 func TestDeliverySimulator_GetAllDeliveries(t *testing.T) {
 	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
 		OSRMBaseURL: "http://localhost:5000",
@@ -242,7 +443,7 @@ func TestDeliverySimulator_GetAllDeliveries(t *testing.T) {
 
 	config := DefaultDeliverySimulatorConfig()
 
-	simulator := NewDeliverySimulator(config, routeGen, locationPub, statusPub)
+	simulator := NewDeliverySimulator(config, routeGen, locationPub, statusPub, nil)
 	defer simulator.Stop()
 
 	ctx := context.Background()
@@ -262,6 +463,123 @@ func TestDeliverySimulator_GetAllDeliveries(t *testing.T) {
 	assert.Len(t, ids, 3)
 }
 
+func TestDeliverySimulator_Snapshot(t *testing.T) {
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	config := DefaultDeliverySimulatorConfig()
+	config.StartOffsetMeters = 0 // couriers start at pickup
+
+	simulator := NewDeliverySimulator(config, routeGen, newMockLocationPublisher(), newMockStatusPublisher(), nil)
+	defer simulator.Stop()
+
+	ctx := context.Background()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5300, 13.4150)
+
+	for i := 1; i <= 2; i++ {
+		order := vo.NewDeliveryOrder("order-"+string(rune('0'+i)), "pkg-"+string(rune('0'+i)), pickup, delivery, time.Now())
+		err = simulator.StartDelivery(ctx, "courier-"+string(rune('0'+i)), order)
+		require.NoError(t, err)
+	}
+
+	summaries := simulator.Snapshot()
+	require.Len(t, summaries, 2)
+
+	for i, summary := range summaries {
+		n := string(rune('1' + i))
+		assert.Equal(t, "courier-"+n, summary.CourierID)
+		assert.Equal(t, "order-"+n, summary.OrderID)
+		assert.Equal(t, "pkg-"+n, summary.PackageID)
+		assert.True(t, summary.Phase.IsActive())
+		assert.InDelta(t, pickup.Latitude(), summary.CurrentLocation.Latitude(), 0.001)
+		assert.InDelta(t, pickup.Longitude(), summary.CurrentLocation.Longitude(), 0.001)
+		assert.GreaterOrEqual(t, summary.ProgressPercent, 0.0)
+		assert.LessOrEqual(t, summary.ProgressPercent, 100.0)
+	}
+
+	// Stopped deliveries drop out of the snapshot
+	simulator.StopDelivery("courier-1")
+	assert.Len(t, simulator.Snapshot(), 1)
+}
+
+func TestRouteProgress(t *testing.T) {
+	a := vo.MustNewLocation(52.50, 13.40)
+	b := vo.MustNewLocation(52.51, 13.40)
+	c := vo.MustNewLocation(52.52, 13.40)
+	halfway := vo.MustNewLocation(52.505, 13.40)
+
+	tests := []struct {
+		name     string
+		movement Movement
+		want     float64
+	}{
+		{"at start", Movement{RoutePoints: []vo.Location{a, b, c}, CurrentLocation: a}, 0},
+		{"middle of first segment", Movement{RoutePoints: []vo.Location{a, b, c}, CurrentLocation: halfway}, 25},
+		{"second point", Movement{RoutePoints: []vo.Location{a, b, c}, CurrentLocation: b, CurrentPointIdx: 1}, 50},
+		{"route completed", Movement{RoutePoints: []vo.Location{a, b, c}, CurrentLocation: c, CurrentPointIdx: 2}, 100},
+		{"zero-length route pending", Movement{RoutePoints: []vo.Location{a, a}, CurrentLocation: a}, 0},
+		{"zero-length route completed", Movement{RoutePoints: []vo.Location{a, a}, CurrentLocation: a, CurrentPointIdx: 1}, 100},
+		{"no route", Movement{CurrentLocation: a}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.movement.progress(), 0.1)
+		})
+	}
+}
+
+func TestDeliverySimulator_LocationEventsReportProgress(t *testing.T) {
+	start := vo.MustNewLocation(52.50, 13.40)
+	end := vo.MustNewLocation(52.52, 13.40)
+	halfway := vo.MustNewLocation(52.51, 13.40)
+	order := vo.NewDeliveryOrder("order-1", "pkg-1", start, end, time.Now())
+
+	locationPub := newMockLocationPublisher()
+	config := DefaultDeliverySimulatorConfig()
+	simulator := NewDeliverySimulator(config, nil, locationPub, nil, nil)
+
+	// Halfway along the leg to the customer; zero speed keeps the courier in place for the tick.
+	state := &DeliveryState{
+		Movement: Movement{
+			CurrentLocation: halfway,
+			RoutePoints:     []vo.Location{start, end},
+			LastUpdateAt:    time.Now(),
+		},
+		CourierID:      "courier-1",
+		CurrentOrder:   &order,
+		Phase:          vo.PhaseHeadingToCustomer,
+		PhaseStartedAt: time.Now(),
+	}
+	simulator.deliveries[state.CourierID] = state
+
+	simulator.mu.Lock()
+	finished, err := simulator.handleMovingPhase(context.Background(), state)
+	require.NoError(t, err)
+	require.False(t, finished)
+
+	// Arrived: waiting at the customer plateaus at 100.
+	state.Phase = vo.PhaseDelivering
+	state.CurrentLocation = end
+	state.CurrentPointIdx = 1
+
+	simulator.mu.Lock()
+	_, err = simulator.handleDeliveringPhase(context.Background(), state)
+	require.NoError(t, err)
+
+	events := locationPub.GetEvents()
+	require.Len(t, events, 2)
+	assert.InDelta(t, 50, events[0].ProgressPercent, 0.5)
+	assert.InDelta(t, 100, events[1].ProgressPercent, 0.01)
+}
+
 func TestDeliverySimulator_StopDelivery(t *testing.T) {
 	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
 		OSRMBaseURL: "http://localhost:5000",
@@ -276,7 +594,7 @@ func TestDeliverySimulator_StopDelivery(t *testing.T) {
 
 	config := DefaultDeliverySimulatorConfig()
 
-	simulator := NewDeliverySimulator(config, routeGen, locationPub, statusPub)
+	simulator := NewDeliverySimulator(config, routeGen, locationPub, statusPub, nil)
 	defer simulator.Stop()
 
 	ctx := context.Background()
@@ -309,4 +627,886 @@ func TestDefaultDeliverySimulatorConfig(t *testing.T) {
 	assert.Equal(t, 30*time.Second, config.PickupWaitTime)
 	assert.Equal(t, 60*time.Second, config.DeliveryWaitTime)
 	assert.Equal(t, 0.05, config.FailureRate)
+	assert.Equal(t, 200.0, config.StartOffsetMeters)
+}
+
+func TestDeliverySimulator_StartOffsetMovesBeforePickup(t *testing.T) {
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5300, 13.4150)
+
+	testCases := []struct {
+		name        string
+		offset      float64
+		wantPhase   vo.DeliveryPhase
+		wantMovedKm float64
+	}{
+		{name: "offset start", offset: 200, wantPhase: vo.PhaseHeadingToPickup, wantMovedKm: 0.2},
+		{name: "start at pickup", offset: 0, wantPhase: vo.PhasePickingUp},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := DefaultDeliverySimulatorConfig()
+			config.UpdateInterval = time.Hour // updates are driven by the test
+			config.StartOffsetMeters = tc.offset
+
+			locationPub := newMockLocationPublisher()
+			simulator := NewDeliverySimulator(config, routeGen, locationPub, newMockStatusPublisher(), nil)
+			defer simulator.Stop()
+
+			order := vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())
+			require.NoError(t, simulator.StartDelivery(context.Background(), "courier-1", order))
+
+			state, exists := simulator.GetDeliveryState("courier-1")
+			require.True(t, exists)
+			assert.InDelta(t, tc.wantMovedKm, state.CurrentLocation.DistanceTo(pickup), 0.01)
+
+			simulator.mu.RLock()
+			started := simulator.deliveries["courier-1"]
+			simulator.mu.RUnlock()
+
+			_, err := simulator.updateDelivery(context.Background(), started)
+			require.NoError(t, err)
+
+			events := locationPub.GetEvents()
+			require.NotEmpty(t, events)
+			assert.Equal(t, vo.CourierStatusMoving, events[0].Status)
+
+			state, exists = simulator.GetDeliveryState("courier-1")
+			require.True(t, exists)
+			assert.Equal(t, tc.wantPhase, state.Phase)
+		})
+	}
+}
+
+func TestDeliverySimulator_StartDeliveryUnroutable(t *testing.T) {
+	// OSRM knows no route between any two points, e.g. a pickup on an island
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(routeServerResponse{Code: "NoRoute"}) //nolint:errcheck // test mock response
+	}))
+	defer server.Close()
+
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: server.URL,
+		Timeout:     time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5300, 13.4150)
+
+	t.Run("strict mode resolves the delivery as unroutable", func(t *testing.T) {
+		statusPub := newMockStatusPublisher()
+		simulator := NewDeliverySimulator(DefaultDeliverySimulatorConfig(), routeGen, newMockLocationPublisher(), statusPub, nil)
+		defer simulator.Stop()
+
+		order := vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())
+
+		err := simulator.StartDelivery(context.Background(), "courier-1", order)
+		require.ErrorIs(t, err, domain.ErrUnroutable)
+
+		_, exists := simulator.GetDeliveryState("courier-1")
+		assert.False(t, exists, "an unroutable delivery is never simulated")
+
+		statusPub.mu.Lock()
+		defer statusPub.mu.Unlock()
+
+		require.Len(t, statusPub.deliveryEvents, 1)
+		event := statusPub.deliveryEvents[0]
+		assert.Equal(t, "pkg-1", event.PackageID)
+		assert.Equal(t, kafka.DeliveryStatusNotDelivered, event.Status)
+		assert.Equal(t, kafka.ReasonUnroutable, event.Reason)
+	})
+
+	t.Run("fallback straight-lines the leg", func(t *testing.T) {
+		config := DefaultDeliverySimulatorConfig()
+		config.StraightLineFallback = true
+
+		statusPub := newMockStatusPublisher()
+		simulator := NewDeliverySimulator(config, routeGen, newMockLocationPublisher(), statusPub, nil)
+		defer simulator.Stop()
+
+		order := vo.NewDeliveryOrder("order-2", "pkg-2", pickup, delivery, time.Now())
+		require.NoError(t, simulator.StartDelivery(context.Background(), "courier-2", order))
+
+		state, exists := simulator.GetDeliveryState("courier-2")
+		require.True(t, exists)
+		assert.Equal(t, vo.PhaseHeadingToPickup, state.Phase)
+		assert.Len(t, state.RoutePoints, 2)
+		assert.Equal(t, pickup, state.RoutePoints[1])
+
+		statusPub.mu.Lock()
+		defer statusPub.mu.Unlock()
+
+		assert.Empty(t, statusPub.deliveryEvents)
+	})
+}
+
+func TestDeliverySimulator_SelfPickupCompletesAtOnce(t *testing.T) {
+	// A self-pickup order has nothing to route; any OSRM call is a bug
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected OSRM request %s", r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: server.URL,
+		Timeout:     time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	config := DefaultDeliverySimulatorConfig()
+	config.UpdateInterval = 10 * time.Millisecond
+	config.FailureRate = 1 // the outcome of a self-pickup is never drawn
+	config.DeliveredProximityMeters = 1
+
+	locationPub := newMockLocationPublisher()
+	statusPub := newMockStatusPublisher()
+	simulator := NewDeliverySimulator(config, routeGen, locationPub, statusPub, nil)
+	defer simulator.Stop()
+
+	store := vo.MustNewLocation(52.5200, 13.4050)
+	order := vo.NewDeliveryOrder("order-1", "pkg-1", store, store, time.Now())
+
+	require.NoError(t, simulator.StartDelivery(context.Background(), "courier-1", order))
+
+	statusPub.mu.Lock()
+	require.Len(t, statusPub.pickupEvents, 1, "pickup is published before StartDelivery returns")
+	require.Len(t, statusPub.deliveryEvents, 1, "delivery is published before StartDelivery returns")
+	pickup, delivered := statusPub.pickupEvents[0], statusPub.deliveryEvents[0]
+	statusPub.mu.Unlock()
+
+	assert.Equal(t, "pkg-1", pickup.PackageID)
+	assert.Equal(t, uint64(1), pickup.Sequence)
+	assert.Equal(t, kafka.DeliveryStatusDelivered, delivered.Status)
+	assert.Empty(t, delivered.Reason)
+	assert.Equal(t, uint64(2), delivered.Sequence)
+
+	state, exists := simulator.GetDeliveryState("courier-1")
+	require.True(t, exists)
+	assert.Equal(t, vo.PhaseIdle, state.Phase)
+	assert.Equal(t, store, state.CurrentLocation)
+	assert.Empty(t, simulator.GetAllDeliveries())
+
+	// No movement phases: nothing is simulated after the short-circuit
+	time.Sleep(5 * config.UpdateInterval)
+	assert.Empty(t, locationPub.GetEvents())
+
+	// The courier is free for the next order
+	next := vo.NewDeliveryOrder("order-2", "pkg-2", store, store, time.Now())
+	require.NoError(t, simulator.StartDelivery(context.Background(), "courier-1", next))
+}
+
+func TestDeliverySimulator_DrainPublishesTerminalEvent(t *testing.T) {
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	locationPub := newMockLocationPublisher()
+	statusPub := newMockStatusPublisher()
+
+	// Default timings keep the delivery in flight for the whole test.
+	simulator := NewDeliverySimulator(DefaultDeliverySimulatorConfig(), routeGen, locationPub, statusPub, nil)
+	defer simulator.Stop()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5300, 13.4150)
+	order := vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())
+
+	err = simulator.StartDelivery(context.Background(), "courier-1", order)
+	require.NoError(t, err)
+
+	// The delivery cannot finish in time, so Drain interrupts it near the deadline.
+	ctx, cancel := context.WithTimeoutCause(context.Background(), time.Second,
+		errors.New("test timeout: Drain (1s)"))
+	defer cancel()
+
+	require.NoError(t, simulator.Drain(ctx))
+
+	events := statusPub.GetDeliveryEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, "pkg-1", events[0].PackageID)
+	assert.Equal(t, "courier-1", events[0].CourierID)
+	assert.Equal(t, kafka.DeliveryStatusNotDelivered, events[0].Status)
+	assert.Equal(t, ShutdownReason, events[0].Reason)
+
+	assert.Empty(t, simulator.GetAllDeliveries())
+
+	// A second drain has nothing left to resolve.
+	require.NoError(t, simulator.Drain(ctx))
+	assert.Len(t, statusPub.GetDeliveryEvents(), 1)
+}
+
+func TestDeliverySimulator_DrainWaitsForInFlightDeliveries(t *testing.T) {
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	statusPub := newMockStatusPublisher()
+
+	config := DeliverySimulatorConfig{
+		UpdateInterval:   10 * time.Millisecond,
+		SpeedKmH:         100.0,
+		TimeMultiplier:   100.0,
+		PickupWaitTime:   50 * time.Millisecond,
+		DeliveryWaitTime: 50 * time.Millisecond,
+		FailureRate:      0.0,
+	}
+
+	simulator := NewDeliverySimulator(config, routeGen, newMockLocationPublisher(), statusPub, nil)
+	defer simulator.Stop()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5201, 13.4051)
+	order := vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())
+
+	// The assignment context ends with the request; the delivery keeps going.
+	startCtx, cancelStart := context.WithCancel(context.Background())
+	require.NoError(t, simulator.StartDelivery(startCtx, "courier-1", order))
+	cancelStart()
+
+	ctx, cancel := context.WithTimeoutCause(context.Background(), 10*time.Second,
+		errors.New("test timeout: Drain (10s)"))
+	defer cancel()
+
+	require.NoError(t, simulator.Drain(ctx))
+
+	events := statusPub.GetDeliveryEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, kafka.DeliveryStatusDelivered, events[0].Status)
+
+	// New work is refused once draining has started.
+	next := vo.NewDeliveryOrder("order-2", "pkg-2", pickup, delivery, time.Now())
+	err = simulator.StartDelivery(context.Background(), "courier-2", next)
+	require.ErrorIs(t, err, domain.ErrSimulatorDraining)
+}
+
+func TestDeliverySimulator_CancelDelivery(t *testing.T) {
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	statusPub := newMockStatusPublisher()
+
+	simulator := NewDeliverySimulator(DefaultDeliverySimulatorConfig(), routeGen, newMockLocationPublisher(), statusPub, nil)
+	defer simulator.Stop()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5300, 13.4150)
+	order := vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())
+
+	ctx := context.Background()
+
+	err = simulator.StartDelivery(ctx, "courier-1", order)
+	require.NoError(t, err)
+
+	// A different package of the same courier is not cancelled.
+	err = simulator.CancelDelivery(ctx, "courier-1", "pkg-other")
+	require.ErrorIs(t, err, domain.ErrDeliveryNotFound)
+
+	require.NoError(t, simulator.CancelDelivery(ctx, "courier-1", "pkg-1"))
+
+	_, exists := simulator.GetDeliveryState("courier-1")
+	assert.False(t, exists)
+
+	events := statusPub.GetDeliveryEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, "pkg-1", events[0].PackageID)
+	assert.Equal(t, kafka.DeliveryStatusNotDelivered, events[0].Status)
+	assert.Equal(t, kafka.ReasonCancelled, events[0].Reason)
+
+	err = simulator.CancelDelivery(ctx, "courier-1", "pkg-1")
+	require.ErrorIs(t, err, domain.ErrDeliveryNotFound)
+}
+
+func TestDeliverySimulator_CancelDeliveryStopsLoop(t *testing.T) {
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	// The loops never tick on their own; the test drives the cancelled one by hand.
+	config := DefaultDeliverySimulatorConfig()
+	config.UpdateInterval = time.Hour
+
+	locationPub := newMockLocationPublisher()
+
+	simulator := NewDeliverySimulator(config, routeGen, locationPub, newMockStatusPublisher(), nil)
+	defer simulator.Stop()
+
+	ctx := context.Background()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5300, 13.4150)
+
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-1", vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())))
+
+	simulator.mu.RLock()
+	cancelled := simulator.deliveries["courier-1"]
+	simulator.mu.RUnlock()
+
+	require.NoError(t, simulator.CancelDelivery(ctx, "courier-1", "pkg-1"))
+
+	select {
+	case <-cancelled.stop:
+	default:
+		t.Fatal("the cancelled simulation loop must be stopped")
+	}
+
+	// The courier takes a new order before the cancelled loop notices
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-1", vo.NewDeliveryOrder("order-2", "pkg-2", pickup, delivery, time.Now())))
+
+	before, exists := simulator.GetDeliveryState("courier-1")
+	require.True(t, exists)
+
+	finished, err := simulator.updateDelivery(ctx, cancelled)
+	require.NoError(t, err)
+	assert.True(t, finished, "a stale loop must finish")
+	assert.Empty(t, locationPub.GetEvents(), "a stale loop must not drive the new delivery")
+
+	after, _ := simulator.GetDeliveryState("courier-1")
+	assert.Equal(t, before.LastUpdateAt, after.LastUpdateAt)
+}
+
+func TestDeliverySimulator_ReassignDelivery(t *testing.T) {
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	// Pickup takes long enough to reassign the delivery while it is still in flight.
+	config := DeliverySimulatorConfig{
+		UpdateInterval:   10 * time.Millisecond,
+		SpeedKmH:         100.0,
+		TimeMultiplier:   1.0,
+		PickupWaitTime:   200 * time.Millisecond,
+		DeliveryWaitTime: 20 * time.Millisecond,
+		FailureRate:      0.0,
+	}
+
+	statusPub := newMockStatusPublisher()
+
+	simulator := NewDeliverySimulator(config, routeGen, newMockLocationPublisher(), statusPub, nil)
+	defer simulator.Stop()
+
+	ctx, cancel := context.WithTimeoutCause(context.Background(), 10*time.Second,
+		errors.New("test timeout: ReassignDelivery (10s)"))
+	defer cancel()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5201, 13.4051)
+	order := vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())
+
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-1", order))
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-busy", vo.NewDeliveryOrder("order-2", "pkg-2", pickup, delivery, time.Now())))
+
+	require.Eventually(t, func() bool {
+		state, exists := simulator.GetDeliveryState("courier-1")
+		return exists && state.Phase == vo.PhasePickingUp
+	}, 5*time.Second, 5*time.Millisecond)
+
+	before, _ := simulator.GetDeliveryState("courier-1")
+
+	err = simulator.ReassignDelivery(ctx, "courier-1", "courier-2", "pkg-other")
+	require.ErrorIs(t, err, domain.ErrDeliveryNotFound)
+
+	err = simulator.ReassignDelivery(ctx, "courier-1", "courier-busy", "pkg-1")
+	require.ErrorIs(t, err, domain.ErrCourierHasActiveDelivery)
+
+	require.NoError(t, simulator.ReassignDelivery(ctx, "courier-1", "courier-2", "pkg-1"))
+
+	_, exists := simulator.GetDeliveryState("courier-1")
+	assert.False(t, exists, "the original courier must no longer carry the delivery")
+
+	after, exists := simulator.GetDeliveryState("courier-2")
+	require.True(t, exists)
+	assert.Equal(t, "pkg-1", after.CurrentOrder.PackageID())
+	assert.Equal(t, vo.PhasePickingUp, after.Phase)
+	assert.Equal(t, before.CurrentLocation, after.CurrentLocation)
+
+	reassignedEvents := statusPub.GetReassignedEvents()
+	require.Len(t, reassignedEvents, 1)
+	assert.Equal(t, "pkg-1", reassignedEvents[0].PackageID)
+	assert.Equal(t, "courier-1", reassignedEvents[0].FromCourierID)
+	assert.Equal(t, "courier-2", reassignedEvents[0].ToCourierID)
+
+	// The delivery finishes under the new courier.
+	require.Eventually(t, func() bool {
+		state, exists := simulator.GetDeliveryState("courier-2")
+		return exists && state.Phase == vo.PhaseIdle
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for _, event := range statusPub.GetPickupEvents() {
+		if event.PackageID == "pkg-1" {
+			assert.Equal(t, "courier-2", event.CourierID)
+		}
+	}
+
+	var delivered []kafka.DeliverOrderEvent
+
+	for _, event := range statusPub.GetDeliveryEvents() {
+		if event.PackageID == "pkg-1" {
+			delivered = append(delivered, event)
+		}
+	}
+
+	require.Len(t, delivered, 1, "the original courier must not resolve the reassigned package")
+	assert.Equal(t, "courier-2", delivered[0].CourierID)
+	assert.Equal(t, kafka.DeliveryStatusDelivered, delivered[0].Status)
+}
+
+func TestDeliverySimulator_ReassignDeliveryStopsOriginalLoop(t *testing.T) {
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	// The loops never tick on their own; the test drives the original one by hand.
+	config := DefaultDeliverySimulatorConfig()
+	config.UpdateInterval = time.Hour
+
+	locationPub := newMockLocationPublisher()
+
+	simulator := NewDeliverySimulator(config, routeGen, locationPub, newMockStatusPublisher(), nil)
+	defer simulator.Stop()
+
+	ctx := context.Background()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5300, 13.4150)
+
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-1", vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())))
+
+	simulator.mu.RLock()
+	original := simulator.deliveries["courier-1"]
+	simulator.mu.RUnlock()
+
+	require.NoError(t, simulator.ReassignDelivery(ctx, "courier-1", "courier-2", "pkg-1"))
+
+	select {
+	case <-original.stop:
+	default:
+		t.Fatal("the original simulation loop must be stopped")
+	}
+
+	// The original courier takes a new order before its old loop notices the handover
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-1", vo.NewDeliveryOrder("order-2", "pkg-2", pickup, delivery, time.Now())))
+
+	before, exists := simulator.GetDeliveryState("courier-1")
+	require.True(t, exists)
+
+	finished, err := simulator.updateDelivery(ctx, original)
+	require.NoError(t, err)
+	assert.True(t, finished, "a stale loop must finish")
+	assert.Empty(t, locationPub.GetEvents(), "a stale loop must not drive the new delivery")
+
+	after, _ := simulator.GetDeliveryState("courier-1")
+	assert.Equal(t, before.CurrentLocation, after.CurrentLocation)
+	assert.Equal(t, before.LastUpdateAt, after.LastUpdateAt)
+}
+
+func TestDeliverySimulator_ReassignDeliveryWhileDraining(t *testing.T) {
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	simulator := NewDeliverySimulator(DefaultDeliverySimulatorConfig(), routeGen, newMockLocationPublisher(), newMockStatusPublisher(), nil)
+	defer simulator.Stop()
+
+	ctx := context.Background()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5300, 13.4150)
+
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-1", vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())))
+
+	simulator.mu.Lock()
+	simulator.draining = true
+	simulator.mu.Unlock()
+
+	err = simulator.ReassignDelivery(ctx, "courier-1", "courier-2", "pkg-1")
+	require.ErrorIs(t, err, domain.ErrSimulatorDraining)
+
+	state, exists := simulator.GetDeliveryState("courier-1")
+	require.True(t, exists)
+	assert.Equal(t, "pkg-1", state.CurrentOrder.PackageID(), "the delivery stays with the original courier")
+
+	_, exists = simulator.GetDeliveryState("courier-2")
+	assert.False(t, exists)
+}
+
+func TestDeliverySimulator_TimelineCoversAllPhases(t *testing.T) {
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	config := DeliverySimulatorConfig{
+		UpdateInterval:   10 * time.Millisecond,
+		SpeedKmH:         100.0,
+		TimeMultiplier:   100.0,
+		PickupWaitTime:   20 * time.Millisecond,
+		DeliveryWaitTime: 20 * time.Millisecond,
+		FailureRate:      0.0,
+	}
+
+	simulator := NewDeliverySimulator(config, routeGen, newMockLocationPublisher(), newMockStatusPublisher(), nil)
+	defer simulator.Stop()
+
+	ctx, cancel := context.WithTimeoutCause(context.Background(), 10*time.Second,
+		errors.New("test timeout: Timeline (10s)"))
+	defer cancel()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5201, 13.4051)
+	order := vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())
+
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-1", order))
+
+	require.Eventually(t, func() bool {
+		state, exists := simulator.GetDeliveryState("courier-1")
+		return exists && state.Phase == vo.PhaseIdle
+	}, 5*time.Second, 10*time.Millisecond)
+
+	state, exists := simulator.GetDeliveryState("courier-1")
+	require.True(t, exists)
+
+	phases := make([]vo.DeliveryPhase, 0, len(state.Timeline))
+	for _, record := range state.Timeline {
+		phases = append(phases, record.Phase)
+	}
+
+	assert.Equal(t, []vo.DeliveryPhase{
+		vo.PhaseHeadingToPickup,
+		vo.PhasePickingUp,
+		vo.PhaseHeadingToCustomer,
+		vo.PhaseDelivering,
+	}, phases)
+
+	// Phases are contiguous: each one starts when the previous one ends.
+	for i, record := range state.Timeline {
+		assert.False(t, record.EndedAt.Before(record.StartedAt), "phase %s ends before it starts", record.Phase)
+
+		if i > 0 {
+			assert.Equal(t, state.Timeline[i-1].EndedAt, record.StartedAt)
+		}
+	}
+
+	assert.Equal(t, state.Timeline[len(state.Timeline)-1].EndedAt, state.PhaseStartedAt)
+
+	// The returned timeline is a copy.
+	state.Timeline[0].Phase = vo.PhaseIdle
+
+	fresh, _ := simulator.GetDeliveryState("courier-1")
+	assert.Equal(t, vo.PhaseHeadingToPickup, fresh.Timeline[0].Phase)
+}
+
+func TestDeliverySimulator_SLABreach(t *testing.T) {
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	config := DeliverySimulatorConfig{
+		UpdateInterval:   10 * time.Millisecond,
+		SpeedKmH:         100.0,
+		TimeMultiplier:   100.0,
+		PickupWaitTime:   20 * time.Millisecond,
+		DeliveryWaitTime: 20 * time.Millisecond,
+		FailureRate:      0.0,
+		SLABreachGrace:   time.Minute,
+	}
+
+	statusPub := newMockStatusPublisher()
+
+	simulator := NewDeliverySimulator(config, routeGen, newMockLocationPublisher(), statusPub, nil)
+	defer simulator.Stop()
+
+	ctx, cancel := context.WithTimeoutCause(context.Background(), 10*time.Second,
+		errors.New("test timeout: SLABreach (10s)"))
+	defer cancel()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5201, 13.4051)
+
+	// The delivery window closed an hour ago; the grace period does not cover it
+	deadline := time.Now().Add(-time.Hour)
+	late := vo.NewDeliveryOrder("order-1", "pkg-late", pickup, delivery, time.Now()).WithDeliveryDeadline(deadline)
+	// Overdue by less than the grace period
+	graced := vo.NewDeliveryOrder("order-2", "pkg-graced", pickup, delivery, time.Now()).WithDeliveryDeadline(time.Now().Add(-time.Second))
+	onTime := vo.NewDeliveryOrder("order-3", "pkg-on-time", pickup, delivery, time.Now()).WithDeliveryDeadline(time.Now().Add(time.Hour))
+	noWindow := vo.NewDeliveryOrder("order-4", "pkg-no-window", pickup, delivery, time.Now())
+
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-1", late))
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-2", graced))
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-3", onTime))
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-4", noWindow))
+
+	require.Eventually(t, func() bool {
+		return len(statusPub.GetDeliveryEvents()) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	breached := statusPub.GetBreachedEvents()
+	require.Len(t, breached, 1)
+
+	event := breached[0]
+	assert.Equal(t, "pkg-late", event.PackageID)
+	assert.Equal(t, "courier-1", event.CourierID)
+	assert.True(t, deadline.UTC().Equal(event.DeliveryDeadline))
+	assert.InDelta(t, event.DeliveredAt.Sub(deadline).Seconds(), event.OverdueSeconds, 1e-6)
+	assert.GreaterOrEqual(t, event.OverdueSeconds, time.Hour.Seconds())
+
+	var delivered kafka.DeliverOrderEvent
+
+	for _, deliveryEvent := range statusPub.GetDeliveryEvents() {
+		if deliveryEvent.PackageID == "pkg-late" {
+			delivered = deliveryEvent
+		}
+	}
+
+	assert.Equal(t, delivered.DeliveredAt, event.DeliveredAt)
+	assert.Equal(t, delivered.Sequence+1, event.Sequence, "the breach follows the delivery in the courier's sequence")
+}
+
+// retryTestSeed makes the first outcome draw fail (0.45 < FailureRate) and the one after the reason
+// sample succeed (0.88), so the first attempt fails and the retry delivers.
+const retryTestSeed = 8
+
+func newRetryTestSimulator(t *testing.T, config DeliverySimulatorConfig, statusPub kafka.StatusPublisher) *DeliverySimulator {
+	t.Helper()
+
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(routeGen.Close)
+
+	config.UpdateInterval = 10 * time.Millisecond
+	config.SpeedKmH = 100.0
+	config.TimeMultiplier = 100.0
+	config.PickupWaitTime = 20 * time.Millisecond
+	config.DeliveryWaitTime = 20 * time.Millisecond
+	config.RetryDelay = 50 * time.Millisecond
+
+	simulator := NewDeliverySimulator(config, routeGen, newMockLocationPublisher(), statusPub, nil)
+	t.Cleanup(simulator.Stop)
+
+	return simulator
+}
+
+func TestDeliverySimulator_RetriesRetryableFailure(t *testing.T) {
+	reasons, err := NewFailureReasonDistribution(map[kafka.NotDeliveredReason]float64{
+		kafka.ReasonCustomerNotAvailable: 1,
+	})
+	require.NoError(t, err)
+
+	statusPub := newMockStatusPublisher()
+	simulator := newRetryTestSimulator(t, DeliverySimulatorConfig{
+		FailureRate:      0.5,
+		FailureReasons:   reasons,
+		RetryMaxAttempts: 2,
+	}, statusPub)
+	simulator.rng = rand.New(rand.NewSource(retryTestSeed)) //nolint:gosec // deterministic test randomness
+
+	ctx, cancel := context.WithTimeoutCause(context.Background(), 10*time.Second,
+		errors.New("test timeout: RetriesRetryableFailure (10s)"))
+	defer cancel()
+
+	order := vo.NewDeliveryOrder("order-1", "pkg-retry", vo.MustNewLocation(52.5200, 13.4050), vo.MustNewLocation(52.5201, 13.4051), time.Now())
+	require.NoError(t, simulator.StartDelivery(ctx, "courier-1", order))
+
+	require.Eventually(t, func() bool {
+		return len(statusPub.GetDeliveryEvents()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	retries := statusPub.GetRetryEvents()
+	require.Len(t, retries, 1, "the failed first attempt is retried instead of resolved")
+
+	retry := retries[0]
+	assert.Equal(t, "pkg-retry", retry.PackageID)
+	assert.Equal(t, "courier-1", retry.CourierID)
+	assert.Equal(t, kafka.ReasonCustomerNotAvailable, retry.Reason)
+	assert.Equal(t, 1, retry.Attempt)
+	assert.False(t, retry.RetryAt.Before(retry.FailedAt))
+
+	delivery := statusPub.GetDeliveryEvents()[0]
+	assert.Equal(t, kafka.DeliveryStatusDelivered, delivery.Status, "the retry delivers")
+	assert.Less(t, retry.Sequence, delivery.Sequence)
+
+	state, ok := simulator.GetDeliveryState("courier-1")
+	require.True(t, ok)
+	assert.Equal(t, vo.PhaseIdle, state.Phase)
+	assert.Equal(t, 1, state.FailedAttempts)
+}
+
+func TestDeliverySimulator_RetryFinalOutcome(t *testing.T) {
+	tests := []struct {
+		name        string
+		reason      kafka.NotDeliveredReason
+		wantRetries int
+	}{
+		{name: "wrong address is not retried", reason: kafka.ReasonWrongAddress, wantRetries: 0},
+		{name: "retries are used up", reason: kafka.ReasonCustomerNotAvailable, wantRetries: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons, err := NewFailureReasonDistribution(map[kafka.NotDeliveredReason]float64{tt.reason: 1})
+			require.NoError(t, err)
+
+			statusPub := newMockStatusPublisher()
+			simulator := newRetryTestSimulator(t, DeliverySimulatorConfig{
+				FailureRate:      1.0,
+				FailureReasons:   reasons,
+				RetryMaxAttempts: 2,
+			}, statusPub)
+
+			order := vo.NewDeliveryOrder("order-1", "pkg-1", vo.MustNewLocation(52.5200, 13.4050), vo.MustNewLocation(52.5201, 13.4051), time.Now())
+			require.NoError(t, simulator.StartDelivery(t.Context(), "courier-1", order))
+
+			require.Eventually(t, func() bool {
+				return len(statusPub.GetDeliveryEvents()) == 1
+			}, 5*time.Second, 10*time.Millisecond)
+
+			delivery := statusPub.GetDeliveryEvents()[0]
+			assert.Equal(t, kafka.DeliveryStatusNotDelivered, delivery.Status)
+			assert.Equal(t, tt.reason, delivery.Reason)
+
+			retries := statusPub.GetRetryEvents()
+			require.Len(t, retries, tt.wantRetries)
+
+			for i, retry := range retries {
+				assert.Equal(t, i+1, retry.Attempt)
+				assert.Less(t, retry.Sequence, delivery.Sequence)
+			}
+		})
+	}
+}
+
+func TestDeliverySimulator_SequencePerCourier(t *testing.T) {
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Timeout:     1 * time.Second,
+	})
+	require.NoError(t, err)
+
+	defer routeGen.Close()
+
+	config := DeliverySimulatorConfig{
+		UpdateInterval:   10 * time.Millisecond,
+		SpeedKmH:         100.0,
+		TimeMultiplier:   100.0,
+		PickupWaitTime:   20 * time.Millisecond,
+		DeliveryWaitTime: 20 * time.Millisecond,
+		FailureRate:      0.0,
+	}
+
+	locationPub := newMockLocationPublisher()
+	statusPub := newMockStatusPublisher()
+	simulator := NewDeliverySimulator(config, routeGen, locationPub, statusPub, nil)
+	defer simulator.Stop()
+
+	ctx, cancel := context.WithTimeoutCause(context.Background(), 10*time.Second,
+		errors.New("test timeout: Sequence (10s)"))
+	defer cancel()
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5201, 13.4051)
+	couriers := []string{"courier-1", "courier-2"}
+
+	for i, courierID := range couriers {
+		order := vo.NewDeliveryOrder(fmt.Sprintf("order-%d", i), fmt.Sprintf("pkg-%d", i), pickup, delivery, time.Now())
+		require.NoError(t, simulator.StartDelivery(ctx, courierID, order))
+	}
+
+	require.Eventually(t, func() bool {
+		return len(statusPub.GetDeliveryEvents()) == len(couriers)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for _, courierID := range couriers {
+		var locationSequences []uint64
+
+		for _, event := range locationPub.GetEvents() {
+			if event.CourierID == courierID {
+				locationSequences = append(locationSequences, event.Sequence)
+			}
+		}
+
+		require.NotEmpty(t, locationSequences)
+
+		for i := 1; i < len(locationSequences); i++ {
+			assert.Greater(t, locationSequences[i], locationSequences[i-1], "%s location events out of order", courierID)
+		}
+
+		var pickupSequence, deliverySequence uint64
+
+		for _, event := range statusPub.GetPickupEvents() {
+			if event.CourierID == courierID {
+				pickupSequence = event.Sequence
+			}
+		}
+
+		for _, event := range statusPub.GetDeliveryEvents() {
+			if event.CourierID == courierID {
+				deliverySequence = event.Sequence
+			}
+		}
+
+		// Location and status events share one gap-free sequence per courier, starting at 1
+		all := append(slices.Clone(locationSequences), pickupSequence, deliverySequence)
+		slices.Sort(all)
+
+		for i, sequence := range all {
+			assert.Equal(t, uint64(i+1), sequence, "%s sequence", courierID)
+		}
+
+		assert.Less(t, pickupSequence, deliverySequence)
+		assert.Equal(t, all[len(all)-1], deliverySequence, "%s delivery is its last event", courierID)
+	}
 }
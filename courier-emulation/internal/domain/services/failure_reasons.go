@@ -0,0 +1,109 @@
+package services
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"math/rand"
+	"slices"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
+)
+
+// DefaultFailureReasonWeights is the relative frequency of NOT_DELIVERED reasons in simulation.
+// Absent customers dominate real failed deliveries; access problems are rare.
+func DefaultFailureReasonWeights() map[kafka.NotDeliveredReason]float64 {
+	return map[kafka.NotDeliveredReason]float64{
+		kafka.ReasonCustomerNotAvailable: 0.5,
+		kafka.ReasonWrongAddress:         0.2,
+		kafka.ReasonCustomerRefused:      0.2,
+		kafka.ReasonAccessDenied:         0.1,
+	}
+}
+
+// FailureReasonDistribution samples NOT_DELIVERED reasons by weight.
+// It only decides which reason a failure has; whether a delivery fails is governed by FailureRate.
+// The zero value is empty; NewDeliverySimulator replaces it with DefaultFailureReasonDistribution.
+type FailureReasonDistribution struct {
+	reasons    []kafka.NotDeliveredReason
+	cumulative []float64
+}
+
+// NewFailureReasonDistribution validates the weights and builds a distribution.
+// Weights are relative and need not sum to 1; zero-weight reasons are never sampled.
+func NewFailureReasonDistribution(weights map[kafka.NotDeliveredReason]float64) (FailureReasonDistribution, error) {
+	var (
+		dist  FailureReasonDistribution
+		total float64
+	)
+
+	// Sorted order keeps sampling reproducible for a seeded rng.
+	for _, reason := range slices.Sorted(maps.Keys(weights)) {
+		weight := weights[reason]
+
+		if !reason.IsValid() {
+			return FailureReasonDistribution{}, fmt.Errorf("%w: got=%q", kafka.ErrInvalidReason, reason)
+		}
+
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return FailureReasonDistribution{}, fmt.Errorf("%w: %s=%f", domain.ErrInvalidFailureWeight, reason, weight)
+		}
+
+		if weight == 0 {
+			continue
+		}
+
+		total += weight
+		dist.reasons = append(dist.reasons, reason)
+		dist.cumulative = append(dist.cumulative, total)
+	}
+
+	if len(dist.reasons) == 0 {
+		return FailureReasonDistribution{}, domain.ErrNoFailureReasons
+	}
+
+	return dist, nil
+}
+
+// DefaultFailureReasonDistribution returns the distribution of DefaultFailureReasonWeights.
+func DefaultFailureReasonDistribution() FailureReasonDistribution {
+	dist, err := NewFailureReasonDistribution(DefaultFailureReasonWeights())
+	if err != nil {
+		panic(fmt.Sprintf("invalid default failure reasons: %v", err))
+	}
+
+	return dist
+}
+
+// IsZero reports whether the distribution was never built.
+func (d FailureReasonDistribution) IsZero() bool {
+	return len(d.reasons) == 0
+}
+
+// Sample draws a reason with probability proportional to its weight.
+func (d FailureReasonDistribution) Sample(rng *rand.Rand) kafka.NotDeliveredReason {
+	target := rng.Float64() * d.cumulative[len(d.cumulative)-1]
+
+	idx, _ := slices.BinarySearch(d.cumulative, target)
+	// BinarySearch returns the first bucket whose upper bound is >= target; an exact hit belongs to the next one.
+	if idx < len(d.cumulative)-1 && d.cumulative[idx] == target {
+		idx++
+	}
+
+	return d.reasons[idx]
+}
+
+// retryableReasons are the NOT_DELIVERED reasons a later attempt can overcome: the customer may be home
+// and the door open next time. A wrong address, a refusal or a damaged package stays that way.
+var retryableReasons = map[kafka.NotDeliveredReason]struct{}{
+	kafka.ReasonCustomerNotAvailable: {},
+	kafka.ReasonAccessDenied:         {},
+}
+
+// IsRetryableReason reports whether a delivery that failed for reason is worth another attempt.
+func IsRetryableReason(reason kafka.NotDeliveredReason) bool {
+	_, ok := retryableReasons[reason]
+
+	return ok
+}
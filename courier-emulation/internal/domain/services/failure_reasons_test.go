@@ -0,0 +1,117 @@
+package services
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
+)
+
+// distributionSamples is large enough for a ±1% tolerance to hold comfortably.
+const distributionSamples = 100_000
+
+func TestFailureReasonDistribution_MatchesWeights(t *testing.T) {
+	weights := map[kafka.NotDeliveredReason]float64{
+		kafka.ReasonCustomerNotAvailable: 6,
+		kafka.ReasonWrongAddress:         3,
+		kafka.ReasonAccessDenied:         1,
+		kafka.ReasonCustomerRefused:      0,
+	}
+
+	dist, err := NewFailureReasonDistribution(weights)
+	require.NoError(t, err)
+
+	rng := rand.New(rand.NewSource(42)) //nolint:gosec // deterministic test randomness
+	counts := map[kafka.NotDeliveredReason]int{}
+
+	for range distributionSamples {
+		counts[dist.Sample(rng)]++
+	}
+
+	assert.NotContains(t, counts, kafka.ReasonCustomerRefused, "zero weight is never sampled")
+	assert.InDelta(t, 0.6, float64(counts[kafka.ReasonCustomerNotAvailable])/distributionSamples, 0.01)
+	assert.InDelta(t, 0.3, float64(counts[kafka.ReasonWrongAddress])/distributionSamples, 0.01)
+	assert.InDelta(t, 0.1, float64(counts[kafka.ReasonAccessDenied])/distributionSamples, 0.01)
+}
+
+func TestDeliverySimulator_DrawDeliveryOutcomeKeepsFailureRate(t *testing.T) {
+	dist, err := NewFailureReasonDistribution(map[kafka.NotDeliveredReason]float64{
+		kafka.ReasonCustomerNotAvailable: 3,
+		kafka.ReasonWrongAddress:         1,
+	})
+	require.NoError(t, err)
+
+	config := DefaultDeliverySimulatorConfig()
+	config.FailureRate = 0.2
+	config.FailureReasons = dist
+
+	simulator := NewDeliverySimulator(config, nil, nil, nil, nil)
+	simulator.rng = rand.New(rand.NewSource(7)) //nolint:gosec // deterministic test randomness
+
+	counts := map[kafka.NotDeliveredReason]int{}
+	failed := 0
+
+	for range distributionSamples {
+		delivered, reason := simulator.drawDeliveryOutcome()
+		if delivered {
+			assert.Empty(t, reason)
+			continue
+		}
+
+		failed++
+		counts[reason]++
+	}
+
+	assert.InDelta(t, 0.2, float64(failed)/distributionSamples, 0.01)
+	assert.InDelta(t, 0.15, float64(counts[kafka.ReasonCustomerNotAvailable])/distributionSamples, 0.01)
+	assert.InDelta(t, 0.05, float64(counts[kafka.ReasonWrongAddress])/distributionSamples, 0.01)
+}
+
+func TestNewFailureReasonDistribution_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights map[kafka.NotDeliveredReason]float64
+		wantErr error
+	}{
+		{"empty", map[kafka.NotDeliveredReason]float64{}, domain.ErrNoFailureReasons},
+		{"all zero", map[kafka.NotDeliveredReason]float64{kafka.ReasonOther: 0}, domain.ErrNoFailureReasons},
+		{"negative", map[kafka.NotDeliveredReason]float64{kafka.ReasonOther: -1}, domain.ErrInvalidFailureWeight},
+		{"NaN", map[kafka.NotDeliveredReason]float64{kafka.ReasonOther: math.NaN()}, domain.ErrInvalidFailureWeight},
+		{"infinite", map[kafka.NotDeliveredReason]float64{kafka.ReasonOther: math.Inf(1)}, domain.ErrInvalidFailureWeight},
+		{"unknown reason", map[kafka.NotDeliveredReason]float64{"TEA_BREAK": 1}, kafka.ErrInvalidReason},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFailureReasonDistribution(tt.weights)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestIsRetryableReason(t *testing.T) {
+	tests := []struct {
+		reason kafka.NotDeliveredReason
+		want   bool
+	}{
+		{kafka.ReasonCustomerNotAvailable, true},
+		{kafka.ReasonAccessDenied, true},
+		{kafka.ReasonWrongAddress, false},
+		{kafka.ReasonCustomerRefused, false},
+		{kafka.ReasonPackageDamaged, false},
+		{kafka.ReasonOther, false},
+		{kafka.ReasonCancelled, false},
+		{kafka.ReasonUnroutable, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.reason), func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryableReason(tt.reason))
+		})
+	}
+}
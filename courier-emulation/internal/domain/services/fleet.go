@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+)
+
+// Fleet is the single entry point to both simulations: couriers roam free in the CourierSimulator
+// and take orders in the DeliverySimulator. An idle free-roaming courier that is assigned an order
+// moves over to the delivery simulation as it is, keeping its location and battery.
+type Fleet struct {
+	couriers   *CourierSimulator
+	deliveries *DeliverySimulator
+}
+
+// NewFleet creates a fleet over the free-roaming and the delivery simulation.
+func NewFleet(couriers *CourierSimulator, deliveries *DeliverySimulator) *Fleet {
+	return &Fleet{
+		couriers:   couriers,
+		deliveries: deliveries,
+	}
+}
+
+// StartDelivery starts the delivery of order by courierID. An idle free-roaming courier heads to
+// pickup from where it stopped; any other courier is started by the DeliverySimulator as a new one.
+// A free-roaming courier still on its route fails with domain.ErrCourierNotIdle.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func (f *Fleet) StartDelivery(ctx context.Context, courierID string, order vo.DeliveryOrder) error {
+	err := f.AssignOrder(ctx, courierID, order)
+	if errors.Is(err, domain.ErrCourierNotFound) {
+		return f.deliveries.StartDelivery(ctx, courierID, order)
+	}
+
+	return err
+}
+
+// AssignOrder moves an idle free-roaming courier into the delivery of order, starting from its
+// current location with the battery it has left. The courier stays in the free-roaming simulation
+// when the delivery does not start, including an unroutable one.
+// It returns domain.ErrCourierNotFound when the courier is not free-roaming and domain.ErrCourierNotIdle
+// when it is still on its route.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func (f *Fleet) AssignOrder(ctx context.Context, courierID string, order vo.DeliveryOrder) error {
+	courier, err := f.couriers.takeIdleCourier(courierID)
+	if err != nil {
+		return err
+	}
+
+	// The courier's events keep one sequence across both simulations
+	f.deliveries.resumeSequence(courierID, f.couriers.lastSequence(courierID))
+
+	err = f.deliveries.StartDeliveryFrom(ctx, courierID, order, courier.CurrentLocation, courier.BatteryPercent)
+	if err != nil {
+		f.couriers.returnCourier(courier, f.deliveries.lastSequence(courierID))
+
+		return fmt.Errorf("start delivery: %w", err)
+	}
+
+	return nil
+}
+
+// AssignNearest assigns order to the idle free-roaming courier nearest to its pickup and returns
+// the courier's ID. It returns domain.ErrCourierNotFound when no courier is idle.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func (f *Fleet) AssignNearest(ctx context.Context, order vo.DeliveryOrder) (string, error) {
+	courierID, ok := f.couriers.NearestIdleCourier(order.PickupLocation())
+	if !ok {
+		return "", fmt.Errorf("no idle courier: %w", domain.ErrCourierNotFound)
+	}
+
+	err := f.AssignOrder(ctx, courierID, order)
+	if err != nil {
+		return "", err
+	}
+
+	return courierID, nil
+}
+
+// CancelDelivery cancels the courier's in-flight delivery of packageID, see DeliverySimulator.CancelDelivery.
+func (f *Fleet) CancelDelivery(ctx context.Context, courierID, packageID string) error {
+	return f.deliveries.CancelDelivery(ctx, courierID, packageID)
+}
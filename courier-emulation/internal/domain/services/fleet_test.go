@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+)
+
+func newTestFleet(t *testing.T, osrmURL string) (*Fleet, *CourierSimulator, *DeliverySimulator) {
+	t.Helper()
+
+	routeGen, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: osrmURL,
+		Timeout:     time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(routeGen.Close)
+
+	couriers := NewCourierSimulator(DefaultCourierSimulatorConfig(), routeGen, newMockLocationPublisher())
+	t.Cleanup(couriers.Stop)
+
+	deliveries := NewDeliverySimulator(DefaultDeliverySimulatorConfig(), routeGen, newMockLocationPublisher(), newMockStatusPublisher(), nil)
+	t.Cleanup(deliveries.Stop)
+
+	return NewFleet(couriers, deliveries), couriers, deliveries
+}
+
+func TestFleet_AssignOrderTakesOverIdleCourier(t *testing.T) {
+	fleet, couriers, deliveries := newTestFleet(t, "http://localhost:5000")
+
+	roamedTo := vo.MustNewLocation(52.5100, 13.3900)
+	couriers.couriers = map[string]*CourierState{
+		"idle":   {ID: "idle", Status: vo.CourierStatusIdle, Movement: Movement{CurrentLocation: roamedTo}, BatteryPercent: 80},
+		"moving": {ID: "moving", Status: vo.CourierStatusMoving, Movement: Movement{CurrentLocation: roamedTo}, BatteryPercent: 90},
+	}
+	couriers.sequences["idle"] = 7
+
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	order := vo.NewDeliveryOrder("order-1", "pkg-1", pickup, vo.MustNewLocation(52.5300, 13.4150), time.Now())
+
+	courierID, err := fleet.AssignNearest(context.Background(), order)
+	require.NoError(t, err)
+	assert.Equal(t, "idle", courierID)
+
+	// The courier heads to pickup from where it stopped roaming, with the battery it had left
+	state, exists := deliveries.GetDeliveryState("idle")
+	require.True(t, exists)
+	assert.Equal(t, vo.PhaseHeadingToPickup, state.Phase)
+	assert.InDelta(t, roamedTo.Latitude(), state.CurrentLocation.Latitude(), 1e-5)
+	assert.InDelta(t, roamedTo.Longitude(), state.CurrentLocation.Longitude(), 1e-5)
+	assert.InDelta(t, 80, state.BatteryPercent, 0.01)
+	assert.Equal(t, uint64(7), deliveries.lastSequence("idle"), "the courier's event sequence carries on")
+
+	_, roaming := couriers.GetCourierState("idle")
+	assert.False(t, roaming, "the courier left the free-roaming simulation")
+
+	// A courier still on its route cannot take an order
+	err = fleet.StartDelivery(context.Background(), "moving", order)
+	require.ErrorIs(t, err, domain.ErrCourierNotIdle)
+
+	// No courier left idle
+	_, err = fleet.AssignNearest(context.Background(), order)
+	require.ErrorIs(t, err, domain.ErrCourierNotFound)
+
+	// Couriers unknown to the free-roaming simulation start their delivery near pickup
+	require.NoError(t, fleet.StartDelivery(context.Background(), "courier-2", order))
+
+	state, exists = deliveries.GetDeliveryState("courier-2")
+	require.True(t, exists)
+	assert.InDelta(t, fullBatteryPercent, state.BatteryPercent, 0.01)
+}
+
+func TestFleet_AssignOrderUnroutableKeepsCourierRoaming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(routeServerResponse{Code: "NoRoute"}) //nolint:errcheck // test mock response
+	}))
+	defer server.Close()
+
+	fleet, couriers, deliveries := newTestFleet(t, server.URL)
+
+	couriers.couriers = map[string]*CourierState{
+		"idle": {ID: "idle", Status: vo.CourierStatusIdle, Movement: Movement{CurrentLocation: vo.MustNewLocation(52.5100, 13.3900)}},
+	}
+
+	order := vo.NewDeliveryOrder("order-1", "pkg-1", vo.MustNewLocation(52.5200, 13.4050), vo.MustNewLocation(52.5300, 13.4150), time.Now())
+
+	err := fleet.AssignOrder(context.Background(), "idle", order)
+	require.ErrorIs(t, err, domain.ErrUnroutable)
+
+	_, delivering := deliveries.GetDeliveryState("idle")
+	assert.False(t, delivering)
+
+	assert.Equal(t, []string{"idle"}, couriers.IdleCouriers(), "the courier is free to take another order")
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+)
+
+// metersPerKm converts the noise radius into the unit of vo.EarthRadiusKm.
+const metersPerKm = 1000.0
+
+// applyGPSNoise offsets location by a random vector uniformly distributed over a disc
+// of radiusMeters, imitating GPS jitter. A non-positive radius returns location unchanged.
+func applyGPSNoise(rng *rand.Rand, location vo.Location, radiusMeters float64) vo.Location {
+	if radiusMeters <= 0 {
+		return location
+	}
+
+	// sqrt keeps the points uniform over the disc instead of clustering at its center.
+	distanceKm := radiusMeters / metersPerKm * math.Sqrt(rng.Float64())
+	bearing := 2 * math.Pi * rng.Float64()
+
+	noisy, err := offsetLocation(location, distanceKm, bearing)
+	if err != nil {
+		// Jitter across a pole or the antimeridian is not worth modelling.
+		return location
+	}
+
+	return noisy
+}
+
+// offsetLocation moves location distanceKm along bearing (radians clockwise from north),
+// using a flat-earth approximation that holds for the short distances of jitter and start offsets.
+func offsetLocation(location vo.Location, distanceKm, bearing float64) (vo.Location, error) {
+	angular := distanceKm / vo.EarthRadiusKm
+	lat := location.Latitude() + angular*math.Cos(bearing)*180/math.Pi
+	lon := location.Longitude() + angular*math.Sin(bearing)/math.Cos(location.Latitude()*math.Pi/180)*180/math.Pi
+
+	offset, err := vo.NewLocation(lat, lon)
+	if err != nil {
+		return vo.Location{}, fmt.Errorf("offset location: %w", err)
+	}
+
+	return offset, nil
+}
+
+// minGPSAccuracyMeters is the accuracy a consumer phone GPS reports even without simulated jitter.
+const minGPSAccuracyMeters = 5.0
+
+// reportedAccuracy is the accuracy radius published with a location jittered by noiseMeters.
+func reportedAccuracy(noiseMeters float64) float64 {
+	return max(noiseMeters, minGPSAccuracyMeters)
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+)
+
+func TestApplyGPSNoise_StaysWithinRadius(t *testing.T) {
+	rng := rand.New(rand.NewSource(42)) //nolint:gosec // deterministic test randomness
+	truePoint := vo.MustNewLocation(52.5200, 13.4050)
+
+	const radiusMeters = 25.0
+
+	moved := 0
+
+	for range 1000 {
+		noisy := applyGPSNoise(rng, truePoint, radiusMeters)
+
+		distanceMeters := truePoint.DistanceTo(noisy) * metersPerKm
+		assert.LessOrEqual(t, distanceMeters, radiusMeters+0.01)
+
+		if distanceMeters > 0 {
+			moved++
+		}
+	}
+
+	assert.Positive(t, moved, "noise must actually move points")
+}
+
+func TestApplyGPSNoise_ZeroRadiusKeepsLocation(t *testing.T) {
+	rng := rand.New(rand.NewSource(42)) //nolint:gosec // deterministic test randomness
+	truePoint := vo.MustNewLocation(52.5200, 13.4050)
+
+	assert.Equal(t, truePoint, applyGPSNoise(rng, truePoint, 0))
+}
+
+func TestCourierSimulator_GPSNoiseOnlyAffectsPublishedLocation(t *testing.T) {
+	publisher := newMockLocationPublisher()
+	config := DefaultCourierSimulatorConfig()
+	config.UpdateInterval = 1 * time.Hour // Updates are driven manually below
+	config.GPSNoiseMeters = 15.0
+
+	simulator := NewCourierSimulator(config, nil, publisher)
+	simulator.rng = rand.New(rand.NewSource(7)) //nolint:gosec // deterministic test randomness
+
+	defer simulator.Stop()
+
+	origin := vo.MustNewLocation(52.5200, 13.4050)
+	destination := vo.MustNewLocation(52.5210, 13.4060)
+	route, err := vo.NewRoute("route", origin, destination, vo.MustNewPolyline("_c`|IgpvpAaB{A"), 150, 30*time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, simulator.StartCourierWithRoute(ctx, "courier-1", route))
+
+	simulator.mu.Lock()
+	simulator.couriers["courier-1"].LastUpdateAt = time.Now().Add(-5 * time.Second)
+	simulator.mu.Unlock()
+
+	require.NoError(t, simulator.updateCourierPosition(ctx, "courier-1"))
+
+	state, exists := simulator.GetCourierState("courier-1")
+	require.True(t, exists)
+
+	// The internal position stays exactly on the route segment.
+	points, err := route.Points()
+	require.NoError(t, err)
+	assert.InDelta(t, points[0].DistanceTo(points[1]),
+		points[0].DistanceTo(state.CurrentLocation)+state.CurrentLocation.DistanceTo(points[1]), 1e-6)
+
+	events := publisher.GetEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, config.GPSNoiseMeters, events[0].Accuracy)
+	assert.NotEqual(t, state.CurrentLocation, events[0].Location)
+	assert.LessOrEqual(t, state.CurrentLocation.DistanceTo(events[0].Location)*metersPerKm, config.GPSNoiseMeters+0.01)
+}
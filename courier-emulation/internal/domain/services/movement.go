@@ -0,0 +1,186 @@
+//nolint:mnd // Bearing math keeps its conversion constants inline for readability.
+package services
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+)
+
+// Movement is a courier's progress along the points of its current route.
+// Both the free-roaming CourierState and the order-driven DeliveryState move through it.
+type Movement struct {
+	CurrentLocation vo.Location
+	CurrentRoute    *vo.Route
+	RoutePoints     []vo.Location
+	CurrentPointIdx int
+	Speed           float64 // km/h
+	LastUpdateAt    time.Time
+}
+
+// newMovement places a courier moving at speedKmH on the first point of a route.
+//
+//nolint:gocritic // Route is an immutable value object; it is copied so the movement owns it.
+func newMovement(route vo.Route, points []vo.Location, speedKmH float64, now time.Time) Movement {
+	return Movement{
+		CurrentLocation: points[0],
+		CurrentRoute:    &route,
+		RoutePoints:     points,
+		Speed:           speedKmH,
+		LastUpdateAt:    now,
+	}
+}
+
+// advance moves the courier as far as it travels at Speed between LastUpdateAt and now, with elapsed
+// time scaled by timeMultiplier, and reports whether it reached the end of the route.
+// A looping courier never reaches the end: it turns around there instead.
+func (m *Movement) advance(now time.Time, timeMultiplier float64, loop bool) bool {
+	distanceKm := (m.Speed / secondsPerHour) * now.Sub(m.LastUpdateAt).Seconds() * timeMultiplier
+	completed := m.advanceBy(distanceKm, loop)
+	m.LastUpdateAt = now
+
+	return completed
+}
+
+// advanceBy moves the courier distanceKm along the route and reports whether it reached the end.
+// A looping courier turns around at most once per call, so a zero-length route cannot spin forever,
+// and turns around when it stops at the end so its heading faces back along the route.
+// A courier that reaches the end of a route otherwise stops exactly on its last point.
+func (m *Movement) advanceBy(distanceKm float64, loop bool) bool {
+	wrapped := false
+
+	for distanceKm > 0 {
+		if m.atEnd() {
+			if !loop || wrapped {
+				break
+			}
+
+			m.reverse()
+
+			wrapped = true
+		}
+
+		nextPoint := m.RoutePoints[m.CurrentPointIdx+1]
+		distanceToNext := m.CurrentLocation.DistanceTo(nextPoint)
+
+		if distanceKm >= distanceToNext {
+			m.CurrentLocation = nextPoint
+			m.CurrentPointIdx++
+			distanceKm -= distanceToNext
+		} else {
+			m.CurrentLocation = interpolateLocation(m.CurrentLocation, nextPoint, distanceKm/distanceToNext)
+			distanceKm = 0
+		}
+	}
+
+	if !m.atEnd() {
+		return false
+	}
+
+	if loop {
+		m.reverse()
+
+		return false
+	}
+
+	m.CurrentLocation = m.RoutePoints[len(m.RoutePoints)-1]
+
+	return true
+}
+
+// atEnd reports whether the courier passed the last segment of the route.
+func (m *Movement) atEnd() bool {
+	return m.CurrentPointIdx >= len(m.RoutePoints)-1
+}
+
+// reverse turns the courier around at the end of its route so it heads back to the origin.
+func (m *Movement) reverse() {
+	// Reverse a copy: state snapshots share the old slice.
+	points := slices.Clone(m.RoutePoints)
+	slices.Reverse(points)
+
+	m.RoutePoints = points
+	m.CurrentPointIdx = 0
+}
+
+// heading returns the bearing towards the next route point, or 0 at the end of the route.
+func (m *Movement) heading() float64 {
+	if m.atEnd() {
+		return 0
+	}
+
+	return calculateHeading(m.CurrentLocation, m.RoutePoints[m.CurrentPointIdx+1])
+}
+
+// routeID returns the ID of the current route, or "" when there is none.
+func (m *Movement) routeID() string {
+	if m.CurrentRoute == nil {
+		return ""
+	}
+
+	return m.CurrentRoute.ID()
+}
+
+// progress returns how far along its route points the courier is, as a percentage of the
+// total route distance. A zero-length route counts as complete once the last point is reached.
+func (m *Movement) progress() float64 {
+	points := m.RoutePoints
+	if len(points) < minimalRoutePoints {
+		return 0
+	}
+
+	lastIdx := len(points) - 1
+	idx := min(max(m.CurrentPointIdx, 0), lastIdx)
+
+	var total, covered float64
+
+	for i := range lastIdx {
+		segment := points[i].DistanceTo(points[i+1])
+		total += segment
+
+		if i < idx {
+			covered += segment
+		}
+	}
+
+	if idx < lastIdx {
+		covered += points[idx].DistanceTo(m.CurrentLocation)
+	}
+
+	if total == 0 {
+		if idx == lastIdx {
+			return 100
+		}
+
+		return 0
+	}
+
+	return min(covered/total, 1) * 100
+}
+
+// interpolateLocation calculates a point between two locations based on ratio (0-1).
+func interpolateLocation(from, to vo.Location, ratio float64) vo.Location {
+	lat := from.Latitude() + (to.Latitude()-from.Latitude())*ratio
+	lon := from.Longitude() + (to.Longitude()-from.Longitude())*ratio
+
+	return vo.MustNewLocation(lat, lon)
+}
+
+// calculateHeading calculates the heading (bearing) between two points in degrees.
+func calculateHeading(from, to vo.Location) float64 {
+	lat1 := from.Latitude() * math.Pi / 180
+	lat2 := to.Latitude() * math.Pi / 180
+	dLon := (to.Longitude() - from.Longitude()) * math.Pi / 180
+
+	x := math.Sin(dLon) * math.Cos(lat2)
+	y := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+
+	heading := math.Atan2(x, y) * 180 / math.Pi
+	if heading < 0 {
+		heading += 360
+	}
+
+	return heading
+}
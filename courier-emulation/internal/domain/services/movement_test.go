@@ -0,0 +1,176 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+)
+
+// legacyPosition is the route position as the simulators kept it before they shared Movement.
+type legacyPosition struct {
+	location vo.Location
+	points   []vo.Location
+	idx      int
+}
+
+// legacyCourierStep is the step of CourierSimulator.updateCourierPosition before Movement, kept
+// verbatim as the reference the shared movement is checked against.
+func legacyCourierStep(courier legacyPosition, distanceToTravel float64, loopRoutes bool) (legacyPosition, bool, float64) {
+	reverse := func() {
+		points := slices.Clone(courier.points)
+		slices.Reverse(points)
+
+		courier.points = points
+		courier.idx = 0
+	}
+
+	wrapped := false
+
+	for distanceToTravel > 0 {
+		if courier.idx >= len(courier.points)-1 {
+			if !loopRoutes || wrapped {
+				break
+			}
+
+			reverse()
+
+			wrapped = true
+		}
+
+		nextPoint := courier.points[courier.idx+1]
+		distanceToNext := courier.location.DistanceTo(nextPoint)
+
+		if distanceToTravel >= distanceToNext {
+			courier.location = nextPoint
+			courier.idx++
+			distanceToTravel -= distanceToNext
+		} else {
+			ratio := distanceToTravel / distanceToNext
+			courier.location = interpolateLocation(courier.location, nextPoint, ratio)
+			distanceToTravel = 0
+		}
+	}
+
+	idle := false
+
+	if courier.idx >= len(courier.points)-1 {
+		if loopRoutes {
+			reverse()
+		} else {
+			idle = true
+			courier.location = courier.points[len(courier.points)-1]
+		}
+	}
+
+	heading := 0.0
+	if courier.idx < len(courier.points)-1 {
+		heading = calculateHeading(courier.location, courier.points[courier.idx+1])
+	}
+
+	return courier, idle, heading
+}
+
+// legacyDeliveryStep is the step of DeliverySimulator.handleMovingPhase before Movement, kept
+// verbatim as the reference the shared movement is checked against.
+func legacyDeliveryStep(state legacyPosition, distanceToTravel float64) (legacyPosition, bool, float64) {
+	for distanceToTravel > 0 && state.idx < len(state.points)-1 {
+		nextPoint := state.points[state.idx+1]
+		distanceToNext := state.location.DistanceTo(nextPoint)
+
+		if distanceToTravel >= distanceToNext {
+			state.location = nextPoint
+			state.idx++
+			distanceToTravel -= distanceToNext
+		} else {
+			ratio := distanceToTravel / distanceToNext
+			state.location = interpolateLocation(state.location, nextPoint, ratio)
+			distanceToTravel = 0
+		}
+	}
+
+	routeCompleted := state.idx >= len(state.points)-1
+	if routeCompleted {
+		state.location = state.points[len(state.points)-1]
+	}
+
+	heading := 0.0
+	if state.idx < len(state.points)-1 {
+		heading = calculateHeading(state.location, state.points[state.idx+1])
+	}
+
+	return state, routeCompleted, heading
+}
+
+func movementCases() []legacyPosition {
+	a := vo.MustNewLocation(52.5000, 13.4000)
+	b := vo.MustNewLocation(52.5100, 13.4000)
+	c := vo.MustNewLocation(52.5100, 13.4200)
+	d := vo.MustNewLocation(52.4950, 13.4300)
+	halfway := interpolateLocation(a, b, 0.5)
+
+	return []legacyPosition{
+		{location: a, points: []vo.Location{a, b}},
+		{location: a, points: []vo.Location{a, b, c, d}},
+		{location: halfway, points: []vo.Location{a, b, c, d}},
+		{location: c, points: []vo.Location{a, b, c, d}, idx: 2},
+		{location: b, points: []vo.Location{a, b, b, c}, idx: 1},
+		{location: d, points: []vo.Location{a, b, c, d}, idx: 3},
+		{location: a, points: []vo.Location{a, a}},
+	}
+}
+
+func TestMovement_MatchesSimulators(t *testing.T) {
+	distances := []float64{0, 0.0005, 0.5, 1.1119, 2, 3.5, 10}
+
+	for i, start := range movementCases() {
+		for _, distanceKm := range distances {
+			t.Run(fmt.Sprintf("route %d/%.4f km", i, distanceKm), func(t *testing.T) {
+				for _, loop := range []bool{false, true} {
+					wantCourier, wantIdle, wantCourierHeading := legacyCourierStep(start, distanceKm, loop)
+
+					courier := Movement{CurrentLocation: start.location, RoutePoints: slices.Clone(start.points), CurrentPointIdx: start.idx}
+					completed := courier.advanceBy(distanceKm, loop)
+
+					assert.Equal(t, wantIdle, completed, "courier completion, loop=%v", loop)
+					assert.Equal(t, wantCourier.location, courier.CurrentLocation, "courier location, loop=%v", loop)
+					assert.Equal(t, wantCourier.points, courier.RoutePoints, "courier route, loop=%v", loop)
+					assert.Equal(t, wantCourier.idx, courier.CurrentPointIdx, "courier point, loop=%v", loop)
+					assert.Equal(t, wantCourierHeading, courier.heading(), "courier heading, loop=%v", loop) //nolint:testifylint // must be identical
+				}
+
+				wantDelivery, wantCompleted, wantDeliveryHeading := legacyDeliveryStep(start, distanceKm)
+
+				delivery := Movement{CurrentLocation: start.location, RoutePoints: slices.Clone(start.points), CurrentPointIdx: start.idx}
+				completed := delivery.advanceBy(distanceKm, false)
+
+				assert.Equal(t, wantCompleted, completed, "delivery completion")
+				assert.Equal(t, wantDelivery.location, delivery.CurrentLocation, "delivery location")
+				assert.Equal(t, wantDelivery.idx, delivery.CurrentPointIdx, "delivery point")
+				assert.Equal(t, wantDeliveryHeading, delivery.heading(), "delivery heading") //nolint:testifylint // must be identical
+			})
+		}
+	}
+}
+
+func TestMovement_AdvanceUsesElapsedTime(t *testing.T) {
+	start := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+	points := movementCases()[1].points
+
+	// 36 km/h for 10 s at 2x time covers 0.2 km
+	byTime := Movement{CurrentLocation: points[0], RoutePoints: points, Speed: 36, LastUpdateAt: start}
+	completed := byTime.advance(start.Add(10*time.Second), 2, false)
+	require.False(t, completed)
+
+	byDistance := Movement{CurrentLocation: points[0], RoutePoints: points}
+	byDistance.advanceBy(0.2, false)
+
+	assert.InDelta(t, byDistance.CurrentLocation.Latitude(), byTime.CurrentLocation.Latitude(), 1e-9)
+	assert.InDelta(t, byDistance.CurrentLocation.Longitude(), byTime.CurrentLocation.Longitude(), 1e-9)
+	assert.Equal(t, start.Add(10*time.Second), byTime.LastUpdateAt)
+}
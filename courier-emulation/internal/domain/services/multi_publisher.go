@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
+)
+
+// MultiLocationPublisher fans location events out to several sinks (e.g. Kafka and the SSE stream).
+// Every sink receives every event; a failing sink does not stop delivery to the others.
+type MultiLocationPublisher struct {
+	publishers []LocationPublisher
+}
+
+// NewMultiLocationPublisher creates a location publisher that writes to all given publishers in order.
+func NewMultiLocationPublisher(publishers ...LocationPublisher) *MultiLocationPublisher {
+	return &MultiLocationPublisher{publishers: publishers}
+}
+
+// PublishLocation publishes the event to every sink and returns the joined errors of the failing ones.
+//
+//nolint:gocritic // CourierLocationEvent is an immutable value object in this boundary.
+func (m *MultiLocationPublisher) PublishLocation(ctx context.Context, event vo.CourierLocationEvent) error {
+	var errs []error
+
+	for _, publisher := range m.publishers {
+		err := publisher.PublishLocation(ctx, event)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// PublishRetryScheduled publishes the retry scheduled event to every sink and returns the joined errors of the failing ones.
+//
+//nolint:gocritic // Kafka event payloads are intentionally passed by value as immutable messages.
+func (m *MultiStatusPublisher) PublishRetryScheduled(ctx context.Context, event kafka.DeliveryRetryScheduledEvent) error {
+	var errs []error
+
+	for _, publisher := range m.publishers {
+		err := publisher.PublishRetryScheduled(ctx, event)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close closes every sink and returns the joined errors of the failing ones.
+func (m *MultiLocationPublisher) Close() error {
+	var errs []error
+
+	for _, publisher := range m.publishers {
+		err := publisher.Close()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// MultiStatusPublisher fans delivery status events out to several sinks.
+// Every sink receives every event; a failing sink does not stop delivery to the others.
+type MultiStatusPublisher struct {
+	publishers []kafka.StatusPublisher
+}
+
+// NewMultiStatusPublisher creates a status publisher that writes to all given publishers in order.
+func NewMultiStatusPublisher(publishers ...kafka.StatusPublisher) *MultiStatusPublisher {
+	return &MultiStatusPublisher{publishers: publishers}
+}
+
+// PublishPickUp publishes the pickup event to every sink and returns the joined errors of the failing ones.
+//
+//nolint:gocritic // Kafka event payloads are intentionally passed by value as immutable messages.
+func (m *MultiStatusPublisher) PublishPickUp(ctx context.Context, event kafka.PickUpOrderEvent) error {
+	var errs []error
+
+	for _, publisher := range m.publishers {
+		err := publisher.PublishPickUp(ctx, event)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// PublishDelivery publishes the delivery event to every sink and returns the joined errors of the failing ones.
+//
+//nolint:gocritic // Kafka event payloads are intentionally passed by value as immutable messages.
+func (m *MultiStatusPublisher) PublishDelivery(ctx context.Context, event kafka.DeliverOrderEvent) error {
+	var errs []error
+
+	for _, publisher := range m.publishers {
+		err := publisher.PublishDelivery(ctx, event)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// PublishReassigned publishes the reassigned event to every sink and returns the joined errors of the failing ones.
+//
+//nolint:gocritic // Kafka event payloads are intentionally passed by value as immutable messages.
+func (m *MultiStatusPublisher) PublishReassigned(ctx context.Context, event kafka.DeliveryReassignedEvent) error {
+	var errs []error
+
+	for _, publisher := range m.publishers {
+		err := publisher.PublishReassigned(ctx, event)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// PublishSLABreached publishes the SLA breached event to every sink and returns the joined errors of the failing ones.
+//
+//nolint:gocritic // Kafka event payloads are intentionally passed by value as immutable messages.
+func (m *MultiStatusPublisher) PublishSLABreached(ctx context.Context, event kafka.DeliverySLABreachedEvent) error {
+	var errs []error
+
+	for _, publisher := range m.publishers {
+		err := publisher.PublishSLABreached(ctx, event)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close closes every sink and returns the joined errors of the failing ones.
+func (m *MultiStatusPublisher) Close() error {
+	var errs []error
+
+	for _, publisher := range m.publishers {
+		err := publisher.Close()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSinkDown = errors.New("sink down")
+
+// failingLocationPublisher fails every call.
+type failingLocationPublisher struct {
+	calls int
+}
+
+func (f *failingLocationPublisher) PublishLocation(context.Context, vo.CourierLocationEvent) error {
+	f.calls++
+	return errSinkDown
+}
+
+func (f *failingLocationPublisher) Close() error {
+	return errSinkDown
+}
+
+// failingStatusPublisher fails every call.
+type failingStatusPublisher struct{}
+
+func (failingStatusPublisher) PublishPickUp(context.Context, kafka.PickUpOrderEvent) error {
+	return errSinkDown
+}
+
+func (failingStatusPublisher) PublishDelivery(context.Context, kafka.DeliverOrderEvent) error {
+	return errSinkDown
+}
+
+func (failingStatusPublisher) PublishReassigned(context.Context, kafka.DeliveryReassignedEvent) error {
+	return errSinkDown
+}
+
+func (failingStatusPublisher) PublishSLABreached(context.Context, kafka.DeliverySLABreachedEvent) error {
+	return errSinkDown
+}
+
+func (failingStatusPublisher) PublishRetryScheduled(context.Context, kafka.DeliveryRetryScheduledEvent) error {
+	return errSinkDown
+}
+
+func (failingStatusPublisher) Close() error {
+	return nil
+}
+
+func TestMultiLocationPublisher_FansOutToAllSinks(t *testing.T) {
+	first := newMockLocationPublisher()
+	second := newMockLocationPublisher()
+	multi := NewMultiLocationPublisher(first, second)
+
+	location := vo.MustNewLocation(52.5200, 13.4050)
+	events := []vo.CourierLocationEvent{
+		vo.NewCourierLocationEvent("courier-1", location, vo.CourierStatusMoving),
+		vo.NewCourierLocationEvent("courier-2", location, vo.CourierStatusIdle),
+	}
+
+	for _, event := range events {
+		require.NoError(t, multi.PublishLocation(context.Background(), event))
+	}
+
+	assert.Equal(t, events, first.GetEvents())
+	assert.Equal(t, events, second.GetEvents())
+
+	require.NoError(t, multi.Close())
+	assert.True(t, first.closed)
+	assert.True(t, second.closed)
+}
+
+func TestMultiLocationPublisher_FailureDoesNotSuppressOtherSinks(t *testing.T) {
+	failing := &failingLocationPublisher{}
+	healthy := newMockLocationPublisher()
+	multi := NewMultiLocationPublisher(failing, healthy)
+
+	event := vo.NewCourierLocationEvent("courier-1", vo.MustNewLocation(52.5200, 13.4050), vo.CourierStatusMoving)
+
+	err := multi.PublishLocation(context.Background(), event)
+	require.ErrorIs(t, err, errSinkDown)
+	assert.Equal(t, 1, failing.calls)
+	assert.Equal(t, []vo.CourierLocationEvent{event}, healthy.GetEvents())
+
+	err = multi.Close()
+	require.ErrorIs(t, err, errSinkDown)
+	assert.True(t, healthy.closed, "healthy sink must be closed even if another sink fails to close")
+}
+
+func TestMultiStatusPublisher_FailureDoesNotSuppressOtherSinks(t *testing.T) {
+	healthy := newMockStatusPublisher()
+	multi := NewMultiStatusPublisher(failingStatusPublisher{}, healthy)
+
+	pickup := kafka.PickUpOrderEvent{PackageID: "package-1", CourierID: "courier-1"}
+	deliver := kafka.DeliverOrderEvent{PackageID: "package-1", CourierID: "courier-1", Status: kafka.DeliveryStatusDelivered}
+
+	require.ErrorIs(t, multi.PublishPickUp(context.Background(), pickup), errSinkDown)
+	require.ErrorIs(t, multi.PublishDelivery(context.Background(), deliver), errSinkDown)
+
+	assert.Equal(t, []kafka.PickUpOrderEvent{pickup}, healthy.GetPickupEvents())
+	assert.Equal(t, []kafka.DeliverOrderEvent{deliver}, healthy.GetDeliveryEvents())
+	require.NoError(t, multi.Close())
+}
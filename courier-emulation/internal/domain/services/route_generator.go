@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dgraph-io/ristretto/v2"
@@ -13,35 +15,92 @@ import (
 )
 
 const (
-	// Cache configuration for route caching
+	// Default route cache configuration (see RouteCacheConfig)
 	routeCacheNumCounters = 100_000   // track 100k routes
 	routeCacheMaxCost     = 50_000_00 // ~50MB
 	routeCacheBufferItems = 64
 	routeCacheTTL         = 24 * time.Hour // routes rarely change
 	// defaultOSRMTimeout bounds a single OSRM request when no explicit timeout is configured.
 	defaultOSRMTimeout = 10 * time.Second
+	// defaultOSRMRequestTimeout bounds one attempt so a slow OSRM leaves room for retries.
+	defaultOSRMRequestTimeout = 3 * time.Second
+	// defaultOSRMMaxRetries is how many times a transient OSRM failure is retried.
+	defaultOSRMMaxRetries = 2
+	// defaultOSRMRetryBackoff is the first retry delay; it doubles on every further retry.
+	defaultOSRMRetryBackoff = 200 * time.Millisecond
+	// defaultBatchConcurrency is how many routes GenerateBatch fetches from OSRM at once.
+	defaultBatchConcurrency = 4
 )
 
 // RouteGenerator errors
 var (
-	ErrOSRMUnavailable = errors.New("OSRM service unavailable")
-	ErrNoRouteFound    = errors.New("no route found between points")
-	ErrInvalidResponse = errors.New("invalid OSRM response")
+	ErrOSRMUnavailable   = errors.New("OSRM service unavailable")
+	ErrNoRouteFound      = errors.New("no route found between points")
+	ErrInvalidResponse   = errors.New("invalid OSRM response")
+	ErrInvalidRouteCache = errors.New("invalid route cache config")
 )
 
+// RouteCacheConfig sizes the route cache.
+type RouteCacheConfig struct {
+	NumCounters int64         // keys tracked for admission, ~10x the expected number of routes
+	MaxCost     int64         // total cost the cache may hold; every route costs 1
+	TTL         time.Duration // how long a cached route is served
+}
+
+// DefaultRouteCacheConfig returns the route cache sizing used when none is configured.
+func DefaultRouteCacheConfig() RouteCacheConfig {
+	return RouteCacheConfig{
+		NumCounters: routeCacheNumCounters,
+		MaxCost:     routeCacheMaxCost,
+		TTL:         routeCacheTTL,
+	}
+}
+
+// IsZero reports whether no cache setting was configured.
+func (c RouteCacheConfig) IsZero() bool {
+	return c == RouteCacheConfig{}
+}
+
+// Validate checks that every setting is positive.
+func (c RouteCacheConfig) Validate() error {
+	switch {
+	case c.NumCounters <= 0:
+		return fmt.Errorf("%w: num counters must be positive, got %d", ErrInvalidRouteCache, c.NumCounters)
+	case c.MaxCost <= 0:
+		return fmt.Errorf("%w: max cost must be positive, got %d", ErrInvalidRouteCache, c.MaxCost)
+	case c.TTL <= 0:
+		return fmt.Errorf("%w: ttl must be positive, got %s", ErrInvalidRouteCache, c.TTL)
+	default:
+		return nil
+	}
+}
+
 // RouteGeneratorConfig holds configuration for the route generator.
 type RouteGeneratorConfig struct {
 	OSRMBaseURL     string
-	Timeout         time.Duration
+	Profile         RouteProfile  // OSRM routing profile; empty means driving
+	Timeout         time.Duration // HTTP client timeout
+	RequestTimeout  time.Duration // Deadline of a single attempt (0 = client timeout only)
+	MaxRetries      int           // Retries of transient failures (connection errors, 5xx)
+	RetryBackoff    time.Duration // Delay before the first retry, doubled on each further retry
 	AuthHeaderName  string
 	AuthHeaderValue string
+	Cache           RouteCacheConfig // Route cache sizing; the zero value means DefaultRouteCacheConfig
+	// BatchConcurrency caps the routes GenerateBatch fetches in parallel (0 = defaultBatchConcurrency)
+	BatchConcurrency int
 }
 
 // DefaultRouteGeneratorConfig returns default configuration.
 func DefaultRouteGeneratorConfig() RouteGeneratorConfig {
 	return RouteGeneratorConfig{
-		OSRMBaseURL: "http://localhost:5000",
-		Timeout:     defaultOSRMTimeout,
+		OSRMBaseURL:      "http://localhost:5000",
+		Profile:          ProfileDriving,
+		Timeout:          defaultOSRMTimeout,
+		RequestTimeout:   defaultOSRMRequestTimeout,
+		MaxRetries:       defaultOSRMMaxRetries,
+		RetryBackoff:     defaultOSRMRetryBackoff,
+		Cache:            DefaultRouteCacheConfig(),
+		BatchConcurrency: defaultBatchConcurrency,
 	}
 }
 
@@ -49,15 +108,37 @@ func DefaultRouteGeneratorConfig() RouteGeneratorConfig {
 type RouteGenerator struct {
 	config     RouteGeneratorConfig
 	osrmClient *osrm.Client
-	idCounter  int
+	idCounter  atomic.Int64
 	cache      *ristretto.Cache[string, vo.Route]
 }
 
 // NewRouteGenerator creates a new RouteGenerator service.
 func NewRouteGenerator(config RouteGeneratorConfig) (*RouteGenerator, error) {
+	if config.Profile == "" {
+		config.Profile = ProfileDriving
+	}
+
+	_, err := ParseRouteProfile(string(config.Profile))
+	if err != nil {
+		return nil, err
+	}
+
+	if config.BatchConcurrency <= 0 {
+		config.BatchConcurrency = defaultBatchConcurrency
+	}
+
+	if config.Cache.IsZero() {
+		config.Cache = DefaultRouteCacheConfig()
+	}
+
+	err = config.Cache.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	cache, err := ristretto.NewCache(&ristretto.Config[string, vo.Route]{
-		NumCounters: routeCacheNumCounters,
-		MaxCost:     routeCacheMaxCost,
+		NumCounters: config.Cache.NumCounters,
+		MaxCost:     config.Cache.MaxCost,
 		BufferItems: routeCacheBufferItems,
 	})
 	if err != nil {
@@ -77,11 +158,15 @@ func NewRouteGenerator(config RouteGeneratorConfig) (*RouteGenerator, error) {
 	return &RouteGenerator{
 		config:     config,
 		osrmClient: osrmClient,
-		idCounter:  0,
 		cache:      cache,
 	}, nil
 }
 
+// Profile returns the OSRM routing profile routes are generated for.
+func (rg *RouteGenerator) Profile() RouteProfile {
+	return rg.config.Profile
+}
+
 // Close closes the route generator and its cache.
 func (rg *RouteGenerator) Close() {
 	if rg.cache != nil {
@@ -90,10 +175,9 @@ func (rg *RouteGenerator) Close() {
 }
 
 // GenerateRoute generates a route between two locations using OSRM.
-// Routes are cached by origin+destination coordinates for 24 hours.
+// Routes are cached by profile and origin+destination coordinates for the configured cache TTL.
 func (rg *RouteGenerator) GenerateRoute(ctx context.Context, origin, destination vo.Location) (vo.Route, error) {
-	// Create cache key from origin and destination coordinates
-	cacheKey := fmt.Sprintf("%s:%s", origin.ToOSRMFormat(), destination.ToOSRMFormat())
+	cacheKey := routeCacheKey(rg.config.Profile, origin, destination)
 
 	// Check cache first
 	if cachedRoute, found := rg.cache.Get(cacheKey); found {
@@ -107,14 +191,20 @@ func (rg *RouteGenerator) GenerateRoute(ctx context.Context, origin, destination
 	}
 
 	// Store in cache with TTL (cost=1 since all routes are similar size)
-	rg.cache.SetWithTTL(cacheKey, route, 1, routeCacheTTL)
+	rg.cache.SetWithTTL(cacheKey, route, 1, rg.config.Cache.TTL)
 
 	return route, nil
 }
 
+// routeCacheKey builds the cache key from profile, origin and destination coordinates,
+// so a cycling route never answers a driving request for the same points.
+func routeCacheKey(profile RouteProfile, origin, destination vo.Location) string {
+	return fmt.Sprintf("%s:%s:%s", profile, origin.ToOSRMFormat(), destination.ToOSRMFormat())
+}
+
 // fetchRouteFromOSRM fetches a route from the OSRM API.
 func (rg *RouteGenerator) fetchRouteFromOSRM(ctx context.Context, origin, destination vo.Location) (vo.Route, error) {
-	osrmRoute, err := rg.osrmClient.Route(ctx, origin.ToOSRMFormat(), destination.ToOSRMFormat())
+	osrmRoute, err := rg.routeWithRetry(ctx, origin.ToOSRMFormat(), destination.ToOSRMFormat())
 	if err != nil {
 		switch {
 		case errors.Is(err, osrm.ErrNoRouteFound):
@@ -131,8 +221,7 @@ func (rg *RouteGenerator) fetchRouteFromOSRM(ctx context.Context, origin, destin
 		return vo.Route{}, fmt.Errorf("invalid polyline: %w", err)
 	}
 
-	rg.idCounter++
-	routeID := fmt.Sprintf("route_%06d", rg.idCounter)
+	routeID := fmt.Sprintf("route_%06d", rg.idCounter.Add(1))
 
 	route, err := vo.NewRoute(
 		routeID,
@@ -149,30 +238,110 @@ func (rg *RouteGenerator) fetchRouteFromOSRM(ctx context.Context, origin, destin
 	return route, nil
 }
 
+// routeWithRetry calls OSRM with a per-attempt deadline and retries transient failures
+// with exponential backoff. The caller's ctx cancellation stops the retries.
+func (rg *RouteGenerator) routeWithRetry(ctx context.Context, origin, destination string) (osrm.RouteResult, error) {
+	backoff := rg.config.RetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		route, err := rg.routeAttempt(ctx, origin, destination)
+		if err == nil || attempt >= rg.config.MaxRetries || !osrm.IsRetryable(err) {
+			return route, err
+		}
+
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return osrm.RouteResult{}, errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+
+		backoff *= 2
+	}
+}
+
+// routeAttempt performs a single OSRM request bounded by RequestTimeout.
+func (rg *RouteGenerator) routeAttempt(ctx context.Context, origin, destination string) (osrm.RouteResult, error) {
+	if rg.config.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, rg.config.RequestTimeout)
+		defer cancel()
+	}
+
+	route, err := rg.osrmClient.Route(ctx, string(rg.config.Profile), origin, destination)
+	if err != nil {
+		return osrm.RouteResult{}, fmt.Errorf("osrm route: %w", err)
+	}
+
+	return route, nil
+}
+
 // GenerateRandomRoute generates a route between two random points in the bounding box.
 func (rg *RouteGenerator) GenerateRandomRoute(ctx context.Context, bbox vo.BoundingBox) (vo.Route, error) {
 	origin, destination := bbox.RandomPointPair()
 	return rg.GenerateRoute(ctx, origin, destination)
 }
 
-// GenerateBatch generates multiple random routes in the bounding box.
+// GenerateBatch generates multiple random routes in the bounding box, fetching at most
+// BatchConcurrency of them at once. Failed routes are skipped; when ctx is cancelled no further
+// routes are started and the routes generated so far are returned with the context error.
 func (rg *RouteGenerator) GenerateBatch(ctx context.Context, bbox vo.BoundingBox, count int) ([]vo.Route, error) {
-	routes := make([]vo.Route, 0, count)
+	results := make([]vo.Route, count)
+	generated := make([]bool, count)
+
+	sem := make(chan struct{}, rg.config.BatchConcurrency)
+
+	var (
+		wg     sync.WaitGroup
+		ctxErr error
+	)
+
+	for i := range count {
+		// Checked first so a free slot never wins over a cancelled ctx
+		ctxErr = ctx.Err()
+		if ctxErr != nil {
+			break
+		}
 
-	for range count {
 		select {
 		case <-ctx.Done():
-			return routes, fmt.Errorf("context: %w", ctx.Err())
-		default:
+			ctxErr = ctx.Err()
+		case sem <- struct{}{}:
 		}
 
-		route, err := rg.GenerateRandomRoute(ctx, bbox)
-		if err != nil {
-			// Skip failed routes, continue generating
-			continue
+		if ctxErr != nil {
+			break
 		}
 
-		routes = append(routes, route)
+		wg.Go(func() {
+			defer func() { <-sem }()
+
+			route, err := rg.GenerateRandomRoute(ctx, bbox)
+			if err != nil {
+				// Skip failed routes, continue generating
+				return
+			}
+
+			results[i], generated[i] = route, true
+		})
+	}
+
+	wg.Wait()
+
+	routes := make([]vo.Route, 0, count)
+
+	for i, route := range results {
+		if generated[i] {
+			routes = append(routes, route)
+		}
+	}
+
+	if ctxErr != nil {
+		return routes, fmt.Errorf("context: %w", ctxErr)
 	}
 
 	return routes, nil
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -96,6 +98,105 @@ func TestRouteGenerator_GenerateRoute_NoRoute(t *testing.T) {
 	assert.ErrorIs(t, err, ErrNoRouteFound)
 }
 
+func TestRouteGenerator_GenerateRoute_RetriesServerErrors(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		resp := routeServerResponse{
+			Code:   "Ok",
+			Routes: []routeServerRoute{{Distance: 1885.4, Duration: 259.5, Geometry: "_p~iF~ps|U_ulLnnqC"}},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp) //nolint:errcheck // test mock response
+	}))
+	defer server.Close()
+
+	config := RouteGeneratorConfig{
+		OSRMBaseURL:    server.URL,
+		Timeout:        5 * time.Second,
+		RequestTimeout: time.Second,
+		MaxRetries:     2,
+		RetryBackoff:   time.Millisecond,
+	}
+	generator, err := NewRouteGenerator(config)
+	require.NoError(t, err)
+
+	defer generator.Close()
+
+	origin := vo.MustNewLocation(52.517037, 13.388860)
+	destination := vo.MustNewLocation(52.529407, 13.397634)
+
+	route, err := generator.GenerateRoute(context.Background(), origin, destination)
+	require.NoError(t, err)
+	assert.InDelta(t, 1885.4, route.Distance(), 0.1)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestRouteGenerator_GenerateRoute_NoRouteIsNotRetried(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(routeServerResponse{Code: "NoRoute"}) //nolint:errcheck // test mock response
+	}))
+	defer server.Close()
+
+	config := RouteGeneratorConfig{
+		OSRMBaseURL:  server.URL,
+		Timeout:      5 * time.Second,
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	}
+	generator, err := NewRouteGenerator(config)
+	require.NoError(t, err)
+
+	defer generator.Close()
+
+	origin := vo.MustNewLocation(52.517037, 13.388860)
+	destination := vo.MustNewLocation(52.529407, 13.397634)
+
+	_, err = generator.GenerateRoute(context.Background(), origin, destination)
+	require.ErrorIs(t, err, ErrNoRouteFound)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestRouteGenerator_GenerateRoute_RetryStopsOnCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	config := RouteGeneratorConfig{
+		OSRMBaseURL:  server.URL,
+		Timeout:      5 * time.Second,
+		MaxRetries:   5,
+		RetryBackoff: time.Hour,
+	}
+	generator, err := NewRouteGenerator(config)
+	require.NoError(t, err)
+
+	defer generator.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	origin := vo.MustNewLocation(52.517037, 13.388860)
+	destination := vo.MustNewLocation(52.529407, 13.397634)
+
+	_, err = generator.GenerateRoute(ctx, origin, destination)
+	require.ErrorIs(t, err, ErrOSRMUnavailable)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func TestRouteGenerator_GenerateRoute_ServiceUnavailable(t *testing.T) {
 	config := RouteGeneratorConfig{
 		OSRMBaseURL: "http://localhost:59999", // Invalid port
@@ -149,10 +250,10 @@ func TestRouteGenerator_GenerateRandomRoute(t *testing.T) {
 }
 
 func TestRouteGenerator_GenerateBatch(t *testing.T) {
-	requestCount := 0
+	var requestCount atomic.Int32
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		requestCount++
+		requestCount.Add(1)
 		resp := routeServerResponse{
 			Code: "Ok",
 			Routes: []routeServerRoute{
@@ -184,7 +285,104 @@ func TestRouteGenerator_GenerateBatch(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, routes, 5)
 	// Note: requestCount may be less than 5 due to caching if random points happen to repeat
-	assert.GreaterOrEqual(t, requestCount, 1)
+	assert.GreaterOrEqual(t, requestCount.Load(), int32(1))
+}
+
+func TestRouteGenerator_GenerateBatch_ConcurrencyLimit(t *testing.T) {
+	const maxConcurrency = 3
+
+	var inFlight, maxInFlight atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		// Hold the request so parallel ones overlap
+		time.Sleep(20 * time.Millisecond)
+
+		resp := routeServerResponse{
+			Code:   "Ok",
+			Routes: []routeServerRoute{{Distance: 2000.0, Duration: 300.0, Geometry: "_p~iF~ps|U_ulLnnqC"}},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp) //nolint:errcheck // test mock response
+	}))
+	defer server.Close()
+
+	generator, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL:      server.URL,
+		Timeout:          5 * time.Second,
+		BatchConcurrency: maxConcurrency,
+	})
+	require.NoError(t, err)
+
+	defer generator.Close()
+
+	routes, err := generator.GenerateBatch(context.Background(), vo.BerlinBoundingBox(), 12)
+	require.NoError(t, err)
+
+	assert.Len(t, routes, 12)
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(maxConcurrency), "no more than the cap may be in flight")
+	assert.Greater(t, maxInFlight.Load(), int32(1), "routes must be fetched in parallel")
+
+	// Route IDs stay unique when generated concurrently
+	ids := make(map[string]struct{}, len(routes))
+	for _, route := range routes {
+		ids[route.ID()] = struct{}{}
+	}
+
+	assert.Len(t, ids, len(routes))
+}
+
+func TestRouteGenerator_GenerateBatch_SkipsFailuresAndStopsOnCancel(t *testing.T) {
+	var requestCount atomic.Int32
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requestCount.Add(1)
+
+		// Every other route has no OSRM route; the sixth request cancels the batch
+		if n == 6 {
+			cancel()
+		}
+
+		resp := routeServerResponse{Code: "NoRoute"}
+		if n%2 == 1 {
+			resp = routeServerResponse{
+				Code:   "Ok",
+				Routes: []routeServerRoute{{Distance: 2000.0, Duration: 300.0, Geometry: "_p~iF~ps|U_ulLnnqC"}},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp) //nolint:errcheck // test mock response
+	}))
+	defer server.Close()
+
+	generator, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL:      server.URL,
+		Timeout:          5 * time.Second,
+		BatchConcurrency: 1,
+	})
+	require.NoError(t, err)
+
+	defer generator.Close()
+
+	routes, err := generator.GenerateBatch(ctx, vo.BerlinBoundingBox(), 100)
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, routes, 3, "the three successful routes before the cancel are kept")
+	assert.Equal(t, int32(6), requestCount.Load(), "no routes are started after the cancel")
 }
 
 func TestRouteGenerator_CacheHit(t *testing.T) {
@@ -238,9 +436,129 @@ func TestRouteGenerator_CacheHit(t *testing.T) {
 	assert.Equal(t, route1.Duration(), route2.Duration())
 }
 
+func TestRouteGenerator_Profile(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		resp := routeServerResponse{
+			Code: "Ok",
+			Routes: []routeServerRoute{
+				{
+					Distance: 1885.4,
+					Duration: 259.5,
+					Geometry: "_p~iF~ps|U_ulLnnqC",
+				},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp) //nolint:errcheck // test mock response
+	}))
+	defer server.Close()
+
+	origin := vo.MustNewLocation(52.517037, 13.388860)
+	destination := vo.MustNewLocation(52.529407, 13.397634)
+
+	for _, profile := range []RouteProfile{ProfileCycling, ProfileWalking} {
+		generator, err := NewRouteGenerator(RouteGeneratorConfig{
+			OSRMBaseURL: server.URL,
+			Profile:     profile,
+			Timeout:     5 * time.Second,
+		})
+		require.NoError(t, err)
+
+		_, err = generator.GenerateRoute(context.Background(), origin, destination)
+		require.NoError(t, err)
+
+		generator.Close()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Len(t, paths, 2)
+	assert.Contains(t, paths[0], "/route/v1/cycling/")
+	assert.Contains(t, paths[1], "/route/v1/walking/")
+
+	// Profiles never share a cache entry for the same points
+	assert.NotEqual(t,
+		routeCacheKey(ProfileDriving, origin, destination),
+		routeCacheKey(ProfileCycling, origin, destination),
+	)
+}
+
+func TestRouteGenerator_UnknownProfile(t *testing.T) {
+	_, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Profile:     "flying",
+	})
+	require.ErrorIs(t, err, ErrUnknownRouteProfile)
+}
+
+func TestRouteProfile_DefaultSpeedKmH(t *testing.T) {
+	assert.Greater(t, ProfileDriving.DefaultSpeedKmH(), ProfileCycling.DefaultSpeedKmH())
+	assert.Greater(t, ProfileCycling.DefaultSpeedKmH(), ProfileWalking.DefaultSpeedKmH())
+}
+
 func TestDefaultRouteGeneratorConfig(t *testing.T) {
 	config := DefaultRouteGeneratorConfig()
 
 	assert.Equal(t, "http://localhost:5000", config.OSRMBaseURL)
+	assert.Equal(t, ProfileDriving, config.Profile)
 	assert.Equal(t, 10*time.Second, config.Timeout)
+	assert.Equal(t, 3*time.Second, config.RequestTimeout)
+	assert.Equal(t, 2, config.MaxRetries)
+	assert.Equal(t, 200*time.Millisecond, config.RetryBackoff)
+	assert.Equal(t, DefaultRouteCacheConfig(), config.Cache)
+}
+
+func TestRouteGenerator_CacheConfig(t *testing.T) {
+	generator, err := NewRouteGenerator(RouteGeneratorConfig{
+		OSRMBaseURL: "http://localhost:5000",
+		Cache:       RouteCacheConfig{NumCounters: 100, MaxCost: 10, TTL: time.Minute},
+	})
+	require.NoError(t, err)
+	t.Cleanup(generator.Close)
+
+	assert.Equal(t, int64(10), generator.cache.MaxCost())
+	assert.Equal(t, time.Minute, generator.config.Cache.TTL)
+
+	// An unset cache config falls back to the defaults
+	defaults, err := NewRouteGenerator(RouteGeneratorConfig{OSRMBaseURL: "http://localhost:5000"})
+	require.NoError(t, err)
+	t.Cleanup(defaults.Close)
+
+	assert.Equal(t, DefaultRouteCacheConfig(), defaults.config.Cache)
+	assert.Equal(t, int64(routeCacheMaxCost), defaults.cache.MaxCost())
+}
+
+func TestRouteGenerator_InvalidCacheConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mutate func(*RouteCacheConfig)
+	}{
+		{name: "zero num counters", mutate: func(c *RouteCacheConfig) { c.NumCounters = 0 }},
+		{name: "negative num counters", mutate: func(c *RouteCacheConfig) { c.NumCounters = -1 }},
+		{name: "zero max cost", mutate: func(c *RouteCacheConfig) { c.MaxCost = 0 }},
+		{name: "negative max cost", mutate: func(c *RouteCacheConfig) { c.MaxCost = -1 }},
+		{name: "zero ttl", mutate: func(c *RouteCacheConfig) { c.TTL = 0 }},
+		{name: "negative ttl", mutate: func(c *RouteCacheConfig) { c.TTL = -time.Second }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := DefaultRouteGeneratorConfig()
+			tc.mutate(&config.Cache)
+
+			_, err := NewRouteGenerator(config)
+			require.ErrorIs(t, err, ErrInvalidRouteCache)
+		})
+	}
 }
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+)
+
+// RouteProfile is the OSRM routing profile couriers travel with.
+type RouteProfile string
+
+// Supported OSRM routing profiles.
+const (
+	ProfileDriving RouteProfile = "driving"
+	ProfileCycling RouteProfile = "cycling"
+	ProfileWalking RouteProfile = "walking"
+)
+
+// ErrUnknownRouteProfile is returned for a profile other than driving, cycling or walking.
+var ErrUnknownRouteProfile = errors.New("unknown OSRM route profile")
+
+// ParseRouteProfile validates an OSRM routing profile name.
+func ParseRouteProfile(value string) (RouteProfile, error) {
+	profile := RouteProfile(value)
+
+	switch profile {
+	case ProfileDriving, ProfileCycling, ProfileWalking:
+		return profile, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownRouteProfile, value)
+	}
+}
+
+// DefaultSpeedKmH returns a typical courier speed for the profile in km/h.
+func (p RouteProfile) DefaultSpeedKmH() float64 {
+	switch p {
+	case ProfileCycling:
+		return 15.0
+	case ProfileWalking:
+		return 5.0
+	default:
+		return 30.0
+	}
+}
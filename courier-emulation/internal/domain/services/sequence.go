@@ -0,0 +1,18 @@
+package services
+
+// courierSequences holds the last event sequence number of each courier.
+// It is not safe for concurrent use; simulators guard it with their own lock.
+type courierSequences map[string]uint64
+
+// next returns the courier's next event sequence number, starting at 1.
+func (s courierSequences) next(courierID string) uint64 {
+	s[courierID]++
+
+	return s[courierID]
+}
+
+// resume continues the courier's sequence after last, e.g. when the courier comes over from
+// another simulator. A sequence already past last is kept.
+func (s courierSequences) resume(courierID string, last uint64) {
+	s[courierID] = max(s[courierID], last)
+}
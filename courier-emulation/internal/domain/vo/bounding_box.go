@@ -5,13 +5,25 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
+	"strings"
 )
 
 // BoundingBox validation errors
 var (
 	ErrInvalidBoundingBox = errors.New("invalid bounding box: min must be less than max")
+	ErrUnknownBoundingBox = errors.New("unknown bounding box preset")
 )
 
+// boundingBoxPresets maps preset names to city bounding boxes for multi-city demos.
+var boundingBoxPresets = map[string]func() BoundingBox{
+	"berlin":           BerlinBoundingBox,
+	"moscow":           MoscowBoundingBox,
+	"saint-petersburg": SaintPetersburgBoundingBox,
+	"london":           LondonBoundingBox,
+	"paris":            ParisBoundingBox,
+}
+
 // BoundingBox represents a geographic bounding box as a value object.
 // Used for generating random points within a region.
 type BoundingBox struct {
@@ -67,6 +79,49 @@ func BerlinBoundingBox() BoundingBox {
 	return MustNewBoundingBox(52.3383, 52.6755, 13.0884, 13.7610)
 }
 
+// MoscowBoundingBox returns the bounding box for Moscow, Russia.
+func MoscowBoundingBox() BoundingBox {
+	return MustNewBoundingBox(55.5700, 55.9116, 37.3700, 37.8500)
+}
+
+// SaintPetersburgBoundingBox returns the bounding box for Saint Petersburg, Russia.
+func SaintPetersburgBoundingBox() BoundingBox {
+	return MustNewBoundingBox(59.8000, 60.0900, 30.1500, 30.5500)
+}
+
+// LondonBoundingBox returns the bounding box for London, United Kingdom.
+func LondonBoundingBox() BoundingBox {
+	return MustNewBoundingBox(51.2868, 51.6919, -0.5103, 0.3340)
+}
+
+// ParisBoundingBox returns the bounding box for Paris, France.
+func ParisBoundingBox() BoundingBox {
+	return MustNewBoundingBox(48.8156, 48.9022, 2.2241, 2.4699)
+}
+
+// BoundingBoxPreset returns the named preset (case-insensitive), e.g. "berlin" or "moscow".
+func BoundingBoxPreset(name string) (BoundingBox, error) {
+	preset, ok := boundingBoxPresets[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return BoundingBox{}, fmt.Errorf("%w: %q (known: %s)",
+			ErrUnknownBoundingBox, name, strings.Join(BoundingBoxPresetNames(), ", "))
+	}
+
+	return preset(), nil
+}
+
+// BoundingBoxPresetNames returns the sorted names of all bounding box presets.
+func BoundingBoxPresetNames() []string {
+	names := make([]string, 0, len(boundingBoxPresets))
+	for name := range boundingBoxPresets {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
 // MinLat returns the minimum latitude.
 func (bb BoundingBox) MinLat() float64 {
 	return bb.minLat
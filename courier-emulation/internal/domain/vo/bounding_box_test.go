@@ -28,6 +28,14 @@ func TestNewBoundingBox_InvalidLonRange(t *testing.T) {
 	assert.ErrorIs(t, err, ErrInvalidBoundingBox)
 }
 
+func TestNewBoundingBox_OutOfRange(t *testing.T) {
+	_, err := NewBoundingBox(-95.0, 52.6755, 13.0884, 13.7610)
+	assert.ErrorIs(t, err, ErrInvalidLatitude)
+
+	_, err = NewBoundingBox(52.3383, 52.6755, 13.0884, 181.0)
+	assert.ErrorIs(t, err, ErrInvalidLongitude)
+}
+
 func TestBerlinBoundingBox(t *testing.T) {
 	bb := BerlinBoundingBox()
 
@@ -68,6 +76,35 @@ func TestBoundingBox_Contains(t *testing.T) {
 	assert.False(t, bb.Contains(moscow))
 }
 
+func TestBoundingBox_ContainsBoundary(t *testing.T) {
+	bb := MustNewBoundingBox(10, 20, 30, 40)
+
+	assert.True(t, bb.Contains(MustNewLocation(10, 30)), "min corner is inside")
+	assert.True(t, bb.Contains(MustNewLocation(20, 40)), "max corner is inside")
+	assert.True(t, bb.Contains(MustNewLocation(15, 40)), "max longitude edge is inside")
+	assert.False(t, bb.Contains(MustNewLocation(20.0001, 35)), "just above max latitude is outside")
+	assert.False(t, bb.Contains(MustNewLocation(15, 29.9999)), "just below min longitude is outside")
+}
+
+func TestBoundingBoxPreset(t *testing.T) {
+	for _, name := range BoundingBoxPresetNames() {
+		bb, err := BoundingBoxPreset(name)
+		require.NoError(t, err, name)
+
+		for range 20 {
+			assert.True(t, bb.Contains(bb.RandomPoint()), name)
+		}
+	}
+
+	moscow, err := BoundingBoxPreset(" Moscow ")
+	require.NoError(t, err)
+	assert.True(t, moscow.Contains(MustNewLocation(55.7558, 37.6173)))
+	assert.False(t, moscow.Contains(MustNewLocation(52.5200, 13.4050)))
+
+	_, err = BoundingBoxPreset("atlantis")
+	assert.ErrorIs(t, err, ErrUnknownBoundingBox)
+}
+
 func TestBoundingBox_String(t *testing.T) {
 	bb := BerlinBoundingBox()
 	str := bb.String()
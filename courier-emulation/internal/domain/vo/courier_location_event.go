@@ -7,6 +7,9 @@ import (
 	"time"
 )
 
+// maxBatteryPercent is a fully charged battery.
+const maxBatteryPercent = 100.0
+
 // CourierLocationEvent represents a courier's location update event.
 // This is published to Kafka for real-time tracking.
 type CourierLocationEvent struct {
@@ -15,8 +18,20 @@ type CourierLocationEvent struct {
 	Timestamp time.Time `json:"timestamp"`
 	Speed     float64   `json:"speed_kmh,omitempty"` // current speed in km/h
 	Heading   float64   `json:"heading,omitempty"`   // heading in degrees (0-360)
+	Accuracy  float64   `json:"accuracy,omitempty"`  // location accuracy radius in meters
 	RouteID   string    `json:"route_id,omitempty"`  // current route being followed
+	OrderID   string    `json:"order_id,omitempty"`  // order being delivered, if any
 	Status    string    `json:"status"`              // moving, idle, delivering
+
+	// ProgressPercent is how much of the current leg (to pickup or to customer) is covered, 0-100
+	ProgressPercent float64 `json:"progress_percent,omitempty"`
+
+	// BatteryPercent is the charge left on the courier's phone, 0-100
+	BatteryPercent float64 `json:"battery_percent,omitempty"`
+
+	// Sequence orders the courier's location and status events: it starts at 1 and increases by one
+	// with every event of the courier, so events with equal timestamps still have a defined order
+	Sequence uint64 `json:"sequence"`
 }
 
 // NewCourierLocationEvent creates a new courier location event.
@@ -41,12 +56,42 @@ func (e CourierLocationEvent) WithHeading(heading float64) CourierLocationEvent
 	return e
 }
 
+// WithAccuracy sets the location accuracy radius in meters for the event.
+func (e CourierLocationEvent) WithAccuracy(accuracyMeters float64) CourierLocationEvent {
+	e.Accuracy = accuracyMeters
+	return e
+}
+
 // WithRouteID sets the route ID for the event.
 func (e CourierLocationEvent) WithRouteID(routeID string) CourierLocationEvent {
 	e.RouteID = routeID
 	return e
 }
 
+// WithOrderID sets the ID of the order the courier is delivering.
+func (e CourierLocationEvent) WithOrderID(orderID string) CourierLocationEvent {
+	e.OrderID = orderID
+	return e
+}
+
+// WithProgress sets the completed share of the current leg, in percent.
+func (e CourierLocationEvent) WithProgress(percent float64) CourierLocationEvent {
+	e.ProgressPercent = percent
+	return e
+}
+
+// WithBatteryPercent sets the charge left on the courier's phone, clamped to 0-100.
+func (e CourierLocationEvent) WithBatteryPercent(percent float64) CourierLocationEvent {
+	e.BatteryPercent = min(max(percent, 0), maxBatteryPercent)
+	return e
+}
+
+// WithSequence sets the courier's event sequence number.
+func (e CourierLocationEvent) WithSequence(sequence uint64) CourierLocationEvent {
+	e.Sequence = sequence
+	return e
+}
+
 // MarshalJSON implements custom JSON marshaling for Location.
 func (e CourierLocationEvent) MarshalJSON() ([]byte, error) {
 	type Alias CourierLocationEvent
@@ -68,6 +113,35 @@ func (e CourierLocationEvent) MarshalJSON() ([]byte, error) {
 	return data, nil
 }
 
+// UnmarshalJSON restores the event from the flat latitude/longitude form written by MarshalJSON.
+func (e *CourierLocationEvent) UnmarshalJSON(data []byte) error {
+	type Alias CourierLocationEvent
+
+	aux := struct {
+		*Alias
+
+		Location  json.RawMessage `json:"location"`
+		Latitude  float64         `json:"latitude"`
+		Longitude float64         `json:"longitude"`
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return fmt.Errorf("unmarshal courier location event: %w", err)
+	}
+
+	location, err := NewLocation(aux.Latitude, aux.Longitude)
+	if err != nil {
+		return fmt.Errorf("unmarshal courier location event: %w", err)
+	}
+
+	e.Location = location
+
+	return nil
+}
+
 // ToJSON serializes the event to JSON bytes.
 func (e CourierLocationEvent) ToJSON() ([]byte, error) {
 	data, err := json.Marshal(e)
@@ -0,0 +1,44 @@
+package vo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCourierLocationEvent_JSONRoundTrip(t *testing.T) {
+	event := NewCourierLocationEvent("courier-1", MustNewLocation(52.5200, 13.4050), CourierStatusMoving).
+		WithSpeed(30).
+		WithHeading(90).
+		WithRouteID("route-1").
+		WithOrderID("order-1").
+		WithProgress(42.5).
+		WithAccuracy(5).
+		WithBatteryPercent(87.5)
+	event.Timestamp = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	data, err := event.ToJSON()
+	require.NoError(t, err)
+
+	var decoded CourierLocationEvent
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.Equal(t, event, decoded)
+}
+
+func TestCourierLocationEvent_UnmarshalInvalidLocation(t *testing.T) {
+	var decoded CourierLocationEvent
+
+	err := json.Unmarshal([]byte(`{"courier_id":"courier-1","latitude":91,"longitude":0}`), &decoded)
+	require.ErrorIs(t, err, ErrInvalidLatitude)
+}
+
+func TestCourierLocationEvent_WithBatteryPercentClamps(t *testing.T) {
+	event := NewCourierLocationEvent("courier-1", MustNewLocation(52.5200, 13.4050), CourierStatusMoving)
+
+	assert.InDelta(t, 100.0, event.WithBatteryPercent(120).BatteryPercent, 1e-9)
+	assert.Zero(t, event.WithBatteryPercent(-3).BatteryPercent)
+}
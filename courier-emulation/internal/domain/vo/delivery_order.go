@@ -12,6 +12,8 @@ type DeliveryOrder struct {
 	pickupLocation   Location
 	deliveryLocation Location
 	assignedAt       time.Time
+	// deliveryDeadline is the end of the desired delivery window; zero when the order has none
+	deliveryDeadline time.Time
 }
 
 // NewDeliveryOrder creates a new DeliveryOrder.
@@ -33,6 +35,14 @@ func NewDeliveryOrder(
 	}
 }
 
+// WithDeliveryDeadline returns a copy of the order that should be delivered by deadline,
+// the end of its desired delivery window. A zero deadline means none.
+func (o DeliveryOrder) WithDeliveryDeadline(deadline time.Time) DeliveryOrder {
+	o.deliveryDeadline = deadline
+
+	return o
+}
+
 // OrderID returns the order ID.
 func (o DeliveryOrder) OrderID() string {
 	return o.orderID
@@ -58,6 +68,21 @@ func (o DeliveryOrder) AssignedAt() time.Time {
 	return o.assignedAt
 }
 
+// DeliveryDeadline returns the end of the desired delivery window; zero when the order has none.
+func (o DeliveryOrder) DeliveryDeadline() time.Time {
+	return o.deliveryDeadline
+}
+
+// Overdue returns how long after its delivery deadline the order is delivered at deliveredAt;
+// zero when it is on time or has no deadline.
+func (o DeliveryOrder) Overdue(deliveredAt time.Time) time.Duration {
+	if o.deliveryDeadline.IsZero() || !deliveredAt.After(o.deliveryDeadline) {
+		return 0
+	}
+
+	return deliveredAt.Sub(o.deliveryDeadline)
+}
+
 // DistanceToPickup calculates the distance from a location to the pickup point.
 func (o DeliveryOrder) DistanceToPickup(from Location) float64 {
 	return from.DistanceTo(o.pickupLocation)
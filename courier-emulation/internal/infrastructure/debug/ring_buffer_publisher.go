@@ -0,0 +1,104 @@
+// Package debug holds in-process sinks for inspecting a running simulation.
+package debug
+
+import (
+	"context"
+	"sync"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+)
+
+// DefaultRingBufferSize is the number of events retained per courier when no size is given.
+const DefaultRingBufferSize = 100
+
+// ring is a fixed-size buffer of one courier's most recent events.
+type ring struct {
+	events []vo.CourierLocationEvent
+	next   int // index the next event is written to
+	full   bool
+}
+
+func (r *ring) push(event vo.CourierLocationEvent) {
+	r.events[r.next] = event
+	r.next = (r.next + 1) % len(r.events)
+
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// snapshot returns the retained events, oldest first.
+func (r *ring) snapshot() []vo.CourierLocationEvent {
+	if !r.full {
+		return append([]vo.CourierLocationEvent(nil), r.events[:r.next]...)
+	}
+
+	out := make([]vo.CourierLocationEvent, 0, len(r.events))
+	out = append(out, r.events[r.next:]...)
+
+	return append(out, r.events[:r.next]...)
+}
+
+// RingBufferPublisher retains the last N location events per courier in memory.
+// It implements services.LocationPublisher so it can sit next to the Kafka publisher
+// behind a MultiLocationPublisher and back a debug endpoint showing recent positions.
+// Memory is bounded by N events per courier; it is safe for concurrent use.
+type RingBufferPublisher struct {
+	mu    sync.RWMutex
+	rings map[string]*ring
+	size  int
+}
+
+// NewRingBufferPublisher creates a publisher that keeps the last size events per courier.
+func NewRingBufferPublisher(size int) *RingBufferPublisher {
+	if size <= 0 {
+		size = DefaultRingBufferSize
+	}
+
+	return &RingBufferPublisher{
+		rings: make(map[string]*ring),
+		size:  size,
+	}
+}
+
+// PublishLocation records the event, evicting the courier's oldest event once the buffer is full.
+//
+//nolint:gocritic // CourierLocationEvent is an immutable value object in this boundary.
+func (p *RingBufferPublisher) PublishLocation(_ context.Context, event vo.CourierLocationEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	r, ok := p.rings[event.CourierID]
+	if !ok {
+		r = &ring{events: make([]vo.CourierLocationEvent, p.size)}
+		p.rings[event.CourierID] = r
+	}
+
+	r.push(event)
+
+	return nil
+}
+
+// Snapshot returns a copy of the retained events of a courier, oldest first;
+// nil when nothing was published for it.
+func (p *RingBufferPublisher) Snapshot(courierID string) []vo.CourierLocationEvent {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	r, ok := p.rings[courierID]
+	if !ok {
+		return nil
+	}
+
+	return r.snapshot()
+}
+
+// Close releases the retained events.
+func (p *RingBufferPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.rings = make(map[string]*ring)
+
+	return nil
+}
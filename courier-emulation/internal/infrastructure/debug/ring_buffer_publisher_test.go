@@ -0,0 +1,86 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/services"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ services.LocationPublisher = (*RingBufferPublisher)(nil)
+
+func locationEvent(courierID string, seq int) vo.CourierLocationEvent {
+	event := vo.NewCourierLocationEvent(courierID, vo.MustNewLocation(52.52, 13.405), vo.CourierStatusMoving)
+	event.RouteID = fmt.Sprintf("route-%d", seq)
+
+	return event
+}
+
+func routeIDs(events []vo.CourierLocationEvent) []string {
+	ids := make([]string, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.RouteID)
+	}
+
+	return ids
+}
+
+func TestRingBufferPublisher_RetainsLastN(t *testing.T) {
+	ctx := context.Background()
+	publisher := NewRingBufferPublisher(3)
+
+	for seq := 1; seq <= 5; seq++ {
+		require.NoError(t, publisher.PublishLocation(ctx, locationEvent("courier-1", seq)))
+	}
+
+	require.NoError(t, publisher.PublishLocation(ctx, locationEvent("courier-2", 1)))
+
+	assert.Equal(t, []string{"route-3", "route-4", "route-5"}, routeIDs(publisher.Snapshot("courier-1")))
+	assert.Equal(t, []string{"route-1"}, routeIDs(publisher.Snapshot("courier-2")), "buffers are per courier")
+	assert.Nil(t, publisher.Snapshot("courier-3"))
+}
+
+func TestRingBufferPublisher_SnapshotIsACopy(t *testing.T) {
+	ctx := context.Background()
+	publisher := NewRingBufferPublisher(2)
+
+	require.NoError(t, publisher.PublishLocation(ctx, locationEvent("courier-1", 1)))
+
+	snapshot := publisher.Snapshot("courier-1")
+
+	require.NoError(t, publisher.PublishLocation(ctx, locationEvent("courier-1", 2)))
+	require.NoError(t, publisher.PublishLocation(ctx, locationEvent("courier-1", 3)))
+
+	assert.Equal(t, []string{"route-1"}, routeIDs(snapshot))
+	assert.Equal(t, []string{"route-2", "route-3"}, routeIDs(publisher.Snapshot("courier-1")))
+}
+
+func TestRingBufferPublisher_ConcurrentPublish(t *testing.T) {
+	ctx := context.Background()
+	publisher := NewRingBufferPublisher(10)
+
+	var wg sync.WaitGroup
+
+	for worker := range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for seq := range 100 {
+				_ = publisher.PublishLocation(ctx, locationEvent(fmt.Sprintf("courier-%d", worker%2), seq))
+				_ = publisher.Snapshot("courier-0")
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Len(t, publisher.Snapshot("courier-0"), 10)
+	assert.Len(t, publisher.Snapshot("courier-1"), 10)
+}
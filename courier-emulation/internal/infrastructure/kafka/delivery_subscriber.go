@@ -3,13 +3,19 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill-kafka/v3/pkg/kafka"
 	"github.com/ThreeDotsLabs/watermill/message"
+	sdkwatermill "github.com/shortlink-org/go-sdk/watermill"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain"
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
 )
 
@@ -17,10 +23,22 @@ const (
 	// TopicOrderAssigned is the Kafka topic for order assignment events from Delivery Service.
 	// Format: {domain}.{entity}.{event}.v1
 	TopicOrderAssigned = "delivery.order.assigned.v1"
+	// TopicOrderCancelled is the Kafka topic for orders cancelled after courier assignment.
+	TopicOrderCancelled = "delivery.order.cancelled.v1"
 	// ConsumerGroupCourierEmulation is the consumer group for this service.
 	ConsumerGroupCourierEmulation = "courier-emulation"
+
+	// dlqTopicSuffix is appended to a topic to name its dead letter queue.
+	dlqTopicSuffix = ".DLQ"
+
+	defaultSubscriberMaxAttempts     = 5
+	defaultSubscriberDedupTTL        = 24 * time.Hour
+	defaultSubscriberShutdownTimeout = 10 * time.Second
 )
 
+// errPoisonMessage marks a message that can never be handled, e.g. a payload that is not valid JSON.
+var errPoisonMessage = errors.New("poison message")
+
 // Address represents a delivery address with location coordinates.
 // Matches proto: domain.delivery.common.v1.Address
 type Address struct {
@@ -52,48 +70,109 @@ type OrderAssignedEvent struct {
 	OccurredAt      time.Time      `json:"occurred_at"`
 }
 
-// OrderAssignmentHandler handles order assignment events.
+// OrderCancelledEvent represents an order cancelled after it was assigned to a courier.
+type OrderCancelledEvent struct {
+	PackageID   string    `json:"package_id"`
+	CourierID   string    `json:"courier_id"`
+	Reason      string    `json:"reason,omitempty"`
+	CancelledAt time.Time `json:"cancelled_at"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// OrderAssignmentHandler handles order assignment and cancellation events.
 type OrderAssignmentHandler interface {
 	//nolint:gocritic // Kafka event payloads are intentionally passed by value as immutable messages.
 	HandleOrderAssigned(ctx context.Context, event OrderAssignedEvent) error
+	HandleOrderCancelled(ctx context.Context, event OrderCancelledEvent) error
+}
+
+// InitialOffset selects where the consumer group starts reading a partition it has no committed offset for.
+//
+// It only applies on the group's first start (or after its committed offsets expired): once the group has
+// committed an offset, consumption resumes from there regardless of this setting. To replay a topic from
+// the beginning, use InitialOffsetEarliest together with a new consumer group.
+type InitialOffset string
+
+const (
+	// InitialOffsetLatest skips the backlog and consumes only messages produced after the subscriber starts.
+	InitialOffsetLatest InitialOffset = "latest"
+	// InitialOffsetEarliest consumes the whole retained backlog, e.g. for replay or bootstrap.
+	InitialOffsetEarliest InitialOffset = "earliest"
+)
+
+// saramaOffset maps the offset to its sarama value; an empty offset means latest.
+func (o InitialOffset) saramaOffset() (int64, error) {
+	switch InitialOffset(strings.ToLower(strings.TrimSpace(string(o)))) {
+	case "", InitialOffsetLatest:
+		return sarama.OffsetNewest, nil
+	case InitialOffsetEarliest:
+		return sarama.OffsetOldest, nil
+	default:
+		return 0, fmt.Errorf("%w: %q (want %q or %q)", ErrUnknownInitialOffset, o, InitialOffsetEarliest, InitialOffsetLatest)
+	}
 }
 
 // DeliverySubscriberConfig holds configuration for the Kafka subscriber.
 type DeliverySubscriberConfig struct {
-	Brokers       []string
-	ConsumerGroup string
+	Brokers         []string
+	ConsumerGroup   string
+	InitialOffset   InitialOffset // where a consumer group without committed offsets starts reading
+	MaxAttempts     int           // failed handling attempts before a message is moved to the DLQ
+	DedupTTL        time.Duration // how long a started package is remembered to drop replayed assignments
+	ShutdownTimeout time.Duration // how long Stop waits for messages being handled to finish
 }
 
 // DefaultDeliverySubscriberConfig returns default configuration.
 func DefaultDeliverySubscriberConfig() DeliverySubscriberConfig {
 	return DeliverySubscriberConfig{
-		Brokers:       []string{"localhost:9092"},
-		ConsumerGroup: ConsumerGroupCourierEmulation,
+		Brokers:         []string{"localhost:9092"},
+		ConsumerGroup:   ConsumerGroupCourierEmulation,
+		InitialOffset:   InitialOffsetLatest,
+		MaxAttempts:     defaultSubscriberMaxAttempts,
+		DedupTTL:        defaultSubscriberDedupTTL,
+		ShutdownTimeout: defaultSubscriberShutdownTimeout,
 	}
 }
 
 // DeliverySubscriber subscribes to delivery events from Kafka.
 type DeliverySubscriber struct {
-	subscriber message.Subscriber
-	handler    OrderAssignmentHandler
-	logger     watermill.LoggerAdapter
-	stopCh     chan struct{}
+	subscriber  message.Subscriber
+	handler     OrderAssignmentHandler
+	logger      watermill.LoggerAdapter
+	stopCh      chan struct{}
+	maxAttempts int
+	dedup       *packageDedup
+	attempts    *attemptCounter
+
+	// running is set once both topics are subscribed and cleared when consuming stops.
+	running atomic.Bool
+
+	// inFlight tracks the consume loops so Stop can wait for the messages they are handling.
+	inFlight        sync.WaitGroup
+	shutdownTimeout time.Duration
+
+	// dlq receives poison and repeatedly failing messages; nil drops them after logging.
+	dlq message.Publisher
 }
 
 // NewDeliverySubscriber creates a new Kafka delivery subscriber.
+// dlqPublisher is optional; without it rejected messages are only logged.
 //
 //nolint:whitespace // Multiline constructor signature is kept compact for readability.
 func NewDeliverySubscriber(
 	config DeliverySubscriberConfig,
 	handler OrderAssignmentHandler,
 	logger watermill.LoggerAdapter,
+	dlqPublisher message.Publisher,
 ) (*DeliverySubscriber, error) {
 	if logger == nil {
 		logger = watermill.NewStdLogger(false, false)
 	}
 
-	saramaConfig := kafka.DefaultSaramaSubscriberConfig()
-	saramaConfig.Consumer.Offsets.Initial = sarama.OffsetNewest
+	saramaConfig, err := newSaramaSubscriberConfig(config)
+	if err != nil {
+		return nil, err
+	}
 
 	subscriber, err := kafka.NewSubscriber(
 		kafka.SubscriberConfig{
@@ -108,28 +187,126 @@ func NewDeliverySubscriber(
 		return nil, fmt.Errorf("new kafka subscriber: %w", err)
 	}
 
+	return newDeliverySubscriber(config, subscriber, handler, logger, dlqPublisher), nil
+}
+
+// newSaramaSubscriberConfig builds the sarama consumer settings for config.
+func newSaramaSubscriberConfig(config DeliverySubscriberConfig) (*sarama.Config, error) {
+	offset, err := config.InitialOffset.saramaOffset()
+	if err != nil {
+		return nil, err
+	}
+
+	saramaConfig := kafka.DefaultSaramaSubscriberConfig()
+	saramaConfig.Consumer.Offsets.Initial = offset
+
+	return saramaConfig, nil
+}
+
+//nolint:whitespace // Multiline constructor signature is kept compact for readability.
+func newDeliverySubscriber(
+	config DeliverySubscriberConfig,
+	subscriber message.Subscriber,
+	handler OrderAssignmentHandler,
+	logger watermill.LoggerAdapter,
+	dlqPublisher message.Publisher,
+) *DeliverySubscriber {
+	shutdownTimeout := config.ShutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultSubscriberShutdownTimeout
+	}
+
 	return &DeliverySubscriber{
-		subscriber: subscriber,
-		handler:    handler,
-		logger:     logger,
-		stopCh:     make(chan struct{}),
-	}, nil
+		subscriber:      subscriber,
+		handler:         handler,
+		logger:          logger,
+		stopCh:          make(chan struct{}),
+		maxAttempts:     config.MaxAttempts,
+		dedup:           newPackageDedup(config.DedupTTL),
+		attempts:        newAttemptCounter(),
+		shutdownTimeout: shutdownTimeout,
+		dlq:             dlqPublisher,
+	}
 }
 
-// Start starts consuming messages from the order assigned topic.
+// Start starts consuming messages from the order assigned and order cancelled topics.
 func (s *DeliverySubscriber) Start(ctx context.Context) error {
 	messages, err := s.subscriber.Subscribe(ctx, TopicOrderAssigned)
 	if err != nil {
 		return fmt.Errorf("subscribe to %s: %w", TopicOrderAssigned, err)
 	}
 
-	go s.processMessages(ctx, messages)
+	cancellations, err := s.subscriber.Subscribe(ctx, TopicOrderCancelled)
+	if err != nil {
+		return fmt.Errorf("subscribe to %s: %w", TopicOrderCancelled, err)
+	}
+
+	s.inFlight.Go(func() { s.processMessages(ctx, messages) })
+	s.inFlight.Go(func() { s.processCancellations(ctx, cancellations) })
+
+	s.running.Store(true)
+	context.AfterFunc(ctx, func() { s.running.Store(false) })
 
 	return nil
 }
 
-// processMessages processes incoming messages.
+// Running reports whether the subscriber is consuming: Start succeeded and neither
+// its context was cancelled nor Stop was called.
+func (s *DeliverySubscriber) Running() bool {
+	return s.running.Load()
+}
+
+// processMessages processes incoming order assigned messages.
+// A package that was already started is acked without starting a second simulation.
 func (s *DeliverySubscriber) processMessages(ctx context.Context, messages <-chan *message.Message) {
+	s.consume(ctx, messages, TopicOrderAssigned, "order assigned", func(ctx context.Context, payload []byte) error {
+		var event OrderAssignedEvent
+
+		err := json.Unmarshal(payload, &event)
+		if err != nil {
+			return fmt.Errorf("%w: unmarshal: %w", errPoisonMessage, err)
+		}
+
+		if s.dedup.Seen(event.PackageID) {
+			s.logger.Info("Skipping duplicate order assigned event", watermill.LogFields{"package_id": event.PackageID})
+
+			return nil
+		}
+
+		err = s.handler.HandleOrderAssigned(ctx, event)
+		if err != nil {
+			return err
+		}
+
+		s.dedup.Remember(event.PackageID)
+
+		return nil
+	})
+}
+
+// processCancellations processes incoming order cancelled messages.
+func (s *DeliverySubscriber) processCancellations(ctx context.Context, messages <-chan *message.Message) {
+	s.consume(ctx, messages, TopicOrderCancelled, "order cancelled", func(ctx context.Context, payload []byte) error {
+		var event OrderCancelledEvent
+
+		err := json.Unmarshal(payload, &event)
+		if err != nil {
+			return fmt.Errorf("%w: unmarshal: %w", errPoisonMessage, err)
+		}
+
+		return s.handler.HandleOrderCancelled(ctx, event)
+	})
+}
+
+// consume acks messages that handle succeeds for and nacks the rest for redelivery.
+// Poison messages and messages that failed maxAttempts times are moved to the topic's DLQ.
+func (s *DeliverySubscriber) consume(
+	ctx context.Context,
+	messages <-chan *message.Message,
+	topic string,
+	eventName string,
+	handle func(ctx context.Context, payload []byte) error,
+) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -141,45 +318,107 @@ func (s *DeliverySubscriber) processMessages(ctx context.Context, messages <-cha
 				continue
 			}
 
-			var event OrderAssignedEvent
-
-			err := json.Unmarshal(msg.Payload, &event)
-			if err != nil {
-				s.logger.Error("Failed to unmarshal order assigned event", err, nil)
-				msg.Nack()
+			err := handle(ctx, msg.Payload)
+			if err == nil {
+				s.attempts.Forget(msg.UUID)
+				msg.Ack()
 
 				continue
 			}
 
-			err = s.handler.HandleOrderAssigned(ctx, event)
-			if err != nil {
-				s.logger.Error("Failed to handle order assigned event", err, nil)
-				msg.Nack()
+			s.logger.Error("Failed to handle "+eventName+" event", err, watermill.LogFields{"uuid": msg.UUID})
+
+			if errors.Is(err, errPoisonMessage) || s.attempts.Fail(msg.UUID) >= s.maxAttempts {
+				s.attempts.Forget(msg.UUID)
+				s.rejectToDLQ(ctx, msg, topic+dlqTopicSuffix, err)
 
 				continue
 			}
 
-			msg.Ack()
+			msg.Nack()
 		}
 	}
 }
 
-// Stop stops the subscriber.
+// rejectToDLQ moves a message that cannot be handled to the DLQ. The message is only
+// acked once the DLQ holds it, so a DLQ outage redelivers instead of losing the event.
+func (s *DeliverySubscriber) rejectToDLQ(ctx context.Context, msg *message.Message, dlqTopic string, cause error) {
+	if s.dlq == nil {
+		s.logger.Error("Rejected message, no DLQ configured", cause, watermill.LogFields{"uuid": msg.UUID})
+		msg.Ack()
+
+		return
+	}
+
+	err := sdkwatermill.PublishDLQ(ctx, s.dlq, dlqTopic, sdkwatermill.DLQEvent{
+		FailedAt:    time.Now().UTC(),
+		Reason:      cause.Error(),
+		OriginalMsg: msg,
+		ServiceName: ConsumerGroupCourierEmulation,
+	})
+	if err != nil {
+		s.logger.Error("Failed to publish rejected message to DLQ", err, watermill.LogFields{"uuid": msg.UUID})
+		msg.Nack()
+
+		return
+	}
+
+	s.logger.Info("Rejected message to DLQ", watermill.LogFields{"uuid": msg.UUID, "dlq_topic": dlqTopic})
+	msg.Ack()
+}
+
+// Stop stops consuming and waits up to ShutdownTimeout for the messages being handled to finish
+// before closing the subscriber. Handlers still running at the deadline are left to complete on
+// their own and ErrShutdownTimeout is returned.
 func (s *DeliverySubscriber) Stop() error {
+	s.running.Store(false)
 	close(s.stopCh)
 
+	var waitErr error
+	if !waitTimeout(&s.inFlight, s.shutdownTimeout) {
+		waitErr = fmt.Errorf("%w after %s", ErrShutdownTimeout, s.shutdownTimeout)
+	}
+
 	err := s.subscriber.Close()
 	if err != nil {
 		return fmt.Errorf("subscriber close: %w", err)
 	}
 
-	return nil
+	if s.dlq != nil {
+		err = s.dlq.Close()
+		if err != nil {
+			return fmt.Errorf("dlq publisher close: %w", err)
+		}
+	}
+
+	return waitErr
+}
+
+// waitTimeout waits for wg and reports whether it finished before timeout elapsed.
+func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+	done := make(chan struct{})
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
 }
 
-// DeliverySimulatorInterface defines the interface for starting deliveries.
+// DeliverySimulatorInterface defines the interface for starting and cancelling deliveries.
 type DeliverySimulatorInterface interface {
 	//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
 	StartDelivery(ctx context.Context, courierID string, order vo.DeliveryOrder) error
+	CancelDelivery(ctx context.Context, courierID, packageID string) error
 }
 
 // CourierEmulationHandler implements OrderAssignmentHandler using DeliverySimulator.
@@ -195,6 +434,9 @@ func NewCourierEmulationHandler(deliverySimulator DeliverySimulatorInterface) *C
 }
 
 // HandleOrderAssigned handles a package assignment by starting a delivery simulation.
+// A replayed assignment is a no-op in StartDelivery; an assignment for a courier that is busy with
+// another package or still on its free-roaming route cannot be simulated and is acknowledged as well,
+// and so is an unroutable one, which StartDelivery already resolved as NOT_DELIVERED.
 //
 //nolint:gocritic // Kafka event payloads are intentionally passed by value as immutable messages.
 func (h *CourierEmulationHandler) HandleOrderAssigned(ctx context.Context, event OrderAssignedEvent) error {
@@ -216,12 +458,33 @@ func (h *CourierEmulationHandler) HandleOrderAssigned(ctx context.Context, event
 		pickup,
 		delivery,
 		event.AssignedAt,
-	)
+	).WithDeliveryDeadline(event.DeliveryPeriod.EndTime)
 
 	startErr := h.deliverySimulator.StartDelivery(ctx, event.CourierID, order)
+	if errors.Is(startErr, domain.ErrCourierHasActiveDelivery) ||
+		errors.Is(startErr, domain.ErrCourierNotIdle) ||
+		errors.Is(startErr, domain.ErrUnroutable) {
+		return nil
+	}
+
 	if startErr != nil {
 		return fmt.Errorf("start delivery: %w", startErr)
 	}
 
 	return nil
 }
+
+// HandleOrderCancelled stops the courier's in-flight delivery of the package.
+// A cancellation for a delivery that already finished (or was never started here) is acknowledged as a no-op.
+func (h *CourierEmulationHandler) HandleOrderCancelled(ctx context.Context, event OrderCancelledEvent) error {
+	err := h.deliverySimulator.CancelDelivery(ctx, event.CourierID, event.PackageID)
+	if errors.Is(err, domain.ErrDeliveryNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("cancel delivery: %w", err)
+	}
+
+	return nil
+}
@@ -4,17 +4,25 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/IBM/sarama"
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
 	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
 )
 
 type mockOrderAssignmentHandler struct {
-	events chan OrderAssignedEvent
-	err    error
+	events        chan OrderAssignedEvent
+	cancellations chan OrderCancelledEvent
+	err           error
 }
 
 func (m *mockOrderAssignmentHandler) HandleOrderAssigned(_ context.Context, event OrderAssignedEvent) error {
@@ -22,15 +30,85 @@ func (m *mockOrderAssignmentHandler) HandleOrderAssigned(_ context.Context, even
 	return m.err
 }
 
+func (m *mockOrderAssignmentHandler) HandleOrderCancelled(_ context.Context, event OrderCancelledEvent) error {
+	m.cancellations <- event
+	return m.err
+}
+
+type mockDeliverySimulator struct {
+	startErr  error
+	cancelErr error
+	cancelled []string
+}
+
+func (m *mockDeliverySimulator) StartDelivery(context.Context, string, vo.DeliveryOrder) error {
+	return m.startErr
+}
+
+func (m *mockDeliverySimulator) CancelDelivery(_ context.Context, courierID, packageID string) error {
+	m.cancelled = append(m.cancelled, courierID+"/"+packageID)
+	return m.cancelErr
+}
+
+func newTestDeliverySubscriber(handler OrderAssignmentHandler, dlq message.Publisher) *DeliverySubscriber {
+	config := DefaultDeliverySubscriberConfig()
+	config.MaxAttempts = 3
+
+	return newDeliverySubscriber(config, nil, handler, watermill.NopLogger{}, dlq)
+}
+
+func newAssignedMessage(t *testing.T, packageID string) *message.Message {
+	t.Helper()
+
+	payload, err := json.Marshal(OrderAssignedEvent{
+		PackageID:       packageID,
+		CourierID:       "courier-1",
+		PickupAddress:   Address{Latitude: 52.52, Longitude: 13.405},
+		DeliveryAddress: Address{Latitude: 52.53, Longitude: 13.415},
+	})
+	require.NoError(t, err)
+
+	return message.NewMessage(watermill.NewUUID(), payload)
+}
+
+func requireAcked(t *testing.T, msg *message.Message) {
+	t.Helper()
+
+	select {
+	case <-msg.Acked():
+	case <-msg.Nacked():
+		t.Fatal("expected message to be acked, got nack")
+	case <-time.After(time.Second):
+		t.Fatal("expected message to be acked")
+	}
+}
+
+func requireDLQMessage(t *testing.T, pubSub *gochannel.GoChannel, topic string) *message.Message {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+
+	dlqMessages, err := pubSub.Subscribe(ctx, topic)
+	require.NoError(t, err)
+
+	select {
+	case dlqMsg := <-dlqMessages:
+		dlqMsg.Ack()
+
+		return dlqMsg
+	case <-ctx.Done():
+		t.Fatalf("expected a message in %s", topic)
+
+		return nil
+	}
+}
+
 func TestDeliverySubscriber_ProcessMessages_HandlesJSONAssignedEvent(t *testing.T) {
 	t.Parallel()
 
 	handler := &mockOrderAssignmentHandler{events: make(chan OrderAssignedEvent, 1)}
-	subscriber := &DeliverySubscriber{
-		handler: handler,
-		logger:  watermill.NewStdLogger(false, false),
-		stopCh:  make(chan struct{}),
-	}
+	subscriber := newTestDeliverySubscriber(handler, nil)
 
 	ctx, cancel := context.WithCancel(t.Context())
 	defer cancel()
@@ -75,3 +153,303 @@ func TestDeliverySubscriber_ProcessMessages_HandlesJSONAssignedEvent(t *testing.
 		t.Fatal("expected message to be acked")
 	}
 }
+
+func TestDeliverySubscriber_ProcessCancellations_HandlesJSONCancelledEvent(t *testing.T) {
+	t.Parallel()
+
+	handler := &mockOrderAssignmentHandler{cancellations: make(chan OrderCancelledEvent, 1)}
+	subscriber := newTestDeliverySubscriber(handler, nil)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	messages := make(chan *message.Message, 1)
+	go subscriber.processCancellations(ctx, messages)
+
+	payload, err := json.Marshal(OrderCancelledEvent{
+		PackageID:   "pkg-1",
+		CourierID:   "courier-1",
+		CancelledAt: time.Date(2026, time.March, 11, 10, 5, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	msg := message.NewMessage(watermill.NewUUID(), payload)
+	messages <- msg
+
+	select {
+	case event := <-handler.cancellations:
+		require.Equal(t, "pkg-1", event.PackageID)
+		require.Equal(t, "courier-1", event.CourierID)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for cancelled event")
+	}
+
+	select {
+	case <-msg.Acked():
+	case <-time.After(time.Second):
+		t.Fatal("expected message to be acked")
+	}
+}
+
+func TestCourierEmulationHandler_HandleOrderCancelled(t *testing.T) {
+	t.Parallel()
+
+	simulator := &mockDeliverySimulator{}
+	handler := NewCourierEmulationHandler(simulator)
+
+	require.NoError(t, handler.HandleOrderCancelled(t.Context(), OrderCancelledEvent{PackageID: "pkg-1", CourierID: "courier-1"}))
+	require.Equal(t, []string{"courier-1/pkg-1"}, simulator.cancelled)
+
+	// Unknown deliveries are acknowledged so the cancellation is not redelivered forever.
+	simulator.cancelErr = domain.ErrDeliveryNotFound
+	require.NoError(t, handler.HandleOrderCancelled(t.Context(), OrderCancelledEvent{PackageID: "pkg-2", CourierID: "courier-1"}))
+
+	simulator.cancelErr = errors.New("broker down")
+	require.Error(t, handler.HandleOrderCancelled(t.Context(), OrderCancelledEvent{PackageID: "pkg-3", CourierID: "courier-1"}))
+}
+
+func TestDeliverySubscriber_ProcessMessages_DropsDuplicateAssignment(t *testing.T) {
+	t.Parallel()
+
+	handler := &mockOrderAssignmentHandler{events: make(chan OrderAssignedEvent, 2)}
+	subscriber := newTestDeliverySubscriber(handler, nil)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	messages := make(chan *message.Message)
+	go subscriber.processMessages(ctx, messages)
+
+	first, replay := newAssignedMessage(t, "pkg-1"), newAssignedMessage(t, "pkg-1")
+
+	messages <- first
+	requireAcked(t, first)
+
+	messages <- replay
+	requireAcked(t, replay)
+
+	require.Len(t, handler.events, 1, "a replayed assignment must not start a second simulation")
+}
+
+func TestDeliverySubscriber_ProcessMessages_PoisonMessageGoesToDLQ(t *testing.T) {
+	t.Parallel()
+
+	pubSub := gochannel.NewGoChannel(gochannel.Config{Persistent: true}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = pubSub.Close() })
+
+	handler := &mockOrderAssignmentHandler{events: make(chan OrderAssignedEvent, 1)}
+	subscriber := newTestDeliverySubscriber(handler, pubSub)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	messages := make(chan *message.Message)
+	go subscriber.processMessages(ctx, messages)
+
+	msg := message.NewMessage(watermill.NewUUID(), []byte("{not json"))
+	messages <- msg
+	requireAcked(t, msg)
+
+	require.Empty(t, handler.events, "a poison message must not reach the handler")
+
+	dlqMsg := requireDLQMessage(t, pubSub, TopicOrderAssigned+dlqTopicSuffix)
+	require.Contains(t, dlqMsg.Metadata.Get("poison_reason"), errPoisonMessage.Error())
+}
+
+func TestDeliverySubscriber_ProcessMessages_RepeatedFailureGoesToDLQ(t *testing.T) {
+	t.Parallel()
+
+	pubSub := gochannel.NewGoChannel(gochannel.Config{Persistent: true}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = pubSub.Close() })
+
+	handler := &mockOrderAssignmentHandler{events: make(chan OrderAssignedEvent, 3), err: errors.New("osrm down")}
+	subscriber := newTestDeliverySubscriber(handler, pubSub)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	messages := make(chan *message.Message)
+	go subscriber.processMessages(ctx, messages)
+
+	msg := newAssignedMessage(t, "pkg-1")
+
+	// Redeliveries keep the message UUID; the first failures are nacked for retry
+	for range 2 {
+		redelivery := msg.Copy()
+		messages <- redelivery
+
+		select {
+		case <-redelivery.Nacked():
+		case <-time.After(time.Second):
+			t.Fatal("expected a failed attempt to be nacked")
+		}
+	}
+
+	last := msg.Copy()
+	messages <- last
+	requireAcked(t, last)
+
+	require.Len(t, handler.events, 3)
+	requireDLQMessage(t, pubSub, TopicOrderAssigned+dlqTopicSuffix)
+}
+
+func TestCourierEmulationHandler_HandleOrderAssigned_ActiveDeliveryIsDuplicate(t *testing.T) {
+	t.Parallel()
+
+	simulator := &mockDeliverySimulator{startErr: domain.ErrCourierHasActiveDelivery}
+	handler := NewCourierEmulationHandler(simulator)
+
+	event := OrderAssignedEvent{
+		PackageID:       "pkg-1",
+		CourierID:       "courier-1",
+		PickupAddress:   Address{Latitude: 52.52, Longitude: 13.405},
+		DeliveryAddress: Address{Latitude: 52.53, Longitude: 13.415},
+	}
+	require.NoError(t, handler.HandleOrderAssigned(t.Context(), event))
+
+	// A free-roaming courier still on its route cannot take the order either
+	simulator.startErr = fmt.Errorf("courier-1: %w", domain.ErrCourierNotIdle)
+	require.NoError(t, handler.HandleOrderAssigned(t.Context(), event))
+
+	// StartDelivery already resolved an unroutable delivery as NOT_DELIVERED
+	simulator.startErr = fmt.Errorf("courier-1: %w", domain.ErrUnroutable)
+	require.NoError(t, handler.HandleOrderAssigned(t.Context(), event))
+
+	simulator.startErr = errors.New("route generation failed")
+	require.Error(t, handler.HandleOrderAssigned(t.Context(), event))
+}
+
+func TestNewSaramaSubscriberConfig_InitialOffset(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset InitialOffset
+		want   int64
+	}{
+		{"default", "", sarama.OffsetNewest},
+		{"latest", InitialOffsetLatest, sarama.OffsetNewest},
+		{"earliest", InitialOffsetEarliest, sarama.OffsetOldest},
+		{"case insensitive", " Earliest ", sarama.OffsetOldest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultDeliverySubscriberConfig()
+			config.InitialOffset = tt.offset
+
+			saramaConfig, err := newSaramaSubscriberConfig(config)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, saramaConfig.Consumer.Offsets.Initial)
+		})
+	}
+
+	config := DefaultDeliverySubscriberConfig()
+	config.InitialOffset = "oldest-ish"
+
+	_, err := newSaramaSubscriberConfig(config)
+	require.ErrorIs(t, err, ErrUnknownInitialOffset)
+}
+
+// blockingOrderAssignmentHandler blocks assignments until release is closed.
+type blockingOrderAssignmentHandler struct {
+	started  chan struct{}
+	release  chan struct{}
+	finished chan struct{}
+}
+
+func (h *blockingOrderAssignmentHandler) HandleOrderAssigned(context.Context, OrderAssignedEvent) error {
+	close(h.started)
+	<-h.release
+	close(h.finished)
+
+	return nil
+}
+
+func (h *blockingOrderAssignmentHandler) HandleOrderCancelled(context.Context, OrderCancelledEvent) error {
+	return nil
+}
+
+func startBlockedDeliverySubscriber(t *testing.T, shutdownTimeout time.Duration) (*DeliverySubscriber, *blockingOrderAssignmentHandler) {
+	t.Helper()
+
+	handler := &blockingOrderAssignmentHandler{
+		started:  make(chan struct{}),
+		release:  make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+
+	pubSub := gochannel.NewGoChannel(gochannel.Config{Persistent: true}, watermill.NopLogger{})
+
+	config := DefaultDeliverySubscriberConfig()
+	config.ShutdownTimeout = shutdownTimeout
+
+	subscriber := newDeliverySubscriber(config, pubSub, handler, watermill.NopLogger{}, nil)
+	require.NoError(t, subscriber.Start(t.Context()))
+	require.NoError(t, pubSub.Publish(TopicOrderAssigned, newAssignedMessage(t, "pkg-1")))
+
+	select {
+	case <-handler.started:
+	case <-time.After(time.Second):
+		t.Fatal("handler was not invoked")
+	}
+
+	return subscriber, handler
+}
+
+func TestDeliverySubscriber_StopWaitsForInFlightHandler(t *testing.T) {
+	t.Parallel()
+
+	subscriber, handler := startBlockedDeliverySubscriber(t, time.Second)
+
+	stopped := make(chan error, 1)
+
+	go func() { stopped <- subscriber.Stop() }()
+
+	select {
+	case <-stopped:
+		t.Fatal("Stop returned while a handler was still running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(handler.release)
+
+	select {
+	case err := <-stopped:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Stop did not return after the handler finished")
+	}
+
+	select {
+	case <-handler.finished:
+	default:
+		t.Fatal("Stop returned before the handler finished")
+	}
+}
+
+func TestDeliverySubscriber_StopTimesOut(t *testing.T) {
+	t.Parallel()
+
+	subscriber, handler := startBlockedDeliverySubscriber(t, 50*time.Millisecond)
+	defer close(handler.release)
+
+	start := time.Now()
+	err := subscriber.Stop()
+
+	require.ErrorIs(t, err, ErrShutdownTimeout)
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestDeliverySubscriber_Running(t *testing.T) {
+	t.Parallel()
+
+	pubSub := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+	subscriber := newDeliverySubscriber(DefaultDeliverySubscriberConfig(), pubSub, &mockOrderAssignmentHandler{}, watermill.NopLogger{}, nil)
+
+	require.False(t, subscriber.Running(), "not running before Start")
+
+	require.NoError(t, subscriber.Start(t.Context()))
+	require.True(t, subscriber.Running())
+
+	require.NoError(t, subscriber.Stop())
+	require.False(t, subscriber.Running(), "not running after Stop")
+}
@@ -1,10 +1,45 @@
 package kafka
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Validation errors for NewDeliverOrderEvent. Callers can use errors.Is.
 var (
 	ErrReasonMustBeEmpty = errors.New("reason must be empty when delivered")
 	ErrReasonRequired    = errors.New("reason is required when not delivered")
 	ErrInvalidReason     = errors.New("invalid not_delivered reason")
+	ErrDeliveredTooFar   = errors.New("delivered too far from the delivery location")
+)
+
+// DeliveredTooFarError is returned by NewDeliverOrderEvent with WithDeliveryProximity when a DELIVERED
+// outcome is reported further than the allowed radius from the order's delivery location.
+// It matches ErrDeliveredTooFar with errors.Is.
+type DeliveredTooFarError struct {
+	DistanceMeters float64
+	RadiusMeters   float64
+}
+
+func (e *DeliveredTooFarError) Error() string {
+	return fmt.Sprintf("%s: %.0fm away, allowed %.0fm", ErrDeliveredTooFar, e.DistanceMeters, e.RadiusMeters)
+}
+
+func (e *DeliveredTooFarError) Unwrap() error {
+	return ErrDeliveredTooFar
+}
+
+// ErrUnknownInitialOffset is returned when DeliverySubscriberConfig.InitialOffset is neither earliest nor latest.
+var ErrUnknownInitialOffset = errors.New("unknown initial offset")
+
+// ErrShutdownTimeout is returned by DeliverySubscriber.Stop when messages were still being handled at the deadline.
+var ErrShutdownTimeout = errors.New("timed out waiting for in-flight messages")
+
+// ErrUnknownPartitionKey is returned by ParseLocationPartitionKey for names other than courier_id and order_id.
+var ErrUnknownPartitionKey = errors.New("unknown partition key")
+
+// Readiness errors returned by HealthChecker.Check. Callers can use errors.Is.
+var (
+	ErrSubscriberNotRunning = errors.New("delivery subscriber is not running")
+	ErrBrokersUnreachable   = errors.New("kafka brokers unreachable")
 )
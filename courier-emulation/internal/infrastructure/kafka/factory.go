@@ -26,8 +26,27 @@ func NewPickUpOrderEvent(courierID string, order vo.DeliveryOrder, location vo.L
 	}
 }
 
+// metersPerKm converts vo.Location distances (km) into meters.
+const metersPerKm = 1000.0
+
+// DeliverOrderEventOption adds a check to NewDeliverOrderEvent.
+type DeliverOrderEventOption func(*deliverOrderEventChecks)
+
+type deliverOrderEventChecks struct {
+	proximityMeters float64
+}
+
+// WithDeliveryProximity requires a DELIVERED outcome to be reported within radiusMeters of the
+// order's delivery location. A radius <= 0 disables the check; NOT_DELIVERED outcomes are never checked.
+func WithDeliveryProximity(radiusMeters float64) DeliverOrderEventOption {
+	return func(checks *deliverOrderEventChecks) {
+		checks.proximityMeters = radiusMeters
+	}
+}
+
 // NewDeliverOrderEvent creates a package delivery result event from domain objects.
 // Validates: when delivered is true, reason must be empty; when false, reason must be from whitelist (or OTHER).
+// With WithDeliveryProximity a DELIVERED outcome away from the delivery location fails with *DeliveredTooFarError.
 //
 //nolint:gocritic,whitespace // DeliveryOrder is immutable here; the multiline signature stays compact for readability.
 func NewDeliverOrderEvent(
@@ -36,17 +55,30 @@ func NewDeliverOrderEvent(
 	location vo.Location,
 	delivered bool,
 	reason NotDeliveredReason,
+	opts ...DeliverOrderEventOption,
 ) (DeliverOrderEvent, error) {
+	var checks deliverOrderEventChecks
+	for _, opt := range opts {
+		opt(&checks)
+	}
+
 	if delivered && reason != "" {
 		return DeliverOrderEvent{}, fmt.Errorf("%w: got=%q", ErrReasonMustBeEmpty, reason)
 	}
 
+	if delivered && checks.proximityMeters > 0 {
+		distanceMeters := location.DistanceTo(order.DeliveryLocation()) * metersPerKm
+		if distanceMeters > checks.proximityMeters {
+			return DeliverOrderEvent{}, &DeliveredTooFarError{DistanceMeters: distanceMeters, RadiusMeters: checks.proximityMeters}
+		}
+	}
+
 	if !delivered {
 		if reason == "" {
 			return DeliverOrderEvent{}, fmt.Errorf("%w", ErrReasonRequired)
 		}
 
-		if _, ok := validNotDeliveredReasons[reason]; !ok {
+		if !reason.IsValid() {
 			return DeliverOrderEvent{}, fmt.Errorf("%w: got=%q", ErrInvalidReason, reason)
 		}
 	}
@@ -72,3 +104,57 @@ func NewDeliverOrderEvent(
 		DeliveredAt: now,
 	}, nil
 }
+
+// NewDeliveryReassignedEvent creates a delivery reassigned event from domain objects.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func NewDeliveryReassignedEvent(fromCourierID, toCourierID string, order vo.DeliveryOrder, location vo.Location) DeliveryReassignedEvent {
+	now := time.Now().UTC()
+
+	return DeliveryReassignedEvent{
+		PackageID:     order.PackageID(),
+		FromCourierID: fromCourierID,
+		ToCourierID:   toCourierID,
+		CurrentLocation: Location{
+			Latitude:  location.Latitude(),
+			Longitude: location.Longitude(),
+			Accuracy:  defaultLocationAccuracy,
+			Timestamp: now,
+		},
+		ReassignedAt: now,
+	}
+}
+
+// NewDeliverySLABreachedEvent creates an SLA breach event for order delivered at deliveredAt.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func NewDeliverySLABreachedEvent(courierID string, order vo.DeliveryOrder, deliveredAt time.Time) DeliverySLABreachedEvent {
+	return DeliverySLABreachedEvent{
+		PackageID:        order.PackageID(),
+		CourierID:        courierID,
+		DeliveryDeadline: order.DeliveryDeadline().UTC(),
+		DeliveredAt:      deliveredAt.UTC(),
+		OverdueSeconds:   order.Overdue(deliveredAt).Seconds(),
+	}
+}
+
+// NewDeliveryRetryScheduledEvent creates a retry scheduled event for the attempt-th delivery attempt of order,
+// which failed for reason and will be repeated at retryAt.
+//
+//nolint:gocritic // DeliveryOrder is an immutable value object in this boundary.
+func NewDeliveryRetryScheduledEvent(
+	courierID string,
+	order vo.DeliveryOrder,
+	reason NotDeliveredReason,
+	attempt int,
+	retryAt time.Time,
+) DeliveryRetryScheduledEvent {
+	return DeliveryRetryScheduledEvent{
+		PackageID: order.PackageID(),
+		CourierID: courierID,
+		Reason:    reason,
+		Attempt:   attempt,
+		FailedAt:  time.Now().UTC(),
+		RetryAt:   retryAt.UTC(),
+	}
+}
@@ -0,0 +1,91 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+const (
+	// ReadinessPath is the HTTP path serving the Kafka readiness check.
+	ReadinessPath = "/ready/kafka"
+
+	defaultHealthCheckTimeout = 2 * time.Second
+)
+
+// HealthChecker reports whether the service can consume from and publish to Kafka.
+type HealthChecker struct {
+	brokers    []string
+	timeout    time.Duration
+	subscriber *DeliverySubscriber
+}
+
+// NewHealthChecker creates a checker that requests metadata from brokers, each attempt bounded by timeout.
+// subscriber is optional; without it only broker connectivity is checked.
+func NewHealthChecker(brokers []string, timeout time.Duration, subscriber *DeliverySubscriber) *HealthChecker {
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+
+	return &HealthChecker{
+		brokers:    brokers,
+		timeout:    timeout,
+		subscriber: subscriber,
+	}
+}
+
+// Check returns ErrSubscriberNotRunning when the delivery subscriber is not consuming and
+// ErrBrokersUnreachable when no broker answers a metadata request, the same brokers the publishers use.
+func (c *HealthChecker) Check(ctx context.Context) error {
+	if c.subscriber != nil && !c.subscriber.Running() {
+		return ErrSubscriberNotRunning
+	}
+
+	timeout := c.timeout
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+
+	if timeout <= 0 {
+		return fmt.Errorf("%w: %w", ErrBrokersUnreachable, context.DeadlineExceeded)
+	}
+
+	// NewClient fetches the full cluster metadata from the first broker that answers.
+	client, err := sarama.NewClient(c.brokers, newHealthSaramaConfig(timeout))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBrokersUnreachable, err)
+	}
+
+	_ = client.Close() //nolint:errcheck // the metadata request already succeeded
+
+	return nil
+}
+
+// newHealthSaramaConfig bounds every network step of the metadata request by timeout and disables retries,
+// so an unreachable cluster fails the check instead of stalling the probe.
+func newHealthSaramaConfig(timeout time.Duration) *sarama.Config {
+	cfg := sarama.NewConfig()
+	cfg.Net.DialTimeout = timeout
+	cfg.Net.ReadTimeout = timeout
+	cfg.Net.WriteTimeout = timeout
+	cfg.Metadata.Retry.Max = 0
+	cfg.Metadata.Timeout = timeout
+
+	return cfg
+}
+
+// ServeHTTP implements http.Handler: 200 when Check passes, 503 with the failure otherwise.
+func (c *HealthChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	err := c.Check(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok\n")) //nolint:errcheck // the status is already sent
+}
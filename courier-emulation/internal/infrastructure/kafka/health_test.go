@@ -0,0 +1,47 @@
+package kafka
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthChecker_SubscriberNotRunning(t *testing.T) {
+	t.Parallel()
+
+	pubSub := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+	subscriber := newDeliverySubscriber(DefaultDeliverySubscriberConfig(), pubSub, &mockOrderAssignmentHandler{}, watermill.NopLogger{}, nil)
+
+	checker := NewHealthChecker([]string{"localhost:9092"}, 0, subscriber)
+
+	require.ErrorIs(t, checker.Check(t.Context()), ErrSubscriberNotRunning)
+
+	recorder := httptest.NewRecorder()
+	checker.ServeHTTP(recorder, httptest.NewRequestWithContext(t.Context(), http.MethodGet, ReadinessPath, nil))
+
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	require.Contains(t, recorder.Body.String(), ErrSubscriberNotRunning.Error())
+}
+
+func TestHealthChecker_Brokers(t *testing.T) {
+	t.Parallel()
+
+	broker := sarama.NewMockBroker(t, 1)
+	t.Cleanup(broker.Close)
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+		"MetadataRequest":    sarama.NewMockMetadataResponse(t).SetBroker(broker.Addr(), broker.BrokerID()),
+	})
+
+	require.NoError(t, NewHealthChecker([]string{broker.Addr()}, time.Second, nil).Check(t.Context()))
+
+	unreachable := NewHealthChecker([]string{"127.0.0.1:1"}, time.Second, nil)
+	require.ErrorIs(t, unreachable.Check(t.Context()), ErrBrokersUnreachable)
+}
@@ -0,0 +1,76 @@
+package kafka
+
+import (
+	"sync"
+	"time"
+)
+
+// packageDedup remembers recently started packages so a replayed assignment is a no-op.
+// It is in-memory: after a restart the delivery simulator is empty as well.
+type packageDedup struct {
+	mu   sync.Mutex
+	ttl  time.Duration
+	seen map[string]time.Time // package ID -> expiry
+	now  func() time.Time
+}
+
+func newPackageDedup(ttl time.Duration) *packageDedup {
+	return &packageDedup{
+		ttl:  ttl,
+		seen: make(map[string]time.Time),
+		now:  time.Now,
+	}
+}
+
+// Seen reports whether the package was remembered and has not expired yet.
+func (d *packageDedup) Seen(packageID string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	expiresAt, ok := d.seen[packageID]
+
+	return ok && d.now().Before(expiresAt)
+}
+
+// Remember records the package and drops expired entries so the set stays bounded.
+func (d *packageDedup) Remember(packageID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	for id, expiresAt := range d.seen {
+		if !now.Before(expiresAt) {
+			delete(d.seen, id)
+		}
+	}
+
+	d.seen[packageID] = now.Add(d.ttl)
+}
+
+// attemptCounter counts failed deliveries per message UUID.
+type attemptCounter struct {
+	mu       sync.Mutex
+	attempts map[string]int
+}
+
+func newAttemptCounter() *attemptCounter {
+	return &attemptCounter{attempts: make(map[string]int)}
+}
+
+// Fail records a failed attempt and returns the number of failures so far.
+func (c *attemptCounter) Fail(messageID string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.attempts[messageID]++
+
+	return c.attempts[messageID]
+}
+
+// Forget drops the counter once the message is acked.
+func (c *attemptCounter) Forget(messageID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.attempts, messageID)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
@@ -16,31 +17,80 @@ const (
 	TopicCourierLocation = "delivery.courier.location_received.v1"
 )
 
+// LocationPartitionKey selects which event field location messages are partitioned by.
+// Kafka only orders messages within a partition, so the key decides whose updates stay in order.
+type LocationPartitionKey string
+
+const (
+	// LocationPartitionByCourier keeps each courier's updates in order (default).
+	LocationPartitionByCourier LocationPartitionKey = "courier_id"
+	// LocationPartitionByOrder keeps each order's updates in order. Events without an order,
+	// e.g. from couriers roaming between deliveries, fall back to the courier ID.
+	LocationPartitionByOrder LocationPartitionKey = "order_id"
+)
+
+// ParseLocationPartitionKey parses a partition key name; an empty name selects LocationPartitionByCourier.
+func ParseLocationPartitionKey(raw string) (LocationPartitionKey, error) {
+	switch key := LocationPartitionKey(strings.ToLower(strings.TrimSpace(raw))); key {
+	case "", LocationPartitionByCourier:
+		return LocationPartitionByCourier, nil
+	case LocationPartitionByOrder:
+		return LocationPartitionByOrder, nil
+	default:
+		return "", fmt.Errorf("%w: %q (want %q or %q)", ErrUnknownPartitionKey, raw, LocationPartitionByCourier, LocationPartitionByOrder)
+	}
+}
+
 // LocationPublisher publishes courier location events to Kafka.
 type LocationPublisher struct {
-	publisher message.Publisher
+	publisher    message.Publisher
+	partitionKey LocationPartitionKey
 }
 
 // NewLocationPublisher creates a new Kafka location publisher using go-sdk/watermill publisher.
-func NewLocationPublisher(publisher message.Publisher) *LocationPublisher {
+// Messages are partitioned by partitionKey; an empty key partitions by courier ID.
+func NewLocationPublisher(publisher message.Publisher, partitionKey LocationPartitionKey) *LocationPublisher {
+	if partitionKey == "" {
+		partitionKey = LocationPartitionByCourier
+	}
+
 	return &LocationPublisher{
-		publisher: publisher,
+		publisher:    publisher,
+		partitionKey: partitionKey,
 	}
 }
 
-// PublishLocation publishes a courier location event to Kafka.
+// newMessage builds the Kafka message for event with its partition key and schema headers set.
 //
 //nolint:gocritic // CourierLocationEvent is an immutable value object in this boundary.
-func (p *LocationPublisher) PublishLocation(ctx context.Context, event vo.CourierLocationEvent) error {
+func (p *LocationPublisher) newMessage(event vo.CourierLocationEvent) (*message.Message, error) {
 	payload, err := event.ToJSON()
 	if err != nil {
-		return fmt.Errorf("event to json: %w", err)
+		return nil, fmt.Errorf("event to json: %w", err)
 	}
 
-	msg := message.NewMessage(watermill.NewUUID(), payload)
+	return newEventMessage(payload, p.partitionValue(event)), nil
+}
+
+// partitionValue returns the value of the configured partition key for event.
+//
+//nolint:gocritic // CourierLocationEvent is an immutable value object in this boundary.
+func (p *LocationPublisher) partitionValue(event vo.CourierLocationEvent) string {
+	if p.partitionKey == LocationPartitionByOrder && event.OrderID != "" {
+		return event.OrderID
+	}
 
-	// Set partition key to courier ID for ordered delivery per courier
-	msg.Metadata.Set("partition_key", event.CourierID)
+	return event.CourierID
+}
+
+// PublishLocation publishes a courier location event to Kafka.
+//
+//nolint:gocritic // CourierLocationEvent is an immutable value object in this boundary.
+func (p *LocationPublisher) PublishLocation(ctx context.Context, event vo.CourierLocationEvent) error {
+	msg, err := p.newMessage(event)
+	if err != nil {
+		return err
+	}
 
 	err = p.publisher.Publish(TopicCourierLocation, msg)
 	if err != nil {
@@ -55,13 +105,11 @@ func (p *LocationPublisher) PublishLocationBatch(ctx context.Context, events []v
 	messages := make([]*message.Message, 0, len(events))
 
 	for _, event := range events {
-		payload, err := event.ToJSON()
+		msg, err := p.newMessage(event)
 		if err != nil {
-			return fmt.Errorf("event to json: %w", err)
+			return err
 		}
 
-		msg := message.NewMessage(watermill.NewUUID(), payload)
-		msg.Metadata.Set("partition_key", event.CourierID)
 		messages = append(messages, msg)
 	}
 
@@ -89,6 +137,9 @@ func (p *LocationPublisher) Close() error {
 type StatusPublisher interface {
 	PublishPickUp(ctx context.Context, event PickUpOrderEvent) error
 	PublishDelivery(ctx context.Context, event DeliverOrderEvent) error
+	PublishReassigned(ctx context.Context, event DeliveryReassignedEvent) error
+	PublishSLABreached(ctx context.Context, event DeliverySLABreachedEvent) error
+	PublishRetryScheduled(ctx context.Context, event DeliveryRetryScheduledEvent) error
 	Close() error
 }
 
@@ -113,11 +164,10 @@ func (p *KafkaStatusPublisher) PublishPickUp(ctx context.Context, event PickUpOr
 		return fmt.Errorf("marshal pickup event: %w", err)
 	}
 
-	msg := message.NewMessage(watermill.NewUUID(), payload)
 	// Partition by package so lifecycle order is preserved.
-	msg.Metadata.Set(metadataKeyPartitionKey, event.PackageID)
+	msg := newEventMessage(payload, event.PackageID)
 
-	err = p.publisher.Publish(TopicPickUpOrder, msg)
+	err = publishWithContext(ctx, p.publisher, TopicPickUpOrder, msg)
 	if err != nil {
 		return fmt.Errorf("publish pickup: %w", err)
 	}
@@ -134,11 +184,10 @@ func (p *KafkaStatusPublisher) PublishDelivery(ctx context.Context, event Delive
 		return fmt.Errorf("marshal delivery event: %w", err)
 	}
 
-	msg := message.NewMessage(watermill.NewUUID(), payload)
 	// Partition by package so lifecycle order is preserved.
-	msg.Metadata.Set(metadataKeyPartitionKey, event.PackageID)
+	msg := newEventMessage(payload, event.PackageID)
 
-	err = p.publisher.Publish(TopicDeliverOrder, msg)
+	err = publishWithContext(ctx, p.publisher, TopicDeliverOrder, msg)
 	if err != nil {
 		return fmt.Errorf("publish delivery: %w", err)
 	}
@@ -146,6 +195,100 @@ func (p *KafkaStatusPublisher) PublishDelivery(ctx context.Context, event Delive
 	return nil
 }
 
+// PublishReassigned publishes a delivery reassigned event.
+//
+//nolint:gocritic // Kafka event payloads are intentionally passed by value as immutable messages.
+func (p *KafkaStatusPublisher) PublishReassigned(ctx context.Context, event DeliveryReassignedEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal reassigned event: %w", err)
+	}
+
+	// Partition by package so the handover stays ordered with the package's other lifecycle events.
+	msg := newEventMessage(payload, event.PackageID)
+
+	err = publishWithContext(ctx, p.publisher, TopicReassignOrder, msg)
+	if err != nil {
+		return fmt.Errorf("publish reassigned: %w", err)
+	}
+
+	return nil
+}
+
+// PublishSLABreached publishes a delivery SLA breached event.
+//
+//nolint:gocritic // Kafka event payloads are intentionally passed by value as immutable messages.
+func (p *KafkaStatusPublisher) PublishSLABreached(ctx context.Context, event DeliverySLABreachedEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal sla breached event: %w", err)
+	}
+
+	// Partition by package so the breach follows the package's delivery event.
+	msg := newEventMessage(payload, event.PackageID)
+
+	err = publishWithContext(ctx, p.publisher, TopicSLABreachedOrder, msg)
+	if err != nil {
+		return fmt.Errorf("publish sla breached: %w", err)
+	}
+
+	return nil
+}
+
+// PublishRetryScheduled publishes a delivery retry scheduled event.
+//
+//nolint:gocritic // Kafka event payloads are intentionally passed by value as immutable messages.
+func (p *KafkaStatusPublisher) PublishRetryScheduled(ctx context.Context, event DeliveryRetryScheduledEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal retry scheduled event: %w", err)
+	}
+
+	// Partition by package so the retry precedes the package's final delivery event.
+	msg := newEventMessage(payload, event.PackageID)
+
+	err = publishWithContext(ctx, p.publisher, TopicRetryScheduledOrder, msg)
+	if err != nil {
+		return fmt.Errorf("publish retry scheduled: %w", err)
+	}
+
+	return nil
+}
+
+// newEventMessage wraps a JSON payload in a message carrying the partition key and schema headers.
+func newEventMessage(payload []byte, partitionKey string) *message.Message {
+	msg := message.NewMessage(watermill.NewUUID(), payload)
+	msg.Metadata.Set(metadataKeyPartitionKey, partitionKey)
+	msg.Metadata.Set(metadataKeySchemaVersion, SchemaVersion)
+	msg.Metadata.Set(metadataKeyContentType, ContentTypeJSON)
+
+	return msg
+}
+
+// publishWithContext publishes msg but returns ctx.Err() as soon as ctx is done, so a
+// blocked broker cannot stall shutdown. An abandoned Publish may still complete in the background.
+func publishWithContext(ctx context.Context, publisher message.Publisher, topic string, msg *message.Message) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	msg.SetContext(ctx)
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- publisher.Publish(topic, msg)
+	}()
+
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Close closes the status publisher.
 func (p *KafkaStatusPublisher) Close() error {
 	err := p.publisher.Close()
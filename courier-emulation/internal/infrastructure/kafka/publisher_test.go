@@ -35,7 +35,7 @@ func (m *mockPublisher) Close() error {
 
 func TestLocationPublisher_PublishLocation(t *testing.T) {
 	mock := newMockPublisher()
-	publisher := NewLocationPublisher(mock)
+	publisher := NewLocationPublisher(mock, LocationPartitionByCourier)
 
 	location := vo.MustNewLocation(52.5200, 13.4050)
 	event := vo.NewCourierLocationEvent("courier-1", location, vo.CourierStatusMoving).
@@ -50,13 +50,15 @@ func TestLocationPublisher_PublishLocation(t *testing.T) {
 	msg := mock.messages[TopicCourierLocation][0]
 	assert.NotEmpty(t, msg.UUID)
 	assert.Equal(t, "courier-1", msg.Metadata.Get("partition_key"))
+	assert.Equal(t, SchemaVersion, msg.Metadata.Get("schema_version"))
+	assert.Equal(t, ContentTypeJSON, msg.Metadata.Get("content_type"))
 	assert.Contains(t, string(msg.Payload), "courier-1")
 	assert.Contains(t, string(msg.Payload), "moving")
 }
 
 func TestLocationPublisher_PublishLocationBatch(t *testing.T) {
 	mock := newMockPublisher()
-	publisher := NewLocationPublisher(mock)
+	publisher := NewLocationPublisher(mock, LocationPartitionByCourier)
 
 	events := []vo.CourierLocationEvent{
 		vo.NewCourierLocationEvent("courier-1", vo.MustNewLocation(52.5200, 13.4050), vo.CourierStatusMoving),
@@ -72,10 +74,54 @@ func TestLocationPublisher_PublishLocationBatch(t *testing.T) {
 
 func TestLocationPublisher_Close(t *testing.T) {
 	mock := newMockPublisher()
-	publisher := NewLocationPublisher(mock)
+	publisher := NewLocationPublisher(mock, LocationPartitionByCourier)
 
 	err := publisher.Close()
 
 	require.NoError(t, err)
 	assert.True(t, mock.closed)
 }
+
+func TestLocationPublisher_PartitionKey(t *testing.T) {
+	location := vo.MustNewLocation(52.5200, 13.4050)
+	delivering := vo.NewCourierLocationEvent("courier-1", location, vo.CourierStatusDelivering).WithOrderID("order-1")
+	roaming := vo.NewCourierLocationEvent("courier-2", location, vo.CourierStatusIdle)
+
+	tests := []struct {
+		name string
+		key  LocationPartitionKey
+		want []string
+	}{
+		{"default is courier", "", []string{"courier-1", "courier-2"}},
+		{"courier", LocationPartitionByCourier, []string{"courier-1", "courier-2"}},
+		{"order falls back to courier without an order", LocationPartitionByOrder, []string{"order-1", "courier-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockPublisher()
+			publisher := NewLocationPublisher(mock, tt.key)
+
+			require.NoError(t, publisher.PublishLocation(context.Background(), delivering))
+			require.NoError(t, publisher.PublishLocationBatch(context.Background(), []vo.CourierLocationEvent{roaming}))
+
+			messages := mock.messages[TopicCourierLocation]
+			require.Len(t, messages, 2)
+			assert.Equal(t, tt.want[0], messages[0].Metadata.Get("partition_key"))
+			assert.Equal(t, tt.want[1], messages[1].Metadata.Get("partition_key"))
+		})
+	}
+}
+
+func TestParseLocationPartitionKey(t *testing.T) {
+	key, err := ParseLocationPartitionKey("")
+	require.NoError(t, err)
+	assert.Equal(t, LocationPartitionByCourier, key)
+
+	key, err = ParseLocationPartitionKey(" Order_ID ")
+	require.NoError(t, err)
+	assert.Equal(t, LocationPartitionByOrder, key)
+
+	_, err = ParseLocationPartitionKey("route_id")
+	require.ErrorIs(t, err, ErrUnknownPartitionKey)
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -50,6 +51,8 @@ func TestStatusPublisher_PublishPickUp(t *testing.T) {
 
 	// Verify partition key (by package for lifecycle ordering)
 	assert.Equal(t, event.PackageID, messages[0].Metadata.Get("partition_key"))
+	assert.Equal(t, SchemaVersion, messages[0].Metadata.Get("schema_version"))
+	assert.Equal(t, ContentTypeJSON, messages[0].Metadata.Get("content_type"))
 }
 
 func TestStatusPublisher_PublishDelivery(t *testing.T) {
@@ -90,6 +93,8 @@ func TestStatusPublisher_PublishDelivery(t *testing.T) {
 
 	// Verify partition key (by package for lifecycle ordering)
 	assert.Equal(t, event.PackageID, messages[0].Metadata.Get("partition_key"))
+	assert.Equal(t, SchemaVersion, messages[0].Metadata.Get("schema_version"))
+	assert.Equal(t, ContentTypeJSON, messages[0].Metadata.Get("content_type"))
 }
 
 func TestStatusPublisher_PublishDeliveryNotDelivered(t *testing.T) {
@@ -127,6 +132,79 @@ func TestStatusPublisher_PublishDeliveryNotDelivered(t *testing.T) {
 	assert.Equal(t, ReasonCustomerNotAvailable, receivedEvent.Reason)
 }
 
+func TestStatusPublisher_PublishReassigned(t *testing.T) {
+	mockPub := newMockPublisher()
+	statusPub := NewStatusPublisher(mockPub)
+
+	order := vo.NewDeliveryOrder("order-1", "pkg-123", vo.MustNewLocation(52.52, 13.405), vo.MustNewLocation(52.53, 13.415), time.Now())
+	event := NewDeliveryReassignedEvent("courier-1", "courier-2", order, vo.MustNewLocation(52.525, 13.41))
+
+	require.NoError(t, statusPub.PublishReassigned(context.Background(), event))
+
+	messages := mockPub.messages[TopicReassignOrder]
+	require.Len(t, messages, 1)
+
+	var receivedEvent DeliveryReassignedEvent
+
+	require.NoError(t, json.Unmarshal(messages[0].Payload, &receivedEvent))
+	assert.Equal(t, "pkg-123", receivedEvent.PackageID)
+	assert.Equal(t, "courier-1", receivedEvent.FromCourierID)
+	assert.Equal(t, "courier-2", receivedEvent.ToCourierID)
+	assert.Equal(t, 52.525, receivedEvent.CurrentLocation.Latitude)
+
+	assert.Equal(t, "pkg-123", messages[0].Metadata.Get("partition_key"))
+}
+
+func TestStatusPublisher_PublishSLABreached(t *testing.T) {
+	mockPub := newMockPublisher()
+	statusPub := NewStatusPublisher(mockPub)
+
+	deadline := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	order := vo.NewDeliveryOrder("order-1", "pkg-123", vo.MustNewLocation(52.52, 13.405), vo.MustNewLocation(52.53, 13.415), time.Now()).
+		WithDeliveryDeadline(deadline)
+	event := NewDeliverySLABreachedEvent("courier-1", order, deadline.Add(90*time.Second))
+
+	require.NoError(t, statusPub.PublishSLABreached(context.Background(), event))
+
+	messages := mockPub.messages[TopicSLABreachedOrder]
+	require.Len(t, messages, 1)
+
+	var receivedEvent DeliverySLABreachedEvent
+
+	require.NoError(t, json.Unmarshal(messages[0].Payload, &receivedEvent))
+	assert.Equal(t, "pkg-123", receivedEvent.PackageID)
+	assert.Equal(t, "courier-1", receivedEvent.CourierID)
+	assert.Equal(t, deadline, receivedEvent.DeliveryDeadline)
+	assert.Equal(t, 90.0, receivedEvent.OverdueSeconds)
+
+	assert.Equal(t, "pkg-123", messages[0].Metadata.Get("partition_key"))
+}
+
+func TestStatusPublisher_PublishRetryScheduled(t *testing.T) {
+	mockPub := newMockPublisher()
+	statusPub := NewStatusPublisher(mockPub)
+
+	retryAt := time.Date(2026, 1, 1, 12, 10, 0, 0, time.UTC)
+	order := vo.NewDeliveryOrder("order-1", "pkg-123", vo.MustNewLocation(52.52, 13.405), vo.MustNewLocation(52.53, 13.415), time.Now())
+	event := NewDeliveryRetryScheduledEvent("courier-1", order, ReasonCustomerNotAvailable, 1, retryAt)
+
+	require.NoError(t, statusPub.PublishRetryScheduled(context.Background(), event))
+
+	messages := mockPub.messages[TopicRetryScheduledOrder]
+	require.Len(t, messages, 1)
+
+	var receivedEvent DeliveryRetryScheduledEvent
+
+	require.NoError(t, json.Unmarshal(messages[0].Payload, &receivedEvent))
+	assert.Equal(t, "pkg-123", receivedEvent.PackageID)
+	assert.Equal(t, "courier-1", receivedEvent.CourierID)
+	assert.Equal(t, ReasonCustomerNotAvailable, receivedEvent.Reason)
+	assert.Equal(t, 1, receivedEvent.Attempt)
+	assert.Equal(t, retryAt, receivedEvent.RetryAt)
+
+	assert.Equal(t, "pkg-123", messages[0].Metadata.Get("partition_key"))
+}
+
 func TestNewPickUpOrderEvent(t *testing.T) {
 	pickup := vo.MustNewLocation(52.5200, 13.4050)
 	delivery := vo.MustNewLocation(52.5300, 13.4150)
@@ -206,6 +284,42 @@ func TestNewDeliverOrderEvent_Validation(t *testing.T) {
 	})
 }
 
+func TestNewDeliverOrderEvent_DeliveryProximity(t *testing.T) {
+	pickup := vo.MustNewLocation(52.5200, 13.4050)
+	delivery := vo.MustNewLocation(52.5300, 13.4150)
+	order := vo.NewDeliveryOrder("order-1", "pkg-1", pickup, delivery, time.Now())
+	proximity := WithDeliveryProximity(100)
+
+	t.Run("delivered_at_destination", func(t *testing.T) {
+		// ~22 m north of the delivery location
+		event, err := NewDeliverOrderEvent("c1", order, vo.MustNewLocation(52.5302, 13.4150), true, "", proximity)
+		require.NoError(t, err)
+		assert.Equal(t, DeliveryStatusDelivered, event.Status)
+	})
+
+	t.Run("delivered_far_away_returns_error", func(t *testing.T) {
+		// Reported from pickup, ~1.3 km away
+		_, err := NewDeliverOrderEvent("c1", order, pickup, true, "", proximity)
+		require.ErrorIs(t, err, ErrDeliveredTooFar)
+
+		var tooFar *DeliveredTooFarError
+		require.ErrorAs(t, err, &tooFar)
+		assert.InDelta(t, 1300, tooFar.DistanceMeters, 50)
+		assert.Equal(t, 100.0, tooFar.RadiusMeters)
+	})
+
+	t.Run("not_delivered_has_no_proximity_requirement", func(t *testing.T) {
+		event, err := NewDeliverOrderEvent("c1", order, pickup, false, ReasonCustomerNotAvailable, proximity)
+		require.NoError(t, err)
+		assert.Equal(t, DeliveryStatusNotDelivered, event.Status)
+	})
+
+	t.Run("zero_radius_disables_the_check", func(t *testing.T) {
+		_, err := NewDeliverOrderEvent("c1", order, pickup, true, "", WithDeliveryProximity(0))
+		require.NoError(t, err)
+	})
+}
+
 func TestStatusPublisher_Close(t *testing.T) {
 	mockPub := newMockPublisher()
 	statusPub := NewStatusPublisher(mockPub)
@@ -219,8 +333,10 @@ func TestStatusPublisher_Close(t *testing.T) {
 func TestTopicConstants(t *testing.T) {
 	assert.Equal(t, "delivery.order.order_picked_up.v1", TopicPickUpOrder)
 	assert.Equal(t, "delivery.order.order_delivered.v1", TopicDeliverOrder)
+	assert.Equal(t, "delivery.order.order_reassigned.v1", TopicReassignOrder)
 	assert.Equal(t, "delivery.courier.location_received.v1", TopicCourierLocation)
 	assert.Equal(t, "delivery.order.assigned.v1", TopicOrderAssigned)
+	assert.Equal(t, "delivery.order.cancelled.v1", TopicOrderCancelled)
 }
 
 // Ensure status constants serialize correctly
@@ -237,4 +353,49 @@ func TestReasonConstants(t *testing.T) {
 	assert.Equal(t, "ACCESS_DENIED", string(ReasonAccessDenied))
 	assert.Equal(t, "PACKAGE_DAMAGED", string(ReasonPackageDamaged))
 	assert.Equal(t, "OTHER", string(ReasonOther))
+	assert.Equal(t, "CANCELLED", string(ReasonCancelled))
+}
+
+// blockingPublisher never completes a publish, like a broker that stopped responding.
+type blockingPublisher struct {
+	mockPublisher
+	release chan struct{}
+}
+
+func (b *blockingPublisher) Publish(string, ...*message.Message) error {
+	<-b.release
+
+	return nil
+}
+
+func TestStatusPublisher_CancelledContext(t *testing.T) {
+	mockPub := newMockPublisher()
+	statusPub := NewStatusPublisher(mockPub)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := statusPub.PublishPickUp(ctx, PickUpOrderEvent{PackageID: "pkg-123", CourierID: "courier-456"})
+	require.ErrorIs(t, err, context.Canceled)
+
+	err = statusPub.PublishDelivery(ctx, DeliverOrderEvent{PackageID: "pkg-123", Status: DeliveryStatusDelivered})
+	require.ErrorIs(t, err, context.Canceled)
+
+	assert.Empty(t, mockPub.messages, "nothing must be published once the context is done")
+}
+
+func TestStatusPublisher_ContextDeadlineUnblocksPublish(t *testing.T) {
+	blocking := &blockingPublisher{release: make(chan struct{})}
+	defer close(blocking.release)
+
+	statusPub := NewStatusPublisher(blocking)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := statusPub.PublishDelivery(ctx, DeliverOrderEvent{PackageID: "pkg-123", Status: DeliveryStatusDelivered})
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second, "publish must return promptly once the context is done")
 }
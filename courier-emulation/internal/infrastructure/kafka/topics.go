@@ -6,8 +6,11 @@ const (
 	topicEntity = "order"
 	topicSuffix = ".v1"
 
-	eventNameOrderPickedUp  = "order_picked_up"
-	eventNameOrderDelivered = "order_delivered"
+	eventNameOrderPickedUp    = "order_picked_up"
+	eventNameOrderDelivered   = "order_delivered"
+	eventNameOrderReassigned  = "order_reassigned"
+	eventNameOrderSLABreached = "order_sla_breached"
+	eventNameOrderRetry       = "order_retry_scheduled"
 
 	topicPrefix = topicDomain + "." + topicEntity + "."
 
@@ -15,9 +18,26 @@ const (
 	TopicPickUpOrder = topicPrefix + eventNameOrderPickedUp + topicSuffix
 	// TopicDeliverOrder is the Kafka topic for order delivered events.
 	TopicDeliverOrder = topicPrefix + eventNameOrderDelivered + topicSuffix
+	// TopicReassignOrder is the Kafka topic for in-flight deliveries handed over to another courier.
+	TopicReassignOrder = topicPrefix + eventNameOrderReassigned + topicSuffix
+	// TopicSLABreachedOrder is the Kafka topic for packages delivered after their desired delivery window.
+	TopicSLABreachedOrder = topicPrefix + eventNameOrderSLABreached + topicSuffix
+	// TopicRetryScheduledOrder is the Kafka topic for failed delivery attempts the courier will repeat.
+	TopicRetryScheduledOrder = topicPrefix + eventNameOrderRetry + topicSuffix
 )
 
 // Metadata keys for Kafka messages.
 const (
-	metadataKeyPartitionKey = "partition_key"
+	metadataKeyPartitionKey  = "partition_key"
+	metadataKeySchemaVersion = "schema_version"
+	metadataKeyContentType   = "content_type"
+)
+
+// Schema headers set on every published event so consumers can reject payloads they cannot decode.
+// Bump the major version, together with the topic suffix, on breaking payload changes.
+const (
+	// SchemaVersion is the "major.minor" version of the event payloads published by this service.
+	SchemaVersion = "1.0"
+	// ContentTypeJSON is the encoding of the event payloads published by this service.
+	ContentTypeJSON = "application/json"
 )
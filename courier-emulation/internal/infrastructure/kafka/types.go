@@ -11,6 +11,8 @@ type PickUpOrderEvent struct {
 	CourierID      string    `json:"courier_id"`
 	PickupLocation Location  `json:"pickup_location"`
 	PickedUpAt     time.Time `json:"picked_up_at"`
+	// Sequence is the courier's event sequence number, shared with its location events
+	Sequence uint64 `json:"sequence"`
 }
 
 // DeliverOrderEvent represents a package delivery result event.
@@ -21,6 +23,46 @@ type DeliverOrderEvent struct {
 	Reason          NotDeliveredReason `json:"reason,omitempty"`
 	CurrentLocation Location           `json:"current_location"`
 	DeliveredAt     time.Time          `json:"delivered_at"`
+	// Sequence is the courier's event sequence number, shared with its location events
+	Sequence uint64 `json:"sequence"`
+}
+
+// DeliveryReassignedEvent represents an in-flight delivery handed over to another courier.
+// The package stays in its current phase; only the courier carrying it changes.
+type DeliveryReassignedEvent struct {
+	PackageID       string    `json:"package_id"`
+	FromCourierID   string    `json:"from_courier_id"`
+	ToCourierID     string    `json:"to_courier_id"`
+	CurrentLocation Location  `json:"current_location"`
+	ReassignedAt    time.Time `json:"reassigned_at"`
+	// Sequence is the event sequence number of ToCourierID, which carries the package on
+	Sequence uint64 `json:"sequence"`
+}
+
+// DeliverySLABreachedEvent reports a package delivered after the end of its desired delivery window.
+type DeliverySLABreachedEvent struct {
+	PackageID        string    `json:"package_id"`
+	CourierID        string    `json:"courier_id"`
+	DeliveryDeadline time.Time `json:"delivery_deadline"`
+	DeliveredAt      time.Time `json:"delivered_at"`
+	// OverdueSeconds is how long after DeliveryDeadline the package was delivered
+	OverdueSeconds float64 `json:"overdue_seconds"`
+	// Sequence is the courier's event sequence number, shared with its location events
+	Sequence uint64 `json:"sequence"`
+}
+
+// DeliveryRetryScheduledEvent reports a failed delivery attempt that the courier will repeat after a delay
+// instead of resolving the package as NOT_DELIVERED.
+type DeliveryRetryScheduledEvent struct {
+	PackageID string             `json:"package_id"`
+	CourierID string             `json:"courier_id"`
+	Reason    NotDeliveredReason `json:"reason"`
+	// Attempt is the number of the failed attempt, starting at 1
+	Attempt  int       `json:"attempt"`
+	FailedAt time.Time `json:"failed_at"`
+	RetryAt  time.Time `json:"retry_at"`
+	// Sequence is the courier's event sequence number, shared with its location events
+	Sequence uint64 `json:"sequence"`
 }
 
 // Location represents a geographic location in events.
@@ -41,6 +83,8 @@ const (
 )
 
 // NotDeliveredReason is the reason for a failed delivery (NOT_DELIVERED).
+// OMS maps these names onto its enum (oms/internal/infrastructure/kafka/not_delivered_reason.go)
+// and rejects unknown ones to its DLQ, so a new reason must be added there too.
 type NotDeliveredReason string
 
 const (
@@ -50,6 +94,10 @@ const (
 	ReasonAccessDenied         NotDeliveredReason = "ACCESS_DENIED"
 	ReasonPackageDamaged       NotDeliveredReason = "PACKAGE_DAMAGED"
 	ReasonOther                NotDeliveredReason = "OTHER"
+	// ReasonCancelled resolves a delivery whose order was cancelled after assignment.
+	ReasonCancelled NotDeliveredReason = "CANCELLED"
+	// ReasonUnroutable resolves a delivery OSRM found no route for.
+	ReasonUnroutable NotDeliveredReason = "UNROUTABLE"
 )
 
 // validNotDeliveredReasons is the whitelist for NOT_DELIVERED reason.
@@ -60,4 +108,12 @@ var validNotDeliveredReasons = map[NotDeliveredReason]struct{}{
 	ReasonAccessDenied:         {},
 	ReasonPackageDamaged:       {},
 	ReasonOther:                {},
+	ReasonCancelled:            {},
+	ReasonUnroutable:           {},
+}
+
+// IsValid reports whether the reason is part of the NOT_DELIVERED contract.
+func (r NotDeliveredReason) IsValid() bool {
+	_, ok := validNotDeliveredReasons[r]
+	return ok
 }
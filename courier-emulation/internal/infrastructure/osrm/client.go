@@ -16,8 +16,18 @@ var (
 	ErrNoRouteFound    = errors.New("osrm no route found")
 	ErrInvalidResponse = errors.New("osrm invalid response")
 	errIncompleteAuth  = errors.New("both auth header name and value must be set")
+
+	// errTransport and errServerStatus mark transient failures that are worth retrying.
+	errTransport    = errors.New("transport error")
+	errServerStatus = errors.New("server error status")
 )
 
+// IsRetryable reports whether err is a transient OSRM failure (connection error or 5xx).
+// NoRoute, invalid responses and 4xx statuses are final answers and are not retried.
+func IsRetryable(err error) bool {
+	return errors.Is(err, errTransport) || errors.Is(err, errServerStatus)
+}
+
 type RouteResult struct {
 	DistanceMeters float64
 	Duration       time.Duration
@@ -64,14 +74,14 @@ func NewClient(baseURL string, timeout time.Duration, authHeaderName, authHeader
 	}, nil
 }
 
-func (c *Client) Route(ctx context.Context, originCoordinates, destinationCoordinates string) (RouteResult, error) {
+func (c *Client) Route(ctx context.Context, profile, originCoordinates, destinationCoordinates string) (RouteResult, error) {
 	coordinates := originCoordinates + ";" + destinationCoordinates
 	overview := osrmgenerated.RouteParamsOverviewFull
 	geometries := osrmgenerated.RouteParamsGeometriesPolyline
 
 	response, err := c.api.RouteWithResponse(
 		ctx,
-		"driving",
+		profile,
 		coordinates,
 		&osrmgenerated.RouteParams{
 			Overview:   &overview,
@@ -79,13 +89,17 @@ func (c *Client) Route(ctx context.Context, originCoordinates, destinationCoordi
 		},
 	)
 	if err != nil {
-		return RouteResult{}, fmt.Errorf("%w: %w", ErrUnavailable, err)
+		return RouteResult{}, fmt.Errorf("%w: %w: %w", ErrUnavailable, errTransport, err)
 	}
 
 	if response == nil {
 		return RouteResult{}, fmt.Errorf("%w: empty route response", ErrInvalidResponse)
 	}
 
+	if response.StatusCode() >= http.StatusInternalServerError {
+		return RouteResult{}, fmt.Errorf("%w: %w: status code %d", ErrUnavailable, errServerStatus, response.StatusCode())
+	}
+
 	if response.StatusCode() != http.StatusOK {
 		return RouteResult{}, fmt.Errorf("%w: status code %d", ErrUnavailable, response.StatusCode())
 	}
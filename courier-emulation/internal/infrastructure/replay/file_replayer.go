@@ -0,0 +1,161 @@
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/services"
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+)
+
+// Configuration errors for NewFileReplayer. Callers can use errors.Is.
+var (
+	ErrPathRequired           = errors.New("replay file path is required")
+	ErrInvalidSpeedMultiplier = errors.New("replay speed multiplier must be positive")
+)
+
+// FileReplayer replays recorded CourierLocationEvents from a file through a LocationPublisher.
+// The file holds either a JSON array or newline-delimited JSON objects, in the format written by
+// CourierLocationEvent.MarshalJSON. The gap between consecutive event timestamps is preserved,
+// divided by the speed multiplier (2.0 = twice as fast).
+type FileReplayer struct {
+	path            string
+	speedMultiplier float64
+	publisher       services.LocationPublisher
+}
+
+// NewFileReplayer creates a replayer for the given file.
+func NewFileReplayer(path string, speedMultiplier float64, publisher services.LocationPublisher) (*FileReplayer, error) {
+	if path == "" {
+		return nil, ErrPathRequired
+	}
+
+	if speedMultiplier <= 0 {
+		return nil, fmt.Errorf("%w: %f", ErrInvalidSpeedMultiplier, speedMultiplier)
+	}
+
+	return &FileReplayer{
+		path:            path,
+		speedMultiplier: speedMultiplier,
+		publisher:       publisher,
+	}, nil
+}
+
+// Path returns the replayed file path.
+func (r *FileReplayer) Path() string {
+	return r.path
+}
+
+// Replay publishes every event in the file in order and returns how many were published.
+// It stops early when ctx is canceled or publishing fails.
+func (r *FileReplayer) Replay(ctx context.Context) (int, error) {
+	file, err := os.Open(r.path)
+	if err != nil {
+		return 0, fmt.Errorf("open replay file: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	isArray, err := startsWithArray(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	decoder := json.NewDecoder(reader)
+
+	if isArray {
+		// Consume the opening bracket so More/Decode walk the array elements.
+		_, err = decoder.Token()
+		if err != nil {
+			return 0, fmt.Errorf("decode replay file: %w", err)
+		}
+	}
+
+	var (
+		published int
+		previous  time.Time
+	)
+
+	for decoder.More() {
+		var event vo.CourierLocationEvent
+
+		err = decoder.Decode(&event)
+		if err != nil {
+			return published, fmt.Errorf("decode replay event %d: %w", published+1, err)
+		}
+
+		if published > 0 {
+			err = r.wait(ctx, event.Timestamp.Sub(previous))
+			if err != nil {
+				return published, err
+			}
+		}
+
+		previous = event.Timestamp
+
+		err = r.publisher.PublishLocation(ctx, event)
+		if err != nil {
+			return published, fmt.Errorf("publish replay event %d: %w", published+1, err)
+		}
+
+		published++
+	}
+
+	return published, nil
+}
+
+// wait sleeps for the recorded gap scaled by the speed multiplier.
+// Out-of-order timestamps are published immediately.
+func (r *FileReplayer) wait(ctx context.Context, gap time.Duration) error {
+	if gap <= 0 {
+		err := ctx.Err()
+		if err != nil {
+			return fmt.Errorf("replay canceled: %w", err)
+		}
+
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(float64(gap) / r.speedMultiplier))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("replay canceled: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// startsWithArray reports whether the first non-whitespace byte opens a JSON array.
+func startsWithArray(reader *bufio.Reader) (bool, error) {
+	for {
+		b, err := reader.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("read replay file: %w", err)
+		}
+
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+
+		err = reader.UnreadByte()
+		if err != nil {
+			return false, fmt.Errorf("read replay file: %w", err)
+		}
+
+		return b == '[', nil
+	}
+}
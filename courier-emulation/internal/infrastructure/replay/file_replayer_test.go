@@ -0,0 +1,108 @@
+package replay
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixtureSpeedMultiplier compresses the fixture's 6s of recorded time into 60ms.
+const fixtureSpeedMultiplier = 100.0
+
+// recordingPublisher records published events with the wall-clock time they arrived.
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []vo.CourierLocationEvent
+	times  []time.Time
+}
+
+//nolint:gocritic // matches services.LocationPublisher
+func (p *recordingPublisher) PublishLocation(_ context.Context, event vo.CourierLocationEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, event)
+	p.times = append(p.times, time.Now())
+
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	return nil
+}
+
+func TestFileReplayer_ReplaysInOrderWithScaledDelays(t *testing.T) {
+	publisher := &recordingPublisher{}
+
+	replayer, err := NewFileReplayer(filepath.Join("testdata", "locations.ndjson"), fixtureSpeedMultiplier, publisher)
+	require.NoError(t, err)
+
+	published, err := replayer.Replay(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 4, published)
+
+	courierIDs := make([]string, 0, len(publisher.events))
+	for _, event := range publisher.events {
+		courierIDs = append(courierIDs, event.CourierID)
+	}
+
+	assert.Equal(t, []string{"courier-1", "courier-1", "courier-2", "courier-1"}, courierIDs)
+	assert.InDelta(t, 52.5210, publisher.events[1].Location.Latitude(), 1e-9)
+	assert.Equal(t, vo.CourierStatusDelivering, publisher.events[3].Status)
+
+	// Recorded gaps are 2s, 0s and 4s; scaled by 100 they become 20ms, 0ms and 40ms.
+	expectedGaps := []time.Duration{20 * time.Millisecond, 0, 40 * time.Millisecond}
+	for i, expected := range expectedGaps {
+		gap := publisher.times[i+1].Sub(publisher.times[i])
+		assert.GreaterOrEqual(t, gap, expected, "gap %d", i)
+		assert.Less(t, gap, expected+30*time.Millisecond, "gap %d", i)
+	}
+}
+
+func TestFileReplayer_ReadsJSONArray(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "locations.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"courier_id":"courier-1","latitude":52.52,"longitude":13.405,"timestamp":"2026-01-02T10:00:00Z","status":"moving"},
+		{"courier_id":"courier-2","latitude":52.53,"longitude":13.41,"timestamp":"2026-01-02T10:00:00Z","status":"idle"}
+	]`), 0o600))
+
+	publisher := &recordingPublisher{}
+
+	replayer, err := NewFileReplayer(path, 1, publisher)
+	require.NoError(t, err)
+
+	published, err := replayer.Replay(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	assert.Equal(t, "courier-2", publisher.events[1].CourierID)
+}
+
+func TestFileReplayer_StopsOnCancel(t *testing.T) {
+	publisher := &recordingPublisher{}
+
+	// Real-time replay would take 6s; cancel after the first event.
+	replayer, err := NewFileReplayer(filepath.Join("testdata", "locations.ndjson"), 1, publisher)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	published, err := replayer.Replay(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, published)
+}
+
+func TestNewFileReplayer_Validation(t *testing.T) {
+	_, err := NewFileReplayer("", 1, &recordingPublisher{})
+	require.ErrorIs(t, err, ErrPathRequired)
+
+	_, err = NewFileReplayer("locations.ndjson", 0, &recordingPublisher{})
+	require.ErrorIs(t, err, ErrInvalidSpeedMultiplier)
+}
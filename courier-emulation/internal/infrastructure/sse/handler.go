@@ -0,0 +1,127 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// StreamPath is the HTTP path serving the courier location event stream.
+	StreamPath = "/couriers/locations/stream"
+	// courierIDQueryParam filters the stream to a single courier.
+	courierIDQueryParam = "courier_id"
+	// eventName is the SSE event name used for location updates.
+	eventName = "location"
+	// keepAliveInterval is how often a comment line is sent to keep idle connections open through proxies.
+	keepAliveInterval = 15 * time.Second
+	// readHeaderTimeout bounds slow-loris style header reads.
+	readHeaderTimeout = 5 * time.Second
+)
+
+// ErrStreamingUnsupported is returned when the response writer cannot flush.
+var ErrStreamingUnsupported = errors.New("streaming unsupported by response writer")
+
+// Handler streams CourierLocationEvents as Server-Sent Events.
+// Query parameter courier_id narrows the stream to one courier.
+type Handler struct {
+	stream *LocationStream
+}
+
+// NewHandler creates an SSE handler fed by the given location stream.
+func NewHandler(stream *LocationStream) *Handler {
+	return &Handler{stream: stream}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, ErrStreamingUnsupported.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	events, unsubscribe := h.stream.Subscribe(r.URL.Query().Get(courierIDQueryParam))
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			_, err := fmt.Fprint(w, ": keep-alive\n\n")
+			if err != nil {
+				return
+			}
+
+			flusher.Flush()
+		case event, open := <-events:
+			if !open {
+				return
+			}
+
+			payload, err := event.ToJSON()
+			if err != nil {
+				continue
+			}
+
+			_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventName, payload)
+			if err != nil {
+				return
+			}
+
+			flusher.Flush()
+		}
+	}
+}
+
+// Server exposes the location stream over HTTP.
+type Server struct {
+	server *http.Server
+}
+
+// NewServer creates an HTTP server listening on addr that serves the stream at StreamPath.
+func NewServer(addr string, stream *LocationStream) *Server {
+	mux := http.NewServeMux()
+	mux.Handle(StreamPath, NewHandler(stream))
+
+	return &Server{
+		server: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}
+}
+
+// ListenAndServe starts serving. It returns nil after Shutdown.
+func (s *Server) ListenAndServe() error {
+	err := s.server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("location stream server: %w", err)
+	}
+
+	return nil
+}
+
+// Shutdown stops accepting connections and waits for open streams to finish until ctx expires.
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.server.Shutdown(ctx)
+	if err != nil {
+		return fmt.Errorf("location stream server shutdown: %w", err)
+	}
+
+	return nil
+}
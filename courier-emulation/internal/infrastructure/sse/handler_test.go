@@ -0,0 +1,117 @@
+package sse
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type streamedLocation struct {
+	CourierID string  `json:"courier_id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Status    string  `json:"status"`
+}
+
+func TestHandler_StreamsPublishedEvents(t *testing.T) {
+	stream := NewLocationStream(DefaultSubscriberBuffer)
+	server := httptest.NewServer(NewHandler(stream))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?courier_id=courier-1", http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	require.Equal(t, 1, stream.SubscriberCount())
+
+	location := vo.MustNewLocation(52.5200, 13.4050)
+	require.NoError(t, stream.PublishLocation(ctx, vo.NewCourierLocationEvent("courier-2", location, vo.CourierStatusIdle)))
+	require.NoError(t, stream.PublishLocation(ctx, vo.NewCourierLocationEvent("courier-1", location, vo.CourierStatusMoving)))
+	require.NoError(t, stream.PublishLocation(ctx, vo.NewCourierLocationEvent("courier-1", location, vo.CourierStatusDelivering)))
+
+	reader := bufio.NewReader(resp.Body)
+
+	first := readStreamedLocation(t, reader)
+	assert.Equal(t, "courier-1", first.CourierID)
+	assert.Equal(t, vo.CourierStatusMoving, first.Status)
+	assert.InDelta(t, 52.5200, first.Latitude, 1e-9)
+	assert.InDelta(t, 13.4050, first.Longitude, 1e-9)
+
+	// courier-2 is filtered out, so the next frame is the second courier-1 event.
+	second := readStreamedLocation(t, reader)
+	assert.Equal(t, "courier-1", second.CourierID)
+	assert.Equal(t, vo.CourierStatusDelivering, second.Status)
+}
+
+func TestHandler_EndsStreamWhenClosed(t *testing.T) {
+	stream := NewLocationStream(DefaultSubscriberBuffer)
+	server := httptest.NewServer(NewHandler(stream))
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL) //nolint:noctx // closed stream ends the request
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	require.NoError(t, stream.Close())
+
+	_, err = bufio.NewReader(resp.Body).ReadString('\n')
+	require.Error(t, err, "stream should end after Close")
+	assert.Eventually(t, func() bool { return stream.SubscriberCount() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestLocationStream_DropsEventsForSlowSubscriber(t *testing.T) {
+	stream := NewLocationStream(1)
+	events, unsubscribe := stream.Subscribe("")
+
+	location := vo.MustNewLocation(52.5200, 13.4050)
+	require.NoError(t, stream.PublishLocation(context.Background(), vo.NewCourierLocationEvent("courier-1", location, vo.CourierStatusMoving)))
+	require.NoError(t, stream.PublishLocation(context.Background(), vo.NewCourierLocationEvent("courier-2", location, vo.CourierStatusMoving)))
+
+	event := <-events
+	assert.Equal(t, "courier-1", event.CourierID)
+
+	unsubscribe()
+	unsubscribe()
+
+	_, open := <-events
+	assert.False(t, open)
+	assert.Zero(t, stream.SubscriberCount())
+}
+
+func readStreamedLocation(t *testing.T, reader *bufio.Reader) streamedLocation {
+	t.Helper()
+
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		data, ok := strings.CutPrefix(strings.TrimRight(line, "\n"), "data: ")
+		if !ok {
+			continue
+		}
+
+		var event streamedLocation
+		require.NoError(t, json.Unmarshal([]byte(data), &event))
+
+		return event
+	}
+}
@@ -0,0 +1,127 @@
+package sse
+
+import (
+	"context"
+	"sync"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/domain/vo"
+)
+
+// DefaultSubscriberBuffer is the number of events buffered per subscriber before new events are dropped.
+const DefaultSubscriberBuffer = 64
+
+// subscriber is a single stream consumer, optionally filtered by courier ID.
+type subscriber struct {
+	courierID string
+	events    chan vo.CourierLocationEvent
+}
+
+// LocationStream is an in-process fan-out of courier location events.
+// It implements services.LocationPublisher so it can sit next to the Kafka publisher.
+// Publishing never blocks the simulator: a subscriber whose buffer is full misses the event.
+type LocationStream struct {
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+	bufferSize  int
+	closed      bool
+}
+
+// NewLocationStream creates a location stream with the given per-subscriber buffer size.
+func NewLocationStream(bufferSize int) *LocationStream {
+	if bufferSize <= 0 {
+		bufferSize = DefaultSubscriberBuffer
+	}
+
+	return &LocationStream{
+		subscribers: make(map[*subscriber]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Subscribe registers a consumer and returns its event channel and an unsubscribe function.
+// An empty courierID receives events for all couriers.
+// The channel is closed on unsubscribe or when the stream is closed.
+func (s *LocationStream) Subscribe(courierID string) (<-chan vo.CourierLocationEvent, func()) {
+	sub := &subscriber{
+		courierID: courierID,
+		events:    make(chan vo.CourierLocationEvent, s.bufferSize),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		close(sub.events)
+
+		return sub.events, func() {}
+	}
+
+	s.subscribers[sub] = struct{}{}
+
+	var once sync.Once
+
+	return sub.events, func() {
+		once.Do(func() { s.remove(sub) })
+	}
+}
+
+// SubscriberCount returns the number of active subscribers.
+func (s *LocationStream) SubscriberCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.subscribers)
+}
+
+// PublishLocation delivers the event to every matching subscriber.
+//
+//nolint:gocritic // CourierLocationEvent is an immutable value object in this boundary.
+func (s *LocationStream) PublishLocation(_ context.Context, event vo.CourierLocationEvent) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for sub := range s.subscribers {
+		if sub.courierID != "" && sub.courierID != event.CourierID {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			// Slow consumer: drop rather than stall the simulation.
+		}
+	}
+
+	return nil
+}
+
+// Close disconnects all subscribers. Further subscriptions receive a closed channel.
+func (s *LocationStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+
+	for sub := range s.subscribers {
+		close(sub.events)
+		delete(s.subscribers, sub)
+	}
+
+	return nil
+}
+
+func (s *LocationStream) remove(sub *subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(s.subscribers, sub)
+	close(sub.events)
+}
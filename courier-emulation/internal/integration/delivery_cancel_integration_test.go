@@ -0,0 +1,155 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
+)
+
+const (
+	cancelFlowTimeout = 30 * time.Second
+	// slowPickupWait keeps the delivery in flight until the cancel arrives.
+	slowPickupWait = "10m"
+	// quietPeriod is how long no further events may appear after the cancel.
+	quietPeriod = 2 * time.Second
+)
+
+// TestDeliveryCancelE2E verifies that a cancel event after assignment stops the simulation
+// and resolves it with NOT_DELIVERED / CANCELLED.
+func TestDeliveryCancelE2E(t *testing.T) {
+	kafkaC := SetupKafkaContainer(t)
+	osrmC := SetupOSRMContainer(t)
+
+	env := append(os.Environ(),
+		"OTEL_SDK_DISABLED=true", // avoid shutdown hang waiting for OTLP exporter (no collector in test)
+		"WATERMILL_KAFKA_BROKERS="+strings.Join(kafkaC.Brokers, ","),
+		"OSRM_URL="+osrmC.BaseURL,
+		"SIMULATION_UPDATE_INTERVAL="+fastUpdateInterval,
+		"SIMULATION_PICKUP_WAIT="+slowPickupWait,
+		"SIMULATION_DELIVERY_WAIT="+fastDeliveryWait,
+		"SIMULATION_TIME_MULTIPLIER="+simulationTimeMultiplier,
+	)
+
+	startCourierEmulation(t, env)
+
+	time.Sleep(serviceStartupWait)
+
+	cfg := sarama.NewConfig()
+	cfg.Producer.Return.Successes = true
+	cfg.Consumer.Offsets.Initial = sarama.OffsetNewest
+	producer, err := sarama.NewSyncProducer(kafkaC.Brokers, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = producer.Close() })
+
+	var (
+		locations  int
+		deliveries []deliverOrderMsg
+		mu         sync.Mutex
+	)
+	handler := &multiTopicHandler{
+		onMessage: func(topic string, b []byte) {
+			mu.Lock()
+			defer mu.Unlock()
+			switch topic {
+			case kafka.TopicCourierLocation:
+				locations++
+			case kafka.TopicDeliverOrder:
+				var m deliverOrderMsg
+				if json.Unmarshal(b, &m) == nil {
+					deliveries = append(deliveries, m)
+				}
+			}
+		},
+	}
+
+	consumer, err := sarama.NewConsumerGroup(kafkaC.Brokers, "integration-cancel", cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = consumer.Close() })
+
+	consumeCtx, consumeCancel := context.WithTimeoutCause(context.Background(), cancelFlowTimeout,
+		fmt.Errorf("integration test consume timeout (%s)", cancelFlowTimeout))
+	t.Cleanup(consumeCancel)
+
+	go func() {
+		topics := []string{kafka.TopicCourierLocation, kafka.TopicDeliverOrder}
+		for {
+			if err := consumer.Consume(consumeCtx, topics, handler); err != nil {
+				return
+			}
+			if consumeCtx.Err() != nil {
+				return
+			}
+		}
+	}()
+	time.Sleep(2 * time.Second) // let consumer join and get partition assignments
+
+	assigned := kafka.OrderAssignedEvent{
+		PackageID:       "pkg-cancel-1",
+		CourierID:       "courier-cancel-1",
+		AssignedAt:      time.Now().Add(-time.Minute),
+		PickupAddress:   kafka.Address{Latitude: berlinPickupLat, Longitude: berlinPickupLon},
+		DeliveryAddress: kafka.Address{Latitude: berlinDeliveryLat, Longitude: berlinDeliveryLon},
+	}
+	sendJSON(t, producer, kafka.TopicOrderAssigned, assigned)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return locations > 0
+	}, cancelFlowTimeout, 200*time.Millisecond, "expected the courier to start moving")
+
+	sendJSON(t, producer, kafka.TopicOrderCancelled, kafka.OrderCancelledEvent{
+		PackageID:   assigned.PackageID,
+		CourierID:   assigned.CourierID,
+		CancelledAt: time.Now(),
+	})
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(deliveries) > 0
+	}, cancelFlowTimeout, 200*time.Millisecond, "expected a terminal deliver_order event")
+
+	mu.Lock()
+	locationsAtCancel := locations
+	mu.Unlock()
+
+	time.Sleep(quietPeriod)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Len(t, deliveries, 1, "cancelled delivery must resolve exactly once")
+	assert.Equal(t, assigned.PackageID, deliveries[0].PackageID)
+	assert.Equal(t, assigned.CourierID, deliveries[0].CourierID)
+	assert.Equal(t, string(kafka.DeliveryStatusNotDelivered), deliveries[0].Status)
+	assert.Equal(t, string(kafka.ReasonCancelled), deliveries[0].Reason)
+	// At most one in-flight tick may land after the cancel.
+	assert.LessOrEqual(t, locations-locationsAtCancel, 1, "courier must stop moving after cancel")
+}
+
+func sendJSON(t *testing.T, producer sarama.SyncProducer, topic string, event any) {
+	t.Helper()
+
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	_, _, err = producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topic,
+		Value: sarama.ByteEncoder(payload),
+	})
+	require.NoError(t, err)
+}
@@ -52,34 +52,7 @@ func TestDeliveryFlowE2E(t *testing.T) {
 		"SIMULATION_TIME_MULTIPLIER="+simulationTimeMultiplier,
 	)
 
-	binDir := t.TempDir()
-	binPath := filepath.Join(binDir, "courier-emulation")
-	buildCmd := exec.Command("go", "build", "-o", binPath, "./cmd/courier-emulation")
-	buildCmd.Dir = repoRoot(t)
-	buildCmd.Env = os.Environ()
-	out, err := buildCmd.CombinedOutput()
-	require.NoError(t, err, "build failed: %s", string(out))
-
-	cmd := exec.Command(binPath)
-	cmd.Dir = repoRoot(t)
-	cmd.Env = env
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	require.NoError(t, cmd.Start())
-	defer func() {
-		if cmd.Process != nil {
-			_ = cmd.Process.Signal(os.Interrupt)
-			// Limit wait so we don't hang on OTLP exporter shutdown (no collector in test)
-			done := make(chan struct{})
-			go func() { _ = cmd.Wait(); close(done) }()
-			select {
-			case <-done:
-			case <-time.After(5 * time.Second):
-				_ = cmd.Process.Kill()
-				<-done
-			}
-		}
-	}()
+	startCourierEmulation(t, env)
 
 	time.Sleep(serviceStartupWait)
 
@@ -192,6 +165,40 @@ func TestDeliveryFlowE2E(t *testing.T) {
 	}
 }
 
+// startCourierEmulation builds and starts the service binary with env; it is interrupted on test cleanup.
+func startCourierEmulation(t *testing.T, env []string) {
+	t.Helper()
+
+	binDir := t.TempDir()
+	binPath := filepath.Join(binDir, "courier-emulation")
+	buildCmd := exec.Command("go", "build", "-o", binPath, "./cmd/courier-emulation")
+	buildCmd.Dir = repoRoot(t)
+	buildCmd.Env = os.Environ()
+	out, err := buildCmd.CombinedOutput()
+	require.NoError(t, err, "build failed: %s", string(out))
+
+	cmd := exec.Command(binPath)
+	cmd.Dir = repoRoot(t)
+	cmd.Env = env
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		if cmd.Process != nil {
+			_ = cmd.Process.Signal(os.Interrupt)
+			// Limit wait so we don't hang on OTLP exporter shutdown (no collector in test)
+			done := make(chan struct{})
+			go func() { _ = cmd.Wait(); close(done) }()
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				_ = cmd.Process.Kill()
+				<-done
+			}
+		}
+	})
+}
+
 func repoRoot(t *testing.T) string {
 	t.Helper()
 	dir, err := os.Getwd()
@@ -0,0 +1,53 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shortlink/boundaries/shop/courier-emulation/internal/infrastructure/kafka"
+)
+
+// healthCheckTimeout keeps the unreachable-broker case fast.
+const healthCheckTimeout = 2 * time.Second
+
+// TestKafkaHealthCheck verifies that the readiness check fails against an unreachable broker
+// and passes against a running one.
+func TestKafkaHealthCheck(t *testing.T) {
+	kafkaC := SetupKafkaContainer(t)
+
+	t.Run("unreachable broker", func(t *testing.T) {
+		checker := kafka.NewHealthChecker([]string{"127.0.0.1:1"}, healthCheckTimeout, nil)
+
+		require.ErrorIs(t, checker.Check(t.Context()), kafka.ErrBrokersUnreachable)
+
+		recorder := httptest.NewRecorder()
+		checker.ServeHTTP(recorder, httptest.NewRequestWithContext(t.Context(), http.MethodGet, kafka.ReadinessPath, nil))
+		require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	})
+
+	t.Run("test container", func(t *testing.T) {
+		config := kafka.DefaultDeliverySubscriberConfig()
+		config.Brokers = kafkaC.Brokers
+
+		subscriber, err := kafka.NewDeliverySubscriber(config, kafka.NewCourierEmulationHandler(nil), nil, nil)
+		require.NoError(t, err)
+
+		checker := kafka.NewHealthChecker(kafkaC.Brokers, healthCheckTimeout, subscriber)
+		require.ErrorIs(t, checker.Check(t.Context()), kafka.ErrSubscriberNotRunning)
+
+		require.NoError(t, subscriber.Start(t.Context()))
+		t.Cleanup(func() { _ = subscriber.Stop() })
+
+		require.NoError(t, checker.Check(t.Context()))
+
+		recorder := httptest.NewRecorder()
+		checker.ServeHTTP(recorder, httptest.NewRequestWithContext(t.Context(), http.MethodGet, kafka.ReadinessPath, nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
@@ -104,6 +104,13 @@ impl DeliveryPeriod {
         time >= self.start && time <= self.end
     }
 
+    /// Check if two delivery windows overlap.
+    ///
+    /// Windows are half-open, so one ending exactly when the other starts does not overlap.
+    pub fn overlaps(&self, other: &DeliveryPeriod) -> bool {
+        self.start < other.end && other.start < self.end
+    }
+
     /// Get the start of the delivery window
     pub fn start(&self) -> &DateTime<Utc> {
         &self.start
@@ -487,6 +494,39 @@ mod tests {
         )
     }
 
+    #[test]
+    fn test_delivery_period_overlaps() {
+        let base = Utc::now();
+        let at = |h: i64| base + chrono::Duration::hours(h);
+        let window = DeliveryPeriod::new(at(2), at(4)).unwrap();
+
+        assert!(window.overlaps(&DeliveryPeriod::new(at(3), at(5)).unwrap()));
+        assert!(window.overlaps(&DeliveryPeriod::new(at(1), at(3)).unwrap()));
+        assert!(window.overlaps(&DeliveryPeriod::new(at(1), at(5)).unwrap()));
+        assert!(window.overlaps(&window));
+    }
+
+    #[test]
+    fn test_delivery_period_adjacent_does_not_overlap() {
+        let base = Utc::now();
+        let at = |h: i64| base + chrono::Duration::hours(h);
+        let window = DeliveryPeriod::new(at(2), at(4)).unwrap();
+
+        assert!(!window.overlaps(&DeliveryPeriod::new(at(4), at(6)).unwrap()));
+        assert!(!window.overlaps(&DeliveryPeriod::new(at(0), at(2)).unwrap()));
+    }
+
+    #[test]
+    fn test_delivery_period_disjoint_does_not_overlap() {
+        let base = Utc::now();
+        let at = |h: i64| base + chrono::Duration::hours(h);
+        let earlier = DeliveryPeriod::new(at(1), at(2)).unwrap();
+        let later = DeliveryPeriod::new(at(5), at(6)).unwrap();
+
+        assert!(!earlier.overlaps(&later));
+        assert!(!later.overlaps(&earlier));
+    }
+
     #[test]
     fn test_package_creation() {
         let package = create_test_package();
@@ -86,6 +86,23 @@ pub struct AssignmentContext<'a> {
 pub struct AssignmentValidationService;
 
 impl AssignmentValidationService {
+    /// Find a package the courier already holds whose delivery window overlaps the candidate's.
+    ///
+    /// Only assigned and in-transit packages occupy the courier; the candidate itself is ignored.
+    pub fn find_schedule_conflict<'a>(
+        package: &Package,
+        courier_packages: &'a [Package],
+    ) -> Option<&'a Package> {
+        courier_packages.iter().find(|other| {
+            other.id() != package.id()
+                && matches!(
+                    other.status(),
+                    PackageStatus::Assigned | PackageStatus::InTransit
+                )
+                && other.delivery_period().overlaps(package.delivery_period())
+        })
+    }
+
     /// Validate all business rules for package assignment.
     ///
     /// Returns `Ok(())` if assignment is valid, or a list of all validation errors.
@@ -385,6 +385,12 @@ fn map_not_delivered_reason(
         Some("OTHER") => Ok(deliver_order::NotDeliveredReason::Other(
             "courier-emulation".to_string(),
         )),
+        Some("CANCELLED") => Ok(deliver_order::NotDeliveredReason::Other(
+            "order cancelled".to_string(),
+        )),
+        Some("UNROUTABLE") => Ok(deliver_order::NotDeliveredReason::Other(
+            "no route to address".to_string(),
+        )),
         Some(value) => Err(format!("Unsupported not delivered reason: {value}")),
         None => Err("Missing not delivered reason".to_string()),
     }
@@ -436,6 +442,14 @@ mod tests {
             map_not_delivered_reason(Some("CUSTOMER_REFUSED")),
             Ok(deliver_order::NotDeliveredReason::Refused)
         ));
+        assert!(matches!(
+            map_not_delivered_reason(Some("CANCELLED")),
+            Ok(deliver_order::NotDeliveredReason::Other(ref description)) if description == "order cancelled"
+        ));
+        assert!(matches!(
+            map_not_delivered_reason(Some("UNROUTABLE")),
+            Ok(deliver_order::NotDeliveredReason::Other(ref description)) if description == "no route to address"
+        ));
         assert!(map_not_delivered_reason(Some("INVALID")).is_err());
         assert!(map_not_delivered_reason(None).is_err());
     }
@@ -256,6 +256,9 @@ pub async fn assign_order(
             crate::usecases::package::command::assign_order::AssignOrderError::NoAvailableCourier(
                 _,
             ) => Status::failed_precondition(e.to_string()),
+            crate::usecases::package::command::assign_order::AssignOrderError::ScheduleConflict {
+                ..
+            } => Status::failed_precondition(e.to_string()),
             _ => Status::internal(e.to_string()),
         }
     })?;
@@ -5,13 +5,15 @@
 //! ## Flow
 //! 1. Load package from repository
 //! 2. Validate package status is InPool
-//! 3. If auto-assign: use DispatchService to find nearest courier
-//! 4. If manual: validate assignment using AssignmentValidationService
-//! 5. Update package status to ASSIGNED
-//! 6. Update courier load
-//! 7. Save package to repository
-//! 8. Publish PackageAssigned event
-//! 9. Send push notification to courier
+//! 3. If auto-assign: skip couriers whose active packages overlap the delivery window,
+//!    then use DispatchService to find nearest courier
+//! 4. If manual: validate assignment using AssignmentValidationService and
+//!    reject delivery windows overlapping the courier's active packages
+//! 6. Update package status to ASSIGNED
+//! 7. Update courier load
+//! 8. Save package to repository
+//! 9. Publish PackageAssigned event
+//! 10. Send push notification to courier
 
 use std::sync::Arc;
 
@@ -63,6 +65,10 @@ pub enum AssignOrderError {
     #[error("Assignment validation failed: {0}")]
     ValidationFailed(String),
 
+    /// Delivery window overlaps a package the courier already holds
+    #[error("Schedule conflict: courier {courier_id} already has package {package_id} in an overlapping delivery window")]
+    ScheduleConflict { courier_id: Uuid, package_id: Uuid },
+
     /// Invalid package status for assignment
     #[error("Invalid package status: expected InPool, got {0}")]
     InvalidPackageStatus(PackageStatus),
@@ -195,11 +201,30 @@ where
                     return Err(AssignOrderError::NoAvailableCourier(zone.to_string()));
                 }
 
+                // Couriers can't be in two places at once: a courier with an overlapping
+                // delivery window is not a candidate, so dispatch falls through to the next one.
                 let mut couriers_for_dispatch = Vec::new();
                 for courier in available_couriers {
+                    let courier_packages = self.package_repo.find_by_courier(courier.id().0).await?;
+                    if let Some(conflict) = AssignmentValidationService::find_schedule_conflict(
+                        &package,
+                        &courier_packages,
+                    ) {
+                        tracing::debug!(
+                            courier_id = %courier.id().0,
+                            conflicting_package_id = %conflict.id().0,
+                            "Skipping courier with a schedule conflict"
+                        );
+                        continue;
+                    }
+
                     couriers_for_dispatch.push(self.courier_to_dispatch_candidate(courier).await);
                 }
 
+                if couriers_for_dispatch.is_empty() {
+                    return Err(AssignOrderError::NoAvailableCourier(zone.to_string()));
+                }
+
                 let dispatch_result =
                     DispatchService::find_nearest_courier(&couriers_for_dispatch, &package)
                         .map_err(|failure| {
@@ -252,6 +277,17 @@ where
                     AssignOrderError::ValidationFailed(error_msgs.join("; "))
                 })?;
 
+                // Couriers can't be in two places at once: reject overlapping delivery windows.
+                let courier_packages = self.package_repo.find_by_courier(courier_id).await?;
+                if let Some(conflict) =
+                    AssignmentValidationService::find_schedule_conflict(&package, &courier_packages)
+                {
+                    return Err(AssignOrderError::ScheduleConflict {
+                        courier_id,
+                        package_id: conflict.id().0,
+                    });
+                }
+
                 let estimated = courier
                     .transport_type()
                     .calculate_travel_time_minutes(distance_to_courier.unwrap_or(5.0));
@@ -313,7 +349,7 @@ where
             }),
         })];
 
-        // 6. Save both aggregates and outbox rows atomically.
+        // 5. Save both aggregates and outbox rows atomically.
         self.package_repo
             .save_courier_with_package_and_events(&courier, &package, &events)
             .await?;
@@ -326,7 +362,7 @@ where
             "PackageAssigned event enqueued to outbox"
         );
 
-        // 7. Send push notification to courier
+        // 6. Send push notification to courier
         if let Some(push_token) = courier.push_token() {
             let notification = OrderAssignedNotification {
                 package_id: package.id().0,
@@ -575,11 +611,13 @@ mod tests {
             Ok(0)
         }
 
-        async fn find_by_courier(
-            &self,
-            _courier_id: Uuid,
-        ) -> Result<Vec<Package>, RepositoryError> {
-            Ok(vec![])
+        async fn find_by_courier(&self, courier_id: Uuid) -> Result<Vec<Package>, RepositoryError> {
+            let packages = self.packages.lock().unwrap();
+            Ok(packages
+                .values()
+                .filter(|p| p.courier_id() == Some(courier_id))
+                .cloned()
+                .collect())
         }
 
         async fn delete(&self, _id: PackageId) -> Result<(), RepositoryError> {
@@ -793,6 +831,90 @@ mod tests {
         assert_eq!(updated_package.unwrap().status(), PackageStatus::Assigned);
     }
 
+    #[tokio::test]
+    async fn test_assign_order_manual_schedule_conflict() {
+        let courier_repo = Arc::new(MockCourierRepository::new());
+        let courier_cache = Arc::new(MockCourierCache::new());
+        let package_repo = Arc::new(MockPackageRepository::new());
+
+        let courier_id = Uuid::new_v4();
+        let mut courier = create_test_courier(courier_id);
+        courier.go_online().unwrap();
+        courier_repo.add_courier(courier);
+
+        // The courier already holds a package in the same delivery window
+        let mut existing = create_test_package_in_pool();
+        existing.assign_to(courier_id).unwrap();
+        let existing_id = existing.id().0;
+        package_repo.add_package(existing);
+
+        let package = create_test_package_in_pool();
+        let package_id = package.id().0;
+        package_repo.add_package(package);
+
+        let handler = create_handler(courier_repo, courier_cache, package_repo.clone());
+
+        let cmd = Command::manual_assign(package_id, courier_id);
+        let result = handler.handle(cmd).await;
+
+        match result {
+            Err(AssignOrderError::ScheduleConflict {
+                courier_id: conflict_courier,
+                package_id: conflict_package,
+            }) => {
+                assert_eq!(conflict_courier, courier_id);
+                assert_eq!(conflict_package, existing_id);
+            }
+            other => panic!("Expected ScheduleConflict, got: {:?}", other),
+        }
+
+        let unchanged = package_repo
+            .find_by_id(PackageId::from_uuid(package_id))
+            .await
+            .unwrap()
+            .unwrap();
+        assert_eq!(unchanged.status(), PackageStatus::InPool);
+    }
+
+    #[tokio::test]
+    async fn test_assign_order_auto_skips_courier_with_schedule_conflict() {
+        let courier_repo = Arc::new(MockCourierRepository::new());
+        let courier_cache = Arc::new(MockCourierCache::new());
+        let package_repo = Arc::new(MockPackageRepository::new());
+
+        let courier_id = Uuid::new_v4();
+        let mut courier = create_test_courier(courier_id);
+        courier.go_online().unwrap();
+        courier_repo.add_courier(courier);
+
+        // The only courier in the zone already holds a package in the same delivery window
+        let mut existing = create_test_package_in_pool();
+        existing.assign_to(courier_id).unwrap();
+        package_repo.add_package(existing);
+
+        let package = create_test_package_in_pool();
+        let package_id = package.id().0;
+        package_repo.add_package(package);
+
+        let handler = create_handler(courier_repo, courier_cache, package_repo.clone());
+
+        let cmd = Command::auto_assign(package_id);
+        let result = handler.handle(cmd).await;
+
+        // Auto dispatch treats the conflict as an unavailable courier, not a hard error
+        assert!(matches!(
+            result,
+            Err(AssignOrderError::NoAvailableCourier(_))
+        ));
+
+        let unchanged = package_repo
+            .find_by_id(PackageId::from_uuid(package_id))
+            .await
+            .unwrap()
+            .unwrap();
+        assert_eq!(unchanged.status(), PackageStatus::InPool);
+    }
+
     #[tokio::test]
     async fn test_assign_order_package_not_found() {
         let courier_repo = Arc::new(MockCourierRepository::new());
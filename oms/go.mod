@@ -33,6 +33,7 @@ require (
 	github.com/shortlink-org/go-sdk/temporal v0.0.0-20260307190635-c49239be411f
 	github.com/shortlink-org/go-sdk/uow v0.0.0-20260307190635-c49239be411f
 	github.com/shortlink-org/go-sdk/watermill v0.0.0-20260307190635-c49239be411f
+	github.com/sony/gobreaker v1.0.0
 	github.com/spf13/viper v1.21.0
 	github.com/stretchr/testify v1.11.1
 	github.com/testcontainers/testcontainers-go v0.42.0
@@ -155,7 +156,6 @@ require (
 	github.com/shirou/gopsutil/v4 v4.26.3 // indirect
 	github.com/shortlink-org/go-sdk/http v0.0.0-20260307190635-c49239be411f // indirect
 	github.com/sirupsen/logrus v1.9.4 // indirect
-	github.com/sony/gobreaker v1.0.0 // indirect
 	github.com/sourcegraph/conc v0.3.1-0.20240121214520-5f936abd7ae8 // indirect
 	github.com/spf13/afero v1.15.0 // indirect
 	github.com/spf13/cast v1.10.0 // indirect
@@ -0,0 +1,67 @@
+package oms_di
+
+import (
+	"fmt"
+
+	"github.com/redis/rueidis"
+	"github.com/shopspring/decimal"
+	"github.com/shortlink-org/go-sdk/config"
+
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/redis/rate_limiter"
+
+	checkout "github.com/shortlink-org/shop/oms/internal/usecases/order/command/create_order_from_cart"
+)
+
+// NewCheckoutConfig reads the checkout rules; CHECKOUT_MIN_ORDER_VALUE of 0 disables the minimum.
+// CHECKOUT_PRICER_FAILURE_POLICY is FAIL (default) or FALLBACK_TO_CART.
+func NewCheckoutConfig(cfg *config.Config) (checkout.Config, error) {
+	cfg.SetDefault("CHECKOUT_MIN_ORDER_VALUE", "0")
+	cfg.SetDefault("CHECKOUT_MAX_ORDER_LINE_ITEMS", orderv1.DefaultMaxOrderLineItems)
+	cfg.SetDefault("CHECKOUT_PRICER_FAILURE_POLICY", string(checkout.PricerFailurePolicyFail))
+
+	minOrderValue, err := decimal.NewFromString(cfg.GetString("CHECKOUT_MIN_ORDER_VALUE"))
+	if err != nil {
+		return checkout.Config{}, fmt.Errorf("parse CHECKOUT_MIN_ORDER_VALUE: %w", err)
+	}
+
+	maxLineItems := cfg.GetInt("CHECKOUT_MAX_ORDER_LINE_ITEMS")
+	if maxLineItems <= 0 {
+		return checkout.Config{}, fmt.Errorf("CHECKOUT_MAX_ORDER_LINE_ITEMS must be positive, got %d", maxLineItems)
+	}
+
+	pricerFailurePolicy, err := checkout.ParsePricerFailurePolicy(cfg.GetString("CHECKOUT_PRICER_FAILURE_POLICY"))
+	if err != nil {
+		return checkout.Config{}, fmt.Errorf("parse CHECKOUT_PRICER_FAILURE_POLICY: %w", err)
+	}
+
+	return checkout.Config{
+		MinOrderValue:       minOrderValue,
+		MaxOrderLineItems:   maxLineItems,
+		PricerFailurePolicy: pricerFailurePolicy,
+	}, nil
+}
+
+// NewCheckoutRateLimiter builds the per-customer checkout limiter: CHECKOUT_RATE_LIMIT_BURST checkouts
+// back to back, then one per CHECKOUT_RATE_LIMIT_INTERVAL. A burst of 0 disables the limiter.
+func NewCheckoutRateLimiter(cfg *config.Config, client rueidis.Client) (ports.RateLimiter, error) {
+	cfg.SetDefault("CHECKOUT_RATE_LIMIT_BURST", 5)
+	cfg.SetDefault("CHECKOUT_RATE_LIMIT_INTERVAL", "6s")
+
+	burst := cfg.GetInt("CHECKOUT_RATE_LIMIT_BURST")
+	if burst == 0 {
+		return nil, nil //nolint:nilnil // nil limiter disables checkout throttling
+	}
+
+	limiter, err := rate_limiter.New(client, rate_limiter.Config{
+		Name:     "checkout",
+		Burst:    burst,
+		Interval: cfg.GetDuration("CHECKOUT_RATE_LIMIT_INTERVAL"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("checkout rate limiter: %w", err)
+	}
+
+	return limiter, nil
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"log/slog"
 
+	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/shortlink-org/go-sdk/config"
 	"github.com/shortlink-org/go-sdk/logger"
 	sdkkafka "github.com/shortlink-org/go-sdk/watermill/backends/kafka"
 
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/kafka"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/set_delivery_status"
 	"github.com/shortlink-org/shop/oms/internal/usecases/order/event/on_delivery_status"
 )
 
@@ -20,13 +22,16 @@ func NewDeliveryConsumer(
 	log logger.Logger,
 	uow ports.UnitOfWork,
 	orderRepo ports.OrderRepository,
-	inboxRepo ports.DeliveryInboxRepository,
-	publisher ports.EventPublisher,
+	orderWorkflow ports.OrderWorkflow,
+	setDeliveryStatus *set_delivery_status.Handler,
 ) (*kafka.DeliveryConsumer, func(), error) {
 	cfg.SetDefault("WATERMILL_KAFKA_CONSUMER_GROUP", kafka.ConsumerGroupOMSDelivery)
+	// Where the group starts on partitions without a committed offset: "latest" skips the backlog,
+	// "earliest" replays it. Committed offsets always win, so replaying needs a reset or a new group.
+	cfg.SetDefault("WATERMILL_KAFKA_CONSUMER_INITIAL_OFFSET", kafka.DefaultInitialOffset)
 
-	// Create event handler
-	handler, err := on_delivery_status.NewHandler(log, uow, orderRepo, inboxRepo, publisher)
+	// Create event handler; updates are applied through the order workflow, or directly when it is not running
+	handler, err := on_delivery_status.NewHandler(log, uow, orderRepo, orderWorkflow, setDeliveryStatus)
 	if err != nil {
 		return nil, func() {}, err
 	}
@@ -37,7 +42,25 @@ func NewDeliveryConsumer(
 		return nil, func() {}, nil //nolint:nilerr // intentionally returning nil to continue without Kafka
 	}
 
-	consumer := kafka.NewDeliveryConsumer(kafka.TopicDeliveryPackageStatus, subscriber, handler, log)
+	// Events with an unknown not-delivered reason are parked in the DLQ
+	var dlqPublisher message.Publisher
+
+	dlq, err := sdkkafka.NewPublisherFromConfig(log, cfg)
+	if err != nil {
+		log.Warn("Failed to create Kafka delivery DLQ publisher, rejected delivery events will only be logged",
+			slog.Any("error", err))
+	} else {
+		dlqPublisher = dlq
+	}
+
+	consumer := kafka.NewDeliveryConsumer(
+		kafka.TopicDeliveryPackageStatus,
+		subscriber,
+		handler,
+		log,
+		dlqPublisher,
+		kafka.TopicDeliveryPackageStatusDLQ,
+	)
 
 	// Start consuming in background
 	if err := consumer.Start(ctx); err != nil {
@@ -0,0 +1,39 @@
+package oms_di
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/shortlink-org/go-sdk/config"
+
+	orderDomain "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+)
+
+// NewDeliveryFeeCalculator creates the checkout delivery fee calculator from the DELIVERY_FEE_* tariff.
+func NewDeliveryFeeCalculator(cfg *config.Config) (*orderDomain.DeliveryFeeCalculator, error) {
+	cfg.SetDefault("DELIVERY_FEE_BASE", "150")
+	cfg.SetDefault("DELIVERY_FEE_PER_KM", "15")
+	cfg.SetDefault("DELIVERY_FEE_PER_KG", "10")
+	cfg.SetDefault("DELIVERY_FEE_FLAT", "300")
+	cfg.SetDefault("DELIVERY_FEE_FREE_SHIPPING_THRESHOLD", "5000")
+
+	var (
+		policy orderDomain.DeliveryFeePolicy
+		err    error
+	)
+
+	for key, dst := range map[string]*decimal.Decimal{
+		"DELIVERY_FEE_BASE":                    &policy.BaseFee,
+		"DELIVERY_FEE_PER_KM":                  &policy.PerKm,
+		"DELIVERY_FEE_PER_KG":                  &policy.PerKg,
+		"DELIVERY_FEE_FLAT":                    &policy.FlatFee,
+		"DELIVERY_FEE_FREE_SHIPPING_THRESHOLD": &policy.FreeShippingThreshold,
+	} {
+		*dst, err = decimal.NewFromString(cfg.GetString(key))
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", key, err)
+		}
+	}
+
+	return orderDomain.NewDeliveryFeeCalculator(policy), nil
+}
@@ -0,0 +1,49 @@
+package oms_di
+
+import (
+	"log/slog"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/logger"
+
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/geocoding"
+)
+
+// NewGeocoder creates the cached address geocoder used before requesting delivery.
+// Geocoding is optional: without GEOCODER_URL the geocoder is nil and addresses
+// are sent to Delivery with the coordinates they already have.
+//
+//nolint:ireturn // DI returns port interface for testability
+func NewGeocoder(
+	cfg *config.Config,
+	log logger.Logger,
+) (ports.Geocoder, func(), error) {
+	cfg.SetDefault("GEOCODER_USER_AGENT", "shortlink-shop-oms")
+	cfg.SetDefault("GEOCODER_TIMEOUT", "5s")
+	cfg.SetDefault("GEOCODER_CACHE_TTL", "24h")
+
+	baseURL := cfg.GetString("GEOCODER_URL")
+	if baseURL == "" {
+		log.Info("GEOCODER_URL is not set, running without address geocoding")
+		return nil, func() {}, nil
+	}
+
+	nominatim, err := geocoding.NewNominatim(geocoding.Config{
+		BaseURL:   baseURL,
+		UserAgent: cfg.GetString("GEOCODER_USER_AGENT"),
+		Timeout:   cfg.GetDuration("GEOCODER_TIMEOUT"),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	geocoder, err := geocoding.NewCachingGeocoder(nominatim, cfg.GetDuration("GEOCODER_CACHE_TTL"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Info("Address geocoding enabled", slog.String("url", baseURL))
+
+	return geocoder, geocoder.Close, nil
+}
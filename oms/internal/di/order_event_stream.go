@@ -0,0 +1,59 @@
+package oms_di
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/logger"
+	sdkkafka "github.com/shortlink-org/go-sdk/watermill/backends/kafka"
+
+	omsKafka "github.com/shortlink-org/shop/oms/internal/infrastructure/kafka"
+)
+
+// NewOrderEventStream consumes the order event topics for the WatchStatus stream.
+// Without Kafka the stream is closed immediately, so watchers get the current status and then Unavailable.
+func NewOrderEventStream(
+	ctx context.Context,
+	cfg *config.Config,
+	log logger.Logger,
+) (*omsKafka.OrderEventStream, func(), error) {
+	// Watchers are connected to one replica, so every replica must consume every partition:
+	// the consumer group is made per host instead of shared.
+	group := omsKafka.ConsumerGroupOMSOrderStatusStream
+	if hostname, err := os.Hostname(); err == nil {
+		group += "-" + hostname
+	}
+
+	cfg.SetDefault("WATERMILL_KAFKA_CONSUMER_GROUP", group)
+	cfg.SetDefault("ORDER_EVENT_STREAM_BUFFER", omsKafka.DefaultOrderEventStreamBuffer)
+
+	buffer := cfg.GetInt("ORDER_EVENT_STREAM_BUFFER")
+
+	subscriber, err := sdkkafka.NewSubscriberFromConfig(log, cfg)
+	if err != nil {
+		log.Warn("Failed to create Kafka order event subscriber, running without order status streaming")
+
+		stream := omsKafka.NewOrderEventStream(nil, log, buffer)
+		_ = stream.Close() //nolint:errcheck // a stream without a subscriber never fails to close
+
+		return stream, func() {}, nil //nolint:nilerr // intentionally non-fatal
+	}
+
+	stream := omsKafka.NewOrderEventStream(subscriber, log, buffer)
+	if err := stream.Start(ctx); err != nil {
+		log.Warn("Failed to start Kafka order event stream", slog.Any("error", err))
+		_ = stream.Close() //nolint:errcheck // best-effort: the start error is the one reported
+
+		return stream, func() {}, nil //nolint:nilerr // intentionally non-fatal
+	}
+
+	cleanup := func() {
+		if err := stream.Close(); err != nil {
+			log.Warn("failed to close order event stream", slog.String("error", err.Error()))
+		}
+	}
+
+	return stream, cleanup, nil
+}
@@ -0,0 +1,53 @@
+package oms_di
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/logger"
+	"github.com/spf13/viper"
+	"go.temporal.io/sdk/client"
+
+	temporalInfra "github.com/shortlink-org/shop/oms/internal/infrastructure/temporal"
+	order_worker "github.com/shortlink-org/shop/oms/internal/workers/order/order_worker"
+)
+
+// PendingOrderExpiry reports whether the pending-order expiry schedule is active.
+type PendingOrderExpiry struct {
+	Enabled bool
+}
+
+// NewPendingOrderExpiry starts the cron workflow that cancels PENDING orders older than ORDER_EXPIRY_MAX_AGE.
+// An empty ORDER_EXPIRY_CRON disables expiry. It depends on the order worker so the workflow has a poller.
+func NewPendingOrderExpiry(
+	ctx context.Context,
+	cfg *config.Config,
+	log logger.Logger,
+	temporalClient client.Client,
+	_ order_worker.OrderWorker,
+) (PendingOrderExpiry, error) {
+	viper.SetDefault("ORDER_EXPIRY_CRON", "*/15 * * * *")
+	viper.SetDefault("ORDER_EXPIRY_MAX_AGE", 24*time.Hour)
+
+	cronSchedule := cfg.GetString("ORDER_EXPIRY_CRON")
+	if cronSchedule == "" {
+		log.Info("Pending order expiry disabled")
+		return PendingOrderExpiry{}, nil
+	}
+
+	maxAge := cfg.GetDuration("ORDER_EXPIRY_MAX_AGE")
+
+	err := temporalInfra.StartExpirePendingOrders(ctx, temporalClient, cronSchedule, maxAge)
+	if err != nil {
+		log.Warn("Failed to start pending order expiry, running without it", slog.Any("error", err))
+		return PendingOrderExpiry{}, nil //nolint:nilerr // intentionally non-fatal
+	}
+
+	log.Info("Pending order expiry scheduled",
+		slog.String("cron", cronSchedule),
+		slog.Duration("max_age", maxAge))
+
+	return PendingOrderExpiry{Enabled: true}, nil
+}
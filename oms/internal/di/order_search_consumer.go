@@ -0,0 +1,52 @@
+package oms_di
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/logger"
+	sdkkafka "github.com/shortlink-org/go-sdk/watermill/backends/kafka"
+
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	omsKafka "github.com/shortlink-org/shop/oms/internal/infrastructure/kafka"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/event/on_order_changed"
+)
+
+// NewOrderSearchConsumer projects the order event topics into the order search read model.
+// Without Kafka the read model is not updated, but SearchOrders keeps serving what it has.
+func NewOrderSearchConsumer(
+	ctx context.Context,
+	cfg *config.Config,
+	log logger.Logger,
+	uow ports.UnitOfWork,
+	orderRepo ports.OrderRepository,
+	searchRepo ports.OrderSearchRepository,
+) (*omsKafka.OrderSearchConsumer, func(), error) {
+	cfg.SetDefault("WATERMILL_KAFKA_CONSUMER_GROUP", omsKafka.ConsumerGroupOMSOrderSearch)
+
+	handler, err := on_order_changed.NewHandler(log, uow, orderRepo, searchRepo)
+	if err != nil {
+		return nil, func() {}, err
+	}
+
+	subscriber, err := sdkkafka.NewSubscriberFromConfig(log, cfg)
+	if err != nil {
+		log.Warn("Failed to create Kafka order search subscriber, running without order search projection")
+		return nil, func() {}, nil //nolint:nilerr // intentionally non-fatal
+	}
+
+	consumer := omsKafka.NewOrderSearchConsumer(subscriber, handler, log)
+	if err := consumer.Start(ctx); err != nil {
+		log.Warn("Failed to start Kafka order search consumer", slog.Any("error", err))
+		return nil, func() {}, nil //nolint:nilerr // intentionally non-fatal
+	}
+
+	cleanup := func() {
+		if err := consumer.Close(); err != nil {
+			log.Warn("failed to close order search consumer", slog.String("error", err.Error()))
+		}
+	}
+
+	return consumer, cleanup, nil
+}
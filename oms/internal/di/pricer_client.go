@@ -20,6 +20,11 @@ func NewPricerClient(
 	log logger.Logger,
 ) (ports.PricerClient, func(), error) {
 	viper.SetDefault("GRPC_CLIENT_TIMEOUT", "15s")
+	viper.SetDefault("PRICER_CALL_TIMEOUT", "3s")
+	viper.SetDefault("PRICER_RETRY_MAX_ATTEMPTS", pricer.DefaultMaxAttempts)
+	viper.SetDefault("PRICER_RETRY_BACKOFF", pricer.DefaultRetryBackoff)
+	viper.SetDefault("PRICER_BREAKER_FAILURES", pricer.DefaultBreakerFailures)
+	viper.SetDefault("PRICER_BREAKER_OPEN_TIMEOUT", pricer.DefaultBreakerOpenTimeout)
 
 	// Use internal-gateway for all gRPC requests
 	address := cfg.GetString("GRPC_CLIENT_HOST")
@@ -33,8 +38,15 @@ func NewPricerClient(
 	}
 
 	clientCfg := pricer.Config{
-		Address:    address,
-		Timeout:    timeout,
+		Address:     address,
+		Timeout:     timeout,
+		CallTimeout: cfg.GetDuration("PRICER_CALL_TIMEOUT"),
+		Resilience: pricer.ResilienceConfig{
+			MaxAttempts:        cfg.GetInt("PRICER_RETRY_MAX_ATTEMPTS"),
+			RetryBackoff:       cfg.GetDuration("PRICER_RETRY_BACKOFF"),
+			BreakerFailures:    cfg.GetInt("PRICER_BREAKER_FAILURES"),
+			BreakerOpenTimeout: cfg.GetDuration("PRICER_BREAKER_OPEN_TIMEOUT"),
+		},
 		TLSEnabled: cfg.GetBool("GRPC_CLIENT_TLS_ENABLED"),
 		CertPath:   cfg.GetString("GRPC_CLIENT_CERT_PATH"),
 	}
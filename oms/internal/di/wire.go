@@ -34,12 +34,15 @@ import (
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
 	omsKafka "github.com/shortlink-org/shop/oms/internal/infrastructure/kafka"
 	cartRepo "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/cart"
+	eventStoreRepo "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/event_store"
 	orderRepo "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order"
+	orderSearchRepo "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order_search"
 	cartGoodsIndex "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/redis/cart_goods_index"
 	leaderboardRepo "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/redis/leaderboard"
 	cartRPC "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/cart/v1"
 	orderRPC "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/run"
+	temporalInfra "github.com/shortlink-org/shop/oms/internal/infrastructure/temporal"
 	pguow "github.com/shortlink-org/shop/oms/pkg/uow/postgres"
 
 	// Cart handlers
@@ -51,11 +54,17 @@ import (
 	// Order handlers
 	leaderboardGet "github.com/shortlink-org/shop/oms/internal/usecases/leaderboard/query/get"
 	orderCancel "github.com/shortlink-org/shop/oms/internal/usecases/order/command/cancel"
+	orderComplete "github.com/shortlink-org/shop/oms/internal/usecases/order/command/complete"
 	orderCreate "github.com/shortlink-org/shop/oms/internal/usecases/order/command/create"
+	orderExpirePending "github.com/shortlink-org/shop/oms/internal/usecases/order/command/expire_pending_orders"
 	orderRequestDelivery "github.com/shortlink-org/shop/oms/internal/usecases/order/command/request_delivery"
+	orderSetDeliveryStatus "github.com/shortlink-org/shop/oms/internal/usecases/order/command/set_delivery_status"
 	orderUpdateDeliveryInfo "github.com/shortlink-org/shop/oms/internal/usecases/order/command/update_delivery_info"
+	orderUpdateItems "github.com/shortlink-org/shop/oms/internal/usecases/order/command/update_order_items"
 	orderGet "github.com/shortlink-org/shop/oms/internal/usecases/order/query/get"
-	orderList "github.com/shortlink-org/shop/oms/internal/usecases/order/query/list"
+	orderList "github.com/shortlink-org/shop/oms/internal/usecases/order/query/list_orders"
+	orderSearch "github.com/shortlink-org/shop/oms/internal/usecases/order/query/search_orders"
+	orderWatchStatus "github.com/shortlink-org/shop/oms/internal/usecases/order/query/watch_status"
 
 	// Checkout handlers
 	checkout "github.com/shortlink-org/shop/oms/internal/usecases/order/command/create_order_from_cart"
@@ -102,6 +111,7 @@ type OMSService struct {
 	DeliveryClient      ports.DeliveryClient
 	DeliveryConsumer    *omsKafka.DeliveryConsumer
 	LeaderboardConsumer *omsKafka.LeaderboardConsumer
+	OrderSearchConsumer *omsKafka.OrderSearchConsumer
 
 	// Pricer Integration
 	PricerClient ports.PricerClient
@@ -110,6 +120,7 @@ type OMSService struct {
 	temporalClient client.Client
 	cartWorker     cart_worker.CartWorker
 	orderWorker    order_worker.OrderWorker
+	orderExpiry    PendingOrderExpiry
 }
 
 // OMSService ==========================================================================================================
@@ -153,6 +164,10 @@ var OMSSet = wire.NewSet(
 	wire.Bind(new(ports.CartRepository), new(*cartRepo.Store)),
 	wire.Bind(new(ports.OrderRepository), new(*orderRepo.Store)),
 	wire.Bind(new(ports.DeliveryInboxRepository), new(*orderRepo.Store)),
+	eventStoreRepo.New,
+	wire.Bind(new(ports.EventStore), new(*eventStoreRepo.Store)),
+	orderSearchRepo.New,
+	wire.Bind(new(ports.OrderSearchRepository), new(*orderSearchRepo.Store)),
 
 	// Indexes
 	cartGoodsIndex.New,
@@ -169,10 +184,18 @@ var OMSSet = wire.NewSet(
 	NewDeliveryClient,
 	NewDeliveryConsumer,
 	NewLeaderboardConsumer,
+	NewOrderSearchConsumer,
+
+	// Order status streaming (Kafka-backed order event fan-out)
+	NewOrderEventStream,
+	wire.Bind(new(ports.OrderEventStream), new(*omsKafka.OrderEventStream)),
 
 	// Pricer Integration
 	NewPricerClient,
 
+	// Address geocoding for delivery requests (optional)
+	NewGeocoder,
+
 	// Cart Handlers
 	cartAddItems.NewHandler,
 	cartRemoveItems.NewHandler,
@@ -182,13 +205,22 @@ var OMSSet = wire.NewSet(
 	// Order Handlers
 	orderCreate.NewHandler,
 	orderCancel.NewHandler,
+	orderComplete.NewHandler,
+	orderExpirePending.NewHandler,
 	orderRequestDelivery.NewHandler,
+	orderSetDeliveryStatus.NewHandler,
 	orderUpdateDeliveryInfo.NewHandler,
+	orderUpdateItems.NewHandler,
 	orderGet.NewHandler,
 	orderList.NewHandler,
+	orderSearch.NewHandler,
+	orderWatchStatus.NewHandler,
 	leaderboardGet.NewHandler,
 
 	// Checkout Handlers
+	NewDeliveryFeeCalculator,
+	NewCheckoutConfig,
+	NewCheckoutRateLimiter,
 	checkout.NewHandler,
 
 	// Delivery
@@ -198,11 +230,14 @@ var OMSSet = wire.NewSet(
 
 	// Temporal
 	temporal.New,
+	temporalInfra.NewOrderWorkflowSignaler,
+	wire.Bind(new(ports.OrderWorkflow), new(*temporalInfra.OrderWorkflowSignaler)),
 
 	// Temporal Workers
 	cart_worker.New,
 	activities.NewWithHandlers,
 	order_worker.NewWithActivities,
+	NewPendingOrderExpiry,
 
 	NewOMSService,
 )
@@ -278,6 +313,7 @@ func NewOMSService(
 	deliveryClient ports.DeliveryClient,
 	deliveryConsumer *omsKafka.DeliveryConsumer,
 	leaderboardConsumer *omsKafka.LeaderboardConsumer,
+	orderSearchConsumer *omsKafka.OrderSearchConsumer,
 
 	// Pricer Integration
 	pricerClient ports.PricerClient,
@@ -291,6 +327,7 @@ func NewOMSService(
 	temporalClient client.Client,
 	cartWorker cart_worker.CartWorker,
 	orderWorker order_worker.OrderWorker,
+	orderExpiry PendingOrderExpiry,
 ) (*OMSService, error) {
 	return &OMSService{
 		// Common
@@ -324,6 +361,7 @@ func NewOMSService(
 		DeliveryClient:      deliveryClient,
 		DeliveryConsumer:    deliveryConsumer,
 		LeaderboardConsumer: leaderboardConsumer,
+		OrderSearchConsumer: orderSearchConsumer,
 
 		// Pricer Integration
 		PricerClient: pricerClient,
@@ -337,6 +375,7 @@ func NewOMSService(
 		temporalClient: temporalClient,
 		cartWorker:     cartWorker,
 		orderWorker:    orderWorker,
+		orderExpiry:    orderExpiry,
 	}, nil
 }
 
@@ -26,24 +26,33 @@ import (
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/kafka"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/cart"
+	postgres4 "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/event_store"
 	postgres2 "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order"
+	postgres5 "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order_search"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/redis/cart_goods_index"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/redis/leaderboard"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/cart/v1"
 	v1_2 "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/run"
+	temporal2 "github.com/shortlink-org/shop/oms/internal/infrastructure/temporal"
 	"github.com/shortlink-org/shop/oms/internal/usecases/cart/command/add_items"
 	"github.com/shortlink-org/shop/oms/internal/usecases/cart/command/remove_items"
 	"github.com/shortlink-org/shop/oms/internal/usecases/cart/command/reset"
 	"github.com/shortlink-org/shop/oms/internal/usecases/cart/query/get"
 	get3 "github.com/shortlink-org/shop/oms/internal/usecases/leaderboard/query/get"
 	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/cancel"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/complete"
 	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/create"
 	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/create_order_from_cart"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/expire_pending_orders"
 	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/request_delivery"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/set_delivery_status"
 	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/update_delivery_info"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/update_order_items"
 	get2 "github.com/shortlink-org/shop/oms/internal/usecases/order/query/get"
-	"github.com/shortlink-org/shop/oms/internal/usecases/order/query/list"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/query/list_orders"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/query/search_orders"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/query/watch_status"
 	"github.com/shortlink-org/shop/oms/internal/workers/cart/cart_worker"
 	"github.com/shortlink-org/shop/oms/internal/workers/order/activities"
 	"github.com/shortlink-org/shop/oms/internal/workers/order/order_worker"
@@ -132,6 +141,22 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
+	postgresStore2, err := postgres4.New(context, dbDB)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	postgresStore3, err := postgres5.New(context, dbDB)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	rueidisClient, cleanup5, err := newRedisClient(config)
 	if err != nil {
 		cleanup4()
@@ -141,6 +166,7 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		return nil, nil, err
 	}
 	leaderboardStore := leaderboard.New(rueidisClient)
+	cart_goods_indexStore := cart_goods_index.New(rueidisClient)
 	eventBus, cleanup6, err := newEventBus(context, config, loggerLogger, dbDB, monitoring)
 	if err != nil {
 		cleanup5()
@@ -161,7 +187,30 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	deliveryConsumer, cleanup8, err := NewDeliveryConsumer(context, config, loggerLogger, uoW, postgresStore, postgresStore, eventPublisher)
+	clientClient, err := temporal.New(loggerLogger, config, tracerProvider, monitoring)
+	if err != nil {
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	orderWorkflowSignaler := temporal2.NewOrderWorkflowSignaler(clientClient)
+	set_delivery_statusHandler, err := set_delivery_status.NewHandler(loggerLogger, uoW, postgresStore, postgresStore, eventPublisher)
+	if err != nil {
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	deliveryConsumer, cleanup8, err := NewDeliveryConsumer(context, config, loggerLogger, uoW, postgresStore, orderWorkflowSignaler, set_delivery_statusHandler)
 	if err != nil {
 		cleanup7()
 		cleanup6()
@@ -184,7 +233,7 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	pricerClient, cleanup10, err := NewPricerClient(config, loggerLogger)
+	orderSearchConsumer, cleanup10, err := NewOrderSearchConsumer(context, config, loggerLogger, uoW, postgresStore, postgresStore3)
 	if err != nil {
 		cleanup9()
 		cleanup8()
@@ -197,9 +246,24 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
+	pricerClient, cleanup11, err := NewPricerClient(config, loggerLogger)
+	if err != nil {
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	registry := monitoring.Prometheus
 	recorder, err := flight_trace.New(context, config)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -214,6 +278,7 @@ func InitializeOMSService() (*OMSService, func(), error) {
 	}
 	server, err := grpc.InitServer(context, loggerLogger, tracerProvider, registry, recorder, config)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -226,8 +291,9 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	handler, err := add_items.NewHandler(loggerLogger, uoW, store, eventPublisher)
+	handler, err := add_items.NewHandler(loggerLogger, uoW, store, cart_goods_indexStore, eventPublisher)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -240,8 +306,9 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	remove_itemsHandler, err := remove_items.NewHandler(loggerLogger, uoW, store, eventPublisher)
+	remove_itemsHandler, err := remove_items.NewHandler(loggerLogger, uoW, store, cart_goods_indexStore, eventPublisher)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -254,8 +321,9 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	resetHandler, err := reset.NewHandler(loggerLogger, uoW, store, eventPublisher)
+	resetHandler, err := reset.NewHandler(loggerLogger, uoW, store, cart_goods_indexStore, eventPublisher)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -270,6 +338,7 @@ func InitializeOMSService() (*OMSService, func(), error) {
 	}
 	getHandler, err := get.NewHandler(uoW, store)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -284,6 +353,7 @@ func InitializeOMSService() (*OMSService, func(), error) {
 	}
 	cartRPC, err := v1.New(server, loggerLogger, handler, remove_itemsHandler, resetHandler, getHandler)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -298,6 +368,7 @@ func InitializeOMSService() (*OMSService, func(), error) {
 	}
 	response, err := NewRunRPCServer(server, cartRPC)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -312,6 +383,7 @@ func InitializeOMSService() (*OMSService, func(), error) {
 	}
 	createHandler, err := create.NewHandler(loggerLogger, uoW, postgresStore, eventPublisher)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -326,6 +398,7 @@ func InitializeOMSService() (*OMSService, func(), error) {
 	}
 	cancelHandler, err := cancel.NewHandler(loggerLogger, uoW, postgresStore, eventPublisher)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -340,6 +413,7 @@ func InitializeOMSService() (*OMSService, func(), error) {
 	}
 	update_delivery_infoHandler, err := update_delivery_info.NewHandler(loggerLogger, uoW, postgresStore, eventPublisher)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -352,8 +426,69 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	create_order_from_cartHandler, err := create_order_from_cart.NewHandler(loggerLogger, uoW, store, postgresStore, eventPublisher, pricerClient)
+	update_order_itemsHandler, err := update_order_items.NewHandler(loggerLogger, uoW, postgresStore, eventPublisher, pricerClient)
 	if err != nil {
+		cleanup11()
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	deliveryFeeCalculator, err := NewDeliveryFeeCalculator(config)
+	if err != nil {
+		cleanup11()
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	create_order_from_cartConfig, err := NewCheckoutConfig(config)
+	if err != nil {
+		cleanup11()
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	rateLimiter, err := NewCheckoutRateLimiter(config, rueidisClient)
+	if err != nil {
+		cleanup11()
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	create_order_from_cartHandler, err := create_order_from_cart.NewHandler(loggerLogger, uoW, store, postgresStore, eventPublisher, postgresStore2, cart_goods_indexStore, pricerClient, rateLimiter, deliveryFeeCalculator, create_order_from_cartConfig)
+	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -368,6 +503,7 @@ func InitializeOMSService() (*OMSService, func(), error) {
 	}
 	handler2, err := get2.NewHandler(uoW, postgresStore)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -380,8 +516,9 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	listHandler, err := list.NewHandler(uoW, postgresStore)
+	list_ordersHandler, err := list_orders.NewHandler(uoW, postgresStore)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -396,6 +533,7 @@ func InitializeOMSService() (*OMSService, func(), error) {
 	}
 	handler3, err := get3.NewHandler(leaderboardStore)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -408,8 +546,9 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	orderRPC, err := v1_2.New(server, loggerLogger, createHandler, cancelHandler, update_delivery_infoHandler, create_order_from_cartHandler, handler2, listHandler, handler3)
+	orderEventStream, cleanup12, err := NewOrderEventStream(context, config, loggerLogger)
 	if err != nil {
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -422,8 +561,42 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	clientClient, err := temporal.New(loggerLogger, config, tracerProvider, monitoring)
+	watch_statusHandler, err := watch_status.NewHandler(uoW, postgresStore, orderEventStream)
+	if err != nil {
+		cleanup12()
+		cleanup11()
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	search_ordersHandler, err := search_orders.NewHandler(postgresStore3)
 	if err != nil {
+		cleanup12()
+		cleanup11()
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	orderRPC, err := v1_2.New(server, loggerLogger, createHandler, cancelHandler, update_delivery_infoHandler, update_order_itemsHandler, create_order_from_cartHandler, handler2, list_ordersHandler, search_ordersHandler, handler3, watch_statusHandler)
+	if err != nil {
+		cleanup12()
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -438,6 +611,8 @@ func InitializeOMSService() (*OMSService, func(), error) {
 	}
 	cartWorker, err := cart_worker.New(context, clientClient, loggerLogger)
 	if err != nil {
+		cleanup12()
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -452,6 +627,24 @@ func InitializeOMSService() (*OMSService, func(), error) {
 	}
 	request_deliveryHandler, err := request_delivery.NewHandler(loggerLogger, uoW, postgresStore, eventPublisher)
 	if err != nil {
+		cleanup12()
+		cleanup11()
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	expire_pending_ordersHandler, err := expire_pending_orders.NewHandler(uoW, postgresStore, eventPublisher)
+	if err != nil {
+		cleanup12()
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -464,9 +657,61 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	activitiesActivities := activities.NewWithHandlers(cancelHandler, handler2, request_deliveryHandler, deliveryClient)
+	completeHandler, err := complete.NewHandler(loggerLogger, uoW, postgresStore, eventPublisher)
+	if err != nil {
+		cleanup12()
+		cleanup11()
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	geocoder, cleanup13, err := NewGeocoder(config, loggerLogger)
+	if err != nil {
+		cleanup12()
+		cleanup11()
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	activitiesActivities := activities.NewWithHandlers(cancelHandler, handler2, request_deliveryHandler, expire_pending_ordersHandler, set_delivery_statusHandler, completeHandler, deliveryClient, geocoder)
 	orderWorker, err := order_worker.NewWithActivities(context, clientClient, loggerLogger, activitiesActivities)
 	if err != nil {
+		cleanup13()
+		cleanup12()
+		cleanup11()
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	pendingOrderExpiry, err := NewPendingOrderExpiry(context, config, loggerLogger, clientClient, orderWorker)
+	if err != nil {
+		cleanup13()
+		cleanup12()
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -479,8 +724,11 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	omsService, err := NewOMSService(loggerLogger, config, monitoring, tracerProvider, pprofEndpoint, client, dbDB, uoW, store, postgresStore, leaderboardStore, eventPublisher, deliveryClient, deliveryConsumer, leaderboardConsumer, pricerClient, response, cartRPC, orderRPC, clientClient, cartWorker, orderWorker)
+	omsService, err := NewOMSService(loggerLogger, config, monitoring, tracerProvider, pprofEndpoint, client, dbDB, uoW, store, postgresStore, leaderboardStore, eventPublisher, deliveryClient, deliveryConsumer, leaderboardConsumer, orderSearchConsumer, pricerClient, response, cartRPC, orderRPC, clientClient, cartWorker, orderWorker, pendingOrderExpiry)
 	if err != nil {
+		cleanup13()
+		cleanup12()
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -494,6 +742,9 @@ func InitializeOMSService() (*OMSService, func(), error) {
 		return nil, nil, err
 	}
 	return omsService, func() {
+		cleanup13()
+		cleanup12()
+		cleanup11()
 		cleanup10()
 		cleanup9()
 		cleanup8()
@@ -545,6 +796,7 @@ type OMSService struct {
 	DeliveryClient      ports.DeliveryClient
 	DeliveryConsumer    *kafka.DeliveryConsumer
 	LeaderboardConsumer *kafka.LeaderboardConsumer
+	OrderSearchConsumer *kafka.OrderSearchConsumer
 
 	// Pricer Integration
 	PricerClient ports.PricerClient
@@ -553,6 +805,7 @@ type OMSService struct {
 	temporalClient client.Client
 	cartWorker     cart_worker.CartWorker
 	orderWorker    order_worker.OrderWorker
+	orderExpiry    PendingOrderExpiry
 }
 
 // OMSService ==========================================================================================================
@@ -565,11 +818,16 @@ var OMSSet = wire.NewSet(
 
 	CustomDefaultSet, flight_trace.New, grpc.InitServer, provideOMSConfig, logger.NewDefault, tracing.New, metrics.New, db.New, newDBOptions, wire.FieldsOf(new(*metrics.Monitoring), "Metrics", "Prometheus"), newRedisClient,
 
-	newUnitOfWork, wire.Bind(new(ports.UnitOfWork), new(*postgres3.UoW)), postgres.New, postgres2.New, wire.Bind(new(ports.CartRepository), new(*postgres.Store)), wire.Bind(new(ports.OrderRepository), new(*postgres2.Store)), wire.Bind(new(ports.DeliveryInboxRepository), new(*postgres2.Store)), cart_goods_index.New, wire.Bind(new(ports.CartGoodsIndex), new(*cart_goods_index.Store)), leaderboard.New, wire.Bind(new(ports.LeaderboardRepository), new(*leaderboard.Store)), newEventBus, bus.NewEventPublisher, wire.Bind(new(ports.EventPublisher), new(*bus.EventPublisher)), NewDeliveryClient,
+	newUnitOfWork, wire.Bind(new(ports.UnitOfWork), new(*postgres3.UoW)), postgres.New, postgres2.New, wire.Bind(new(ports.CartRepository), new(*postgres.Store)), wire.Bind(new(ports.OrderRepository), new(*postgres2.Store)), wire.Bind(new(ports.DeliveryInboxRepository), new(*postgres2.Store)), postgres4.New, wire.Bind(new(ports.EventStore), new(*postgres4.Store)), postgres5.New, wire.Bind(new(ports.OrderSearchRepository), new(*postgres5.Store)), cart_goods_index.New, wire.Bind(new(ports.CartGoodsIndex), new(*cart_goods_index.Store)), leaderboard.New, wire.Bind(new(ports.LeaderboardRepository), new(*leaderboard.Store)), newEventBus, bus.NewEventPublisher, wire.Bind(new(ports.EventPublisher), new(*bus.EventPublisher)), NewDeliveryClient,
 	NewDeliveryConsumer,
 	NewLeaderboardConsumer,
+	NewOrderSearchConsumer,
+
+	NewOrderEventStream, wire.Bind(new(ports.OrderEventStream), new(*kafka.OrderEventStream)), NewPricerClient,
+
+	NewGeocoder, add_items.NewHandler, remove_items.NewHandler, reset.NewHandler, get.NewHandler, create.NewHandler, cancel.NewHandler, complete.NewHandler, expire_pending_orders.NewHandler, request_delivery.NewHandler, set_delivery_status.NewHandler, update_delivery_info.NewHandler, update_order_items.NewHandler, get2.NewHandler, list_orders.NewHandler, search_orders.NewHandler, watch_status.NewHandler, get3.NewHandler, NewDeliveryFeeCalculator, NewCheckoutConfig, NewCheckoutRateLimiter, create_order_from_cart.NewHandler, v1.New, v1_2.New, NewRunRPCServer, temporal.New, temporal2.NewOrderWorkflowSignaler, wire.Bind(new(ports.OrderWorkflow), new(*temporal2.OrderWorkflowSignaler)), cart_worker.New, activities.NewWithHandlers, order_worker.NewWithActivities, NewPendingOrderExpiry,
 
-	NewPricerClient, add_items.NewHandler, remove_items.NewHandler, reset.NewHandler, get.NewHandler, create.NewHandler, cancel.NewHandler, request_delivery.NewHandler, update_delivery_info.NewHandler, get2.NewHandler, list.NewHandler, get3.NewHandler, create_order_from_cart.NewHandler, v1.New, v1_2.New, NewRunRPCServer, temporal.New, cart_worker.New, activities.NewWithHandlers, order_worker.NewWithActivities, NewOMSService,
+	NewOMSService,
 )
 
 // NewRunRPCServer starts the gRPC server
@@ -635,6 +893,7 @@ func NewOMSService(
 	deliveryClient ports.DeliveryClient,
 	deliveryConsumer *kafka.DeliveryConsumer,
 	leaderboardConsumer *kafka.LeaderboardConsumer,
+	orderSearchConsumer *kafka.OrderSearchConsumer,
 
 	pricerClient ports.PricerClient, run2 *run.Response,
 	cartRPCServer *v1.CartRPC,
@@ -643,6 +902,7 @@ func NewOMSService(
 	temporalClient client.Client,
 	cartWorker cart_worker.CartWorker,
 	orderWorker order_worker.OrderWorker,
+	orderExpiry PendingOrderExpiry,
 ) (*OMSService, error) {
 	return &OMSService{
 
@@ -666,6 +926,7 @@ func NewOMSService(
 		DeliveryClient:      deliveryClient,
 		DeliveryConsumer:    deliveryConsumer,
 		LeaderboardConsumer: leaderboardConsumer,
+		OrderSearchConsumer: orderSearchConsumer,
 
 		PricerClient: pricerClient,
 
@@ -676,5 +937,6 @@ func NewOMSService(
 		temporalClient: temporalClient,
 		cartWorker:     cartWorker,
 		orderWorker:    orderWorker,
+		orderExpiry:    orderExpiry,
 	}, nil
 }
@@ -19,8 +19,9 @@ func (s *State) AddItem(item itemv1.Item) error {
 	defer s.mu.Unlock()
 
 	// Validate item before adding
-	if !item.IsValid() {
-		return fmt.Errorf("invalid cart item goodId=%s quantity=%d: %w", item.GetGoodId(), item.GetQuantity(), ErrInvalidCartItem)
+	err := validateCartItem(item)
+	if err != nil {
+		return err
 	}
 
 	// Check if the item already exists in the cart
@@ -29,12 +30,13 @@ func (s *State) AddItem(item itemv1.Item) error {
 			continue
 		}
 		// Create a new item with updated quantity (immutable update)
-		updatedItem, err := cartItem.WithQuantity(cartItem.GetQuantity() + item.GetQuantity())
+		updatedItem, err := mergeCartItem(cartItem, item)
 		if err != nil {
-			return fmt.Errorf("failed to update item quantity: %w", err)
+			return err
 		}
 
 		s.items[i] = updatedItem
+		s.pricingSnapshot = nil
 		// Generate domain event for item added/updated
 		s.addDomainEvent(&eventsv1.ItemAddedEvent{
 			CustomerID: s.customerId,
@@ -47,6 +49,7 @@ func (s *State) AddItem(item itemv1.Item) error {
 
 	// Item doesn't exist, add it
 	s.items = append(s.items, item)
+	s.pricingSnapshot = nil
 	// Generate domain event for item added
 	s.addDomainEvent(&eventsv1.ItemAddedEvent{
 		CustomerID: s.customerId,
@@ -56,3 +59,30 @@ func (s *State) AddItem(item itemv1.Item) error {
 
 	return nil
 }
+
+// validateCartItem rejects an item that cannot be put in the cart.
+func validateCartItem(item itemv1.Item) error {
+	if !item.IsValid() {
+		return fmt.Errorf("invalid cart item goodId=%s quantity=%d: %w", item.GetGoodId(), item.GetQuantity(), ErrInvalidCartItem)
+	}
+
+	return nil
+}
+
+// mergeCartItem adds the quantity of item to cartItem of the same good.
+// A note on item replaces the one already in the cart.
+func mergeCartItem(cartItem, item itemv1.Item) (itemv1.Item, error) {
+	updatedItem, err := cartItem.WithQuantity(cartItem.GetQuantity() + item.GetQuantity())
+	if err != nil {
+		return itemv1.Item{}, fmt.Errorf("failed to update item quantity: %w", err)
+	}
+
+	if item.GetNote() != "" {
+		updatedItem, err = updatedItem.WithNote(item.GetNote())
+		if err != nil {
+			return itemv1.Item{}, fmt.Errorf("failed to update item note: %w", err)
+		}
+	}
+
+	return updatedItem, nil
+}
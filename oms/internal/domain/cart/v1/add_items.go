@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	eventsv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/events/v1"
+	itemsv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/items/v1"
+)
+
+// AddItems adds a batch of items to the cart in one step.
+// All items are validated first; if any is invalid the cart is left unchanged.
+// Items of the same good, in the batch or already in the cart, are merged into one line
+// and one ItemAddedEvent is recorded per touched line.
+func (s *State) AddItems(items itemsv1.Items) error {
+	for i, item := range items {
+		err := validateCartItem(item)
+		if err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Work on a copy so a failed merge leaves the cart untouched
+	merged := slices.Clone(s.items)
+
+	index := make(map[uuid.UUID]int, len(merged)+len(items))
+	for i, cartItem := range merged {
+		index[cartItem.GetGoodId()] = i
+	}
+
+	touched := make([]int, 0, len(items))
+	seen := make(map[int]struct{}, len(items))
+
+	for _, item := range items {
+		i, ok := index[item.GetGoodId()]
+		if !ok {
+			i = len(merged)
+			index[item.GetGoodId()] = i
+			merged = append(merged, item)
+		} else {
+			updatedItem, err := mergeCartItem(merged[i], item)
+			if err != nil {
+				return err
+			}
+
+			merged[i] = updatedItem
+		}
+
+		if _, ok := seen[i]; !ok {
+			seen[i] = struct{}{}
+			touched = append(touched, i)
+		}
+	}
+
+	s.items = merged
+	s.pricingSnapshot = nil
+
+	now := time.Now()
+	for _, i := range touched {
+		s.addDomainEvent(&eventsv1.ItemAddedEvent{
+			CustomerID: s.customerId,
+			Item:       s.items[i],
+			OccurredAt: now,
+		})
+	}
+
+	return nil
+}
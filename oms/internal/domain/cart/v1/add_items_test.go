@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	eventsv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/events/v1"
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+	itemsv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/items/v1"
+)
+
+func mustItem(t *testing.T, goodID uuid.UUID, quantity int32) itemv1.Item {
+	t.Helper()
+
+	item, err := itemv1.NewItem(goodID, quantity)
+	require.NoError(t, err)
+
+	return item
+}
+
+func itemAddedEvents(state *State) []*eventsv1.ItemAddedEvent {
+	var added []*eventsv1.ItemAddedEvent
+
+	for _, event := range state.GetDomainEvents() {
+		if e, ok := event.(*eventsv1.ItemAddedEvent); ok {
+			added = append(added, e)
+		}
+	}
+
+	return added
+}
+
+func TestState_AddItems(t *testing.T) {
+	state := New(uuid.New())
+	first, second := uuid.New(), uuid.New()
+
+	err := state.AddItems(itemsv1.Items{mustItem(t, first, 1), mustItem(t, second, 2)})
+	require.NoError(t, err)
+
+	items := state.GetItems()
+	require.Len(t, items, 2)
+	require.Equal(t, first, items[0].GetGoodId())
+	require.Equal(t, int32(1), items[0].GetQuantity())
+	require.Equal(t, second, items[1].GetGoodId())
+	require.Equal(t, int32(2), items[1].GetQuantity())
+	require.Len(t, itemAddedEvents(state), 2)
+}
+
+func TestState_AddItemsMergesDuplicates(t *testing.T) {
+	state := New(uuid.New())
+	existing, fresh := uuid.New(), uuid.New()
+
+	require.NoError(t, state.AddItem(mustItem(t, existing, 1)))
+	state.ClearDomainEvents()
+
+	err := state.AddItems(itemsv1.Items{
+		mustItem(t, fresh, 1),
+		mustItem(t, existing, 2),
+		mustItem(t, fresh, 3),
+	})
+	require.NoError(t, err)
+
+	items := state.GetItems()
+	require.Len(t, items, 2)
+	require.Equal(t, existing, items[0].GetGoodId())
+	require.Equal(t, int32(3), items[0].GetQuantity())
+	require.Equal(t, fresh, items[1].GetGoodId())
+	require.Equal(t, int32(4), items[1].GetQuantity())
+
+	// One event per touched line, carrying the merged quantity
+	added := itemAddedEvents(state)
+	require.Len(t, added, 2)
+	require.Equal(t, fresh, added[0].Item.GetGoodId())
+	require.Equal(t, int32(4), added[0].Item.GetQuantity())
+	require.Equal(t, existing, added[1].Item.GetGoodId())
+	require.Equal(t, int32(3), added[1].Item.GetQuantity())
+}
+
+func TestState_AddItemsInvalidItemAppliesNone(t *testing.T) {
+	state := New(uuid.New())
+	existing := uuid.New()
+
+	require.NoError(t, state.AddItem(mustItem(t, existing, 1)))
+	state.ClearDomainEvents()
+
+	err := state.AddItems(itemsv1.Items{
+		mustItem(t, existing, 2),
+		mustItem(t, uuid.New(), 1),
+		{}, // zero value: no good ID, no quantity
+	})
+	require.ErrorIs(t, err, ErrInvalidCartItem)
+
+	items := state.GetItems()
+	require.Len(t, items, 1)
+	require.Equal(t, int32(1), items[0].GetQuantity())
+	require.Empty(t, state.GetDomainEvents())
+}
@@ -0,0 +1,21 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	itemsv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/items/v1"
+)
+
+// CartAbandonedEvent represents the domain event when a cart is cleared by the session timeout
+// instead of being checked out or reset by the customer
+type CartAbandonedEvent struct {
+	CustomerID uuid.UUID
+	Items      itemsv1.Items
+	OccurredAt time.Time
+}
+
+func (e *CartAbandonedEvent) EventType() string {
+	return "CartAbandoned"
+}
@@ -9,6 +9,8 @@ import (
 // ResetEvent represents the domain event when the cart is reset
 type ResetEvent struct {
 	CustomerID uuid.UUID
+	// Reason is why the cart was reset (CHECKOUT, ABANDONED, ADMIN); empty when not recorded
+	Reason     string
 	OccurredAt time.Time
 }
 
@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// MaxGiftMessageLength is the maximum length of a cart gift message, in characters.
+const MaxGiftMessageLength = 1000
+
+// ErrGiftMessageTooLong is returned when a gift message exceeds MaxGiftMessageLength.
+var ErrGiftMessageTooLong = errors.New("gift message is too long")
+
+// SetGiftMessage attaches a gift message to the whole cart; an empty message removes it.
+func (s *State) SetGiftMessage(message string) error {
+	if utf8.RuneCountInString(message) > MaxGiftMessageLength {
+		return fmt.Errorf("%w: maximum is %d characters", ErrGiftMessageTooLong, MaxGiftMessageLength)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.giftMessage = message
+
+	return nil
+}
+
+// GetGiftMessage returns the cart gift message; empty when none.
+func (s *State) GetGiftMessage() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.giftMessage
+}
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+)
+
+func TestState_SetGiftMessageEnforcesLength(t *testing.T) {
+	state := New(uuid.New())
+
+	require.NoError(t, state.SetGiftMessage(strings.Repeat("ж", MaxGiftMessageLength)))
+	require.Equal(t, MaxGiftMessageLength, len([]rune(state.GetGiftMessage())))
+
+	err := state.SetGiftMessage(strings.Repeat("a", MaxGiftMessageLength+1))
+	require.ErrorIs(t, err, ErrGiftMessageTooLong)
+	require.Equal(t, MaxGiftMessageLength, len([]rune(state.GetGiftMessage())), "a rejected message must not replace the current one")
+
+	require.NoError(t, state.SetGiftMessage(""))
+	require.Empty(t, state.GetGiftMessage())
+}
+
+func TestState_ResetClearsGiftMessage(t *testing.T) {
+	state := New(uuid.New())
+	require.NoError(t, state.SetGiftMessage("Happy birthday!"))
+
+	state.Reset()
+
+	require.Empty(t, state.GetGiftMessage())
+}
+
+func TestState_AddItemKeepsItemNote(t *testing.T) {
+	state := New(uuid.New())
+	goodID := uuid.New()
+
+	item, err := itemv1.NewItem(goodID, 1)
+	require.NoError(t, err)
+	item, err = item.WithNote("wrap separately")
+	require.NoError(t, err)
+	require.NoError(t, state.AddItem(item))
+
+	// Adding more of the same good without a note keeps the existing one
+	more, err := itemv1.NewItem(goodID, 2)
+	require.NoError(t, err)
+	require.NoError(t, state.AddItem(more))
+	require.Equal(t, "wrap separately", state.GetItems()[0].GetNote())
+
+	// A new note replaces it
+	renoted, err := more.WithNote("no ribbon")
+	require.NoError(t, err)
+	require.NoError(t, state.AddItem(renoted))
+
+	items := state.GetItems()
+	require.Len(t, items, 1)
+	require.Equal(t, int32(5), items[0].GetQuantity())
+	require.Equal(t, "no ribbon", items[0].GetNote())
+
+	// Repricing keeps the note
+	priced, err := items[0].WithPricing(decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+	require.Equal(t, "no ribbon", priced.GetNote())
+}
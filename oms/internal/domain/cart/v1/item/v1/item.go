@@ -3,6 +3,7 @@ package v1
 import (
 	"errors"
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -18,8 +19,12 @@ var (
 	ErrItemDiscountNegative     = errors.New("item discount cannot be negative")
 	ErrItemTaxNegative          = errors.New("item tax cannot be negative")
 	ErrItemDiscountExceedsPrice = errors.New("item discount cannot exceed price")
+	ErrItemNoteTooLong          = errors.New("item note is too long")
 )
 
+// MaxNoteLength is the maximum length of an item note, in characters.
+const MaxNoteLength = 500
+
 // Item represents an immutable cart item.
 // All fields are private and can only be set through constructors.
 type Item struct {
@@ -33,6 +38,12 @@ type Item struct {
 	discount decimal.Decimal
 	// tax is the tax amount per unit
 	tax decimal.Decimal
+	// currency is the ISO-4217 currency of price, discount and tax
+	currency pricing.Currency
+	// note is an optional customer instruction for this item; empty when none
+	note string
+	// taxCategory is the product category tax policies branch on; empty means the pricer's default
+	taxCategory string
 }
 
 // NewItem creates a new Item with required fields only.
@@ -104,10 +115,46 @@ func NewItemWithPricing(
 	return item, nil
 }
 
-// WithPricing returns a new Item with updated pricing information.
+// WithPricing returns a new Item with updated pricing information in the item's currency.
 // This preserves immutability by creating a new instance.
 func (i Item) WithPricing(price, discount, tax decimal.Decimal) (Item, error) {
-	return NewItemWithPricing(i.goodId, i.quantity, price, discount, tax)
+	item, err := NewItemWithPricing(i.goodId, i.quantity, price, discount, tax)
+	if err != nil {
+		return Item{}, err
+	}
+
+	item.note = i.note
+	item.taxCategory = i.taxCategory
+
+	return item.WithCurrency(i.currency), nil
+}
+
+// WithNote returns a new Item carrying the given note; an empty note removes it.
+// This preserves immutability by creating a new instance.
+func (i Item) WithNote(note string) (Item, error) {
+	if utf8.RuneCountInString(note) > MaxNoteLength {
+		return Item{}, fmt.Errorf("%w: maximum is %d characters", ErrItemNoteTooLong, MaxNoteLength)
+	}
+
+	i.note = note
+
+	return i, nil
+}
+
+// WithCurrency returns a new Item whose amounts are in the given currency.
+// This preserves immutability by creating a new instance.
+func (i Item) WithCurrency(currency pricing.Currency) Item {
+	i.currency = currency
+
+	return i
+}
+
+// WithTaxCategory returns a new Item in the given tax category, e.g. "food"; empty means the pricer's default.
+// This preserves immutability by creating a new instance.
+func (i Item) WithTaxCategory(taxCategory string) Item {
+	i.taxCategory = taxCategory
+
+	return i
 }
 
 // WithQuantity returns a new Item with updated quantity.
@@ -118,11 +165,14 @@ func (i Item) WithQuantity(quantity int32) (Item, error) {
 	}
 
 	return Item{
-		goodId:   i.goodId,
-		quantity: quantity,
-		price:    i.price,
-		discount: i.discount,
-		tax:      i.tax,
+		goodId:      i.goodId,
+		quantity:    quantity,
+		price:       i.price,
+		discount:    i.discount,
+		tax:         i.tax,
+		currency:    i.currency,
+		note:        i.note,
+		taxCategory: i.taxCategory,
 	}, nil
 }
 
@@ -137,7 +187,7 @@ func (i Item) WithPricePolicy(policy pricing.PricePolicy) (Item, error) {
 		return Item{}, fmt.Errorf("price quote: %w", err)
 	}
 
-	return NewItemWithPricing(i.goodId, i.quantity, quote.UnitPrice, quote.Discount, quote.Tax)
+	return i.WithPricing(quote.UnitPrice, quote.Discount, quote.Tax)
 }
 
 // GetGoodId returns the good ID.
@@ -165,6 +215,21 @@ func (i Item) GetTax() decimal.Decimal {
 	return i.tax
 }
 
+// GetCurrency returns the currency of the item amounts.
+func (i Item) GetCurrency() pricing.Currency {
+	return i.currency
+}
+
+// GetNote returns the customer note for this item; empty when none.
+func (i Item) GetNote() string {
+	return i.note
+}
+
+// GetTaxCategory returns the tax category of the item; empty means the pricer's default.
+func (i Item) GetTaxCategory() string {
+	return i.taxCategory
+}
+
 // GetPriceAfterDiscount returns the price after discount (price - discount).
 func (i Item) GetPriceAfterDiscount() decimal.Decimal {
 	priceAfterDiscount := i.price.Sub(i.discount)
@@ -197,5 +262,6 @@ func (i Item) IsValid() bool {
 		!i.price.IsNegative() &&
 		!i.discount.IsNegative() &&
 		!i.tax.IsNegative() &&
-		!i.discount.GreaterThan(i.price)
+		!i.discount.GreaterThan(i.price) &&
+		utf8.RuneCountInString(i.note) <= MaxNoteLength
 }
@@ -16,7 +16,7 @@ func NewItemSpecification() specification.Specification[itemv1.Item] {
 }
 
 // NewItemWithPricingSpecification returns a composite specification for full Item validation.
-// Validates: goodId not empty, quantity > 0, price >= 0, discount >= 0, tax >= 0, discount <= price.
+// Validates: goodId not empty, quantity > 0, price >= 0, discount >= 0, tax >= 0, discount <= price, note length.
 func NewItemWithPricingSpecification() specification.Specification[itemv1.Item] {
 	return specification.NewAndSpecification[itemv1.Item](
 		GoodIdNotEmptySpec{},
@@ -25,5 +25,6 @@ func NewItemWithPricingSpecification() specification.Specification[itemv1.Item]
 		DiscountNonNegativeSpec{},
 		TaxNonNegativeSpec{},
 		DiscountNotExceedsPriceSpec{},
+		NoteLengthSpec{},
 	)
 }
@@ -0,0 +1,18 @@
+package rules
+
+import (
+	"unicode/utf8"
+
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+)
+
+// NoteLengthSpec validates that the item note fits in itemv1.MaxNoteLength characters.
+type NoteLengthSpec struct{}
+
+func (s NoteLengthSpec) IsSatisfiedBy(item *itemv1.Item) error {
+	if utf8.RuneCountInString(item.GetNote()) > itemv1.MaxNoteLength {
+		return itemv1.ErrItemNoteTooLong
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+)
+
+func TestNoteLengthSpec(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty note passes", func(t *testing.T) {
+		t.Parallel()
+
+		item, err := itemv1.NewItem(uuid.New(), 1)
+		require.NoError(t, err)
+
+		spec := NoteLengthSpec{}
+		assert.NoError(t, spec.IsSatisfiedBy(&item))
+	})
+
+	t.Run("note at the limit passes", func(t *testing.T) {
+		t.Parallel()
+
+		item, err := itemv1.NewItem(uuid.New(), 1)
+		require.NoError(t, err)
+
+		// Multi-byte characters count once each
+		item, err = item.WithNote(strings.Repeat("ü", itemv1.MaxNoteLength))
+		require.NoError(t, err)
+
+		spec := NoteLengthSpec{}
+		assert.NoError(t, spec.IsSatisfiedBy(&item))
+		assert.True(t, item.IsValid())
+	})
+
+	t.Run("note over the limit fails in WithNote", func(t *testing.T) {
+		t.Parallel()
+
+		item, err := itemv1.NewItem(uuid.New(), 1)
+		require.NoError(t, err)
+
+		_, err = item.WithNote(strings.Repeat("a", itemv1.MaxNoteLength+1))
+		assert.ErrorIs(t, err, itemv1.ErrItemNoteTooLong)
+	})
+}
@@ -2,7 +2,19 @@ package v1
 
 import (
 	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+	pricing "github.com/shortlink-org/shop/oms/internal/domain/pricing"
 )
 
 // Items represents a collection of cart items.
 type Items []itemv1.Item
+
+// Currency returns the single currency the items are priced in.
+// Mixing currencies yields a *pricing.CurrencyMismatchError.
+func (items Items) Currency() (pricing.Currency, error) {
+	currencies := make([]pricing.Currency, 0, len(items))
+	for _, item := range items {
+		currencies = append(currencies, item.GetCurrency())
+	}
+
+	return pricing.SingleCurrency(currencies...)
+}
@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	pricing "github.com/shortlink-org/shop/oms/internal/domain/pricing"
+)
+
+// PricingSnapshotTTL is how long prices locked at "review order" time are honored by checkout.
+const PricingSnapshotTTL = 15 * time.Minute
+
+// PricingTotals is the pricer result locked into a PricingSnapshot.
+type PricingTotals struct {
+	Subtotal      decimal.Decimal
+	TotalDiscount decimal.Decimal
+	OrderDiscount decimal.Decimal
+	TotalTax      decimal.Decimal
+	FinalPrice    decimal.Decimal
+	Currency      pricing.Currency
+	Policies      []string
+	// Items is the pricer's per-item breakdown; empty when the pricer reports totals only
+	Items []PricedItem
+}
+
+// PricedItem is the unit price the pricer charged for a good, kept so checkout can
+// reconcile the cart prices against a reused price lock.
+type PricedItem struct {
+	GoodID    uuid.UUID
+	UnitPrice decimal.Decimal
+}
+
+// PricingSnapshot is a price lock taken when the customer reviews the cart.
+// It is dropped as soon as the cart items change.
+type PricingSnapshot struct {
+	totals    PricingTotals
+	pricedAt  time.Time
+	expiresAt time.Time
+}
+
+// NewPricingSnapshot creates a snapshot from persisted data.
+func NewPricingSnapshot(totals PricingTotals, pricedAt, expiresAt time.Time) PricingSnapshot {
+	totals.Policies = slices.Clone(totals.Policies)
+	totals.Items = slices.Clone(totals.Items)
+
+	return PricingSnapshot{
+		totals:    totals,
+		pricedAt:  pricedAt,
+		expiresAt: expiresAt,
+	}
+}
+
+// GetTotals returns the locked pricer result.
+func (p PricingSnapshot) GetTotals() PricingTotals {
+	totals := p.totals
+	totals.Policies = slices.Clone(p.totals.Policies)
+	totals.Items = slices.Clone(p.totals.Items)
+
+	return totals
+}
+
+// GetPricedAt returns when the cart was priced.
+func (p PricingSnapshot) GetPricedAt() time.Time {
+	return p.pricedAt
+}
+
+// GetExpiresAt returns when the price lock stops being honored.
+func (p PricingSnapshot) GetExpiresAt() time.Time {
+	return p.expiresAt
+}
+
+// IsValidAt reports whether the price lock is still honored at now.
+func (p PricingSnapshot) IsValidAt(now time.Time) bool {
+	return now.Before(p.expiresAt)
+}
+
+// SnapshotPricing locks the pricer result for PricingSnapshotTTL from at.
+// A previous snapshot is replaced.
+func (s *State) SnapshotPricing(totals PricingTotals, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := NewPricingSnapshot(totals, at, at.Add(PricingSnapshotTTL))
+	s.pricingSnapshot = &snapshot
+}
+
+// GetPricingSnapshot returns the price lock, expired or not; nil when the cart has none.
+func (s *State) GetPricingSnapshot() *PricingSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pricingSnapshot == nil {
+		return nil
+	}
+
+	snapshot := *s.pricingSnapshot
+
+	return &snapshot
+}
+
+// ValidPricingSnapshot returns the price lock if it is still honored at now.
+func (s *State) ValidPricingSnapshot(now time.Time) (PricingSnapshot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pricingSnapshot == nil || !s.pricingSnapshot.IsValidAt(now) {
+		return PricingSnapshot{}, false
+	}
+
+	return *s.pricingSnapshot, true
+}
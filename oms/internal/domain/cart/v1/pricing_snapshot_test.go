@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+)
+
+func newSnapshotCart(t *testing.T) (*State, itemv1.Item) {
+	t.Helper()
+
+	state := New(uuid.New())
+
+	item, err := itemv1.NewItem(uuid.New(), 2)
+	require.NoError(t, err)
+	require.NoError(t, state.AddItem(item))
+
+	return state, item
+}
+
+func TestState_SnapshotPricingValidUntilTTL(t *testing.T) {
+	state, _ := newSnapshotCart(t)
+	pricedAt := time.Date(2026, time.March, 11, 10, 0, 0, 0, time.UTC)
+
+	state.SnapshotPricing(PricingTotals{FinalPrice: decimal.NewFromInt(42), Currency: "EUR"}, pricedAt)
+
+	snapshot, ok := state.ValidPricingSnapshot(pricedAt.Add(PricingSnapshotTTL - time.Second))
+	require.True(t, ok)
+	require.True(t, snapshot.GetTotals().FinalPrice.Equal(decimal.NewFromInt(42)))
+	require.Equal(t, pricedAt, snapshot.GetPricedAt())
+
+	_, ok = state.ValidPricingSnapshot(pricedAt.Add(PricingSnapshotTTL))
+	require.False(t, ok, "an expired price lock must not be honored")
+	require.NotNil(t, state.GetPricingSnapshot(), "an expired snapshot stays until it is replaced or invalidated")
+}
+
+func TestState_ItemChangesInvalidatePricingSnapshot(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		change func(t *testing.T, state *State, item itemv1.Item)
+	}{
+		{
+			name: "add new item",
+			change: func(t *testing.T, state *State, _ itemv1.Item) {
+				other, err := itemv1.NewItem(uuid.New(), 1)
+				require.NoError(t, err)
+				require.NoError(t, state.AddItem(other))
+			},
+		},
+		{
+			name: "increase quantity",
+			change: func(t *testing.T, state *State, item itemv1.Item) {
+				require.NoError(t, state.AddItem(item))
+			},
+		},
+		{
+			name: "decrease quantity",
+			change: func(t *testing.T, state *State, item itemv1.Item) {
+				one, err := item.WithQuantity(1)
+				require.NoError(t, err)
+				require.NoError(t, state.RemoveItem(one))
+			},
+		},
+		{
+			name: "remove item",
+			change: func(t *testing.T, state *State, item itemv1.Item) {
+				require.NoError(t, state.RemoveItem(item))
+			},
+		},
+		{
+			name: "reset",
+			change: func(_ *testing.T, state *State, _ itemv1.Item) {
+				state.ResetWithReason(ResetReasonAdmin)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, item := newSnapshotCart(t)
+			state.SnapshotPricing(PricingTotals{FinalPrice: decimal.NewFromInt(42)}, now)
+
+			tt.change(t, state, item)
+
+			require.Nil(t, state.GetPricingSnapshot())
+		})
+	}
+}
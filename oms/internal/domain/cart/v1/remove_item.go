@@ -24,6 +24,7 @@ func (s *State) RemoveItem(item itemv1.Item) error {
 		if newQuantity <= 0 {
 			// Remove the item completely
 			s.items = append(s.items[:i], s.items[i+1:]...)
+			s.pricingSnapshot = nil
 			// Generate domain event for item removed
 			s.addDomainEvent(&eventsv1.ItemRemovedEvent{
 				CustomerID: s.customerId,
@@ -41,6 +42,7 @@ func (s *State) RemoveItem(item itemv1.Item) error {
 		}
 
 		s.items[i] = updatedItem
+		s.pricingSnapshot = nil
 		// Generate domain event for item removed (quantity decreased)
 		s.addDomainEvent(&eventsv1.ItemRemovedEvent{
 			CustomerID: s.customerId,
@@ -7,15 +7,116 @@ import (
 	itemsv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/items/v1"
 )
 
-// Reset resets the cart.
+// ResetReason records why a cart was emptied.
+type ResetReason string
+
+const (
+	// ResetReasonCheckout means the items were moved into an order.
+	ResetReasonCheckout ResetReason = "CHECKOUT"
+	// ResetReasonAbandoned means the session timed out without checkout.
+	ResetReasonAbandoned ResetReason = "ABANDONED"
+	// ResetReasonAdmin means the reset was requested explicitly through the Cart API.
+	ResetReasonAdmin ResetReason = "ADMIN"
+)
+
+// IsValid reports whether the reason is one of the known reset reasons.
+func (r ResetReason) IsValid() bool {
+	switch r {
+	case ResetReasonCheckout, ResetReasonAbandoned, ResetReasonAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResetRecord is the reason and time of the last reset of a cart.
+type ResetRecord struct {
+	Reason ResetReason
+	At     time.Time
+}
+
+// IsZero reports whether the cart was never reset with a reason.
+func (r ResetRecord) IsZero() bool {
+	return r.Reason == "" && r.At.IsZero()
+}
+
+// Reset resets the cart without recording a reason.
+//
+// Deprecated: use ResetWithReason so the reset can be audited.
 func (s *State) Reset() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.reset("", time.Now())
+}
+
+// ResetWithReason resets the cart and records reason as its last reset.
+// ResetReasonAbandoned behaves like Abandon.
+func (s *State) ResetWithReason(reason ResetReason) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	if reason == ResetReasonAbandoned {
+		s.recordAbandonment(now)
+	}
+
+	s.reset(reason, now)
+}
+
+// Abandon resets a cart left without checkout and records a CartAbandonedEvent
+// with the dropped items. An empty cart is only reset.
+func (s *State) Abandon() {
+	s.ResetWithReason(ResetReasonAbandoned)
+}
+
+// GetLastReset returns the reason and time of the last reset; zero when the cart was never reset with a reason.
+func (s *State) GetLastReset() ResetRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lastReset
+}
+
+// WithLastReset restores the persisted last reset of a reconstituted cart.
+func (s *State) WithLastReset(record ResetRecord) *State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastReset = record
+
+	return s
+}
+
+// recordAbandonment records a CartAbandonedEvent for a non-empty cart; callers must hold s.mu.
+func (s *State) recordAbandonment(now time.Time) {
+	if len(s.items) == 0 {
+		return
+	}
+
+	s.addDomainEvent(&eventsv1.CartAbandonedEvent{
+		CustomerID: s.customerId,
+		Items:      s.items,
+		OccurredAt: now,
+	})
+}
+
+// reset clears the items, the price lock and the gift message; callers must hold s.mu.
+// An empty reason leaves the last reset record untouched.
+func (s *State) reset(reason ResetReason, now time.Time) {
 	s.items = make(itemsv1.Items, 0)
+	s.pricingSnapshot = nil
+	s.giftMessage = ""
+
+	if reason != "" {
+		s.lastReset = ResetRecord{Reason: reason, At: now}
+	}
+
 	// Generate domain event for cart reset
 	s.addDomainEvent(&eventsv1.ResetEvent{
 		CustomerID: s.customerId,
-		OccurredAt: time.Now(),
+		Reason:     string(reason),
+		OccurredAt: now,
 	})
 }
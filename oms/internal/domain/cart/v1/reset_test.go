@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	eventsv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/events/v1"
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+)
+
+func abandonedEvents(state *State) []*eventsv1.CartAbandonedEvent {
+	var abandoned []*eventsv1.CartAbandonedEvent
+
+	for _, event := range state.GetDomainEvents() {
+		if e, ok := event.(*eventsv1.CartAbandonedEvent); ok {
+			abandoned = append(abandoned, e)
+		}
+	}
+
+	return abandoned
+}
+
+func TestState_AbandonRecordsItems(t *testing.T) {
+	customerID := uuid.New()
+	state := New(customerID)
+
+	item, err := itemv1.NewItem(uuid.New(), 2)
+	require.NoError(t, err)
+	require.NoError(t, state.AddItem(item))
+	state.ClearDomainEvents()
+
+	state.Abandon()
+
+	abandoned := abandonedEvents(state)
+	require.Len(t, abandoned, 1)
+	require.Equal(t, customerID, abandoned[0].CustomerID)
+	require.Len(t, abandoned[0].Items, 1)
+	require.Equal(t, item.GetGoodId(), abandoned[0].Items[0].GetGoodId())
+	require.Empty(t, state.GetItems())
+}
+
+func TestState_ResetAndEmptyAbandonRecordNoAbandonment(t *testing.T) {
+	state := New(uuid.New())
+
+	item, err := itemv1.NewItem(uuid.New(), 1)
+	require.NoError(t, err)
+	require.NoError(t, state.AddItem(item))
+
+	state.ResetWithReason(ResetReasonAdmin)
+	require.Empty(t, abandonedEvents(state))
+
+	state.Abandon()
+	require.Empty(t, abandonedEvents(state), "an empty cart is not abandoned")
+}
+
+func TestState_ResetWithReasonRecordsReason(t *testing.T) {
+	reasons := []ResetReason{ResetReasonCheckout, ResetReasonAbandoned, ResetReasonAdmin}
+
+	for _, reason := range reasons {
+		t.Run(string(reason), func(t *testing.T) {
+			state := New(uuid.New())
+
+			item, err := itemv1.NewItem(uuid.New(), 1)
+			require.NoError(t, err)
+			require.NoError(t, state.AddItem(item))
+			require.NoError(t, state.SetGiftMessage("Happy birthday!"))
+
+			before := time.Now()
+			state.ResetWithReason(reason)
+
+			require.Empty(t, state.GetItems())
+			require.Empty(t, state.GetGiftMessage())
+
+			lastReset := state.GetLastReset()
+			require.Equal(t, reason, lastReset.Reason)
+			require.False(t, lastReset.At.Before(before))
+
+			events := state.GetDomainEvents()
+			resetEvent, ok := events[len(events)-1].(*eventsv1.ResetEvent)
+			require.True(t, ok)
+			require.Equal(t, string(reason), resetEvent.Reason)
+
+			// Only an abandonment records the dropped items
+			require.Equal(t, reason == ResetReasonAbandoned, len(abandonedEvents(state)) == 1)
+		})
+	}
+}
+
+func TestState_DeprecatedResetKeepsLastReason(t *testing.T) {
+	state := New(uuid.New())
+	state.ResetWithReason(ResetReasonCheckout)
+	recorded := state.GetLastReset()
+
+	//nolint:staticcheck // SA1019: the deprecated shim is what is under test
+	state.Reset()
+
+	require.Equal(t, recorded, state.GetLastReset())
+	require.True(t, New(uuid.New()).GetLastReset().IsZero())
+}
+
+func TestResetReason_IsValid(t *testing.T) {
+	require.True(t, ResetReasonCheckout.IsValid())
+	require.True(t, ResetReasonAbandoned.IsValid())
+	require.True(t, ResetReasonAdmin.IsValid())
+	require.False(t, ResetReason("").IsValid())
+	require.False(t, ResetReason("TIMEOUT").IsValid())
+}
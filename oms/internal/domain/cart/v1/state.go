@@ -19,6 +19,12 @@ type State struct {
 	customerId uuid.UUID
 	// version is used for optimistic concurrency control
 	version int
+	// pricingSnapshot is the price lock taken at "review order" time; nil when none
+	pricingSnapshot *PricingSnapshot
+	// giftMessage is an optional message for the whole cart; empty when none
+	giftMessage string
+	// lastReset is the reason and time of the last reset; zero when never reset with a reason
+	lastReset ResetRecord
 	// domainEvents stores domain events that occurred during aggregate operations
 	domainEvents []domainevents.Event
 }
@@ -36,12 +42,21 @@ func New(customerId uuid.UUID) *State {
 // Reconstitute creates a cart state from persisted data.
 // This is used by the repository to rebuild the aggregate from the database.
 // It bypasses validation since the data is already validated when it was saved.
-func Reconstitute(customerId uuid.UUID, items itemsv1.Items, version int) *State {
+// pricingSnapshot is optional (nil when the cart has no price lock); giftMessage is empty when none.
+func Reconstitute(
+	customerId uuid.UUID,
+	items itemsv1.Items,
+	version int,
+	pricingSnapshot *PricingSnapshot,
+	giftMessage string,
+) *State {
 	return &State{
-		items:        items,
-		customerId:   customerId,
-		version:      version,
-		domainEvents: make([]domainevents.Event, 0),
+		items:           items,
+		customerId:      customerId,
+		version:         version,
+		pricingSnapshot: pricingSnapshot,
+		giftMessage:     giftMessage,
+		domainEvents:    make([]domainevents.Event, 0),
 	}
 }
 
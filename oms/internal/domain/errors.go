@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Domain/application error sentinels. Used by usecases and API layer.
@@ -24,8 +25,26 @@ var (
 	// ErrUnavailable is returned when an infrastructure failure occurs (db, network, timeout).
 	// The underlying cause is available via errors.Unwrap for logging.
 	ErrUnavailable = errors.New("unavailable")
+
+	// ErrRateLimited is returned when a caller exceeded its request rate; see RateLimitedError for the retry delay.
+	ErrRateLimited = errors.New("rate limited")
 )
 
+// RateLimitedError is returned when a caller exceeded its request rate.
+// It unwraps to ErrRateLimited.
+type RateLimitedError struct {
+	// RetryAfter is how long the caller should wait before trying again.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrRateLimited, e.RetryAfter)
+}
+
+func (e *RateLimitedError) Unwrap() error {
+	return ErrRateLimited
+}
+
 // WrapUnavailable wraps an infrastructure error as ErrUnavailable, preserving the cause for Unwrap.
 // Use in usecases when mapping infra failures (tx begin, commit, repo, network) to domain errors.
 func WrapUnavailable(op string, err error) error {
@@ -53,7 +72,7 @@ func MapInfraErr(op string, err error) error {
 	}
 
 	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrVersionConflict) || errors.Is(err, ErrConflict) ||
-		errors.Is(err, ErrValidation) || errors.Is(err, ErrUnavailable) {
+		errors.Is(err, ErrValidation) || errors.Is(err, ErrUnavailable) || errors.Is(err, ErrRateLimited) {
 		return err
 	}
 
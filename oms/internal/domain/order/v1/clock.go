@@ -0,0 +1,38 @@
+package v1
+
+import "time"
+
+// Clock supplies the current time to the order aggregate.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the wall-clock Clock used unless another one is injected.
+type SystemClock struct{}
+
+// Now returns the current wall-clock time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock always returns the same instant; use it where timestamps must be deterministic.
+type FixedClock struct {
+	Time time.Time
+}
+
+// Now returns the fixed instant.
+func (c FixedClock) Now() time.Time {
+	return c.Time
+}
+
+// Option configures an OrderState on construction.
+type Option func(*OrderState)
+
+// WithClock sets the clock the order uses for event timestamps and time-based validation.
+func WithClock(clock Clock) Option {
+	return func(o *OrderState) {
+		if clock != nil {
+			o.clock = clock
+		}
+	}
+}
@@ -140,6 +140,59 @@ func (OrderTransitionEvent) EnumDescriptor() ([]byte, []int) {
 	return file_domain_order_v1_common_common_proto_rawDescGZIP(), []int{1}
 }
 
+// FulfillmentType describes how an order reaches the customer
+type FulfillmentType int32
+
+const (
+	// Unspecified fulfillment type
+	FulfillmentType_FULFILLMENT_TYPE_UNSPECIFIED FulfillmentType = 0
+	// Order is delivered to the customer; requires delivery info
+	FulfillmentType_FULFILLMENT_TYPE_DELIVERY FulfillmentType = 1
+	// Customer picks the order up; delivery info is not allowed
+	FulfillmentType_FULFILLMENT_TYPE_PICKUP FulfillmentType = 2
+)
+
+// Enum value maps for FulfillmentType.
+var (
+	FulfillmentType_name = map[int32]string{
+		0: "FULFILLMENT_TYPE_UNSPECIFIED",
+		1: "FULFILLMENT_TYPE_DELIVERY",
+		2: "FULFILLMENT_TYPE_PICKUP",
+	}
+	FulfillmentType_value = map[string]int32{
+		"FULFILLMENT_TYPE_UNSPECIFIED": 0,
+		"FULFILLMENT_TYPE_DELIVERY":    1,
+		"FULFILLMENT_TYPE_PICKUP":      2,
+	}
+)
+
+func (x FulfillmentType) Enum() *FulfillmentType {
+	p := new(FulfillmentType)
+	*p = x
+	return p
+}
+
+func (x FulfillmentType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FulfillmentType) Descriptor() protoreflect.EnumDescriptor {
+	return file_domain_order_v1_common_common_proto_enumTypes[2].Descriptor()
+}
+
+func (FulfillmentType) Type() protoreflect.EnumType {
+	return &file_domain_order_v1_common_common_proto_enumTypes[2]
+}
+
+func (x FulfillmentType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FulfillmentType.Descriptor instead.
+func (FulfillmentType) EnumDescriptor() ([]byte, []int) {
+	return file_domain_order_v1_common_common_proto_rawDescGZIP(), []int{2}
+}
+
 // DeliveryPriority levels for packages
 type DeliveryPriority int32
 
@@ -177,11 +230,11 @@ func (x DeliveryPriority) String() string {
 }
 
 func (DeliveryPriority) Descriptor() protoreflect.EnumDescriptor {
-	return file_domain_order_v1_common_common_proto_enumTypes[2].Descriptor()
+	return file_domain_order_v1_common_common_proto_enumTypes[3].Descriptor()
 }
 
 func (DeliveryPriority) Type() protoreflect.EnumType {
-	return &file_domain_order_v1_common_common_proto_enumTypes[2]
+	return &file_domain_order_v1_common_common_proto_enumTypes[3]
 }
 
 func (x DeliveryPriority) Number() protoreflect.EnumNumber {
@@ -190,7 +243,7 @@ func (x DeliveryPriority) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DeliveryPriority.Descriptor instead.
 func (DeliveryPriority) EnumDescriptor() ([]byte, []int) {
-	return file_domain_order_v1_common_common_proto_rawDescGZIP(), []int{2}
+	return file_domain_order_v1_common_common_proto_rawDescGZIP(), []int{3}
 }
 
 // DeliveryStatus represents delivery status
@@ -209,6 +262,8 @@ const (
 	DeliveryStatus_DELIVERY_STATUS_DELIVERED DeliveryStatus = 4
 	// Package not delivered
 	DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED DeliveryStatus = 5
+	// Order summary only: some packages delivered, the others still on the way or not delivered
+	DeliveryStatus_DELIVERY_STATUS_PARTIALLY_DELIVERED DeliveryStatus = 6
 )
 
 // Enum value maps for DeliveryStatus.
@@ -220,14 +275,16 @@ var (
 		3: "DELIVERY_STATUS_IN_TRANSIT",
 		4: "DELIVERY_STATUS_DELIVERED",
 		5: "DELIVERY_STATUS_NOT_DELIVERED",
+		6: "DELIVERY_STATUS_PARTIALLY_DELIVERED",
 	}
 	DeliveryStatus_value = map[string]int32{
-		"DELIVERY_STATUS_UNSPECIFIED":   0,
-		"DELIVERY_STATUS_ACCEPTED":      1,
-		"DELIVERY_STATUS_ASSIGNED":      2,
-		"DELIVERY_STATUS_IN_TRANSIT":    3,
-		"DELIVERY_STATUS_DELIVERED":     4,
-		"DELIVERY_STATUS_NOT_DELIVERED": 5,
+		"DELIVERY_STATUS_UNSPECIFIED":         0,
+		"DELIVERY_STATUS_ACCEPTED":            1,
+		"DELIVERY_STATUS_ASSIGNED":            2,
+		"DELIVERY_STATUS_IN_TRANSIT":          3,
+		"DELIVERY_STATUS_DELIVERED":           4,
+		"DELIVERY_STATUS_NOT_DELIVERED":       5,
+		"DELIVERY_STATUS_PARTIALLY_DELIVERED": 6,
 	}
 )
 
@@ -242,11 +299,11 @@ func (x DeliveryStatus) String() string {
 }
 
 func (DeliveryStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_domain_order_v1_common_common_proto_enumTypes[3].Descriptor()
+	return file_domain_order_v1_common_common_proto_enumTypes[4].Descriptor()
 }
 
 func (DeliveryStatus) Type() protoreflect.EnumType {
-	return &file_domain_order_v1_common_common_proto_enumTypes[3]
+	return &file_domain_order_v1_common_common_proto_enumTypes[4]
 }
 
 func (x DeliveryStatus) Number() protoreflect.EnumNumber {
@@ -255,7 +312,7 @@ func (x DeliveryStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DeliveryStatus.Descriptor instead.
 func (DeliveryStatus) EnumDescriptor() ([]byte, []int) {
-	return file_domain_order_v1_common_common_proto_rawDescGZIP(), []int{3}
+	return file_domain_order_v1_common_common_proto_rawDescGZIP(), []int{4}
 }
 
 // NotDeliveredReason represents reason for not delivered
@@ -311,11 +368,11 @@ func (x NotDeliveredReason) String() string {
 }
 
 func (NotDeliveredReason) Descriptor() protoreflect.EnumDescriptor {
-	return file_domain_order_v1_common_common_proto_enumTypes[4].Descriptor()
+	return file_domain_order_v1_common_common_proto_enumTypes[5].Descriptor()
 }
 
 func (NotDeliveredReason) Type() protoreflect.EnumType {
-	return &file_domain_order_v1_common_common_proto_enumTypes[4]
+	return &file_domain_order_v1_common_common_proto_enumTypes[5]
 }
 
 func (x NotDeliveredReason) Number() protoreflect.EnumNumber {
@@ -324,7 +381,7 @@ func (x NotDeliveredReason) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use NotDeliveredReason.Descriptor instead.
 func (NotDeliveredReason) EnumDescriptor() ([]byte, []int) {
-	return file_domain_order_v1_common_common_proto_rawDescGZIP(), []int{4}
+	return file_domain_order_v1_common_common_proto_rawDescGZIP(), []int{5}
 }
 
 // NotDeliveredDetails contains reason and optional description (required if reason == OTHER)
@@ -388,7 +445,13 @@ type OrderItem struct {
 	// Quantity
 	Quantity int32 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	// Price per item (Decimal as string to preserve precision)
-	Price         string `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	Price string `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	// Customer note for the item; empty when none
+	Note string `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	// ISO 4217 currency of the price; empty when unknown
+	Currency string `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Tax category of the item; empty means the pricer's default
+	TaxCategory   string `protobuf:"bytes,6,opt,name=tax_category,json=taxCategory,proto3" json:"tax_category,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -444,6 +507,27 @@ func (x *OrderItem) GetPrice() string {
 	return ""
 }
 
+func (x *OrderItem) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *OrderItem) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OrderItem) GetTaxCategory() string {
+	if x != nil {
+		return x.TaxCategory
+	}
+	return ""
+}
+
 // DeliveryAddress represents a physical address with coordinates
 type DeliveryAddress struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -594,7 +678,13 @@ func (x *DeliveryPeriod) GetEndTime() *timestamppb.Timestamp {
 type PackageInfo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// weight_kg is the weight of the package in kilograms
-	WeightKg      float64 `protobuf:"fixed64,1,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`
+	WeightKg float64 `protobuf:"fixed64,1,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`
+	// length_cm is the package length in centimeters (0 = unknown)
+	LengthCm float64 `protobuf:"fixed64,2,opt,name=length_cm,json=lengthCm,proto3" json:"length_cm,omitempty"`
+	// width_cm is the package width in centimeters (0 = unknown)
+	WidthCm float64 `protobuf:"fixed64,3,opt,name=width_cm,json=widthCm,proto3" json:"width_cm,omitempty"`
+	// height_cm is the package height in centimeters (0 = unknown)
+	HeightCm      float64 `protobuf:"fixed64,4,opt,name=height_cm,json=heightCm,proto3" json:"height_cm,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -636,6 +726,27 @@ func (x *PackageInfo) GetWeightKg() float64 {
 	return 0
 }
 
+func (x *PackageInfo) GetLengthCm() float64 {
+	if x != nil {
+		return x.LengthCm
+	}
+	return 0
+}
+
+func (x *PackageInfo) GetWidthCm() float64 {
+	if x != nil {
+		return x.WidthCm
+	}
+	return 0
+}
+
+func (x *PackageInfo) GetHeightCm() float64 {
+	if x != nil {
+		return x.HeightCm
+	}
+	return 0
+}
+
 // DeliveryLocation represents a GPS location
 type DeliveryLocation struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -871,11 +982,14 @@ const file_domain_order_v1_common_common_proto_rawDesc = "" +
 	"#domain/order/v1/common/common.proto\x12\x16domain.order.common.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"{\n" +
 	"\x13NotDeliveredDetails\x12B\n" +
 	"\x06reason\x18\x01 \x01(\x0e2*.domain.order.common.v1.NotDeliveredReasonR\x06reason\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\"V\n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"\xa9\x01\n" +
 	"\tOrderItem\x12\x17\n" +
 	"\agood_id\x18\x01 \x01(\tR\x06goodId\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\tR\x05price\"\xb2\x01\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12!\n" +
+	"\ftax_category\x18\x06 \x01(\tR\vtaxCategory\"\xb2\x01\n" +
 	"\x0fDeliveryAddress\x12\x16\n" +
 	"\x06street\x18\x01 \x01(\tR\x06street\x12\x12\n" +
 	"\x04city\x18\x02 \x01(\tR\x04city\x12\x1f\n" +
@@ -887,9 +1001,12 @@ const file_domain_order_v1_common_common_proto_rawDesc = "" +
 	"\x0eDeliveryPeriod\x129\n" +
 	"\n" +
 	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
-	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"*\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"\x7f\n" +
 	"\vPackageInfo\x12\x1b\n" +
-	"\tweight_kg\x18\x01 \x01(\x01R\bweightKg\"\xa2\x01\n" +
+	"\tweight_kg\x18\x01 \x01(\x01R\bweightKg\x12\x1b\n" +
+	"\tlength_cm\x18\x02 \x01(\x01R\blengthCm\x12\x19\n" +
+	"\bwidth_cm\x18\x03 \x01(\x01R\awidthCm\x12\x1b\n" +
+	"\theight_cm\x18\x04 \x01(\x01R\bheightCm\"\xa2\x01\n" +
 	"\x10DeliveryLocation\x12\x1a\n" +
 	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
 	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x1a\n" +
@@ -916,18 +1033,23 @@ const file_domain_order_v1_common_common_proto_rawDesc = "" +
 	"\"ORDER_TRANSITION_EVENT_UNSPECIFIED\x10\x00\x12!\n" +
 	"\x1dORDER_TRANSITION_EVENT_CREATE\x10\x01\x12!\n" +
 	"\x1dORDER_TRANSITION_EVENT_CANCEL\x10\x02\x12#\n" +
-	"\x1fORDER_TRANSITION_EVENT_COMPLETE\x10\x03*q\n" +
+	"\x1fORDER_TRANSITION_EVENT_COMPLETE\x10\x03*o\n" +
+	"\x0fFulfillmentType\x12 \n" +
+	"\x1cFULFILLMENT_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19FULFILLMENT_TYPE_DELIVERY\x10\x01\x12\x1b\n" +
+	"\x17FULFILLMENT_TYPE_PICKUP\x10\x02*q\n" +
 	"\x10DeliveryPriority\x12!\n" +
 	"\x1dDELIVERY_PRIORITY_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18DELIVERY_PRIORITY_NORMAL\x10\x01\x12\x1c\n" +
-	"\x18DELIVERY_PRIORITY_URGENT\x10\x02*\xcf\x01\n" +
+	"\x18DELIVERY_PRIORITY_URGENT\x10\x02*\xf8\x01\n" +
 	"\x0eDeliveryStatus\x12\x1f\n" +
 	"\x1bDELIVERY_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18DELIVERY_STATUS_ACCEPTED\x10\x01\x12\x1c\n" +
 	"\x18DELIVERY_STATUS_ASSIGNED\x10\x02\x12\x1e\n" +
 	"\x1aDELIVERY_STATUS_IN_TRANSIT\x10\x03\x12\x1d\n" +
 	"\x19DELIVERY_STATUS_DELIVERED\x10\x04\x12!\n" +
-	"\x1dDELIVERY_STATUS_NOT_DELIVERED\x10\x05*\xb0\x02\n" +
+	"\x1dDELIVERY_STATUS_NOT_DELIVERED\x10\x05\x12'\n" +
+	"#DELIVERY_STATUS_PARTIALLY_DELIVERED\x10\x06*\xb0\x02\n" +
 	"\x12NotDeliveredReason\x12$\n" +
 	" NOT_DELIVERED_REASON_UNSPECIFIED\x10\x00\x12/\n" +
 	"+NOT_DELIVERED_REASON_CUSTOMER_NOT_AVAILABLE\x10\x01\x12&\n" +
@@ -950,35 +1072,36 @@ func file_domain_order_v1_common_common_proto_rawDescGZIP() []byte {
 	return file_domain_order_v1_common_common_proto_rawDescData
 }
 
-var file_domain_order_v1_common_common_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_domain_order_v1_common_common_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
 var file_domain_order_v1_common_common_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_domain_order_v1_common_common_proto_goTypes = []any{
 	(OrderStatus)(0),              // 0: domain.order.common.v1.OrderStatus
 	(OrderTransitionEvent)(0),     // 1: domain.order.common.v1.OrderTransitionEvent
-	(DeliveryPriority)(0),         // 2: domain.order.common.v1.DeliveryPriority
-	(DeliveryStatus)(0),           // 3: domain.order.common.v1.DeliveryStatus
-	(NotDeliveredReason)(0),       // 4: domain.order.common.v1.NotDeliveredReason
-	(*NotDeliveredDetails)(nil),   // 5: domain.order.common.v1.NotDeliveredDetails
-	(*OrderItem)(nil),             // 6: domain.order.common.v1.OrderItem
-	(*DeliveryAddress)(nil),       // 7: domain.order.common.v1.DeliveryAddress
-	(*DeliveryPeriod)(nil),        // 8: domain.order.common.v1.DeliveryPeriod
-	(*PackageInfo)(nil),           // 9: domain.order.common.v1.PackageInfo
-	(*DeliveryLocation)(nil),      // 10: domain.order.common.v1.DeliveryLocation
-	(*RecipientContacts)(nil),     // 11: domain.order.common.v1.RecipientContacts
-	(*DeliveryInfo)(nil),          // 12: domain.order.common.v1.DeliveryInfo
-	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+	(FulfillmentType)(0),          // 2: domain.order.common.v1.FulfillmentType
+	(DeliveryPriority)(0),         // 3: domain.order.common.v1.DeliveryPriority
+	(DeliveryStatus)(0),           // 4: domain.order.common.v1.DeliveryStatus
+	(NotDeliveredReason)(0),       // 5: domain.order.common.v1.NotDeliveredReason
+	(*NotDeliveredDetails)(nil),   // 6: domain.order.common.v1.NotDeliveredDetails
+	(*OrderItem)(nil),             // 7: domain.order.common.v1.OrderItem
+	(*DeliveryAddress)(nil),       // 8: domain.order.common.v1.DeliveryAddress
+	(*DeliveryPeriod)(nil),        // 9: domain.order.common.v1.DeliveryPeriod
+	(*PackageInfo)(nil),           // 10: domain.order.common.v1.PackageInfo
+	(*DeliveryLocation)(nil),      // 11: domain.order.common.v1.DeliveryLocation
+	(*RecipientContacts)(nil),     // 12: domain.order.common.v1.RecipientContacts
+	(*DeliveryInfo)(nil),          // 13: domain.order.common.v1.DeliveryInfo
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
 }
 var file_domain_order_v1_common_common_proto_depIdxs = []int32{
-	4,  // 0: domain.order.common.v1.NotDeliveredDetails.reason:type_name -> domain.order.common.v1.NotDeliveredReason
-	13, // 1: domain.order.common.v1.DeliveryPeriod.start_time:type_name -> google.protobuf.Timestamp
-	13, // 2: domain.order.common.v1.DeliveryPeriod.end_time:type_name -> google.protobuf.Timestamp
-	13, // 3: domain.order.common.v1.DeliveryLocation.timestamp:type_name -> google.protobuf.Timestamp
-	7,  // 4: domain.order.common.v1.DeliveryInfo.pickup_address:type_name -> domain.order.common.v1.DeliveryAddress
-	7,  // 5: domain.order.common.v1.DeliveryInfo.delivery_address:type_name -> domain.order.common.v1.DeliveryAddress
-	8,  // 6: domain.order.common.v1.DeliveryInfo.delivery_period:type_name -> domain.order.common.v1.DeliveryPeriod
-	9,  // 7: domain.order.common.v1.DeliveryInfo.package_info:type_name -> domain.order.common.v1.PackageInfo
-	2,  // 8: domain.order.common.v1.DeliveryInfo.priority:type_name -> domain.order.common.v1.DeliveryPriority
-	11, // 9: domain.order.common.v1.DeliveryInfo.recipient_contacts:type_name -> domain.order.common.v1.RecipientContacts
+	5,  // 0: domain.order.common.v1.NotDeliveredDetails.reason:type_name -> domain.order.common.v1.NotDeliveredReason
+	14, // 1: domain.order.common.v1.DeliveryPeriod.start_time:type_name -> google.protobuf.Timestamp
+	14, // 2: domain.order.common.v1.DeliveryPeriod.end_time:type_name -> google.protobuf.Timestamp
+	14, // 3: domain.order.common.v1.DeliveryLocation.timestamp:type_name -> google.protobuf.Timestamp
+	8,  // 4: domain.order.common.v1.DeliveryInfo.pickup_address:type_name -> domain.order.common.v1.DeliveryAddress
+	8,  // 5: domain.order.common.v1.DeliveryInfo.delivery_address:type_name -> domain.order.common.v1.DeliveryAddress
+	9,  // 6: domain.order.common.v1.DeliveryInfo.delivery_period:type_name -> domain.order.common.v1.DeliveryPeriod
+	10, // 7: domain.order.common.v1.DeliveryInfo.package_info:type_name -> domain.order.common.v1.PackageInfo
+	3,  // 8: domain.order.common.v1.DeliveryInfo.priority:type_name -> domain.order.common.v1.DeliveryPriority
+	12, // 9: domain.order.common.v1.DeliveryInfo.recipient_contacts:type_name -> domain.order.common.v1.RecipientContacts
 	10, // [10:10] is the sub-list for method output_type
 	10, // [10:10] is the sub-list for method input_type
 	10, // [10:10] is the sub-list for extension type_name
@@ -996,7 +1119,7 @@ func file_domain_order_v1_common_common_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_domain_order_v1_common_common_proto_rawDesc), len(file_domain_order_v1_common_common_proto_rawDesc)),
-			NumEnums:      5,
+			NumEnums:      6,
 			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
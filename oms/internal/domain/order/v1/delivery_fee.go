@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// deliveryFeePrecision is the number of decimal places fees are rounded to.
+const deliveryFeePrecision = 2
+
+// DeliveryFeePolicy holds the tariff used to price a delivery.
+type DeliveryFeePolicy struct {
+	// BaseFee is charged for every priced delivery
+	BaseFee decimal.Decimal
+	// PerKm is charged per kilometer of pickup→delivery distance
+	PerKm decimal.Decimal
+	// PerKg is charged per kilogram of chargeable package weight
+	PerKg decimal.Decimal
+	// FlatFee is charged instead of the distance tariff when either address has no coordinates
+	FlatFee decimal.Decimal
+	// FreeShippingThreshold makes delivery free for orders totalling at least this amount (zero disables)
+	FreeShippingThreshold decimal.Decimal
+}
+
+// DeliveryFeeCalculator prices deliveries from distance and package weight.
+type DeliveryFeeCalculator struct {
+	policy DeliveryFeePolicy
+}
+
+// NewDeliveryFeeCalculator creates a calculator for the given tariff.
+func NewDeliveryFeeCalculator(policy DeliveryFeePolicy) *DeliveryFeeCalculator {
+	return &DeliveryFeeCalculator{policy: policy}
+}
+
+// Calculate returns the delivery fee for info on an order totalling orderTotal.
+// The fee is BaseFee + PerKm × distance + PerKg × chargeable weight, or FlatFee when the
+// distance is unknown; it is zero once orderTotal reaches FreeShippingThreshold.
+func (c *DeliveryFeeCalculator) Calculate(info DeliveryInfo, orderTotal decimal.Decimal) decimal.Decimal {
+	threshold := c.policy.FreeShippingThreshold
+	if threshold.IsPositive() && orderTotal.GreaterThanOrEqual(threshold) {
+		return decimal.Zero
+	}
+
+	pickup := info.GetPickupAddress()
+	delivery := info.GetDeliveryAddress()
+
+	if !pickup.HasCoordinates() || !delivery.HasCoordinates() {
+		return c.policy.FlatFee.Round(deliveryFeePrecision)
+	}
+
+	distanceKm := decimal.NewFromFloat(pickup.Location().DistanceTo(delivery.Location()))
+	weightKg := decimal.NewFromFloat(info.GetPackageInfo().ChargeableWeightKg())
+
+	return c.policy.BaseFee.
+		Add(c.policy.PerKm.Mul(distanceKm)).
+		Add(c.policy.PerKg.Mul(weightKg)).
+		Round(deliveryFeePrecision)
+}
@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/location"
+)
+
+var testDeliveryFeePolicy = DeliveryFeePolicy{
+	BaseFee:               decimal.NewFromInt(100),
+	PerKm:                 decimal.NewFromInt(10),
+	PerKg:                 decimal.NewFromInt(5),
+	FlatFee:               decimal.NewFromInt(300),
+	FreeShippingThreshold: decimal.NewFromInt(5000),
+}
+
+func newFeeDeliveryInfo(t *testing.T, pickup, delivery location.Location, weightKg float64) DeliveryInfo {
+	t.Helper()
+
+	pickupAddr, err := address.NewAddressWithLocation("1 Warehouse St", "Moscow", "101000", "Russia", pickup)
+	require.NoError(t, err)
+
+	deliveryAddr, err := address.NewAddressWithLocation("2 Customer St", "Moscow", "102000", "Russia", delivery)
+	require.NoError(t, err)
+
+	start := time.Now().Add(time.Hour)
+
+	return NewDeliveryInfo(
+		pickupAddr,
+		deliveryAddr,
+		NewDeliveryPeriod(start, start.Add(2*time.Hour)),
+		NewPackageInfo(weightKg),
+		DeliveryPriorityNormal,
+		nil,
+	)
+}
+
+func TestDeliveryFeeCalculator_ScalesWithDistance(t *testing.T) {
+	t.Parallel()
+
+	calculator := NewDeliveryFeeCalculator(testDeliveryFeePolicy)
+	warehouse := location.MustNewLocation(55.7558, 37.6173)
+	nearby := location.MustNewLocation(55.7648, 37.6173)      // ~1 km north
+	anotherCity := location.MustNewLocation(59.9343, 30.3351) // ~634 km away
+	orderTotal := decimal.NewFromInt(1000)
+
+	short := calculator.Calculate(newFeeDeliveryInfo(t, warehouse, nearby, 2), orderTotal)
+	long := calculator.Calculate(newFeeDeliveryInfo(t, warehouse, anotherCity, 2), orderTotal)
+
+	// 100 base + 10/km × ~1 km + 5/kg × 2 kg
+	require.InDelta(t, 120, short.InexactFloat64(), 0.5)
+	// 100 base + 10/km × ~634 km + 5/kg × 2 kg
+	require.InDelta(t, 6450, long.InexactFloat64(), 20)
+	require.True(t, long.GreaterThan(short))
+	require.Equal(t, int32(-2), long.Exponent(), "fee is rounded to cents")
+}
+
+func TestDeliveryFeeCalculator_ChargesPerChargeableKg(t *testing.T) {
+	t.Parallel()
+
+	calculator := NewDeliveryFeeCalculator(testDeliveryFeePolicy)
+	warehouse := location.MustNewLocation(55.7558, 37.6173)
+	nearby := location.MustNewLocation(55.7648, 37.6173)
+	orderTotal := decimal.NewFromInt(1000)
+
+	light := calculator.Calculate(newFeeDeliveryInfo(t, warehouse, nearby, 1), orderTotal)
+	heavy := calculator.Calculate(newFeeDeliveryInfo(t, warehouse, nearby, 11), orderTotal)
+
+	require.True(t, heavy.Sub(light).Equal(decimal.NewFromInt(50)), "10 extra kg at 5/kg, got %s", heavy.Sub(light))
+}
+
+func TestDeliveryFeeCalculator_FreeShippingThreshold(t *testing.T) {
+	t.Parallel()
+
+	calculator := NewDeliveryFeeCalculator(testDeliveryFeePolicy)
+	info := newFeeDeliveryInfo(t,
+		location.MustNewLocation(55.7558, 37.6173),
+		location.MustNewLocation(55.7648, 37.6173),
+		2,
+	)
+
+	require.True(t, calculator.Calculate(info, decimal.RequireFromString("4999.99")).IsPositive(),
+		"just below the threshold is charged")
+	require.True(t, calculator.Calculate(info, decimal.NewFromInt(5000)).IsZero(),
+		"reaching the threshold is free")
+	require.True(t, calculator.Calculate(info, decimal.NewFromInt(7000)).IsZero())
+
+	noThreshold := testDeliveryFeePolicy
+	noThreshold.FreeShippingThreshold = decimal.Zero
+
+	require.True(t, NewDeliveryFeeCalculator(noThreshold).Calculate(info, decimal.NewFromInt(1_000_000)).IsPositive(),
+		"a zero threshold disables free shipping")
+}
+
+func TestDeliveryFeeCalculator_FlatFeeWithoutCoordinates(t *testing.T) {
+	t.Parallel()
+
+	calculator := NewDeliveryFeeCalculator(testDeliveryFeePolicy)
+	orderTotal := decimal.NewFromInt(1000)
+
+	withoutDelivery := newFeeDeliveryInfo(t, location.MustNewLocation(55.7558, 37.6173), location.Location{}, 2)
+	withoutPickup := newFeeDeliveryInfo(t, location.Location{}, location.MustNewLocation(55.7648, 37.6173), 2)
+
+	require.True(t, calculator.Calculate(withoutDelivery, orderTotal).Equal(decimal.NewFromInt(300)))
+	require.True(t, calculator.Calculate(withoutPickup, orderTotal).Equal(decimal.NewFromInt(300)))
+	require.True(t, calculator.Calculate(withoutDelivery, decimal.NewFromInt(5000)).IsZero(),
+		"free shipping also covers flat-fee deliveries")
+}
@@ -1,7 +1,10 @@
 package v1
 
 import (
+	"time"
+
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
 )
@@ -44,6 +47,8 @@ type DeliveryInfo struct {
 	priority DeliveryPriority
 	// recipientContacts is optional contact details for the recipient
 	recipientContacts *RecipientContacts
+	// deliveryFee is the shipping charge calculated at checkout (zero = free or not priced)
+	deliveryFee decimal.Decimal
 }
 
 // DeliveryPriority represents delivery priority level.
@@ -129,6 +134,16 @@ func (d *DeliveryInfo) SetPackageId(packageId uuid.UUID) {
 	d.packageId = &packageId
 }
 
+// GetDeliveryFee returns the shipping charge calculated at checkout.
+func (d DeliveryInfo) GetDeliveryFee() decimal.Decimal {
+	return d.deliveryFee
+}
+
+// SetDeliveryFee sets the shipping charge (called by checkout once the fee is calculated).
+func (d *DeliveryInfo) SetDeliveryFee(fee decimal.Decimal) {
+	d.deliveryFee = fee
+}
+
 // GetPriority returns the delivery priority.
 func (d DeliveryInfo) GetPriority() DeliveryPriority {
 	return d.priority
@@ -141,8 +156,13 @@ func (d DeliveryInfo) GetRecipientContacts() *RecipientContacts {
 
 // IsValid checks if the delivery info is valid.
 func (d DeliveryInfo) IsValid() bool {
+	return d.IsValidAt(time.Now())
+}
+
+// IsValidAt checks if the delivery info is valid at the given time.
+func (d DeliveryInfo) IsValidAt(now time.Time) bool {
 	return d.pickupAddress.IsValid() &&
 		d.deliveryAddress.IsValid() &&
-		d.deliveryPeriod.IsValid() &&
+		d.deliveryPeriod.IsValidAt(now) &&
 		d.packageInfo.IsValid()
 }
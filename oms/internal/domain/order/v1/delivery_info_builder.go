@@ -0,0 +1,137 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+)
+
+// Delivery info field errors, joined by DeliveryInfoBuilder.Build.
+var (
+	ErrInvalidPickupAddress    = errors.New("invalid pickup address")
+	ErrInvalidDeliveryAddress  = errors.New("invalid delivery address")
+	ErrInvalidDeliveryPeriod   = errors.New("invalid delivery period: start must be before end")
+	ErrInvalidPackageInfo      = errors.New("invalid package info: weight must be positive")
+	ErrInvalidDeliveryPriority = errors.New("invalid delivery priority")
+)
+
+// DeliveryInfoBuilder is used to build a DeliveryInfo, collecting every field error instead of stopping at the first
+type DeliveryInfoBuilder struct {
+	info       DeliveryInfo
+	hasPickup  bool
+	hasTarget  bool
+	hasPeriod  bool
+	hasPackage bool
+	errors     error
+}
+
+// NewDeliveryInfoBuilder returns a new instance of DeliveryInfoBuilder with normal priority
+func NewDeliveryInfoBuilder() *DeliveryInfoBuilder {
+	return &DeliveryInfoBuilder{info: DeliveryInfo{priority: DeliveryPriorityNormal}}
+}
+
+// WithPickup sets the address the package is picked up from
+func (b *DeliveryInfoBuilder) WithPickup(addr address.Address) *DeliveryInfoBuilder {
+	b.hasPickup = true
+
+	if !addr.IsValid() {
+		b.errors = errors.Join(b.errors, ErrInvalidPickupAddress)
+		return b
+	}
+
+	b.info.pickupAddress = addr
+
+	return b
+}
+
+// WithDelivery sets the address the package is delivered to
+func (b *DeliveryInfoBuilder) WithDelivery(addr address.Address) *DeliveryInfoBuilder {
+	b.hasTarget = true
+
+	if !addr.IsValid() {
+		b.errors = errors.Join(b.errors, ErrInvalidDeliveryAddress)
+		return b
+	}
+
+	b.info.deliveryAddress = addr
+
+	return b
+}
+
+// WithPeriod sets the desired delivery window.
+// Whether the window is still in the future is checked against the order clock by OrderState.SetDeliveryInfo.
+func (b *DeliveryInfoBuilder) WithPeriod(startTime, endTime time.Time) *DeliveryInfoBuilder {
+	b.hasPeriod = true
+
+	if !startTime.Before(endTime) {
+		b.errors = errors.Join(b.errors, ErrInvalidDeliveryPeriod)
+		return b
+	}
+
+	b.info.deliveryPeriod = NewDeliveryPeriod(startTime, endTime)
+
+	return b
+}
+
+// WithPackage sets the physical characteristics of the package
+func (b *DeliveryInfoBuilder) WithPackage(info PackageInfo) *DeliveryInfoBuilder {
+	b.hasPackage = true
+
+	if !info.IsValid() {
+		b.errors = errors.Join(b.errors, ErrInvalidPackageInfo)
+		return b
+	}
+
+	b.info.packageInfo = info
+
+	return b
+}
+
+// WithPriority sets the delivery priority (defaults to normal)
+func (b *DeliveryInfoBuilder) WithPriority(priority DeliveryPriority) *DeliveryInfoBuilder {
+	switch priority {
+	case DeliveryPriorityUnspecified, DeliveryPriorityNormal, DeliveryPriorityUrgent:
+		b.info.priority = priority
+	default:
+		b.errors = errors.Join(b.errors, fmt.Errorf("%w: %d", ErrInvalidDeliveryPriority, priority))
+	}
+
+	return b
+}
+
+// WithRecipientContacts sets the optional recipient contact details
+func (b *DeliveryInfoBuilder) WithRecipientContacts(contacts *RecipientContacts) *DeliveryInfoBuilder {
+	b.info.recipientContacts = contacts
+
+	return b
+}
+
+// Build finalizes the building process and returns the built DeliveryInfo.
+// Returns ErrInvalidDeliveryInfo joined with every invalid or missing field.
+func (b *DeliveryInfoBuilder) Build() (DeliveryInfo, error) {
+	err := b.errors
+
+	if !b.hasPickup {
+		err = errors.Join(err, fmt.Errorf("%w: required", ErrInvalidPickupAddress))
+	}
+
+	if !b.hasTarget {
+		err = errors.Join(err, fmt.Errorf("%w: required", ErrInvalidDeliveryAddress))
+	}
+
+	if !b.hasPeriod {
+		err = errors.Join(err, fmt.Errorf("%w: required", ErrInvalidDeliveryPeriod))
+	}
+
+	if !b.hasPackage {
+		err = errors.Join(err, fmt.Errorf("%w: required", ErrInvalidPackageInfo))
+	}
+
+	if err != nil {
+		return DeliveryInfo{}, fmt.Errorf("%w: %w", ErrInvalidDeliveryInfo, err)
+	}
+
+	return b.info, nil
+}
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+)
+
+func TestDeliveryInfoBuilder_Build(t *testing.T) {
+	pickupAddr, err := address.NewAddress("123 Warehouse St", "Moscow", "101000", "Russia")
+	require.NoError(t, err)
+
+	deliveryAddr, err := address.NewAddress("456 Customer St", "Moscow", "102000", "Russia")
+	require.NoError(t, err)
+
+	start := time.Now().Add(24 * time.Hour)
+	end := start.Add(2 * time.Hour)
+	contacts := NewRecipientContacts("Jane Doe", "+79001234567", "")
+
+	info, err := NewDeliveryInfoBuilder().
+		WithPickup(pickupAddr).
+		WithDelivery(deliveryAddr).
+		WithPeriod(start, end).
+		WithPackage(NewPackageInfo(2.5)).
+		WithPriority(DeliveryPriorityUrgent).
+		WithRecipientContacts(&contacts).
+		Build()
+	require.NoError(t, err)
+
+	assert.True(t, info.IsValid())
+	assert.Equal(t, pickupAddr, info.GetPickupAddress())
+	assert.Equal(t, deliveryAddr, info.GetDeliveryAddress())
+	assert.True(t, info.GetDeliveryPeriod().GetStartTime().Equal(start))
+	assert.True(t, info.GetDeliveryPeriod().GetEndTime().Equal(end))
+	assert.InDelta(t, 2.5, info.GetPackageInfo().GetWeightKg(), 1e-9)
+	assert.Equal(t, DeliveryPriorityUrgent, info.GetPriority())
+	assert.Equal(t, "Jane Doe", info.GetRecipientContacts().GetName())
+	assert.Nil(t, info.GetPackageId())
+
+	order := NewOrderState(uuid.New())
+	require.NoError(t, order.SetDeliveryInfo(info))
+}
+
+func TestDeliveryInfoBuilder_AccumulatesFieldErrors(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour)
+
+	// The delivery address is never set; every other field is invalid.
+	_, err := NewDeliveryInfoBuilder().
+		WithPickup(address.Address{}).
+		WithPeriod(start, start.Add(-time.Hour)).
+		WithPackage(NewPackageInfo(0)).
+		WithPriority(DeliveryPriority(42)).
+		Build()
+	require.Error(t, err)
+
+	require.ErrorIs(t, err, ErrInvalidDeliveryInfo)
+	require.ErrorIs(t, err, ErrInvalidPickupAddress)
+	require.ErrorIs(t, err, ErrInvalidDeliveryAddress)
+	require.ErrorIs(t, err, ErrInvalidDeliveryPeriod)
+	require.ErrorIs(t, err, ErrInvalidPackageInfo)
+	require.ErrorIs(t, err, ErrInvalidDeliveryPriority)
+}
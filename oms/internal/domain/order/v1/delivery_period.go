@@ -30,7 +30,11 @@ func (d DeliveryPeriod) GetEndTime() time.Time {
 
 // IsValid checks if the delivery period is valid (start < end and both are in the future).
 func (d DeliveryPeriod) IsValid() bool {
-	now := time.Now()
+	return d.IsValidAt(time.Now())
+}
+
+// IsValidAt checks if the delivery period is valid relative to now.
+func (d DeliveryPeriod) IsValidAt(now time.Time) bool {
 	return d.startTime.Before(d.endTime) && d.startTime.After(now)
 }
 
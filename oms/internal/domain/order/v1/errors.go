@@ -10,8 +10,12 @@ import (
 
 // Sentinel domain errors for order aggregate. Handlers can use errors.Is/As to map to gRPC/HTTP codes.
 var (
-	ErrInvalidDeliveryInfo  = errors.New("invalid delivery info: address, delivery period and package info are required")
-	ErrDeliveryInfoRequired = errors.New("delivery info is required")
+	ErrInvalidDeliveryInfo              = errors.New("invalid delivery info: address, delivery period and package info are required")
+	ErrDeliveryInfoRequired             = errors.New("delivery info is required")
+	ErrDeliveryCorrectionReasonRequired = errors.New("delivery status correction requires a reason")
+	ErrFulfillmentTypeRequired          = errors.New("fulfillment type is required")
+	ErrDeliveryInfoNotAllowedForPickup  = errors.New("delivery info is not allowed for self-pickup orders")
+	ErrPackageIDRequired                = errors.New("package id is required")
 )
 
 // OrderTerminalStateError is returned when an operation is not allowed because the order is in a terminal state (COMPLETED or CANCELED).
@@ -23,6 +27,21 @@ func (e *OrderTerminalStateError) Error() string {
 	return fmt.Sprintf("order in terminal state: %s", orderStatusString(e.Status))
 }
 
+// OrderLineItemsExceededError is returned when an order has more distinct items than its line-item limit.
+// It matches ErrOrderTotalItemsExceeded with errors.Is.
+type OrderLineItemsExceededError struct {
+	Count int
+	Max   int
+}
+
+func (e *OrderLineItemsExceededError) Error() string {
+	return fmt.Sprintf("%s: %d items, maximum is %d", ErrOrderTotalItemsExceeded, e.Count, e.Max)
+}
+
+func (e *OrderLineItemsExceededError) Unwrap() error {
+	return ErrOrderTotalItemsExceeded
+}
+
 // DeliveryAlreadyInProgressError is returned when delivery info cannot be updated because the package is already assigned or in transit.
 type DeliveryAlreadyInProgressError struct {
 	DeliveryStatus commonv1.DeliveryStatus
@@ -17,8 +17,16 @@ func (*OrderDeliveryStatusUpdatedEvent) EventType() string {
 	return "oms.order.delivery_status_updated.v1"
 }
 
+// EventType returns the canonical event type for subscription/routing.
+func (*OrderDeliveryStatusCorrectedEvent) EventType() string {
+	return "oms.order.delivery_status_corrected.v1"
+}
+
 // EventType returns the canonical event type for subscription/routing.
 func (*OrderDeliveryCompletedEvent) EventType() string { return "oms.order.delivery_completed.v1" }
 
 // EventType returns the canonical event type for subscription/routing.
 func (*OrderDeliveryFailedEvent) EventType() string { return "oms.order.delivery_failed.v1" }
+
+// EventType returns the canonical event type for subscription/routing.
+func (*OrderItemsUpdated) EventType() string { return "oms.order.items_updated.v1" }
@@ -558,6 +558,117 @@ func (x *OrderDeliveryStatusUpdatedEvent) GetAggregateVersion() int32 {
 	return 0
 }
 
+// OrderDeliveryStatusCorrectedEvent - canonical name: oms.order.delivery_status_corrected.v1
+// Event when delivery status is moved backwards by an explicit correction
+// (e.g. courier assignment revoked: ASSIGNED -> ACCEPTED)
+type OrderDeliveryStatusCorrectedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Order ID
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Package ID assigned by delivery service
+	PackageId string `protobuf:"bytes,2,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
+	// Delivery status before the correction
+	PreviousStatus common.DeliveryStatus `protobuf:"varint,3,opt,name=previous_status,json=previousStatus,proto3,enum=domain.order.common.v1.DeliveryStatus" json:"previous_status,omitempty"`
+	// Delivery status after the correction
+	Status common.DeliveryStatus `protobuf:"varint,4,opt,name=status,proto3,enum=domain.order.common.v1.DeliveryStatus" json:"status,omitempty"`
+	// Why the status was corrected
+	Reason string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Timestamp when status was corrected
+	CorrectedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=corrected_at,json=correctedAt,proto3" json:"corrected_at,omitempty"`
+	// OccurredAt is the timestamp when the event occurred
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Aggregate version after the mutation was applied
+	AggregateVersion int32 `protobuf:"varint,8,opt,name=aggregate_version,json=aggregateVersion,proto3" json:"aggregate_version,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *OrderDeliveryStatusCorrectedEvent) Reset() {
+	*x = OrderDeliveryStatusCorrectedEvent{}
+	mi := &file_domain_order_v1_events_v1_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderDeliveryStatusCorrectedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderDeliveryStatusCorrectedEvent) ProtoMessage() {}
+
+func (x *OrderDeliveryStatusCorrectedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_order_v1_events_v1_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderDeliveryStatusCorrectedEvent.ProtoReflect.Descriptor instead.
+func (*OrderDeliveryStatusCorrectedEvent) Descriptor() ([]byte, []int) {
+	return file_domain_order_v1_events_v1_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OrderDeliveryStatusCorrectedEvent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderDeliveryStatusCorrectedEvent) GetPackageId() string {
+	if x != nil {
+		return x.PackageId
+	}
+	return ""
+}
+
+func (x *OrderDeliveryStatusCorrectedEvent) GetPreviousStatus() common.DeliveryStatus {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return common.DeliveryStatus(0)
+}
+
+func (x *OrderDeliveryStatusCorrectedEvent) GetStatus() common.DeliveryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return common.DeliveryStatus(0)
+}
+
+func (x *OrderDeliveryStatusCorrectedEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderDeliveryStatusCorrectedEvent) GetCorrectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CorrectedAt
+	}
+	return nil
+}
+
+func (x *OrderDeliveryStatusCorrectedEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *OrderDeliveryStatusCorrectedEvent) GetAggregateVersion() int32 {
+	if x != nil {
+		return x.AggregateVersion
+	}
+	return 0
+}
+
 // OrderDeliveryCompletedEvent - canonical name: oms.order.delivery_completed.v1
 // Event when delivery is completed
 // This event is received from delivery service
@@ -583,7 +694,7 @@ type OrderDeliveryCompletedEvent struct {
 
 func (x *OrderDeliveryCompletedEvent) Reset() {
 	*x = OrderDeliveryCompletedEvent{}
-	mi := &file_domain_order_v1_events_v1_events_proto_msgTypes[5]
+	mi := &file_domain_order_v1_events_v1_events_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -595,7 +706,7 @@ func (x *OrderDeliveryCompletedEvent) String() string {
 func (*OrderDeliveryCompletedEvent) ProtoMessage() {}
 
 func (x *OrderDeliveryCompletedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_order_v1_events_v1_events_proto_msgTypes[5]
+	mi := &file_domain_order_v1_events_v1_events_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -608,7 +719,7 @@ func (x *OrderDeliveryCompletedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderDeliveryCompletedEvent.ProtoReflect.Descriptor instead.
 func (*OrderDeliveryCompletedEvent) Descriptor() ([]byte, []int) {
-	return file_domain_order_v1_events_v1_events_proto_rawDescGZIP(), []int{5}
+	return file_domain_order_v1_events_v1_events_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *OrderDeliveryCompletedEvent) GetOrderId() string {
@@ -685,7 +796,7 @@ type OrderDeliveryFailedEvent struct {
 
 func (x *OrderDeliveryFailedEvent) Reset() {
 	*x = OrderDeliveryFailedEvent{}
-	mi := &file_domain_order_v1_events_v1_events_proto_msgTypes[6]
+	mi := &file_domain_order_v1_events_v1_events_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -697,7 +808,7 @@ func (x *OrderDeliveryFailedEvent) String() string {
 func (*OrderDeliveryFailedEvent) ProtoMessage() {}
 
 func (x *OrderDeliveryFailedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_order_v1_events_v1_events_proto_msgTypes[6]
+	mi := &file_domain_order_v1_events_v1_events_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -710,7 +821,7 @@ func (x *OrderDeliveryFailedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderDeliveryFailedEvent.ProtoReflect.Descriptor instead.
 func (*OrderDeliveryFailedEvent) Descriptor() ([]byte, []int) {
-	return file_domain_order_v1_events_v1_events_proto_rawDescGZIP(), []int{6}
+	return file_domain_order_v1_events_v1_events_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *OrderDeliveryFailedEvent) GetOrderId() string {
@@ -762,6 +873,125 @@ func (x *OrderDeliveryFailedEvent) GetAggregateVersion() int32 {
 	return 0
 }
 
+// OrderItemsUpdated event - canonical name: oms.order.items_updated.v1
+// Published when UpdateOrder adds items or changes their quantity or price
+type OrderItemsUpdated struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Order ID
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Customer ID
+	CustomerId string `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	// Items before the update
+	PreviousItems []*common.OrderItem `protobuf:"bytes,3,rep,name=previous_items,json=previousItems,proto3" json:"previous_items,omitempty"`
+	// Items after the update
+	Items []*common.OrderItem `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	// OccurredAt is the timestamp when the event occurred
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Aggregate version after the mutation was applied
+	AggregateVersion int32 `protobuf:"varint,6,opt,name=aggregate_version,json=aggregateVersion,proto3" json:"aggregate_version,omitempty"`
+	// Items whose good was not in the order before the update
+	AddedItems []*common.OrderItem `protobuf:"bytes,7,rep,name=added_items,json=addedItems,proto3" json:"added_items,omitempty"`
+	// Items whose good is no longer in the order, as they were before the update
+	RemovedItems []*common.OrderItem `protobuf:"bytes,8,rep,name=removed_items,json=removedItems,proto3" json:"removed_items,omitempty"`
+	// Items whose quantity, price or other attributes changed, as they are after the update
+	ChangedItems  []*common.OrderItem `protobuf:"bytes,9,rep,name=changed_items,json=changedItems,proto3" json:"changed_items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItemsUpdated) Reset() {
+	*x = OrderItemsUpdated{}
+	mi := &file_domain_order_v1_events_v1_events_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItemsUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItemsUpdated) ProtoMessage() {}
+
+func (x *OrderItemsUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_order_v1_events_v1_events_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItemsUpdated.ProtoReflect.Descriptor instead.
+func (*OrderItemsUpdated) Descriptor() ([]byte, []int) {
+	return file_domain_order_v1_events_v1_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OrderItemsUpdated) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderItemsUpdated) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *OrderItemsUpdated) GetPreviousItems() []*common.OrderItem {
+	if x != nil {
+		return x.PreviousItems
+	}
+	return nil
+}
+
+func (x *OrderItemsUpdated) GetItems() []*common.OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OrderItemsUpdated) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *OrderItemsUpdated) GetAggregateVersion() int32 {
+	if x != nil {
+		return x.AggregateVersion
+	}
+	return 0
+}
+
+func (x *OrderItemsUpdated) GetAddedItems() []*common.OrderItem {
+	if x != nil {
+		return x.AddedItems
+	}
+	return nil
+}
+
+func (x *OrderItemsUpdated) GetRemovedItems() []*common.OrderItem {
+	if x != nil {
+		return x.RemovedItems
+	}
+	return nil
+}
+
+func (x *OrderItemsUpdated) GetChangedItems() []*common.OrderItem {
+	if x != nil {
+		return x.ChangedItems
+	}
+	return nil
+}
+
 var File_domain_order_v1_events_v1_events_proto protoreflect.FileDescriptor
 
 const file_domain_order_v1_events_v1_events_proto_rawDesc = "" +
@@ -825,7 +1055,18 @@ const file_domain_order_v1_events_v1_events_proto_rawDesc = "" +
 	"courier_id\x18\x05 \x01(\tR\tcourierId\x12;\n" +
 	"\voccurred_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"occurredAt\x12+\n" +
-	"\x11aggregate_version\x18\a \x01(\x05R\x10aggregateVersion\"\xf6\x02\n" +
+	"\x11aggregate_version\x18\a \x01(\x05R\x10aggregateVersion\"\xaf\x03\n" +
+	"!OrderDeliveryStatusCorrectedEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"package_id\x18\x02 \x01(\tR\tpackageId\x12O\n" +
+	"\x0fprevious_status\x18\x03 \x01(\x0e2&.domain.order.common.v1.DeliveryStatusR\x0epreviousStatus\x12>\n" +
+	"\x06status\x18\x04 \x01(\x0e2&.domain.order.common.v1.DeliveryStatusR\x06status\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12=\n" +
+	"\fcorrected_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcorrectedAt\x12;\n" +
+	"\voccurred_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12+\n" +
+	"\x11aggregate_version\x18\b \x01(\x05R\x10aggregateVersion\"\xf6\x02\n" +
 	"\x1bOrderDeliveryCompletedEvent\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
 	"\n" +
@@ -847,7 +1088,20 @@ const file_domain_order_v1_events_v1_events_proto_rawDesc = "" +
 	"\tfailed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bfailedAt\x12;\n" +
 	"\voccurred_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"occurredAt\x12+\n" +
-	"\x11aggregate_version\x18\a \x01(\x05R\x10aggregateVersionB\xea\x01\n" +
+	"\x11aggregate_version\x18\a \x01(\x05R\x10aggregateVersion\"\x90\x04\n" +
+	"\x11OrderItemsUpdated\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12H\n" +
+	"\x0eprevious_items\x18\x03 \x03(\v2!.domain.order.common.v1.OrderItemR\rpreviousItems\x127\n" +
+	"\x05items\x18\x04 \x03(\v2!.domain.order.common.v1.OrderItemR\x05items\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12+\n" +
+	"\x11aggregate_version\x18\x06 \x01(\x05R\x10aggregateVersion\x12B\n" +
+	"\vadded_items\x18\a \x03(\v2!.domain.order.common.v1.OrderItemR\n" +
+	"addedItems\x12F\n" +
+	"\rremoved_items\x18\b \x03(\v2!.domain.order.common.v1.OrderItemR\fremovedItems\x12F\n" +
+	"\rchanged_items\x18\t \x03(\v2!.domain.order.common.v1.OrderItemR\fchangedItemsB\xea\x01\n" +
 	"\x1acom.domain.order.events.v1B\vEventsProtoP\x01ZDgithub.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1\xa2\x02\x03DOE\xaa\x02\x16Domain.Order.Events.V1\xca\x02\x16Domain\\Order\\Events\\V1\xe2\x02\"Domain\\Order\\Events\\V1\\GPBMetadata\xea\x02\x19Domain::Order::Events::V1b\x06proto3"
 
 var (
@@ -862,58 +1116,70 @@ func file_domain_order_v1_events_v1_events_proto_rawDescGZIP() []byte {
 	return file_domain_order_v1_events_v1_events_proto_rawDescData
 }
 
-var file_domain_order_v1_events_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_domain_order_v1_events_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_domain_order_v1_events_v1_events_proto_goTypes = []any{
-	(*OrderCreated)(nil),                    // 0: domain.order.events.v1.OrderCreated
-	(*OrderCancelled)(nil),                  // 1: domain.order.events.v1.OrderCancelled
-	(*OrderCompleted)(nil),                  // 2: domain.order.events.v1.OrderCompleted
-	(*OrderDeliveryRequestedEvent)(nil),     // 3: domain.order.events.v1.OrderDeliveryRequestedEvent
-	(*OrderDeliveryStatusUpdatedEvent)(nil), // 4: domain.order.events.v1.OrderDeliveryStatusUpdatedEvent
-	(*OrderDeliveryCompletedEvent)(nil),     // 5: domain.order.events.v1.OrderDeliveryCompletedEvent
-	(*OrderDeliveryFailedEvent)(nil),        // 6: domain.order.events.v1.OrderDeliveryFailedEvent
-	(*common.OrderItem)(nil),                // 7: domain.order.common.v1.OrderItem
-	(common.OrderStatus)(0),                 // 8: domain.order.common.v1.OrderStatus
-	(*timestamppb.Timestamp)(nil),           // 9: google.protobuf.Timestamp
-	(*common.DeliveryAddress)(nil),          // 10: domain.order.common.v1.DeliveryAddress
-	(*common.DeliveryPeriod)(nil),           // 11: domain.order.common.v1.DeliveryPeriod
-	(*common.PackageInfo)(nil),              // 12: domain.order.common.v1.PackageInfo
-	(common.DeliveryPriority)(0),            // 13: domain.order.common.v1.DeliveryPriority
-	(common.DeliveryStatus)(0),              // 14: domain.order.common.v1.DeliveryStatus
-	(*common.DeliveryLocation)(nil),         // 15: domain.order.common.v1.DeliveryLocation
-	(*common.NotDeliveredDetails)(nil),      // 16: domain.order.common.v1.NotDeliveredDetails
+	(*OrderCreated)(nil),                      // 0: domain.order.events.v1.OrderCreated
+	(*OrderCancelled)(nil),                    // 1: domain.order.events.v1.OrderCancelled
+	(*OrderCompleted)(nil),                    // 2: domain.order.events.v1.OrderCompleted
+	(*OrderDeliveryRequestedEvent)(nil),       // 3: domain.order.events.v1.OrderDeliveryRequestedEvent
+	(*OrderDeliveryStatusUpdatedEvent)(nil),   // 4: domain.order.events.v1.OrderDeliveryStatusUpdatedEvent
+	(*OrderDeliveryStatusCorrectedEvent)(nil), // 5: domain.order.events.v1.OrderDeliveryStatusCorrectedEvent
+	(*OrderDeliveryCompletedEvent)(nil),       // 6: domain.order.events.v1.OrderDeliveryCompletedEvent
+	(*OrderDeliveryFailedEvent)(nil),          // 7: domain.order.events.v1.OrderDeliveryFailedEvent
+	(*OrderItemsUpdated)(nil),                 // 8: domain.order.events.v1.OrderItemsUpdated
+	(*common.OrderItem)(nil),                  // 9: domain.order.common.v1.OrderItem
+	(common.OrderStatus)(0),                   // 10: domain.order.common.v1.OrderStatus
+	(*timestamppb.Timestamp)(nil),             // 11: google.protobuf.Timestamp
+	(*common.DeliveryAddress)(nil),            // 12: domain.order.common.v1.DeliveryAddress
+	(*common.DeliveryPeriod)(nil),             // 13: domain.order.common.v1.DeliveryPeriod
+	(*common.PackageInfo)(nil),                // 14: domain.order.common.v1.PackageInfo
+	(common.DeliveryPriority)(0),              // 15: domain.order.common.v1.DeliveryPriority
+	(common.DeliveryStatus)(0),                // 16: domain.order.common.v1.DeliveryStatus
+	(*common.DeliveryLocation)(nil),           // 17: domain.order.common.v1.DeliveryLocation
+	(*common.NotDeliveredDetails)(nil),        // 18: domain.order.common.v1.NotDeliveredDetails
 }
 var file_domain_order_v1_events_v1_events_proto_depIdxs = []int32{
-	7,  // 0: domain.order.events.v1.OrderCreated.items:type_name -> domain.order.common.v1.OrderItem
-	8,  // 1: domain.order.events.v1.OrderCreated.status:type_name -> domain.order.common.v1.OrderStatus
-	9,  // 2: domain.order.events.v1.OrderCreated.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 3: domain.order.events.v1.OrderCreated.occurred_at:type_name -> google.protobuf.Timestamp
-	8,  // 4: domain.order.events.v1.OrderCancelled.status:type_name -> domain.order.common.v1.OrderStatus
-	9,  // 5: domain.order.events.v1.OrderCancelled.cancelled_at:type_name -> google.protobuf.Timestamp
-	9,  // 6: domain.order.events.v1.OrderCancelled.occurred_at:type_name -> google.protobuf.Timestamp
-	8,  // 7: domain.order.events.v1.OrderCompleted.status:type_name -> domain.order.common.v1.OrderStatus
-	9,  // 8: domain.order.events.v1.OrderCompleted.completed_at:type_name -> google.protobuf.Timestamp
-	9,  // 9: domain.order.events.v1.OrderCompleted.occurred_at:type_name -> google.protobuf.Timestamp
-	10, // 10: domain.order.events.v1.OrderDeliveryRequestedEvent.pickup_address:type_name -> domain.order.common.v1.DeliveryAddress
-	10, // 11: domain.order.events.v1.OrderDeliveryRequestedEvent.delivery_address:type_name -> domain.order.common.v1.DeliveryAddress
-	11, // 12: domain.order.events.v1.OrderDeliveryRequestedEvent.delivery_period:type_name -> domain.order.common.v1.DeliveryPeriod
-	12, // 13: domain.order.events.v1.OrderDeliveryRequestedEvent.package_info:type_name -> domain.order.common.v1.PackageInfo
-	13, // 14: domain.order.events.v1.OrderDeliveryRequestedEvent.priority:type_name -> domain.order.common.v1.DeliveryPriority
-	9,  // 15: domain.order.events.v1.OrderDeliveryRequestedEvent.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 16: domain.order.events.v1.OrderDeliveryRequestedEvent.occurred_at:type_name -> google.protobuf.Timestamp
-	14, // 17: domain.order.events.v1.OrderDeliveryStatusUpdatedEvent.status:type_name -> domain.order.common.v1.DeliveryStatus
-	9,  // 18: domain.order.events.v1.OrderDeliveryStatusUpdatedEvent.updated_at:type_name -> google.protobuf.Timestamp
-	9,  // 19: domain.order.events.v1.OrderDeliveryStatusUpdatedEvent.occurred_at:type_name -> google.protobuf.Timestamp
-	9,  // 20: domain.order.events.v1.OrderDeliveryCompletedEvent.delivered_at:type_name -> google.protobuf.Timestamp
-	15, // 21: domain.order.events.v1.OrderDeliveryCompletedEvent.delivery_location:type_name -> domain.order.common.v1.DeliveryLocation
-	9,  // 22: domain.order.events.v1.OrderDeliveryCompletedEvent.occurred_at:type_name -> google.protobuf.Timestamp
-	16, // 23: domain.order.events.v1.OrderDeliveryFailedEvent.not_delivered_details:type_name -> domain.order.common.v1.NotDeliveredDetails
-	9,  // 24: domain.order.events.v1.OrderDeliveryFailedEvent.failed_at:type_name -> google.protobuf.Timestamp
-	9,  // 25: domain.order.events.v1.OrderDeliveryFailedEvent.occurred_at:type_name -> google.protobuf.Timestamp
-	26, // [26:26] is the sub-list for method output_type
-	26, // [26:26] is the sub-list for method input_type
-	26, // [26:26] is the sub-list for extension type_name
-	26, // [26:26] is the sub-list for extension extendee
-	0,  // [0:26] is the sub-list for field type_name
+	9,  // 0: domain.order.events.v1.OrderCreated.items:type_name -> domain.order.common.v1.OrderItem
+	10, // 1: domain.order.events.v1.OrderCreated.status:type_name -> domain.order.common.v1.OrderStatus
+	11, // 2: domain.order.events.v1.OrderCreated.created_at:type_name -> google.protobuf.Timestamp
+	11, // 3: domain.order.events.v1.OrderCreated.occurred_at:type_name -> google.protobuf.Timestamp
+	10, // 4: domain.order.events.v1.OrderCancelled.status:type_name -> domain.order.common.v1.OrderStatus
+	11, // 5: domain.order.events.v1.OrderCancelled.cancelled_at:type_name -> google.protobuf.Timestamp
+	11, // 6: domain.order.events.v1.OrderCancelled.occurred_at:type_name -> google.protobuf.Timestamp
+	10, // 7: domain.order.events.v1.OrderCompleted.status:type_name -> domain.order.common.v1.OrderStatus
+	11, // 8: domain.order.events.v1.OrderCompleted.completed_at:type_name -> google.protobuf.Timestamp
+	11, // 9: domain.order.events.v1.OrderCompleted.occurred_at:type_name -> google.protobuf.Timestamp
+	12, // 10: domain.order.events.v1.OrderDeliveryRequestedEvent.pickup_address:type_name -> domain.order.common.v1.DeliveryAddress
+	12, // 11: domain.order.events.v1.OrderDeliveryRequestedEvent.delivery_address:type_name -> domain.order.common.v1.DeliveryAddress
+	13, // 12: domain.order.events.v1.OrderDeliveryRequestedEvent.delivery_period:type_name -> domain.order.common.v1.DeliveryPeriod
+	14, // 13: domain.order.events.v1.OrderDeliveryRequestedEvent.package_info:type_name -> domain.order.common.v1.PackageInfo
+	15, // 14: domain.order.events.v1.OrderDeliveryRequestedEvent.priority:type_name -> domain.order.common.v1.DeliveryPriority
+	11, // 15: domain.order.events.v1.OrderDeliveryRequestedEvent.created_at:type_name -> google.protobuf.Timestamp
+	11, // 16: domain.order.events.v1.OrderDeliveryRequestedEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	16, // 17: domain.order.events.v1.OrderDeliveryStatusUpdatedEvent.status:type_name -> domain.order.common.v1.DeliveryStatus
+	11, // 18: domain.order.events.v1.OrderDeliveryStatusUpdatedEvent.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 19: domain.order.events.v1.OrderDeliveryStatusUpdatedEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	16, // 20: domain.order.events.v1.OrderDeliveryStatusCorrectedEvent.previous_status:type_name -> domain.order.common.v1.DeliveryStatus
+	16, // 21: domain.order.events.v1.OrderDeliveryStatusCorrectedEvent.status:type_name -> domain.order.common.v1.DeliveryStatus
+	11, // 22: domain.order.events.v1.OrderDeliveryStatusCorrectedEvent.corrected_at:type_name -> google.protobuf.Timestamp
+	11, // 23: domain.order.events.v1.OrderDeliveryStatusCorrectedEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	11, // 24: domain.order.events.v1.OrderDeliveryCompletedEvent.delivered_at:type_name -> google.protobuf.Timestamp
+	17, // 25: domain.order.events.v1.OrderDeliveryCompletedEvent.delivery_location:type_name -> domain.order.common.v1.DeliveryLocation
+	11, // 26: domain.order.events.v1.OrderDeliveryCompletedEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	18, // 27: domain.order.events.v1.OrderDeliveryFailedEvent.not_delivered_details:type_name -> domain.order.common.v1.NotDeliveredDetails
+	11, // 28: domain.order.events.v1.OrderDeliveryFailedEvent.failed_at:type_name -> google.protobuf.Timestamp
+	11, // 29: domain.order.events.v1.OrderDeliveryFailedEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	9,  // 30: domain.order.events.v1.OrderItemsUpdated.previous_items:type_name -> domain.order.common.v1.OrderItem
+	9,  // 31: domain.order.events.v1.OrderItemsUpdated.items:type_name -> domain.order.common.v1.OrderItem
+	11, // 32: domain.order.events.v1.OrderItemsUpdated.occurred_at:type_name -> google.protobuf.Timestamp
+	9,  // 33: domain.order.events.v1.OrderItemsUpdated.added_items:type_name -> domain.order.common.v1.OrderItem
+	9,  // 34: domain.order.events.v1.OrderItemsUpdated.removed_items:type_name -> domain.order.common.v1.OrderItem
+	9,  // 35: domain.order.events.v1.OrderItemsUpdated.changed_items:type_name -> domain.order.common.v1.OrderItem
+	36, // [36:36] is the sub-list for method output_type
+	36, // [36:36] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_domain_order_v1_events_v1_events_proto_init() }
@@ -927,7 +1193,7 @@ func file_domain_order_v1_events_v1_events_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_domain_order_v1_events_v1_events_proto_rawDesc), len(file_domain_order_v1_events_v1_events_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
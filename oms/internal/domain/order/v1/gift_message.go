@@ -0,0 +1,31 @@
+package v1
+
+// GetGiftMessage returns the gift message for the order; empty when none.
+func (o *OrderState) GetGiftMessage() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.giftMessage
+}
+
+// SetGiftMessage attaches a gift message to the order; an empty message removes it.
+func (o *OrderState) SetGiftMessage(message string) error {
+	err := ValidateGiftMessage(message)
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.giftMessage = message
+
+	return nil
+}
+
+// WithGiftMessage restores a persisted gift message; it was validated when the order was created.
+func WithGiftMessage(message string) Option {
+	return func(o *OrderState) {
+		o.giftMessage = message
+	}
+}
@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderState_SetGiftMessage(t *testing.T) {
+	order := NewOrderState(uuid.New())
+
+	require.NoError(t, order.SetGiftMessage(strings.Repeat("ж", MaxGiftMessageLength)))
+
+	err := order.SetGiftMessage(strings.Repeat("a", MaxGiftMessageLength+1))
+	require.ErrorIs(t, err, ErrOrderGiftMessageTooLong)
+	require.Len(t, []rune(order.GetGiftMessage()), MaxGiftMessageLength, "a rejected message must not replace the current one")
+
+	restored := NewOrderStateFromPersisted(
+		order.GetOrderID(), order.GetCustomerId(), nil,
+		OrderStatus_ORDER_STATUS_PENDING, 1, nil, 0, nil,
+		WithGiftMessage("Happy birthday!"),
+	)
+	require.Equal(t, "Happy birthday!", restored.GetGiftMessage())
+}
+
+func TestCreateFromLines_ItemNotes(t *testing.T) {
+	goodID := uuid.New()
+
+	t.Run("note is carried to the order item", func(t *testing.T) {
+		order := NewOrderState(uuid.New())
+
+		err := order.CreateFromLines(context.Background(), []Line{
+			{ProductID: goodID, Qty: 1, UnitPrice: decimal.NewFromInt(10), Note: "leave at the door"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "leave at the door", order.GetItems()[0].GetNote())
+	})
+
+	t.Run("note over the limit is rejected", func(t *testing.T) {
+		order := NewOrderState(uuid.New())
+
+		err := order.CreateFromLines(context.Background(), []Line{
+			{ProductID: goodID, Qty: 1, UnitPrice: decimal.NewFromInt(10), Note: strings.Repeat("a", MaxItemNoteLength+1)},
+		})
+		require.ErrorIs(t, err, ErrOrderItemNoteTooLong)
+		require.Equal(t, OrderStatus_ORDER_STATUS_PENDING, order.GetStatus())
+	})
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+
+	pricing "github.com/shortlink-org/shop/oms/internal/domain/pricing"
 )
 
 // Line is a neutral input for creating order items (e.g. from cart or API).
@@ -13,13 +15,22 @@ type Line struct {
 	ProductID uuid.UUID
 	Qty       int32
 	UnitPrice decimal.Decimal
+	Currency  pricing.Currency
+	// Note is the optional customer instruction for the line
+	Note string
+	// TaxCategory is the product category tax policies branch on; empty means the pricer's default
+	TaxCategory string
 }
 
 // CreateFromLines initializes the order with the provided lines and transitions it to Processing state.
 func (o *OrderState) CreateFromLines(ctx context.Context, lines []Line) error {
 	items := make(Items, 0, len(lines))
 	for _, l := range lines {
-		items = append(items, NewItem(l.ProductID, l.Qty, l.UnitPrice))
+		item := NewItem(l.ProductID, l.Qty, l.UnitPrice).
+			WithCurrency(l.Currency).
+			WithNote(l.Note).
+			WithTaxCategory(l.TaxCategory)
+		items = append(items, item)
 	}
 
 	return o.CreateOrder(ctx, items)
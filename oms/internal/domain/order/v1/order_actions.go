@@ -0,0 +1,61 @@
+package v1
+
+import (
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+)
+
+// OrderAction is an operation a client can request on an existing order.
+type OrderAction string
+
+const (
+	// OrderActionCancel cancels the order (CancelOrder).
+	OrderActionCancel OrderAction = "cancel"
+	// OrderActionComplete completes the order (CompleteOrder).
+	OrderActionComplete OrderAction = "complete"
+	// OrderActionUpdateItems changes the order items (UpdateOrder).
+	OrderActionUpdateItems OrderAction = "update_items"
+	// OrderActionUpdateDeliveryInfo sets or replaces the delivery info (SetDeliveryInfo).
+	OrderActionUpdateDeliveryInfo OrderAction = "update_delivery_info"
+)
+
+// AvailableActions returns the operations the order currently accepts, in a stable order.
+// It evaluates the same transition rules and guards the commands do, so an action listed here
+// is rejected only for input-specific reasons (e.g. invalid items or delivery info).
+// Terminal orders have no actions.
+func (o *OrderState) AvailableActions() []OrderAction {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	status := o.getStatusUnlocked()
+	actions := make([]OrderAction, 0, 4)
+
+	if hasOrderTransition(status, commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_CANCEL) {
+		actions = append(actions, OrderActionCancel)
+	}
+
+	if hasOrderTransition(status, commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_COMPLETE) &&
+		validateOrderItems(o.items, o.maxLineItems) == nil {
+		actions = append(actions, OrderActionComplete)
+	}
+
+	if status != OrderStatus_ORDER_STATUS_COMPLETED && status != OrderStatus_ORDER_STATUS_CANCELED {
+		actions = append(actions, OrderActionUpdateItems)
+	}
+
+	if o.checkDeliveryInfoEditableLocked() == nil {
+		actions = append(actions, OrderActionUpdateDeliveryInfo)
+	}
+
+	return actions
+}
+
+// hasOrderTransition reports whether orderTransitionRules accept event in status from.
+func hasOrderTransition(from OrderStatus, event commonv1.OrderTransitionEvent) bool {
+	for _, rule := range orderTransitionRules {
+		if rule.From == from && rule.Event == event {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,100 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	common "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+)
+
+func TestOrderState_AvailableActions(t *testing.T) {
+	t.Parallel()
+
+	items := Items{NewItem(uuid.New(), 1, decimal.NewFromInt(10))}
+	requestedAt := time.Date(2026, time.March, 11, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		status         OrderStatus
+		deliveryStatus common.DeliveryStatus
+		requestedAt    *time.Time
+		want           []OrderAction
+	}{
+		{
+			name:   "pending",
+			status: OrderStatus_ORDER_STATUS_PENDING,
+			want:   []OrderAction{OrderActionCancel, OrderActionUpdateItems, OrderActionUpdateDeliveryInfo},
+		},
+		{
+			name:   "processing before delivery is requested",
+			status: OrderStatus_ORDER_STATUS_PROCESSING,
+			want:   []OrderAction{OrderActionCancel, OrderActionComplete, OrderActionUpdateItems, OrderActionUpdateDeliveryInfo},
+		},
+		{
+			name:        "processing with delivery requested",
+			status:      OrderStatus_ORDER_STATUS_PROCESSING,
+			requestedAt: &requestedAt,
+			want:        []OrderAction{OrderActionCancel, OrderActionComplete, OrderActionUpdateItems},
+		},
+		{
+			name:           "processing while assigned",
+			status:         OrderStatus_ORDER_STATUS_PROCESSING,
+			deliveryStatus: common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+			want:           []OrderAction{OrderActionCancel, OrderActionComplete, OrderActionUpdateItems},
+		},
+		{
+			name:           "processing while in transit",
+			status:         OrderStatus_ORDER_STATUS_PROCESSING,
+			deliveryStatus: common.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+			want:           []OrderAction{OrderActionCancel, OrderActionComplete, OrderActionUpdateItems},
+		},
+		{
+			name:           "completed",
+			status:         OrderStatus_ORDER_STATUS_COMPLETED,
+			deliveryStatus: common.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+			want:           []OrderAction{},
+		},
+		{
+			name:           "canceled",
+			status:         OrderStatus_ORDER_STATUS_CANCELED,
+			deliveryStatus: common.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED,
+			want:           []OrderAction{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			order := NewOrderStateFromPersisted(uuid.New(), uuid.New(), items, tt.status, 1, nil, tt.deliveryStatus, tt.requestedAt)
+			require.Equal(t, tt.want, order.AvailableActions())
+		})
+	}
+}
+
+func TestOrderState_AvailableActions_MatchCommands(t *testing.T) {
+	t.Parallel()
+
+	newOrder := func() *OrderState {
+		return NewOrderStateFromPersisted(uuid.New(), uuid.New(), Items{NewItem(uuid.New(), 1, decimal.NewFromInt(10))},
+			OrderStatus_ORDER_STATUS_PROCESSING, 1, nil, common.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED, nil)
+	}
+
+	pickup := newOrder()
+	require.NoError(t, pickup.SetFulfillmentType(FulfillmentType_FULFILLMENT_TYPE_PICKUP))
+	require.NotContains(t, pickup.AvailableActions(), OrderActionUpdateDeliveryInfo)
+
+	processing := newOrder()
+	require.Contains(t, processing.AvailableActions(), OrderActionComplete)
+	require.NoError(t, processing.CompleteOrder())
+	require.Empty(t, processing.AvailableActions())
+
+	canceled := newOrder()
+	require.Contains(t, canceled.AvailableActions(), OrderActionCancel)
+	require.NoError(t, canceled.CancelOrder())
+	require.Empty(t, canceled.AvailableActions())
+}
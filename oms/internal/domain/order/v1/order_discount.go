@@ -0,0 +1,34 @@
+package v1
+
+import "github.com/shopspring/decimal"
+
+// GetOrderDiscount returns the order-level discount; zero when none applies.
+// It is separate from the item discounts: the order is charged
+// subtotal - item discounts - order discount + tax.
+func (o *OrderState) GetOrderDiscount() decimal.Decimal {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.orderDiscount
+}
+
+// SetOrderDiscount records the order-level discount the pricer applied.
+func (o *OrderState) SetOrderDiscount(amount decimal.Decimal) error {
+	if amount.IsNegative() {
+		return ErrOrderDiscountNegative
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.orderDiscount = amount
+
+	return nil
+}
+
+// WithOrderDiscount restores a persisted order discount; it was validated when it was set.
+func WithOrderDiscount(amount decimal.Decimal) Option {
+	return func(o *OrderState) {
+		o.orderDiscount = amount
+	}
+}
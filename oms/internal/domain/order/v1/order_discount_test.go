@@ -0,0 +1,27 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderState_SetOrderDiscount(t *testing.T) {
+	order := NewOrderState(uuid.New())
+	require.True(t, order.GetOrderDiscount().IsZero())
+
+	require.NoError(t, order.SetOrderDiscount(decimal.NewFromInt(10)))
+
+	err := order.SetOrderDiscount(decimal.NewFromInt(-1))
+	require.ErrorIs(t, err, ErrOrderDiscountNegative)
+	require.True(t, decimal.NewFromInt(10).Equal(order.GetOrderDiscount()), "a rejected discount must not replace the current one")
+
+	restored := NewOrderStateFromPersisted(
+		order.GetOrderID(), order.GetCustomerId(), nil,
+		OrderStatus_ORDER_STATUS_PENDING, 1, nil, 0, nil,
+		WithOrderDiscount(decimal.RequireFromString("10.00")),
+	)
+	require.True(t, decimal.NewFromInt(10).Equal(restored.GetOrderDiscount()))
+}
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -17,6 +18,11 @@ type Item struct {
 	goodId   uuid.UUID
 	quantity int32
 	price    decimal.Decimal
+	currency pricing.Currency
+	// note is the customer instruction carried over from the cart; empty when none
+	note string
+	// taxCategory is the product category tax policies branch on; empty means the pricer's default
+	taxCategory string
 }
 
 // NewItem creates a new item.
@@ -43,6 +49,43 @@ func (m Item) GetPrice() decimal.Decimal {
 	return m.price
 }
 
+// GetCurrency returns the currency of the price.
+func (m Item) GetCurrency() pricing.Currency {
+	return m.currency
+}
+
+// WithCurrency returns a copy of the item priced in the given currency.
+func (m Item) WithCurrency(currency pricing.Currency) Item {
+	m.currency = currency
+
+	return m
+}
+
+// GetNote returns the customer note for the item; empty when none.
+func (m Item) GetNote() string {
+	return m.note
+}
+
+// WithNote returns a copy of the item carrying the given note.
+// Length is enforced by ValidateOrderItem.
+func (m Item) WithNote(note string) Item {
+	m.note = note
+
+	return m
+}
+
+// GetTaxCategory returns the tax category of the item; empty means the pricer's default.
+func (m Item) GetTaxCategory() string {
+	return m.taxCategory
+}
+
+// WithTaxCategory returns a copy of the item in the given tax category.
+func (m Item) WithTaxCategory(taxCategory string) Item {
+	m.taxCategory = taxCategory
+
+	return m
+}
+
 // WithPricePolicy applies a price policy and returns a new priced item.
 func (m Item) WithPricePolicy(policy pricing.PricePolicy) (Item, error) {
 	if policy == nil {
@@ -55,8 +98,60 @@ func (m Item) WithPricePolicy(policy pricing.PricePolicy) (Item, error) {
 	}
 
 	return Item{
-		goodId:   m.goodId,
-		quantity: m.quantity,
-		price:    quote.FinalUnitPrice(),
+		goodId:      m.goodId,
+		quantity:    m.quantity,
+		price:       quote.FinalUnitPrice(),
+		currency:    m.currency,
+		note:        m.note,
+		taxCategory: m.taxCategory,
 	}, nil
 }
+
+// Equal reports whether both items have the same good, quantity, price, currency, note and tax category.
+func (m Item) Equal(other Item) bool {
+	return m.goodId == other.goodId &&
+		m.quantity == other.quantity &&
+		m.price.Equal(other.price) &&
+		m.currency == other.currency &&
+		m.note == other.note &&
+		m.taxCategory == other.taxCategory
+}
+
+// Equal reports whether both lists hold equal items in the same order.
+func (i Items) Equal(other Items) bool {
+	return slices.EqualFunc(i, other, Item.Equal)
+}
+
+// Diff compares the items with other by good: added are the items of other whose good is not in i,
+// removed the items of i whose good is not in other, and changed the items of other whose good is
+// in i with a different quantity, price or other attribute (see Item.Equal).
+// Each result keeps the order of its source list; all three are empty when the lists hold equal items.
+func (i Items) Diff(other Items) (added, removed, changed Items) {
+	before := make(map[uuid.UUID]Item, len(i))
+	for _, item := range i {
+		before[item.GetGoodId()] = item
+	}
+
+	after := make(map[uuid.UUID]struct{}, len(other))
+
+	for _, item := range other {
+		after[item.GetGoodId()] = struct{}{}
+
+		previous, ok := before[item.GetGoodId()]
+
+		switch {
+		case !ok:
+			added = append(added, item)
+		case !previous.Equal(item):
+			changed = append(changed, item)
+		}
+	}
+
+	for _, item := range i {
+		if _, ok := after[item.GetGoodId()]; !ok {
+			removed = append(removed, item)
+		}
+	}
+
+	return added, removed, changed
+}
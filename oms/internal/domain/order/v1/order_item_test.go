@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItems_Diff(t *testing.T) {
+	goodID1 := uuid.MustParse("123e4567-e89b-12d3-a456-426614174001")
+	goodID2 := uuid.MustParse("123e4567-e89b-12d3-a456-426614174002")
+	goodID3 := uuid.MustParse("123e4567-e89b-12d3-a456-426614174003")
+
+	before := Items{
+		NewItem(goodID1, 2, decimal.RequireFromString("19.99")),
+		NewItem(goodID2, 1, decimal.RequireFromString("9.99")),
+	}
+
+	tests := []struct {
+		name        string
+		after       Items
+		wantAdded   Items
+		wantRemoved Items
+		wantChanged Items
+	}{
+		{
+			name: "identical",
+			// Equal decimals with a different scale are not a change
+			after: Items{
+				NewItem(goodID1, 2, decimal.RequireFromString("19.990")),
+				NewItem(goodID2, 1, decimal.RequireFromString("9.99")),
+			},
+		},
+		{
+			name: "added",
+			after: Items{
+				NewItem(goodID1, 2, decimal.RequireFromString("19.99")),
+				NewItem(goodID2, 1, decimal.RequireFromString("9.99")),
+				NewItem(goodID3, 4, decimal.RequireFromString("1.50")),
+			},
+			wantAdded: Items{NewItem(goodID3, 4, decimal.RequireFromString("1.50"))},
+		},
+		{
+			name:        "removed",
+			after:       Items{NewItem(goodID2, 1, decimal.RequireFromString("9.99"))},
+			wantRemoved: Items{NewItem(goodID1, 2, decimal.RequireFromString("19.99"))},
+		},
+		{
+			name: "quantity changed",
+			after: Items{
+				NewItem(goodID1, 5, decimal.RequireFromString("19.99")),
+				NewItem(goodID2, 1, decimal.RequireFromString("9.99")),
+			},
+			wantChanged: Items{NewItem(goodID1, 5, decimal.RequireFromString("19.99"))},
+		},
+		{
+			name: "price changed",
+			after: Items{
+				NewItem(goodID1, 2, decimal.RequireFromString("19.99")),
+				NewItem(goodID2, 1, decimal.RequireFromString("8.49")),
+			},
+			wantChanged: Items{NewItem(goodID2, 1, decimal.RequireFromString("8.49"))},
+		},
+		{
+			name: "all categories",
+			after: Items{
+				NewItem(goodID3, 1, decimal.RequireFromString("1.50")),
+				NewItem(goodID1, 3, decimal.RequireFromString("19.99")),
+			},
+			wantAdded:   Items{NewItem(goodID3, 1, decimal.RequireFromString("1.50"))},
+			wantRemoved: Items{NewItem(goodID2, 1, decimal.RequireFromString("9.99"))},
+			wantChanged: Items{NewItem(goodID1, 3, decimal.RequireFromString("19.99"))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed, changed := before.Diff(tt.after)
+
+			require.True(t, tt.wantAdded.Equal(added), "added: got %v", added)
+			require.True(t, tt.wantRemoved.Equal(removed), "removed: got %v", removed)
+			require.True(t, tt.wantChanged.Equal(changed), "changed: got %v", changed)
+		})
+	}
+
+	t.Run("empty sets", func(t *testing.T) {
+		added, removed, changed := Items{}.Diff(nil)
+
+		require.Empty(t, added)
+		require.Empty(t, removed)
+		require.Empty(t, changed)
+	})
+}
@@ -0,0 +1,319 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/shortlink-org/go-sdk/fsm"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	eventsv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1"
+	addressvo "github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/location"
+	"github.com/shortlink-org/shop/oms/internal/domain/pricing"
+)
+
+// Replay error definitions
+var (
+	ErrReplayNoEvents            = errors.New("no events to replay")
+	ErrReplayMissingCreated      = errors.New("event stream must start with OrderCreated")
+	ErrReplayDuplicateCreated    = errors.New("event stream contains more than one OrderCreated")
+	ErrReplayOrderMismatch       = errors.New("event belongs to a different order")
+	ErrReplayVersionOutOfOrder   = errors.New("event aggregate version is out of order")
+	ErrReplayUnsupportedEvent    = errors.New("unsupported event type")
+	ErrReplayInvalidEventPayload = errors.New("invalid event payload")
+)
+
+// ReplayEvents rebuilds an OrderState by folding its domain events in order.
+// The stream must start with OrderCreated; every status change goes through the FSM,
+// so an illegal path (e.g. COMPLETED after CANCELED) is rejected.
+// The returned aggregate has no pending domain events and its version equals the last AggregateVersion.
+func ReplayEvents(events []any) (*OrderState, error) {
+	if len(events) == 0 {
+		return nil, ErrReplayNoEvents
+	}
+
+	created, ok := events[0].(*eventsv1.OrderCreated)
+	if !ok {
+		return nil, fmt.Errorf("%w: got %T", ErrReplayMissingCreated, events[0])
+	}
+
+	order, err := orderFromCreatedEvent(created)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, event := range events[1:] {
+		if err := order.applyReplayedEvent(event); err != nil {
+			return nil, fmt.Errorf("replay event #%d (%T): %w", i+1, event, err)
+		}
+	}
+
+	return order, nil
+}
+
+func orderFromCreatedEvent(event *eventsv1.OrderCreated) (*OrderState, error) {
+	orderID, err := uuid.Parse(event.GetOrderId())
+	if err != nil {
+		return nil, fmt.Errorf("%w: order_id: %w", ErrReplayInvalidEventPayload, err)
+	}
+
+	customerID, err := uuid.Parse(event.GetCustomerId())
+	if err != nil {
+		return nil, fmt.Errorf("%w: customer_id: %w", ErrReplayInvalidEventPayload, err)
+	}
+
+	items, err := orderItemsFromProto(event.GetItems())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateOrderItems(items); err != nil {
+		return nil, fmt.Errorf("cannot replay order: %w", err)
+	}
+
+	order := newOrderState(
+		orderID,
+		customerID,
+		items,
+		OrderStatus_ORDER_STATUS_PENDING,
+		0,
+		nil,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+		nil,
+	)
+
+	if err := order.replayTransition(commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_CREATE); err != nil {
+		return nil, err
+	}
+
+	order.version = int(event.GetAggregateVersion())
+
+	return order, nil
+}
+
+// applyReplayedEvent folds a single event into the aggregate.
+//
+//nolint:funcorder // unexported helper used by ReplayEvents
+func (o *OrderState) applyReplayedEvent(event any) error {
+	versioned, ok := event.(interface {
+		GetOrderId() string
+		GetAggregateVersion() int32
+	})
+	if !ok {
+		return ErrReplayUnsupportedEvent
+	}
+
+	if versioned.GetOrderId() != o.id.String() {
+		return fmt.Errorf("%w: expected %s, got %s", ErrReplayOrderMismatch, o.id, versioned.GetOrderId())
+	}
+
+	version := int(versioned.GetAggregateVersion())
+	if version < o.version {
+		return fmt.Errorf("%w: %d after %d", ErrReplayVersionOutOfOrder, version, o.version)
+	}
+
+	var err error
+
+	switch e := event.(type) {
+	case *eventsv1.OrderCreated:
+		err = ErrReplayDuplicateCreated
+	case *eventsv1.OrderCancelled:
+		err = o.replayTransition(commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_CANCEL)
+	case *eventsv1.OrderCompleted:
+		err = o.replayTransition(commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_COMPLETE)
+	case *eventsv1.OrderDeliveryRequestedEvent:
+		err = o.replayDeliveryRequested(e)
+	case *eventsv1.OrderDeliveryStatusUpdatedEvent:
+		err = o.replayDeliveryStatusUpdated(e)
+	case *eventsv1.OrderDeliveryStatusCorrectedEvent:
+		err = o.replayDeliveryStatusCorrected(e)
+	case *eventsv1.OrderDeliveryCompletedEvent:
+		err = o.setDeliveryStatusLocked(commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED)
+	case *eventsv1.OrderDeliveryFailedEvent:
+		err = o.setDeliveryStatusLocked(commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED)
+	case *eventsv1.OrderItemsUpdated:
+		err = o.replayItemsUpdated(e)
+	default:
+		err = ErrReplayUnsupportedEvent
+	}
+
+	if err != nil {
+		return err
+	}
+
+	o.version = version
+
+	return nil
+}
+
+// replayTransition triggers an FSM event, rejecting transitions the order rules do not allow.
+//
+//nolint:funcorder // unexported helper used by ReplayEvents
+func (o *OrderState) replayTransition(event commonv1.OrderTransitionEvent) error {
+	from := o.getStatusUnlocked()
+
+	err := o.fsm.TriggerEvent(context.Background(), fsm.Event(event.String()))
+	if err != nil {
+		return fmt.Errorf("illegal transition %s from %s: %w", event, from, err)
+	}
+
+	return nil
+}
+
+//nolint:funcorder // unexported helper used by ReplayEvents
+func (o *OrderState) replayDeliveryRequested(event *eventsv1.OrderDeliveryRequestedEvent) error {
+	currentStatus := o.getStatusUnlocked()
+	if currentStatus == OrderStatus_ORDER_STATUS_COMPLETED ||
+		currentStatus == OrderStatus_ORDER_STATUS_CANCELED {
+		return &OrderTerminalStateError{Status: currentStatus}
+	}
+
+	if o.deliveryRequestedAt != nil {
+		return &DeliveryAlreadyRequestedError{}
+	}
+
+	pickupAddress, err := deliveryAddressFromProto(event.GetPickupAddress())
+	if err != nil {
+		return fmt.Errorf("pickup address: %w", err)
+	}
+
+	deliveryAddress, err := deliveryAddressFromProto(event.GetDeliveryAddress())
+	if err != nil {
+		return fmt.Errorf("delivery address: %w", err)
+	}
+
+	info := NewDeliveryInfo(
+		pickupAddress,
+		deliveryAddress,
+		NewDeliveryPeriod(
+			event.GetDeliveryPeriod().GetStartTime().AsTime(),
+			event.GetDeliveryPeriod().GetEndTime().AsTime(),
+		),
+		packageInfoFromProto(event.GetPackageInfo()),
+		DeliveryPriority(event.GetPriority()),
+		nil,
+	)
+
+	if event.GetPackageId() != "" {
+		packageID, err := uuid.Parse(event.GetPackageId())
+		if err != nil {
+			return fmt.Errorf("%w: package_id: %w", ErrReplayInvalidEventPayload, err)
+		}
+
+		info.SetPackageId(packageID)
+	}
+
+	requestedAt := replayEventTime(event.GetOccurredAt())
+	o.deliveryInfo = &info
+	o.deliveryRequestedAt = &requestedAt
+
+	return nil
+}
+
+// replayDeliveryStatusUpdated routes a package of a split delivery the same way SetPackageDeliveryStatus does.
+//
+//nolint:funcorder // unexported helper used by ReplayEvents
+func (o *OrderState) replayDeliveryStatusUpdated(event *eventsv1.OrderDeliveryStatusUpdatedEvent) error {
+	if event.GetPackageId() == "" {
+		return o.setDeliveryStatusLocked(event.GetStatus())
+	}
+
+	packageID, err := uuid.Parse(event.GetPackageId())
+	if err != nil {
+		return fmt.Errorf("%w: package_id: %w", ErrReplayInvalidEventPayload, err)
+	}
+
+	if !o.routesByPackageLocked(packageID) {
+		return o.setDeliveryStatusLocked(event.GetStatus())
+	}
+
+	return o.setPackageDeliveryStatusLocked(packageID, event.GetStatus())
+}
+
+//nolint:funcorder // unexported helper used by ReplayEvents
+func (o *OrderState) replayDeliveryStatusCorrected(event *eventsv1.OrderDeliveryStatusCorrectedEvent) error {
+	if event.GetPreviousStatus() != o.deliveryStatus ||
+		!o.isValidDeliveryStatusCorrection(o.deliveryStatus, event.GetStatus()) {
+		return &InvalidDeliveryStatusTransitionError{From: o.deliveryStatus, To: event.GetStatus()}
+	}
+
+	o.deliveryStatus = event.GetStatus()
+
+	return nil
+}
+
+//nolint:funcorder // unexported helper used by ReplayEvents
+func (o *OrderState) replayItemsUpdated(event *eventsv1.OrderItemsUpdated) error {
+	currentStatus := o.getStatusUnlocked()
+	if currentStatus == OrderStatus_ORDER_STATUS_COMPLETED ||
+		currentStatus == OrderStatus_ORDER_STATUS_CANCELED {
+		return &OrderTerminalStateError{Status: currentStatus}
+	}
+
+	items, err := orderItemsFromProto(event.GetItems())
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateOrderItems(items); err != nil {
+		return fmt.Errorf("cannot replay items update: %w", err)
+	}
+
+	o.items = items
+
+	return nil
+}
+
+func orderItemsFromProto(items []*commonv1.OrderItem) (Items, error) {
+	out := make(Items, 0, len(items))
+
+	for _, it := range items {
+		goodID, err := uuid.Parse(it.GetGoodId())
+		if err != nil {
+			return nil, fmt.Errorf("%w: good_id: %w", ErrReplayInvalidEventPayload, err)
+		}
+
+		price, err := decimal.NewFromString(it.GetPrice())
+		if err != nil {
+			return nil, fmt.Errorf("%w: price of %s: %w", ErrReplayInvalidEventPayload, goodID, err)
+		}
+
+		out = append(out, NewItem(goodID, it.GetQuantity(), price).
+			WithNote(it.GetNote()).
+			WithCurrency(pricing.Currency(it.GetCurrency())).
+			WithTaxCategory(it.GetTaxCategory()))
+	}
+
+	return out, nil
+}
+
+func deliveryAddressFromProto(addr *commonv1.DeliveryAddress) (addressvo.Address, error) {
+	if addr.GetLatitude() == 0 && addr.GetLongitude() == 0 {
+		return addressvo.NewAddress(addr.GetStreet(), addr.GetCity(), addr.GetPostalCode(), addr.GetCountry())
+	}
+
+	loc, err := location.NewLocation(addr.GetLatitude(), addr.GetLongitude())
+	if err != nil {
+		return addressvo.Address{}, err
+	}
+
+	return addressvo.NewAddressWithLocation(addr.GetStreet(), addr.GetCity(), addr.GetPostalCode(), addr.GetCountry(), loc)
+}
+
+func packageInfoFromProto(info *commonv1.PackageInfo) PackageInfo {
+	return NewPackageInfoWithDimensions(info.GetWeightKg(), info.GetLengthCm(), info.GetWidthCm(), info.GetHeightCm())
+}
+
+func replayEventTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
+}
@@ -0,0 +1,293 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	common "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	eventsv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+	"github.com/shortlink-org/shop/oms/internal/domain/pricing"
+)
+
+func TestReplayEvents(t *testing.T) {
+	items := Items{
+		NewItem(uuid.New(), 2, decimal.NewFromFloat(19.99)),
+		NewItem(uuid.New(), 1, decimal.NewFromFloat(9.99)),
+	}
+
+	testCases := []struct {
+		name           string
+		finish         func(order *OrderState) error
+		expectedStatus OrderStatus
+	}{
+		{
+			name:           "create then complete",
+			finish:         (*OrderState).CompleteOrder,
+			expectedStatus: OrderStatus_ORDER_STATUS_COMPLETED,
+		},
+		{
+			name:           "create then cancel",
+			finish:         (*OrderState).CancelOrder,
+			expectedStatus: OrderStatus_ORDER_STATUS_CANCELED,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			original := NewOrderState(uuid.New())
+			require.NoError(t, original.CreateOrder(context.Background(), items))
+			require.NoError(t, tc.finish(original))
+
+			replayed, err := ReplayEvents(domainEventsAsAny(original))
+			require.NoError(t, err)
+
+			require.Equal(t, original.GetOrderID(), replayed.GetOrderID())
+			require.Equal(t, original.GetCustomerId(), replayed.GetCustomerId())
+			require.Equal(t, tc.expectedStatus, replayed.GetStatus())
+			require.Len(t, replayed.GetItems(), len(items))
+
+			for i, item := range replayed.GetItems() {
+				require.Equal(t, items[i].GetGoodId(), item.GetGoodId())
+				require.Equal(t, items[i].GetQuantity(), item.GetQuantity())
+				require.True(t, items[i].GetPrice().Equal(item.GetPrice()))
+			}
+
+			require.Empty(t, replayed.GetDomainEvents())
+			require.Equal(t, 1, replayed.GetVersion())
+		})
+	}
+}
+
+func TestReplayEvents_DeliveryLifecycle(t *testing.T) {
+	original := NewOrderState(uuid.New())
+	require.NoError(t, original.SetDeliveryInfo(replayTestDeliveryInfo(t)))
+	require.NoError(t, original.CreateOrder(context.Background(), Items{
+		NewItem(uuid.New(), 1, decimal.NewFromFloat(9.99)),
+	}))
+
+	packageID := uuid.New()
+	courierID := uuid.New()
+	requestedAt := time.Date(2026, time.March, 11, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(t, original.RequestDelivery(&packageID, requestedAt))
+	require.NoError(t, original.ApplyDeliveryAccepted(&packageID, requestedAt.Add(time.Minute)))
+	require.NoError(t, original.ApplyDeliveryAssigned(&packageID, &courierID, requestedAt.Add(2*time.Minute)))
+	require.NoError(t, original.ApplyDeliveryInTransit(&packageID, &courierID, requestedAt.Add(3*time.Minute)))
+	require.NoError(t, original.ApplyDeliveryDelivered(&packageID, &courierID, nil, requestedAt.Add(4*time.Minute)))
+
+	replayed, err := ReplayEvents(domainEventsAsAny(original))
+	require.NoError(t, err)
+
+	require.Equal(t, OrderStatus_ORDER_STATUS_COMPLETED, replayed.GetStatus())
+	require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_DELIVERED, replayed.GetDeliveryStatus())
+	require.NotNil(t, replayed.GetDeliveryRequestedAt())
+	require.True(t, requestedAt.Equal(*replayed.GetDeliveryRequestedAt()))
+
+	info := replayed.GetDeliveryInfo()
+	require.NotNil(t, info)
+	require.NotNil(t, info.GetPackageId())
+	require.Equal(t, packageID, *info.GetPackageId())
+	require.Equal(t, "456 Customer St", info.GetDeliveryAddress().Street())
+	require.Equal(t, DeliveryPriorityUrgent, info.GetPriority())
+	require.InDelta(t, 2.5, info.GetPackageInfo().GetWeightKg(), 1e-9)
+	require.InDelta(t, 40, info.GetPackageInfo().GetLengthCm(), 1e-9)
+	require.InDelta(t, 30, info.GetPackageInfo().GetWidthCm(), 1e-9)
+	require.InDelta(t, 20, info.GetPackageInfo().GetHeightCm(), 1e-9)
+}
+
+func TestReplayEvents_DeliveryStatusCorrection(t *testing.T) {
+	original := NewOrderState(uuid.New())
+	require.NoError(t, original.SetDeliveryInfo(replayTestDeliveryInfo(t)))
+	require.NoError(t, original.CreateOrder(context.Background(), Items{
+		NewItem(uuid.New(), 1, decimal.NewFromFloat(9.99)),
+	}))
+
+	packageID := uuid.New()
+	courierID := uuid.New()
+	now := time.Now()
+
+	require.NoError(t, original.RequestDelivery(&packageID, now))
+	require.NoError(t, original.ApplyDeliveryAccepted(&packageID, now))
+	require.NoError(t, original.ApplyDeliveryAssigned(&packageID, &courierID, now))
+	require.NoError(t, original.CorrectDeliveryStatus(common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED, "courier assignment revoked", now))
+
+	replayed, err := ReplayEvents(domainEventsAsAny(original))
+	require.NoError(t, err)
+	require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED, replayed.GetDeliveryStatus())
+
+	// A correction whose previous status does not match the replayed state is rejected.
+	mismatched := &eventsv1.OrderDeliveryStatusCorrectedEvent{
+		OrderId:          original.GetOrderID().String(),
+		PreviousStatus:   common.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+		Status:           common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+		AggregateVersion: 1,
+	}
+	_, err = ReplayEvents(append(domainEventsAsAny(original), mismatched))
+
+	var transitionErr *InvalidDeliveryStatusTransitionError
+	require.ErrorAs(t, err, &transitionErr)
+}
+
+func TestReplayEvents_ItemsUpdated(t *testing.T) {
+	goodID := uuid.New()
+
+	original := NewOrderState(uuid.New())
+	require.NoError(t, original.CreateOrder(context.Background(), Items{
+		NewItem(goodID, 1, decimal.NewFromFloat(9.99)),
+	}))
+	require.NoError(t, original.UpdateOrder(Items{NewItem(goodID, 3, decimal.NewFromFloat(9.99))}))
+
+	replayed, err := ReplayEvents(domainEventsAsAny(original))
+	require.NoError(t, err)
+	require.Len(t, replayed.GetItems(), 1)
+	require.Equal(t, int32(3), replayed.GetItems()[0].GetQuantity())
+
+	// Items cannot change once the order reached a terminal state.
+	require.NoError(t, original.CancelOrder())
+
+	events := domainEventsAsAny(original)
+	_, err = ReplayEvents([]any{events[0], events[2], events[1]})
+
+	var terminalErr *OrderTerminalStateError
+	require.ErrorAs(t, err, &terminalErr)
+}
+
+// discountPolicy quotes every good at 20.00 with 5.00 off per unit.
+type discountPolicy struct{}
+
+func (discountPolicy) Quote(uuid.UUID, int32) (pricing.Quote, error) {
+	return pricing.Quote{UnitPrice: decimal.RequireFromString("20.00"), Discount: decimal.RequireFromString("5.00")}, nil
+}
+
+func TestReplayEvents_MatchesPersistedItems(t *testing.T) {
+	wrapped, err := NewItem(uuid.New(), 2, decimal.Zero).
+		WithNote("gift wrap, please").
+		WithCurrency(pricing.Currency("EUR")).
+		WithTaxCategory("books").
+		WithPricePolicy(discountPolicy{})
+	require.NoError(t, err)
+
+	plain, err := NewItem(uuid.New(), 1, decimal.Zero).
+		WithCurrency(pricing.Currency("EUR")).
+		WithPricePolicy(discountPolicy{})
+	require.NoError(t, err)
+
+	original := NewOrderState(uuid.New())
+	require.NoError(t, original.CreateOrder(context.Background(), Items{wrapped, plain}))
+	require.NoError(t, original.UpdateOrder(Items{wrapped.WithNote("leave at the door"), plain}))
+
+	// The order as the repository restores it: discounted unit price, note, currency and tax category per item
+	persisted := NewOrderStateFromPersisted(
+		original.GetOrderID(), original.GetCustomerId(),
+		Items{
+			NewItem(wrapped.GetGoodId(), 2, decimal.RequireFromString("15.00")).
+				WithNote("leave at the door").
+				WithCurrency(pricing.Currency("EUR")).
+				WithTaxCategory("books"),
+			NewItem(plain.GetGoodId(), 1, decimal.RequireFromString("15.00")).
+				WithCurrency(pricing.Currency("EUR")),
+		},
+		original.GetStatus(), original.GetVersion(), nil, common.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED, nil,
+	)
+
+	replayed, err := ReplayEvents(domainEventsAsAny(original))
+	require.NoError(t, err)
+	require.True(t, persisted.GetItems().Equal(replayed.GetItems()),
+		"replayed items %v differ from the persisted ones %v", replayed.GetItems(), persisted.GetItems())
+}
+
+func TestReplayEvents_RejectsInvalidStreams(t *testing.T) {
+	order := NewOrderState(uuid.New())
+	require.NoError(t, order.CreateOrder(context.Background(), Items{
+		NewItem(uuid.New(), 1, decimal.NewFromFloat(9.99)),
+	}))
+	require.NoError(t, order.CancelOrder())
+
+	events := domainEventsAsAny(order)
+	created := events[0]
+	cancelled := events[1]
+
+	completed := &eventsv1.OrderCompleted{
+		OrderId:          order.GetOrderID().String(),
+		CustomerId:       order.GetCustomerId().String(),
+		AggregateVersion: 1,
+	}
+
+	t.Run("empty stream", func(t *testing.T) {
+		_, err := ReplayEvents(nil)
+		require.ErrorIs(t, err, ErrReplayNoEvents)
+	})
+
+	t.Run("stream not starting with OrderCreated", func(t *testing.T) {
+		_, err := ReplayEvents([]any{cancelled})
+		require.ErrorIs(t, err, ErrReplayMissingCreated)
+	})
+
+	t.Run("complete after cancel is illegal", func(t *testing.T) {
+		_, err := ReplayEvents([]any{created, cancelled, completed})
+		require.Error(t, err)
+	})
+
+	t.Run("duplicate OrderCreated", func(t *testing.T) {
+		_, err := ReplayEvents([]any{created, created})
+		require.ErrorIs(t, err, ErrReplayDuplicateCreated)
+	})
+
+	t.Run("event from another order", func(t *testing.T) {
+		_, err := ReplayEvents([]any{created, &eventsv1.OrderCancelled{OrderId: uuid.NewString(), AggregateVersion: 1}})
+		require.ErrorIs(t, err, ErrReplayOrderMismatch)
+	})
+
+	t.Run("aggregate version goes backwards", func(t *testing.T) {
+		newer := &eventsv1.OrderCreated{
+			OrderId:          order.GetOrderID().String(),
+			CustomerId:       order.GetCustomerId().String(),
+			Items:            orderItemsToProto(order.GetItems()),
+			AggregateVersion: 3,
+		}
+		_, err := ReplayEvents([]any{newer, cancelled})
+		require.ErrorIs(t, err, ErrReplayVersionOutOfOrder)
+	})
+
+	t.Run("unsupported event", func(t *testing.T) {
+		_, err := ReplayEvents([]any{created, "not an event"})
+		require.ErrorIs(t, err, ErrReplayUnsupportedEvent)
+	})
+}
+
+func domainEventsAsAny(order *OrderState) []any {
+	events := order.GetDomainEvents()
+	out := make([]any, 0, len(events))
+
+	for _, event := range events {
+		out = append(out, event)
+	}
+
+	return out
+}
+
+func replayTestDeliveryInfo(t *testing.T) DeliveryInfo {
+	t.Helper()
+
+	pickup, err := address.NewAddress("123 Warehouse St", "Moscow", "101000", "Russia")
+	require.NoError(t, err)
+	destination, err := address.NewAddress("456 Customer St", "Moscow", "102000", "Russia")
+	require.NoError(t, err)
+
+	start := time.Now().Add(24 * time.Hour)
+
+	return NewDeliveryInfo(
+		pickup,
+		destination,
+		NewDeliveryPeriod(start, start.Add(2*time.Hour)),
+		NewPackageInfoWithDimensions(2.5, 40, 30, 20),
+		DeliveryPriorityUrgent,
+		nil,
+	)
+}
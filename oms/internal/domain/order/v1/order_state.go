@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/shortlink-org/go-sdk/fsm"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -33,16 +35,31 @@ type OrderState struct {
 	fsm *fsm.FSM
 	// domainEvents stores domain events (proto) that occurred during aggregate operations
 	domainEvents []domainevents.Event
+	// fulfillmentType records delivery vs self-pickup; UNSPECIFIED for orders that predate it
+	fulfillmentType FulfillmentType
 	// deliveryInfo contains delivery information for the order (nil = self-pickup)
 	deliveryInfo *DeliveryInfo
 	// deliveryStatus tracks the delivery status (ACCEPTED, ASSIGNED, IN_TRANSIT, etc.)
 	deliveryStatus commonv1.DeliveryStatus
+	// packageStatuses tracks each package of a split delivery; deliveryStatus is then their summary.
+	// Empty while the order ships as a single package.
+	packageStatuses map[uuid.UUID]commonv1.DeliveryStatus
 	// deliveryRequestedAt records when OMS successfully requested delivery.
 	deliveryRequestedAt *time.Time
+	// giftMessage is the customer's message for the whole order; empty when none
+	giftMessage string
+	// orderDiscount is the order-level promotion applied by the pricer on top of the item discounts
+	orderDiscount decimal.Decimal
+	// totals are the amounts the order was last priced at
+	totals OrderTotals
+	// clock supplies event timestamps; wall clock unless injected with WithClock
+	clock Clock
+	// maxLineItems caps the distinct items CreateOrder and UpdateOrder accept; see WithMaxLineItems
+	maxLineItems int
 }
 
 // NewOrderState creates a new OrderState instance with the given customer ID.
-func NewOrderState(customerId uuid.UUID) *OrderState {
+func NewOrderState(customerId uuid.UUID, opts ...Option) *OrderState {
 	return newOrderState(
 		uuid.New(),
 		customerId,
@@ -52,6 +69,7 @@ func NewOrderState(customerId uuid.UUID) *OrderState {
 		nil,
 		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
 		nil,
+		opts...,
 	)
 }
 
@@ -65,12 +83,13 @@ func NewOrderStateFromPersisted(
 	deliveryInfo *DeliveryInfo,
 	deliveryStatus commonv1.DeliveryStatus,
 	deliveryRequestedAt *time.Time,
+	opts ...Option,
 ) *OrderState {
 	if items == nil {
 		items = make(Items, 0)
 	}
 
-	return newOrderState(id, customerId, items, status, version, deliveryInfo, deliveryStatus, deliveryRequestedAt)
+	return newOrderState(id, customerId, items, status, version, deliveryInfo, deliveryStatus, deliveryRequestedAt, opts...)
 }
 
 // newOrderState is the single place that builds OrderState and configures the FSM.
@@ -82,6 +101,7 @@ func newOrderState(
 	deliveryInfo *DeliveryInfo,
 	deliveryStatus commonv1.DeliveryStatus,
 	deliveryRequestedAt *time.Time,
+	opts ...Option,
 ) *OrderState {
 	order := &OrderState{
 		id:                  id,
@@ -92,6 +112,11 @@ func newOrderState(
 		deliveryInfo:        deliveryInfo,
 		deliveryStatus:      deliveryStatus,
 		deliveryRequestedAt: cloneTimePointer(deliveryRequestedAt),
+		clock:               SystemClock{},
+		maxLineItems:        DefaultMaxOrderLineItems,
+	}
+	for _, opt := range opts {
+		opt(order)
 	}
 	order.fsm = fsm.New(fsm.State(status.String()))
 	order.addOrderTransitionRules(order.fsm)
@@ -101,31 +126,33 @@ func newOrderState(
 	return order
 }
 
-// addOrderTransitionRules registers the order FSM transition rules (single source of truth).
+// orderTransitionRule is one edge of the order FSM: Event moves an order from From to To.
+type orderTransitionRule struct {
+	From  OrderStatus
+	Event commonv1.OrderTransitionEvent
+	To    OrderStatus
+}
+
+// orderTransitionRules are the order FSM transition rules (single source of truth).
 // State = status (PENDING, PROCESSING, ...), Event = action (OrderTransitionEvent from proto).
+var orderTransitionRules = []orderTransitionRule{
+	{OrderStatus_ORDER_STATUS_PENDING, commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_CREATE, OrderStatus_ORDER_STATUS_PROCESSING},
+	{OrderStatus_ORDER_STATUS_PENDING, commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_CANCEL, OrderStatus_ORDER_STATUS_CANCELED},
+	{OrderStatus_ORDER_STATUS_PROCESSING, commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_CANCEL, OrderStatus_ORDER_STATUS_CANCELED},
+	{OrderStatus_ORDER_STATUS_PROCESSING, commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_COMPLETE, OrderStatus_ORDER_STATUS_COMPLETED},
+}
+
+// addOrderTransitionRules registers orderTransitionRules on the order FSM.
 //
 //nolint:funcorder // unexported helper; order kept for FSM setup flow
 func (o *OrderState) addOrderTransitionRules(f *fsm.FSM) {
-	f.AddTransitionRule(
-		fsm.State(OrderStatus_ORDER_STATUS_PENDING.String()),
-		fsm.Event(commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_CREATE.String()),
-		fsm.State(OrderStatus_ORDER_STATUS_PROCESSING.String()),
-	)
-	f.AddTransitionRule(
-		fsm.State(OrderStatus_ORDER_STATUS_PENDING.String()),
-		fsm.Event(commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_CANCEL.String()),
-		fsm.State(OrderStatus_ORDER_STATUS_CANCELED.String()),
-	)
-	f.AddTransitionRule(
-		fsm.State(OrderStatus_ORDER_STATUS_PROCESSING.String()),
-		fsm.Event(commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_CANCEL.String()),
-		fsm.State(OrderStatus_ORDER_STATUS_CANCELED.String()),
-	)
-	f.AddTransitionRule(
-		fsm.State(OrderStatus_ORDER_STATUS_PROCESSING.String()),
-		fsm.Event(commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_COMPLETE.String()),
-		fsm.State(OrderStatus_ORDER_STATUS_COMPLETED.String()),
-	)
+	for _, rule := range orderTransitionRules {
+		f.AddTransitionRule(
+			fsm.State(rule.From.String()),
+			fsm.Event(rule.Event.String()),
+			fsm.State(rule.To.String()),
+		)
+	}
 }
 
 // GetVersion returns the current version for optimistic concurrency control.
@@ -166,6 +193,20 @@ func (o *OrderState) GetItems() Items {
 	return itemsCopy
 }
 
+// Subtotal returns the sum of price * quantity over the order items, before item discounts,
+// the order discount and tax.
+func (o *OrderState) Subtotal() decimal.Decimal {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	subtotal := decimal.Zero
+	for _, item := range o.items {
+		subtotal = subtotal.Add(item.GetPrice().Mul(decimal.NewFromInt32(item.GetQuantity())))
+	}
+
+	return subtotal
+}
+
 // GetCustomerId returns the customer ID associated with the order.
 func (o *OrderState) GetCustomerId() uuid.UUID {
 	return o.customerId
@@ -179,15 +220,92 @@ func (o *OrderState) GetDeliveryInfo() *DeliveryInfo {
 	return o.deliveryInfo
 }
 
+// GetFulfillmentType returns how the order reaches the customer.
+// Orders without a recorded type follow the legacy rule: delivery info present means DELIVERY, otherwise PICKUP.
+func (o *OrderState) GetFulfillmentType() FulfillmentType {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.fulfillmentType != FulfillmentType_FULFILLMENT_TYPE_UNSPECIFIED {
+		return o.fulfillmentType
+	}
+
+	if o.deliveryInfo != nil {
+		return FulfillmentType_FULFILLMENT_TYPE_DELIVERY
+	}
+
+	return FulfillmentType_FULFILLMENT_TYPE_PICKUP
+}
+
+// GetRecordedFulfillmentType returns the fulfillment type recorded at checkout without the legacy
+// fallback of GetFulfillmentType; UNSPECIFIED for orders that predate it. Repositories persist this value.
+func (o *OrderState) GetRecordedFulfillmentType() FulfillmentType {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.fulfillmentType
+}
+
+// WithFulfillmentType restores a persisted fulfillment type; it was validated when it was recorded.
+func WithFulfillmentType(fulfillmentType FulfillmentType) Option {
+	return func(o *OrderState) {
+		o.fulfillmentType = fulfillmentType
+	}
+}
+
+// SetFulfillmentType records how the order reaches the customer.
+// A PICKUP order must not carry delivery info.
+func (o *OrderState) SetFulfillmentType(fulfillmentType FulfillmentType) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	switch fulfillmentType {
+	case FulfillmentType_FULFILLMENT_TYPE_DELIVERY:
+	case FulfillmentType_FULFILLMENT_TYPE_PICKUP:
+		if o.deliveryInfo != nil {
+			return ErrDeliveryInfoNotAllowedForPickup
+		}
+	default:
+		return ErrFulfillmentTypeRequired
+	}
+
+	o.fulfillmentType = fulfillmentType
+
+	return nil
+}
+
 // SetDeliveryInfo sets the delivery information for the order.
+// Info without a fee keeps the fee charged at checkout, so changing the address does not make
+// the delivery free.
 func (o *OrderState) SetDeliveryInfo(info DeliveryInfo) error {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
-	if !info.IsValid() {
+	if !info.IsValidAt(o.clock.Now()) {
 		return ErrInvalidDeliveryInfo
 	}
 
+	if err := o.checkDeliveryInfoEditableLocked(); err != nil {
+		return err
+	}
+
+	if o.deliveryInfo != nil && info.GetDeliveryFee().IsZero() {
+		info.SetDeliveryFee(o.deliveryInfo.GetDeliveryFee())
+	}
+
+	o.deliveryInfo = &info
+
+	return nil
+}
+
+// checkDeliveryInfoEditableLocked returns why the delivery info can no longer be set, or nil if it can.
+//
+//nolint:funcorder // unexported helper shared by SetDeliveryInfo and AvailableActions
+func (o *OrderState) checkDeliveryInfoEditableLocked() error {
+	if o.fulfillmentType == FulfillmentType_FULFILLMENT_TYPE_PICKUP {
+		return ErrDeliveryInfoNotAllowedForPickup
+	}
+
 	currentStatus := o.getStatusUnlocked()
 	if currentStatus == OrderStatus_ORDER_STATUS_COMPLETED ||
 		currentStatus == OrderStatus_ORDER_STATUS_CANCELED {
@@ -201,12 +319,11 @@ func (o *OrderState) SetDeliveryInfo(info DeliveryInfo) error {
 	if o.deliveryStatus == commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED ||
 		o.deliveryStatus == commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT ||
 		o.deliveryStatus == commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED ||
-		o.deliveryStatus == commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED {
+		o.deliveryStatus == commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED ||
+		o.deliveryStatus == commonv1.DeliveryStatus_DELIVERY_STATUS_PARTIALLY_DELIVERED {
 		return &DeliveryAlreadyInProgressError{DeliveryStatus: o.deliveryStatus}
 	}
 
-	o.deliveryInfo = &info
-
 	return nil
 }
 
@@ -271,7 +388,7 @@ func (o *OrderState) RequestDelivery(packageID *uuid.UUID, requestedAt time.Time
 	}
 
 	if requestedAt.IsZero() {
-		requestedAt = time.Now()
+		requestedAt = o.clock.Now()
 	}
 
 	if packageID != nil {
@@ -377,7 +494,7 @@ func (o *OrderState) ApplyDeliveryDelivered(
 		return err
 	}
 
-	ts := nonZeroEventTime(occurredAt)
+	ts := o.nonZeroEventTime(occurredAt)
 	protoTS := timestamppb.New(ts)
 	o.addDomainEvent(&eventsv1.OrderDeliveryCompletedEvent{
 		OrderId:          o.id.String(),
@@ -412,7 +529,7 @@ func (o *OrderState) ApplyDeliveryFailed(
 		return err
 	}
 
-	ts := nonZeroEventTime(occurredAt)
+	ts := o.nonZeroEventTime(occurredAt)
 	protoTS := timestamppb.New(ts)
 	o.addDomainEvent(&eventsv1.OrderDeliveryFailedEvent{
 		OrderId:             o.id.String(),
@@ -427,29 +544,89 @@ func (o *OrderState) ApplyDeliveryFailed(
 	return o.cancelOrderLocked("DELIVERY_FAILED", ts)
 }
 
-// isValidDeliveryStatusTransition checks if the delivery status transition is valid.
-// Delivery status can only move forward: UNSPECIFIED -> ACCEPTED -> ASSIGNED -> IN_TRANSIT -> DELIVERED/NOT_DELIVERED
+// CorrectDeliveryStatus moves the delivery status one step backwards for operational corrections
+// (e.g. a courier assignment was revoked and the package waits for reassignment).
+// Only whitelisted backward moves are allowed (see isValidDeliveryStatusCorrection);
+// SetDeliveryStatus and the Apply* methods stay forward-only for automated flows.
+func (o *OrderState) CorrectDeliveryStatus(status commonv1.DeliveryStatus, reason string, occurredAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	currentOrderStatus := o.getStatusUnlocked()
+	if currentOrderStatus == OrderStatus_ORDER_STATUS_COMPLETED ||
+		currentOrderStatus == OrderStatus_ORDER_STATUS_CANCELED {
+		return &OrderTerminalStateError{Status: currentOrderStatus}
+	}
+
+	if strings.TrimSpace(reason) == "" {
+		return ErrDeliveryCorrectionReasonRequired
+	}
+
+	if !o.isValidDeliveryStatusCorrection(o.deliveryStatus, status) {
+		return &InvalidDeliveryStatusTransitionError{From: o.deliveryStatus, To: status}
+	}
+
+	previousStatus := o.deliveryStatus
+	o.deliveryStatus = status
+
+	var packageID *uuid.UUID
+	if o.deliveryInfo != nil {
+		packageID = o.deliveryInfo.GetPackageId()
+	}
+
+	protoTS := timestamppb.New(o.nonZeroEventTime(occurredAt))
+	o.addDomainEvent(&eventsv1.OrderDeliveryStatusCorrectedEvent{
+		OrderId:          o.id.String(),
+		PackageId:        packageIDString(packageID),
+		PreviousStatus:   previousStatus,
+		Status:           status,
+		Reason:           reason,
+		CorrectedAt:      protoTS,
+		OccurredAt:       protoTS,
+		AggregateVersion: o.nextAggregateVersion(),
+	})
+
+	return nil
+}
+
+// isValidDeliveryStatusCorrection checks if a backward delivery status correction is whitelisted:
+// ASSIGNED -> ACCEPTED (assignment revoked) and IN_TRANSIT -> ASSIGNED (pickup rolled back).
 //
 //nolint:funcorder // unexported helper
-func (o *OrderState) isValidDeliveryStatusTransition(from, to commonv1.DeliveryStatus) bool {
-	if from == commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED {
+func (o *OrderState) isValidDeliveryStatusCorrection(from, to commonv1.DeliveryStatus) bool {
+	switch from {
+	case commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED:
 		return to == commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED
+	case commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT:
+		return to == commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED
+	default:
+		return false
 	}
+}
 
-	validTransitions := map[commonv1.DeliveryStatus][]commonv1.DeliveryStatus{
-		commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED: {
-			commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
-		},
-		commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED: {
-			commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
-		},
-		commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT: {
-			commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
-			commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED,
-		},
-	}
+// deliveryStatusTransitions are the forward delivery status moves (single source of truth).
+// Delivery status can only move forward: UNSPECIFIED -> ACCEPTED -> ASSIGNED -> IN_TRANSIT -> DELIVERED/NOT_DELIVERED
+var deliveryStatusTransitions = map[commonv1.DeliveryStatus][]commonv1.DeliveryStatus{
+	commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED: {
+		commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+	},
+	commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED: {
+		commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+	},
+	commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED: {
+		commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+	},
+	commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT: {
+		commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED,
+	},
+}
 
-	allowedTargets, exists := validTransitions[from]
+// isValidDeliveryStatusTransition checks if the delivery status transition is one of deliveryStatusTransitions.
+//
+//nolint:funcorder // unexported helper
+func (o *OrderState) isValidDeliveryStatusTransition(from, to commonv1.DeliveryStatus) bool {
+	allowedTargets, exists := deliveryStatusTransitions[from]
 	if !exists {
 		return false
 	}
@@ -490,9 +667,12 @@ func orderItemsToProto(items Items) []*commonv1.OrderItem {
 	out := make([]*commonv1.OrderItem, 0, len(items))
 	for _, it := range items {
 		out = append(out, &commonv1.OrderItem{
-			GoodId:   it.GetGoodId().String(),
-			Quantity: it.GetQuantity(),
-			Price:    it.GetPrice().String(),
+			GoodId:      it.GetGoodId().String(),
+			Quantity:    it.GetQuantity(),
+			Price:       it.GetPrice().String(),
+			Note:        it.GetNote(),
+			Currency:    string(it.GetCurrency()),
+			TaxCategory: it.GetTaxCategory(),
 		})
 	}
 
@@ -530,18 +710,14 @@ func (o *OrderState) CreateOrder(ctx context.Context, items Items) error {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
-	if err := ValidateOrderItems(items); err != nil {
+	// Covers everything ValidateOrderStateTransition checks for PROCESSING, under this order's line-item limit.
+	if err := validateOrderItems(items, o.maxLineItems); err != nil {
 		return fmt.Errorf("cannot create order: %w", err)
 	}
 
 	itemsCopy := make(Items, len(items))
 	copy(itemsCopy, items)
 
-	currentStatus := o.getStatusUnlocked()
-	if err := ValidateOrderStateTransition(currentStatus, OrderStatus_ORDER_STATUS_PROCESSING, itemsCopy); err != nil {
-		return err
-	}
-
 	err := o.fsm.TriggerEvent(ctx, fsm.Event(commonv1.OrderTransitionEvent_ORDER_TRANSITION_EVENT_CREATE.String()))
 	if err != nil {
 		return err
@@ -549,7 +725,7 @@ func (o *OrderState) CreateOrder(ctx context.Context, items Items) error {
 
 	o.items = itemsCopy
 
-	ts := timestamppb.New(time.Now())
+	ts := timestamppb.New(o.clock.Now())
 	o.addDomainEvent(&eventsv1.OrderCreated{
 		OrderId:          o.id.String(),
 		CustomerId:       o.customerId.String(),
@@ -563,7 +739,7 @@ func (o *OrderState) CreateOrder(ctx context.Context, items Items) error {
 	return nil
 }
 
-// UpdateOrder updates the order's items.
+// UpdateOrder updates the order's items and records OrderItemsUpdated when they change.
 func (o *OrderState) UpdateOrder(items Items) error {
 	o.mu.Lock()
 	defer o.mu.Unlock()
@@ -573,6 +749,12 @@ func (o *OrderState) UpdateOrder(items Items) error {
 		return &OrderTerminalStateError{Status: currentStatus}
 	}
 
+	// Reject duplicates in the input itself; merging below would otherwise silently keep the last entry.
+	err := ValidateUniqueGoods(items)
+	if err != nil {
+		return fmt.Errorf("cannot update order: %w", err)
+	}
+
 	canonical := make(map[uuid.UUID]Item, len(o.items)+len(items))
 	for _, it := range o.items {
 		canonical[it.GetGoodId()] = it
@@ -606,13 +788,31 @@ func (o *OrderState) UpdateOrder(items Items) error {
 		}
 	}
 
-	err := ValidateOrderItems(result)
+	err = validateOrderItems(result, o.maxLineItems)
 	if err != nil {
 		return fmt.Errorf("cannot update order: %w", err)
 	}
 
+	added, removed, changed := o.items.Diff(result)
+	if len(added) == 0 && len(removed) == 0 && len(changed) == 0 {
+		return nil
+	}
+
+	previous := o.items
 	o.items = result
 
+	o.addDomainEvent(&eventsv1.OrderItemsUpdated{
+		OrderId:          o.id.String(),
+		CustomerId:       o.customerId.String(),
+		PreviousItems:    orderItemsToProto(previous),
+		Items:            orderItemsToProto(result),
+		OccurredAt:       timestamppb.New(o.clock.Now()),
+		AggregateVersion: o.nextAggregateVersion(),
+		AddedItems:       orderItemsToProto(added),
+		RemovedItems:     orderItemsToProto(removed),
+		ChangedItems:     orderItemsToProto(changed),
+	})
+
 	return nil
 }
 
@@ -621,7 +821,15 @@ func (o *OrderState) CancelOrder() error {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
-	return o.cancelOrderLocked("", time.Now())
+	return o.cancelOrderLocked("", o.clock.Now())
+}
+
+// CancelOrderWithReason transitions the order to the Canceled state, recording why on OrderCancelled.
+func (o *OrderState) CancelOrderWithReason(reason string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.cancelOrderLocked(reason, o.clock.Now())
 }
 
 // CompleteOrder transitions the order to the Completed state.
@@ -629,7 +837,7 @@ func (o *OrderState) CompleteOrder() error {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
-	return o.completeOrderLocked(time.Now())
+	return o.completeOrderLocked(o.clock.Now())
 }
 
 func (o *OrderState) setDeliveryStatusLocked(status commonv1.DeliveryStatus) error {
@@ -658,7 +866,7 @@ func (o *OrderState) applyDeliveryStatusUpdatedLocked(
 		return err
 	}
 
-	ts := nonZeroEventTime(occurredAt)
+	ts := o.nonZeroEventTime(occurredAt)
 	protoTS := timestamppb.New(ts)
 	o.addDomainEvent(&eventsv1.OrderDeliveryStatusUpdatedEvent{
 		OrderId:          o.id.String(),
@@ -679,7 +887,7 @@ func (o *OrderState) cancelOrderLocked(reason string, occurredAt time.Time) erro
 		return err
 	}
 
-	ts := timestamppb.New(nonZeroEventTime(occurredAt))
+	ts := timestamppb.New(o.nonZeroEventTime(occurredAt))
 	o.addDomainEvent(&eventsv1.OrderCancelled{
 		OrderId:          o.id.String(),
 		CustomerId:       o.customerId.String(),
@@ -699,7 +907,7 @@ func (o *OrderState) completeOrderLocked(occurredAt time.Time) error {
 		return &InvalidOrderTransitionError{From: currentStatus, To: OrderStatus_ORDER_STATUS_COMPLETED}
 	}
 
-	if err := ValidateOrderItems(o.items); err != nil {
+	if err := validateOrderItems(o.items, o.maxLineItems); err != nil {
 		return fmt.Errorf("cannot complete order with invalid items: %w", err)
 	}
 
@@ -708,7 +916,7 @@ func (o *OrderState) completeOrderLocked(occurredAt time.Time) error {
 		return err
 	}
 
-	ts := timestamppb.New(nonZeroEventTime(occurredAt))
+	ts := timestamppb.New(o.nonZeroEventTime(occurredAt))
 	o.addDomainEvent(&eventsv1.OrderCompleted{
 		OrderId:          o.id.String(),
 		CustomerId:       o.customerId.String(),
@@ -766,9 +974,9 @@ func cloneTimePointer(in *time.Time) *time.Time {
 	return &cloned
 }
 
-func nonZeroEventTime(ts time.Time) time.Time {
+func (o *OrderState) nonZeroEventTime(ts time.Time) time.Time {
 	if ts.IsZero() {
-		return time.Now()
+		return o.clock.Now()
 	}
 
 	return ts
@@ -811,5 +1019,8 @@ func deliveryPeriodToProto(period DeliveryPeriod) *commonv1.DeliveryPeriod {
 func packageInfoToProto(info PackageInfo) *commonv1.PackageInfo {
 	return &commonv1.PackageInfo{
 		WeightKg: info.GetWeightKg(),
+		LengthCm: info.GetLengthCm(),
+		WidthCm:  info.GetWidthCm(),
+		HeightCm: info.GetHeightCm(),
 	}
 }
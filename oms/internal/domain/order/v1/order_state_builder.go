@@ -47,17 +47,20 @@ func (b *OrderStateBuilder) SetId(id uuid.UUID) *OrderStateBuilder {
 
 // AddItem adds an item to the order
 func (b *OrderStateBuilder) AddItem(goodId uuid.UUID, quantity int32, price decimal.Decimal) *OrderStateBuilder {
-	if goodId == uuid.Nil {
+	return b.AddOrderItem(NewItem(goodId, quantity, price))
+}
+
+// AddOrderItem adds an item carrying optional attributes (note, currency, tax category) to the order
+func (b *OrderStateBuilder) AddOrderItem(item Item) *OrderStateBuilder {
+	if item.GetGoodId() == uuid.Nil {
 		b.errors = errors.Join(b.errors, ErrInvalidGoodID)
 		return b
 	}
 
-	item := NewItem(goodId, quantity, price)
-
 	// Validate item before adding to maintain invariants
 	err := ValidateOrderItem(item)
 	if err != nil {
-		b.errors = errors.Join(b.errors, fmt.Errorf("invalid item %s: %w", goodId, err))
+		b.errors = errors.Join(b.errors, fmt.Errorf("invalid item %s: %w", item.GetGoodId(), err))
 		return b
 	}
 
@@ -66,6 +69,18 @@ func (b *OrderStateBuilder) AddItem(goodId uuid.UUID, quantity int32, price deci
 	return b
 }
 
+// SetDeliveryInfo sets the delivery information of the order.
+// Call it before SetStatus: terminal orders no longer accept delivery info.
+func (b *OrderStateBuilder) SetDeliveryInfo(info DeliveryInfo) *OrderStateBuilder {
+	err := b.orderState.SetDeliveryInfo(info)
+	if err != nil {
+		b.errors = errors.Join(b.errors, fmt.Errorf("invalid delivery info: %w", err))
+		return b
+	}
+
+	return b
+}
+
 // SetStatus sets the status of the order by playing back the sequence of events needed to reach the desired status.
 // This ensures that the FSM transitions through all required states correctly.
 // Domain layer should not depend on context.Context from application layer.
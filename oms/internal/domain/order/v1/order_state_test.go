@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	common "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	eventsv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1"
 	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
 )
 
@@ -287,6 +288,91 @@ func TestSetDeliveryInfo_OrderStatusValidation(t *testing.T) {
 		require.Error(t, err, "SetDeliveryInfo should fail in CANCELED state")
 		require.Contains(t, err.Error(), "ORDER_STATUS_CANCELED")
 	})
+
+	t.Run("KeepsDeliveryFeeWhenUpdatedWithoutOne", func(t *testing.T) {
+		order := NewOrderState(fixedCustomerID)
+		withFee := createTestDeliveryInfo(t)
+		withFee.SetDeliveryFee(decimal.RequireFromString("4.99"))
+		require.NoError(t, order.SetDeliveryInfo(withFee))
+
+		require.NoError(t, order.SetDeliveryInfo(createTestDeliveryInfo(t)))
+		require.Equal(t, "4.99", order.GetDeliveryInfo().GetDeliveryFee().StringFixed(2))
+	})
+}
+
+func TestFulfillmentType(t *testing.T) {
+	fixedCustomerID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+	t.Run("LegacyOrdersDeriveTypeFromDeliveryInfo", func(t *testing.T) {
+		order := NewOrderState(fixedCustomerID)
+		require.Equal(t, FulfillmentType_FULFILLMENT_TYPE_PICKUP, order.GetFulfillmentType())
+
+		require.NoError(t, order.SetDeliveryInfo(createTestDeliveryInfo(t)))
+		require.Equal(t, FulfillmentType_FULFILLMENT_TYPE_DELIVERY, order.GetFulfillmentType())
+	})
+
+	t.Run("PickupOrderRejectsDeliveryInfo", func(t *testing.T) {
+		order := NewOrderState(fixedCustomerID)
+		require.NoError(t, order.SetFulfillmentType(FulfillmentType_FULFILLMENT_TYPE_PICKUP))
+
+		err := order.SetDeliveryInfo(createTestDeliveryInfo(t))
+		require.ErrorIs(t, err, ErrDeliveryInfoNotAllowedForPickup)
+		require.False(t, order.HasDeliveryInfo())
+	})
+
+	t.Run("RestoredPickupOrderRejectsDeliveryInfo", func(t *testing.T) {
+		order := NewOrderState(fixedCustomerID)
+		require.NoError(t, order.SetFulfillmentType(FulfillmentType_FULFILLMENT_TYPE_PICKUP))
+
+		restored := NewOrderStateFromPersisted(
+			order.GetOrderID(), fixedCustomerID, nil, OrderStatus_ORDER_STATUS_PROCESSING, 1,
+			nil, common.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED, nil,
+			WithFulfillmentType(order.GetRecordedFulfillmentType()),
+		)
+
+		err := restored.SetDeliveryInfo(createTestDeliveryInfo(t))
+		require.ErrorIs(t, err, ErrDeliveryInfoNotAllowedForPickup)
+	})
+
+	t.Run("OrderWithDeliveryInfoCannotBecomePickup", func(t *testing.T) {
+		order := NewOrderState(fixedCustomerID)
+		require.NoError(t, order.SetDeliveryInfo(createTestDeliveryInfo(t)))
+
+		err := order.SetFulfillmentType(FulfillmentType_FULFILLMENT_TYPE_PICKUP)
+		require.ErrorIs(t, err, ErrDeliveryInfoNotAllowedForPickup)
+	})
+
+	t.Run("RejectsUnspecified", func(t *testing.T) {
+		order := NewOrderState(fixedCustomerID)
+
+		err := order.SetFulfillmentType(FulfillmentType_FULFILLMENT_TYPE_UNSPECIFIED)
+		require.ErrorIs(t, err, ErrFulfillmentTypeRequired)
+	})
+}
+
+func TestOrderState_Subtotal(t *testing.T) {
+	fixedCustomerID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+	t.Run("EmptyOrder", func(t *testing.T) {
+		order := NewOrderState(fixedCustomerID)
+
+		require.True(t, order.Subtotal().IsZero(), "an order without items has a zero subtotal")
+	})
+
+	t.Run("MultipleItemsWithFractionalPrices", func(t *testing.T) {
+		order := NewOrderState(fixedCustomerID)
+		err := order.CreateOrder(context.Background(), Items{
+			// 3 * 0.1 is 0.30000000000000004 in float64
+			NewItem(uuid.MustParse("123e4567-e89b-12d3-a456-426614174001"), 3, decimal.RequireFromString("0.10")),
+			NewItem(uuid.MustParse("123e4567-e89b-12d3-a456-426614174002"), 7, decimal.RequireFromString("19.99")),
+			NewItem(uuid.MustParse("123e4567-e89b-12d3-a456-426614174003"), 1, decimal.RequireFromString("0.01")),
+		})
+		require.NoError(t, err)
+
+		subtotal := order.Subtotal()
+		require.True(t, decimal.RequireFromString("140.24").Equal(subtotal), "got %s", subtotal)
+		require.Equal(t, "140.24", subtotal.String())
+	})
 }
 
 func TestSetDeliveryInfo_DeliveryStatusValidation(t *testing.T) {
@@ -457,3 +543,356 @@ func TestSetDeliveryStatus(t *testing.T) {
 		require.Contains(t, err.Error(), "ORDER_STATUS_CANCELED")
 	})
 }
+
+func TestCorrectDeliveryStatus(t *testing.T) {
+	fixedCustomerID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	fixedGoodID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174001")
+
+	newOrderWithDeliveryStatus := func(t *testing.T, statuses ...common.DeliveryStatus) *OrderState {
+		t.Helper()
+
+		order := NewOrderState(fixedCustomerID)
+		items := Items{NewItem(fixedGoodID, 1, decimal.NewFromFloat(10.00))}
+		require.NoError(t, order.CreateOrder(context.Background(), items))
+
+		for _, status := range statuses {
+			require.NoError(t, order.SetDeliveryStatus(status))
+		}
+
+		order.ClearDomainEvents()
+
+		return order
+	}
+
+	t.Run("SetDeliveryStatusStillRejectsBackwardMove", func(t *testing.T) {
+		order := newOrderWithDeliveryStatus(t,
+			common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+			common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+		)
+
+		err := order.SetDeliveryStatus(common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED)
+
+		var transitionErr *InvalidDeliveryStatusTransitionError
+		require.ErrorAs(t, err, &transitionErr)
+		require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED, order.GetDeliveryStatus())
+	})
+
+	t.Run("AllowsAssignedToAccepted", func(t *testing.T) {
+		order := newOrderWithDeliveryStatus(t,
+			common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+			common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+		)
+		correctedAt := time.Date(2026, time.March, 11, 10, 5, 0, 0, time.UTC)
+
+		err := order.CorrectDeliveryStatus(common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED, "courier assignment revoked", correctedAt)
+		require.NoError(t, err)
+		require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED, order.GetDeliveryStatus())
+
+		events := order.GetDomainEvents()
+		require.Len(t, events, 1)
+
+		corrected, ok := events[0].(*eventsv1.OrderDeliveryStatusCorrectedEvent)
+		require.True(t, ok, "expected OrderDeliveryStatusCorrectedEvent, got %T", events[0])
+		require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED, corrected.GetPreviousStatus())
+		require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED, corrected.GetStatus())
+		require.Equal(t, "courier assignment revoked", corrected.GetReason())
+		require.True(t, correctedAt.Equal(corrected.GetCorrectedAt().AsTime()))
+
+		// Forward flow resumes after the correction (reassignment).
+		require.NoError(t, order.SetDeliveryStatus(common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED))
+	})
+
+	t.Run("AllowsInTransitToAssigned", func(t *testing.T) {
+		order := newOrderWithDeliveryStatus(t,
+			common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+			common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+			common.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+		)
+
+		err := order.CorrectDeliveryStatus(common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED, "pickup rolled back", time.Now())
+		require.NoError(t, err)
+		require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED, order.GetDeliveryStatus())
+	})
+
+	t.Run("BlocksNonWhitelistedCorrections", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			statuses []common.DeliveryStatus
+			target   common.DeliveryStatus
+		}{
+			{
+				name:     "ForwardMove",
+				statuses: []common.DeliveryStatus{common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED},
+				target:   common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+			},
+			{
+				name: "InTransitToAccepted",
+				statuses: []common.DeliveryStatus{
+					common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+					common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+					common.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+				},
+				target: common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+			},
+			{
+				name:     "AcceptedToUnspecified",
+				statuses: []common.DeliveryStatus{common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED},
+				target:   common.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				order := newOrderWithDeliveryStatus(t, tc.statuses...)
+				before := order.GetDeliveryStatus()
+
+				err := order.CorrectDeliveryStatus(tc.target, "manual fix", time.Now())
+
+				var transitionErr *InvalidDeliveryStatusTransitionError
+				require.ErrorAs(t, err, &transitionErr)
+				require.Equal(t, before, order.GetDeliveryStatus())
+				require.Empty(t, order.GetDomainEvents())
+			})
+		}
+	})
+
+	t.Run("RequiresReason", func(t *testing.T) {
+		order := newOrderWithDeliveryStatus(t,
+			common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+			common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+		)
+
+		err := order.CorrectDeliveryStatus(common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED, " ", time.Now())
+		require.ErrorIs(t, err, ErrDeliveryCorrectionReasonRequired)
+		require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED, order.GetDeliveryStatus())
+	})
+
+	t.Run("BlocksCorrectionInTerminalOrder", func(t *testing.T) {
+		order := newOrderWithDeliveryStatus(t)
+		require.NoError(t, order.CancelOrder())
+
+		err := order.CorrectDeliveryStatus(common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED, "manual fix", time.Now())
+
+		var terminalErr *OrderTerminalStateError
+		require.ErrorAs(t, err, &terminalErr)
+	})
+}
+
+func TestOrderState_UpdateOrderRecordsItemsUpdated(t *testing.T) {
+	goodID1 := uuid.New()
+	goodID2 := uuid.New()
+	clock := FixedClock{Time: time.Date(2026, time.March, 11, 10, 0, 0, 0, time.UTC)}
+
+	newCreatedOrder := func(t *testing.T) *OrderState {
+		t.Helper()
+
+		orderState := NewOrderState(uuid.New(), WithClock(clock))
+		require.NoError(t, orderState.CreateOrder(context.Background(), Items{
+			NewItem(goodID1, 2, decimal.NewFromFloat(19.99)),
+		}))
+
+		return orderState
+	}
+
+	itemsUpdatedEvents := func(orderState *OrderState) []*eventsv1.OrderItemsUpdated {
+		var out []*eventsv1.OrderItemsUpdated
+
+		for _, event := range orderState.GetDomainEvents() {
+			if updated, ok := event.(*eventsv1.OrderItemsUpdated); ok {
+				out = append(out, updated)
+			}
+		}
+
+		return out
+	}
+
+	t.Run("adding an item", func(t *testing.T) {
+		orderState := newCreatedOrder(t)
+
+		require.NoError(t, orderState.UpdateOrder(Items{NewItem(goodID2, 1, decimal.NewFromFloat(9.99))}))
+
+		events := itemsUpdatedEvents(orderState)
+		require.Len(t, events, 1)
+		require.Equal(t, orderState.GetOrderID().String(), events[0].GetOrderId())
+		require.Equal(t, orderState.GetCustomerId().String(), events[0].GetCustomerId())
+		require.Len(t, events[0].GetPreviousItems(), 1)
+		require.Len(t, events[0].GetItems(), 2)
+		require.Equal(t, goodID2.String(), events[0].GetItems()[1].GetGoodId())
+		require.Equal(t, clock.Now(), events[0].GetOccurredAt().AsTime())
+		require.Len(t, events[0].GetAddedItems(), 1)
+		require.Equal(t, goodID2.String(), events[0].GetAddedItems()[0].GetGoodId())
+		require.Empty(t, events[0].GetRemovedItems())
+		require.Empty(t, events[0].GetChangedItems())
+	})
+
+	t.Run("modifying an item", func(t *testing.T) {
+		orderState := newCreatedOrder(t)
+
+		require.NoError(t, orderState.UpdateOrder(Items{NewItem(goodID1, 5, decimal.NewFromFloat(19.99))}))
+
+		events := itemsUpdatedEvents(orderState)
+		require.Len(t, events, 1)
+		require.Equal(t, int32(2), events[0].GetPreviousItems()[0].GetQuantity())
+		require.Equal(t, int32(5), events[0].GetItems()[0].GetQuantity())
+		require.Empty(t, events[0].GetAddedItems())
+		require.Len(t, events[0].GetChangedItems(), 1)
+		require.Equal(t, int32(5), events[0].GetChangedItems()[0].GetQuantity())
+	})
+
+	t.Run("no-op update", func(t *testing.T) {
+		orderState := newCreatedOrder(t)
+
+		require.NoError(t, orderState.UpdateOrder(Items{NewItem(goodID1, 2, decimal.RequireFromString("19.990"))}))
+		require.NoError(t, orderState.UpdateOrder(nil))
+
+		require.Empty(t, itemsUpdatedEvents(orderState))
+	})
+}
+
+func TestOrderState_RejectsDuplicateGoods(t *testing.T) {
+	goodID1 := uuid.New()
+	goodID2 := uuid.New()
+
+	duplicates := Items{
+		NewItem(goodID1, 1, decimal.NewFromFloat(19.99)),
+		NewItem(goodID2, 1, decimal.NewFromFloat(9.99)),
+		NewItem(goodID1, 2, decimal.NewFromFloat(19.99)),
+	}
+
+	t.Run("CreateOrder", func(t *testing.T) {
+		orderState := NewOrderState(uuid.New())
+
+		err := orderState.CreateOrder(context.Background(), duplicates)
+		require.ErrorIs(t, err, ErrOrderItemsDuplicate)
+		require.Equal(t, OrderStatus_ORDER_STATUS_PENDING, orderState.GetStatus())
+		require.Empty(t, orderState.GetDomainEvents())
+	})
+
+	t.Run("UpdateOrder", func(t *testing.T) {
+		orderState := NewOrderState(uuid.New())
+		original := Items{NewItem(goodID1, 1, decimal.NewFromFloat(19.99))}
+		require.NoError(t, orderState.CreateOrder(context.Background(), original))
+		orderState.ClearDomainEvents()
+
+		err := orderState.UpdateOrder(duplicates)
+		require.ErrorIs(t, err, ErrOrderItemsDuplicate)
+		require.Equal(t, original, orderState.GetItems())
+		require.Empty(t, orderState.GetDomainEvents())
+	})
+}
+
+func TestOrderState_MaxLineItems(t *testing.T) {
+	newItems := func(n int) Items {
+		items := make(Items, 0, n)
+		for range n {
+			items = append(items, NewItem(uuid.New(), 1, decimal.NewFromFloat(9.99)))
+		}
+
+		return items
+	}
+
+	requireLineItemsExceeded := func(t *testing.T, err error, count, limit int) {
+		t.Helper()
+
+		require.ErrorIs(t, err, ErrOrderTotalItemsExceeded)
+
+		var exceededErr *OrderLineItemsExceededError
+		require.ErrorAs(t, err, &exceededErr)
+		require.Equal(t, count, exceededErr.Count)
+		require.Equal(t, limit, exceededErr.Max)
+	}
+
+	t.Run("default limit", func(t *testing.T) {
+		require.NoError(t, NewOrderState(uuid.New()).CreateOrder(context.Background(), newItems(DefaultMaxOrderLineItems)))
+
+		err := NewOrderState(uuid.New()).CreateOrder(context.Background(), newItems(DefaultMaxOrderLineItems+1))
+		requireLineItemsExceeded(t, err, DefaultMaxOrderLineItems+1, DefaultMaxOrderLineItems)
+	})
+
+	t.Run("configured limit on CreateOrder", func(t *testing.T) {
+		require.NoError(t, NewOrderState(uuid.New(), WithMaxLineItems(3)).CreateOrder(context.Background(), newItems(3)))
+
+		err := NewOrderState(uuid.New(), WithMaxLineItems(3)).CreateOrder(context.Background(), newItems(4))
+		requireLineItemsExceeded(t, err, 4, 3)
+	})
+
+	t.Run("configured limit on UpdateOrder", func(t *testing.T) {
+		orderState := NewOrderState(uuid.New(), WithMaxLineItems(3))
+		require.NoError(t, orderState.CreateOrder(context.Background(), newItems(2)))
+
+		require.NoError(t, orderState.UpdateOrder(newItems(1)))
+		require.Len(t, orderState.GetItems(), 3)
+
+		err := orderState.UpdateOrder(newItems(1))
+		requireLineItemsExceeded(t, err, 4, 3)
+		require.Len(t, orderState.GetItems(), 3)
+	})
+
+	t.Run("limit above the default", func(t *testing.T) {
+		orderState := NewOrderState(uuid.New(), WithMaxLineItems(DefaultMaxOrderLineItems+10))
+
+		require.NoError(t, orderState.CreateOrder(context.Background(), newItems(DefaultMaxOrderLineItems+10)))
+		require.NoError(t, orderState.CompleteOrder())
+	})
+
+	t.Run("non-positive limit keeps the default", func(t *testing.T) {
+		err := NewOrderState(uuid.New(), WithMaxLineItems(0)).CreateOrder(context.Background(), newItems(DefaultMaxOrderLineItems+1))
+		requireLineItemsExceeded(t, err, DefaultMaxOrderLineItems+1, DefaultMaxOrderLineItems)
+	})
+}
+
+func TestOrderState_EventTimestampsUseClock(t *testing.T) {
+	clock := FixedClock{Time: time.Date(2026, time.March, 11, 10, 0, 0, 0, time.UTC)}
+	items := Items{NewItem(uuid.New(), 1, decimal.NewFromFloat(9.99))}
+
+	t.Run("create and complete", func(t *testing.T) {
+		orderState := NewOrderState(uuid.New(), WithClock(clock))
+		require.NoError(t, orderState.CreateOrder(context.Background(), items))
+		require.NoError(t, orderState.CompleteOrder())
+
+		events := orderState.GetDomainEvents()
+		require.Len(t, events, 2)
+
+		created, ok := events[0].(*eventsv1.OrderCreated)
+		require.True(t, ok)
+		require.Equal(t, clock.Now(), created.GetOccurredAt().AsTime())
+		require.Equal(t, clock.Now(), created.GetCreatedAt().AsTime())
+
+		completed, ok := events[1].(*eventsv1.OrderCompleted)
+		require.True(t, ok)
+		require.Equal(t, clock.Now(), completed.GetOccurredAt().AsTime())
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		orderState := NewOrderState(uuid.New(), WithClock(clock))
+		require.NoError(t, orderState.CreateOrder(context.Background(), items))
+		require.NoError(t, orderState.CancelOrder())
+
+		cancelled, ok := orderState.GetDomainEvents()[1].(*eventsv1.OrderCancelled)
+		require.True(t, ok)
+		require.Equal(t, clock.Now(), cancelled.GetOccurredAt().AsTime())
+	})
+
+	t.Run("zero occurredAt falls back to the clock", func(t *testing.T) {
+		orderState := NewOrderState(uuid.New(), WithClock(clock))
+		require.NoError(t, orderState.SetDeliveryInfo(replayTestDeliveryInfo(t)))
+		require.NoError(t, orderState.CreateOrder(context.Background(), items))
+
+		packageID := uuid.New()
+		require.NoError(t, orderState.RequestDelivery(&packageID, time.Time{}))
+		require.NoError(t, orderState.ApplyDeliveryAccepted(&packageID, time.Time{}))
+
+		requestedAt := orderState.GetDeliveryRequestedAt()
+		require.NotNil(t, requestedAt)
+		require.Equal(t, clock.Now(), *requestedAt)
+
+		events := orderState.GetDomainEvents()
+		requested, ok := events[1].(*eventsv1.OrderDeliveryRequestedEvent)
+		require.True(t, ok)
+		require.Equal(t, clock.Now(), requested.GetOccurredAt().AsTime())
+
+		accepted, ok := events[2].(*eventsv1.OrderDeliveryStatusUpdatedEvent)
+		require.True(t, ok)
+		require.Equal(t, clock.Now(), accepted.GetOccurredAt().AsTime())
+	})
+}
@@ -0,0 +1,43 @@
+package v1
+
+import "github.com/shopspring/decimal"
+
+// OrderTotals are the amounts the order was last priced at.
+// Zero for orders priced before the totals were recorded.
+type OrderTotals struct {
+	// Subtotal is the sum of the item prices before discounts and tax
+	Subtotal decimal.Decimal
+	// TotalTax is the tax charged on the order
+	TotalTax decimal.Decimal
+	// FinalPrice is what the customer is charged for the items
+	FinalPrice decimal.Decimal
+}
+
+// GetTotals returns the amounts the order was last priced at.
+func (o *OrderState) GetTotals() OrderTotals {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.totals
+}
+
+// SetTotals records the amounts the order was priced at; checkout and item changes set them.
+func (o *OrderState) SetTotals(totals OrderTotals) error {
+	if totals.Subtotal.IsNegative() || totals.TotalTax.IsNegative() || totals.FinalPrice.IsNegative() {
+		return ErrOrderTotalsNegative
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.totals = totals
+
+	return nil
+}
+
+// WithTotals restores persisted order totals; they were validated when they were set.
+func WithTotals(totals OrderTotals) Option {
+	return func(o *OrderState) {
+		o.totals = totals
+	}
+}
@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderState_SetTotals(t *testing.T) {
+	order := NewOrderState(uuid.New())
+	require.True(t, order.GetTotals().FinalPrice.IsZero())
+
+	totals := OrderTotals{
+		Subtotal:   decimal.RequireFromString("100.00"),
+		TotalTax:   decimal.RequireFromString("8.00"),
+		FinalPrice: decimal.RequireFromString("98.00"),
+	}
+	require.NoError(t, order.SetTotals(totals))
+
+	err := order.SetTotals(OrderTotals{Subtotal: decimal.NewFromInt(-1)})
+	require.ErrorIs(t, err, ErrOrderTotalsNegative)
+	require.Equal(t, totals, order.GetTotals(), "rejected totals must not replace the current ones")
+
+	restored := NewOrderStateFromPersisted(
+		order.GetOrderID(), order.GetCustomerId(), nil,
+		OrderStatus_ORDER_STATUS_PENDING, 1, nil, 0, nil,
+		WithTotals(totals),
+	)
+	require.Equal(t, totals, restored.GetTotals())
+}
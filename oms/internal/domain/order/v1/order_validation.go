@@ -3,6 +3,7 @@ package v1
 import (
 	"errors"
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -19,32 +20,60 @@ var (
 	ErrOrderTotalItemsExceeded     = errors.New("total order items count exceeds maximum allowed")
 	ErrOrderItemsDuplicate         = errors.New("order contains duplicate items")
 	ErrOrderInvalidStateTransition = errors.New("invalid state transition for order")
+	ErrOrderItemNoteTooLong        = errors.New("order item note is too long")
+	ErrOrderGiftMessageTooLong     = errors.New("order gift message is too long")
+	ErrOrderDiscountNegative       = errors.New("order discount cannot be negative")
+	ErrOrderTotalsNegative         = errors.New("order totals cannot be negative")
 )
 
 // Order invariants constants
 const (
 	// MaxOrderWeightKg is the maximum total weight allowed for an order in kilograms
 	MaxOrderWeightKg = 500.0
-	// MaxOrderItems is the maximum number of distinct items allowed in an order
-	MaxOrderItems = 100
+	// DefaultMaxOrderLineItems is the maximum number of distinct items allowed in an order unless
+	// the order is built with WithMaxLineItems
+	DefaultMaxOrderLineItems = 100
 	// MinOrderItems is the minimum number of items required in an order
 	MinOrderItems = 1
+	// MaxItemNoteLength is the maximum length of an order item note, in characters
+	MaxItemNoteLength = 500
+	// MaxGiftMessageLength is the maximum length of an order gift message, in characters
+	MaxGiftMessageLength = 1000
 )
 
-// ValidateOrderItems validates that order items meet all business rules and invariants.
+// WithMaxLineItems sets how many distinct items CreateOrder and UpdateOrder accept.
+// Non-positive limits keep DefaultMaxOrderLineItems.
+func WithMaxLineItems(limit int) Option {
+	return func(o *OrderState) {
+		if limit > 0 {
+			o.maxLineItems = limit
+		}
+	}
+}
+
+// ValidateOrderItems validates that order items meet all business rules and invariants,
+// allowing at most DefaultMaxOrderLineItems distinct items.
 func ValidateOrderItems(items Items) error {
+	return validateOrderItems(items, DefaultMaxOrderLineItems)
+}
+
+// validateOrderItems validates order items, allowing at most maxLineItems distinct items.
+func validateOrderItems(items Items, maxLineItems int) error {
 	if len(items) == 0 {
 		return ErrOrderItemsEmpty
 	}
 
-	if len(items) > MaxOrderItems {
-		return fmt.Errorf("%w: %d items, maximum is %d", ErrOrderTotalItemsExceeded, len(items), MaxOrderItems)
+	if len(items) > maxLineItems {
+		return &OrderLineItemsExceededError{Count: len(items), Max: maxLineItems}
 	}
 
-	// Track seen good IDs to detect duplicates
-	seenGoodIds := make(map[string]bool)
 	totalWeight := 0.0
 
+	err := ValidateUniqueGoods(items)
+	if err != nil {
+		return err
+	}
+
 	for i, item := range items {
 		// Validate individual item
 		err := ValidateOrderItem(item)
@@ -52,14 +81,6 @@ func ValidateOrderItems(items Items) error {
 			return fmt.Errorf("item %d: %w", i, err)
 		}
 
-		// Check for duplicate items
-		goodIdStr := item.GetGoodId().String()
-		if seenGoodIds[goodIdStr] {
-			return fmt.Errorf("%w: good ID %s appears multiple times", ErrOrderItemsDuplicate, goodIdStr)
-		}
-
-		seenGoodIds[goodIdStr] = true
-
 		// Note: We don't have weight per item in the current Item model
 		// This would need to be added if we want to validate total weight
 		// For now, we validate structure only
@@ -69,6 +90,23 @@ func ValidateOrderItems(items Items) error {
 	return nil
 }
 
+// ValidateUniqueGoods rejects items that list the same good more than once.
+// Each good must appear once with its total quantity, so no caller has to guess how to merge entries.
+func ValidateUniqueGoods(items Items) error {
+	seenGoodIds := make(map[uuid.UUID]bool, len(items))
+
+	for _, item := range items {
+		goodId := item.GetGoodId()
+		if seenGoodIds[goodId] {
+			return fmt.Errorf("%w: good ID %s appears multiple times", ErrOrderItemsDuplicate, goodId)
+		}
+
+		seenGoodIds[goodId] = true
+	}
+
+	return nil
+}
+
 // ValidateOrderItem validates a single order item.
 func ValidateOrderItem(item Item) error {
 	if item.GetGoodId() == uuid.Nil {
@@ -88,6 +126,19 @@ func ValidateOrderItem(item Item) error {
 		return ErrOrderItemPriceZero
 	}
 
+	if utf8.RuneCountInString(item.GetNote()) > MaxItemNoteLength {
+		return fmt.Errorf("%w: maximum is %d characters", ErrOrderItemNoteTooLong, MaxItemNoteLength)
+	}
+
+	return nil
+}
+
+// ValidateGiftMessage validates the length of an order gift message.
+func ValidateGiftMessage(message string) error {
+	if utf8.RuneCountInString(message) > MaxGiftMessageLength {
+		return fmt.Errorf("%w: maximum is %d characters", ErrOrderGiftMessageTooLong, MaxGiftMessageLength)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,209 @@
+package v1
+
+import (
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	eventsv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1"
+)
+
+// WithPackageDeliveryStatuses restores the persisted per-package delivery statuses of a split delivery.
+func WithPackageDeliveryStatuses(statuses map[uuid.UUID]commonv1.DeliveryStatus) Option {
+	return func(o *OrderState) {
+		if len(statuses) > 0 {
+			o.packageStatuses = maps.Clone(statuses)
+		}
+	}
+}
+
+// GetPackageDeliveryStatuses returns the delivery status of every package of a split delivery.
+// Empty while the order ships as a single package; GetDeliveryStatus covers that case.
+func (o *OrderState) GetPackageDeliveryStatuses() map[uuid.UUID]commonv1.DeliveryStatus {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return maps.Clone(o.packageStatuses)
+}
+
+// GetPackageDeliveryStatus returns the delivery status of one package.
+// For an order shipping as a single package this is the order's delivery status.
+func (o *OrderState) GetPackageDeliveryStatus(packageID uuid.UUID) commonv1.DeliveryStatus {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.routesByPackageLocked(packageID) {
+		return o.deliveryStatus
+	}
+
+	if status, ok := o.packageStatuses[packageID]; ok {
+		return status
+	}
+
+	if o.isPrimaryPackageLocked(packageID) {
+		return o.deliveryStatus
+	}
+
+	return commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED
+}
+
+// RoutesByPackage reports whether a delivery update for packageID must go through SetPackageDeliveryStatus:
+// the order is already tracked per package, or packageID is a second package next to the requested one.
+func (o *OrderState) RoutesByPackage(packageID uuid.UUID) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.routesByPackageLocked(packageID)
+}
+
+// SetPackageDeliveryStatus moves one package of a split delivery forward and re-derives the order's
+// delivery status from all packages (see summarizeDeliveryStatus). Packages become known as their
+// first update arrives; the order completes once every known package has an outcome and at least
+// one was delivered, and is canceled when none was.
+func (o *OrderState) SetPackageDeliveryStatus(packageID uuid.UUID, status commonv1.DeliveryStatus, occurredAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if err := o.setPackageDeliveryStatusLocked(packageID, status); err != nil {
+		return err
+	}
+
+	ts := o.nonZeroEventTime(occurredAt)
+	protoTS := timestamppb.New(ts)
+	o.addDomainEvent(&eventsv1.OrderDeliveryStatusUpdatedEvent{
+		OrderId:          o.id.String(),
+		PackageId:        packageID.String(),
+		Status:           status,
+		UpdatedAt:        protoTS,
+		OccurredAt:       protoTS,
+		AggregateVersion: o.nextAggregateVersion(),
+	})
+
+	if !o.allPackagesSettledLocked() {
+		return nil
+	}
+
+	if o.deliveryStatus == commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED {
+		return o.cancelOrderLocked("DELIVERY_FAILED", ts)
+	}
+
+	return o.completeOrderLocked(ts)
+}
+
+// setPackageDeliveryStatusLocked validates and stores a package status and re-derives the summary.
+//
+//nolint:funcorder // unexported helper shared with ReplayEvents
+func (o *OrderState) setPackageDeliveryStatusLocked(packageID uuid.UUID, status commonv1.DeliveryStatus) error {
+	if err := o.ensureDeliveryInfoLocked(); err != nil {
+		return err
+	}
+
+	if packageID == uuid.Nil {
+		return ErrPackageIDRequired
+	}
+
+	currentOrderStatus := o.getStatusUnlocked()
+	if currentOrderStatus == OrderStatus_ORDER_STATUS_COMPLETED ||
+		currentOrderStatus == OrderStatus_ORDER_STATUS_CANCELED {
+		return &OrderTerminalStateError{Status: currentOrderStatus}
+	}
+
+	statuses := o.packageStatuses
+	if statuses == nil {
+		statuses = make(map[uuid.UUID]commonv1.DeliveryStatus)
+
+		// Seed the requested package with the status it reached while the order shipped as one.
+		if primary := o.deliveryInfo.GetPackageId(); primary != nil {
+			statuses[*primary] = o.deliveryStatus
+		}
+	}
+
+	current := statuses[packageID]
+	if !o.isValidDeliveryStatusTransition(current, status) {
+		return &InvalidDeliveryStatusTransitionError{From: current, To: status}
+	}
+
+	statuses[packageID] = status
+	o.packageStatuses = statuses
+	o.deliveryStatus = summarizeDeliveryStatus(statuses)
+
+	return nil
+}
+
+//nolint:funcorder // unexported helper
+func (o *OrderState) routesByPackageLocked(packageID uuid.UUID) bool {
+	if len(o.packageStatuses) > 0 {
+		return true
+	}
+
+	if packageID == uuid.Nil || o.deliveryInfo == nil {
+		return false
+	}
+
+	primary := o.deliveryInfo.GetPackageId()
+
+	return primary != nil && *primary != packageID
+}
+
+//nolint:funcorder // unexported helper
+func (o *OrderState) isPrimaryPackageLocked(packageID uuid.UUID) bool {
+	if o.deliveryInfo == nil {
+		return false
+	}
+
+	primary := o.deliveryInfo.GetPackageId()
+
+	return primary != nil && *primary == packageID
+}
+
+//nolint:funcorder // unexported helper
+func (o *OrderState) allPackagesSettledLocked() bool {
+	for _, status := range o.packageStatuses {
+		if status != commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED &&
+			status != commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED {
+			return false
+		}
+	}
+
+	return true
+}
+
+// summarizeDeliveryStatus derives the order's delivery status from its packages:
+// DELIVERED or NOT_DELIVERED when every package ended the same way, PARTIALLY_DELIVERED once
+// some but not all packages were delivered, otherwise the least advanced package still on the way.
+func summarizeDeliveryStatus(statuses map[uuid.UUID]commonv1.DeliveryStatus) commonv1.DeliveryStatus {
+	delivered, failed := 0, 0
+	leastAdvanced := commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED
+	inFlight := false
+
+	for _, status := range statuses {
+		switch status {
+		case commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED:
+			delivered++
+		case commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED:
+			failed++
+		default:
+			if !inFlight || status < leastAdvanced {
+				leastAdvanced = status
+			}
+
+			inFlight = true
+		}
+	}
+
+	switch {
+	case len(statuses) == 0:
+		return commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED
+	case delivered == len(statuses):
+		return commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED
+	case failed == len(statuses):
+		return commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED
+	case delivered > 0:
+		return commonv1.DeliveryStatus_DELIVERY_STATUS_PARTIALLY_DELIVERED
+	default:
+		return leastAdvanced
+	}
+}
@@ -0,0 +1,198 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	common "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+)
+
+// newSplitDeliveryOrder returns an order whose delivery was requested as firstPackageID.
+func newSplitDeliveryOrder(t *testing.T, firstPackageID uuid.UUID) *OrderState {
+	t.Helper()
+
+	order := NewOrderState(uuid.New())
+	require.NoError(t, order.SetDeliveryInfo(createTestDeliveryInfo(t)))
+	require.NoError(t, order.CreateOrder(context.Background(), Items{
+		NewItem(uuid.New(), 1, decimal.NewFromFloat(10.00)),
+		NewItem(uuid.New(), 2, decimal.NewFromFloat(5.00)),
+	}))
+	require.NoError(t, order.RequestDelivery(&firstPackageID, time.Now()))
+
+	return order
+}
+
+func advancePackage(t *testing.T, order *OrderState, packageID uuid.UUID, statuses ...common.DeliveryStatus) {
+	t.Helper()
+
+	for _, status := range statuses {
+		require.NoError(t, order.SetPackageDeliveryStatus(packageID, status, time.Time{}))
+	}
+}
+
+func TestSetPackageDeliveryStatus(t *testing.T) {
+	inTransit := []common.DeliveryStatus{
+		common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+		common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+		common.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+	}
+
+	t.Run("MixedOutcomesArePartiallyDelivered", func(t *testing.T) {
+		first, second := uuid.New(), uuid.New()
+		order := newSplitDeliveryOrder(t, first)
+
+		require.False(t, order.RoutesByPackage(first))
+		require.True(t, order.RoutesByPackage(second))
+
+		advancePackage(t, order, first, inTransit...)
+		require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT, order.GetDeliveryStatus())
+
+		// The summary follows the package that is furthest behind.
+		advancePackage(t, order, second, common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED)
+		require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED, order.GetDeliveryStatus())
+		require.True(t, order.RoutesByPackage(first))
+
+		advancePackage(t, order, second, inTransit[1:]...)
+		advancePackage(t, order, first, common.DeliveryStatus_DELIVERY_STATUS_DELIVERED)
+		require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_PARTIALLY_DELIVERED, order.GetDeliveryStatus())
+		require.Equal(t, OrderStatus_ORDER_STATUS_PROCESSING, order.GetStatus())
+
+		advancePackage(t, order, second, common.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED)
+		require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_PARTIALLY_DELIVERED, order.GetDeliveryStatus())
+		require.Equal(t, OrderStatus_ORDER_STATUS_COMPLETED, order.GetStatus())
+
+		require.Equal(t, map[uuid.UUID]common.DeliveryStatus{
+			first:  common.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+			second: common.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED,
+		}, order.GetPackageDeliveryStatuses())
+		require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED, order.GetPackageDeliveryStatus(second))
+	})
+
+	t.Run("AllPackagesFailedCancelsOrder", func(t *testing.T) {
+		first, second := uuid.New(), uuid.New()
+		order := newSplitDeliveryOrder(t, first)
+
+		advancePackage(t, order, first, inTransit...)
+		advancePackage(t, order, second, inTransit...)
+		advancePackage(t, order, first, common.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED)
+		require.Equal(t, OrderStatus_ORDER_STATUS_PROCESSING, order.GetStatus())
+
+		advancePackage(t, order, second, common.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED)
+
+		require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED, order.GetDeliveryStatus())
+		require.Equal(t, OrderStatus_ORDER_STATUS_CANCELED, order.GetStatus())
+	})
+
+	t.Run("SeedsRequestedPackageWithItsStatus", func(t *testing.T) {
+		first, second := uuid.New(), uuid.New()
+		order := newSplitDeliveryOrder(t, first)
+
+		require.NoError(t, order.ApplyDeliveryAccepted(&first, time.Time{}))
+		advancePackage(t, order, second, common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED)
+
+		require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED, order.GetPackageDeliveryStatus(first))
+		advancePackage(t, order, first, common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED)
+	})
+
+	t.Run("BlocksInvalidPackageTransition", func(t *testing.T) {
+		order := newSplitDeliveryOrder(t, uuid.New())
+
+		err := order.SetPackageDeliveryStatus(uuid.New(), common.DeliveryStatus_DELIVERY_STATUS_DELIVERED, time.Time{})
+
+		var transitionErr *InvalidDeliveryStatusTransitionError
+		require.ErrorAs(t, err, &transitionErr)
+		require.Empty(t, order.GetPackageDeliveryStatuses())
+	})
+
+	t.Run("RequiresPackageID", func(t *testing.T) {
+		order := newSplitDeliveryOrder(t, uuid.New())
+
+		err := order.SetPackageDeliveryStatus(uuid.Nil, common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED, time.Time{})
+		require.ErrorIs(t, err, ErrPackageIDRequired)
+	})
+}
+
+func TestSummarizeDeliveryStatus(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name     string
+		statuses map[uuid.UUID]common.DeliveryStatus
+		expected common.DeliveryStatus
+	}{
+		{
+			name:     "no packages",
+			expected: common.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+		},
+		{
+			name: "all delivered",
+			statuses: map[uuid.UUID]common.DeliveryStatus{
+				a: common.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+				b: common.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+			},
+			expected: common.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+		},
+		{
+			name: "delivered and still in transit",
+			statuses: map[uuid.UUID]common.DeliveryStatus{
+				a: common.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+				b: common.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+			},
+			expected: common.DeliveryStatus_DELIVERY_STATUS_PARTIALLY_DELIVERED,
+		},
+		{
+			name: "failed and still in transit",
+			statuses: map[uuid.UUID]common.DeliveryStatus{
+				a: common.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED,
+				b: common.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+			},
+			expected: common.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+		},
+		{
+			name: "all failed",
+			statuses: map[uuid.UUID]common.DeliveryStatus{
+				a: common.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED,
+				b: common.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED,
+			},
+			expected: common.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED,
+		},
+		{
+			name: "least advanced package wins",
+			statuses: map[uuid.UUID]common.DeliveryStatus{
+				a: common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+				b: common.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+			},
+			expected: common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, summarizeDeliveryStatus(tc.statuses))
+		})
+	}
+}
+
+func TestReplayEvents_SplitDelivery(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	original := newSplitDeliveryOrder(t, first)
+
+	advancePackage(t, original, first,
+		common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+		common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+		common.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+	)
+	advancePackage(t, original, second, common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED)
+	advancePackage(t, original, first, common.DeliveryStatus_DELIVERY_STATUS_DELIVERED)
+
+	replayed, err := ReplayEvents(domainEventsAsAny(original))
+	require.NoError(t, err)
+
+	require.Equal(t, common.DeliveryStatus_DELIVERY_STATUS_PARTIALLY_DELIVERED, replayed.GetDeliveryStatus())
+	require.Equal(t, original.GetPackageDeliveryStatuses(), replayed.GetPackageDeliveryStatuses())
+}
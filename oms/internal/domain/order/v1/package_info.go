@@ -1,8 +1,16 @@
 package v1
 
+// DefaultVolumetricDivisor is the volumetric divisor (cm³/kg) used by ChargeableWeightKg.
+// 5000 is the common courier/express convention.
+const DefaultVolumetricDivisor = 5000.0
+
 // PackageInfo contains package physical characteristics for order delivery.
 type PackageInfo struct {
 	weightKg float64
+	// lengthCm, widthCm and heightCm are optional package dimensions in centimeters (0 = unknown)
+	lengthCm float64
+	widthCm  float64
+	heightCm float64
 }
 
 // NewPackageInfo creates a new PackageInfo value object.
@@ -10,12 +18,58 @@ func NewPackageInfo(weightKg float64) PackageInfo {
 	return PackageInfo{weightKg: weightKg}
 }
 
+// NewPackageInfoWithDimensions creates a new PackageInfo value object with dimensions in centimeters.
+func NewPackageInfoWithDimensions(weightKg, lengthCm, widthCm, heightCm float64) PackageInfo {
+	return PackageInfo{
+		weightKg: weightKg,
+		lengthCm: lengthCm,
+		widthCm:  widthCm,
+		heightCm: heightCm,
+	}
+}
+
 // GetWeightKg returns the weight in kilograms.
 func (p PackageInfo) GetWeightKg() float64 {
 	return p.weightKg
 }
 
-// IsValid checks if the package info is valid (weight > 0).
+// GetLengthCm returns the package length in centimeters.
+func (p PackageInfo) GetLengthCm() float64 {
+	return p.lengthCm
+}
+
+// GetWidthCm returns the package width in centimeters.
+func (p PackageInfo) GetWidthCm() float64 {
+	return p.widthCm
+}
+
+// GetHeightCm returns the package height in centimeters.
+func (p PackageInfo) GetHeightCm() float64 {
+	return p.heightCm
+}
+
+// HasDimensions reports whether all three dimensions are known (> 0).
+func (p PackageInfo) HasDimensions() bool {
+	return p.lengthCm > 0 && p.widthCm > 0 && p.heightCm > 0
+}
+
+// VolumetricWeightKg returns l*w*h/divisor.
+// Returns 0 when dimensions are unknown or divisor is not positive.
+func (p PackageInfo) VolumetricWeightKg(divisor float64) float64 {
+	if !p.HasDimensions() || divisor <= 0 {
+		return 0
+	}
+
+	return p.lengthCm * p.widthCm * p.heightCm / divisor
+}
+
+// ChargeableWeightKg returns the greater of actual and volumetric weight (DefaultVolumetricDivisor).
+// Falls back to the actual weight when dimensions are unknown.
+func (p PackageInfo) ChargeableWeightKg() float64 {
+	return max(p.weightKg, p.VolumetricWeightKg(DefaultVolumetricDivisor))
+}
+
+// IsValid checks if the package info is valid (weight > 0, dimensions not negative).
 func (p PackageInfo) IsValid() bool {
-	return p.weightKg > 0
+	return p.weightKg > 0 && p.lengthCm >= 0 && p.widthCm >= 0 && p.heightCm >= 0
 }
@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageInfo_VolumetricWeightKg(t *testing.T) {
+	t.Parallel()
+
+	// 50 x 40 x 30 cm = 60000 cm³
+	pkg := NewPackageInfoWithDimensions(2.5, 50, 40, 30)
+
+	require.True(t, pkg.HasDimensions())
+	require.InDelta(t, 12.0, pkg.VolumetricWeightKg(DefaultVolumetricDivisor), 1e-9)
+	require.InDelta(t, 10.0, pkg.VolumetricWeightKg(6000), 1e-9)
+	require.Zero(t, pkg.VolumetricWeightKg(0), "non-positive divisor must not divide")
+}
+
+func TestPackageInfo_ChargeableWeightKg(t *testing.T) {
+	t.Parallel()
+
+	t.Run("volumetric exceeds actual", func(t *testing.T) {
+		t.Parallel()
+
+		pkg := NewPackageInfoWithDimensions(2.5, 50, 40, 30)
+		require.InDelta(t, 12.0, pkg.ChargeableWeightKg(), 1e-9)
+	})
+
+	t.Run("actual exceeds volumetric", func(t *testing.T) {
+		t.Parallel()
+
+		pkg := NewPackageInfoWithDimensions(20, 10, 10, 10)
+		require.InDelta(t, 20.0, pkg.ChargeableWeightKg(), 1e-9)
+	})
+
+	t.Run("zero dimensions fall back to actual weight", func(t *testing.T) {
+		t.Parallel()
+
+		for _, pkg := range []PackageInfo{
+			NewPackageInfo(2.5),
+			NewPackageInfoWithDimensions(2.5, 50, 0, 30),
+		} {
+			require.False(t, pkg.HasDimensions())
+			require.Zero(t, pkg.VolumetricWeightKg(DefaultVolumetricDivisor))
+			require.InDelta(t, 2.5, pkg.ChargeableWeightKg(), 1e-9)
+		}
+	})
+}
+
+func TestPackageInfo_IsValid(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, NewPackageInfo(1).IsValid())
+	require.True(t, NewPackageInfoWithDimensions(1, 10, 20, 30).IsValid())
+	require.False(t, NewPackageInfo(0).IsValid())
+	require.False(t, NewPackageInfoWithDimensions(1, -1, 20, 30).IsValid())
+}
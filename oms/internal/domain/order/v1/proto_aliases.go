@@ -12,6 +12,10 @@ type (
 	OrderStatus = commonv1.OrderStatus
 	// DeliveryAddress represents an address with coordinates.
 	DeliveryAddress = commonv1.DeliveryAddress
+	// FulfillmentType describes how an order reaches the customer (delivery or self-pickup).
+	FulfillmentType = commonv1.FulfillmentType
+	// DeliveryStatus describes where the order's package is in the delivery lifecycle.
+	DeliveryStatus = commonv1.DeliveryStatus
 )
 
 const (
@@ -22,6 +26,12 @@ const (
 	OrderStatus_ORDER_STATUS_CANCELED    OrderStatus = commonv1.OrderStatus_ORDER_STATUS_CANCELLED //nolint:misspell // proto uses CANCELLED
 )
 
+const (
+	FulfillmentType_FULFILLMENT_TYPE_UNSPECIFIED FulfillmentType = commonv1.FulfillmentType_FULFILLMENT_TYPE_UNSPECIFIED
+	FulfillmentType_FULFILLMENT_TYPE_DELIVERY    FulfillmentType = commonv1.FulfillmentType_FULFILLMENT_TYPE_DELIVERY
+	FulfillmentType_FULFILLMENT_TYPE_PICKUP      FulfillmentType = commonv1.FulfillmentType_FULFILLMENT_TYPE_PICKUP
+)
+
 var (
 	// OrderStatus_name allows mapping FSM state strings back to domain enums.
 	OrderStatus_name = commonv1.OrderStatus_name
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// OrderTransitions returns the allowed next statuses for every order status that has any,
+// derived from the order FSM rules. Terminal statuses are absent. The result is a fresh copy.
+func OrderTransitions() map[OrderStatus][]OrderStatus {
+	transitions := make(map[OrderStatus][]OrderStatus)
+
+	for _, rule := range orderTransitionRules {
+		if !slices.Contains(transitions[rule.From], rule.To) {
+			transitions[rule.From] = append(transitions[rule.From], rule.To)
+		}
+	}
+
+	return transitions
+}
+
+// DeliveryTransitions returns the allowed forward delivery status moves, keyed by the current status.
+// Operational corrections (see CorrectDeliveryStatus) are not included. The result is a fresh copy.
+func DeliveryTransitions() map[DeliveryStatus][]DeliveryStatus {
+	transitions := make(map[DeliveryStatus][]DeliveryStatus, len(deliveryStatusTransitions))
+
+	for from, to := range deliveryStatusTransitions {
+		transitions[from] = slices.Clone(to)
+	}
+
+	return transitions
+}
+
+// diagramStatus is a proto enum usable as a state diagram node.
+type diagramStatus interface {
+	~int32
+	String() string
+}
+
+// MermaidStateDiagram renders transitions as a Mermaid stateDiagram-v2 block.
+// Edges are ordered by enum value, so the output is stable and can be committed to docs.
+func MermaidStateDiagram[S diagramStatus](transitions map[S][]S) string {
+	var b strings.Builder
+
+	b.WriteString("stateDiagram-v2\n")
+
+	for _, edge := range sortedEdges(transitions) {
+		fmt.Fprintf(&b, "    %s --> %s\n", edge[0], edge[1])
+	}
+
+	return b.String()
+}
+
+// DOTStateDiagram renders transitions as a Graphviz digraph called name.
+// Edges are ordered by enum value, so the output is stable and can be committed to docs.
+func DOTStateDiagram[S diagramStatus](name string, transitions map[S][]S) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "digraph %q {\n", name)
+
+	for _, edge := range sortedEdges(transitions) {
+		fmt.Fprintf(&b, "    %q -> %q;\n", edge[0], edge[1])
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// sortedEdges flattens transitions into [from, to] pairs ordered by enum value.
+func sortedEdges[S diagramStatus](transitions map[S][]S) [][2]S {
+	edges := make([][2]S, 0, len(transitions))
+
+	for _, from := range slices.Sorted(maps.Keys(transitions)) {
+		for _, to := range slices.Sorted(slices.Values(transitions[from])) {
+			edges = append(edges, [2]S{from, to})
+		}
+	}
+
+	return edges
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shortlink-org/go-sdk/fsm"
+	"github.com/stretchr/testify/require"
+
+	common "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+)
+
+// TestOrderTransitions_MatchFSM drives a fresh order FSM with every event from every status
+// and checks the exported map lists exactly the statuses the FSM reached.
+func TestOrderTransitions_MatchFSM(t *testing.T) {
+	t.Parallel()
+
+	reached := make(map[OrderStatus][]OrderStatus)
+
+	for fromValue := range common.OrderStatus_name {
+		from := OrderStatus(fromValue)
+
+		for eventValue := range common.OrderTransitionEvent_name {
+			event := common.OrderTransitionEvent(eventValue)
+			order := NewOrderStateFromPersisted(uuid.New(), uuid.New(), nil, from, 0, nil, common.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED, nil)
+
+			err := order.fsm.TriggerEvent(t.Context(), fsm.Event(event.String()))
+			if err != nil {
+				continue
+			}
+
+			reached[from] = append(reached[from], order.GetStatus())
+		}
+	}
+
+	exported := OrderTransitions()
+	require.Len(t, exported, len(reached))
+
+	for from, to := range reached {
+		require.ElementsMatch(t, to, exported[from], "transitions from %s", from)
+	}
+}
+
+func TestDeliveryTransitions_MatchValidation(t *testing.T) {
+	t.Parallel()
+
+	order := NewOrderState(uuid.New())
+	exported := DeliveryTransitions()
+
+	for fromValue := range common.DeliveryStatus_name {
+		for toValue := range common.DeliveryStatus_name {
+			from, to := DeliveryStatus(fromValue), DeliveryStatus(toValue)
+
+			require.Equal(t, order.isValidDeliveryStatusTransition(from, to), slices.Contains(exported[from], to),
+				"%s -> %s", from, to)
+		}
+	}
+}
+
+func TestDeliveryTransitions_ReturnsCopy(t *testing.T) {
+	t.Parallel()
+
+	exported := DeliveryTransitions()
+	exported[common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED][0] = common.DeliveryStatus_DELIVERY_STATUS_DELIVERED
+
+	require.Equal(t, []DeliveryStatus{common.DeliveryStatus_DELIVERY_STATUS_ASSIGNED},
+		DeliveryTransitions()[common.DeliveryStatus_DELIVERY_STATUS_ACCEPTED])
+}
+
+func TestMermaidStateDiagram(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, `stateDiagram-v2
+    ORDER_STATUS_PENDING --> ORDER_STATUS_PROCESSING
+    ORDER_STATUS_PENDING --> ORDER_STATUS_CANCELLED
+    ORDER_STATUS_PROCESSING --> ORDER_STATUS_COMPLETED
+    ORDER_STATUS_PROCESSING --> ORDER_STATUS_CANCELLED
+`, MermaidStateDiagram(OrderTransitions()))
+}
+
+func TestDOTStateDiagram(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, `digraph "delivery" {
+    "DELIVERY_STATUS_UNSPECIFIED" -> "DELIVERY_STATUS_ACCEPTED";
+    "DELIVERY_STATUS_ACCEPTED" -> "DELIVERY_STATUS_ASSIGNED";
+    "DELIVERY_STATUS_ASSIGNED" -> "DELIVERY_STATUS_IN_TRANSIT";
+    "DELIVERY_STATUS_IN_TRANSIT" -> "DELIVERY_STATUS_DELIVERED";
+    "DELIVERY_STATUS_IN_TRANSIT" -> "DELIVERY_STATUS_NOT_DELIVERED";
+}
+`, DOTStateDiagram("delivery", DeliveryTransitions()))
+}
@@ -3,6 +3,7 @@ package location
 import (
 	"errors"
 	"fmt"
+	"math"
 )
 
 // Location validation errors
@@ -21,6 +22,8 @@ const (
 	MinLongitude float64 = -180.0
 	// MaxLongitude is the maximum valid longitude (180.0)
 	MaxLongitude float64 = 180.0
+	// EarthRadiusKm is the mean Earth radius used for great-circle distances
+	EarthRadiusKm float64 = 6371.0
 )
 
 // Location represents a GPS location as a value object.
@@ -90,6 +93,19 @@ func (l Location) IsZero() bool {
 	return l.latitude == 0 && l.longitude == 0
 }
 
+// DistanceTo returns the great-circle (haversine) distance to other in kilometers.
+func (l Location) DistanceTo(other Location) float64 {
+	lat1 := l.latitude * math.Pi / 180
+	lat2 := other.latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (other.longitude - l.longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
 // String returns a string representation of the location.
 func (l Location) String() string {
 	return fmt.Sprintf("(%.6f, %.6f)", l.latitude, l.longitude)
@@ -224,3 +224,13 @@ func TestMustNewLocation(t *testing.T) {
 		_ = MustNewLocation(91.0, 37.6173)
 	})
 }
+
+func TestLocation_DistanceTo(t *testing.T) {
+	moscow := MustNewLocation(55.7558, 37.6173)
+	saintPetersburg := MustNewLocation(59.9343, 30.3351)
+
+	require.InDelta(t, 0, moscow.DistanceTo(moscow), 1e-9)
+	// Great-circle distance between the city centres is about 634 km
+	require.InDelta(t, 634, moscow.DistanceTo(saintPetersburg), 2)
+	require.InDelta(t, moscow.DistanceTo(saintPetersburg), saintPetersburg.DistanceTo(moscow), 1e-9)
+}
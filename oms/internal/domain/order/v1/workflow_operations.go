@@ -1,5 +1,13 @@
 package v1
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+)
+
 // Workflow operation names for Temporal workflows.
 // These are used for signals and queries, not domain events.
 const (
@@ -9,6 +17,25 @@ const (
 	// WorkflowSignalComplete is the signal name for completing an order
 	WorkflowSignalComplete = "order.complete"
 
+	// WorkflowSignalDeliveryStatus is the signal name for a delivery status update (payload: DeliveryStatusUpdate)
+	WorkflowSignalDeliveryStatus = "order.delivery_status"
+
 	// WorkflowQueryGet is the query name for getting order state
 	WorkflowQueryGet = "order.get"
 )
+
+// DeliveryStatusUpdate is a delivery status change reported by the Delivery service.
+// It is sent to the order workflow, which applies it to the order.
+type DeliveryStatusUpdate struct {
+	// MessageID identifies the source message; re-applying the same message is a no-op
+	MessageID  string
+	OrderID    uuid.UUID
+	PackageID  uuid.UUID
+	CourierID  uuid.UUID
+	Status     commonv1.DeliveryStatus
+	OccurredAt time.Time
+	// DeliveryLocation is set for DELIVERED updates only
+	DeliveryLocation *commonv1.DeliveryLocation
+	// NotDeliveredDetails is set for NOT_DELIVERED updates only
+	NotDeliveredDetails *commonv1.NotDeliveredDetails
+}
@@ -8,10 +8,15 @@ import (
 
 // CartGoodsIndex provides an index for quickly looking up which customers
 // have a specific good in their cart. This is used for stock change notifications.
+// It also projects the total units of each good across all active carts as a demand signal.
 //
 //nolint:iface // port interface used by usecases and DI
 type CartGoodsIndex interface {
 	AddGoodToCart(ctx context.Context, goodID, customerID uuid.UUID) error
+	AddGoodsToCart(ctx context.Context, goodIDs []uuid.UUID, customerID uuid.UUID) error
 	RemoveGoodFromCart(ctx context.Context, goodID, customerID uuid.UUID) error
 	GetCustomersWithGood(ctx context.Context, goodID uuid.UUID) ([]uuid.UUID, error)
+	IncrementGoodDemand(ctx context.Context, goodID, customerID uuid.UUID, quantity int64) error
+	DecrementGoodDemand(ctx context.Context, goodID, customerID uuid.UUID, quantity int64) error
+	GetGoodDemand(ctx context.Context, goodID uuid.UUID) (int64, error)
 }
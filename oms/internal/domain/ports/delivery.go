@@ -67,6 +67,12 @@ type DeliveryPeriodDTO struct {
 // PackageInfoDTO contains physical characteristics of the package.
 type PackageInfoDTO struct {
 	WeightKg float64
+	// LengthCm, WidthCm and HeightCm are optional dimensions in centimeters (0 = unknown)
+	LengthCm float64
+	WidthCm  float64
+	HeightCm float64
+	// ChargeableWeightKg is max(actual, volumetric) weight used for courier pricing
+	ChargeableWeightKg float64
 }
 
 // DeliveryPriorityDTO represents delivery priority level.
@@ -10,4 +10,5 @@ var (
 	ErrConflict        = domain.ErrConflict
 	ErrValidation      = domain.ErrValidation
 	ErrUnavailable     = domain.ErrUnavailable
+	ErrRateLimited     = domain.ErrRateLimited
 )
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domainevents "github.com/shortlink-org/shop/oms/internal/domain/events"
+)
+
+// EventStore persists the domain event stream of each aggregate for audit and rehydration.
+// Streams are append-only; each event gets the next stream version, starting at 1.
+//
+//nolint:iface // port interface used by usecases and DI
+type EventStore interface {
+	// Append adds events to the aggregate's stream. expectedVersion is the stream version the
+	// caller last saw (0 for a new aggregate); returns ErrVersionConflict when the stream has moved on.
+	Append(ctx context.Context, aggregateID uuid.UUID, events []domainevents.Event, expectedVersion int) error
+	// Load returns the aggregate's events in stream order, ready for order.ReplayEvents.
+	// An unknown aggregate has an empty stream.
+	Load(ctx context.Context, aggregateID uuid.UUID) ([]any, error)
+}
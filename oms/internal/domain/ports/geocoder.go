@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/location"
+)
+
+// ErrAddressNotGeocoded is returned when the geocoder finds no coordinates for an address.
+var ErrAddressNotGeocoded = errors.New("address could not be geocoded")
+
+// Geocoder resolves postal addresses into GPS coordinates.
+//
+//nolint:iface // port interface used by activities and DI
+type Geocoder interface {
+	Geocode(ctx context.Context, addr address.Address) (location.Location, error)
+}
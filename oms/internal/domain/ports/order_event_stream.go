@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"github.com/google/uuid"
+)
+
+// OrderEventStream delivers published order domain events to in-process watchers.
+// It is backed by the outbox-fed Kafka topics, so it sees events from every OMS replica.
+//
+//nolint:iface // port interface used by usecases and DI
+type OrderEventStream interface {
+	// SubscribeOrder returns the events of one order as they are consumed.
+	// The channel is closed by unsubscribe or when the stream shuts down.
+	SubscribeOrder(orderID uuid.UUID) (events <-chan Event, unsubscribe func())
+}
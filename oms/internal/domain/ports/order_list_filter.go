@@ -1,6 +1,8 @@
 package ports
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 
 	order "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
@@ -10,4 +12,28 @@ import (
 type ListFilter struct {
 	CustomerID   *uuid.UUID
 	StatusFilter []order.OrderStatus
+	// IncludeArchived also returns archived (soft-deleted) orders; they are excluded by default
+	IncludeArchived bool
+}
+
+// ListPageFilter contains optional filters and cursor pagination for listing orders.
+// Empty filters list recent orders across all customers, newest first.
+type ListPageFilter struct {
+	ListFilter
+
+	// CreatedFrom is the inclusive lower bound for order creation time
+	CreatedFrom *time.Time
+	// CreatedTo is the exclusive upper bound for order creation time
+	CreatedTo *time.Time
+	// PageSize is the maximum number of orders to return (must be > 0)
+	PageSize int32
+	// PageToken is the opaque cursor returned by the previous page (empty for the first page)
+	PageToken string
+}
+
+// OrderPage is a single page of orders returned by OrderRepository.ListPage.
+type OrderPage struct {
+	Orders []*order.OrderState
+	// NextPageToken is empty when there are no more orders
+	NextPageToken string
 }
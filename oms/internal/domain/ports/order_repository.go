@@ -22,5 +22,12 @@ type OrderRepository interface {
 	LoadByPackageID(ctx context.Context, packageID uuid.UUID) (*order.OrderState, error)
 	Save(ctx context.Context, state *order.OrderState) error
 	List(ctx context.Context, filter ListFilter) ([]*order.OrderState, error)
+	ListPage(ctx context.Context, filter ListPageFilter) (*OrderPage, error)
 	ListByCustomer(ctx context.Context, customerID uuid.UUID) ([]*order.OrderState, error)
 }
+
+// LoadOptions controls which orders a load may return.
+type LoadOptions struct {
+	// IncludeArchived also loads archived (soft-deleted) orders; by default they are not found
+	IncludeArchived bool
+}
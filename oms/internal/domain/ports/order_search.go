@@ -0,0 +1,98 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	order "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+)
+
+// ErrUnsupportedOrderSearchSort is returned for an unknown SearchOrders sort.
+var ErrUnsupportedOrderSearchSort = errors.New("unsupported order search sort")
+
+// OrderSearchSort is the order of SearchOrders results.
+type OrderSearchSort string
+
+const (
+	OrderSearchSortCreatedDesc OrderSearchSort = "CREATED_AT_DESC"
+	OrderSearchSortCreatedAsc  OrderSearchSort = "CREATED_AT_ASC"
+	OrderSearchSortTotalDesc   OrderSearchSort = "TOTAL_DESC"
+	OrderSearchSortTotalAsc    OrderSearchSort = "TOTAL_ASC"
+)
+
+// ParseOrderSearchSort parses a sort; an empty value selects OrderSearchSortCreatedDesc.
+func ParseOrderSearchSort(raw string) (OrderSearchSort, error) {
+	switch OrderSearchSort(raw) {
+	case "":
+		return OrderSearchSortCreatedDesc, nil
+	case OrderSearchSortCreatedDesc, OrderSearchSortCreatedAsc, OrderSearchSortTotalDesc, OrderSearchSortTotalAsc:
+		return OrderSearchSort(raw), nil
+	default:
+		return "", ErrUnsupportedOrderSearchSort
+	}
+}
+
+// OrderEvent is an order domain event as seen by the projections built from it.
+type OrderEvent interface {
+	Event
+	GetOrderId() string
+	GetOccurredAt() *timestamppb.Timestamp
+}
+
+// OrderSearchDocument is the denormalized search row of one order.
+type OrderSearchDocument struct {
+	OrderID       uuid.UUID
+	CustomerID    uuid.UUID
+	CustomerEmail string
+	Status        order.OrderStatus
+	GoodIDs       []uuid.UUID
+	Total         decimal.Decimal
+	// AggregateVersion of the order the document was built from; older versions never replace newer ones
+	AggregateVersion int32
+	// CreatedAt is only written when the document is first inserted
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OrderSearchFilter contains the predicates and pagination of SearchOrders.
+// Empty predicates match every order.
+type OrderSearchFilter struct {
+	// Text matches a part of the customer email or the beginning of the order ID, case-insensitively
+	Text string
+	// CustomerEmail matches the customer email exactly, case-insensitively
+	CustomerEmail string
+	// GoodID matches orders containing the good
+	GoodID       *uuid.UUID
+	StatusFilter []order.OrderStatus
+	Sort         OrderSearchSort
+	// PageSize is the maximum number of orders to return (must be > 0)
+	PageSize int32
+	// PageToken is the opaque cursor returned by the previous page (empty for the first page)
+	PageToken string
+}
+
+// OrderSearchPage is a single page of SearchOrders results.
+type OrderSearchPage struct {
+	Orders []OrderSearchDocument
+	// NextPageToken is empty when there are no more orders
+	NextPageToken string
+}
+
+// OrderSearchRepository manages the order search read model.
+// It is fed from order events and never touched by the order write model.
+//
+//nolint:iface // port interface used by use cases and DI
+type OrderSearchRepository interface {
+	// ApplyOrder upserts the document of an order; it reports false when a document
+	// of the same or a newer aggregate version is already stored.
+	ApplyOrder(ctx context.Context, document OrderSearchDocument) (bool, error)
+	// RemoveOrder deletes the document of an order that is no longer loadable, e.g. once archived;
+	// a missing document is not an error.
+	RemoveOrder(ctx context.Context, orderID uuid.UUID) error
+	Search(ctx context.Context, filter OrderSearchFilter) (*OrderSearchPage, error)
+}
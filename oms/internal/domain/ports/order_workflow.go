@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+)
+
+// ErrWorkflowNotRunning is returned when the order has no running workflow to signal: it was never
+// started, has already finished, or failed.
+var ErrWorkflowNotRunning = errors.New("order workflow is not running")
+
+// OrderWorkflow forwards external changes to the running order workflow.
+//
+//nolint:iface // port interface used by usecases and DI
+type OrderWorkflow interface {
+	// SignalDeliveryStatus hands a delivery status update to the workflow of update.OrderID.
+	// It returns ErrWorkflowNotRunning when that workflow does not exist or is closed.
+	SignalDeliveryStatus(ctx context.Context, update orderv1.DeliveryStatusUpdate) error
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+
+	pricing "github.com/shortlink-org/shop/oms/internal/domain/pricing"
 )
 
 // PricerClient is the interface for the pricing service client.
@@ -19,15 +21,31 @@ type CalculateTotalRequest struct {
 	Cart           CartData
 	DiscountParams map[string]string
 	TaxParams      map[string]string
+	// Currency is the ISO-4217 currency of the cart; items must not mix currencies.
+	Currency pricing.Currency
 }
 
 // CalculateTotalResponse is the response after calculating totals.
 type CalculateTotalResponse struct {
-	TotalTax      decimal.Decimal
+	TotalTax decimal.Decimal
+	// TotalDiscount is the sum of the per-item discounts.
 	TotalDiscount decimal.Decimal
+	// OrderDiscount is the order-level promotion applied on top of the item discounts;
+	// FinalPrice = Subtotal - TotalDiscount - OrderDiscount + TotalTax.
+	OrderDiscount decimal.Decimal
 	FinalPrice    decimal.Decimal
 	Subtotal      decimal.Decimal
 	Policies      []string
+	// Currency is the currency all amounts are in, as echoed by the pricer.
+	Currency pricing.Currency
+	// Items is the per-item price breakdown; empty while the pricer reports totals only.
+	Items []PricedItemData
+}
+
+// PricedItemData is the pricer's per-item result used to reconcile against cart prices.
+type PricedItemData struct {
+	ProductID uuid.UUID       // Good/product identifier
+	UnitPrice decimal.Decimal // Price per unit as priced by the pricer (before discount/tax)
 }
 
 // CartData represents cart data for pricing calculation.
@@ -38,7 +56,10 @@ type CartData struct {
 
 // CartItemData represents a cart item for pricing calculation.
 type CartItemData struct {
-	ProductID uuid.UUID       // Good/product identifier
-	Quantity  int32           // Number of units
-	UnitPrice decimal.Decimal // Price per unit (before discount/tax)
+	ProductID uuid.UUID        // Good/product identifier
+	Quantity  int32            // Number of units
+	UnitPrice decimal.Decimal  // Price per unit (before discount/tax)
+	Currency  pricing.Currency // Currency of UnitPrice
+	// TaxCategory is the category tax policies branch on, e.g. "food"; empty means the pricer's default
+	TaxCategory string
 }
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimiter throttles actions per key (e.g. checkouts per customer) with a token bucket.
+//
+//nolint:iface // port interface used by usecases and DI
+type RateLimiter interface {
+	// Allow takes one token from key's bucket. When the bucket is empty the action is denied
+	// and RateLimitDecision.RetryAfter tells when the next token is available.
+	Allow(ctx context.Context, key string) (RateLimitDecision, error)
+}
+
+// RateLimitDecision is the outcome of a RateLimiter.Allow call.
+type RateLimitDecision struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
@@ -0,0 +1,72 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const currencyCodeLength = 3
+
+var (
+	// ErrInvalidCurrency is returned for codes that are not three-letter ISO-4217 codes.
+	ErrInvalidCurrency = errors.New("invalid ISO-4217 currency code")
+	// ErrCurrencyMismatch is returned when amounts in different currencies are combined.
+	// Use errors.As with *CurrencyMismatchError for the currencies involved.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
+// Currency is an ISO-4217 currency code such as "USD". The zero value means "not specified".
+type Currency string
+
+// NewCurrency normalizes and validates an ISO-4217 code. An empty code yields the zero Currency.
+func NewCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return "", nil
+	}
+
+	if len(code) != currencyCodeLength || strings.IndexFunc(code, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+	}
+
+	return Currency(code), nil
+}
+
+// String returns the ISO-4217 code.
+func (c Currency) String() string {
+	return string(c)
+}
+
+// CurrencyMismatchError reports two currencies that must not be mixed.
+type CurrencyMismatchError struct {
+	Expected Currency
+	Got      Currency
+}
+
+func (e *CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("%s: expected %q, got %q", ErrCurrencyMismatch, e.Expected, e.Got)
+}
+
+// Is makes errors.Is(err, ErrCurrencyMismatch) match.
+func (e *CurrencyMismatchError) Is(target error) bool {
+	return target == ErrCurrencyMismatch
+}
+
+// SingleCurrency returns the one currency shared by all given currencies so money math stays within it.
+// Any disagreement, including a specified currency next to an unspecified one, is a *CurrencyMismatchError.
+func SingleCurrency(currencies ...Currency) (Currency, error) {
+	if len(currencies) == 0 {
+		return "", nil
+	}
+
+	first := currencies[0]
+
+	for _, currency := range currencies[1:] {
+		if currency != first {
+			return "", &CurrencyMismatchError{Expected: first, Got: currency}
+		}
+	}
+
+	return first, nil
+}
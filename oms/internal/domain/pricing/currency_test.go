@@ -0,0 +1,37 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCurrency(t *testing.T) {
+	currency, err := NewCurrency(" usd ")
+	require.NoError(t, err)
+	assert.Equal(t, Currency("USD"), currency)
+
+	currency, err = NewCurrency("")
+	require.NoError(t, err)
+	assert.Empty(t, currency)
+
+	for _, code := range []string{"US", "USDT", "U$D"} {
+		_, err = NewCurrency(code)
+		require.ErrorIs(t, err, ErrInvalidCurrency, code)
+	}
+}
+
+func TestSingleCurrency(t *testing.T) {
+	currency, err := SingleCurrency("EUR", "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, Currency("EUR"), currency)
+
+	_, err = SingleCurrency("EUR", "", "USD")
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	var mismatch *CurrencyMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, Currency("EUR"), mismatch.Expected)
+	assert.Empty(t, mismatch.Got)
+}
@@ -0,0 +1,75 @@
+package geocoding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/location"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+const (
+	// Cache configuration for resolved addresses
+	cacheNumCounters = 100_000 // track 100k addresses
+	cacheMaxCost     = 10_000  // one unit per address
+	cacheBufferItems = 64
+)
+
+// CachingGeocoder remembers resolved addresses so repeated pickups and customers
+// do not hit the geocoding service again. Failures are not cached.
+type CachingGeocoder struct {
+	next  ports.Geocoder
+	cache *ristretto.Cache[string, location.Location]
+	ttl   time.Duration
+}
+
+// NewCachingGeocoder wraps next with an in-memory cache whose entries expire after ttl.
+func NewCachingGeocoder(next ports.Geocoder, ttl time.Duration) (*CachingGeocoder, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config[string, location.Location]{
+		NumCounters: cacheNumCounters,
+		MaxCost:     cacheMaxCost,
+		BufferItems: cacheBufferItems,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create geocoder cache: %w", err)
+	}
+
+	return &CachingGeocoder{
+		next:  next,
+		cache: cache,
+		ttl:   ttl,
+	}, nil
+}
+
+// Geocode implements ports.Geocoder.
+func (c *CachingGeocoder) Geocode(ctx context.Context, addr address.Address) (location.Location, error) {
+	key := cacheKey(addr)
+
+	if loc, found := c.cache.Get(key); found {
+		return loc, nil
+	}
+
+	loc, err := c.next.Geocode(ctx, addr)
+	if err != nil {
+		return location.Location{}, err
+	}
+
+	c.cache.SetWithTTL(key, loc, 1, c.ttl)
+
+	return loc, nil
+}
+
+// Close releases the cache.
+func (c *CachingGeocoder) Close() {
+	c.cache.Close()
+}
+
+// cacheKey ignores letter case, which does not change where an address is.
+func cacheKey(addr address.Address) string {
+	return strings.ToLower(addr.String())
+}
@@ -0,0 +1,82 @@
+package geocoding
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/location"
+)
+
+// countingGeocoder returns a fixed result and counts calls.
+type countingGeocoder struct {
+	loc   location.Location
+	err   error
+	calls int
+}
+
+func (g *countingGeocoder) Geocode(context.Context, address.Address) (location.Location, error) {
+	g.calls++
+
+	return g.loc, g.err
+}
+
+func newTestAddress(t *testing.T, street string) address.Address {
+	t.Helper()
+
+	addr, err := address.NewAddress(street, "Moscow", "101000", "Russia")
+	require.NoError(t, err)
+
+	return addr
+}
+
+func TestCachingGeocoder_CachesResolvedAddresses(t *testing.T) {
+	t.Parallel()
+
+	loc, err := location.NewLocation(55.7558, 37.6173)
+	require.NoError(t, err)
+
+	next := &countingGeocoder{loc: loc}
+	geocoder, err := NewCachingGeocoder(next, time.Minute)
+	require.NoError(t, err)
+	t.Cleanup(geocoder.Close)
+
+	first, err := geocoder.Geocode(context.Background(), newTestAddress(t, "123 Warehouse St"))
+	require.NoError(t, err)
+	require.Equal(t, loc, first)
+
+	// Ristretto applies sets asynchronously.
+	geocoder.cache.Wait()
+
+	second, err := geocoder.Geocode(context.Background(), newTestAddress(t, "123 WAREHOUSE ST"))
+	require.NoError(t, err)
+	require.Equal(t, loc, second)
+	require.Equal(t, 1, next.calls)
+
+	_, err = geocoder.Geocode(context.Background(), newTestAddress(t, "456 Customer St"))
+	require.NoError(t, err)
+	require.Equal(t, 2, next.calls)
+}
+
+func TestCachingGeocoder_DoesNotCacheFailures(t *testing.T) {
+	t.Parallel()
+
+	next := &countingGeocoder{err: errors.New("geocoder down")}
+	geocoder, err := NewCachingGeocoder(next, time.Minute)
+	require.NoError(t, err)
+	t.Cleanup(geocoder.Close)
+
+	addr := newTestAddress(t, "123 Warehouse St")
+
+	for range 2 {
+		_, err = geocoder.Geocode(context.Background(), addr)
+		require.Error(t, err)
+		geocoder.cache.Wait()
+	}
+
+	require.Equal(t, 2, next.calls)
+}
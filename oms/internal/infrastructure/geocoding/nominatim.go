@@ -0,0 +1,114 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/location"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+// Config contains configuration for the Nominatim geocoder.
+type Config struct {
+	// BaseURL is the Nominatim-compatible API root (e.g., "https://nominatim.openstreetmap.org")
+	BaseURL string
+	// UserAgent identifies OMS to the geocoding service, as its usage policy requires
+	UserAgent string
+	// Timeout bounds a single geocoding request
+	Timeout time.Duration
+}
+
+// Nominatim implements ports.Geocoder using the structured search of a Nominatim-compatible API.
+type Nominatim struct {
+	baseURL   string
+	userAgent string
+	client    *http.Client
+}
+
+// NewNominatim creates a new Nominatim geocoder.
+func NewNominatim(cfg Config) (*Nominatim, error) {
+	baseURL, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse geocoder base url: %w", err)
+	}
+
+	return &Nominatim{
+		baseURL:   strings.TrimSuffix(baseURL.String(), "/"),
+		userAgent: cfg.UserAgent,
+		client:    &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// nominatimPlace is the part of a search result OMS needs; coordinates come as strings.
+type nominatimPlace struct {
+	Lat string `json:"lat"`
+	Lon string `json:"lon"`
+}
+
+// Geocode implements ports.Geocoder.
+func (n *Nominatim) Geocode(ctx context.Context, addr address.Address) (location.Location, error) {
+	query := url.Values{}
+	query.Set("format", "jsonv2")
+	query.Set("limit", "1")
+	query.Set("street", addr.Street())
+	query.Set("city", addr.City())
+	query.Set("country", addr.Country())
+
+	if addr.PostalCode() != "" {
+		query.Set("postalcode", addr.PostalCode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, n.baseURL+"/search?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return location.Location{}, fmt.Errorf("build geocoding request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", n.userAgent)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return location.Location{}, domain.WrapUnavailable("Geocode", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return location.Location{}, domain.WrapUnavailable("Geocode",
+			fmt.Errorf("unexpected geocoder status %d", resp.StatusCode)) //nolint:err113 // status code in error message for diagnostics
+	}
+
+	var places []nominatimPlace
+
+	err = json.NewDecoder(resp.Body).Decode(&places)
+	if err != nil {
+		return location.Location{}, fmt.Errorf("decode geocoding response: %w", err)
+	}
+
+	if len(places) == 0 {
+		return location.Location{}, fmt.Errorf("%w: %s", ports.ErrAddressNotGeocoded, addr.String())
+	}
+
+	return parsePlace(places[0])
+}
+
+func parsePlace(place nominatimPlace) (location.Location, error) {
+	lat, err := strconv.ParseFloat(place.Lat, 64)
+	if err != nil {
+		return location.Location{}, fmt.Errorf("parse geocoded latitude %q: %w", place.Lat, err)
+	}
+
+	lon, err := strconv.ParseFloat(place.Lon, 64)
+	if err != nil {
+		return location.Location{}, fmt.Errorf("parse geocoded longitude %q: %w", place.Lon, err)
+	}
+
+	return location.NewLocation(lat, lon)
+}
@@ -84,7 +84,11 @@ func (c *Client) AcceptOrder(ctx context.Context, req ports.AcceptOrderRequest)
 			EndTime:   timestamppb.New(req.DeliveryPeriod.EndTime),
 		},
 		PackageInfo: &PackageInfo{
-			WeightKg: req.PackageInfo.WeightKg,
+			WeightKg:           req.PackageInfo.WeightKg,
+			LengthCm:           req.PackageInfo.LengthCm,
+			WidthCm:            req.PackageInfo.WidthCm,
+			HeightCm:           req.PackageInfo.HeightCm,
+			ChargeableWeightKg: req.PackageInfo.ChargeableWeightKg,
 		},
 		Priority: Priority(req.Priority),
 		RecipientContacts: &RecipientContacts{
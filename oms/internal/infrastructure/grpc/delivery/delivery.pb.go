@@ -277,10 +277,16 @@ func (x *DeliveryPeriod) GetEndTime() *timestamppb.Timestamp {
 
 // PackageInfo contains physical characteristics of the package
 type PackageInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WeightKg      float64                `protobuf:"fixed64,1,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	WeightKg float64                `protobuf:"fixed64,1,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`
+	// Optional dimensions in centimeters (0 = unknown)
+	LengthCm float64 `protobuf:"fixed64,2,opt,name=length_cm,json=lengthCm,proto3" json:"length_cm,omitempty"`
+	WidthCm  float64 `protobuf:"fixed64,3,opt,name=width_cm,json=widthCm,proto3" json:"width_cm,omitempty"`
+	HeightCm float64 `protobuf:"fixed64,4,opt,name=height_cm,json=heightCm,proto3" json:"height_cm,omitempty"`
+	// max(actual, volumetric) weight used for courier pricing
+	ChargeableWeightKg float64 `protobuf:"fixed64,5,opt,name=chargeable_weight_kg,json=chargeableWeightKg,proto3" json:"chargeable_weight_kg,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *PackageInfo) Reset() {
@@ -320,6 +326,34 @@ func (x *PackageInfo) GetWeightKg() float64 {
 	return 0
 }
 
+func (x *PackageInfo) GetLengthCm() float64 {
+	if x != nil {
+		return x.LengthCm
+	}
+	return 0
+}
+
+func (x *PackageInfo) GetWidthCm() float64 {
+	if x != nil {
+		return x.WidthCm
+	}
+	return 0
+}
+
+func (x *PackageInfo) GetHeightCm() float64 {
+	if x != nil {
+		return x.HeightCm
+	}
+	return 0
+}
+
+func (x *PackageInfo) GetChargeableWeightKg() float64 {
+	if x != nil {
+		return x.ChargeableWeightKg
+	}
+	return 0
+}
+
 // RecipientContacts contains recipient contact details for delivery
 type RecipientContacts struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -559,9 +593,13 @@ const file_infrastructure_grpc_delivery_delivery_proto_rawDesc = "" +
 	"\x0eDeliveryPeriod\x129\n" +
 	"\n" +
 	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
-	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"*\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"\xb1\x01\n" +
 	"\vPackageInfo\x12\x1b\n" +
-	"\tweight_kg\x18\x01 \x01(\x01R\bweightKg\"\x8c\x01\n" +
+	"\tweight_kg\x18\x01 \x01(\x01R\bweightKg\x12\x1b\n" +
+	"\tlength_cm\x18\x02 \x01(\x01R\blengthCm\x12\x19\n" +
+	"\bwidth_cm\x18\x03 \x01(\x01R\awidthCm\x12\x1b\n" +
+	"\theight_cm\x18\x04 \x01(\x01R\bheightCm\x120\n" +
+	"\x14chargeable_weight_kg\x18\x05 \x01(\x01R\x12chargeableWeightKg\"\x8c\x01\n" +
 	"\x11RecipientContacts\x12%\n" +
 	"\x0erecipient_name\x18\x01 \x01(\tR\rrecipientName\x12'\n" +
 	"\x0frecipient_phone\x18\x02 \x01(\tR\x0erecipientPhone\x12'\n" +
@@ -10,11 +10,13 @@ import (
 	"time"
 
 	"github.com/shopspring/decimal"
+	"github.com/sony/gobreaker"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	pricing "github.com/shortlink-org/shop/oms/internal/domain/pricing"
 	pricerv1 "github.com/shortlink-org/shop/oms/internal/infrastructure/grpc/pricer/v1"
 )
 
@@ -22,8 +24,11 @@ var errAppendCA = errors.New("failed to add CA certificate to pool")
 
 // Client implements the ports.PricerClient interface using gRPC.
 type Client struct {
-	conn   *grpc.ClientConn
-	client pricerv1.CartServiceClient
+	conn        *grpc.ClientConn
+	client      pricerv1.CartServiceClient
+	callTimeout time.Duration
+	resilience  ResilienceConfig
+	breaker     *gobreaker.CircuitBreaker
 }
 
 // Config contains configuration for the Pricer gRPC client.
@@ -32,6 +37,10 @@ type Config struct {
 	Address string
 	// Timeout is the connection timeout
 	Timeout time.Duration
+	// CallTimeout bounds every CalculateTotal attempt; zero leaves only the caller's deadline
+	CallTimeout time.Duration
+	// Resilience configures retries of transient failures and the circuit breaker
+	Resilience ResilienceConfig
 	// TLSEnabled enables TLS for the connection
 	TLSEnabled bool
 	// CertPath is the path to the CA certificate
@@ -62,10 +71,22 @@ func NewClient(cfg Config) (*Client, error) {
 		return nil, fmt.Errorf("failed to connect to pricer service: %w", err)
 	}
 
+	client := newClient(pricerv1.NewCartServiceClient(conn), cfg)
+	client.conn = conn
+
+	return client, nil
+}
+
+// newClient wraps a CartService client with the per-call timeout, retries and circuit breaker from cfg.
+func newClient(client pricerv1.CartServiceClient, cfg Config) *Client {
+	resilience := cfg.Resilience.withDefaults()
+
 	return &Client{
-		conn:   conn,
-		client: pricerv1.NewCartServiceClient(conn),
-	}, nil
+		client:      client,
+		callTimeout: cfg.CallTimeout,
+		resilience:  resilience,
+		breaker:     newBreaker(resilience),
+	}
 }
 
 // Close closes the gRPC connection.
@@ -81,6 +102,9 @@ func (c *Client) Close() error {
 }
 
 // CalculateTotal calculates the total price, tax, and discounts for a cart.
+// A hung pricer fails an attempt with codes.DeadlineExceeded once CallTimeout elapses;
+// Unavailable and DeadlineExceeded are retried, and repeated ones open the circuit breaker
+// so later calls fail fast with ErrCircuitOpen.
 func (c *Client) CalculateTotal(ctx context.Context, req ports.CalculateTotalRequest) (*ports.CalculateTotalResponse, error) {
 	// Convert domain request to proto
 	protoReq := &pricerv1.CalculateTotalRequest{
@@ -90,17 +114,20 @@ func (c *Client) CalculateTotal(ctx context.Context, req ports.CalculateTotalReq
 		},
 		DiscountParams: req.DiscountParams,
 		TaxParams:      req.TaxParams,
+		Currency:       req.Currency.String(),
 	}
 
 	for _, item := range req.Cart.Items {
 		protoReq.Cart.Items = append(protoReq.Cart.Items, &pricerv1.CartItem{
-			ProductId: item.ProductID.String(),
-			Quantity:  item.Quantity,
-			Price:     item.UnitPrice.String(),
+			ProductId:   item.ProductID.String(),
+			Quantity:    item.Quantity,
+			Price:       item.UnitPrice.String(),
+			Currency:    item.Currency.String(),
+			TaxCategory: item.TaxCategory,
 		})
 	}
 
-	resp, err := c.client.CalculateTotal(ctx, protoReq)
+	resp, err := c.calculateTotal(ctx, protoReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate total: %w", err)
 	}
@@ -116,11 +143,25 @@ func (c *Client) CalculateTotal(ctx context.Context, req ports.CalculateTotalReq
 		return nil, fmt.Errorf("invalid total discount from pricer: %w", err)
 	}
 
+	// Pricers that predate order-level discounts leave the field empty
+	orderDiscount := decimal.Zero
+	if raw := resp.GetTotal().GetOrderDiscount(); raw != "" {
+		orderDiscount, err = decimal.NewFromString(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid order discount from pricer: %w", err)
+		}
+	}
+
 	finalPrice, err := decimal.NewFromString(resp.GetTotal().GetFinalPrice())
 	if err != nil {
 		return nil, fmt.Errorf("invalid final price from pricer: %w", err)
 	}
 
+	currency, err := pricing.NewCurrency(resp.GetTotal().GetCurrency())
+	if err != nil {
+		return nil, fmt.Errorf("invalid currency from pricer: %w", err)
+	}
+
 	// Calculate subtotal from items
 	subtotal := decimal.Zero
 	for _, item := range req.Cart.Items {
@@ -130,9 +171,11 @@ func (c *Client) CalculateTotal(ctx context.Context, req ports.CalculateTotalReq
 	return &ports.CalculateTotalResponse{
 		TotalTax:      totalTax,
 		TotalDiscount: totalDiscount,
+		OrderDiscount: orderDiscount,
 		FinalPrice:    finalPrice,
 		Subtotal:      subtotal,
 		Policies:      resp.GetTotal().GetPolicies(),
+		Currency:      currency,
 	}, nil
 }
 
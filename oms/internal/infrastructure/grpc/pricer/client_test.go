@@ -0,0 +1,76 @@
+package pricer
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	pricerv1 "github.com/shortlink-org/shop/oms/internal/infrastructure/grpc/pricer/v1"
+)
+
+// blockingCartService never answers until the call is canceled.
+type blockingCartService struct {
+	pricerv1.UnimplementedCartServiceServer
+}
+
+func (blockingCartService) CalculateTotal(ctx context.Context, _ *pricerv1.CalculateTotalRequest) (*pricerv1.CalculateTotalResponse, error) {
+	<-ctx.Done()
+
+	return nil, status.FromContextError(ctx.Err()).Err()
+}
+
+func newBlockingClient(t *testing.T, callTimeout time.Duration) *Client {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pricerv1.RegisterCartServiceServer(server, blockingCartService{})
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	client := newClient(pricerv1.NewCartServiceClient(conn), Config{
+		CallTimeout: callTimeout,
+		Resilience:  ResilienceConfig{MaxAttempts: 1},
+	})
+	client.conn = conn
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func TestClient_CalculateTotalTimesOutOnHungPricer(t *testing.T) {
+	t.Parallel()
+
+	const callTimeout = 100 * time.Millisecond
+
+	client := newBlockingClient(t, callTimeout)
+
+	started := time.Now()
+	_, err := client.CalculateTotal(context.Background(), ports.CalculateTotalRequest{
+		Cart: ports.CartData{CustomerID: uuid.New()},
+	})
+
+	require.Error(t, err)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	require.Less(t, time.Since(started), 10*callTimeout)
+}
@@ -0,0 +1,137 @@
+package pricer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sony/gobreaker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pricerv1 "github.com/shortlink-org/shop/oms/internal/infrastructure/grpc/pricer/v1"
+)
+
+const (
+	// DefaultMaxAttempts is the CalculateTotal attempt budget, the first call included.
+	DefaultMaxAttempts = 3
+	// DefaultRetryBackoff is the delay before the second attempt; it doubles on every further attempt.
+	DefaultRetryBackoff = 100 * time.Millisecond
+	// DefaultBreakerFailures is the number of consecutive transient failures that opens the breaker.
+	DefaultBreakerFailures = 5
+	// DefaultBreakerOpenTimeout is how long the open breaker fails fast before letting a probe through.
+	DefaultBreakerOpenTimeout = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned while the breaker fails calls fast after repeated pricer failures.
+var ErrCircuitOpen = errors.New("pricer circuit breaker is open")
+
+// ResilienceConfig tunes retries and the circuit breaker around the pricer calls.
+// Zero values select the Default* constants.
+type ResilienceConfig struct {
+	// MaxAttempts is the number of tries per CalculateTotal, the first call included
+	MaxAttempts int
+	// RetryBackoff is the delay before the second attempt, doubled for every further one
+	RetryBackoff time.Duration
+	// BreakerFailures is the number of consecutive transient failures that opens the breaker
+	BreakerFailures int
+	// BreakerOpenTimeout is how long the breaker stays open before a half-open probe
+	BreakerOpenTimeout time.Duration
+}
+
+func (c ResilienceConfig) withDefaults() ResilienceConfig {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = DefaultMaxAttempts
+	}
+
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = DefaultRetryBackoff
+	}
+
+	if c.BreakerFailures <= 0 {
+		c.BreakerFailures = DefaultBreakerFailures
+	}
+
+	if c.BreakerOpenTimeout <= 0 {
+		c.BreakerOpenTimeout = DefaultBreakerOpenTimeout
+	}
+
+	return c
+}
+
+func newBreaker(cfg ResilienceConfig) *gobreaker.CircuitBreaker {
+	return gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        "oms_pricer",
+		MaxRequests: 1,
+		Timeout:     cfg.BreakerOpenTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return int(counts.ConsecutiveFailures) >= cfg.BreakerFailures
+		},
+		// Only an unreachable pricer trips the breaker; rejected requests prove it is up.
+		IsSuccessful: func(err error) bool {
+			return err == nil || !isTransient(err)
+		},
+	})
+}
+
+// isTransient reports whether a pricer error is worth retrying.
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// calculateTotal calls the pricer through the breaker, retrying transient failures with exponential backoff.
+// Each attempt gets its own CallTimeout; the breaker failing fast ends the retries.
+func (c *Client) calculateTotal(ctx context.Context, req *pricerv1.CalculateTotalRequest) (*pricerv1.CalculateTotalResponse, error) {
+	var err error
+
+	for attempt := range c.resilience.MaxAttempts {
+		if attempt > 0 {
+			timer := time.NewTimer(c.resilience.RetryBackoff << (attempt - 1))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+
+				return nil, errors.Join(err, ctx.Err())
+			case <-timer.C:
+			}
+		}
+
+		var resp any
+
+		resp, err = c.breaker.Execute(func() (any, error) {
+			return c.callOnce(ctx, req)
+		})
+		if err == nil {
+			return resp.(*pricerv1.CalculateTotalResponse), nil //nolint:forcetypeassert // Execute returns what callOnce returned
+		}
+
+		if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+			return nil, fmt.Errorf("%w: %w", ErrCircuitOpen, err)
+		}
+
+		if !isTransient(err) {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("gave up after %d attempts: %w", c.resilience.MaxAttempts, err)
+}
+
+func (c *Client) callOnce(ctx context.Context, req *pricerv1.CalculateTotalRequest) (*pricerv1.CalculateTotalResponse, error) {
+	if c.callTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeoutCause(ctx, c.callTimeout,
+			fmt.Errorf("pricer CalculateTotal timeout (%s)", c.callTimeout)) //nolint:err113 // dynamic timeout in error message for diagnostics
+		defer cancel()
+	}
+
+	return c.client.CalculateTotal(ctx, req)
+}
@@ -0,0 +1,126 @@
+package pricer
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	pricerv1 "github.com/shortlink-org/shop/oms/internal/infrastructure/grpc/pricer/v1"
+)
+
+// flakyCartServiceClient fails the first failures calls with code, then answers.
+type flakyCartServiceClient struct {
+	failures int32
+	code     codes.Code
+	calls    atomic.Int32
+}
+
+func (f *flakyCartServiceClient) CalculateTotal(
+	_ context.Context,
+	_ *pricerv1.CalculateTotalRequest,
+	_ ...grpc.CallOption,
+) (*pricerv1.CalculateTotalResponse, error) {
+	if f.calls.Add(1) <= f.failures {
+		return nil, status.Error(f.code, "pricer is down")
+	}
+
+	return &pricerv1.CalculateTotalResponse{
+		Total: &pricerv1.CartTotal{
+			TotalTax:      "0",
+			TotalDiscount: "0",
+			FinalPrice:    "10",
+			Currency:      "USD",
+		},
+	}, nil
+}
+
+func newFlakyClient(fake *flakyCartServiceClient, resilience ResilienceConfig) *Client {
+	return newClient(fake, Config{Resilience: resilience})
+}
+
+func testCalculateTotalRequest() ports.CalculateTotalRequest {
+	return ports.CalculateTotalRequest{Cart: ports.CartData{CustomerID: uuid.New()}}
+}
+
+func TestClient_CalculateTotalRetriesTransientErrors(t *testing.T) {
+	t.Parallel()
+
+	fake := &flakyCartServiceClient{failures: 2, code: codes.Unavailable}
+	client := newFlakyClient(fake, ResilienceConfig{MaxAttempts: 3, RetryBackoff: time.Millisecond})
+
+	resp, err := client.CalculateTotal(context.Background(), testCalculateTotalRequest())
+	require.NoError(t, err)
+	require.Equal(t, "10", resp.FinalPrice.String())
+	require.EqualValues(t, 3, fake.calls.Load())
+}
+
+func TestClient_CalculateTotalGivesUpAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	fake := &flakyCartServiceClient{failures: 10, code: codes.DeadlineExceeded}
+	client := newFlakyClient(fake, ResilienceConfig{MaxAttempts: 2, RetryBackoff: time.Millisecond})
+
+	_, err := client.CalculateTotal(context.Background(), testCalculateTotalRequest())
+	require.Error(t, err)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	require.EqualValues(t, 2, fake.calls.Load())
+}
+
+func TestClient_CalculateTotalDoesNotRetryPermanentErrors(t *testing.T) {
+	t.Parallel()
+
+	fake := &flakyCartServiceClient{failures: 10, code: codes.InvalidArgument}
+	client := newFlakyClient(fake, ResilienceConfig{MaxAttempts: 3, RetryBackoff: time.Millisecond, BreakerFailures: 1})
+
+	for range 3 {
+		_, err := client.CalculateTotal(context.Background(), testCalculateTotalRequest())
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	require.EqualValues(t, 3, fake.calls.Load())
+	require.Equal(t, gobreaker.StateClosed, client.breaker.State(), "rejected requests must not trip the breaker")
+}
+
+func TestClient_CircuitBreakerOpensAndHalfOpens(t *testing.T) {
+	t.Parallel()
+
+	const openTimeout = 50 * time.Millisecond
+
+	fake := &flakyCartServiceClient{failures: 3, code: codes.Unavailable}
+	client := newFlakyClient(fake, ResilienceConfig{
+		MaxAttempts:        3,
+		RetryBackoff:       time.Millisecond,
+		BreakerFailures:    3,
+		BreakerOpenTimeout: openTimeout,
+	})
+
+	// Three consecutive failures open the breaker.
+	_, err := client.CalculateTotal(context.Background(), testCalculateTotalRequest())
+	require.Error(t, err)
+	require.Equal(t, gobreaker.StateOpen, client.breaker.State())
+
+	// While open, calls fail fast without reaching the pricer.
+	_, err = client.CalculateTotal(context.Background(), testCalculateTotalRequest())
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	require.EqualValues(t, 3, fake.calls.Load())
+
+	// After the open timeout a half-open probe goes through; the recovered pricer closes the breaker.
+	require.Eventually(t, func() bool {
+		return client.breaker.State() == gobreaker.StateHalfOpen
+	}, time.Second, 5*time.Millisecond)
+
+	resp, err := client.CalculateTotal(context.Background(), testCalculateTotalRequest())
+	require.NoError(t, err)
+	require.Equal(t, "10", resp.FinalPrice.String())
+	require.EqualValues(t, 4, fake.calls.Load())
+	require.Equal(t, gobreaker.StateClosed, client.breaker.State())
+}
@@ -26,7 +26,9 @@ type CartItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // UUID as a string
 	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"` // Decimal as a string to preserve precision
+	Price         string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`                                // Decimal as a string to preserve precision
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`                          // ISO-4217 code of price; empty means the request currency
+	TaxCategory   string                 `protobuf:"bytes,5,opt,name=tax_category,json=taxCategory,proto3" json:"tax_category,omitempty"` // Category tax policies branch on, e.g. "food"; empty means "standard"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,6 +84,20 @@ func (x *CartItem) GetPrice() string {
 	return ""
 }
 
+func (x *CartItem) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CartItem) GetTaxCategory() string {
+	if x != nil {
+		return x.TaxCategory
+	}
+	return ""
+}
+
 // Cart represents a customer's shopping cart
 type Cart struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -142,6 +158,8 @@ type CartTotal struct {
 	TotalDiscount string                 `protobuf:"bytes,2,opt,name=total_discount,json=totalDiscount,proto3" json:"total_discount,omitempty"` // Decimal as a string
 	FinalPrice    string                 `protobuf:"bytes,3,opt,name=final_price,json=finalPrice,proto3" json:"final_price,omitempty"`          // Decimal as a string
 	Policies      []string               `protobuf:"bytes,4,rep,name=policies,proto3" json:"policies,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`                                // ISO-4217 code all amounts are in
+	OrderDiscount string                 `protobuf:"bytes,8,opt,name=order_discount,json=orderDiscount,proto3" json:"order_discount,omitempty"` // Decimal as a string; order-level promotions applied after item discounts
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -204,12 +222,27 @@ func (x *CartTotal) GetPolicies() []string {
 	return nil
 }
 
+func (x *CartTotal) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CartTotal) GetOrderDiscount() string {
+	if x != nil {
+		return x.OrderDiscount
+	}
+	return ""
+}
+
 // CalculateTotalRequest is the request message for calculating cart totals
 type CalculateTotalRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Cart           *Cart                  `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
 	DiscountParams map[string]string      `protobuf:"bytes,2,rep,name=discount_params,json=discountParams,proto3" json:"discount_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Using string for simplicity
 	TaxParams      map[string]string      `protobuf:"bytes,3,rep,name=tax_params,json=taxParams,proto3" json:"tax_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                // Using string for simplicity
+	Currency       string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`                                                                                                             // ISO-4217 code of the cart; items must not mix currencies
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -265,6 +298,13 @@ func (x *CalculateTotalRequest) GetTaxParams() map[string]string {
 	return nil
 }
 
+func (x *CalculateTotalRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 // CalculateTotalResponse is the response message after calculating totals
 type CalculateTotalResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -314,28 +354,33 @@ var File_infrastructure_grpc_pricer_v1_pricer_proto protoreflect.FileDescriptor
 
 const file_infrastructure_grpc_pricer_v1_pricer_proto_rawDesc = "" +
 	"\n" +
-	"*infrastructure/grpc/pricer/v1/pricer.proto\x12\x04cart\"[\n" +
+	"*infrastructure/grpc/pricer/v1/pricer.proto\x12\x04cart\"\x9a\x01\n" +
 	"\bCartItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\tR\x05price\"M\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12!\n" +
+	"\ftax_category\x18\x05 \x01(\tR\vtaxCategory\"M\n" +
 	"\x04Cart\x12$\n" +
 	"\x05items\x18\x01 \x03(\v2\x0e.cart.CartItemR\x05items\x12\x1f\n" +
 	"\vcustomer_id\x18\x02 \x01(\tR\n" +
-	"customerId\"\x8c\x01\n" +
+	"customerId\"\xcf\x01\n" +
 	"\tCartTotal\x12\x1b\n" +
 	"\ttotal_tax\x18\x01 \x01(\tR\btotalTax\x12%\n" +
 	"\x0etotal_discount\x18\x02 \x01(\tR\rtotalDiscount\x12\x1f\n" +
 	"\vfinal_price\x18\x03 \x01(\tR\n" +
 	"finalPrice\x12\x1a\n" +
-	"\bpolicies\x18\x04 \x03(\tR\bpolicies\"\xdd\x02\n" +
+	"\bpolicies\x18\x04 \x03(\tR\bpolicies\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12%\n" +
+	"\x0eorder_discount\x18\b \x01(\tR\rorderDiscount\"\xf9\x02\n" +
 	"\x15CalculateTotalRequest\x12\x1e\n" +
 	"\x04cart\x18\x01 \x01(\v2\n" +
 	".cart.CartR\x04cart\x12X\n" +
 	"\x0fdiscount_params\x18\x02 \x03(\v2/.cart.CalculateTotalRequest.DiscountParamsEntryR\x0ediscountParams\x12I\n" +
 	"\n" +
-	"tax_params\x18\x03 \x03(\v2*.cart.CalculateTotalRequest.TaxParamsEntryR\ttaxParams\x1aA\n" +
+	"tax_params\x18\x03 \x03(\v2*.cart.CalculateTotalRequest.TaxParamsEntryR\ttaxParams\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x1aA\n" +
 	"\x13DiscountParamsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a<\n" +
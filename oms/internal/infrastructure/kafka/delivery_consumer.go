@@ -5,16 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/google/uuid"
 	"github.com/shortlink-org/go-sdk/logger"
+	sdkwatermill "github.com/shortlink-org/go-sdk/watermill"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	deliverycommon "github.com/shortlink-org/shop/oms/internal/domain/delivery/common/v1"
 	deliveryevents "github.com/shortlink-org/shop/oms/internal/domain/delivery/events/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
 )
 
 // DeliveryEventType represents the type of delivery event.
@@ -32,24 +35,39 @@ const (
 
 	// TopicDeliveryPackageStatus is the topic for delivery package status events.
 	TopicDeliveryPackageStatus = "delivery.package.status.v1"
+
+	// TopicDeliveryPackageStatusDLQ receives delivery events OMS rejects as unprocessable.
+	TopicDeliveryPackageStatusDLQ = TopicDeliveryPackageStatus + ".DLQ"
+
+	// DefaultCloseTimeout bounds how long Close waits for the event being handled to finish.
+	DefaultCloseTimeout = 10 * time.Second
+
+	// DefaultInitialOffset makes a new consumer group skip the status backlog; set
+	// WATERMILL_KAFKA_CONSUMER_INITIAL_OFFSET=earliest to replay it instead.
+	DefaultInitialOffset = "latest"
 )
 
 var (
 	errUnsupportedEventType = errors.New("unsupported or non-status event_type")
 	errConsumerClosed       = errors.New("consumer closed")
+
+	// ErrCloseTimeout is returned by Close when the in-flight event was still being handled at the deadline.
+	ErrCloseTimeout = errors.New("timed out waiting for in-flight delivery event")
 )
 
 // DeliveryStatusEvent represents a delivery status update from the Delivery service.
 type DeliveryStatusEvent struct {
-	MessageID           string                              `json:"-"`
-	PackageID           uuid.UUID                           `json:"package_id"`
-	OrderID             uuid.UUID                           `json:"order_id"`
-	CourierID           uuid.UUID                           `json:"courier_id"`
-	Status              string                              `json:"status"`
-	EventType           DeliveryEventType                   `json:"event_type"`
-	Reason              string                              `json:"reason,omitempty"`
-	Description         string                              `json:"reason_description,omitempty"`
-	OccurredAt          time.Time                           `json:"occurred_at"`
+	MessageID   string            `json:"-"`
+	PackageID   uuid.UUID         `json:"package_id"`
+	OrderID     uuid.UUID         `json:"order_id"`
+	CourierID   uuid.UUID         `json:"courier_id"`
+	Status      string            `json:"status"`
+	EventType   DeliveryEventType `json:"event_type"`
+	Reason      string            `json:"reason,omitempty"`
+	Description string            `json:"reason_description,omitempty"`
+	OccurredAt  time.Time         `json:"occurred_at"`
+	// NotDeliveredReason is Reason mapped to the OMS enum; set for NOT_DELIVERED events only.
+	NotDeliveredReason  commonv1.NotDeliveredReason         `json:"-"`
 	NotDeliveredDetails *deliverycommon.NotDeliveredDetails `json:"-"`
 	DeliveryLocation    *deliverycommon.Location            `json:"-"`
 }
@@ -66,20 +84,35 @@ type DeliveryConsumer struct {
 	log        logger.Logger
 	subscriber message.Subscriber
 	cancel     context.CancelCauseFunc
+
+	// inFlight tracks the consume loop so Close can wait for the event it is handling.
+	inFlight     sync.WaitGroup
+	cancelWork   context.CancelCauseFunc
+	closeTimeout time.Duration
+
+	// dlq receives events with an unknown not-delivered reason or schema version; nil drops them after logging.
+	dlq      message.Publisher
+	dlqTopic string
 }
 
 // NewDeliveryConsumer creates a new delivery consumer.
+// dlqPublisher is optional; without it rejected events are only logged.
 func NewDeliveryConsumer(
 	topic string,
 	subscriber message.Subscriber,
 	handler DeliveryEventHandler,
 	log logger.Logger,
+	dlqPublisher message.Publisher,
+	dlqTopic string,
 ) *DeliveryConsumer {
 	return &DeliveryConsumer{
-		topic:      topic,
-		handler:    handler,
-		log:        log,
-		subscriber: subscriber,
+		topic:        topic,
+		handler:      handler,
+		log:          log,
+		subscriber:   subscriber,
+		dlq:          dlqPublisher,
+		dlqTopic:     dlqTopic,
+		closeTimeout: DefaultCloseTimeout,
 	}
 }
 
@@ -93,22 +126,30 @@ func (c *DeliveryConsumer) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to subscribe to topic: %w", err)
 	}
 
+	// Handlers run on a context that survives the consume loop being stopped, so Close can let
+	// the event in progress finish its DB writes instead of interrupting them.
+	workCtx, cancelWork := context.WithCancelCause(context.WithoutCancel(ctx))
 	ctx, c.cancel = context.WithCancelCause(ctx)
+	c.cancelWork = cancelWork
 
-	go func() {
+	c.inFlight.Go(func() {
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case msg := <-messages:
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
 				if msg == nil {
 					continue
 				}
 
-				c.processMessage(ctx, msg)
+				c.processMessage(workCtx, msg)
 			}
 		}
-	}()
+	})
 
 	c.log.Info("Delivery consumer started")
 
@@ -134,7 +175,20 @@ func (c *DeliveryConsumer) processMessage(ctx context.Context, msg *message.Mess
 		return
 	}
 
+	err := checkEventSchema(msg.Metadata, SupportedSchemaMajor, ContentTypeProtobuf)
+	if err != nil {
+		c.rejectToDLQ(ctx, msg, err)
+
+		return
+	}
+
 	event, err := c.unmarshalDeliveryEvent(eventType, msg.Payload)
+	if errors.Is(err, ErrUnknownNotDeliveredReason) {
+		c.rejectToDLQ(ctx, msg, err)
+
+		return
+	}
+
 	if err != nil {
 		c.log.Error("Failed to unmarshal delivery event",
 			slog.Any("error", err),
@@ -164,6 +218,42 @@ func (c *DeliveryConsumer) processMessage(ctx context.Context, msg *message.Mess
 		slog.String("status", event.Status))
 }
 
+// rejectToDLQ moves an event OMS cannot interpret to the DLQ. The message is only
+// acked once the DLQ holds it, so a DLQ outage redelivers instead of losing the event.
+//
+//nolint:funcorder // unexported handler
+func (c *DeliveryConsumer) rejectToDLQ(ctx context.Context, msg *message.Message, cause error) {
+	if c.dlq == nil {
+		c.log.Error("Rejected delivery event, no DLQ configured",
+			slog.Any("error", cause),
+			slog.String("uuid", msg.UUID))
+		msg.Ack()
+
+		return
+	}
+
+	err := sdkwatermill.PublishDLQ(ctx, c.dlq, c.dlqTopic, sdkwatermill.DLQEvent{
+		FailedAt:    time.Now().UTC(),
+		Reason:      cause.Error(),
+		OriginalMsg: msg,
+		ServiceName: ConsumerGroupOMSDelivery,
+	})
+	if err != nil {
+		c.log.Error("Failed to publish rejected delivery event to DLQ",
+			slog.Any("error", err),
+			slog.String("uuid", msg.UUID))
+		msg.Nack()
+
+		return
+	}
+
+	c.log.Warn("Rejected delivery event to DLQ",
+		slog.Any("error", cause),
+		slog.String("uuid", msg.UUID),
+		slog.String("dlq_topic", c.dlqTopic))
+	msg.Ack()
+}
+
 // unmarshalDeliveryEvent decodes payload by event_type and maps to DeliveryStatusEvent.
 //
 //nolint:funcorder // unexported helper
@@ -339,6 +429,11 @@ func mapNotDeliveredToStatusEvent(e *deliveryevents.PackageNotDeliveredEvent) (D
 		desc = d.GetDescription()
 	}
 
+	reason, err := ParseNotDeliveredReason(reasonStr)
+	if err != nil {
+		return DeliveryStatusEvent{}, err
+	}
+
 	packageID, err := parseRequiredUUID(e.GetPackageId(), "package_id")
 	if err != nil {
 		return DeliveryStatusEvent{}, err
@@ -361,6 +456,7 @@ func mapNotDeliveredToStatusEvent(e *deliveryevents.PackageNotDeliveredEvent) (D
 		Status:              deliverycommon.PackageStatus_name[int32(e.GetStatus())],
 		EventType:           EventTypePackageNotDelivered,
 		Reason:              reasonStr,
+		NotDeliveredReason:  reason,
 		Description:         desc,
 		OccurredAt:          timestampToTime(e.GetOccurredAt()),
 		NotDeliveredDetails: e.GetNotDeliveredDetails(),
@@ -396,16 +492,54 @@ func timestampToTime(ts *timestamppb.Timestamp) time.Time {
 	return ts.AsTime()
 }
 
-// Close closes the consumer.
+// Close stops consuming and waits up to the close timeout for the event being handled to finish
+// before closing the subscriber. If the deadline passes, the handler's context is cancelled and
+// ErrCloseTimeout is returned.
 func (c *DeliveryConsumer) Close() error {
 	if c.cancel != nil {
 		c.cancel(errConsumerClosed)
 	}
 
+	var waitErr error
+	if !waitTimeout(&c.inFlight, c.closeTimeout) {
+		waitErr = fmt.Errorf("%w after %s", ErrCloseTimeout, c.closeTimeout)
+	}
+
+	if c.cancelWork != nil {
+		c.cancelWork(errConsumerClosed)
+	}
+
 	err := c.subscriber.Close()
 	if err != nil {
 		return fmt.Errorf("close subscriber: %w", err)
 	}
 
-	return nil
+	if c.dlq != nil {
+		err = c.dlq.Close()
+		if err != nil {
+			return fmt.Errorf("close dlq publisher: %w", err)
+		}
+	}
+
+	return waitErr
+}
+
+// waitTimeout waits for wg and reports whether it finished before timeout elapsed.
+func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+	done := make(chan struct{})
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
 }
@@ -1,16 +1,22 @@
 package kafka
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
 	"github.com/google/uuid"
+	"github.com/shortlink-org/go-sdk/logger"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	deliverycommon "github.com/shortlink-org/shop/oms/internal/domain/delivery/common/v1"
 	deliveryevents "github.com/shortlink-org/shop/oms/internal/domain/delivery/events/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
 )
 
 func TestDeliveryConsumer_UnmarshalDeliveryEvent_PackageAssigned(t *testing.T) {
@@ -36,3 +42,190 @@ func TestDeliveryConsumer_UnmarshalDeliveryEvent_PackageAssigned(t *testing.T) {
 	require.Equal(t, "PACKAGE_STATUS_ASSIGNED", statusEvent.Status)
 	require.Equal(t, occurredAt, statusEvent.OccurredAt)
 }
+
+func TestDeliveryConsumer_UnmarshalDeliveryEvent_PackageNotDelivered(t *testing.T) {
+	t.Parallel()
+
+	event := &deliveryevents.PackageNotDeliveredEvent{
+		PackageId: uuid.NewString(),
+		CourierId: uuid.NewString(),
+		Status:    deliverycommon.PackageStatus_PACKAGE_STATUS_NOT_DELIVERED,
+		NotDeliveredDetails: &deliverycommon.NotDeliveredDetails{
+			Reason:      deliverycommon.NotDeliveredReason_NOT_DELIVERED_REASON_WRONG_ADDRESS,
+			Description: "no such house",
+		},
+	}
+
+	payload, err := proto.Marshal(event)
+	require.NoError(t, err)
+
+	statusEvent, err := (&DeliveryConsumer{}).unmarshalDeliveryEvent("PackageNotDeliveredEvent", payload)
+	require.NoError(t, err)
+	require.Equal(t, EventTypePackageNotDelivered, statusEvent.EventType)
+	require.Equal(t, "NOT_DELIVERED_REASON_WRONG_ADDRESS", statusEvent.Reason)
+	require.Equal(t, commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_WRONG_ADDRESS, statusEvent.NotDeliveredReason)
+	require.Equal(t, "no such house", statusEvent.Description)
+}
+
+// recordingDeliveryHandler records the events passed to it.
+type recordingDeliveryHandler struct {
+	events []DeliveryStatusEvent
+}
+
+func (h *recordingDeliveryHandler) HandleDeliveryStatus(_ context.Context, event DeliveryStatusEvent) error {
+	h.events = append(h.events, event)
+	return nil
+}
+
+func TestDeliveryConsumer_UnknownNotDeliveredReasonGoesToDLQ(t *testing.T) {
+	t.Parallel()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	pubSub := gochannel.NewGoChannel(gochannel.Config{Persistent: true}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = pubSub.Close() })
+
+	handler := &recordingDeliveryHandler{}
+	consumer := NewDeliveryConsumer(TopicDeliveryPackageStatus, pubSub, handler, log, pubSub, TopicDeliveryPackageStatusDLQ)
+
+	payload, err := proto.Marshal(&deliveryevents.PackageNotDeliveredEvent{
+		PackageId: uuid.NewString(),
+		CourierId: uuid.NewString(),
+		Status:    deliverycommon.PackageStatus_PACKAGE_STATUS_NOT_DELIVERED,
+		NotDeliveredDetails: &deliverycommon.NotDeliveredDetails{
+			// A reason added by Delivery that OMS does not know yet
+			Reason: deliverycommon.NotDeliveredReason(42),
+		},
+	})
+	require.NoError(t, err)
+
+	msg := message.NewMessage(uuid.NewString(), payload)
+	msg.Metadata.Set(eventTypeHeader, "PackageNotDeliveredEvent")
+
+	consumer.processMessage(context.Background(), msg)
+
+	select {
+	case <-msg.Acked():
+	default:
+		t.Fatal("rejected message must be acked once it is in the DLQ")
+	}
+
+	require.Empty(t, handler.events, "unknown reason must not reach the order handler")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	dlqMessages, err := pubSub.Subscribe(ctx, TopicDeliveryPackageStatusDLQ)
+	require.NoError(t, err)
+
+	select {
+	case dlqMsg := <-dlqMessages:
+		require.Contains(t, dlqMsg.Metadata.Get("poison_reason"), ErrUnknownNotDeliveredReason.Error())
+		dlqMsg.Ack()
+	case <-ctx.Done():
+		t.Fatal("expected the rejected event in the DLQ")
+	}
+}
+
+// blockingDeliveryHandler blocks until release is closed or its context is cancelled.
+type blockingDeliveryHandler struct {
+	started  chan struct{}
+	release  chan struct{}
+	finished chan error
+}
+
+func newBlockingDeliveryHandler() *blockingDeliveryHandler {
+	return &blockingDeliveryHandler{
+		started:  make(chan struct{}),
+		release:  make(chan struct{}),
+		finished: make(chan error, 1),
+	}
+}
+
+func (h *blockingDeliveryHandler) HandleDeliveryStatus(ctx context.Context, _ DeliveryStatusEvent) error {
+	close(h.started)
+
+	select {
+	case <-h.release:
+		h.finished <- ctx.Err()
+	case <-ctx.Done():
+		h.finished <- ctx.Err()
+	}
+
+	return ctx.Err()
+}
+
+func startBlockedConsumer(t *testing.T, closeTimeout time.Duration) (*DeliveryConsumer, *blockingDeliveryHandler) {
+	t.Helper()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	pubSub := gochannel.NewGoChannel(gochannel.Config{Persistent: true}, watermill.NopLogger{})
+
+	handler := newBlockingDeliveryHandler()
+	consumer := NewDeliveryConsumer(TopicDeliveryPackageStatus, pubSub, handler, log, nil, TopicDeliveryPackageStatusDLQ)
+	consumer.closeTimeout = closeTimeout
+
+	require.NoError(t, consumer.Start(context.Background()))
+
+	payload, err := proto.Marshal(&deliveryevents.PackageAssignedEvent{
+		PackageId: uuid.NewString(),
+		CourierId: uuid.NewString(),
+		Status:    deliverycommon.PackageStatus_PACKAGE_STATUS_ASSIGNED,
+	})
+	require.NoError(t, err)
+
+	msg := message.NewMessage(uuid.NewString(), payload)
+	msg.Metadata.Set(eventTypeHeader, "PackageAssignedEvent")
+	require.NoError(t, pubSub.Publish(TopicDeliveryPackageStatus, msg))
+
+	select {
+	case <-handler.started:
+	case <-time.After(time.Second):
+		t.Fatal("handler was not invoked")
+	}
+
+	return consumer, handler
+}
+
+func TestDeliveryConsumer_CloseWaitsForInFlightHandler(t *testing.T) {
+	t.Parallel()
+
+	consumer, handler := startBlockedConsumer(t, time.Second)
+
+	closed := make(chan error, 1)
+
+	go func() { closed <- consumer.Close() }()
+
+	select {
+	case <-closed:
+		t.Fatal("Close returned while a handler was still running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(handler.release)
+
+	select {
+	case err := <-closed:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Close did not return after the handler finished")
+	}
+
+	require.NoError(t, <-handler.finished, "the handler context must stay live while Close waits")
+}
+
+func TestDeliveryConsumer_CloseTimesOut(t *testing.T) {
+	t.Parallel()
+
+	consumer, handler := startBlockedConsumer(t, 50*time.Millisecond)
+
+	start := time.Now()
+	err := consumer.Close()
+
+	require.ErrorIs(t, err, ErrCloseTimeout)
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	require.ErrorIs(t, <-handler.finished, context.Canceled, "the handler is cancelled once the timeout elapsed")
+}
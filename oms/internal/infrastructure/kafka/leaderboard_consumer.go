@@ -73,6 +73,15 @@ func (c *LeaderboardConsumer) Start(ctx context.Context) error {
 }
 
 func (c *LeaderboardConsumer) processMessage(ctx context.Context, msg *message.Message) {
+	if err := checkEventSchema(msg.Metadata, SupportedSchemaMajor, ContentTypeJSON); err != nil {
+		c.log.Error("rejected completed-order event with unsupported schema",
+			slog.String("uuid", msg.UUID),
+			slog.String("error", err.Error()))
+		msg.Ack()
+
+		return
+	}
+
 	var event orderevents.OrderCompleted
 
 	if err := c.marshaler.Unmarshal(msg, &event); err != nil {
@@ -0,0 +1,45 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+)
+
+// ErrUnknownNotDeliveredReason is returned for a NOT_DELIVERED event whose reason OMS does not understand.
+// Such events are routed to the DLQ instead of failing the order with a made-up reason.
+var ErrUnknownNotDeliveredReason = errors.New("unknown not-delivered reason")
+
+// notDeliveredReasons is the reason taxonomy shared with the delivery pipeline.
+// Delivery publishes proto enum names; courier-emulation uses the short names,
+// including CANCELLED and UNROUTABLE, which Delivery folds into OTHER.
+var notDeliveredReasons = map[string]commonv1.NotDeliveredReason{
+	"NOT_DELIVERED_REASON_CUSTOMER_NOT_AVAILABLE": commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_CUSTOMER_NOT_AVAILABLE,
+	"NOT_DELIVERED_REASON_WRONG_ADDRESS":          commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_WRONG_ADDRESS,
+	"NOT_DELIVERED_REASON_CUSTOMER_REFUSED":       commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_CUSTOMER_REFUSED,
+	"NOT_DELIVERED_REASON_ACCESS_DENIED":          commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_ACCESS_DENIED,
+	"NOT_DELIVERED_REASON_PACKAGE_DAMAGED":        commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_PACKAGE_DAMAGED,
+	"NOT_DELIVERED_REASON_OTHER":                  commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_OTHER,
+
+	"CUSTOMER_NOT_AVAILABLE": commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_CUSTOMER_NOT_AVAILABLE,
+	"WRONG_ADDRESS":          commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_WRONG_ADDRESS,
+	"CUSTOMER_REFUSED":       commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_CUSTOMER_REFUSED,
+	"ACCESS_DENIED":          commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_ACCESS_DENIED,
+	"PACKAGE_DAMAGED":        commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_PACKAGE_DAMAGED,
+	"OTHER":                  commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_OTHER,
+	"CANCELLED":              commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_OTHER,
+	"UNROUTABLE":             commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_OTHER,
+}
+
+// ParseNotDeliveredReason maps an incoming reason name to the canonical OMS enum.
+// An empty or UNSPECIFIED reason is unknown: a failed delivery must say why it failed.
+func ParseNotDeliveredReason(reason string) (commonv1.NotDeliveredReason, error) {
+	canonical, ok := notDeliveredReasons[reason]
+	if !ok {
+		return commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_UNSPECIFIED,
+			fmt.Errorf("%w: %q", ErrUnknownNotDeliveredReason, reason)
+	}
+
+	return canonical, nil
+}
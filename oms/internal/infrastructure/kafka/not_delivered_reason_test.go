@@ -0,0 +1,63 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	deliverycommon "github.com/shortlink-org/shop/oms/internal/domain/delivery/common/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+)
+
+func TestParseNotDeliveredReason_KnownReasons(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]commonv1.NotDeliveredReason{
+		// Delivery proto enum names
+		"NOT_DELIVERED_REASON_CUSTOMER_NOT_AVAILABLE": commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_CUSTOMER_NOT_AVAILABLE,
+		"NOT_DELIVERED_REASON_WRONG_ADDRESS":          commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_WRONG_ADDRESS,
+		"NOT_DELIVERED_REASON_CUSTOMER_REFUSED":       commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_CUSTOMER_REFUSED,
+		"NOT_DELIVERED_REASON_ACCESS_DENIED":          commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_ACCESS_DENIED,
+		"NOT_DELIVERED_REASON_PACKAGE_DAMAGED":        commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_PACKAGE_DAMAGED,
+		"NOT_DELIVERED_REASON_OTHER":                  commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_OTHER,
+		// courier-emulation names
+		"CUSTOMER_NOT_AVAILABLE": commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_CUSTOMER_NOT_AVAILABLE,
+		"WRONG_ADDRESS":          commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_WRONG_ADDRESS,
+		"CUSTOMER_REFUSED":       commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_CUSTOMER_REFUSED,
+		"ACCESS_DENIED":          commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_ACCESS_DENIED,
+		"PACKAGE_DAMAGED":        commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_PACKAGE_DAMAGED,
+		"OTHER":                  commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_OTHER,
+		"CANCELLED":              commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_OTHER,
+		"UNROUTABLE":             commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_OTHER,
+	}
+
+	for reason, want := range tests {
+		got, err := ParseNotDeliveredReason(reason)
+		require.NoError(t, err, reason)
+		require.Equal(t, want, got, reason)
+	}
+}
+
+func TestParseNotDeliveredReason_CoversEveryDeliveryEnumValue(t *testing.T) {
+	t.Parallel()
+
+	for value, name := range deliverycommon.NotDeliveredReason_name {
+		if deliverycommon.NotDeliveredReason(value) == deliverycommon.NotDeliveredReason_NOT_DELIVERED_REASON_UNSPECIFIED {
+			continue
+		}
+
+		got, err := ParseNotDeliveredReason(name)
+		require.NoError(t, err, "Delivery reason %s has no OMS mapping", name)
+		require.Equal(t, name, got.String())
+	}
+}
+
+func TestParseNotDeliveredReason_UnknownReasons(t *testing.T) {
+	t.Parallel()
+
+	for _, reason := range []string{"", "NOT_DELIVERED_REASON_UNSPECIFIED", "LOST_IN_SPACE", "customer_refused"} {
+		got, err := ParseNotDeliveredReason(reason)
+		require.ErrorIs(t, err, ErrUnknownNotDeliveredReason, reason)
+		require.Equal(t, commonv1.NotDeliveredReason_NOT_DELIVERED_REASON_UNSPECIFIED, got)
+	}
+}
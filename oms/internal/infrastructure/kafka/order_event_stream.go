@@ -0,0 +1,212 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+	logger "github.com/shortlink-org/go-sdk/logger"
+
+	orderevents "github.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+const (
+	ConsumerGroupOMSOrderStatusStream = "oms-order-status-stream"
+	// DefaultOrderEventStreamBuffer is the per-watcher channel capacity.
+	DefaultOrderEventStreamBuffer = 16
+)
+
+// orderEvent is the part of every order event the stream routes on.
+type orderEvent = ports.OrderEvent
+
+// orderEventTopics maps each order event topic to a constructor of its payload.
+var orderEventTopics = map[string]func() orderEvent{
+	(&orderevents.OrderCreated{}).EventType():                      func() orderEvent { return &orderevents.OrderCreated{} },
+	(&orderevents.OrderCancelled{}).EventType():                    func() orderEvent { return &orderevents.OrderCancelled{} },
+	(&orderevents.OrderCompleted{}).EventType():                    func() orderEvent { return &orderevents.OrderCompleted{} },
+	(&orderevents.OrderDeliveryRequestedEvent{}).EventType():       func() orderEvent { return &orderevents.OrderDeliveryRequestedEvent{} },
+	(&orderevents.OrderDeliveryStatusUpdatedEvent{}).EventType():   func() orderEvent { return &orderevents.OrderDeliveryStatusUpdatedEvent{} },
+	(&orderevents.OrderDeliveryStatusCorrectedEvent{}).EventType(): func() orderEvent { return &orderevents.OrderDeliveryStatusCorrectedEvent{} },
+	(&orderevents.OrderDeliveryCompletedEvent{}).EventType():       func() orderEvent { return &orderevents.OrderDeliveryCompletedEvent{} },
+	(&orderevents.OrderDeliveryFailedEvent{}).EventType():          func() orderEvent { return &orderevents.OrderDeliveryFailedEvent{} },
+}
+
+// OrderEventStream consumes the order event topics and fans each event out to the
+// watchers of its order. Watchers that fall behind are closed rather than blocking the consumer:
+// a watcher must never silently miss an event, least of all the terminal one.
+type OrderEventStream struct {
+	subscriber message.Subscriber
+	log        logger.Logger
+	marshaler  *cqrsmessage.JSONMarshaler
+	buffer     int
+	cancel     context.CancelCauseFunc
+
+	mu       sync.Mutex
+	watchers map[uuid.UUID]map[chan ports.Event]struct{}
+	closed   bool
+}
+
+// NewOrderEventStream creates an order event stream reading from subscriber.
+// A nil subscriber is allowed when Kafka is unavailable; such a stream must be closed
+// right away so watchers end instead of waiting for events that never come.
+func NewOrderEventStream(subscriber message.Subscriber, log logger.Logger, buffer int) *OrderEventStream {
+	return &OrderEventStream{
+		subscriber: subscriber,
+		log:        log,
+		marshaler:  cqrsmessage.NewJSONMarshaler(cqrsmessage.NewShortlinkNamer("oms")),
+		buffer:     buffer,
+		watchers:   make(map[uuid.UUID]map[chan ports.Event]struct{}),
+	}
+}
+
+// Start subscribes to every order event topic.
+func (s *OrderEventStream) Start(ctx context.Context) error {
+	ctx, s.cancel = context.WithCancelCause(ctx)
+
+	for topic, newEvent := range orderEventTopics {
+		messages, err := s.subscriber.Subscribe(ctx, topic)
+		if err != nil {
+			s.cancel(fmt.Errorf("subscribe %s: %w", topic, err))
+
+			return fmt.Errorf("subscribe order event topic %s: %w", topic, err)
+		}
+
+		go s.consume(ctx, messages, newEvent)
+	}
+
+	s.log.Info("Started order event stream", slog.Int("topics", len(orderEventTopics)))
+
+	return nil
+}
+
+func (s *OrderEventStream) consume(ctx context.Context, messages <-chan *message.Message, newEvent func() orderEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			s.processMessage(msg, newEvent())
+		}
+	}
+}
+
+func (s *OrderEventStream) processMessage(msg *message.Message, event orderEvent) {
+	// The stream is best-effort: undecodable messages are skipped, never redelivered.
+	defer msg.Ack()
+
+	if err := s.marshaler.Unmarshal(msg, event); err != nil {
+		s.log.Error("failed to decode order event for status stream",
+			slog.String("uuid", msg.UUID),
+			slog.String("error", err.Error()))
+
+		return
+	}
+
+	orderID, err := uuid.Parse(event.GetOrderId())
+	if err != nil {
+		s.log.Warn("order event without a valid order id",
+			slog.String("uuid", msg.UUID),
+			slog.String("event_type", event.EventType()))
+
+		return
+	}
+
+	s.Publish(orderID, event)
+}
+
+// Publish delivers the event to every watcher of the order without blocking.
+// A watcher whose buffer is full is closed: it still drains the buffered events, then
+// ends, so WatchStatus returns Unavailable and the client resubscribes from a fresh snapshot.
+func (s *OrderEventStream) Publish(orderID uuid.UUID, event ports.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.watchers[orderID] {
+		select {
+		case ch <- event:
+		default:
+			s.log.Warn("closing lagging order watcher",
+				slog.String("order_id", orderID.String()),
+				slog.String("event_type", event.EventType()))
+
+			delete(s.watchers[orderID], ch)
+			close(ch)
+		}
+	}
+
+	if len(s.watchers[orderID]) == 0 {
+		delete(s.watchers, orderID)
+	}
+}
+
+// SubscribeOrder implements ports.OrderEventStream.
+func (s *OrderEventStream) SubscribeOrder(orderID uuid.UUID) (<-chan ports.Event, func()) {
+	ch := make(chan ports.Event, s.buffer)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		close(ch)
+
+		return ch, func() {}
+	}
+
+	if s.watchers[orderID] == nil {
+		s.watchers[orderID] = make(map[chan ports.Event]struct{})
+	}
+	s.watchers[orderID][ch] = struct{}{}
+
+	unsubscribe := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.watchers[orderID][ch]; !ok {
+			return
+		}
+
+		delete(s.watchers[orderID], ch)
+		if len(s.watchers[orderID]) == 0 {
+			delete(s.watchers, orderID)
+		}
+		close(ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// Close stops consuming and closes every watcher channel.
+func (s *OrderEventStream) Close() error {
+	if s.cancel != nil {
+		s.cancel(fmt.Errorf("order event stream closed"))
+	}
+
+	s.mu.Lock()
+	s.closed = true
+	for orderID, channels := range s.watchers {
+		for ch := range channels {
+			close(ch)
+		}
+		delete(s.watchers, orderID)
+	}
+	s.mu.Unlock()
+
+	if s.subscriber == nil {
+		return nil
+	}
+
+	if err := s.subscriber.Close(); err != nil {
+		return fmt.Errorf("close order event stream subscriber: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/google/uuid"
+	"github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/require"
+
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	orderevents "github.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+func newTestOrderEventStream(t *testing.T) *OrderEventStream {
+	t.Helper()
+
+	subscriber := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+
+	return NewOrderEventStream(subscriber, nil, DefaultOrderEventStreamBuffer)
+}
+
+func TestOrderEventStream_RoutesDecodedEventsToOrderWatchers(t *testing.T) {
+	t.Parallel()
+
+	stream := newTestOrderEventStream(t)
+	orderID := uuid.New()
+
+	watched, unsubscribe := stream.SubscribeOrder(orderID)
+	defer unsubscribe()
+
+	other, unsubscribeOther := stream.SubscribeOrder(uuid.New())
+	defer unsubscribeOther()
+
+	event := &orderevents.OrderDeliveryStatusUpdatedEvent{
+		OrderId: orderID.String(),
+		Status:  commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+	}
+
+	msg, err := stream.marshaler.Marshal(context.Background(), event)
+	require.NoError(t, err)
+
+	topic := event.EventType()
+	stream.processMessage(msg, orderEventTopics[topic]())
+
+	got := <-watched
+	decoded, ok := got.(*orderevents.OrderDeliveryStatusUpdatedEvent)
+	require.True(t, ok, "unexpected event type %T", got)
+	require.Equal(t, orderID.String(), decoded.GetOrderId())
+	require.Equal(t, commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT, decoded.GetStatus())
+	require.Empty(t, other)
+}
+
+func TestOrderEventStream_ClosesLaggingWatcher(t *testing.T) {
+	t.Parallel()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	stream := NewOrderEventStream(nil, log, 1)
+	orderID := uuid.New()
+
+	lagging, unsubscribe := stream.SubscribeOrder(orderID)
+
+	inTransit := &orderevents.OrderDeliveryStatusUpdatedEvent{
+		OrderId: orderID.String(),
+		Status:  commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+	}
+	stream.Publish(orderID, inTransit)
+	// The buffer is full, so the terminal event closes the watcher instead of being dropped
+	stream.Publish(orderID, &orderevents.OrderCompleted{OrderId: orderID.String()})
+
+	got, open := <-lagging
+	require.True(t, open)
+	require.Equal(t, inTransit, got)
+
+	_, open = <-lagging
+	require.False(t, open, "lagging watcher must end instead of missing events")
+
+	// Unsubscribing a closed watcher must not panic.
+	unsubscribe()
+
+	// New watchers of the order still receive events.
+	fresh, unsubscribeFresh := stream.SubscribeOrder(orderID)
+	defer unsubscribeFresh()
+
+	stream.Publish(orderID, inTransit)
+	require.Equal(t, inTransit, <-fresh)
+}
+
+func TestOrderEventStream_CloseEndsWatchers(t *testing.T) {
+	t.Parallel()
+
+	stream := newTestOrderEventStream(t)
+
+	events, unsubscribe := stream.SubscribeOrder(uuid.New())
+	require.NoError(t, stream.Close())
+
+	_, open := <-events
+	require.False(t, open)
+
+	// Unsubscribing after Close and subscribing to a closed stream must not panic.
+	unsubscribe()
+
+	late, _ := stream.SubscribeOrder(uuid.New())
+	_, open = <-late
+	require.False(t, open)
+
+	var _ ports.OrderEventStream = stream
+}
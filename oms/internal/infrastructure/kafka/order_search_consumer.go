@@ -0,0 +1,123 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+	logger "github.com/shortlink-org/go-sdk/logger"
+
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+const ConsumerGroupOMSOrderSearch = "oms-order-search-consumer"
+
+// OrderChangedHandler applies an order event to a projection.
+type OrderChangedHandler interface {
+	Handle(ctx context.Context, event ports.OrderEvent) error
+}
+
+// OrderSearchConsumer consumes every order event topic and feeds the order search projection.
+// Failed events are nacked for redelivery; the projection ignores versions it already has.
+type OrderSearchConsumer struct {
+	subscriber message.Subscriber
+	handler    OrderChangedHandler
+	log        logger.Logger
+	marshaler  *cqrsmessage.JSONMarshaler
+	cancel     context.CancelCauseFunc
+}
+
+func NewOrderSearchConsumer(
+	subscriber message.Subscriber,
+	handler OrderChangedHandler,
+	log logger.Logger,
+) *OrderSearchConsumer {
+	return &OrderSearchConsumer{
+		subscriber: subscriber,
+		handler:    handler,
+		log:        log,
+		marshaler:  cqrsmessage.NewJSONMarshaler(cqrsmessage.NewShortlinkNamer("oms")),
+	}
+}
+
+// Start subscribes to every order event topic.
+func (c *OrderSearchConsumer) Start(ctx context.Context) error {
+	ctx, c.cancel = context.WithCancelCause(ctx)
+
+	for topic, newEvent := range orderEventTopics {
+		messages, err := c.subscriber.Subscribe(ctx, topic)
+		if err != nil {
+			c.cancel(fmt.Errorf("subscribe %s: %w", topic, err))
+
+			return fmt.Errorf("subscribe order search topic %s: %w", topic, err)
+		}
+
+		go c.consume(ctx, messages, newEvent)
+	}
+
+	c.log.Info("Started order search consumer", slog.Int("topics", len(orderEventTopics)))
+
+	return nil
+}
+
+func (c *OrderSearchConsumer) consume(ctx context.Context, messages <-chan *message.Message, newEvent func() orderEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			c.processMessage(ctx, msg, newEvent())
+		}
+	}
+}
+
+func (c *OrderSearchConsumer) processMessage(ctx context.Context, msg *message.Message, event orderEvent) {
+	if err := checkEventSchema(msg.Metadata, SupportedSchemaMajor, ContentTypeJSON); err != nil {
+		c.log.Error("rejected order event with unsupported schema",
+			slog.String("uuid", msg.UUID),
+			slog.String("error", err.Error()))
+		msg.Ack()
+
+		return
+	}
+
+	if err := c.marshaler.Unmarshal(msg, event); err != nil {
+		c.log.Error("failed to decode order event for order search",
+			slog.String("uuid", msg.UUID),
+			slog.String("error", err.Error()))
+		msg.Ack()
+
+		return
+	}
+
+	if err := c.handler.Handle(ctx, event); err != nil {
+		c.log.Error("failed to apply order search projection",
+			slog.String("uuid", msg.UUID),
+			slog.String("order_id", event.GetOrderId()),
+			slog.String("event_type", event.EventType()),
+			slog.String("error", err.Error()))
+		msg.Nack()
+
+		return
+	}
+
+	msg.Ack()
+}
+
+func (c *OrderSearchConsumer) Close() error {
+	if c.cancel != nil {
+		c.cancel(fmt.Errorf("order search consumer closed"))
+	}
+
+	if err := c.subscriber.Close(); err != nil {
+		return fmt.Errorf("close order search subscriber: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/google/uuid"
+	logger "github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/require"
+
+	orderevents "github.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+type recordingOrderChangedHandler struct {
+	events []ports.OrderEvent
+	err    error
+}
+
+func (h *recordingOrderChangedHandler) Handle(_ context.Context, event ports.OrderEvent) error {
+	h.events = append(h.events, event)
+
+	return h.err
+}
+
+func newTestOrderSearchConsumer(t *testing.T, handler OrderChangedHandler) *OrderSearchConsumer {
+	t.Helper()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	subscriber := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+
+	return NewOrderSearchConsumer(subscriber, handler, log)
+}
+
+func TestOrderSearchConsumer_HandlesDecodedEvents(t *testing.T) {
+	t.Parallel()
+
+	handler := &recordingOrderChangedHandler{}
+	consumer := newTestOrderSearchConsumer(t, handler)
+
+	event := &orderevents.OrderCancelled{OrderId: uuid.NewString(), Reason: "customer request"}
+	msg, err := consumer.marshaler.Marshal(context.Background(), event)
+	require.NoError(t, err)
+
+	consumer.processMessage(context.Background(), msg, orderEventTopics[event.EventType()]())
+
+	require.Len(t, handler.events, 1)
+	decoded, ok := handler.events[0].(*orderevents.OrderCancelled)
+	require.True(t, ok, "unexpected event type %T", handler.events[0])
+	require.Equal(t, event.GetOrderId(), decoded.GetOrderId())
+
+	select {
+	case <-msg.Acked():
+	default:
+		t.Fatal("message was not acked")
+	}
+}
+
+func TestOrderSearchConsumer_NacksFailedProjection(t *testing.T) {
+	t.Parallel()
+
+	handler := &recordingOrderChangedHandler{err: errors.New("database down")}
+	consumer := newTestOrderSearchConsumer(t, handler)
+
+	event := &orderevents.OrderCompleted{OrderId: uuid.NewString()}
+	msg, err := consumer.marshaler.Marshal(context.Background(), event)
+	require.NoError(t, err)
+
+	consumer.processMessage(context.Background(), msg, orderEventTopics[event.EventType()]())
+
+	select {
+	case <-msg.Nacked():
+	default:
+		t.Fatal("message was not nacked")
+	}
+}
@@ -0,0 +1,55 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+// Kafka headers describing the payload schema of an event.
+const (
+	schemaVersionHeader = "schema_version"
+	contentTypeHeader   = "content_type"
+)
+
+// Content types OMS consumers can decode.
+const (
+	ContentTypeProtobuf = "application/x-protobuf"
+	ContentTypeJSON     = "application/json"
+)
+
+// SupportedSchemaMajor is the major schema version OMS consumers decode; it matches the .v1 topic suffix.
+const SupportedSchemaMajor = 1
+
+var (
+	// ErrUnsupportedSchemaVersion is returned for events whose schema_version major OMS cannot decode.
+	ErrUnsupportedSchemaVersion = errors.New("unsupported schema version")
+	// ErrUnsupportedContentType is returned for events whose content_type OMS cannot decode.
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+)
+
+// checkEventSchema validates the schema headers of msg against the major version and content type
+// a consumer decodes. Missing headers are accepted so producers that predate them keep working;
+// minor versions are additive and always accepted.
+func checkEventSchema(metadata message.Metadata, wantMajor int, wantContentType string) error {
+	if contentType := metadata.Get(contentTypeHeader); contentType != "" && contentType != wantContentType {
+		return fmt.Errorf("%w: %q (want %q)", ErrUnsupportedContentType, contentType, wantContentType)
+	}
+
+	version := metadata.Get(schemaVersionHeader)
+	if version == "" {
+		return nil
+	}
+
+	major, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), ".")
+
+	n, err := strconv.Atoi(major)
+	if err != nil || n != wantMajor {
+		return fmt.Errorf("%w: %q (want major %d)", ErrUnsupportedSchemaVersion, version, wantMajor)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/google/uuid"
+	"github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	deliverycommon "github.com/shortlink-org/shop/oms/internal/domain/delivery/common/v1"
+	deliveryevents "github.com/shortlink-org/shop/oms/internal/domain/delivery/events/v1"
+)
+
+func TestCheckEventSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		schemaVersion string
+		contentType   string
+		wantErr       error
+	}{
+		{name: "no headers"},
+		{name: "same major", schemaVersion: "1", contentType: ContentTypeProtobuf},
+		{name: "newer minor", schemaVersion: "1.3"},
+		{name: "v prefix", schemaVersion: "v1.0"},
+		{name: "newer major", schemaVersion: "2.0", wantErr: ErrUnsupportedSchemaVersion},
+		{name: "malformed version", schemaVersion: "latest", wantErr: ErrUnsupportedSchemaVersion},
+		{name: "other content type", schemaVersion: "1.0", contentType: ContentTypeJSON, wantErr: ErrUnsupportedContentType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			metadata := message.Metadata{}
+			if tt.schemaVersion != "" {
+				metadata.Set(schemaVersionHeader, tt.schemaVersion)
+			}
+
+			if tt.contentType != "" {
+				metadata.Set(contentTypeHeader, tt.contentType)
+			}
+
+			err := checkEventSchema(metadata, SupportedSchemaMajor, ContentTypeProtobuf)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeliveryConsumer_SchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	payload, err := proto.Marshal(&deliveryevents.PackageAssignedEvent{
+		PackageId: uuid.NewString(),
+		CourierId: uuid.NewString(),
+		Status:    deliverycommon.PackageStatus_PACKAGE_STATUS_ASSIGNED,
+	})
+	require.NoError(t, err)
+
+	newMessage := func(schemaVersion string) *message.Message {
+		msg := message.NewMessage(uuid.NewString(), payload)
+		msg.Metadata.Set(eventTypeHeader, "PackageAssignedEvent")
+		msg.Metadata.Set(schemaVersionHeader, schemaVersion)
+		msg.Metadata.Set(contentTypeHeader, ContentTypeProtobuf)
+
+		return msg
+	}
+
+	t.Run("supported major is handled", func(t *testing.T) {
+		t.Parallel()
+
+		handler := &recordingDeliveryHandler{}
+		consumer := NewDeliveryConsumer(TopicDeliveryPackageStatus, nil, handler, log, nil, TopicDeliveryPackageStatusDLQ)
+
+		consumer.processMessage(context.Background(), newMessage("1.1"))
+
+		require.Len(t, handler.events, 1)
+	})
+
+	t.Run("unknown major goes to the DLQ", func(t *testing.T) {
+		t.Parallel()
+
+		pubSub := gochannel.NewGoChannel(gochannel.Config{Persistent: true}, watermill.NopLogger{})
+		t.Cleanup(func() { _ = pubSub.Close() })
+
+		handler := &recordingDeliveryHandler{}
+		consumer := NewDeliveryConsumer(TopicDeliveryPackageStatus, pubSub, handler, log, pubSub, TopicDeliveryPackageStatusDLQ)
+
+		msg := newMessage("2.0")
+		consumer.processMessage(context.Background(), msg)
+
+		select {
+		case <-msg.Acked():
+		default:
+			t.Fatal("rejected message must be acked once it is in the DLQ")
+		}
+
+		require.Empty(t, handler.events, "unknown schema version must not reach the order handler")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		dlqMessages, err := pubSub.Subscribe(ctx, TopicDeliveryPackageStatusDLQ)
+		require.NoError(t, err)
+
+		select {
+		case dlqMsg := <-dlqMessages:
+			require.Contains(t, dlqMsg.Metadata.Get("poison_reason"), ErrUnsupportedSchemaVersion.Error())
+			dlqMsg.Ack()
+		case <-ctx.Done():
+			t.Fatal("expected the rejected event in the DLQ")
+		}
+	})
+}
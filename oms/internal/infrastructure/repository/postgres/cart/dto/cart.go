@@ -1,11 +1,13 @@
 package dto
 
 import (
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 
 	cart "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
 	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
 	itemsv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/items/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/pricing"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/cart/schema/queries"
 )
 
@@ -21,8 +23,29 @@ func ToDomain(row queries.OmsCart, items []queries.GetCartItemsRow) *cart.State
 			continue
 		}
 
+		item, err = item.WithNote(i.Note.String)
+		if err != nil {
+			continue
+		}
+
+		item = item.WithCurrency(pricing.Currency(i.Currency.String)).WithTaxCategory(i.TaxCategory.String)
+
 		domainItems = append(domainItems, item)
 	}
 
-	return cart.Reconstitute(row.CustomerID, domainItems, int(row.Version))
+	state := cart.Reconstitute(row.CustomerID, domainItems, int(row.Version), pricingSnapshotToDomain(row.PricingSnapshot), row.GiftMessage.String)
+
+	return state.WithLastReset(cart.ResetRecord{
+		Reason: cart.ResetReason(row.LastResetReason.String),
+		At:     row.LastResetAt.Time,
+	})
+}
+
+// LastResetToDB converts the last reset of a cart to its nullable columns; a zero record stores NULLs.
+func LastResetToDB(record cart.ResetRecord) (pgtype.Text, pgtype.Timestamptz) {
+	if record.IsZero() {
+		return pgtype.Text{}, pgtype.Timestamptz{}
+	}
+
+	return pgtype.Text{String: string(record.Reason), Valid: true}, pgtype.Timestamptz{Time: record.At, Valid: true}
 }
@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	cart "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
+	pricing "github.com/shortlink-org/shop/oms/internal/domain/pricing"
+)
+
+// pricingSnapshot is the JSONB shape of oms.carts.pricing_snapshot.
+type pricingSnapshot struct {
+	Subtotal      decimal.Decimal `json:"subtotal"`
+	TotalDiscount decimal.Decimal `json:"total_discount"`
+	OrderDiscount decimal.Decimal `json:"order_discount"`
+	TotalTax      decimal.Decimal `json:"total_tax"`
+	FinalPrice    decimal.Decimal `json:"final_price"`
+	Currency      string          `json:"currency,omitempty"`
+	Policies      []string        `json:"policies,omitempty"`
+	Items         []pricedItem    `json:"items,omitempty"`
+	PricedAt      time.Time       `json:"priced_at"`
+	ExpiresAt     time.Time       `json:"expires_at"`
+}
+
+// pricedItem is one entry of the pricer's per-item breakdown.
+type pricedItem struct {
+	GoodID    uuid.UUID       `json:"good_id"`
+	UnitPrice decimal.Decimal `json:"unit_price"`
+}
+
+// PricingSnapshotToJSON encodes the cart price lock for storage; nil stores NULL.
+func PricingSnapshotToJSON(snapshot *cart.PricingSnapshot) ([]byte, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	totals := snapshot.GetTotals()
+
+	items := make([]pricedItem, 0, len(totals.Items))
+	for _, item := range totals.Items {
+		items = append(items, pricedItem{GoodID: item.GoodID, UnitPrice: item.UnitPrice})
+	}
+
+	payload, err := json.Marshal(pricingSnapshot{
+		Subtotal:      totals.Subtotal,
+		TotalDiscount: totals.TotalDiscount,
+		OrderDiscount: totals.OrderDiscount,
+		TotalTax:      totals.TotalTax,
+		FinalPrice:    totals.FinalPrice,
+		Currency:      totals.Currency.String(),
+		Policies:      totals.Policies,
+		Items:         items,
+		PricedAt:      snapshot.GetPricedAt(),
+		ExpiresAt:     snapshot.GetExpiresAt(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal pricing snapshot: %w", err)
+	}
+
+	return payload, nil
+}
+
+// pricingSnapshotToDomain decodes a stored price lock. An unreadable snapshot is
+// dropped, so checkout prices the cart afresh instead of failing to load it.
+func pricingSnapshotToDomain(payload []byte) *cart.PricingSnapshot {
+	if len(payload) == 0 {
+		return nil
+	}
+
+	var stored pricingSnapshot
+
+	err := json.Unmarshal(payload, &stored)
+	if err != nil {
+		return nil
+	}
+
+	currency, err := pricing.NewCurrency(stored.Currency)
+	if err != nil {
+		return nil
+	}
+
+	items := make([]cart.PricedItem, 0, len(stored.Items))
+	for _, item := range stored.Items {
+		items = append(items, cart.PricedItem{GoodID: item.GoodID, UnitPrice: item.UnitPrice})
+	}
+
+	snapshot := cart.NewPricingSnapshot(cart.PricingTotals{
+		Subtotal:      stored.Subtotal,
+		TotalDiscount: stored.TotalDiscount,
+		OrderDiscount: stored.OrderDiscount,
+		TotalTax:      stored.TotalTax,
+		FinalPrice:    stored.FinalPrice,
+		Currency:      currency,
+		Policies:      stored.Policies,
+		Items:         items,
+	}, stored.PricedAt, stored.ExpiresAt)
+
+	return &snapshot
+}
@@ -19,7 +19,8 @@ func cloneCartState(state *cart.State) *cart.State {
 		return nil
 	}
 
-	return cart.Reconstitute(state.GetCustomerId(), state.GetItems(), state.GetVersion())
+	return cart.Reconstitute(state.GetCustomerId(), state.GetItems(), state.GetVersion(), state.GetPricingSnapshot(), state.GetGiftMessage()).
+		WithLastReset(state.GetLastReset())
 }
 
 // Load retrieves a cart by customer ID.
@@ -33,6 +34,20 @@ func (s *Store) Load(ctx context.Context, customerID uuid.UUID) (*cart.State, er
 	}
 
 	// Cache miss - fetch from database
+	result, err := s.loadFromDB(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store in L1 cache: cost = base + items * per-item cost
+	cost := int64(100 + len(result.GetItems())*50)
+	s.cache.SetWithTTL(cacheKey, cloneCartState(result), cost, cacheTTL)
+
+	return result, nil
+}
+
+// loadFromDB reads a cart inside the transaction from context, bypassing the L1 cache.
+func (s *Store) loadFromDB(ctx context.Context, customerID uuid.UUID) (*cart.State, error) {
 	pgxTx := uow.FromContext(ctx)
 	if pgxTx == nil {
 		return nil, ErrTransactionRequired
@@ -56,11 +71,5 @@ func (s *Store) Load(ctx context.Context, customerID uuid.UUID) (*cart.State, er
 		return nil, domain.WrapUnavailable("GetCartItems", err)
 	}
 
-	result := dto.ToDomain(row, items)
-
-	// Store in L1 cache: cost = base + items * per-item cost
-	cost := int64(100 + len(items)*50)
-	s.cache.SetWithTTL(cacheKey, cloneCartState(result), cost, cacheTTL)
-
-	return result, nil
+	return dto.ToDomain(row, items), nil
 }
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	cart "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/cart/dto"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/cart/schema/queries"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
+)
+
+// LoadMany retrieves the carts of several customers in two queries, one for the carts and one for their items.
+// Customers without a cart are omitted from the map.
+// Bypasses the L1 cache so batch jobs neither see stale carts nor evict the hot ones.
+// Requires transaction in context (use UnitOfWork.Begin()).
+func (s *Store) LoadMany(ctx context.Context, customerIDs []uuid.UUID) (map[uuid.UUID]*cart.State, error) {
+	pgxTx := uow.FromContext(ctx)
+	if pgxTx == nil {
+		return nil, ErrTransactionRequired
+	}
+
+	result := make(map[uuid.UUID]*cart.State, len(customerIDs))
+	if len(customerIDs) == 0 {
+		return result, nil
+	}
+
+	qtx := s.query.WithTx(pgxTx)
+
+	rows, err := qtx.GetCartsByCustomerIDs(ctx, customerIDs)
+	if err != nil {
+		return nil, domain.WrapUnavailable("GetCartsByCustomerIDs", err)
+	}
+
+	if len(rows) == 0 {
+		return result, nil
+	}
+
+	cartIDs := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		cartIDs = append(cartIDs, row.CustomerID)
+	}
+
+	itemRows, err := qtx.GetCartItemsByCartIDs(ctx, cartIDs)
+	if err != nil {
+		return nil, domain.WrapUnavailable("GetCartItemsByCartIDs", err)
+	}
+
+	items := make(map[uuid.UUID][]queries.GetCartItemsRow, len(rows))
+	for _, item := range itemRows {
+		items[item.CartID] = append(items[item.CartID], queries.GetCartItemsRow{
+			GoodID:      item.GoodID,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			Discount:    item.Discount,
+			Note:        item.Note,
+			Currency:    item.Currency,
+			TaxCategory: item.TaxCategory,
+		})
+	}
+
+	for _, row := range rows {
+		result[row.CustomerID] = dto.ToDomain(row, items[row.CustomerID])
+	}
+
+	return result, nil
+}
@@ -0,0 +1,2 @@
+ALTER TABLE oms.carts
+    DROP COLUMN IF EXISTS pricing_snapshot;
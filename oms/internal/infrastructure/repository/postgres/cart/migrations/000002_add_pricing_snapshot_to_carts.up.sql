@@ -0,0 +1,4 @@
+ALTER TABLE oms.carts
+    ADD COLUMN IF NOT EXISTS pricing_snapshot JSONB;
+
+COMMENT ON COLUMN oms.carts.pricing_snapshot IS 'Price lock taken at review time (totals, priced_at, expires_at); NULL when none';
@@ -0,0 +1,5 @@
+ALTER TABLE oms.cart_items
+    DROP COLUMN IF EXISTS note;
+
+ALTER TABLE oms.carts
+    DROP COLUMN IF EXISTS gift_message;
@@ -0,0 +1,8 @@
+ALTER TABLE oms.carts
+    ADD COLUMN IF NOT EXISTS gift_message TEXT;
+
+ALTER TABLE oms.cart_items
+    ADD COLUMN IF NOT EXISTS note TEXT;
+
+COMMENT ON COLUMN oms.carts.gift_message IS 'Gift message for the whole cart; NULL when none';
+COMMENT ON COLUMN oms.cart_items.note IS 'Customer note for the item (e.g. gift wrapping instructions); NULL when none';
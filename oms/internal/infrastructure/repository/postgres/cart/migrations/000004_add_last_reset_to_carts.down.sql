@@ -0,0 +1,3 @@
+ALTER TABLE oms.carts
+    DROP COLUMN IF EXISTS last_reset_at,
+    DROP COLUMN IF EXISTS last_reset_reason;
@@ -0,0 +1,6 @@
+ALTER TABLE oms.carts
+    ADD COLUMN IF NOT EXISTS last_reset_reason TEXT,
+    ADD COLUMN IF NOT EXISTS last_reset_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN oms.carts.last_reset_reason IS 'Why the cart was last emptied (CHECKOUT, ABANDONED, ADMIN); NULL when never reset with a reason';
+COMMENT ON COLUMN oms.carts.last_reset_at IS 'When the cart was last emptied; NULL when never reset with a reason';
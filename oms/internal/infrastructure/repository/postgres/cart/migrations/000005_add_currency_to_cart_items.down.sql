@@ -0,0 +1,2 @@
+ALTER TABLE oms.cart_items
+    DROP COLUMN IF EXISTS currency;
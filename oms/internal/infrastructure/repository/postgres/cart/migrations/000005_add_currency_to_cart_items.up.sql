@@ -0,0 +1,4 @@
+ALTER TABLE oms.cart_items
+    ADD COLUMN IF NOT EXISTS currency CHAR(3);
+
+COMMENT ON COLUMN oms.cart_items.currency IS 'ISO-4217 currency of price and discount; NULL when not specified';
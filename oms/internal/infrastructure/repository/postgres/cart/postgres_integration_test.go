@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -15,6 +16,7 @@ import (
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
 	cart "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
 	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/pricing"
 	cartrepo "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/cart"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/testhelpers"
 	uowpg "github.com/shortlink-org/shop/oms/pkg/uow/postgres"
@@ -27,7 +29,11 @@ CREATE TABLE IF NOT EXISTS oms.carts (
     customer_id UUID PRIMARY KEY,
     version     INT NOT NULL DEFAULT 1,
     created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    pricing_snapshot JSONB,
+    gift_message TEXT,
+    last_reset_reason TEXT,
+    last_reset_at TIMESTAMPTZ
 );
 
 CREATE TABLE IF NOT EXISTS oms.cart_items (
@@ -36,6 +42,9 @@ CREATE TABLE IF NOT EXISTS oms.cart_items (
     quantity  INT NOT NULL CHECK (quantity > 0),
     price     DECIMAL(12,2) NOT NULL,
     discount  DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (discount >= 0),
+    note      TEXT,
+    currency  CHAR(3),
+    tax_category TEXT,
     PRIMARY KEY (cart_id, good_id)
 );
 `
@@ -105,6 +114,162 @@ func TestCart_SaveAndLoad(t *testing.T) {
 	assert.True(t, items[0].GetDiscount().Equal(decimal.NewFromFloat(2.00)))
 }
 
+func TestCart_PricingSnapshotRoundTrip(t *testing.T) {
+	store, uow, _ := setupCartTest(t)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+
+	goodID := uuid.New()
+
+	cartState := cart.New(customerID)
+	err := cartState.AddItem(mustNewItem(t, goodID, 1, decimal.NewFromFloat(10.00), decimal.Zero))
+	require.NoError(t, err)
+
+	pricedAt := time.Now().UTC().Truncate(time.Microsecond)
+	cartState.SnapshotPricing(cart.PricingTotals{
+		Subtotal:   decimal.NewFromFloat(10.00),
+		TotalTax:   decimal.NewFromFloat(1.50),
+		FinalPrice: decimal.NewFromFloat(11.50),
+		Currency:   "EUR",
+		Policies:   []string{"vat"},
+		Items:      []cart.PricedItem{{GoodID: goodID, UnitPrice: decimal.NewFromFloat(10.00)}},
+	}, pricedAt)
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(txCtx, cartState))
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	loaded, err := store.Load(txCtx2, customerID)
+	require.NoError(t, err)
+
+	snapshot, ok := loaded.ValidPricingSnapshot(pricedAt)
+	require.True(t, ok, "the price lock must survive a save/load round trip")
+	assert.True(t, snapshot.GetTotals().FinalPrice.Equal(decimal.NewFromFloat(11.50)))
+	assert.Equal(t, []string{"vat"}, snapshot.GetTotals().Policies)
+	require.Len(t, snapshot.GetTotals().Items, 1, "the per-item breakdown is kept for reconciliation")
+	assert.Equal(t, goodID, snapshot.GetTotals().Items[0].GoodID)
+	assert.True(t, snapshot.GetTotals().Items[0].UnitPrice.Equal(decimal.NewFromFloat(10.00)))
+	assert.True(t, pricedAt.Add(cart.PricingSnapshotTTL).Equal(snapshot.GetExpiresAt()))
+}
+
+func TestCart_NotesRoundTrip(t *testing.T) {
+	store, uow, pc := setupCartTest(t)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	notedGoodID := uuid.New()
+
+	cartState := cart.New(customerID)
+	noted, err := mustNewItem(t, notedGoodID, 1, decimal.NewFromFloat(10.00), decimal.Zero).WithNote("wrap in blue paper")
+	require.NoError(t, err)
+	require.NoError(t, cartState.AddItem(noted))
+	require.NoError(t, cartState.AddItem(mustNewItem(t, uuid.New(), 1, decimal.NewFromFloat(5.00), decimal.Zero)))
+	require.NoError(t, cartState.SetGiftMessage("Happy birthday!"))
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(txCtx, cartState))
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	loaded, err := store.Load(txCtx2, customerID)
+	require.NoError(t, err)
+	assert.Equal(t, "Happy birthday!", loaded.GetGiftMessage())
+
+	for _, item := range loaded.GetItems() {
+		if item.GetGoodId() == notedGoodID {
+			assert.Equal(t, "wrap in blue paper", item.GetNote())
+		} else {
+			assert.Empty(t, item.GetNote())
+		}
+	}
+
+	// Empty notes are stored as NULL
+	var nullNotes int
+	err = pc.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM oms.cart_items WHERE cart_id = $1 AND note IS NULL`, customerID).Scan(&nullNotes)
+	require.NoError(t, err)
+	assert.Equal(t, 1, nullNotes)
+}
+
+func TestCart_CurrencyAndTaxCategoryRoundTrip(t *testing.T) {
+	store, uow, _ := setupCartTest(t)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	pricedGoodID := uuid.New()
+
+	cartState := cart.New(customerID)
+	require.NoError(t, cartState.AddItem(mustNewItem(t, pricedGoodID, 1, decimal.NewFromFloat(10.00), decimal.Zero).WithCurrency("EUR").WithTaxCategory("food")))
+	require.NoError(t, cartState.AddItem(mustNewItem(t, uuid.New(), 1, decimal.NewFromFloat(5.00), decimal.Zero)))
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(txCtx, cartState))
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	loaded, err := store.Load(txCtx2, customerID)
+	require.NoError(t, err)
+
+	for _, item := range loaded.GetItems() {
+		if item.GetGoodId() == pricedGoodID {
+			assert.Equal(t, pricing.Currency("EUR"), item.GetCurrency())
+			assert.Equal(t, "food", item.GetTaxCategory())
+		} else {
+			assert.Empty(t, item.GetCurrency())
+			assert.Empty(t, item.GetTaxCategory())
+		}
+	}
+}
+
+func TestCart_LastResetRoundTrip(t *testing.T) {
+	store, uow, _ := setupCartTest(t)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+
+	cartState := cart.New(customerID)
+	require.NoError(t, cartState.AddItem(mustNewItem(t, uuid.New(), 1, decimal.NewFromFloat(10.00), decimal.Zero)))
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(txCtx, cartState))
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	loaded, err := store.Load(txCtx2, customerID)
+	require.NoError(t, err)
+	assert.True(t, loaded.GetLastReset().IsZero(), "a cart that was never reset has no reset record")
+
+	loaded.ResetWithReason(cart.ResetReasonCheckout)
+	recorded := loaded.GetLastReset()
+	require.NoError(t, store.Save(txCtx2, loaded))
+	require.NoError(t, uow.Commit(txCtx2))
+
+	txCtx3, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx3)
+
+	reloaded, err := store.Load(txCtx3, customerID)
+	require.NoError(t, err)
+	assert.Empty(t, reloaded.GetItems())
+	assert.Equal(t, cart.ResetReasonCheckout, reloaded.GetLastReset().Reason)
+	assert.WithinDuration(t, recorded.At, reloaded.GetLastReset().At, time.Millisecond)
+}
+
 func TestCart_UpdateExistingCart(t *testing.T) {
 	store, uow, _ := setupCartTest(t)
 	ctx := context.Background()
@@ -206,6 +371,112 @@ func TestCart_OptimisticConcurrency(t *testing.T) {
 	uow.Rollback(txCtx2)
 }
 
+// mergeAddedItems keeps the concurrently committed cart and adds the local items it does not have yet.
+func mergeAddedItems(current, local *cart.State) (*cart.State, error) {
+	known := make(map[uuid.UUID]struct{})
+	for _, item := range current.GetItems() {
+		known[item.GetGoodId()] = struct{}{}
+	}
+
+	for _, item := range local.GetItems() {
+		if _, ok := known[item.GetGoodId()]; ok {
+			continue
+		}
+
+		if err := current.AddItem(item); err != nil {
+			return nil, err
+		}
+	}
+
+	return current, nil
+}
+
+func TestCart_SaveWithMerge(t *testing.T) {
+	store, uow, _ := setupCartTest(t)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	initialGoodID, firstGoodID, secondGoodID := uuid.New(), uuid.New(), uuid.New()
+
+	cartState := cart.New(customerID)
+	require.NoError(t, cartState.AddItem(mustNewItem(t, initialGoodID, 1, decimal.NewFromFloat(10.00), decimal.Zero)))
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(txCtx, cartState))
+	require.NoError(t, uow.Commit(txCtx))
+
+	// Two transactions load the same version and add different items
+	txCtx1, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	cart1, err := store.Load(txCtx1, customerID)
+	require.NoError(t, err)
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+	cart2, err := store.Load(txCtx2, customerID)
+	require.NoError(t, err)
+
+	require.NoError(t, cart1.AddItem(mustNewItem(t, firstGoodID, 2, decimal.NewFromFloat(20.00), decimal.Zero)))
+	require.NoError(t, store.SaveWithMerge(txCtx1, cart1, mergeAddedItems))
+	require.NoError(t, uow.Commit(txCtx1))
+
+	// The stale save conflicts, merges onto the committed cart and retries
+	require.NoError(t, cart2.AddItem(mustNewItem(t, secondGoodID, 3, decimal.NewFromFloat(30.00), decimal.Zero)))
+	require.NoError(t, store.SaveWithMerge(txCtx2, cart2, mergeAddedItems))
+	require.NoError(t, uow.Commit(txCtx2))
+
+	txCtx3, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx3)
+
+	loaded, err := store.Load(txCtx3, customerID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, loaded.GetVersion())
+
+	goodIDs := make([]uuid.UUID, 0, len(loaded.GetItems()))
+	for _, item := range loaded.GetItems() {
+		goodIDs = append(goodIDs, item.GetGoodId())
+	}
+
+	assert.ElementsMatch(t, []uuid.UUID{initialGoodID, firstGoodID, secondGoodID}, goodIDs)
+}
+
+func TestCart_SaveWithMerge_NilMergeReportsConflict(t *testing.T) {
+	store, uow, _ := setupCartTest(t)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+
+	cartState := cart.New(customerID)
+	require.NoError(t, cartState.AddItem(mustNewItem(t, uuid.New(), 1, decimal.NewFromFloat(10.00), decimal.Zero)))
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(txCtx, cartState))
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx1, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	cart1, err := store.Load(txCtx1, customerID)
+	require.NoError(t, err)
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+	cart2, err := store.Load(txCtx2, customerID)
+	require.NoError(t, err)
+
+	require.NoError(t, cart1.AddItem(mustNewItem(t, uuid.New(), 2, decimal.NewFromFloat(20.00), decimal.Zero)))
+	require.NoError(t, store.Save(txCtx1, cart1))
+	require.NoError(t, uow.Commit(txCtx1))
+
+	require.NoError(t, cart2.AddItem(mustNewItem(t, uuid.New(), 3, decimal.NewFromFloat(30.00), decimal.Zero)))
+	err = store.SaveWithMerge(txCtx2, cart2, nil)
+	assert.ErrorIs(t, err, ports.ErrVersionConflict)
+}
+
 func TestCart_LoadNotFound(t *testing.T) {
 	store, uow, _ := setupCartTest(t)
 	ctx := context.Background()
@@ -221,6 +492,67 @@ func TestCart_LoadNotFound(t *testing.T) {
 	assert.True(t, errors.Is(err, ports.ErrNotFound), "expected ErrNotFound, got: %v", err)
 }
 
+func TestCart_LoadMany(t *testing.T) {
+	store, uow, _ := setupCartTest(t)
+	ctx := context.Background()
+
+	// Two carts with items and one empty cart
+	withTwoItems, withOneItem, empty := uuid.New(), uuid.New(), uuid.New()
+	goodA, goodB, goodC := uuid.New(), uuid.New(), uuid.New()
+
+	first := cart.New(withTwoItems)
+	require.NoError(t, first.AddItem(mustNewItem(t, goodA, 1, decimal.NewFromFloat(10.00), decimal.Zero)))
+	require.NoError(t, first.AddItem(mustNewItem(t, goodB, 3, decimal.NewFromFloat(5.50), decimal.NewFromFloat(0.50))))
+
+	second := cart.New(withOneItem)
+	require.NoError(t, second.AddItem(mustNewItem(t, goodC, 2, decimal.NewFromFloat(7.25), decimal.Zero)))
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+
+	for _, state := range []*cart.State{first, second, cart.New(empty)} {
+		require.NoError(t, store.Save(txCtx, state))
+	}
+
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	missing1, missing2 := uuid.New(), uuid.New()
+
+	loaded, err := store.LoadMany(txCtx2, []uuid.UUID{missing1, withTwoItems, empty, missing2, withOneItem})
+	require.NoError(t, err)
+
+	require.Len(t, loaded, 3, "customers without a cart are omitted")
+	assert.NotContains(t, loaded, missing1)
+	assert.NotContains(t, loaded, missing2)
+
+	require.Contains(t, loaded, withTwoItems)
+	assert.Equal(t, withTwoItems, loaded[withTwoItems].GetCustomerId())
+	assert.Equal(t, 1, loaded[withTwoItems].GetVersion())
+
+	quantities := make(map[uuid.UUID]int32)
+	for _, item := range loaded[withTwoItems].GetItems() {
+		quantities[item.GetGoodId()] = item.GetQuantity()
+	}
+
+	assert.Equal(t, map[uuid.UUID]int32{goodA: 1, goodB: 3}, quantities)
+
+	require.Contains(t, loaded, withOneItem)
+	require.Len(t, loaded[withOneItem].GetItems(), 1)
+	assert.Equal(t, goodC, loaded[withOneItem].GetItems()[0].GetGoodId())
+	assert.True(t, loaded[withOneItem].GetItems()[0].GetPrice().Equal(decimal.NewFromFloat(7.25)))
+
+	require.Contains(t, loaded, empty)
+	assert.Empty(t, loaded[empty].GetItems())
+
+	none, err := store.LoadMany(txCtx2, nil)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
 func TestCart_ClearItems(t *testing.T) {
 	store, uow, _ := setupCartTest(t)
 	ctx := context.Background()
@@ -250,7 +582,7 @@ func TestCart_ClearItems(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, loaded.GetItems(), 3)
 
-	loaded.Reset()
+	loaded.ResetWithReason(cart.ResetReasonAdmin)
 
 	err = store.Save(txCtx2, loaded)
 	require.NoError(t, err)
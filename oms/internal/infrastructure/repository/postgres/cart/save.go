@@ -3,10 +3,14 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/shortlink-org/shop/oms/internal/domain"
 	cart "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/cart/dto"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/cart/schema/queries"
 	"github.com/shortlink-org/shop/oms/pkg/uow"
 )
@@ -14,6 +18,10 @@ import (
 // ErrTransactionRequired is returned when repository is called without UoW transaction.
 var ErrTransactionRequired = errors.New("transaction required: use UnitOfWork.Begin()")
 
+// MergeFunc combines the cart committed by a concurrent writer (current) with the caller's
+// unsaved cart (local). The result must be built on current so it carries current's version.
+type MergeFunc func(current, local *cart.State) (*cart.State, error)
+
 // Save persists the cart state with optimistic concurrency control.
 // Invalidates the L1 cache after successful save.
 // Requires transaction in context (use UnitOfWork.Begin()).
@@ -29,12 +37,27 @@ func (s *Store) Save(ctx context.Context, state *cart.State) error {
 	newVersion := int32(state.GetVersion() + 1)
 	oldVersion := int32(state.GetVersion())
 
+	pricingSnapshot, err := dto.PricingSnapshotToJSON(state.GetPricingSnapshot())
+	if err != nil {
+		return err
+	}
+
+	// An empty gift message is stored as NULL
+	giftMessage := state.GetGiftMessage()
+	giftMessageText := pgtype.Text{String: giftMessage, Valid: giftMessage != ""}
+
+	lastResetReason, lastResetAt := dto.LastResetToDB(state.GetLastReset())
+
 	// Try to update with optimistic lock
 	if oldVersion > 0 {
 		result, err := qtx.UpsertCart(ctx, queries.UpsertCartParams{
-			CustomerID: customerID,
-			Version:    newVersion,
-			Version_2:  oldVersion,
+			CustomerID:      customerID,
+			Version:         newVersion,
+			Version_2:       oldVersion,
+			PricingSnapshot: pricingSnapshot,
+			GiftMessage:     giftMessageText,
+			LastResetReason: lastResetReason,
+			LastResetAt:     lastResetAt,
 		})
 		if err != nil {
 			return err
@@ -46,25 +69,34 @@ func (s *Store) Save(ctx context.Context, state *cart.State) error {
 		}
 	} else {
 		// New cart - insert
-		err := qtx.InsertCart(ctx, customerID)
+		err := qtx.InsertCart(ctx, queries.InsertCartParams{
+			CustomerID:      customerID,
+			PricingSnapshot: pricingSnapshot,
+			GiftMessage:     giftMessageText,
+			LastResetReason: lastResetReason,
+			LastResetAt:     lastResetAt,
+		})
 		if err != nil {
 			return domain.WrapUnavailable("InsertCart", err)
 		}
 	}
 
 	// Delete existing items and insert new ones
-	err := qtx.DeleteCartItems(ctx, customerID)
+	err = qtx.DeleteCartItems(ctx, customerID)
 	if err != nil {
 		return domain.WrapUnavailable("DeleteCartItems", err)
 	}
 
 	for _, item := range state.GetItems() {
 		err := qtx.InsertCartItem(ctx, queries.InsertCartItemParams{
-			CartID:   customerID,
-			GoodID:   item.GetGoodId(),
-			Quantity: item.GetQuantity(),
-			Price:    item.GetPrice(),
-			Discount: item.GetDiscount(),
+			CartID:      customerID,
+			GoodID:      item.GetGoodId(),
+			Quantity:    item.GetQuantity(),
+			Price:       item.GetPrice(),
+			Discount:    item.GetDiscount(),
+			Note:        pgtype.Text{String: item.GetNote(), Valid: item.GetNote() != ""},
+			Currency:    pgtype.Text{String: item.GetCurrency().String(), Valid: item.GetCurrency() != ""},
+			TaxCategory: pgtype.Text{String: item.GetTaxCategory(), Valid: item.GetTaxCategory() != ""},
 		})
 		if err != nil {
 			return domain.WrapUnavailable("InsertCartItem", err)
@@ -76,3 +108,27 @@ func (s *Store) Save(ctx context.Context, state *cart.State) error {
 
 	return nil
 }
+
+// SaveWithMerge persists the cart like Save. On a version conflict it reloads the committed cart
+// in the same transaction, saves merge(current, state) instead and retries once; a second conflict
+// is returned as ports.ErrVersionConflict. A nil merge behaves like Save.
+// Requires transaction in context (use UnitOfWork.Begin()).
+func (s *Store) SaveWithMerge(ctx context.Context, state *cart.State, merge MergeFunc) error {
+	err := s.Save(ctx, state)
+	if merge == nil || !errors.Is(err, ports.ErrVersionConflict) {
+		return err
+	}
+
+	// The L1 cache may still hold the version this save lost to
+	current, err := s.loadFromDB(ctx, state.GetCustomerId())
+	if err != nil {
+		return err
+	}
+
+	merged, err := merge(current, state)
+	if err != nil {
+		return fmt.Errorf("merge cart %s: %w", state.GetCustomerId(), err)
+	}
+
+	return s.Save(ctx, merged)
+}
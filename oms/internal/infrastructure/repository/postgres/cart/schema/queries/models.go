@@ -17,6 +17,14 @@ type OmsCart struct {
 	Version   int32
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
+	// Price lock taken at review time (totals, priced_at, expires_at); NULL when none
+	PricingSnapshot []byte
+	// Gift message for the whole cart; NULL when none
+	GiftMessage pgtype.Text
+	// Why the cart was last emptied (CHECKOUT, ABANDONED, ADMIN); NULL when never reset with a reason
+	LastResetReason pgtype.Text
+	// When the cart was last emptied; NULL when never reset with a reason
+	LastResetAt pgtype.Timestamptz
 }
 
 // Items in shopping carts
@@ -26,4 +34,10 @@ type OmsCartItem struct {
 	Quantity int32
 	Price    decimal.Decimal
 	Discount decimal.Decimal
+	// Customer note for the item (e.g. gift wrapping instructions); NULL when none
+	Note pgtype.Text
+	// ISO-4217 currency of price and discount; NULL when not specified
+	Currency pgtype.Text
+	// Tax category tax policies branch on (e.g. food); NULL means the pricer's default
+	TaxCategory pgtype.Text
 }
@@ -15,7 +15,9 @@ type Querier interface {
 	DeleteCartItems(ctx context.Context, cartID uuid.UUID) error
 	GetCart(ctx context.Context, customerID uuid.UUID) (OmsCart, error)
 	GetCartItems(ctx context.Context, cartID uuid.UUID) ([]GetCartItemsRow, error)
-	InsertCart(ctx context.Context, customerID uuid.UUID) error
+	GetCartItemsByCartIDs(ctx context.Context, cartIds []uuid.UUID) ([]GetCartItemsByCartIDsRow, error)
+	GetCartsByCustomerIDs(ctx context.Context, customerIds []uuid.UUID) ([]OmsCart, error)
+	InsertCart(ctx context.Context, arg InsertCartParams) error
 	InsertCartItem(ctx context.Context, arg InsertCartItemParams) error
 	UpsertCart(ctx context.Context, arg UpsertCartParams) (pgconn.CommandTag, error)
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
 
@@ -24,7 +25,7 @@ func (q *Queries) DeleteCartItems(ctx context.Context, cartID uuid.UUID) error {
 }
 
 const getCart = `-- name: GetCart :one
-SELECT customer_id, version, created_at, updated_at
+SELECT customer_id, version, created_at, updated_at, pricing_snapshot, gift_message, last_reset_reason, last_reset_at
 FROM oms.carts
 WHERE customer_id = $1
 `
@@ -37,21 +38,28 @@ func (q *Queries) GetCart(ctx context.Context, customerID uuid.UUID) (OmsCart, e
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PricingSnapshot,
+		&i.GiftMessage,
+		&i.LastResetReason,
+		&i.LastResetAt,
 	)
 	return i, err
 }
 
 const getCartItems = `-- name: GetCartItems :many
-SELECT good_id, quantity, price, discount
+SELECT good_id, quantity, price, discount, note, currency, tax_category
 FROM oms.cart_items
 WHERE cart_id = $1
 `
 
 type GetCartItemsRow struct {
-	GoodID   uuid.UUID
-	Quantity int32
-	Price    decimal.Decimal
-	Discount decimal.Decimal
+	GoodID      uuid.UUID
+	Quantity    int32
+	Price       decimal.Decimal
+	Discount    decimal.Decimal
+	Note        pgtype.Text
+	Currency    pgtype.Text
+	TaxCategory pgtype.Text
 }
 
 func (q *Queries) GetCartItems(ctx context.Context, cartID uuid.UUID) ([]GetCartItemsRow, error) {
@@ -68,6 +76,90 @@ func (q *Queries) GetCartItems(ctx context.Context, cartID uuid.UUID) ([]GetCart
 			&i.Quantity,
 			&i.Price,
 			&i.Discount,
+			&i.Note,
+			&i.Currency,
+			&i.TaxCategory,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCartItemsByCartIDs = `-- name: GetCartItemsByCartIDs :many
+SELECT cart_id, good_id, quantity, price, discount, note, currency, tax_category
+FROM oms.cart_items
+WHERE cart_id = ANY($1::uuid[])
+`
+
+type GetCartItemsByCartIDsRow struct {
+	CartID      uuid.UUID
+	GoodID      uuid.UUID
+	Quantity    int32
+	Price       decimal.Decimal
+	Discount    decimal.Decimal
+	Note        pgtype.Text
+	Currency    pgtype.Text
+	TaxCategory pgtype.Text
+}
+
+func (q *Queries) GetCartItemsByCartIDs(ctx context.Context, cartIds []uuid.UUID) ([]GetCartItemsByCartIDsRow, error) {
+	rows, err := q.db.Query(ctx, getCartItemsByCartIDs, cartIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCartItemsByCartIDsRow
+	for rows.Next() {
+		var i GetCartItemsByCartIDsRow
+		if err := rows.Scan(
+			&i.CartID,
+			&i.GoodID,
+			&i.Quantity,
+			&i.Price,
+			&i.Discount,
+			&i.Note,
+			&i.Currency,
+			&i.TaxCategory,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCartsByCustomerIDs = `-- name: GetCartsByCustomerIDs :many
+SELECT customer_id, version, created_at, updated_at, pricing_snapshot, gift_message, last_reset_reason, last_reset_at
+FROM oms.carts
+WHERE customer_id = ANY($1::uuid[])
+`
+
+func (q *Queries) GetCartsByCustomerIDs(ctx context.Context, customerIds []uuid.UUID) ([]OmsCart, error) {
+	rows, err := q.db.Query(ctx, getCartsByCustomerIDs, customerIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OmsCart
+	for rows.Next() {
+		var i OmsCart
+		if err := rows.Scan(
+			&i.CustomerID,
+			&i.Version,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PricingSnapshot,
+			&i.GiftMessage,
+			&i.LastResetReason,
+			&i.LastResetAt,
 		); err != nil {
 			return nil, err
 		}
@@ -80,26 +172,43 @@ func (q *Queries) GetCartItems(ctx context.Context, cartID uuid.UUID) ([]GetCart
 }
 
 const insertCart = `-- name: InsertCart :exec
-INSERT INTO oms.carts (customer_id, version, created_at, updated_at)
-VALUES ($1, 1, NOW(), NOW())
+INSERT INTO oms.carts (customer_id, version, pricing_snapshot, gift_message, last_reset_reason, last_reset_at, created_at, updated_at)
+VALUES ($1, 1, $2, $3, $4, $5, NOW(), NOW())
 `
 
-func (q *Queries) InsertCart(ctx context.Context, customerID uuid.UUID) error {
-	_, err := q.db.Exec(ctx, insertCart, customerID)
+type InsertCartParams struct {
+	CustomerID      uuid.UUID
+	PricingSnapshot []byte
+	GiftMessage     pgtype.Text
+	LastResetReason pgtype.Text
+	LastResetAt     pgtype.Timestamptz
+}
+
+func (q *Queries) InsertCart(ctx context.Context, arg InsertCartParams) error {
+	_, err := q.db.Exec(ctx, insertCart,
+		arg.CustomerID,
+		arg.PricingSnapshot,
+		arg.GiftMessage,
+		arg.LastResetReason,
+		arg.LastResetAt,
+	)
 	return err
 }
 
 const insertCartItem = `-- name: InsertCartItem :exec
-INSERT INTO oms.cart_items (cart_id, good_id, quantity, price, discount)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO oms.cart_items (cart_id, good_id, quantity, price, discount, note, currency, tax_category)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type InsertCartItemParams struct {
-	CartID   uuid.UUID
-	GoodID   uuid.UUID
-	Quantity int32
-	Price    decimal.Decimal
-	Discount decimal.Decimal
+	CartID      uuid.UUID
+	GoodID      uuid.UUID
+	Quantity    int32
+	Price       decimal.Decimal
+	Discount    decimal.Decimal
+	Note        pgtype.Text
+	Currency    pgtype.Text
+	TaxCategory pgtype.Text
 }
 
 func (q *Queries) InsertCartItem(ctx context.Context, arg InsertCartItemParams) error {
@@ -109,24 +218,39 @@ func (q *Queries) InsertCartItem(ctx context.Context, arg InsertCartItemParams)
 		arg.Quantity,
 		arg.Price,
 		arg.Discount,
+		arg.Note,
+		arg.Currency,
+		arg.TaxCategory,
 	)
 	return err
 }
 
 const upsertCart = `-- name: UpsertCart :execresult
-INSERT INTO oms.carts (customer_id, version, created_at, updated_at)
-VALUES ($1, $2, NOW(), NOW())
+INSERT INTO oms.carts (customer_id, version, pricing_snapshot, gift_message, last_reset_reason, last_reset_at, created_at, updated_at)
+VALUES ($1, $2, $4, $5, $6, $7, NOW(), NOW())
 ON CONFLICT (customer_id)
-DO UPDATE SET version = $2, updated_at = NOW()
+DO UPDATE SET version = $2, pricing_snapshot = $4, gift_message = $5, last_reset_reason = $6, last_reset_at = $7, updated_at = NOW()
 WHERE oms.carts.version = $3
 `
 
 type UpsertCartParams struct {
-	CustomerID uuid.UUID
-	Version    int32
-	Version_2  int32
+	CustomerID      uuid.UUID
+	Version         int32
+	Version_2       int32
+	PricingSnapshot []byte
+	GiftMessage     pgtype.Text
+	LastResetReason pgtype.Text
+	LastResetAt     pgtype.Timestamptz
 }
 
 func (q *Queries) UpsertCart(ctx context.Context, arg UpsertCartParams) (pgconn.CommandTag, error) {
-	return q.db.Exec(ctx, upsertCart, arg.CustomerID, arg.Version, arg.Version_2)
+	return q.db.Exec(ctx, upsertCart,
+		arg.CustomerID,
+		arg.Version,
+		arg.Version_2,
+		arg.PricingSnapshot,
+		arg.GiftMessage,
+		arg.LastResetReason,
+		arg.LastResetAt,
+	)
 }
@@ -1,28 +1,38 @@
 -- name: GetCart :one
-SELECT customer_id, version, created_at, updated_at
+SELECT customer_id, version, created_at, updated_at, pricing_snapshot, gift_message, last_reset_reason, last_reset_at
 FROM oms.carts
 WHERE customer_id = $1;
 
 -- name: GetCartItems :many
-SELECT good_id, quantity, price, discount
+SELECT good_id, quantity, price, discount, note, currency, tax_category
 FROM oms.cart_items
 WHERE cart_id = $1;
 
+-- name: GetCartsByCustomerIDs :many
+SELECT customer_id, version, created_at, updated_at, pricing_snapshot, gift_message, last_reset_reason, last_reset_at
+FROM oms.carts
+WHERE customer_id = ANY(@customer_ids::uuid[]);
+
+-- name: GetCartItemsByCartIDs :many
+SELECT cart_id, good_id, quantity, price, discount, note, currency, tax_category
+FROM oms.cart_items
+WHERE cart_id = ANY(@cart_ids::uuid[]);
+
 -- name: UpsertCart :execresult
-INSERT INTO oms.carts (customer_id, version, created_at, updated_at)
-VALUES ($1, $2, NOW(), NOW())
+INSERT INTO oms.carts (customer_id, version, pricing_snapshot, gift_message, last_reset_reason, last_reset_at, created_at, updated_at)
+VALUES ($1, $2, $4, $5, $6, $7, NOW(), NOW())
 ON CONFLICT (customer_id)
-DO UPDATE SET version = $2, updated_at = NOW()
+DO UPDATE SET version = $2, pricing_snapshot = $4, gift_message = $5, last_reset_reason = $6, last_reset_at = $7, updated_at = NOW()
 WHERE oms.carts.version = $3;
 
 -- name: InsertCart :exec
-INSERT INTO oms.carts (customer_id, version, created_at, updated_at)
-VALUES ($1, 1, NOW(), NOW());
+INSERT INTO oms.carts (customer_id, version, pricing_snapshot, gift_message, last_reset_reason, last_reset_at, created_at, updated_at)
+VALUES ($1, 1, $2, $3, $4, $5, NOW(), NOW());
 
 -- name: DeleteCartItems :exec
 DELETE FROM oms.cart_items
 WHERE cart_id = $1;
 
 -- name: InsertCartItem :exec
-INSERT INTO oms.cart_items (cart_id, good_id, quantity, price, discount)
-VALUES ($1, $2, $3, $4, $5);
+INSERT INTO oms.cart_items (cart_id, good_id, quantity, price, discount, note, currency, tax_category)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
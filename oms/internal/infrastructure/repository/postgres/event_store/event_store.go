@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	domainevents "github.com/shortlink-org/shop/oms/internal/domain/events"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/event_store/schema/queries"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
+)
+
+// Append adds events to the aggregate's stream in oms.events at expectedVersion+1, +2, ...
+// A stream that moved past expectedVersion, including by a concurrent append, yields ports.ErrVersionConflict.
+// Requires transaction in context (use UnitOfWork.Begin()), so events commit with the aggregate.
+func (s *Store) Append(ctx context.Context, aggregateID uuid.UUID, events []domainevents.Event, expectedVersion int) error {
+	pgxTx := uow.FromContext(ctx)
+	if pgxTx == nil {
+		return ErrTransactionRequired
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	qtx := s.query.WithTx(pgxTx)
+
+	currentVersion, err := qtx.GetEventStreamVersion(ctx, aggregateID)
+	if err != nil {
+		return domain.WrapUnavailable("GetEventStreamVersion", fmt.Errorf("query stream version: %w", err))
+	}
+
+	if int(currentVersion) != expectedVersion {
+		return fmt.Errorf("%w: event stream %s is at version %d, expected %d",
+			ports.ErrVersionConflict, aggregateID, currentVersion, expectedVersion)
+	}
+
+	for i, event := range events {
+		msg, ok := event.(proto.Message)
+		if !ok {
+			return fmt.Errorf("append %s: event %T is not a protobuf message", event.EventType(), event)
+		}
+
+		payload, err := protojson.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("marshal %s: %w", event.EventType(), err)
+		}
+
+		version := expectedVersion + i + 1
+
+		// A concurrent append of the same version waits for the other transaction and then inserts nothing.
+		tag, err := qtx.InsertEvent(ctx, queries.InsertEventParams{
+			AggregateID: aggregateID,
+			Version:     int32(version),
+			EventType:   string(proto.MessageName(msg)),
+			Payload:     payload,
+		})
+		if err != nil {
+			return domain.WrapUnavailable("InsertEvent", fmt.Errorf("exec insert: %w", err))
+		}
+
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("%w: event stream %s already has version %d", ports.ErrVersionConflict, aggregateID, version)
+		}
+	}
+
+	return nil
+}
+
+// Load returns the aggregate's events from oms.events in stream order as protobuf messages.
+// Requires transaction in context (use UnitOfWork.Begin()).
+func (s *Store) Load(ctx context.Context, aggregateID uuid.UUID) ([]any, error) {
+	pgxTx := uow.FromContext(ctx)
+	if pgxTx == nil {
+		return nil, ErrTransactionRequired
+	}
+
+	rows, err := s.query.WithTx(pgxTx).ListEvents(ctx, aggregateID)
+	if err != nil {
+		return nil, domain.WrapUnavailable("ListEvents", fmt.Errorf("query events: %w", err))
+	}
+
+	events := make([]any, 0, len(rows))
+
+	for _, row := range rows {
+		event, err := decodeStoredEvent(row.EventType, row.Payload)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// decodeStoredEvent rebuilds the protobuf event named eventType from its JSON payload.
+func decodeStoredEvent(eventType string, payload []byte) (proto.Message, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(eventType))
+	if err != nil {
+		return nil, fmt.Errorf("resolve stored event type %s: %w", eventType, err)
+	}
+
+	event := messageType.New().Interface()
+
+	err = protojson.Unmarshal(payload, event)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal stored event %s: %w", eventType, err)
+	}
+
+	return event, nil
+}
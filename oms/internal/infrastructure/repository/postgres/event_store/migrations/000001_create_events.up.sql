@@ -0,0 +1,17 @@
+-- OMS domain event stream
+CREATE SCHEMA IF NOT EXISTS oms;
+
+CREATE TABLE IF NOT EXISTS oms.events (
+    aggregate_id UUID NOT NULL,
+    version      INT NOT NULL CHECK (version > 0),
+    event_type   VARCHAR(255) NOT NULL,
+    payload      JSONB NOT NULL,
+    recorded_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (aggregate_id, version)
+);
+
+COMMENT ON TABLE oms.events IS 'Append-only domain event stream per aggregate, for audit and rehydration';
+COMMENT ON COLUMN oms.events.aggregate_id IS 'Aggregate (order) the event belongs to';
+COMMENT ON COLUMN oms.events.version IS 'Position in the aggregate stream, starting at 1; the primary key rejects concurrent appends';
+COMMENT ON COLUMN oms.events.event_type IS 'Full protobuf message name of the event';
+COMMENT ON COLUMN oms.events.payload IS 'Event encoded as protobuf JSON';
@@ -0,0 +1,44 @@
+//go:generate sqlc generate -f ./schema/sqlc.yaml
+
+package postgres
+
+import (
+	"context"
+	"embed"
+	"errors"
+
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shortlink-org/go-sdk/db"
+	"github.com/shortlink-org/go-sdk/db/drivers/postgres/migrate"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/event_store/schema/queries"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// ErrTransactionRequired is returned when the event store is called without UoW transaction.
+var ErrTransactionRequired = errors.New("transaction required: use UnitOfWork.Begin()")
+
+// Store implements EventStore on the oms.events table.
+// All access goes through the UoW transaction, so events commit or roll back with the aggregate.
+type Store struct {
+	query *queries.Queries
+}
+
+// New creates a new PostgreSQL event store, migrating oms.events.
+func New(ctx context.Context, store db.DB) (*Store, error) {
+	client, ok := store.GetConn().(*pgxpool.Pool)
+	if !ok {
+		return nil, db.ErrGetConnection
+	}
+
+	err := migrate.Migration(ctx, store, migrations, "repository_event_store")
+	if err != nil {
+		return nil, domain.WrapUnavailable("migrate repository_event_store", err)
+	}
+
+	return &Store{query: queries.New(client)}, nil
+}
@@ -0,0 +1,138 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	order "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	eventstore "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/event_store"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/testhelpers"
+	uowpg "github.com/shortlink-org/shop/oms/pkg/uow/postgres"
+)
+
+func setupEventStoreTest(t *testing.T) (*eventstore.Store, *uowpg.UoW) {
+	t.Helper()
+
+	pc := testhelpers.SetupPostgresContainer(t)
+
+	store, err := eventstore.New(context.Background(), pc.DB())
+	require.NoError(t, err, "failed to create event store")
+
+	return store, uowpg.New(pc.Pool)
+}
+
+// newCompletedOrder returns an order whose pending domain events are OrderCreated and OrderCompleted.
+func newCompletedOrder(t *testing.T) *order.OrderState {
+	t.Helper()
+
+	o := order.NewOrderState(uuid.New())
+	require.NoError(t, o.CreateOrder(context.Background(), order.Items{
+		order.NewItem(uuid.New(), 2, decimal.NewFromFloat(19.99)),
+	}))
+	require.NoError(t, o.CompleteOrder())
+
+	return o
+}
+
+func TestEventStore_Integration_AppendAndReplay(t *testing.T) {
+	ctx := context.Background()
+	store, uow := setupEventStoreTest(t)
+
+	original := newCompletedOrder(t)
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Append(txCtx, original.GetOrderID(), original.GetDomainEvents(), 0))
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	defer func() {
+		_ = uow.Rollback(txCtx)
+	}()
+
+	events, err := store.Load(txCtx, original.GetOrderID())
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	replayed, err := order.ReplayEvents(events)
+	require.NoError(t, err)
+	assert.Equal(t, original.GetOrderID(), replayed.GetOrderID())
+	assert.Equal(t, original.GetCustomerId(), replayed.GetCustomerId())
+	assert.Equal(t, order.OrderStatus_ORDER_STATUS_COMPLETED, replayed.GetStatus())
+	assert.Len(t, replayed.GetItems(), 1)
+
+	unknown, err := store.Load(txCtx, uuid.New())
+	require.NoError(t, err)
+	assert.Empty(t, unknown)
+}
+
+func TestEventStore_Integration_StaleVersionConflict(t *testing.T) {
+	ctx := context.Background()
+	store, uow := setupEventStoreTest(t)
+
+	o := newCompletedOrder(t)
+	events := o.GetDomainEvents()
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Append(txCtx, o.GetOrderID(), events[:1], 0))
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	defer func() {
+		_ = uow.Rollback(txCtx)
+	}()
+
+	err = store.Append(txCtx, o.GetOrderID(), events[1:], 0)
+	require.ErrorIs(t, err, ports.ErrVersionConflict)
+
+	require.NoError(t, store.Append(txCtx, o.GetOrderID(), events[1:], 1))
+}
+
+func TestEventStore_Integration_ConcurrentAppendConflicts(t *testing.T) {
+	ctx := context.Background()
+	store, uow := setupEventStoreTest(t)
+
+	o := newCompletedOrder(t)
+	events := o.GetDomainEvents()
+
+	// Both writers saw an empty stream; only the first to commit may claim version 1.
+	txA, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	txB, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer func() {
+		_ = uow.Rollback(txB)
+	}()
+
+	require.NoError(t, store.Append(txA, o.GetOrderID(), events[:1], 0))
+
+	errB := make(chan error, 1)
+	go func() {
+		// Blocks on the uncommitted version 1 row until txA commits.
+		errB <- store.Append(txB, o.GetOrderID(), events[1:], 0)
+	}()
+
+	require.NoError(t, uow.Commit(txA))
+	require.ErrorIs(t, <-errB, ports.ErrVersionConflict)
+}
+
+func TestEventStore_Integration_RequiresTransaction(t *testing.T) {
+	store, _ := setupEventStoreTest(t)
+
+	err := store.Append(context.Background(), uuid.New(), nil, 0)
+	require.ErrorIs(t, err, eventstore.ErrTransactionRequired)
+
+	_, err = store.Load(context.Background(), uuid.New())
+	require.ErrorIs(t, err, eventstore.ErrTransactionRequired)
+}
@@ -0,0 +1,13 @@
+-- name: GetEventStreamVersion :one
+SELECT COALESCE(MAX(version), 0)::int AS version FROM oms.events WHERE aggregate_id = $1;
+
+-- name: InsertEvent :execresult
+-- A concurrent append of the same version waits for the other transaction and then inserts nothing.
+INSERT INTO oms.events (aggregate_id, version, event_type, payload)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (aggregate_id, version) DO NOTHING;
+
+-- name: ListEvents :many
+SELECT event_type, payload FROM oms.events
+WHERE aggregate_id = $1
+ORDER BY version;
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	order "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order/schema/queries"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
+)
+
+// ErrOrderNotArchivable is returned when archiving an order that is not completed or cancelled.
+var ErrOrderNotArchivable = fmt.Errorf("%w: only completed or cancelled orders can be archived", domain.ErrConflict)
+
+// archivableStatuses are the terminal statuses an order must be in to be archived.
+var archivableStatuses = []string{
+	order.OrderStatus_ORDER_STATUS_COMPLETED.String(),
+	order.OrderStatus_ORDER_STATUS_CANCELED.String(),
+}
+
+// Archive soft-deletes a completed or cancelled order: the row stays in oms.orders
+// but is hidden from loads and lists unless archived orders are requested explicitly.
+// Archiving an already archived order is a no-op.
+// Requires transaction in context (use UnitOfWork.Begin()).
+func (s *Store) Archive(ctx context.Context, orderID uuid.UUID) error {
+	pgxTx := uow.FromContext(ctx)
+	if pgxTx == nil {
+		return ErrTransactionRequired
+	}
+
+	qtx := s.query.WithTx(pgxTx)
+
+	result, err := qtx.ArchiveOrder(ctx, queries.ArchiveOrderParams{
+		ID:      orderID,
+		Column2: archivableStatuses,
+	})
+	if err != nil {
+		return domain.WrapUnavailable("ArchiveOrder", err)
+	}
+
+	if result.RowsAffected() > 0 {
+		s.invalidateCache(ctx, orderID.String())
+
+		return nil
+	}
+
+	// Nothing archived: tell a missing order from an archived or still active one
+	row, err := qtx.GetOrder(ctx, queries.GetOrderParams{ID: orderID, Column2: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ports.ErrNotFound
+		}
+
+		return domain.WrapUnavailable("GetOrder", err)
+	}
+
+	if row.ArchivedAt.Valid {
+		return nil
+	}
+
+	return ErrOrderNotArchivable
+}
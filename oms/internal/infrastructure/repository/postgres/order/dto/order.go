@@ -11,6 +11,7 @@ import (
 	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
 	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
 	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/location"
+	"github.com/shortlink-org/shop/oms/internal/domain/pricing"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order/schema/queries"
 )
 
@@ -19,13 +20,19 @@ type OrderRow struct {
 	Order    queries.OmsOrder
 	Items    []queries.GetOrderItemsRow
 	Delivery *queries.GetOrderDeliveryInfoRow
+	// Packages holds per-package delivery statuses; empty unless the delivery was split
+	Packages []queries.GetOrderPackageDeliveryStatusesRow
 }
 
 // ToDomain converts the row to domain aggregate.
 func (r *OrderRow) ToDomain() *order.OrderState {
 	domainItems := make(order.Items, 0, len(r.Items))
 	for _, i := range r.Items {
-		domainItems = append(domainItems, order.NewItem(i.GoodID, i.Quantity, i.Price))
+		item := order.NewItem(i.GoodID, i.Quantity, i.Price).
+			WithNote(i.Note.String).
+			WithCurrency(pricing.Currency(i.Currency.String)).
+			WithTaxCategory(i.TaxCategory.String)
+		domainItems = append(domainItems, item)
 	}
 
 	status := stringToOrderStatus(r.Order.Status)
@@ -36,9 +43,32 @@ func (r *OrderRow) ToDomain() *order.OrderState {
 	return order.NewOrderStateFromPersisted(
 		r.Order.ID, r.Order.CustomerID, domainItems,
 		status, int(r.Order.Version), deliveryInfo, deliveryStatus, deliveryRequestedAt,
+		order.WithGiftMessage(r.Order.GiftMessage.String),
+		order.WithOrderDiscount(r.Order.OrderDiscount),
+		order.WithTotals(order.OrderTotals{
+			Subtotal:   r.Order.Subtotal,
+			TotalTax:   r.Order.TotalTax,
+			FinalPrice: r.Order.FinalPrice,
+		}),
+		order.WithFulfillmentType(stringToFulfillmentType(r.Order.FulfillmentType)),
+		order.WithPackageDeliveryStatuses(packageDeliveryStatuses(r.Packages)),
 	)
 }
 
+// packageDeliveryStatuses converts per-package delivery status rows to the domain map.
+func packageDeliveryStatuses(rows []queries.GetOrderPackageDeliveryStatusesRow) map[uuid.UUID]commonv1.DeliveryStatus {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	statuses := make(map[uuid.UUID]commonv1.DeliveryStatus, len(rows))
+	for _, row := range rows {
+		statuses[row.PackageID] = parseDeliveryStatus(row.DeliveryStatus)
+	}
+
+	return statuses
+}
+
 // toDeliveryInfoDomain converts database delivery info row to domain DeliveryInfo.
 func toDeliveryInfoDomain(row *queries.GetOrderDeliveryInfoRow) *order.DeliveryInfo {
 	if row == nil {
@@ -92,7 +122,12 @@ func toDeliveryInfoDomain(row *queries.GetOrderDeliveryInfoRow) *order.DeliveryI
 	)
 
 	// Build package info
-	pkgInfo := order.NewPackageInfo(numericToFloat64(row.WeightKg))
+	pkgInfo := order.NewPackageInfoWithDimensions(
+		numericToFloat64(row.WeightKg),
+		numericToFloat64(row.LengthCm),
+		numericToFloat64(row.WidthCm),
+		numericToFloat64(row.HeightCm),
+	)
 
 	// Build priority
 	priority := order.DeliveryPriorityFromString(row.Priority)
@@ -125,6 +160,8 @@ func toDeliveryInfoDomain(row *queries.GetOrderDeliveryInfoRow) *order.DeliveryI
 		deliveryInfo.SetPackageId(pkgID)
 	}
 
+	deliveryInfo.SetDeliveryFee(row.DeliveryFee)
+
 	return &deliveryInfo
 }
 
@@ -138,6 +175,15 @@ func deliveryRequestedAt(row *queries.GetOrderDeliveryInfoRow) *time.Time {
 	return &requestedAt
 }
 
+// stringToFulfillmentType converts the stored fulfillment type; NULL (orders that predate it) is UNSPECIFIED.
+func stringToFulfillmentType(s pgtype.Text) order.FulfillmentType {
+	if !s.Valid {
+		return order.FulfillmentType_FULFILLMENT_TYPE_UNSPECIFIED
+	}
+
+	return order.FulfillmentType(commonv1.FulfillmentType_value[s.String])
+}
+
 // stringToOrderStatus converts status string to OrderStatus enum.
 func stringToOrderStatus(s string) order.OrderStatus {
 	switch s {
@@ -159,7 +205,11 @@ func stringToDeliveryStatus(row *queries.GetOrderDeliveryInfoRow) commonv1.Deliv
 		return commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED
 	}
 
-	switch row.DeliveryStatus {
+	return parseDeliveryStatus(row.DeliveryStatus)
+}
+
+func parseDeliveryStatus(s string) commonv1.DeliveryStatus {
+	switch s {
 	case "ACCEPTED", "DELIVERY_STATUS_ACCEPTED":
 		return commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED
 	case "ASSIGNED", "DELIVERY_STATUS_ASSIGNED":
@@ -170,6 +220,8 @@ func stringToDeliveryStatus(row *queries.GetOrderDeliveryInfoRow) commonv1.Deliv
 		return commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED
 	case "NOT_DELIVERED", "DELIVERY_STATUS_NOT_DELIVERED":
 		return commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED
+	case "PARTIALLY_DELIVERED", "DELIVERY_STATUS_PARTIALLY_DELIVERED":
+		return commonv1.DeliveryStatus_DELIVERY_STATUS_PARTIALLY_DELIVERED
 	default:
 		return commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED
 	}
@@ -16,6 +16,7 @@ import (
 )
 
 // List retrieves orders with optional filter. No pagination.
+// Archived orders are excluded unless filter.IncludeArchived is set.
 // Requires transaction in context (use UnitOfWork.Begin()).
 func (s *Store) List(ctx context.Context, filter ports.ListFilter) ([]*order.OrderState, error) {
 	pgxTx := uow.FromContext(ctx)
@@ -41,12 +42,14 @@ func (s *Store) List(ctx context.Context, filter ports.ListFilter) ([]*order.Ord
 			Column2:    statusInts,
 			Limit:      math.MaxInt32,
 			Offset:     0,
+			Column5:    filter.IncludeArchived,
 		})
 	case hasCustomer:
 		rows, err = qtx.ListOrdersWithCustomerFilter(ctx, queries.ListOrdersWithCustomerFilterParams{
 			CustomerID: *filter.CustomerID,
 			Limit:      math.MaxInt32,
 			Offset:     0,
+			Column4:    filter.IncludeArchived,
 		})
 	case hasStatus:
 		statusInts := statusesToInts(filter.StatusFilter)
@@ -54,11 +57,13 @@ func (s *Store) List(ctx context.Context, filter ports.ListFilter) ([]*order.Ord
 			Column1: statusInts,
 			Limit:   math.MaxInt32,
 			Offset:  0,
+			Column4: filter.IncludeArchived,
 		})
 	default:
 		rows, err = qtx.ListOrders(ctx, queries.ListOrdersParams{
-			Limit:  math.MaxInt32,
-			Offset: 0,
+			Limit:   math.MaxInt32,
+			Offset:  0,
+			Column3: filter.IncludeArchived,
 		})
 	}
 
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	order "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order/schema/queries"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
+)
+
+// errInvalidPageToken is returned when the page token cannot be decoded.
+var errInvalidPageToken = errors.New("invalid page token")
+
+// pageCursor is the keyset position (created_at, id) of the last order on a page.
+type pageCursor struct {
+	createdAt time.Time
+	id        uuid.UUID
+}
+
+// ListPage retrieves a page of orders with optional filters, newest first.
+// Uses keyset pagination on (created_at, id); the page token is opaque to callers.
+// Items, delivery info and package statuses are loaded for the whole page in one query each.
+// Requires transaction in context (use UnitOfWork.Begin()).
+func (s *Store) ListPage(ctx context.Context, filter ports.ListPageFilter) (*ports.OrderPage, error) {
+	pgxTx := uow.FromContext(ctx)
+	if pgxTx == nil {
+		return nil, ErrTransactionRequired
+	}
+
+	params := queries.ListOrdersPageParams{
+		Statuses:    statusesToStrings(filter.StatusFilter),
+		CreatedFrom: timeToTimestamptz(filter.CreatedFrom),
+		CreatedTo:   timeToTimestamptz(filter.CreatedTo),
+		// Archived orders are excluded unless explicitly requested
+		IncludeArchived: filter.IncludeArchived,
+		// Fetch one extra row to know whether a next page exists
+		PageLimit: filter.PageSize + 1,
+	}
+
+	if filter.CustomerID != nil {
+		params.CustomerID = pgtype.UUID{Bytes: *filter.CustomerID, Valid: true}
+	}
+
+	if filter.PageToken != "" {
+		cursor, err := decodePageToken(filter.PageToken)
+		if err != nil {
+			return nil, domain.WrapValidation("ListPage", err)
+		}
+
+		params.CursorCreatedAt = pgtype.Timestamptz{Time: cursor.createdAt, Valid: true}
+		params.CursorID = pgtype.UUID{Bytes: cursor.id, Valid: true}
+	}
+
+	qtx := s.query.WithTx(pgxTx)
+
+	rows, err := qtx.ListOrdersPage(ctx, params)
+	if err != nil {
+		return nil, domain.WrapUnavailable("ListOrdersPage", err)
+	}
+
+	page := &ports.OrderPage{}
+
+	if len(rows) > int(filter.PageSize) {
+		rows = rows[:filter.PageSize]
+		last := rows[len(rows)-1]
+		page.NextPageToken = encodePageToken(pageCursor{createdAt: last.CreatedAt.Time, id: last.ID})
+	}
+
+	page.Orders, err = s.loadOrderAggregates(ctx, qtx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// statusesToStrings converts OrderStatus slice to the string form stored in oms.orders.status.
+func statusesToStrings(statuses []order.OrderStatus) []string {
+	result := make([]string, len(statuses))
+	for i, s := range statuses {
+		result[i] = s.String()
+	}
+
+	return result
+}
+
+// timeToTimestamptz converts an optional time to pgtype.Timestamptz (NULL when nil).
+func timeToTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+// encodePageToken encodes a cursor as base64url("<unix_micro>:<id>").
+// Microseconds match PostgreSQL timestamptz precision.
+func encodePageToken(cursor pageCursor) string {
+	raw := strconv.FormatInt(cursor.createdAt.UnixMicro(), 10) + ":" + cursor.id.String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodePageToken decodes a token produced by encodePageToken.
+func decodePageToken(token string) (pageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageCursor{}, errInvalidPageToken
+	}
+
+	micros, id, found := strings.Cut(string(raw), ":")
+	if !found {
+		return pageCursor{}, errInvalidPageToken
+	}
+
+	unixMicro, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return pageCursor{}, errInvalidPageToken
+	}
+
+	orderID, err := uuid.Parse(id)
+	if err != nil {
+		return pageCursor{}, errInvalidPageToken
+	}
+
+	return pageCursor{createdAt: time.UnixMicro(unixMicro).UTC(), id: orderID}, nil
+}
@@ -69,6 +69,11 @@ func cloneOrderState(state *order.OrderState) *order.OrderState {
 		cloneOrderDeliveryInfo(state.GetDeliveryInfo()),
 		state.GetDeliveryStatus(),
 		cloneTimePointer(state.GetDeliveryRequestedAt()),
+		order.WithGiftMessage(state.GetGiftMessage()),
+		order.WithOrderDiscount(state.GetOrderDiscount()),
+		order.WithTotals(state.GetTotals()),
+		order.WithFulfillmentType(state.GetRecordedFulfillmentType()),
+		order.WithPackageDeliveryStatuses(state.GetPackageDeliveryStatuses()),
 	)
 }
 
@@ -89,25 +94,103 @@ func (s *Store) loadOrderAggregate(ctx context.Context, qtx *queries.Queries, ro
 		deliveryInfoRow = &deliveryRow
 	}
 
-	result := (&dto.OrderRow{Order: row, Items: items, Delivery: deliveryInfoRow}).ToDomain()
+	packages, err := qtx.GetOrderPackageDeliveryStatuses(ctx, row.ID)
+	if err != nil {
+		return nil, domain.WrapUnavailable("GetOrderPackageDeliveryStatuses", err)
+	}
+
+	return s.assembleOrderAggregate(&dto.OrderRow{Order: row, Items: items, Delivery: deliveryInfoRow, Packages: packages}), nil
+}
+
+// loadOrderAggregates loads the items, delivery info and package statuses of all rows in three
+// queries and assembles the aggregates in row order.
+func (s *Store) loadOrderAggregates(ctx context.Context, qtx *queries.Queries, rows []queries.OmsOrder) ([]*order.OrderState, error) {
+	result := make([]*order.OrderState, 0, len(rows))
+	if len(rows) == 0 {
+		return result, nil
+	}
+
+	orderIDs := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		orderIDs = append(orderIDs, row.ID)
+	}
+
+	itemRows, err := qtx.GetOrderItemsByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, domain.WrapUnavailable("GetOrderItemsByOrderIDs", err)
+	}
+
+	items := make(map[uuid.UUID][]queries.GetOrderItemsRow, len(rows))
+	for _, item := range itemRows {
+		items[item.OrderID] = append(items[item.OrderID], queries.GetOrderItemsRow{
+			GoodID:      item.GoodID,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			Note:        item.Note,
+			Currency:    item.Currency,
+			TaxCategory: item.TaxCategory,
+		})
+	}
+
+	deliveryRows, err := qtx.GetOrderDeliveryInfoByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, domain.WrapUnavailable("GetOrderDeliveryInfoByOrderIDs", err)
+	}
+
+	deliveries := make(map[uuid.UUID]*queries.GetOrderDeliveryInfoRow, len(deliveryRows))
+	for _, deliveryRow := range deliveryRows {
+		deliveryInfoRow := queries.GetOrderDeliveryInfoRow(deliveryRow)
+		deliveries[deliveryRow.OrderID] = &deliveryInfoRow
+	}
+
+	packageRows, err := qtx.GetOrderPackageDeliveryStatusesByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, domain.WrapUnavailable("GetOrderPackageDeliveryStatusesByOrderIDs", err)
+	}
+
+	packages := make(map[uuid.UUID][]queries.GetOrderPackageDeliveryStatusesRow, len(rows))
+	for _, pkg := range packageRows {
+		packages[pkg.OrderID] = append(packages[pkg.OrderID], queries.GetOrderPackageDeliveryStatusesRow{
+			PackageID:      pkg.PackageID,
+			DeliveryStatus: pkg.DeliveryStatus,
+		})
+	}
 
-	cost := int64(200 + len(items)*50) //nolint:mnd // ristretto cost formula
-	s.cache.SetWithTTL(row.ID.String(), cloneOrderState(result), cost, cacheTTL)
+	for _, row := range rows {
+		result = append(result, s.assembleOrderAggregate(&dto.OrderRow{
+			Order:    row,
+			Items:    items[row.ID],
+			Delivery: deliveries[row.ID],
+			Packages: packages[row.ID],
+		}))
+	}
 
 	return result, nil
 }
 
-// Load retrieves an order by ID.
+// assembleOrderAggregate builds the aggregate from its loaded rows and caches it unless it is archived.
+func (s *Store) assembleOrderAggregate(orderRow *dto.OrderRow) *order.OrderState {
+	result := orderRow.ToDomain()
+
+	// Archived orders are never cached, so a cache hit is always an active order
+	if !orderRow.Order.ArchivedAt.Valid {
+		cost := int64(200 + len(orderRow.Items)*50) //nolint:mnd // ristretto cost formula
+		s.cache.SetWithTTL(orderRow.Order.ID.String(), cloneOrderState(result), cost, cacheTTL)
+	}
+
+	return result
+}
+
+// Load retrieves an order by ID. Archived orders are reported as ports.ErrNotFound.
 // Uses L1 cache for frequently accessed orders.
 // Requires transaction in context (use UnitOfWork.Begin()).
 func (s *Store) Load(ctx context.Context, orderID uuid.UUID) (*order.OrderState, error) {
-	// Check L1 cache first
-	cacheKey := orderID.String()
-	if cachedOrder, found := s.cache.Get(cacheKey); found {
-		return cloneOrderState(cachedOrder), nil
-	}
+	return s.LoadWithOptions(ctx, orderID, ports.LoadOptions{})
+}
 
-	// Cache miss - fetch from database
+// LoadWithOptions retrieves an order by ID; opts.IncludeArchived also finds archived orders.
+// Requires transaction in context (use UnitOfWork.Begin()).
+func (s *Store) LoadWithOptions(ctx context.Context, orderID uuid.UUID, opts ports.LoadOptions) (*order.OrderState, error) {
 	pgxTx := uow.FromContext(ctx)
 	if pgxTx == nil {
 		return nil, ErrTransactionRequired
@@ -115,8 +198,23 @@ func (s *Store) Load(ctx context.Context, orderID uuid.UUID) (*order.OrderState,
 
 	qtx := s.query.WithTx(pgxTx)
 
+	// Check L1 cache first
+	cacheKey := orderID.String()
+	if cachedOrder, found := s.cache.Get(cacheKey); found {
+		cached, err := s.checkCachedOrder(ctx, qtx, orderID, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if cached {
+			return cloneOrderState(cachedOrder), nil
+		}
+	}
+
+	// Cache miss - fetch from database
+
 	// Get order header
-	row, err := qtx.GetOrder(ctx, orderID)
+	row, err := qtx.GetOrder(ctx, queries.GetOrderParams{ID: orderID, Column2: opts.IncludeArchived})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ports.ErrNotFound
@@ -128,7 +226,35 @@ func (s *Store) Load(ctx context.Context, orderID uuid.UUID) (*order.OrderState,
 	return s.loadOrderAggregate(ctx, qtx, row)
 }
 
-// LoadByPackageID retrieves an order by delivery package ID.
+// checkCachedOrder reports whether a cached order may still be served. The entry is
+// dropped once the order is archived, which another replica may have done without
+// touching this replica's cache.
+func (s *Store) checkCachedOrder(ctx context.Context, qtx *queries.Queries, orderID uuid.UUID, opts ports.LoadOptions) (bool, error) {
+	if opts.IncludeArchived {
+		return true, nil
+	}
+
+	archived, err := qtx.IsOrderArchived(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.cache.Del(orderID.String())
+
+			return false, ports.ErrNotFound
+		}
+
+		return false, domain.WrapUnavailable("IsOrderArchived", err)
+	}
+
+	if archived {
+		s.cache.Del(orderID.String())
+
+		return false, ports.ErrNotFound
+	}
+
+	return true, nil
+}
+
+// LoadByPackageID retrieves an active (not archived) order by delivery package ID.
 // Requires transaction in context (use UnitOfWork.Begin()).
 func (s *Store) LoadByPackageID(ctx context.Context, packageID uuid.UUID) (*order.OrderState, error) {
 	pgxTx := uow.FromContext(ctx)
@@ -149,7 +275,7 @@ func (s *Store) LoadByPackageID(ctx context.Context, packageID uuid.UUID) (*orde
 	return s.loadOrderAggregate(ctx, qtx, row)
 }
 
-// ListByCustomer retrieves all orders for a customer.
+// ListByCustomer retrieves all active (not archived) orders for a customer.
 // Requires transaction in context (use UnitOfWork.Begin()).
 func (s *Store) ListByCustomer(ctx context.Context, customerID uuid.UUID) ([]*order.OrderState, error) {
 	pgxTx := uow.FromContext(ctx)
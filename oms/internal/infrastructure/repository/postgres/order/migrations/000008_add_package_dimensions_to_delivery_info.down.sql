@@ -0,0 +1,4 @@
+ALTER TABLE oms.order_delivery_info
+    DROP COLUMN IF EXISTS length_cm,
+    DROP COLUMN IF EXISTS width_cm,
+    DROP COLUMN IF EXISTS height_cm;
@@ -0,0 +1,8 @@
+ALTER TABLE oms.order_delivery_info
+    ADD COLUMN IF NOT EXISTS length_cm DECIMAL(8, 2),
+    ADD COLUMN IF NOT EXISTS width_cm  DECIMAL(8, 2),
+    ADD COLUMN IF NOT EXISTS height_cm DECIMAL(8, 2);
+
+COMMENT ON COLUMN oms.order_delivery_info.length_cm IS 'Package length in centimeters (used for volumetric weight)';
+COMMENT ON COLUMN oms.order_delivery_info.width_cm IS 'Package width in centimeters (used for volumetric weight)';
+COMMENT ON COLUMN oms.order_delivery_info.height_cm IS 'Package height in centimeters (used for volumetric weight)';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS oms.orders_active_customer_id_idx;
+
+ALTER TABLE oms.orders
+    DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,6 @@
+ALTER TABLE oms.orders
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ NULL;
+
+COMMENT ON COLUMN oms.orders.archived_at IS 'When the order was archived (soft-deleted); archived orders are hidden from loads and lists by default';
+
+CREATE INDEX IF NOT EXISTS orders_active_customer_id_idx ON oms.orders(customer_id) WHERE archived_at IS NULL;
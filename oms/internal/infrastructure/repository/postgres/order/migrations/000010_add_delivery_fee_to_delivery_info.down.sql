@@ -0,0 +1,2 @@
+ALTER TABLE oms.order_delivery_info
+    DROP COLUMN IF EXISTS delivery_fee;
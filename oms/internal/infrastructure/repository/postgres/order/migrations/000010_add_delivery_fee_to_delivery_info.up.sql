@@ -0,0 +1,4 @@
+ALTER TABLE oms.order_delivery_info
+    ADD COLUMN IF NOT EXISTS delivery_fee DECIMAL(12, 2) NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN oms.order_delivery_info.delivery_fee IS 'Shipping charge calculated at checkout (0 = free shipping)';
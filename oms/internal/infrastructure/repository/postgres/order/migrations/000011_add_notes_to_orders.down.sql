@@ -0,0 +1,5 @@
+ALTER TABLE oms.order_items
+    DROP COLUMN IF EXISTS note;
+
+ALTER TABLE oms.orders
+    DROP COLUMN IF EXISTS gift_message;
@@ -0,0 +1,8 @@
+ALTER TABLE oms.orders
+    ADD COLUMN IF NOT EXISTS gift_message TEXT NULL;
+
+ALTER TABLE oms.order_items
+    ADD COLUMN IF NOT EXISTS note TEXT NULL;
+
+COMMENT ON COLUMN oms.orders.gift_message IS 'Gift message carried over from the cart at checkout; NULL when none';
+COMMENT ON COLUMN oms.order_items.note IS 'Customer note carried over from the cart item at checkout; NULL when none';
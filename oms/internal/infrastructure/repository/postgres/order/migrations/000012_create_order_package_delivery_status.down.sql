@@ -0,0 +1 @@
+DROP TABLE IF EXISTS oms.order_package_delivery_status;
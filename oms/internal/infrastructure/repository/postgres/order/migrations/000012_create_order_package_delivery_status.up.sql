@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS oms.order_package_delivery_status (
+    order_id        UUID NOT NULL REFERENCES oms.orders(id) ON DELETE CASCADE,
+    package_id      UUID NOT NULL,
+    delivery_status VARCHAR(32) NOT NULL,
+    PRIMARY KEY (order_id, package_id)
+);
+
+CREATE INDEX IF NOT EXISTS order_package_delivery_status_package_id_idx ON oms.order_package_delivery_status(package_id);
+
+COMMENT ON TABLE oms.order_package_delivery_status IS 'Delivery status of each package of an order split across several packages';
+COMMENT ON COLUMN oms.order_package_delivery_status.package_id IS 'Delivery service package ID';
+COMMENT ON COLUMN oms.order_package_delivery_status.delivery_status IS 'Delivery status of the package; order_delivery_info.delivery_status holds the summary';
@@ -0,0 +1,2 @@
+ALTER TABLE oms.orders
+    DROP COLUMN IF EXISTS order_discount;
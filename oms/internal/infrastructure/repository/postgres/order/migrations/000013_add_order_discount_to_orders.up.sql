@@ -0,0 +1,4 @@
+ALTER TABLE oms.orders
+    ADD COLUMN IF NOT EXISTS order_discount DECIMAL(12,2) NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN oms.orders.order_discount IS 'Order-level discount applied by the pricer on top of the item discounts';
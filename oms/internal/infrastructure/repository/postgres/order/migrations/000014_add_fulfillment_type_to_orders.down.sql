@@ -0,0 +1,2 @@
+ALTER TABLE oms.orders
+    DROP COLUMN IF EXISTS fulfillment_type;
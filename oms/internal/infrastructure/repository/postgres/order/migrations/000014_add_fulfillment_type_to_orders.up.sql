@@ -0,0 +1,4 @@
+ALTER TABLE oms.orders
+    ADD COLUMN IF NOT EXISTS fulfillment_type TEXT;
+
+COMMENT ON COLUMN oms.orders.fulfillment_type IS 'Delivery or self-pickup as chosen at checkout; NULL for orders that predate it';
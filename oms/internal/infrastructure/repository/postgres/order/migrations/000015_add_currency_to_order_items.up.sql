@@ -0,0 +1,4 @@
+ALTER TABLE oms.order_items
+    ADD COLUMN IF NOT EXISTS currency CHAR(3);
+
+COMMENT ON COLUMN oms.order_items.currency IS 'ISO-4217 currency of the price; NULL when not specified';
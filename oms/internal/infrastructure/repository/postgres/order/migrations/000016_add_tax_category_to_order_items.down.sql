@@ -0,0 +1,2 @@
+ALTER TABLE oms.order_items
+    DROP COLUMN IF EXISTS tax_category;
@@ -0,0 +1,4 @@
+ALTER TABLE oms.order_items
+    ADD COLUMN IF NOT EXISTS tax_category TEXT;
+
+COMMENT ON COLUMN oms.order_items.tax_category IS 'Tax category tax policies branch on (e.g. food); NULL means the pricer''s default';
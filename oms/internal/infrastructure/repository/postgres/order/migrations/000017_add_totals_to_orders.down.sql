@@ -0,0 +1,4 @@
+ALTER TABLE oms.orders
+    DROP COLUMN IF EXISTS subtotal,
+    DROP COLUMN IF EXISTS total_tax,
+    DROP COLUMN IF EXISTS final_price;
@@ -0,0 +1,8 @@
+ALTER TABLE oms.orders
+    ADD COLUMN IF NOT EXISTS subtotal DECIMAL(12,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS total_tax DECIMAL(12,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS final_price DECIMAL(12,2) NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN oms.orders.subtotal IS 'Sum of the item prices the order was last priced at, before discounts and tax';
+COMMENT ON COLUMN oms.orders.total_tax IS 'Tax the order was last priced at';
+COMMENT ON COLUMN oms.orders.final_price IS 'Price charged for the items when the order was last priced';
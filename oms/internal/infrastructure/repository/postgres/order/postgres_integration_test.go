@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -13,7 +14,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	order "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/internal/domain/pricing"
 	orderrepo "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/testhelpers"
 	uowpg "github.com/shortlink-org/shop/oms/pkg/uow/postgres"
@@ -28,7 +31,13 @@ CREATE TABLE IF NOT EXISTS oms.orders (
     status      VARCHAR(32) NOT NULL DEFAULT 'PENDING',
     version     INT NOT NULL DEFAULT 1,
     created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    gift_message TEXT,
+    order_discount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    fulfillment_type TEXT,
+    subtotal DECIMAL(12,2) NOT NULL DEFAULT 0,
+    total_tax DECIMAL(12,2) NOT NULL DEFAULT 0,
+    final_price DECIMAL(12,2) NOT NULL DEFAULT 0
 );
 
 CREATE INDEX IF NOT EXISTS orders_customer_id_idx ON oms.orders(customer_id);
@@ -39,6 +48,9 @@ CREATE TABLE IF NOT EXISTS oms.order_items (
     good_id   UUID NOT NULL,
     quantity  INT NOT NULL CHECK (quantity > 0),
     price     DECIMAL(12,2) NOT NULL,
+    note      TEXT,
+    currency  CHAR(3),
+    tax_category TEXT,
     PRIMARY KEY (order_id, good_id)
 );
 `
@@ -107,6 +119,218 @@ func TestOrder_SaveAndLoad(t *testing.T) {
 	require.Len(t, loadedItems, 2)
 }
 
+func TestOrder_NotesRoundTrip(t *testing.T) {
+	store, uow, pc := setupOrderTest(t)
+	ctx := context.Background()
+
+	notedGoodID := uuid.New()
+	orderState := createOrderWithItems(t, uuid.New(), order.Items{
+		order.NewItem(notedGoodID, 1, decimal.NewFromFloat(19.99)).WithNote("wrap in blue paper"),
+		order.NewItem(uuid.New(), 1, decimal.NewFromFloat(5.00)),
+	})
+	require.NoError(t, orderState.SetGiftMessage("Happy birthday!"))
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(txCtx, orderState))
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	loaded, err := store.Load(txCtx2, orderState.GetOrderID())
+	require.NoError(t, err)
+	assert.Equal(t, "Happy birthday!", loaded.GetGiftMessage())
+
+	for _, item := range loaded.GetItems() {
+		if item.GetGoodId() == notedGoodID {
+			assert.Equal(t, "wrap in blue paper", item.GetNote())
+		} else {
+			assert.Empty(t, item.GetNote())
+		}
+	}
+
+	// Empty notes are stored as NULL
+	var nullNotes int
+	err = pc.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM oms.order_items WHERE order_id = $1 AND note IS NULL`, orderState.GetOrderID()).Scan(&nullNotes)
+	require.NoError(t, err)
+	assert.Equal(t, 1, nullNotes)
+}
+
+func TestOrder_ItemCurrencyAndTaxCategoryRoundTrip(t *testing.T) {
+	store, uow, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	orderState := createOrderWithItems(t, uuid.New(), order.Items{
+		order.NewItem(uuid.New(), 1, decimal.NewFromFloat(19.99)).WithCurrency("EUR").WithTaxCategory("food"),
+		order.NewItem(uuid.New(), 2, decimal.NewFromFloat(5.00)).WithCurrency("EUR"),
+	})
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(txCtx, orderState))
+	require.NoError(t, uow.Commit(txCtx))
+
+	// Rewrite the items through the batch path
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	loaded, err := store.Load(txCtx, orderState.GetOrderID())
+	require.NoError(t, err)
+
+	for _, item := range loaded.GetItems() {
+		assert.Equal(t, pricing.Currency("EUR"), item.GetCurrency())
+	}
+
+	require.NoError(t, store.SaveBatch(txCtx, []*order.OrderState{loaded}))
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	reloaded, err := store.Load(txCtx2, orderState.GetOrderID())
+	require.NoError(t, err)
+
+	taxCategories := make([]string, 0, len(reloaded.GetItems()))
+	for _, item := range reloaded.GetItems() {
+		assert.Equal(t, pricing.Currency("EUR"), item.GetCurrency())
+		taxCategories = append(taxCategories, item.GetTaxCategory())
+	}
+
+	assert.ElementsMatch(t, []string{"food", ""}, taxCategories)
+}
+
+func TestOrder_FulfillmentTypeRoundTrip(t *testing.T) {
+	store, uow, pc := setupOrderTest(t)
+	ctx := context.Background()
+
+	pickupOrder := createOrderWithItems(t, uuid.New(), order.Items{
+		order.NewItem(uuid.New(), 1, decimal.NewFromFloat(19.99)),
+	})
+	require.NoError(t, pickupOrder.SetFulfillmentType(order.FulfillmentType_FULFILLMENT_TYPE_PICKUP))
+
+	legacyOrder := createOrderWithItems(t, uuid.New(), order.Items{
+		order.NewItem(uuid.New(), 1, decimal.NewFromFloat(5.00)),
+	})
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(txCtx, pickupOrder))
+	require.NoError(t, store.Save(txCtx, legacyOrder))
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	// A pickup order still rejects delivery info after the round trip
+	loaded, err := store.Load(txCtx2, pickupOrder.GetOrderID())
+	require.NoError(t, err)
+	assert.Equal(t, order.FulfillmentType_FULFILLMENT_TYPE_PICKUP, loaded.GetRecordedFulfillmentType())
+
+	pickupAddr, err := address.NewAddress("123 Warehouse St", "Moscow", "101000", "Russia")
+	require.NoError(t, err)
+	deliveryAddr, err := address.NewAddress("456 Customer St", "Moscow", "102000", "Russia")
+	require.NoError(t, err)
+
+	deliveryInfo := order.NewDeliveryInfo(
+		pickupAddr, deliveryAddr,
+		order.NewDeliveryPeriod(time.Now().Add(24*time.Hour), time.Now().Add(26*time.Hour)),
+		order.NewPackageInfo(1.5), order.DeliveryPriorityNormal, nil,
+	)
+	require.ErrorIs(t, loaded.SetDeliveryInfo(deliveryInfo), order.ErrDeliveryInfoNotAllowedForPickup)
+
+	// Orders without a recorded type are stored as NULL and keep the legacy rule
+	legacyLoaded, err := store.Load(txCtx2, legacyOrder.GetOrderID())
+	require.NoError(t, err)
+	assert.Equal(t, order.FulfillmentType_FULFILLMENT_TYPE_UNSPECIFIED, legacyLoaded.GetRecordedFulfillmentType())
+
+	var nullTypes int
+	err = pc.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM oms.orders WHERE id = $1 AND fulfillment_type IS NULL`, legacyOrder.GetOrderID()).Scan(&nullTypes)
+	require.NoError(t, err)
+	assert.Equal(t, 1, nullTypes)
+}
+
+func TestOrder_OrderDiscountRoundTrip(t *testing.T) {
+	store, uow, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	orderState := createOrderWithItems(t, uuid.New(), order.Items{
+		order.NewItem(uuid.New(), 2, decimal.NewFromFloat(60.00)),
+	})
+	require.NoError(t, orderState.SetOrderDiscount(decimal.NewFromInt(10)))
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(txCtx, orderState))
+	require.NoError(t, uow.Commit(txCtx))
+
+	// Update the discount through the batch path
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	loaded, err := store.Load(txCtx, orderState.GetOrderID())
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(10).Equal(loaded.GetOrderDiscount()))
+
+	require.NoError(t, loaded.SetOrderDiscount(decimal.NewFromFloat(12.50)))
+	require.NoError(t, store.SaveBatch(txCtx, []*order.OrderState{loaded}))
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	reloaded, err := store.Load(txCtx2, orderState.GetOrderID())
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromFloat(12.50).Equal(reloaded.GetOrderDiscount()))
+}
+
+func TestOrder_TotalsRoundTrip(t *testing.T) {
+	store, uow, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	orderState := createOrderWithItems(t, uuid.New(), order.Items{
+		order.NewItem(uuid.New(), 2, decimal.NewFromFloat(60.00)),
+	})
+	require.NoError(t, orderState.SetTotals(order.OrderTotals{
+		Subtotal:   decimal.NewFromInt(120),
+		TotalTax:   decimal.NewFromFloat(9.60),
+		FinalPrice: decimal.NewFromFloat(129.60),
+	}))
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(txCtx, orderState))
+	require.NoError(t, uow.Commit(txCtx))
+
+	// Reprice through the batch path
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	loaded, err := store.Load(txCtx, orderState.GetOrderID())
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromFloat(129.60).Equal(loaded.GetTotals().FinalPrice))
+
+	require.NoError(t, loaded.SetTotals(order.OrderTotals{
+		Subtotal:   decimal.NewFromInt(100),
+		TotalTax:   decimal.NewFromInt(8),
+		FinalPrice: decimal.NewFromInt(108),
+	}))
+	require.NoError(t, store.SaveBatch(txCtx, []*order.OrderState{loaded}))
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	reloaded, err := store.Load(txCtx2, orderState.GetOrderID())
+	require.NoError(t, err)
+	totals := reloaded.GetTotals()
+	assert.True(t, decimal.NewFromInt(100).Equal(totals.Subtotal))
+	assert.True(t, decimal.NewFromInt(8).Equal(totals.TotalTax))
+	assert.True(t, decimal.NewFromInt(108).Equal(totals.FinalPrice))
+}
+
 func TestOrder_ListByCustomer(t *testing.T) {
 	store, uow, _ := setupOrderTest(t)
 	ctx := context.Background()
@@ -389,3 +613,360 @@ func TestOrder_MultipleItemsPreserved(t *testing.T) {
 	assert.Equal(t, int32(1), item2.GetQuantity())
 	assert.True(t, item2.GetPrice().Equal(decimal.NewFromFloat(99.99)))
 }
+
+func TestOrder_SaveBatch(t *testing.T) {
+	store, uow, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	existing := createOrderWithItems(t, customerID, order.Items{
+		order.NewItem(uuid.New(), 1, decimal.NewFromFloat(10.00)),
+	})
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	err = store.Save(txCtx, existing)
+	require.NoError(t, err)
+	err = uow.Commit(txCtx)
+	require.NoError(t, err)
+
+	// Batch one update of a persisted order with two new orders
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	loaded, err := store.Load(txCtx, existing.GetOrderID())
+	require.NoError(t, err)
+	err = loaded.CancelOrder()
+	require.NoError(t, err)
+
+	created1 := createOrderWithItems(t, customerID, order.Items{
+		order.NewItem(uuid.New(), 2, decimal.NewFromFloat(20.00)),
+		order.NewItem(uuid.New(), 1, decimal.NewFromFloat(5.50)),
+	})
+	created2 := createOrderWithItems(t, customerID, order.Items{
+		order.NewItem(uuid.New(), 3, decimal.NewFromFloat(30.00)),
+	})
+
+	err = store.SaveBatch(txCtx, []*order.OrderState{loaded, created1, created2})
+	require.NoError(t, err)
+	err = uow.Commit(txCtx)
+	require.NoError(t, err)
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	updated, err := store.Load(txCtx2, existing.GetOrderID())
+	require.NoError(t, err)
+	assert.Equal(t, order.OrderStatus_ORDER_STATUS_CANCELED, updated.GetStatus())
+	assert.Equal(t, 2, updated.GetVersion())
+
+	for _, created := range []*order.OrderState{created1, created2} {
+		saved, loadErr := store.Load(txCtx2, created.GetOrderID())
+		require.NoError(t, loadErr)
+		assert.Equal(t, 1, saved.GetVersion())
+		assert.Len(t, saved.GetItems(), len(created.GetItems()))
+	}
+
+	orders, err := store.ListByCustomer(txCtx2, customerID)
+	require.NoError(t, err)
+	assert.Len(t, orders, 3)
+}
+
+func TestOrder_SaveBatchStaleVersionAbortsBatch(t *testing.T) {
+	store, uow, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	stale := createOrderWithItems(t, customerID, order.Items{
+		order.NewItem(uuid.New(), 1, decimal.NewFromFloat(10.00)),
+	})
+	untouched := createOrderWithItems(t, customerID, order.Items{
+		order.NewItem(uuid.New(), 1, decimal.NewFromFloat(15.00)),
+	})
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	err = store.SaveBatch(txCtx, []*order.OrderState{stale, untouched})
+	require.NoError(t, err)
+	err = uow.Commit(txCtx)
+	require.NoError(t, err)
+
+	// Load both orders, then bump the first one's version behind the batch's back
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	staleCopy, err := store.Load(txCtx, stale.GetOrderID())
+	require.NoError(t, err)
+	untouchedCopy, err := store.Load(txCtx, untouched.GetOrderID())
+	require.NoError(t, err)
+	err = uow.Commit(txCtx)
+	require.NoError(t, err)
+
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	concurrent, err := store.Load(txCtx, stale.GetOrderID())
+	require.NoError(t, err)
+	err = concurrent.CancelOrder()
+	require.NoError(t, err)
+	err = store.Save(txCtx, concurrent)
+	require.NoError(t, err)
+	err = uow.Commit(txCtx)
+	require.NoError(t, err)
+
+	// The batch holds a stale copy of the first order; nothing of it may be written
+	err = staleCopy.CancelOrder()
+	require.NoError(t, err)
+	err = untouchedCopy.CancelOrder()
+	require.NoError(t, err)
+	created := createOrderWithItems(t, customerID, order.Items{
+		order.NewItem(uuid.New(), 1, decimal.NewFromFloat(20.00)),
+	})
+
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	err = store.SaveBatch(txCtx, []*order.OrderState{untouchedCopy, staleCopy, created})
+	assert.True(t, errors.Is(err, ports.ErrVersionConflict), "expected ErrVersionConflict, got: %v", err)
+	err = uow.Commit(txCtx)
+	require.NoError(t, err, "a failed batch must leave the surrounding transaction usable")
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	reloaded, err := store.Load(txCtx2, untouched.GetOrderID())
+	require.NoError(t, err)
+	assert.Equal(t, order.OrderStatus_ORDER_STATUS_PROCESSING, reloaded.GetStatus())
+	assert.Equal(t, 1, reloaded.GetVersion())
+
+	_, err = store.Load(txCtx2, created.GetOrderID())
+	assert.True(t, errors.Is(err, ports.ErrNotFound), "expected ErrNotFound, got: %v", err)
+}
+
+// saveCancelledOrder persists a new order and cancels it, so it can be archived.
+func saveCancelledOrder(t *testing.T, store *orderrepo.Store, uow *uowpg.UoW, customerID uuid.UUID) *order.OrderState {
+	t.Helper()
+	ctx := context.Background()
+
+	orderState := createOrderWithItems(t, customerID, order.Items{
+		order.NewItem(uuid.New(), 1, decimal.NewFromFloat(10.00)),
+	})
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	err = store.Save(txCtx, orderState)
+	require.NoError(t, err)
+	err = uow.Commit(txCtx)
+	require.NoError(t, err)
+
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	loaded, err := store.Load(txCtx, orderState.GetOrderID())
+	require.NoError(t, err)
+	err = loaded.CancelOrder()
+	require.NoError(t, err)
+	err = store.Save(txCtx, loaded)
+	require.NoError(t, err)
+	err = uow.Commit(txCtx)
+	require.NoError(t, err)
+
+	return loaded
+}
+
+func TestOrder_ArchiveThenLoad(t *testing.T) {
+	store, uow, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	cancelled := saveCancelledOrder(t, store, uow, uuid.New())
+	orderID := cancelled.GetOrderID()
+
+	// Warm the L1 cache so Archive must invalidate it
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	_, err = store.Load(txCtx, orderID)
+	require.NoError(t, err)
+	err = store.Archive(txCtx, orderID)
+	require.NoError(t, err)
+	err = uow.Commit(txCtx)
+	require.NoError(t, err)
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	_, err = store.Load(txCtx2, orderID)
+	assert.True(t, errors.Is(err, ports.ErrNotFound), "expected ErrNotFound, got: %v", err)
+
+	archived, err := store.LoadWithOptions(txCtx2, orderID, ports.LoadOptions{IncludeArchived: true})
+	require.NoError(t, err)
+	assert.Equal(t, order.OrderStatus_ORDER_STATUS_CANCELED, archived.GetStatus())
+
+	// Loading with IncludeArchived must not make the archived order visible to plain loads
+	_, err = store.Load(txCtx2, orderID)
+	assert.True(t, errors.Is(err, ports.ErrNotFound), "expected ErrNotFound, got: %v", err)
+
+	// Archiving twice is a no-op
+	err = store.Archive(txCtx2, orderID)
+	require.NoError(t, err)
+}
+
+func TestOrder_ArchiveOnAnotherReplica(t *testing.T) {
+	store, uow, pc := setupOrderTest(t)
+	ctx := context.Background()
+
+	// A second store stands in for another replica with its own L1 cache
+	replica, err := orderrepo.New(ctx, pc.DB())
+	require.NoError(t, err)
+	t.Cleanup(replica.Close)
+
+	cancelled := saveCancelledOrder(t, store, uow, uuid.New())
+	orderID := cancelled.GetOrderID()
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	_, err = replica.Load(txCtx, orderID)
+	require.NoError(t, err)
+	err = uow.Commit(txCtx)
+	require.NoError(t, err)
+
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	err = store.Archive(txCtx, orderID)
+	require.NoError(t, err)
+	err = uow.Commit(txCtx)
+	require.NoError(t, err)
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	_, err = replica.Load(txCtx2, orderID)
+	assert.True(t, errors.Is(err, ports.ErrNotFound), "expected ErrNotFound, got: %v", err)
+}
+
+func TestOrder_ArchiveRejectsActiveAndMissingOrders(t *testing.T) {
+	store, uow, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	active := createOrderWithItems(t, uuid.New(), order.Items{
+		order.NewItem(uuid.New(), 1, decimal.NewFromFloat(10.00)),
+	})
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx)
+
+	err = store.Save(txCtx, active)
+	require.NoError(t, err)
+
+	err = store.Archive(txCtx, active.GetOrderID())
+	assert.True(t, errors.Is(err, orderrepo.ErrOrderNotArchivable), "expected ErrOrderNotArchivable, got: %v", err)
+
+	err = store.Archive(txCtx, uuid.New())
+	assert.True(t, errors.Is(err, ports.ErrNotFound), "expected ErrNotFound, got: %v", err)
+}
+
+func TestOrder_ListExcludesArchived(t *testing.T) {
+	store, uow, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	archivedOrder := saveCancelledOrder(t, store, uow, customerID)
+	activeOrder := createOrderWithItems(t, customerID, order.Items{
+		order.NewItem(uuid.New(), 2, decimal.NewFromFloat(20.00)),
+	})
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	err = store.Save(txCtx, activeOrder)
+	require.NoError(t, err)
+	err = store.Archive(txCtx, archivedOrder.GetOrderID())
+	require.NoError(t, err)
+	err = uow.Commit(txCtx)
+	require.NoError(t, err)
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	byCustomer, err := store.ListByCustomer(txCtx2, customerID)
+	require.NoError(t, err)
+	require.Len(t, byCustomer, 1)
+	assert.Equal(t, activeOrder.GetOrderID(), byCustomer[0].GetOrderID())
+
+	listed, err := store.List(txCtx2, ports.ListFilter{CustomerID: &customerID})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, activeOrder.GetOrderID(), listed[0].GetOrderID())
+
+	listedAll, err := store.List(txCtx2, ports.ListFilter{CustomerID: &customerID, IncludeArchived: true})
+	require.NoError(t, err)
+	assert.Len(t, listedAll, 2)
+
+	page, err := store.ListPage(txCtx2, ports.ListPageFilter{
+		ListFilter: ports.ListFilter{CustomerID: &customerID},
+		PageSize:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 1)
+	assert.Equal(t, activeOrder.GetOrderID(), page.Orders[0].GetOrderID())
+
+	pageAll, err := store.ListPage(txCtx2, ports.ListPageFilter{
+		ListFilter: ports.ListFilter{CustomerID: &customerID, IncludeArchived: true},
+		PageSize:   10,
+	})
+	require.NoError(t, err)
+	assert.Len(t, pageAll.Orders, 2)
+}
+
+func TestOrder_ListPageLoadsEachOrdersItems(t *testing.T) {
+	store, uow, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	first := createOrderWithItems(t, customerID, order.Items{
+		order.NewItem(uuid.New(), 1, decimal.NewFromFloat(10.00)).WithNote("fragile"),
+	})
+	second := createOrderWithItems(t, customerID, order.Items{
+		order.NewItem(uuid.New(), 2, decimal.NewFromFloat(20.00)),
+		order.NewItem(uuid.New(), 3, decimal.NewFromFloat(30.00)),
+	})
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(txCtx, first))
+	require.NoError(t, store.Save(txCtx, second))
+	require.NoError(t, uow.Commit(txCtx))
+
+	txCtx2, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	defer uow.Rollback(txCtx2)
+
+	page, err := store.ListPage(txCtx2, ports.ListPageFilter{
+		ListFilter: ports.ListFilter{CustomerID: &customerID},
+		PageSize:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 2)
+
+	listed := make(map[uuid.UUID]*order.OrderState, len(page.Orders))
+	for _, orderState := range page.Orders {
+		listed[orderState.GetOrderID()] = orderState
+	}
+
+	require.Contains(t, listed, first.GetOrderID())
+	require.Len(t, listed[first.GetOrderID()].GetItems(), 1)
+	assert.Equal(t, "fragile", listed[first.GetOrderID()].GetItems()[0].GetNote())
+
+	require.Contains(t, listed, second.GetOrderID())
+
+	quantities := make(map[uuid.UUID]int32)
+	for _, item := range listed[second.GetOrderID()].GetItems() {
+		quantities[item.GetGoodId()] = item.GetQuantity()
+	}
+
+	want := make(map[uuid.UUID]int32)
+	for _, item := range second.GetItems() {
+		want[item.GetGoodId()] = item.GetQuantity()
+	}
+
+	assert.Equal(t, want, quantities)
+}
@@ -32,13 +32,24 @@ func (s *Store) Save(ctx context.Context, state *order.OrderState) error {
 	status := state.GetStatus().String()
 	newVersion := int32(state.GetVersion() + 1)
 	oldVersion := int32(state.GetVersion())
+	giftMessage := state.GetGiftMessage()
+	// An empty gift message is stored as NULL
+	giftMessageText := pgtype.Text{String: giftMessage, Valid: giftMessage != ""}
+	fulfillmentType := fulfillmentTypeText(state.GetRecordedFulfillmentType())
+	totals := state.GetTotals()
 
 	if oldVersion == 0 {
 		// New order - insert
 		err := qtx.InsertOrder(ctx, queries.InsertOrderParams{
-			ID:         orderID,
-			CustomerID: customerID,
-			Status:     status,
+			ID:              orderID,
+			CustomerID:      customerID,
+			Status:          status,
+			GiftMessage:     giftMessageText,
+			OrderDiscount:   state.GetOrderDiscount(),
+			FulfillmentType: fulfillmentType,
+			Subtotal:        totals.Subtotal,
+			TotalTax:        totals.TotalTax,
+			FinalPrice:      totals.FinalPrice,
 		})
 		if err != nil {
 			return domain.WrapUnavailable("InsertOrder", err)
@@ -46,10 +57,16 @@ func (s *Store) Save(ctx context.Context, state *order.OrderState) error {
 	} else {
 		// Update with optimistic lock
 		result, err := qtx.UpdateOrder(ctx, queries.UpdateOrderParams{
-			ID:        orderID,
-			Status:    status,
-			Version:   newVersion,
-			Version_2: oldVersion,
+			ID:              orderID,
+			Status:          status,
+			Version:         newVersion,
+			Version_2:       oldVersion,
+			GiftMessage:     giftMessageText,
+			OrderDiscount:   state.GetOrderDiscount(),
+			FulfillmentType: fulfillmentType,
+			Subtotal:        totals.Subtotal,
+			TotalTax:        totals.TotalTax,
+			FinalPrice:      totals.FinalPrice,
 		})
 		if err != nil {
 			return domain.WrapUnavailable("UpdateOrder", err)
@@ -68,10 +85,13 @@ func (s *Store) Save(ctx context.Context, state *order.OrderState) error {
 
 	for _, item := range state.GetItems() {
 		insertErr := qtx.InsertOrderItem(ctx, queries.InsertOrderItemParams{
-			OrderID:  orderID,
-			GoodID:   item.GetGoodId(),
-			Quantity: item.GetQuantity(),
-			Price:    item.GetPrice(),
+			OrderID:     orderID,
+			GoodID:      item.GetGoodId(),
+			Quantity:    item.GetQuantity(),
+			Price:       item.GetPrice(),
+			Note:        pgtype.Text{String: item.GetNote(), Valid: item.GetNote() != ""},
+			Currency:    pgtype.Text{String: item.GetCurrency().String(), Valid: item.GetCurrency() != ""},
+			TaxCategory: pgtype.Text{String: item.GetTaxCategory(), Valid: item.GetTaxCategory() != ""},
 		})
 		if insertErr != nil {
 			return domain.WrapUnavailable("InsertOrderItem", insertErr)
@@ -85,7 +105,7 @@ func (s *Store) Save(ctx context.Context, state *order.OrderState) error {
 	}
 
 	// Invalidate L1 cache after successful save
-	s.invalidateCache(orderID.String())
+	s.invalidateCache(ctx, orderID.String())
 
 	return nil
 }
@@ -155,6 +175,10 @@ func (s *Store) saveDeliveryInfo(ctx context.Context, qtx *queries.Queries, orde
 		RecipientName:      recipientName,
 		RecipientPhone:     recipientPhone,
 		RecipientEmail:     recipientEmail,
+		LengthCm:           optionalFloat64ToNumeric(pkgInfo.GetLengthCm()),
+		WidthCm:            optionalFloat64ToNumeric(pkgInfo.GetWidthCm()),
+		HeightCm:           optionalFloat64ToNumeric(pkgInfo.GetHeightCm()),
+		DeliveryFee:        deliveryInfo.GetDeliveryFee(),
 	}
 
 	if isNew {
@@ -163,7 +187,7 @@ func (s *Store) saveDeliveryInfo(ctx context.Context, qtx *queries.Queries, orde
 			return domain.WrapUnavailable("InsertOrderDeliveryInfo", err)
 		}
 
-		return nil
+		return s.savePackageDeliveryStatuses(ctx, qtx, orderID, state, isNew)
 	}
 
 	// For updates, delete and re-insert (simpler than upsert)
@@ -177,12 +201,40 @@ func (s *Store) saveDeliveryInfo(ctx context.Context, qtx *queries.Queries, orde
 		return domain.WrapUnavailable("InsertOrderDeliveryInfo", err)
 	}
 
+	return s.savePackageDeliveryStatuses(ctx, qtx, orderID, state, isNew)
+}
+
+// savePackageDeliveryStatuses replaces the per-package delivery statuses of a split delivery.
+func (s *Store) savePackageDeliveryStatuses(ctx context.Context, qtx *queries.Queries, orderID uuid.UUID, state *order.OrderState, isNew bool) error {
+	if !isNew {
+		err := qtx.DeleteOrderPackageDeliveryStatuses(ctx, orderID)
+		if err != nil {
+			return domain.WrapUnavailable("DeleteOrderPackageDeliveryStatuses", err)
+		}
+	}
+
+	for packageID, status := range state.GetPackageDeliveryStatuses() {
+		err := qtx.InsertOrderPackageDeliveryStatus(ctx, queries.InsertOrderPackageDeliveryStatusParams{
+			OrderID:        orderID,
+			PackageID:      packageID,
+			DeliveryStatus: status.String(),
+		})
+		if err != nil {
+			return domain.WrapUnavailable("InsertOrderPackageDeliveryStatus", err)
+		}
+	}
+
 	return nil
 }
 
-// invalidateCache removes an order from the L1 cache.
-func (s *Store) invalidateCache(orderID string) {
+// invalidateCache removes an order from the L1 cache now and again once the
+// transaction in ctx commits, so a load racing the commit cannot keep the old state cached.
+func (s *Store) invalidateCache(ctx context.Context, orderID string) {
 	s.cache.Del(orderID)
+
+	uow.AfterCommit(ctx, func() {
+		s.cache.Del(orderID)
+	})
 }
 
 // float64ToNumeric converts a float64 to pgtype.Numeric.
@@ -196,3 +248,21 @@ func float64ToNumeric(f float64) pgtype.Numeric {
 
 	return n
 }
+
+// optionalFloat64ToNumeric converts a float64 to pgtype.Numeric, mapping 0 (unknown) to NULL.
+func optionalFloat64ToNumeric(f float64) pgtype.Numeric {
+	if f == 0 {
+		return pgtype.Numeric{}
+	}
+
+	return float64ToNumeric(f)
+}
+
+// fulfillmentTypeText converts a fulfillment type to its stored form, mapping UNSPECIFIED (orders that predate it) to NULL.
+func fulfillmentTypeText(fulfillmentType order.FulfillmentType) pgtype.Text {
+	if fulfillmentType == order.FulfillmentType_FULFILLMENT_TYPE_UNSPECIFIED {
+		return pgtype.Text{}
+	}
+
+	return pgtype.Text{String: fulfillmentType.String(), Valid: true}
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	order "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order/schema/queries"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
+)
+
+// ErrDuplicateOrderInBatch is returned when SaveBatch receives the same order twice.
+var ErrDuplicateOrderInBatch = errors.New("order appears more than once in batch")
+
+// SaveBatch persists several order states with one multi-row upsert for orders and one for their items.
+// Every order is version-checked like Save; a single conflict aborts the whole batch.
+// Requires transaction in context (use UnitOfWork.Begin()); the batch runs in a savepoint of it,
+// so a failed batch leaves the surrounding transaction usable.
+func (s *Store) SaveBatch(ctx context.Context, states []*order.OrderState) error {
+	if len(states) == 0 {
+		return nil
+	}
+
+	pgxTx := uow.FromContext(ctx)
+	if pgxTx == nil {
+		return ErrTransactionRequired
+	}
+
+	batchTx, err := pgxTx.Begin(ctx)
+	if err != nil {
+		return domain.WrapUnavailable("BeginSaveBatch", err)
+	}
+
+	defer func() {
+		_ = batchTx.Rollback(ctx) //nolint:errcheck // no-op after a successful commit
+	}()
+
+	qtx := s.query.WithTx(batchTx)
+
+	orders, items, err := newSaveBatchParams(states)
+	if err != nil {
+		return err
+	}
+
+	saved, err := qtx.UpsertOrdersBatch(ctx, orders)
+	if err != nil {
+		return domain.WrapUnavailable("UpsertOrdersBatch", err)
+	}
+
+	err = checkBatchVersions(states, saved)
+	if err != nil {
+		return err
+	}
+
+	// Delete existing items and insert new ones
+	err = qtx.DeleteOrderItemsBatch(ctx, orders.Ids)
+	if err != nil {
+		return domain.WrapUnavailable("DeleteOrderItemsBatch", err)
+	}
+
+	if len(items.OrderIds) > 0 {
+		err = qtx.InsertOrderItemsBatch(ctx, items)
+		if err != nil {
+			return domain.WrapUnavailable("InsertOrderItemsBatch", err)
+		}
+	}
+
+	for _, state := range states {
+		err = s.saveDeliveryInfo(ctx, qtx, state.GetOrderID(), state, state.GetVersion() == 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = batchTx.Commit(ctx)
+	if err != nil {
+		return domain.WrapUnavailable("CommitSaveBatch", err)
+	}
+
+	// Invalidate L1 cache after successful save
+	for _, state := range states {
+		s.invalidateCache(ctx, state.GetOrderID().String())
+	}
+
+	return nil
+}
+
+// newSaveBatchParams flattens the states into the column arrays of the batch queries.
+func newSaveBatchParams(states []*order.OrderState) (queries.UpsertOrdersBatchParams, queries.InsertOrderItemsBatchParams, error) {
+	orders := queries.UpsertOrdersBatchParams{
+		Ids:              make([]uuid.UUID, 0, len(states)),
+		CustomerIds:      make([]uuid.UUID, 0, len(states)),
+		Statuses:         make([]string, 0, len(states)),
+		GiftMessages:     make([]string, 0, len(states)),
+		OrderDiscounts:   make([]string, 0, len(states)),
+		FulfillmentTypes: make([]string, 0, len(states)),
+		Subtotals:        make([]string, 0, len(states)),
+		TotalTaxes:       make([]string, 0, len(states)),
+		FinalPrices:      make([]string, 0, len(states)),
+		ExpectedVersions: make([]int32, 0, len(states)),
+	}
+
+	var items queries.InsertOrderItemsBatchParams
+
+	seen := make(map[uuid.UUID]struct{}, len(states))
+
+	for _, state := range states {
+		orderID := state.GetOrderID()
+		if _, ok := seen[orderID]; ok {
+			return orders, items, fmt.Errorf("%w: %s", ErrDuplicateOrderInBatch, orderID)
+		}
+		seen[orderID] = struct{}{}
+
+		orders.Ids = append(orders.Ids, orderID)
+		orders.CustomerIds = append(orders.CustomerIds, state.GetCustomerId())
+		orders.Statuses = append(orders.Statuses, state.GetStatus().String())
+		orders.GiftMessages = append(orders.GiftMessages, state.GetGiftMessage())
+		orders.OrderDiscounts = append(orders.OrderDiscounts, state.GetOrderDiscount().String())
+		orders.FulfillmentTypes = append(orders.FulfillmentTypes, fulfillmentTypeText(state.GetRecordedFulfillmentType()).String)
+		totals := state.GetTotals()
+		orders.Subtotals = append(orders.Subtotals, totals.Subtotal.String())
+		orders.TotalTaxes = append(orders.TotalTaxes, totals.TotalTax.String())
+		orders.FinalPrices = append(orders.FinalPrices, totals.FinalPrice.String())
+		orders.ExpectedVersions = append(orders.ExpectedVersions, int32(state.GetVersion()))
+
+		for _, item := range state.GetItems() {
+			items.OrderIds = append(items.OrderIds, orderID)
+			items.GoodIds = append(items.GoodIds, item.GetGoodId())
+			items.Quantities = append(items.Quantities, item.GetQuantity())
+			items.Prices = append(items.Prices, item.GetPrice().String())
+			items.Notes = append(items.Notes, item.GetNote())
+			items.Currencies = append(items.Currencies, item.GetCurrency().String())
+			items.TaxCategories = append(items.TaxCategories, item.GetTaxCategory())
+		}
+	}
+
+	return orders, items, nil
+}
+
+// checkBatchVersions verifies every order was written at its expected version + 1.
+// A stale existing order is not returned by the upsert; an order saved as existing
+// but missing in the table comes back freshly inserted at version 1.
+func checkBatchVersions(states []*order.OrderState, saved []queries.UpsertOrdersBatchRow) error {
+	versions := make(map[uuid.UUID]int32, len(saved))
+	for _, row := range saved {
+		versions[row.ID] = row.Version
+	}
+
+	for _, state := range states {
+		version, ok := versions[state.GetOrderID()]
+		if !ok || version != int32(state.GetVersion()+1) {
+			return ports.ErrVersionConflict
+		}
+	}
+
+	return nil
+}
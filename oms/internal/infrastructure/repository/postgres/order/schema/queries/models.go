@@ -20,6 +20,20 @@ type OmsOrder struct {
 	Version   int32
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
+	// When the order was archived (soft-deleted); archived orders are hidden from loads and lists by default
+	ArchivedAt pgtype.Timestamptz
+	// Gift message carried over from the cart at checkout; NULL when none
+	GiftMessage pgtype.Text
+	// Order-level discount applied by the pricer on top of the item discounts
+	OrderDiscount decimal.Decimal
+	// Delivery or self-pickup as chosen at checkout; NULL for orders that predate it
+	FulfillmentType pgtype.Text
+	// Sum of the item prices the order was last priced at, before discounts and tax
+	Subtotal decimal.Decimal
+	// Tax the order was last priced at
+	TotalTax decimal.Decimal
+	// Price charged for the items when the order was last priced
+	FinalPrice decimal.Decimal
 }
 
 // Delivery information for orders
@@ -58,6 +72,14 @@ type OmsOrderDeliveryInfo struct {
 	DeliveryStatus string
 	// When OMS successfully requested delivery and received package_id
 	RequestedAt pgtype.Timestamptz
+	// Package length in centimeters (used for volumetric weight)
+	LengthCm pgtype.Numeric
+	// Package width in centimeters (used for volumetric weight)
+	WidthCm pgtype.Numeric
+	// Package height in centimeters (used for volumetric weight)
+	HeightCm pgtype.Numeric
+	// Shipping charge calculated at checkout (0 = free shipping)
+	DeliveryFee decimal.Decimal
 }
 
 // Items in orders
@@ -66,6 +88,21 @@ type OmsOrderItem struct {
 	GoodID   uuid.UUID
 	Quantity int32
 	Price    decimal.Decimal
+	// Customer note carried over from the cart item at checkout; NULL when none
+	Note pgtype.Text
+	// ISO-4217 currency of the price; NULL when not specified
+	Currency pgtype.Text
+	// Tax category tax policies branch on (e.g. food); NULL means the pricer's default
+	TaxCategory pgtype.Text
+}
+
+// Delivery status of each package of an order split across several packages
+type OmsOrderPackageDeliveryStatus struct {
+	OrderID uuid.UUID
+	// Delivery service package ID
+	PackageID uuid.UUID
+	// Delivery status of the package; order_delivery_info.delivery_status holds the summary
+	DeliveryStatus string
 }
 
 // Outbox for OMS domain events; forwarded to Kafka by RunForwarder
@@ -13,26 +13,41 @@ import (
 )
 
 type Querier interface {
+	ArchiveOrder(ctx context.Context, arg ArchiveOrderParams) (pgconn.CommandTag, error)
 	CountOrders(ctx context.Context) (int64, error)
 	CountOrdersByCustomer(ctx context.Context, customerID uuid.UUID) (int64, error)
 	CountOrdersByStatus(ctx context.Context, dollar_1 []int32) (int64, error)
 	CountOrdersWithFilters(ctx context.Context, arg CountOrdersWithFiltersParams) (int64, error)
 	DeleteOrderDeliveryInfo(ctx context.Context, orderID uuid.UUID) error
 	DeleteOrderItems(ctx context.Context, orderID uuid.UUID) error
-	GetOrder(ctx context.Context, id uuid.UUID) (OmsOrder, error)
+	DeleteOrderItemsBatch(ctx context.Context, orderIds []uuid.UUID) error
+	DeleteOrderPackageDeliveryStatuses(ctx context.Context, orderID uuid.UUID) error
+	GetOrder(ctx context.Context, arg GetOrderParams) (OmsOrder, error)
+	// Matches the requested package as well as any package of a split delivery.
 	GetOrderByPackageID(ctx context.Context, packageID pgtype.UUID) (OmsOrder, error)
 	GetOrderDeliveryInfo(ctx context.Context, orderID uuid.UUID) (GetOrderDeliveryInfoRow, error)
+	GetOrderDeliveryInfoByOrderIDs(ctx context.Context, orderIds []uuid.UUID) ([]GetOrderDeliveryInfoByOrderIDsRow, error)
 	GetOrderItems(ctx context.Context, orderID uuid.UUID) ([]GetOrderItemsRow, error)
+	GetOrderItemsByOrderIDs(ctx context.Context, orderIds []uuid.UUID) ([]GetOrderItemsByOrderIDsRow, error)
+	GetOrderPackageDeliveryStatuses(ctx context.Context, orderID uuid.UUID) ([]GetOrderPackageDeliveryStatusesRow, error)
+	GetOrderPackageDeliveryStatusesByOrderIDs(ctx context.Context, orderIds []uuid.UUID) ([]GetOrderPackageDeliveryStatusesByOrderIDsRow, error)
 	InsertOrder(ctx context.Context, arg InsertOrderParams) error
 	InsertOrderDeliveryInfo(ctx context.Context, arg InsertOrderDeliveryInfoParams) error
 	InsertOrderItem(ctx context.Context, arg InsertOrderItemParams) error
+	InsertOrderItemsBatch(ctx context.Context, arg InsertOrderItemsBatchParams) error
+	InsertOrderPackageDeliveryStatus(ctx context.Context, arg InsertOrderPackageDeliveryStatusParams) error
+	IsOrderArchived(ctx context.Context, id uuid.UUID) (bool, error)
 	ListOrders(ctx context.Context, arg ListOrdersParams) ([]OmsOrder, error)
 	ListOrdersByCustomer(ctx context.Context, customerID uuid.UUID) ([]OmsOrder, error)
+	ListOrdersPage(ctx context.Context, arg ListOrdersPageParams) ([]OmsOrder, error)
 	ListOrdersWithCustomerFilter(ctx context.Context, arg ListOrdersWithCustomerFilterParams) ([]OmsOrder, error)
 	ListOrdersWithFilters(ctx context.Context, arg ListOrdersWithFiltersParams) ([]OmsOrder, error)
 	ListOrdersWithStatusFilter(ctx context.Context, arg ListOrdersWithStatusFilterParams) ([]OmsOrder, error)
 	UpdateOrder(ctx context.Context, arg UpdateOrderParams) (pgconn.CommandTag, error)
 	UpdateOrderDeliveryInfo(ctx context.Context, arg UpdateOrderDeliveryInfoParams) error
+	// Inserts new orders and updates existing ones in one statement. An existing row is only
+	// updated when its version matches the expected one; callers compare the returned versions.
+	UpsertOrdersBatch(ctx context.Context, arg UpsertOrdersBatchParams) ([]UpsertOrdersBatchRow, error)
 }
 
 var _ Querier = (*Queries)(nil)
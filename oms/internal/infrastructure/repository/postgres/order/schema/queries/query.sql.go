@@ -14,6 +14,21 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+const archiveOrder = `-- name: ArchiveOrder :execresult
+UPDATE oms.orders
+SET archived_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND archived_at IS NULL AND status = ANY($2::text[])
+`
+
+type ArchiveOrderParams struct {
+	ID      uuid.UUID
+	Column2 []string
+}
+
+func (q *Queries) ArchiveOrder(ctx context.Context, arg ArchiveOrderParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, archiveOrder, arg.ID, arg.Column2)
+}
+
 const countOrders = `-- name: CountOrders :one
 SELECT COUNT(*) FROM oms.orders
 `
@@ -83,14 +98,39 @@ func (q *Queries) DeleteOrderItems(ctx context.Context, orderID uuid.UUID) error
 	return err
 }
 
+const deleteOrderItemsBatch = `-- name: DeleteOrderItemsBatch :exec
+DELETE FROM oms.order_items
+WHERE order_id = ANY($1::uuid[])
+`
+
+func (q *Queries) DeleteOrderItemsBatch(ctx context.Context, orderIds []uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteOrderItemsBatch, orderIds)
+	return err
+}
+
+const deleteOrderPackageDeliveryStatuses = `-- name: DeleteOrderPackageDeliveryStatuses :exec
+DELETE FROM oms.order_package_delivery_status
+WHERE order_id = $1
+`
+
+func (q *Queries) DeleteOrderPackageDeliveryStatuses(ctx context.Context, orderID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteOrderPackageDeliveryStatuses, orderID)
+	return err
+}
+
 const getOrder = `-- name: GetOrder :one
-SELECT id, customer_id, status, version, created_at, updated_at
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
 FROM oms.orders
-WHERE id = $1
+WHERE id = $1 AND ($2::boolean OR archived_at IS NULL)
 `
 
-func (q *Queries) GetOrder(ctx context.Context, id uuid.UUID) (OmsOrder, error) {
-	row := q.db.QueryRow(ctx, getOrder, id)
+type GetOrderParams struct {
+	ID      uuid.UUID
+	Column2 bool
+}
+
+func (q *Queries) GetOrder(ctx context.Context, arg GetOrderParams) (OmsOrder, error) {
+	row := q.db.QueryRow(ctx, getOrder, arg.ID, arg.Column2)
 	var i OmsOrder
 	err := row.Scan(
 		&i.ID,
@@ -99,17 +139,27 @@ func (q *Queries) GetOrder(ctx context.Context, id uuid.UUID) (OmsOrder, error)
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ArchivedAt,
+		&i.GiftMessage,
+		&i.OrderDiscount,
+		&i.FulfillmentType,
+		&i.Subtotal,
+		&i.TotalTax,
+		&i.FinalPrice,
 	)
 	return i, err
 }
 
 const getOrderByPackageID = `-- name: GetOrderByPackageID :one
-SELECT o.id, o.customer_id, o.status, o.version, o.created_at, o.updated_at
+SELECT o.id, o.customer_id, o.status, o.version, o.created_at, o.updated_at, o.archived_at, o.gift_message, o.order_discount, o.fulfillment_type, o.subtotal, o.total_tax, o.final_price
 FROM oms.orders o
-JOIN oms.order_delivery_info odi ON odi.order_id = o.id
-WHERE odi.package_id = $1
+WHERE o.archived_at IS NULL AND (
+    EXISTS (SELECT 1 FROM oms.order_delivery_info odi WHERE odi.order_id = o.id AND odi.package_id = $1)
+    OR EXISTS (SELECT 1 FROM oms.order_package_delivery_status ops WHERE ops.order_id = o.id AND ops.package_id = $1)
+)
 `
 
+// Matches the requested package as well as any package of a split delivery.
 func (q *Queries) GetOrderByPackageID(ctx context.Context, packageID pgtype.UUID) (OmsOrder, error) {
 	row := q.db.QueryRow(ctx, getOrderByPackageID, packageID)
 	var i OmsOrder
@@ -120,6 +170,13 @@ func (q *Queries) GetOrderByPackageID(ctx context.Context, packageID pgtype.UUID
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ArchivedAt,
+		&i.GiftMessage,
+		&i.OrderDiscount,
+		&i.FulfillmentType,
+		&i.Subtotal,
+		&i.TotalTax,
+		&i.FinalPrice,
 	)
 	return i, err
 }
@@ -132,7 +189,9 @@ SELECT
     period_start, period_end,
     weight_kg,
     priority, package_id, delivery_status, requested_at,
-    recipient_name, recipient_phone, recipient_email
+    recipient_name, recipient_phone, recipient_email,
+    length_cm, width_cm, height_cm,
+    delivery_fee
 FROM oms.order_delivery_info
 WHERE order_id = $1
 `
@@ -161,6 +220,10 @@ type GetOrderDeliveryInfoRow struct {
 	RecipientName      pgtype.Text
 	RecipientPhone     pgtype.Text
 	RecipientEmail     pgtype.Text
+	LengthCm           pgtype.Numeric
+	WidthCm            pgtype.Numeric
+	HeightCm           pgtype.Numeric
+	DeliveryFee        decimal.Decimal
 }
 
 func (q *Queries) GetOrderDeliveryInfo(ctx context.Context, orderID uuid.UUID) (GetOrderDeliveryInfoRow, error) {
@@ -190,20 +253,120 @@ func (q *Queries) GetOrderDeliveryInfo(ctx context.Context, orderID uuid.UUID) (
 		&i.RecipientName,
 		&i.RecipientPhone,
 		&i.RecipientEmail,
+		&i.LengthCm,
+		&i.WidthCm,
+		&i.HeightCm,
+		&i.DeliveryFee,
 	)
 	return i, err
 }
 
+const getOrderDeliveryInfoByOrderIDs = `-- name: GetOrderDeliveryInfoByOrderIDs :many
+SELECT
+    order_id,
+    pickup_street, pickup_city, pickup_postal_code, pickup_country, pickup_latitude, pickup_longitude,
+    delivery_street, delivery_city, delivery_postal_code, delivery_country, delivery_latitude, delivery_longitude,
+    period_start, period_end,
+    weight_kg,
+    priority, package_id, delivery_status, requested_at,
+    recipient_name, recipient_phone, recipient_email,
+    length_cm, width_cm, height_cm,
+    delivery_fee
+FROM oms.order_delivery_info
+WHERE order_id = ANY($1::uuid[])
+`
+
+type GetOrderDeliveryInfoByOrderIDsRow struct {
+	OrderID            uuid.UUID
+	PickupStreet       pgtype.Text
+	PickupCity         pgtype.Text
+	PickupPostalCode   pgtype.Text
+	PickupCountry      pgtype.Text
+	PickupLatitude     pgtype.Numeric
+	PickupLongitude    pgtype.Numeric
+	DeliveryStreet     string
+	DeliveryCity       string
+	DeliveryPostalCode pgtype.Text
+	DeliveryCountry    string
+	DeliveryLatitude   pgtype.Numeric
+	DeliveryLongitude  pgtype.Numeric
+	PeriodStart        pgtype.Timestamptz
+	PeriodEnd          pgtype.Timestamptz
+	WeightKg           pgtype.Numeric
+	Priority           string
+	PackageID          pgtype.UUID
+	DeliveryStatus     string
+	RequestedAt        pgtype.Timestamptz
+	RecipientName      pgtype.Text
+	RecipientPhone     pgtype.Text
+	RecipientEmail     pgtype.Text
+	LengthCm           pgtype.Numeric
+	WidthCm            pgtype.Numeric
+	HeightCm           pgtype.Numeric
+	DeliveryFee        decimal.Decimal
+}
+
+func (q *Queries) GetOrderDeliveryInfoByOrderIDs(ctx context.Context, orderIds []uuid.UUID) ([]GetOrderDeliveryInfoByOrderIDsRow, error) {
+	rows, err := q.db.Query(ctx, getOrderDeliveryInfoByOrderIDs, orderIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOrderDeliveryInfoByOrderIDsRow
+	for rows.Next() {
+		var i GetOrderDeliveryInfoByOrderIDsRow
+		if err := rows.Scan(
+			&i.OrderID,
+			&i.PickupStreet,
+			&i.PickupCity,
+			&i.PickupPostalCode,
+			&i.PickupCountry,
+			&i.PickupLatitude,
+			&i.PickupLongitude,
+			&i.DeliveryStreet,
+			&i.DeliveryCity,
+			&i.DeliveryPostalCode,
+			&i.DeliveryCountry,
+			&i.DeliveryLatitude,
+			&i.DeliveryLongitude,
+			&i.PeriodStart,
+			&i.PeriodEnd,
+			&i.WeightKg,
+			&i.Priority,
+			&i.PackageID,
+			&i.DeliveryStatus,
+			&i.RequestedAt,
+			&i.RecipientName,
+			&i.RecipientPhone,
+			&i.RecipientEmail,
+			&i.LengthCm,
+			&i.WidthCm,
+			&i.HeightCm,
+			&i.DeliveryFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getOrderItems = `-- name: GetOrderItems :many
-SELECT good_id, quantity, price
+SELECT good_id, quantity, price, note, currency, tax_category
 FROM oms.order_items
 WHERE order_id = $1
 `
 
 type GetOrderItemsRow struct {
-	GoodID   uuid.UUID
-	Quantity int32
-	Price    decimal.Decimal
+	GoodID      uuid.UUID
+	Quantity    int32
+	Price       decimal.Decimal
+	Note        pgtype.Text
+	Currency    pgtype.Text
+	TaxCategory pgtype.Text
 }
 
 func (q *Queries) GetOrderItems(ctx context.Context, orderID uuid.UUID) ([]GetOrderItemsRow, error) {
@@ -215,7 +378,121 @@ func (q *Queries) GetOrderItems(ctx context.Context, orderID uuid.UUID) ([]GetOr
 	var items []GetOrderItemsRow
 	for rows.Next() {
 		var i GetOrderItemsRow
-		if err := rows.Scan(&i.GoodID, &i.Quantity, &i.Price); err != nil {
+		if err := rows.Scan(
+			&i.GoodID,
+			&i.Quantity,
+			&i.Price,
+			&i.Note,
+			&i.Currency,
+			&i.TaxCategory,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrderItemsByOrderIDs = `-- name: GetOrderItemsByOrderIDs :many
+SELECT order_id, good_id, quantity, price, note, currency, tax_category
+FROM oms.order_items
+WHERE order_id = ANY($1::uuid[])
+`
+
+type GetOrderItemsByOrderIDsRow struct {
+	OrderID     uuid.UUID
+	GoodID      uuid.UUID
+	Quantity    int32
+	Price       decimal.Decimal
+	Note        pgtype.Text
+	Currency    pgtype.Text
+	TaxCategory pgtype.Text
+}
+
+func (q *Queries) GetOrderItemsByOrderIDs(ctx context.Context, orderIds []uuid.UUID) ([]GetOrderItemsByOrderIDsRow, error) {
+	rows, err := q.db.Query(ctx, getOrderItemsByOrderIDs, orderIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOrderItemsByOrderIDsRow
+	for rows.Next() {
+		var i GetOrderItemsByOrderIDsRow
+		if err := rows.Scan(
+			&i.OrderID,
+			&i.GoodID,
+			&i.Quantity,
+			&i.Price,
+			&i.Note,
+			&i.Currency,
+			&i.TaxCategory,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrderPackageDeliveryStatuses = `-- name: GetOrderPackageDeliveryStatuses :many
+SELECT package_id, delivery_status
+FROM oms.order_package_delivery_status
+WHERE order_id = $1
+`
+
+type GetOrderPackageDeliveryStatusesRow struct {
+	PackageID      uuid.UUID
+	DeliveryStatus string
+}
+
+func (q *Queries) GetOrderPackageDeliveryStatuses(ctx context.Context, orderID uuid.UUID) ([]GetOrderPackageDeliveryStatusesRow, error) {
+	rows, err := q.db.Query(ctx, getOrderPackageDeliveryStatuses, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOrderPackageDeliveryStatusesRow
+	for rows.Next() {
+		var i GetOrderPackageDeliveryStatusesRow
+		if err := rows.Scan(&i.PackageID, &i.DeliveryStatus); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrderPackageDeliveryStatusesByOrderIDs = `-- name: GetOrderPackageDeliveryStatusesByOrderIDs :many
+SELECT order_id, package_id, delivery_status
+FROM oms.order_package_delivery_status
+WHERE order_id = ANY($1::uuid[])
+`
+
+type GetOrderPackageDeliveryStatusesByOrderIDsRow struct {
+	OrderID        uuid.UUID
+	PackageID      uuid.UUID
+	DeliveryStatus string
+}
+
+func (q *Queries) GetOrderPackageDeliveryStatusesByOrderIDs(ctx context.Context, orderIds []uuid.UUID) ([]GetOrderPackageDeliveryStatusesByOrderIDsRow, error) {
+	rows, err := q.db.Query(ctx, getOrderPackageDeliveryStatusesByOrderIDs, orderIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOrderPackageDeliveryStatusesByOrderIDsRow
+	for rows.Next() {
+		var i GetOrderPackageDeliveryStatusesByOrderIDsRow
+		if err := rows.Scan(&i.OrderID, &i.PackageID, &i.DeliveryStatus); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -227,18 +504,34 @@ func (q *Queries) GetOrderItems(ctx context.Context, orderID uuid.UUID) ([]GetOr
 }
 
 const insertOrder = `-- name: InsertOrder :exec
-INSERT INTO oms.orders (id, customer_id, status, version, created_at, updated_at)
-VALUES ($1, $2, $3, 1, NOW(), NOW())
+INSERT INTO oms.orders (id, customer_id, status, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price, version, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 1, NOW(), NOW())
 `
 
 type InsertOrderParams struct {
-	ID         uuid.UUID
-	CustomerID uuid.UUID
-	Status     string
+	ID              uuid.UUID
+	CustomerID      uuid.UUID
+	Status          string
+	GiftMessage     pgtype.Text
+	OrderDiscount   decimal.Decimal
+	FulfillmentType pgtype.Text
+	Subtotal        decimal.Decimal
+	TotalTax        decimal.Decimal
+	FinalPrice      decimal.Decimal
 }
 
 func (q *Queries) InsertOrder(ctx context.Context, arg InsertOrderParams) error {
-	_, err := q.db.Exec(ctx, insertOrder, arg.ID, arg.CustomerID, arg.Status)
+	_, err := q.db.Exec(ctx, insertOrder,
+		arg.ID,
+		arg.CustomerID,
+		arg.Status,
+		arg.GiftMessage,
+		arg.OrderDiscount,
+		arg.FulfillmentType,
+		arg.Subtotal,
+		arg.TotalTax,
+		arg.FinalPrice,
+	)
 	return err
 }
 
@@ -250,7 +543,9 @@ INSERT INTO oms.order_delivery_info (
     period_start, period_end,
     weight_kg,
     priority, package_id, delivery_status, requested_at,
-    recipient_name, recipient_phone, recipient_email
+    recipient_name, recipient_phone, recipient_email,
+    length_cm, width_cm, height_cm,
+    delivery_fee
 ) VALUES (
     $1,
     $2, $3, $4, $5, $6, $7,
@@ -258,7 +553,9 @@ INSERT INTO oms.order_delivery_info (
     $14, $15,
     $16,
     $17, $18, $19, $20,
-    $21, $22, $23
+    $21, $22, $23,
+    $24, $25, $26,
+    $27
 )
 `
 
@@ -286,6 +583,10 @@ type InsertOrderDeliveryInfoParams struct {
 	RecipientName      pgtype.Text
 	RecipientPhone     pgtype.Text
 	RecipientEmail     pgtype.Text
+	LengthCm           pgtype.Numeric
+	WidthCm            pgtype.Numeric
+	HeightCm           pgtype.Numeric
+	DeliveryFee        decimal.Decimal
 }
 
 func (q *Queries) InsertOrderDeliveryInfo(ctx context.Context, arg InsertOrderDeliveryInfoParams) error {
@@ -313,20 +614,27 @@ func (q *Queries) InsertOrderDeliveryInfo(ctx context.Context, arg InsertOrderDe
 		arg.RecipientName,
 		arg.RecipientPhone,
 		arg.RecipientEmail,
+		arg.LengthCm,
+		arg.WidthCm,
+		arg.HeightCm,
+		arg.DeliveryFee,
 	)
 	return err
 }
 
 const insertOrderItem = `-- name: InsertOrderItem :exec
-INSERT INTO oms.order_items (order_id, good_id, quantity, price)
-VALUES ($1, $2, $3, $4)
+INSERT INTO oms.order_items (order_id, good_id, quantity, price, note, currency, tax_category)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 `
 
 type InsertOrderItemParams struct {
-	OrderID  uuid.UUID
-	GoodID   uuid.UUID
-	Quantity int32
-	Price    decimal.Decimal
+	OrderID     uuid.UUID
+	GoodID      uuid.UUID
+	Quantity    int32
+	Price       decimal.Decimal
+	Note        pgtype.Text
+	Currency    pgtype.Text
+	TaxCategory pgtype.Text
 }
 
 func (q *Queries) InsertOrderItem(ctx context.Context, arg InsertOrderItemParams) error {
@@ -335,24 +643,86 @@ func (q *Queries) InsertOrderItem(ctx context.Context, arg InsertOrderItemParams
 		arg.GoodID,
 		arg.Quantity,
 		arg.Price,
+		arg.Note,
+		arg.Currency,
+		arg.TaxCategory,
+	)
+	return err
+}
+
+const insertOrderItemsBatch = `-- name: InsertOrderItemsBatch :exec
+INSERT INTO oms.order_items (order_id, good_id, quantity, price, note, currency, tax_category)
+SELECT order_id, good_id, quantity, price::numeric, NULLIF(note, ''), NULLIF(currency, ''), NULLIF(tax_category, '')
+FROM unnest($1::uuid[], $2::uuid[], $3::int[], $4::text[], $5::text[], $6::text[], $7::text[])
+    AS t(order_id, good_id, quantity, price, note, currency, tax_category)
+`
+
+type InsertOrderItemsBatchParams struct {
+	OrderIds      []uuid.UUID
+	GoodIds       []uuid.UUID
+	Quantities    []int32
+	Prices        []string
+	Notes         []string
+	Currencies    []string
+	TaxCategories []string
+}
+
+func (q *Queries) InsertOrderItemsBatch(ctx context.Context, arg InsertOrderItemsBatchParams) error {
+	_, err := q.db.Exec(ctx, insertOrderItemsBatch,
+		arg.OrderIds,
+		arg.GoodIds,
+		arg.Quantities,
+		arg.Prices,
+		arg.Notes,
+		arg.Currencies,
+		arg.TaxCategories,
 	)
 	return err
 }
 
+const insertOrderPackageDeliveryStatus = `-- name: InsertOrderPackageDeliveryStatus :exec
+INSERT INTO oms.order_package_delivery_status (order_id, package_id, delivery_status)
+VALUES ($1, $2, $3)
+`
+
+type InsertOrderPackageDeliveryStatusParams struct {
+	OrderID        uuid.UUID
+	PackageID      uuid.UUID
+	DeliveryStatus string
+}
+
+func (q *Queries) InsertOrderPackageDeliveryStatus(ctx context.Context, arg InsertOrderPackageDeliveryStatusParams) error {
+	_, err := q.db.Exec(ctx, insertOrderPackageDeliveryStatus, arg.OrderID, arg.PackageID, arg.DeliveryStatus)
+	return err
+}
+
+const isOrderArchived = `-- name: IsOrderArchived :one
+SELECT (archived_at IS NOT NULL)::bool AS archived FROM oms.orders WHERE id = $1
+`
+
+func (q *Queries) IsOrderArchived(ctx context.Context, id uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, isOrderArchived, id)
+	var archived bool
+	err := row.Scan(&archived)
+	return archived, err
+}
+
 const listOrders = `-- name: ListOrders :many
-SELECT id, customer_id, status, version, created_at, updated_at
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
 FROM oms.orders
+WHERE ($3::boolean OR archived_at IS NULL)
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
 `
 
 type ListOrdersParams struct {
-	Limit  int32
-	Offset int32
+	Limit   int32
+	Offset  int32
+	Column3 bool
 }
 
 func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]OmsOrder, error) {
-	rows, err := q.db.Query(ctx, listOrders, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listOrders, arg.Limit, arg.Offset, arg.Column3)
 	if err != nil {
 		return nil, err
 	}
@@ -367,6 +737,13 @@ func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]OmsOr
 			&i.Version,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ArchivedAt,
+			&i.GiftMessage,
+			&i.OrderDiscount,
+			&i.FulfillmentType,
+			&i.Subtotal,
+			&i.TotalTax,
+			&i.FinalPrice,
 		); err != nil {
 			return nil, err
 		}
@@ -379,9 +756,9 @@ func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]OmsOr
 }
 
 const listOrdersByCustomer = `-- name: ListOrdersByCustomer :many
-SELECT id, customer_id, status, version, created_at, updated_at
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
 FROM oms.orders
-WHERE customer_id = $1
+WHERE customer_id = $1 AND archived_at IS NULL
 ORDER BY created_at DESC
 `
 
@@ -401,6 +778,81 @@ func (q *Queries) ListOrdersByCustomer(ctx context.Context, customerID uuid.UUID
 			&i.Version,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ArchivedAt,
+			&i.GiftMessage,
+			&i.OrderDiscount,
+			&i.FulfillmentType,
+			&i.Subtotal,
+			&i.TotalTax,
+			&i.FinalPrice,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrdersPage = `-- name: ListOrdersPage :many
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
+FROM oms.orders
+WHERE ($1::uuid IS NULL OR customer_id = $1::uuid)
+  AND (cardinality($2::text[]) = 0 OR status = ANY($2::text[]))
+  AND ($3::timestamptz IS NULL OR created_at >= $3::timestamptz)
+  AND ($4::timestamptz IS NULL OR created_at < $4::timestamptz)
+  AND ($5::timestamptz IS NULL
+       OR (created_at, id) < ($5::timestamptz, $6::uuid))
+  AND ($7::boolean OR archived_at IS NULL)
+ORDER BY created_at DESC, id DESC
+LIMIT $8
+`
+
+type ListOrdersPageParams struct {
+	CustomerID      pgtype.UUID
+	Statuses        []string
+	CreatedFrom     pgtype.Timestamptz
+	CreatedTo       pgtype.Timestamptz
+	CursorCreatedAt pgtype.Timestamptz
+	CursorID        pgtype.UUID
+	IncludeArchived bool
+	PageLimit       int32
+}
+
+func (q *Queries) ListOrdersPage(ctx context.Context, arg ListOrdersPageParams) ([]OmsOrder, error) {
+	rows, err := q.db.Query(ctx, listOrdersPage,
+		arg.CustomerID,
+		arg.Statuses,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.IncludeArchived,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OmsOrder
+	for rows.Next() {
+		var i OmsOrder
+		if err := rows.Scan(
+			&i.ID,
+			&i.CustomerID,
+			&i.Status,
+			&i.Version,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ArchivedAt,
+			&i.GiftMessage,
+			&i.OrderDiscount,
+			&i.FulfillmentType,
+			&i.Subtotal,
+			&i.TotalTax,
+			&i.FinalPrice,
 		); err != nil {
 			return nil, err
 		}
@@ -413,9 +865,9 @@ func (q *Queries) ListOrdersByCustomer(ctx context.Context, customerID uuid.UUID
 }
 
 const listOrdersWithCustomerFilter = `-- name: ListOrdersWithCustomerFilter :many
-SELECT id, customer_id, status, version, created_at, updated_at
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
 FROM oms.orders
-WHERE customer_id = $1
+WHERE customer_id = $1 AND ($4::boolean OR archived_at IS NULL)
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -424,10 +876,16 @@ type ListOrdersWithCustomerFilterParams struct {
 	CustomerID uuid.UUID
 	Limit      int32
 	Offset     int32
+	Column4    bool
 }
 
 func (q *Queries) ListOrdersWithCustomerFilter(ctx context.Context, arg ListOrdersWithCustomerFilterParams) ([]OmsOrder, error) {
-	rows, err := q.db.Query(ctx, listOrdersWithCustomerFilter, arg.CustomerID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listOrdersWithCustomerFilter,
+		arg.CustomerID,
+		arg.Limit,
+		arg.Offset,
+		arg.Column4,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -442,6 +900,13 @@ func (q *Queries) ListOrdersWithCustomerFilter(ctx context.Context, arg ListOrde
 			&i.Version,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ArchivedAt,
+			&i.GiftMessage,
+			&i.OrderDiscount,
+			&i.FulfillmentType,
+			&i.Subtotal,
+			&i.TotalTax,
+			&i.FinalPrice,
 		); err != nil {
 			return nil, err
 		}
@@ -454,9 +919,9 @@ func (q *Queries) ListOrdersWithCustomerFilter(ctx context.Context, arg ListOrde
 }
 
 const listOrdersWithFilters = `-- name: ListOrdersWithFilters :many
-SELECT id, customer_id, status, version, created_at, updated_at
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
 FROM oms.orders
-WHERE customer_id = $1 AND status = ANY($2::int[])
+WHERE customer_id = $1 AND status = ANY($2::int[]) AND ($5::boolean OR archived_at IS NULL)
 ORDER BY created_at DESC
 LIMIT $3 OFFSET $4
 `
@@ -466,6 +931,7 @@ type ListOrdersWithFiltersParams struct {
 	Column2    []int32
 	Limit      int32
 	Offset     int32
+	Column5    bool
 }
 
 func (q *Queries) ListOrdersWithFilters(ctx context.Context, arg ListOrdersWithFiltersParams) ([]OmsOrder, error) {
@@ -474,6 +940,7 @@ func (q *Queries) ListOrdersWithFilters(ctx context.Context, arg ListOrdersWithF
 		arg.Column2,
 		arg.Limit,
 		arg.Offset,
+		arg.Column5,
 	)
 	if err != nil {
 		return nil, err
@@ -489,6 +956,13 @@ func (q *Queries) ListOrdersWithFilters(ctx context.Context, arg ListOrdersWithF
 			&i.Version,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ArchivedAt,
+			&i.GiftMessage,
+			&i.OrderDiscount,
+			&i.FulfillmentType,
+			&i.Subtotal,
+			&i.TotalTax,
+			&i.FinalPrice,
 		); err != nil {
 			return nil, err
 		}
@@ -501,9 +975,9 @@ func (q *Queries) ListOrdersWithFilters(ctx context.Context, arg ListOrdersWithF
 }
 
 const listOrdersWithStatusFilter = `-- name: ListOrdersWithStatusFilter :many
-SELECT id, customer_id, status, version, created_at, updated_at
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
 FROM oms.orders
-WHERE status = ANY($1::int[])
+WHERE status = ANY($1::int[]) AND ($4::boolean OR archived_at IS NULL)
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -512,10 +986,16 @@ type ListOrdersWithStatusFilterParams struct {
 	Column1 []int32
 	Limit   int32
 	Offset  int32
+	Column4 bool
 }
 
 func (q *Queries) ListOrdersWithStatusFilter(ctx context.Context, arg ListOrdersWithStatusFilterParams) ([]OmsOrder, error) {
-	rows, err := q.db.Query(ctx, listOrdersWithStatusFilter, arg.Column1, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listOrdersWithStatusFilter,
+		arg.Column1,
+		arg.Limit,
+		arg.Offset,
+		arg.Column4,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -530,6 +1010,13 @@ func (q *Queries) ListOrdersWithStatusFilter(ctx context.Context, arg ListOrders
 			&i.Version,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ArchivedAt,
+			&i.GiftMessage,
+			&i.OrderDiscount,
+			&i.FulfillmentType,
+			&i.Subtotal,
+			&i.TotalTax,
+			&i.FinalPrice,
 		); err != nil {
 			return nil, err
 		}
@@ -543,15 +1030,21 @@ func (q *Queries) ListOrdersWithStatusFilter(ctx context.Context, arg ListOrders
 
 const updateOrder = `-- name: UpdateOrder :execresult
 UPDATE oms.orders
-SET status = $2, version = $3, updated_at = NOW()
+SET status = $2, version = $3, gift_message = $5, order_discount = $6, fulfillment_type = $7, subtotal = $8, total_tax = $9, final_price = $10, updated_at = NOW()
 WHERE id = $1 AND version = $4
 `
 
 type UpdateOrderParams struct {
-	ID        uuid.UUID
-	Status    string
-	Version   int32
-	Version_2 int32
+	ID              uuid.UUID
+	Status          string
+	Version         int32
+	Version_2       int32
+	GiftMessage     pgtype.Text
+	OrderDiscount   decimal.Decimal
+	FulfillmentType pgtype.Text
+	Subtotal        decimal.Decimal
+	TotalTax        decimal.Decimal
+	FinalPrice      decimal.Decimal
 }
 
 func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) (pgconn.CommandTag, error) {
@@ -560,6 +1053,12 @@ func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) (pgcon
 		arg.Status,
 		arg.Version,
 		arg.Version_2,
+		arg.GiftMessage,
+		arg.OrderDiscount,
+		arg.FulfillmentType,
+		arg.Subtotal,
+		arg.TotalTax,
+		arg.FinalPrice,
 	)
 }
 
@@ -571,7 +1070,9 @@ SET
     period_start = $14, period_end = $15,
     weight_kg = $16,
     priority = $17, package_id = $18, delivery_status = $19, requested_at = $20,
-    recipient_name = $21, recipient_phone = $22, recipient_email = $23
+    recipient_name = $21, recipient_phone = $22, recipient_email = $23,
+    length_cm = $24, width_cm = $25, height_cm = $26,
+    delivery_fee = $27
 WHERE order_id = $1
 `
 
@@ -599,6 +1100,10 @@ type UpdateOrderDeliveryInfoParams struct {
 	RecipientName      pgtype.Text
 	RecipientPhone     pgtype.Text
 	RecipientEmail     pgtype.Text
+	LengthCm           pgtype.Numeric
+	WidthCm            pgtype.Numeric
+	HeightCm           pgtype.Numeric
+	DeliveryFee        decimal.Decimal
 }
 
 func (q *Queries) UpdateOrderDeliveryInfo(ctx context.Context, arg UpdateOrderDeliveryInfoParams) error {
@@ -626,6 +1131,76 @@ func (q *Queries) UpdateOrderDeliveryInfo(ctx context.Context, arg UpdateOrderDe
 		arg.RecipientName,
 		arg.RecipientPhone,
 		arg.RecipientEmail,
+		arg.LengthCm,
+		arg.WidthCm,
+		arg.HeightCm,
+		arg.DeliveryFee,
 	)
 	return err
 }
+
+const upsertOrdersBatch = `-- name: UpsertOrdersBatch :many
+WITH input AS (
+    SELECT *
+    FROM unnest($1::uuid[], $2::uuid[], $3::text[], $4::text[], $5::text[], $6::text[], $7::text[], $8::text[], $9::text[], $10::int[])
+        AS t(id, customer_id, status, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price, expected_version)
+)
+INSERT INTO oms.orders (id, customer_id, status, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price, version, created_at, updated_at)
+SELECT id, customer_id, status, NULLIF(gift_message, ''), order_discount::numeric, NULLIF(fulfillment_type, ''), subtotal::numeric, total_tax::numeric, final_price::numeric, 1, NOW(), NOW()
+FROM input
+ON CONFLICT (id) DO UPDATE
+SET status = EXCLUDED.status, gift_message = EXCLUDED.gift_message, order_discount = EXCLUDED.order_discount, fulfillment_type = EXCLUDED.fulfillment_type, subtotal = EXCLUDED.subtotal, total_tax = EXCLUDED.total_tax, final_price = EXCLUDED.final_price, version = oms.orders.version + 1, updated_at = NOW()
+WHERE oms.orders.version = (SELECT input.expected_version FROM input WHERE input.id = EXCLUDED.id)
+RETURNING id, version
+`
+
+type UpsertOrdersBatchParams struct {
+	Ids              []uuid.UUID
+	CustomerIds      []uuid.UUID
+	Statuses         []string
+	GiftMessages     []string
+	OrderDiscounts   []string
+	FulfillmentTypes []string
+	Subtotals        []string
+	TotalTaxes       []string
+	FinalPrices      []string
+	ExpectedVersions []int32
+}
+
+type UpsertOrdersBatchRow struct {
+	ID      uuid.UUID
+	Version int32
+}
+
+// Inserts new orders and updates existing ones in one statement. An existing row is only
+// updated when its version matches the expected one; callers compare the returned versions.
+func (q *Queries) UpsertOrdersBatch(ctx context.Context, arg UpsertOrdersBatchParams) ([]UpsertOrdersBatchRow, error) {
+	rows, err := q.db.Query(ctx, upsertOrdersBatch,
+		arg.Ids,
+		arg.CustomerIds,
+		arg.Statuses,
+		arg.GiftMessages,
+		arg.OrderDiscounts,
+		arg.FulfillmentTypes,
+		arg.Subtotals,
+		arg.TotalTaxes,
+		arg.FinalPrices,
+		arg.ExpectedVersions,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UpsertOrdersBatchRow
+	for rows.Next() {
+		var i UpsertOrdersBatchRow
+		if err := rows.Scan(&i.ID, &i.Version); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
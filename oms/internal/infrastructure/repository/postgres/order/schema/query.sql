@@ -1,52 +1,74 @@
 -- name: GetOrder :one
-SELECT id, customer_id, status, version, created_at, updated_at
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
 FROM oms.orders
-WHERE id = $1;
+WHERE id = $1 AND ($2::boolean OR archived_at IS NULL);
 
 -- name: GetOrderByPackageID :one
-SELECT o.id, o.customer_id, o.status, o.version, o.created_at, o.updated_at
+-- Matches the requested package as well as any package of a split delivery.
+SELECT o.id, o.customer_id, o.status, o.version, o.created_at, o.updated_at, o.archived_at, o.gift_message, o.order_discount, o.fulfillment_type, o.subtotal, o.total_tax, o.final_price
 FROM oms.orders o
-JOIN oms.order_delivery_info odi ON odi.order_id = o.id
-WHERE odi.package_id = $1;
+WHERE o.archived_at IS NULL AND (
+    EXISTS (SELECT 1 FROM oms.order_delivery_info odi WHERE odi.order_id = o.id AND odi.package_id = $1)
+    OR EXISTS (SELECT 1 FROM oms.order_package_delivery_status ops WHERE ops.order_id = o.id AND ops.package_id = $1)
+);
 
 -- name: GetOrderItems :many
-SELECT good_id, quantity, price
+SELECT good_id, quantity, price, note, currency, tax_category
 FROM oms.order_items
 WHERE order_id = $1;
 
+-- name: GetOrderItemsByOrderIDs :many
+SELECT order_id, good_id, quantity, price, note, currency, tax_category
+FROM oms.order_items
+WHERE order_id = ANY(@order_ids::uuid[]);
+
 -- name: ListOrdersByCustomer :many
-SELECT id, customer_id, status, version, created_at, updated_at
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
 FROM oms.orders
-WHERE customer_id = $1
+WHERE customer_id = $1 AND archived_at IS NULL
 ORDER BY created_at DESC;
 
 -- name: ListOrders :many
-SELECT id, customer_id, status, version, created_at, updated_at
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
 FROM oms.orders
+WHERE ($3::boolean OR archived_at IS NULL)
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
 -- name: ListOrdersWithCustomerFilter :many
-SELECT id, customer_id, status, version, created_at, updated_at
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
 FROM oms.orders
-WHERE customer_id = $1
+WHERE customer_id = $1 AND ($4::boolean OR archived_at IS NULL)
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: ListOrdersWithStatusFilter :many
-SELECT id, customer_id, status, version, created_at, updated_at
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
 FROM oms.orders
-WHERE status = ANY($1::int[])
+WHERE status = ANY($1::int[]) AND ($4::boolean OR archived_at IS NULL)
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: ListOrdersWithFilters :many
-SELECT id, customer_id, status, version, created_at, updated_at
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
 FROM oms.orders
-WHERE customer_id = $1 AND status = ANY($2::int[])
+WHERE customer_id = $1 AND status = ANY($2::int[]) AND ($5::boolean OR archived_at IS NULL)
 ORDER BY created_at DESC
 LIMIT $3 OFFSET $4;
 
+-- name: ListOrdersPage :many
+SELECT id, customer_id, status, version, created_at, updated_at, archived_at, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price
+FROM oms.orders
+WHERE (sqlc.narg('customer_id')::uuid IS NULL OR customer_id = sqlc.narg('customer_id')::uuid)
+  AND (cardinality(@statuses::text[]) = 0 OR status = ANY(@statuses::text[]))
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from')::timestamptz)
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to')::timestamptz)
+  AND (sqlc.narg('cursor_created_at')::timestamptz IS NULL
+       OR (created_at, id) < (sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id')::uuid))
+  AND (@include_archived::boolean OR archived_at IS NULL)
+ORDER BY created_at DESC, id DESC
+LIMIT @page_limit;
+
 -- name: CountOrders :one
 SELECT COUNT(*) FROM oms.orders;
 
@@ -60,21 +82,29 @@ SELECT COUNT(*) FROM oms.orders WHERE status = ANY($1::int[]);
 SELECT COUNT(*) FROM oms.orders WHERE customer_id = $1 AND status = ANY($2::int[]);
 
 -- name: InsertOrder :exec
-INSERT INTO oms.orders (id, customer_id, status, version, created_at, updated_at)
-VALUES ($1, $2, $3, 1, NOW(), NOW());
+INSERT INTO oms.orders (id, customer_id, status, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price, version, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 1, NOW(), NOW());
 
 -- name: UpdateOrder :execresult
 UPDATE oms.orders
-SET status = $2, version = $3, updated_at = NOW()
+SET status = $2, version = $3, gift_message = $5, order_discount = $6, fulfillment_type = $7, subtotal = $8, total_tax = $9, final_price = $10, updated_at = NOW()
 WHERE id = $1 AND version = $4;
 
+-- name: ArchiveOrder :execresult
+UPDATE oms.orders
+SET archived_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND archived_at IS NULL AND status = ANY($2::text[]);
+
+-- name: IsOrderArchived :one
+SELECT (archived_at IS NOT NULL)::bool AS archived FROM oms.orders WHERE id = $1;
+
 -- name: DeleteOrderItems :exec
 DELETE FROM oms.order_items
 WHERE order_id = $1;
 
 -- name: InsertOrderItem :exec
-INSERT INTO oms.order_items (order_id, good_id, quantity, price)
-VALUES ($1, $2, $3, $4);
+INSERT INTO oms.order_items (order_id, good_id, quantity, price, note, currency, tax_category)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
 
 -- name: GetOrderDeliveryInfo :one
 SELECT 
@@ -84,10 +114,26 @@ SELECT
     period_start, period_end,
     weight_kg,
     priority, package_id, delivery_status, requested_at,
-    recipient_name, recipient_phone, recipient_email
+    recipient_name, recipient_phone, recipient_email,
+    length_cm, width_cm, height_cm,
+    delivery_fee
 FROM oms.order_delivery_info
 WHERE order_id = $1;
 
+-- name: GetOrderDeliveryInfoByOrderIDs :many
+SELECT
+    order_id,
+    pickup_street, pickup_city, pickup_postal_code, pickup_country, pickup_latitude, pickup_longitude,
+    delivery_street, delivery_city, delivery_postal_code, delivery_country, delivery_latitude, delivery_longitude,
+    period_start, period_end,
+    weight_kg,
+    priority, package_id, delivery_status, requested_at,
+    recipient_name, recipient_phone, recipient_email,
+    length_cm, width_cm, height_cm,
+    delivery_fee
+FROM oms.order_delivery_info
+WHERE order_id = ANY(@order_ids::uuid[]);
+
 -- name: InsertOrderDeliveryInfo :exec
 INSERT INTO oms.order_delivery_info (
     order_id,
@@ -96,7 +142,9 @@ INSERT INTO oms.order_delivery_info (
     period_start, period_end,
     weight_kg,
     priority, package_id, delivery_status, requested_at,
-    recipient_name, recipient_phone, recipient_email
+    recipient_name, recipient_phone, recipient_email,
+    length_cm, width_cm, height_cm,
+    delivery_fee
 ) VALUES (
     $1,
     $2, $3, $4, $5, $6, $7,
@@ -104,7 +152,9 @@ INSERT INTO oms.order_delivery_info (
     $14, $15,
     $16,
     $17, $18, $19, $20,
-    $21, $22, $23
+    $21, $22, $23,
+    $24, $25, $26,
+    $27
 );
 
 -- name: UpdateOrderDeliveryInfo :exec
@@ -115,9 +165,55 @@ SET
     period_start = $14, period_end = $15,
     weight_kg = $16,
     priority = $17, package_id = $18, delivery_status = $19, requested_at = $20,
-    recipient_name = $21, recipient_phone = $22, recipient_email = $23
+    recipient_name = $21, recipient_phone = $22, recipient_email = $23,
+    length_cm = $24, width_cm = $25, height_cm = $26,
+    delivery_fee = $27
 WHERE order_id = $1;
 
 -- name: DeleteOrderDeliveryInfo :exec
 DELETE FROM oms.order_delivery_info
 WHERE order_id = $1;
+
+-- name: GetOrderPackageDeliveryStatuses :many
+SELECT package_id, delivery_status
+FROM oms.order_package_delivery_status
+WHERE order_id = $1;
+
+-- name: GetOrderPackageDeliveryStatusesByOrderIDs :many
+SELECT order_id, package_id, delivery_status
+FROM oms.order_package_delivery_status
+WHERE order_id = ANY(@order_ids::uuid[]);
+
+-- name: InsertOrderPackageDeliveryStatus :exec
+INSERT INTO oms.order_package_delivery_status (order_id, package_id, delivery_status)
+VALUES ($1, $2, $3);
+
+-- name: DeleteOrderPackageDeliveryStatuses :exec
+DELETE FROM oms.order_package_delivery_status
+WHERE order_id = $1;
+
+-- name: UpsertOrdersBatch :many
+-- Inserts new orders and updates existing ones in one statement. An existing row is only
+-- updated when its version matches the expected one; callers compare the returned versions.
+WITH input AS (
+    SELECT *
+    FROM unnest(@ids::uuid[], @customer_ids::uuid[], @statuses::text[], @gift_messages::text[], @order_discounts::text[], @fulfillment_types::text[], @subtotals::text[], @total_taxes::text[], @final_prices::text[], @expected_versions::int[])
+        AS t(id, customer_id, status, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price, expected_version)
+)
+INSERT INTO oms.orders (id, customer_id, status, gift_message, order_discount, fulfillment_type, subtotal, total_tax, final_price, version, created_at, updated_at)
+SELECT id, customer_id, status, NULLIF(gift_message, ''), order_discount::numeric, NULLIF(fulfillment_type, ''), subtotal::numeric, total_tax::numeric, final_price::numeric, 1, NOW(), NOW()
+FROM input
+ON CONFLICT (id) DO UPDATE
+SET status = EXCLUDED.status, gift_message = EXCLUDED.gift_message, order_discount = EXCLUDED.order_discount, fulfillment_type = EXCLUDED.fulfillment_type, subtotal = EXCLUDED.subtotal, total_tax = EXCLUDED.total_tax, final_price = EXCLUDED.final_price, version = oms.orders.version + 1, updated_at = NOW()
+WHERE oms.orders.version = (SELECT input.expected_version FROM input WHERE input.id = EXCLUDED.id)
+RETURNING id, version;
+
+-- name: DeleteOrderItemsBatch :exec
+DELETE FROM oms.order_items
+WHERE order_id = ANY(@order_ids::uuid[]);
+
+-- name: InsertOrderItemsBatch :exec
+INSERT INTO oms.order_items (order_id, good_id, quantity, price, note, currency, tax_category)
+SELECT order_id, good_id, quantity, price::numeric, NULLIF(note, ''), NULLIF(currency, ''), NULLIF(tax_category, '')
+FROM unnest(@order_ids::uuid[], @good_ids::uuid[], @quantities::int[], @prices::text[], @notes::text[], @currencies::text[], @tax_categories::text[])
+    AS t(order_id, good_id, quantity, price, note, currency, tax_category);
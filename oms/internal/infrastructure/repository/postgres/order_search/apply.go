@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order_search/schema/queries"
+)
+
+// ApplyOrder upserts the search document of an order.
+// It reports false when a document of the same or a newer aggregate version is already stored,
+// so replayed and out-of-order events never roll a document back.
+func (s *Store) ApplyOrder(ctx context.Context, document ports.OrderSearchDocument) (bool, error) {
+	applied, err := s.queriesFor(ctx).UpsertOrderSearch(ctx, queries.UpsertOrderSearchParams{
+		OrderID:          document.OrderID,
+		CustomerID:       document.CustomerID,
+		CustomerEmail:    document.CustomerEmail,
+		Status:           document.Status.String(),
+		GoodIds:          document.GoodIDs,
+		Total:            document.Total,
+		AggregateVersion: document.AggregateVersion,
+		CreatedAt:        pgtype.Timestamptz{Time: document.CreatedAt, Valid: true},
+		UpdatedAt:        pgtype.Timestamptz{Time: document.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return false, domain.WrapUnavailable("UpsertOrderSearch", err)
+	}
+
+	return applied > 0, nil
+}
+
+// RemoveOrder deletes the search document of an order; a missing document is not an error.
+func (s *Store) RemoveOrder(ctx context.Context, orderID uuid.UUID) error {
+	err := s.queriesFor(ctx).DeleteOrderSearch(ctx, orderID)
+	if err != nil {
+		return domain.WrapUnavailable("DeleteOrderSearch", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+-- OMS order search read model, projected from order events
+CREATE SCHEMA IF NOT EXISTS oms;
+
+CREATE TABLE IF NOT EXISTS oms.order_search (
+    order_id          UUID PRIMARY KEY,
+    customer_id       UUID NOT NULL,
+    customer_email    TEXT NOT NULL DEFAULT '',
+    status            VARCHAR(32) NOT NULL,
+    good_ids          UUID[] NOT NULL DEFAULT '{}',
+    total             DECIMAL(12,2) NOT NULL DEFAULT 0,
+    aggregate_version INT NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL
+);
+
+COMMENT ON TABLE oms.order_search IS 'Denormalized order search read model; rebuilt from order events, never written by the order write model';
+COMMENT ON COLUMN oms.order_search.customer_email IS 'Recipient email from the delivery info; empty when unknown';
+COMMENT ON COLUMN oms.order_search.good_ids IS 'Goods of the order items';
+COMMENT ON COLUMN oms.order_search.total IS 'Sum of item price times quantity';
+COMMENT ON COLUMN oms.order_search.aggregate_version IS 'Order version the row was built from; older versions never replace newer ones';
+
+CREATE INDEX IF NOT EXISTS order_search_customer_email_idx ON oms.order_search(lower(customer_email));
+CREATE INDEX IF NOT EXISTS order_search_good_ids_idx ON oms.order_search USING GIN (good_ids);
+CREATE INDEX IF NOT EXISTS order_search_status_created_at_idx ON oms.order_search(status, created_at DESC);
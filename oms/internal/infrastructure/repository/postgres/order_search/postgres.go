@@ -0,0 +1,52 @@
+//go:generate sqlc generate -f ./schema/sqlc.yaml
+
+package postgres
+
+import (
+	"context"
+	"embed"
+
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shortlink-org/go-sdk/db"
+	"github.com/shortlink-org/go-sdk/db/drivers/postgres/migrate"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order_search/schema/queries"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Store implements OrderSearchRepository on the oms.order_search read model table.
+// It keeps its own migrations so the order write model schema stays untouched.
+type Store struct {
+	query *queries.Queries
+}
+
+// New creates a new PostgreSQL order search repository.
+func New(ctx context.Context, store db.DB) (*Store, error) {
+	client, ok := store.GetConn().(*pgxpool.Pool)
+	if !ok {
+		return nil, db.ErrGetConnection
+	}
+
+	err := migrate.Migration(ctx, store, migrations, "repository_order_search")
+	if err != nil {
+		return nil, domain.WrapUnavailable("migrate repository_order_search", err)
+	}
+
+	return &Store{
+		query: queries.New(client),
+	}, nil
+}
+
+// queriesFor runs on the transaction in ctx when there is one; the read model does not require it.
+func (s *Store) queriesFor(ctx context.Context) *queries.Queries {
+	if pgxTx := uow.FromContext(ctx); pgxTx != nil {
+		return s.query.WithTx(pgxTx)
+	}
+
+	return s.query
+}
@@ -0,0 +1,204 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	order "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	searchrepo "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order_search"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/testhelpers"
+)
+
+func setupOrderSearchTest(t *testing.T) *searchrepo.Store {
+	t.Helper()
+
+	pc := testhelpers.SetupPostgresContainer(t)
+
+	store, err := searchrepo.New(context.Background(), pc.DB())
+	require.NoError(t, err, "failed to create order search repository")
+
+	return store
+}
+
+func newDocument(email string, status order.OrderStatus, total int64, createdAt time.Time, goods ...uuid.UUID) ports.OrderSearchDocument {
+	return ports.OrderSearchDocument{
+		OrderID:          uuid.New(),
+		CustomerID:       uuid.New(),
+		CustomerEmail:    email,
+		Status:           status,
+		GoodIDs:          goods,
+		Total:            decimal.NewFromInt(total),
+		AggregateVersion: 1,
+		CreatedAt:        createdAt,
+		UpdatedAt:        createdAt,
+	}
+}
+
+func orderIDs(page *ports.OrderSearchPage) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(page.Orders))
+	for _, document := range page.Orders {
+		ids = append(ids, document.OrderID)
+	}
+
+	return ids
+}
+
+func TestOrderSearch_ApplyOrderKeepsNewestVersion(t *testing.T) {
+	store := setupOrderSearchTest(t)
+	ctx := context.Background()
+
+	createdAt := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+	document := newDocument("jane@example.com", order.OrderStatus_ORDER_STATUS_PENDING, 25, createdAt)
+
+	applied, err := store.ApplyOrder(ctx, document)
+	require.NoError(t, err)
+	assert.True(t, applied)
+
+	completed := document
+	completed.Status = order.OrderStatus_ORDER_STATUS_COMPLETED
+	completed.AggregateVersion = 2
+	completed.CreatedAt = createdAt.Add(time.Hour) // not the creation time: the first insert wins
+	completed.UpdatedAt = createdAt.Add(time.Hour)
+
+	applied, err = store.ApplyOrder(ctx, completed)
+	require.NoError(t, err)
+	assert.True(t, applied)
+
+	// A replayed older version does not roll the document back
+	applied, err = store.ApplyOrder(ctx, document)
+	require.NoError(t, err)
+	assert.False(t, applied)
+
+	page, err := store.Search(ctx, ports.OrderSearchFilter{Text: "jane", PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 1)
+
+	got := page.Orders[0]
+	assert.Equal(t, order.OrderStatus_ORDER_STATUS_COMPLETED, got.Status)
+	assert.Equal(t, int32(2), got.AggregateVersion)
+	assert.True(t, got.CreatedAt.Equal(createdAt))
+	assert.True(t, got.UpdatedAt.Equal(createdAt.Add(time.Hour)))
+}
+
+func TestOrderSearch_RemoveOrder(t *testing.T) {
+	store := setupOrderSearchTest(t)
+	ctx := context.Background()
+
+	document := newDocument("jane@example.com", order.OrderStatus_ORDER_STATUS_COMPLETED, 25, time.Now().UTC())
+
+	_, err := store.ApplyOrder(ctx, document)
+	require.NoError(t, err)
+
+	require.NoError(t, store.RemoveOrder(ctx, document.OrderID))
+
+	page, err := store.Search(ctx, ports.OrderSearchFilter{Text: "jane", PageSize: 10})
+	require.NoError(t, err)
+	assert.Empty(t, page.Orders)
+
+	// Removing again is a no-op
+	require.NoError(t, store.RemoveOrder(ctx, document.OrderID))
+}
+
+func TestOrderSearch_Search(t *testing.T) {
+	store := setupOrderSearchTest(t)
+	ctx := context.Background()
+
+	base := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+	coffee, tea := uuid.New(), uuid.New()
+
+	janeOld := newDocument("jane@example.com", order.OrderStatus_ORDER_STATUS_COMPLETED, 30, base, coffee)
+	janeNew := newDocument("Jane@Example.com", order.OrderStatus_ORDER_STATUS_PENDING, 10, base.Add(time.Hour), tea)
+	john := newDocument("john_doe@example.org", order.OrderStatus_ORDER_STATUS_COMPLETED, 50, base.Add(2*time.Hour), coffee, tea)
+
+	for _, document := range []ports.OrderSearchDocument{janeOld, janeNew, john} {
+		_, err := store.ApplyOrder(ctx, document)
+		require.NoError(t, err)
+	}
+
+	testCases := []struct {
+		name   string
+		filter ports.OrderSearchFilter
+		want   []uuid.UUID
+	}{
+		{
+			name: "no predicates lists newest first",
+			want: []uuid.UUID{john.OrderID, janeNew.OrderID, janeOld.OrderID},
+		},
+		{
+			name:   "text matches email case-insensitively",
+			filter: ports.OrderSearchFilter{Text: "JANE@"},
+			want:   []uuid.UUID{janeNew.OrderID, janeOld.OrderID},
+		},
+		{
+			name:   "text matches order id prefix",
+			filter: ports.OrderSearchFilter{Text: john.OrderID.String()[:8]},
+			want:   []uuid.UUID{john.OrderID},
+		},
+		{
+			name:   "text wildcards match literally",
+			filter: ports.OrderSearchFilter{Text: "_doe"},
+			want:   []uuid.UUID{john.OrderID},
+		},
+		{
+			name:   "exact email",
+			filter: ports.OrderSearchFilter{CustomerEmail: "JANE@example.com"},
+			want:   []uuid.UUID{janeNew.OrderID, janeOld.OrderID},
+		},
+		{
+			name:   "good and status",
+			filter: ports.OrderSearchFilter{GoodID: &coffee, StatusFilter: []order.OrderStatus{order.OrderStatus_ORDER_STATUS_COMPLETED}},
+			want:   []uuid.UUID{john.OrderID, janeOld.OrderID},
+		},
+		{
+			name:   "sort by total ascending",
+			filter: ports.OrderSearchFilter{Sort: ports.OrderSearchSortTotalAsc},
+			want:   []uuid.UUID{janeNew.OrderID, janeOld.OrderID, john.OrderID},
+		},
+		{
+			name:   "sort by creation ascending",
+			filter: ports.OrderSearchFilter{Sort: ports.OrderSearchSortCreatedAsc},
+			want:   []uuid.UUID{janeOld.OrderID, janeNew.OrderID, john.OrderID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.filter.PageSize = 10
+
+			page, err := store.Search(ctx, tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, orderIDs(page))
+			assert.Empty(t, page.NextPageToken)
+		})
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		filter := ports.OrderSearchFilter{Sort: ports.OrderSearchSortTotalDesc, PageSize: 2}
+
+		first, err := store.Search(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{john.OrderID, janeOld.OrderID}, orderIDs(first))
+		require.NotEmpty(t, first.NextPageToken)
+
+		filter.PageToken = first.NextPageToken
+		second, err := store.Search(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{janeNew.OrderID}, orderIDs(second))
+		assert.Empty(t, second.NextPageToken)
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		_, err := store.Search(ctx, ports.OrderSearchFilter{PageSize: 2, PageToken: "!!"})
+		require.ErrorIs(t, err, domain.ErrValidation)
+	})
+}
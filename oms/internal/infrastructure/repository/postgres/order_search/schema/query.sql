@@ -0,0 +1,33 @@
+-- name: UpsertOrderSearch :execrows
+-- Replaces the document of an order unless one of the same or a newer version is stored.
+-- created_at is kept from the first insert.
+INSERT INTO oms.order_search (order_id, customer_id, customer_email, status, good_ids, total, aggregate_version, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (order_id) DO UPDATE
+SET customer_id       = EXCLUDED.customer_id,
+    customer_email    = EXCLUDED.customer_email,
+    status            = EXCLUDED.status,
+    good_ids          = EXCLUDED.good_ids,
+    total             = EXCLUDED.total,
+    aggregate_version = EXCLUDED.aggregate_version,
+    updated_at        = EXCLUDED.updated_at
+WHERE oms.order_search.aggregate_version < EXCLUDED.aggregate_version;
+
+-- name: DeleteOrderSearch :exec
+DELETE FROM oms.order_search
+WHERE order_id = $1;
+
+-- name: SearchOrders :many
+SELECT order_id, customer_id, customer_email, status, good_ids, total, aggregate_version, created_at, updated_at
+FROM oms.order_search
+WHERE (@text_pattern::text = '' OR customer_email ILIKE @text_pattern::text OR order_id::text ILIKE @order_id_prefix::text)
+  AND (@customer_email::text = '' OR lower(customer_email) = lower(@customer_email::text))
+  AND (sqlc.narg('good_id')::uuid IS NULL OR sqlc.narg('good_id')::uuid = ANY(good_ids))
+  AND (cardinality(@statuses::text[]) = 0 OR status = ANY(@statuses::text[]))
+ORDER BY
+  CASE WHEN @sort::text = 'CREATED_AT_ASC' THEN created_at END ASC,
+  CASE WHEN @sort::text = 'TOTAL_DESC' THEN total END DESC,
+  CASE WHEN @sort::text = 'TOTAL_ASC' THEN total END ASC,
+  created_at DESC,
+  order_id DESC
+LIMIT @page_limit OFFSET @page_offset;
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	order "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order_search/schema/queries"
+)
+
+// errInvalidPageToken is returned when the page token cannot be decoded.
+var errInvalidPageToken = errors.New("invalid page token")
+
+// likeEscaper escapes the ILIKE wildcards of user input so they match literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Search returns a page of order documents matching the filter.
+// Results can be sorted by several columns, so pages are addressed by offset; the token is opaque to callers.
+func (s *Store) Search(ctx context.Context, filter ports.OrderSearchFilter) (*ports.OrderSearchPage, error) {
+	sort := filter.Sort
+	if sort == "" {
+		sort = ports.OrderSearchSortCreatedDesc
+	}
+
+	offset, err := decodePageToken(filter.PageToken)
+	if err != nil {
+		return nil, domain.WrapValidation("SearchOrders", err)
+	}
+
+	params := queries.SearchOrdersParams{
+		CustomerEmail: strings.TrimSpace(filter.CustomerEmail),
+		Statuses:      statusesToStrings(filter.StatusFilter),
+		Sort:          string(sort),
+		// Fetch one extra row to know whether a next page exists
+		PageLimit:  filter.PageSize + 1,
+		PageOffset: offset,
+	}
+
+	if text := strings.TrimSpace(filter.Text); text != "" {
+		escaped := likeEscaper.Replace(text)
+		params.TextPattern = "%" + escaped + "%"
+		params.OrderIDPrefix = escaped + "%"
+	}
+
+	if filter.GoodID != nil {
+		params.GoodID = pgtype.UUID{Bytes: *filter.GoodID, Valid: true}
+	}
+
+	rows, err := s.queriesFor(ctx).SearchOrders(ctx, params)
+	if err != nil {
+		return nil, domain.WrapUnavailable("SearchOrders", err)
+	}
+
+	page := &ports.OrderSearchPage{}
+
+	if len(rows) > int(filter.PageSize) {
+		rows = rows[:filter.PageSize]
+		page.NextPageToken = encodePageToken(offset + filter.PageSize)
+	}
+
+	page.Orders = make([]ports.OrderSearchDocument, 0, len(rows))
+	for _, row := range rows {
+		page.Orders = append(page.Orders, ports.OrderSearchDocument{
+			OrderID:          row.OrderID,
+			CustomerID:       row.CustomerID,
+			CustomerEmail:    row.CustomerEmail,
+			Status:           order.OrderStatus(order.OrderStatus_value[row.Status]),
+			GoodIDs:          row.GoodIds,
+			Total:            row.Total,
+			AggregateVersion: row.AggregateVersion,
+			CreatedAt:        row.CreatedAt.Time,
+			UpdatedAt:        row.UpdatedAt.Time,
+		})
+	}
+
+	return page, nil
+}
+
+// statusesToStrings converts OrderStatus slice to the string form stored in oms.order_search.status.
+func statusesToStrings(statuses []order.OrderStatus) []string {
+	result := make([]string, len(statuses))
+	for i, s := range statuses {
+		result[i] = s.String()
+	}
+
+	return result
+}
+
+// encodePageToken encodes the offset of the next page as base64url.
+func encodePageToken(offset int32) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(int64(offset), 10)))
+}
+
+// decodePageToken decodes a token produced by encodePageToken; an empty token is the first page.
+func decodePageToken(token string) (int32, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errInvalidPageToken
+	}
+
+	offset, err := strconv.ParseInt(string(raw), 10, 32)
+	if err != nil || offset < 0 {
+		return 0, errInvalidPageToken
+	}
+
+	return int32(offset), nil
+}
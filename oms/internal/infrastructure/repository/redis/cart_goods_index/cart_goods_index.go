@@ -59,6 +59,38 @@ func (s *Store) AddGoodToCart(ctx context.Context, goodID, customerID uuid.UUID)
 	return nil
 }
 
+// AddGoodsToCart adds several goods to a customer's cart in the index.
+// All SADD commands (both directions of the index) are pipelined in a single
+// round-trip; SADD is idempotent, so re-adding an indexed good is a no-op.
+func (s *Store) AddGoodsToCart(ctx context.Context, goodIDs []uuid.UUID, customerID uuid.UUID) error {
+	if len(goodIDs) == 0 {
+		return nil
+	}
+
+	customer := customerID.String()
+	goods := make([]string, 0, len(goodIDs))
+	cmds := make([]rueidis.Completed, 0, len(goodIDs)+1)
+
+	for _, goodID := range goodIDs {
+		goods = append(goods, goodID.String())
+		// Add customer to good's customer set
+		cmds = append(cmds, s.client.B().Sadd().Key(goodCustomersKey(goodID)).Member(customer).Build())
+	}
+
+	// Add all goods to customer's goods set (reverse index)
+	cmds = append(cmds, s.client.B().Sadd().Key(customerGoodsKey(customerID)).Member(goods...).Build())
+
+	// Execute all commands in one pipeline
+	for _, resp := range s.client.DoMulti(ctx, cmds...) {
+		err := resp.Error()
+		if err != nil {
+			return fmt.Errorf("failed to add goods to cart index: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // RemoveGoodFromCart removes a good from a customer's cart in the index.
 func (s *Store) RemoveGoodFromCart(ctx context.Context, goodID, customerID uuid.UUID) error {
 	// Remove customer from good's customer set
@@ -74,6 +106,12 @@ func (s *Store) RemoveGoodFromCart(ctx context.Context, goodID, customerID uuid.
 		}
 	}
 
+	// Release the units the customer held from the good's demand
+	err := s.releaseAllDemand(ctx, goodID, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to remove good from cart index: %w", err)
+	}
+
 	return nil
 }
 
@@ -106,26 +144,31 @@ func (s *Store) GetCustomersWithGood(ctx context.Context, goodID uuid.UUID) ([]u
 }
 
 // ClearCart removes all goods for a customer from the index.
-// This uses the reverse index to find all goods and remove the customer from each.
+// This uses the reverse indexes to find all goods, remove the customer from each
+// and release the units the customer held from each good's demand.
 func (s *Store) ClearCart(ctx context.Context, customerID uuid.UUID) error {
 	// Get all goods in customer's cart
 	goods, err := s.client.Do(ctx,
 		s.client.B().Smembers().Key(customerGoodsKey(customerID)).Build(),
 	).AsStrSlice()
-	if err != nil {
-		if rueidis.IsRedisNil(err) {
-			return nil // No goods to clear
-		}
-
+	if err != nil && !rueidis.IsRedisNil(err) {
 		return fmt.Errorf("failed to get customer goods: %w", err)
 	}
 
-	if len(goods) == 0 {
-		return nil
+	// Get the units per good held by the customer
+	units, err := s.client.Do(ctx,
+		s.client.B().Hgetall().Key(customerUnitsKey(customerID)).Build(),
+	).AsIntMap()
+	if err != nil && !rueidis.IsRedisNil(err) {
+		return fmt.Errorf("failed to get customer good units: %w", err)
+	}
+
+	if len(goods) == 0 && len(units) == 0 {
+		return nil // No goods to clear
 	}
 
 	// Build commands to remove customer from each good's customer set
-	cmds := make([]rueidis.Completed, 0, len(goods)+1)
+	cmds := make([]rueidis.Completed, 0, len(goods)+len(units)+2)
 	for _, goodIDStr := range goods {
 		goodID, err := uuid.Parse(goodIDStr)
 		if err != nil {
@@ -135,8 +178,21 @@ func (s *Store) ClearCart(ctx context.Context, customerID uuid.UUID) error {
 		cmds = append(cmds, s.client.B().Srem().Key(goodCustomersKey(goodID)).Member(customerID.String()).Build())
 	}
 
-	// Delete the customer's goods set
-	cmds = append(cmds, s.client.B().Del().Key(customerGoodsKey(customerID)).Build())
+	// Build commands to release the customer's units from each good's demand
+	for goodIDStr, quantity := range units {
+		goodID, err := uuid.Parse(goodIDStr)
+		if err != nil {
+			continue
+		}
+
+		cmds = append(cmds, s.client.B().Decrby().Key(goodDemandKey(goodID)).Decrement(quantity).Build())
+	}
+
+	// Delete the customer's reverse indexes
+	cmds = append(cmds,
+		s.client.B().Del().Key(customerGoodsKey(customerID)).Build(),
+		s.client.B().Del().Key(customerUnitsKey(customerID)).Build(),
+	)
 
 	// Execute all commands
 	for _, resp := range s.client.DoMulti(ctx, cmds...) {
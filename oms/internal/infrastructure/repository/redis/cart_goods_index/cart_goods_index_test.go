@@ -0,0 +1,202 @@
+package cart_goods_index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreAddGoodsToCart(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	customerID := uuid.New()
+	otherCustomerID := uuid.New()
+
+	goodIDs := make([]uuid.UUID, 30)
+	for i := range goodIDs {
+		goodIDs[i] = uuid.New()
+	}
+
+	require.NoError(t, store.AddGoodsToCart(ctx, goodIDs, customerID))
+	require.NoError(t, store.AddGoodsToCart(ctx, goodIDs[:5], otherCustomerID))
+
+	for i, goodID := range goodIDs {
+		customers, err := store.GetCustomersWithGood(ctx, goodID)
+		require.NoError(t, err)
+
+		if i < 5 {
+			require.ElementsMatch(t, []uuid.UUID{customerID, otherCustomerID}, customers)
+		} else {
+			require.Equal(t, []uuid.UUID{customerID}, customers)
+		}
+	}
+
+	// Reverse index must allow ClearCart to drop every good of the customer.
+	require.NoError(t, store.ClearCart(ctx, customerID))
+
+	for _, goodID := range goodIDs[5:] {
+		customers, err := store.GetCustomersWithGood(ctx, goodID)
+		require.NoError(t, err)
+		require.Empty(t, customers)
+	}
+}
+
+func TestStoreAddGoodsToCartIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	customerID := uuid.New()
+	goodIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	require.NoError(t, store.AddGoodToCart(ctx, goodIDs[0], customerID))
+	require.NoError(t, store.AddGoodsToCart(ctx, goodIDs, customerID))
+	require.NoError(t, store.AddGoodsToCart(ctx, goodIDs, customerID))
+	require.NoError(t, store.AddGoodsToCart(ctx, nil, customerID))
+
+	for _, goodID := range goodIDs {
+		customers, err := store.GetCustomersWithGood(ctx, goodID)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{customerID}, customers)
+	}
+}
+
+func TestStoreGoodDemandAcrossCustomers(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	goodID, otherGoodID := uuid.New(), uuid.New()
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+
+	requireDemand := func(t *testing.T, goodID uuid.UUID, want int64) {
+		t.Helper()
+
+		demand, err := store.GetGoodDemand(ctx, goodID)
+		require.NoError(t, err)
+		require.Equal(t, want, demand)
+	}
+
+	// Unknown good has no demand
+	requireDemand(t, goodID, 0)
+
+	require.NoError(t, store.IncrementGoodDemand(ctx, goodID, alice, 2))
+	require.NoError(t, store.IncrementGoodDemand(ctx, goodID, bob, 3))
+	require.NoError(t, store.IncrementGoodDemand(ctx, goodID, alice, 1))
+	require.NoError(t, store.IncrementGoodDemand(ctx, otherGoodID, alice, 4))
+	require.NoError(t, store.IncrementGoodDemand(ctx, goodID, carol, 5))
+	requireDemand(t, goodID, 11)
+	requireDemand(t, otherGoodID, 4)
+
+	customers, err := store.GetCustomersWithGood(ctx, goodID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{alice, bob, carol}, customers)
+
+	// Partial decrement keeps bob in the index
+	require.NoError(t, store.DecrementGoodDemand(ctx, goodID, bob, 1))
+	requireDemand(t, goodID, 10)
+
+	// Decrement past the held units is capped and drops bob from the index
+	require.NoError(t, store.DecrementGoodDemand(ctx, goodID, bob, 10))
+	requireDemand(t, goodID, 8)
+
+	customers, err = store.GetCustomersWithGood(ctx, goodID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{alice, carol}, customers)
+
+	// Removing the good releases everything carol held
+	require.NoError(t, store.RemoveGoodFromCart(ctx, goodID, carol))
+	requireDemand(t, goodID, 3)
+
+	// Clearing the cart releases alice's units of every good
+	require.NoError(t, store.ClearCart(ctx, alice))
+	requireDemand(t, goodID, 0)
+	requireDemand(t, otherGoodID, 0)
+
+	customers, err = store.GetCustomersWithGood(ctx, goodID)
+	require.NoError(t, err)
+	require.Empty(t, customers)
+}
+
+func TestStoreGoodDemandRejectsNonPositiveQuantity(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	goodID, customerID := uuid.New(), uuid.New()
+
+	require.ErrorIs(t, store.IncrementGoodDemand(ctx, goodID, customerID, 0), errNonPositiveQuantity)
+	require.ErrorIs(t, store.DecrementGoodDemand(ctx, goodID, customerID, -1), errNonPositiveQuantity)
+}
+
+func BenchmarkStoreAddGoodToCartLoop(b *testing.B) {
+	store, cleanup := newTestStore(b)
+	defer cleanup()
+
+	ctx := context.Background()
+	goodIDs := benchmarkGoodIDs(30)
+
+	for b.Loop() {
+		customerID := uuid.New()
+		for _, goodID := range goodIDs {
+			if err := store.AddGoodToCart(ctx, goodID, customerID); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkStoreAddGoodsToCart(b *testing.B) {
+	store, cleanup := newTestStore(b)
+	defer cleanup()
+
+	ctx := context.Background()
+	goodIDs := benchmarkGoodIDs(30)
+
+	for b.Loop() {
+		if err := store.AddGoodsToCart(ctx, goodIDs, uuid.New()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkGoodIDs(n int) []uuid.UUID {
+	goodIDs := make([]uuid.UUID, n)
+	for i := range goodIDs {
+		goodIDs[i] = uuid.New()
+	}
+
+	return goodIDs
+}
+
+func newTestStore(tb testing.TB) (*Store, func()) {
+	tb.Helper()
+
+	mr := miniredis.RunT(tb)
+	client, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{mr.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(tb, err)
+
+	cleanup := func() {
+		client.Close()
+		mr.Close()
+	}
+
+	return New(client), cleanup
+}
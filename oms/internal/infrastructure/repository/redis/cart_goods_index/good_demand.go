@@ -0,0 +1,140 @@
+package cart_goods_index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/redis/rueidis"
+)
+
+// errNonPositiveQuantity is returned when a demand change is not a positive number of units.
+var errNonPositiveQuantity = errors.New("quantity must be positive")
+
+// releaseUnitsScript releases up to ARGV[2] units of good ARGV[1] from a customer's units hash (KEYS[1]).
+// The release is capped at the units the customer holds, so the demand counter never drifts
+// below the sum of all carts. Returns {released, remaining}.
+// The demand counter lives in another hash slot, so the caller applies the released units to it.
+var releaseUnitsScript = rueidis.NewLuaScriptNoShaRetryable(`
+local held = tonumber(redis.call('HGET', KEYS[1], ARGV[1]) or '0')
+local released = math.min(held, tonumber(ARGV[2]))
+if released <= 0 then
+  return {0, held}
+end
+
+local remaining = held - released
+if remaining == 0 then
+  redis.call('HDEL', KEYS[1], ARGV[1])
+else
+  redis.call('HSET', KEYS[1], ARGV[1], remaining)
+end
+
+return {released, remaining}
+`)
+
+// goodDemandKey returns the key for the total units of a good across all active carts.
+// Pattern: oms:cart:good:{good_id}:demand
+func goodDemandKey(goodID uuid.UUID) string {
+	return fmt.Sprintf("%s:%s:demand", keyPrefix, goodID.String())
+}
+
+// customerUnitsKey returns the key for the units per good in a customer's cart.
+// Pattern: oms:cart:customer:{customer_id}:units
+// This reverse index lets RemoveGoodFromCart and ClearCart release exactly what the customer held.
+func customerUnitsKey(customerID uuid.UUID) string {
+	return fmt.Sprintf("%s:%s:units", customerGoodsPrefix, customerID.String())
+}
+
+// IncrementGoodDemand records quantity more units of a good in a customer's cart.
+// The good is also added to the cart index, so the demand never counts a cart the index misses.
+func (s *Store) IncrementGoodDemand(ctx context.Context, goodID, customerID uuid.UUID, quantity int64) error {
+	if quantity <= 0 {
+		return fmt.Errorf("failed to increment good demand: %w", errNonPositiveQuantity)
+	}
+
+	good, customer := goodID.String(), customerID.String()
+
+	cmds := rueidis.Commands{
+		s.client.B().Sadd().Key(goodCustomersKey(goodID)).Member(customer).Build(),
+		s.client.B().Sadd().Key(customerGoodsKey(customerID)).Member(good).Build(),
+		s.client.B().Hincrby().Key(customerUnitsKey(customerID)).Field(good).Increment(quantity).Build(),
+		s.client.B().Incrby().Key(goodDemandKey(goodID)).Increment(quantity).Build(),
+	}
+
+	for _, resp := range s.client.DoMulti(ctx, cmds...) {
+		err := resp.Error()
+		if err != nil {
+			return fmt.Errorf("failed to increment good demand: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DecrementGoodDemand releases quantity units of a good from a customer's cart.
+// Releasing more than the customer holds is capped; once no units remain the good
+// is removed from the cart index as well.
+func (s *Store) DecrementGoodDemand(ctx context.Context, goodID, customerID uuid.UUID, quantity int64) error {
+	if quantity <= 0 {
+		return fmt.Errorf("failed to decrement good demand: %w", errNonPositiveQuantity)
+	}
+
+	remaining, err := s.releaseDemand(ctx, goodID, customerID, quantity)
+	if err != nil {
+		return fmt.Errorf("failed to decrement good demand: %w", err)
+	}
+
+	if remaining > 0 {
+		return nil
+	}
+
+	return s.RemoveGoodFromCart(ctx, goodID, customerID)
+}
+
+// GetGoodDemand returns the total units of a good across all active carts.
+func (s *Store) GetGoodDemand(ctx context.Context, goodID uuid.UUID) (int64, error) {
+	demand, err := s.client.Do(ctx, s.client.B().Get().Key(goodDemandKey(goodID)).Build()).AsInt64()
+	if err != nil {
+		// Missing counter means no cart holds the good
+		if rueidis.IsRedisNil(err) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("failed to get good demand: %w", err)
+	}
+
+	return demand, nil
+}
+
+// releaseDemand releases up to quantity units and returns the units the customer still holds.
+func (s *Store) releaseDemand(ctx context.Context, goodID, customerID uuid.UUID, quantity int64) (int64, error) {
+	keys := []string{customerUnitsKey(customerID)}
+	args := []string{goodID.String(), strconv.FormatInt(quantity, 10)}
+
+	result, err := releaseUnitsScript.Exec(ctx, s.client, keys, args).AsIntSlice()
+	if err != nil {
+		return 0, err
+	}
+
+	released, remaining := result[0], result[1]
+	if released == 0 {
+		return remaining, nil
+	}
+
+	err = s.client.Do(ctx, s.client.B().Decrby().Key(goodDemandKey(goodID)).Decrement(released).Build()).Error()
+	if err != nil {
+		return 0, err
+	}
+
+	return remaining, nil
+}
+
+// releaseAllDemand releases every unit of a good the customer holds.
+func (s *Store) releaseAllDemand(ctx context.Context, goodID, customerID uuid.UUID) error {
+	_, err := s.releaseDemand(ctx, goodID, customerID, math.MaxInt64)
+
+	return err
+}
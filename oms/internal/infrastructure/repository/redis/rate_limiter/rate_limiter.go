@@ -0,0 +1,108 @@
+package rate_limiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/rueidis"
+
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+// keyPrefix is the prefix for all rate limiter buckets
+const keyPrefix = "oms:ratelimit"
+
+// ErrInvalidConfig is returned by New for a non-positive burst or interval.
+var ErrInvalidConfig = errors.New("rate limiter: burst and interval must be positive")
+
+// takeTokenScript refills the bucket for the time elapsed since the last call, then takes one token.
+// Returns {allowed, retry_after_ms}. The bucket expires once it would be full again.
+var takeTokenScript = rueidis.NewLuaScriptNoShaRetryable(`
+local burst = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) / interval)
+
+local allowed = 0
+local retry_after = 0
+
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  retry_after = math.ceil((1 - tokens) * interval)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) * interval) + 1)
+
+return {allowed, retry_after}
+`)
+
+// Config configures the token bucket.
+type Config struct {
+	// Name namespaces the buckets, e.g. "checkout"
+	Name string
+	// Burst is the bucket capacity: how many actions a key may take back to back
+	Burst int
+	// Interval is how long it takes to refill one token
+	Interval time.Duration
+}
+
+// Store implements ports.RateLimiter using a token bucket per key in Redis.
+type Store struct {
+	client rueidis.Client
+	cfg    Config
+	now    func() time.Time
+}
+
+// New creates a new Redis RateLimiter.
+func New(client rueidis.Client, cfg Config) (*Store, error) {
+	if cfg.Burst <= 0 || cfg.Interval <= 0 {
+		return nil, fmt.Errorf("%w: burst %d, interval %s", ErrInvalidConfig, cfg.Burst, cfg.Interval)
+	}
+
+	return &Store{client: client, cfg: cfg, now: time.Now}, nil
+}
+
+// bucketKey returns the key of key's bucket.
+// Pattern: oms:ratelimit:{name}:{key}
+func (s *Store) bucketKey(key string) string {
+	return fmt.Sprintf("%s:%s:%s", keyPrefix, s.cfg.Name, key)
+}
+
+// Allow takes one token from key's bucket.
+func (s *Store) Allow(ctx context.Context, key string) (ports.RateLimitDecision, error) {
+	args := []string{
+		strconv.Itoa(s.cfg.Burst),
+		strconv.FormatInt(s.cfg.Interval.Milliseconds(), 10),
+		strconv.FormatInt(s.now().UnixMilli(), 10),
+	}
+
+	result, err := takeTokenScript.Exec(ctx, s.client, []string{s.bucketKey(key)}, args).AsIntSlice()
+	if err != nil {
+		return ports.RateLimitDecision{}, fmt.Errorf("take rate limit token: %w", err)
+	}
+
+	if len(result) != 2 {
+		return ports.RateLimitDecision{}, fmt.Errorf("take rate limit token: unexpected reply %v", result)
+	}
+
+	return ports.RateLimitDecision{
+		Allowed:    result[0] == 1,
+		RetryAfter: time.Duration(result[1]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package rate_limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreAllow_TokenBucket(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newTestStore(t, Config{Name: "checkout", Burst: 2, Interval: 10 * time.Second})
+	defer cleanup()
+
+	now := time.Date(2026, time.March, 11, 10, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	// The burst is available immediately.
+	for range 2 {
+		decision, err := store.Allow(ctx, "customer-a")
+		require.NoError(t, err)
+		require.True(t, decision.Allowed)
+	}
+
+	decision, err := store.Allow(ctx, "customer-a")
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+	require.Equal(t, 10*time.Second, decision.RetryAfter)
+
+	// Buckets are per key.
+	decision, err = store.Allow(ctx, "customer-b")
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+
+	// Half an interval later the token is still not back.
+	now = now.Add(5 * time.Second)
+
+	decision, err = store.Allow(ctx, "customer-a")
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+	require.Equal(t, 5*time.Second, decision.RetryAfter)
+
+	// A full interval refills exactly one token.
+	now = now.Add(5 * time.Second)
+
+	decision, err = store.Allow(ctx, "customer-a")
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+
+	decision, err = store.Allow(ctx, "customer-a")
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(nil, Config{Name: "checkout", Burst: 0, Interval: time.Second})
+	require.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = New(nil, Config{Name: "checkout", Burst: 1})
+	require.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func newTestStore(t *testing.T, cfg Config) (*Store, func()) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{mr.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+
+	cleanup := func() {
+		client.Close()
+		mr.Close()
+	}
+
+	store, err := New(client, cfg)
+	require.NoError(t, err)
+
+	return store, cleanup
+}
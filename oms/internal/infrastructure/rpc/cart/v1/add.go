@@ -19,7 +19,7 @@ func (c *CartRPC) Add(ctx context.Context, in *v1.AddRequest) (*emptypb.Empty, e
 		return nil, grpcerr.ToStatus(ctx, c.log, "Cart.Add", domain.WrapValidation("AddRequestToDomain", err))
 	}
 
-	cmd := add_items.NewCommand(params.CustomerID, params.Items)
+	cmd := add_items.NewCommand(params.CustomerID, params.Items).WithGiftMessage(params.GiftMessage)
 	if err := c.addItemsHandler.Handle(ctx, cmd); err != nil {
 		return nil, grpcerr.ToStatus(ctx, c.log, "Cart.Add", err)
 	}
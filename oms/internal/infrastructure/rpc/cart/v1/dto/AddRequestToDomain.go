@@ -7,17 +7,20 @@ import (
 	"github.com/google/uuid"
 
 	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/pricing"
 	v2 "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/cart/v1/model/v1"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/rpcmeta"
 )
 
 // AddRequestParams holds parsed parameters from AddRequest
 type AddRequestParams struct {
-	CustomerID uuid.UUID
-	Items      []itemv1.Item
+	CustomerID  uuid.UUID
+	Items       []itemv1.Item
+	GiftMessage string
 }
 
-// AddRequestToDomain converts an AddRequest to domain parameters
+// AddRequestToDomain converts an AddRequest to domain parameters.
+// A good listed more than once is rejected with ErrDuplicateGoodInRequest.
 func AddRequestToDomain(ctx context.Context, r *v2.AddRequest) (*AddRequestParams, error) {
 	customerID, err := rpcmeta.CustomerIDFromContext(ctx)
 	if err != nil {
@@ -25,6 +28,7 @@ func AddRequestToDomain(ctx context.Context, r *v2.AddRequest) (*AddRequestParam
 	}
 
 	items := make([]itemv1.Item, 0, len(r.GetItems()))
+	seen := make(map[uuid.UUID]struct{}, len(r.GetItems()))
 
 	// parse items
 	for i := range r.GetItems() {
@@ -34,16 +38,34 @@ func AddRequestToDomain(ctx context.Context, r *v2.AddRequest) (*AddRequestParam
 			return nil, ParseItemError{Err: errParseItem, item: r.GetItems()[i].GetGoodId()}
 		}
 
+		// each good may appear once per request
+		if _, ok := seen[goodID]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateGoodInRequest, goodID)
+		}
+
+		seen[goodID] = struct{}{}
+
 		cartItem, err := itemv1.NewItem(goodID, r.GetItems()[i].GetQuantity())
 		if err != nil {
 			return nil, fmt.Errorf("invalid cart item %+v: %w", r.GetItems()[i], err)
 		}
 
+		currency, err := pricing.NewCurrency(r.GetItems()[i].GetCurrency())
+		if err != nil {
+			return nil, fmt.Errorf("invalid cart item %+v: %w", r.GetItems()[i], err)
+		}
+
+		cartItem, err = cartItem.WithCurrency(currency).WithTaxCategory(r.GetItems()[i].GetTaxCategory()).WithNote(r.GetItems()[i].GetNote())
+		if err != nil {
+			return nil, fmt.Errorf("invalid cart item %+v: %w", r.GetItems()[i], err)
+		}
+
 		items = append(items, cartItem)
 	}
 
 	return &AddRequestParams{
-		CustomerID: customerID,
-		Items:      items,
+		CustomerID:  customerID,
+		Items:       items,
+		GiftMessage: r.GetGiftMessage(),
 	}, nil
 }
@@ -3,6 +3,7 @@ package dto
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -11,6 +12,7 @@ import (
 
 	domain "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
 	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/pricing"
 	model "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/cart/v1/model/v1"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/rpcmeta"
 )
@@ -138,3 +140,77 @@ func TestAddRequestToDomain(t *testing.T) {
 		})
 	}
 }
+
+func TestAddRequestToDomain_DuplicateGood(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", uuid.New().String()))
+	goodID := uuid.New().String()
+
+	params, err := AddRequestToDomain(ctx, &model.AddRequest{
+		Items: []*model.CartItem{
+			{GoodId: goodID, Quantity: 1},
+			{GoodId: uuid.New().String(), Quantity: 1},
+			{GoodId: goodID, Quantity: 2},
+		},
+	})
+
+	assert.ErrorIs(t, err, ErrDuplicateGoodInRequest)
+	assert.Nil(t, params)
+}
+
+func TestAddRequestToDomain_Currency(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", uuid.New().String()))
+
+	params, err := AddRequestToDomain(ctx, &model.AddRequest{
+		Items: []*model.CartItem{
+			{GoodId: uuid.New().String(), Quantity: 1, Currency: "usd"},
+			{GoodId: uuid.New().String(), Quantity: 1},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pricing.Currency("USD"), params.Items[0].GetCurrency())
+	assert.Empty(t, params.Items[1].GetCurrency())
+
+	params, err = AddRequestToDomain(ctx, &model.AddRequest{
+		Items: []*model.CartItem{{GoodId: uuid.New().String(), Quantity: 1, Currency: "US"}},
+	})
+	assert.ErrorIs(t, err, pricing.ErrInvalidCurrency)
+	assert.Nil(t, params)
+}
+
+func TestAddRequestToDomain_TaxCategory(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", uuid.New().String()))
+
+	params, err := AddRequestToDomain(ctx, &model.AddRequest{
+		Items: []*model.CartItem{
+			{GoodId: uuid.New().String(), Quantity: 1, TaxCategory: "food"},
+			{GoodId: uuid.New().String(), Quantity: 1},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "food", params.Items[0].GetTaxCategory())
+	assert.Empty(t, params.Items[1].GetTaxCategory())
+}
+
+func TestAddRequestToDomain_NoteAndGiftMessage(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", uuid.New().String()))
+
+	params, err := AddRequestToDomain(ctx, &model.AddRequest{
+		Items: []*model.CartItem{
+			{GoodId: uuid.New().String(), Quantity: 1, Note: "no onions"},
+			{GoodId: uuid.New().String(), Quantity: 1},
+		},
+		GiftMessage: "Happy birthday!",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "no onions", params.Items[0].GetNote())
+	assert.Empty(t, params.Items[1].GetNote())
+	assert.Equal(t, "Happy birthday!", params.GiftMessage)
+
+	params, err = AddRequestToDomain(ctx, &model.AddRequest{
+		Items: []*model.CartItem{
+			{GoodId: uuid.New().String(), Quantity: 1, Note: strings.Repeat("a", itemv1.MaxNoteLength+1)},
+		},
+	})
+	assert.ErrorIs(t, err, itemv1.ErrItemNoteTooLong)
+	assert.Nil(t, params)
+}
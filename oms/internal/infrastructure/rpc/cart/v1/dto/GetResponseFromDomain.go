@@ -10,15 +10,19 @@ func GetResponseFromDomain(response *v2.State) *v1.GetResponse {
 
 	for _, item := range response.GetItems() {
 		items = append(items, &v1.CartItem{
-			GoodId:   item.GetGoodId().String(),
-			Quantity: item.GetQuantity(),
+			GoodId:      item.GetGoodId().String(),
+			Quantity:    item.GetQuantity(),
+			Currency:    item.GetCurrency().String(),
+			TaxCategory: item.GetTaxCategory(),
+			Note:        item.GetNote(),
 		})
 	}
 
 	return &v1.GetResponse{
 		State: &v1.CartState{
-			CustomerId: response.GetCustomerId().String(),
-			Items:      items,
+			CustomerId:  response.GetCustomerId().String(),
+			Items:       items,
+			GiftMessage: response.GetGiftMessage(),
 		},
 	}
 }
@@ -17,7 +17,8 @@ type RemoveRequestParams struct {
 	Items      []itemv1.Item
 }
 
-// RemoveRequestToDomain converts a RemoveRequest to domain parameters
+// RemoveRequestToDomain converts a RemoveRequest to domain parameters.
+// A good listed more than once is rejected with ErrDuplicateGoodInRequest.
 func RemoveRequestToDomain(ctx context.Context, r *v1.RemoveRequest) (*RemoveRequestParams, error) {
 	customerID, err := rpcmeta.CustomerIDFromContext(ctx)
 	if err != nil {
@@ -25,6 +26,7 @@ func RemoveRequestToDomain(ctx context.Context, r *v1.RemoveRequest) (*RemoveReq
 	}
 
 	items := make([]itemv1.Item, 0, len(r.GetItems()))
+	seen := make(map[uuid.UUID]struct{}, len(r.GetItems()))
 
 	// parse items
 	for i := range r.GetItems() {
@@ -34,6 +36,13 @@ func RemoveRequestToDomain(ctx context.Context, r *v1.RemoveRequest) (*RemoveReq
 			return nil, ParseItemError{Err: errParseItem, item: r.GetItems()[i].GetGoodId()}
 		}
 
+		// each good may appear once per request
+		if _, ok := seen[goodID]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateGoodInRequest, goodID)
+		}
+
+		seen[goodID] = struct{}{}
+
 		// create CartItem
 		item, err := itemv1.NewItem(goodID, r.GetItems()[i].GetQuantity())
 		if err != nil {
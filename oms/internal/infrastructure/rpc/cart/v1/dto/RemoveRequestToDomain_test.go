@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	model "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/cart/v1/model/v1"
+)
+
+func TestRemoveRequestToDomain(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", uuid.New().String()))
+	goodID := uuid.New().String()
+
+	t.Run("distinct goods", func(t *testing.T) {
+		params, err := RemoveRequestToDomain(ctx, &model.RemoveRequest{
+			Items: []*model.CartItem{
+				{GoodId: goodID, Quantity: 1},
+				{GoodId: uuid.New().String(), Quantity: 2},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, params.Items, 2)
+	})
+
+	t.Run("duplicate good", func(t *testing.T) {
+		params, err := RemoveRequestToDomain(ctx, &model.RemoveRequest{
+			Items: []*model.CartItem{
+				{GoodId: goodID, Quantity: 1},
+				{GoodId: goodID, Quantity: 1},
+			},
+		})
+
+		assert.ErrorIs(t, err, ErrDuplicateGoodInRequest)
+		assert.Nil(t, params)
+	})
+}
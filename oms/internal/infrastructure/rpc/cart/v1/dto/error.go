@@ -7,6 +7,10 @@ import (
 
 var ErrInvalidCustomerId = errors.New("invalid customer id")
 
+// ErrDuplicateGoodInRequest is returned when an Add or Remove request lists the same good more than once.
+// Clients must send one entry per good with the total quantity instead of relying on an implicit merge.
+var ErrDuplicateGoodInRequest = errors.New("duplicate good in request")
+
 type ParseItemError struct {
 	Err error
 
@@ -32,7 +32,13 @@ type CartItem struct {
 	// Good ID
 	GoodId string `protobuf:"bytes,1,opt,name=good_id,json=goodId,proto3" json:"good_id,omitempty"`
 	// Quantity
-	Quantity      int32 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Quantity int32 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// ISO-4217 currency of the item price, e.g. "USD"; empty when not specified
+	Currency string `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Tax category tax policies branch on, e.g. "food"; empty means the pricer's default
+	TaxCategory string `protobuf:"bytes,8,opt,name=tax_category,json=taxCategory,proto3" json:"tax_category,omitempty"`
+	// Customer note for this item, up to 500 characters; empty when none
+	Note          string `protobuf:"bytes,9,opt,name=note,proto3" json:"note,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -88,6 +94,27 @@ func (x *CartItem) GetQuantity() int32 {
 	return 0
 }
 
+func (x *CartItem) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CartItem) GetTaxCategory() string {
+	if x != nil {
+		return x.TaxCategory
+	}
+	return ""
+}
+
+func (x *CartItem) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
 // CartState is the cart state message.
 type CartState struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -102,7 +129,9 @@ type CartState struct {
 	// Created at
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	// Updated at
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Gift message for the whole cart, up to 1000 characters; empty when none
+	GiftMessage   string `protobuf:"bytes,7,opt,name=gift_message,json=giftMessage,proto3" json:"gift_message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -179,12 +208,21 @@ func (x *CartState) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *CartState) GetGiftMessage() string {
+	if x != nil {
+		return x.GiftMessage
+	}
+	return ""
+}
+
 // AddRequest is the request message for adding an item to the cart.
 // Customer identity comes from request metadata (x-user-id set by Istio from JWT).
 type AddRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Item to add
-	Items         []*CartItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Items []*CartItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// Gift message for the whole cart, up to 1000 characters; empty keeps the current one
+	GiftMessage   string `protobuf:"bytes,3,opt,name=gift_message,json=giftMessage,proto3" json:"gift_message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -226,6 +264,13 @@ func (x *AddRequest) GetItems() []*CartItem {
 	return nil
 }
 
+func (x *AddRequest) GetGiftMessage() string {
+	if x != nil {
+		return x.GiftMessage
+	}
+	return ""
+}
+
 // RemoveRequest is the request message for removing an item from the cart.
 type RemoveRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -396,12 +441,15 @@ var File_infrastructure_rpc_cart_v1_model_v1_model_proto protoreflect.FileDescri
 
 const file_infrastructure_rpc_cart_v1_model_v1_model_proto_rawDesc = "" +
 	"\n" +
-	"/infrastructure/rpc/cart/v1/model/v1/model.proto\x12#infrastructure.rpc.cart.v1.model.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\"z\n" +
+	"/infrastructure/rpc/cart/v1/model/v1/model.proto\x12#infrastructure.rpc.cart.v1.model.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\"\xcd\x01\n" +
 	"\bCartItem\x129\n" +
 	"\n" +
 	"field_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\tfieldMask\x12\x17\n" +
 	"\agood_id\x18\x01 \x01(\tR\x06goodId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xbb\x02\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12!\n" +
+	"\ftax_category\x18\b \x01(\tR\vtaxCategory\x12\x12\n" +
+	"\x04note\x18\t \x01(\tR\x04note\"\xde\x02\n" +
 	"\tCartState\x129\n" +
 	"\n" +
 	"field_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\tfieldMask\x12\x17\n" +
@@ -412,10 +460,12 @@ const file_infrastructure_rpc_cart_v1_model_v1_model_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"W\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12!\n" +
+	"\fgift_message\x18\a \x01(\tR\vgiftMessage\"z\n" +
 	"\n" +
 	"AddRequest\x12C\n" +
-	"\x05items\x18\x02 \x03(\v2-.infrastructure.rpc.cart.v1.model.v1.CartItemR\x05itemsJ\x04\b\x01\x10\x02\"Z\n" +
+	"\x05items\x18\x02 \x03(\v2-.infrastructure.rpc.cart.v1.model.v1.CartItemR\x05items\x12!\n" +
+	"\fgift_message\x18\x03 \x01(\tR\vgiftMessageJ\x04\b\x01\x10\x02\"Z\n" +
 	"\rRemoveRequest\x12C\n" +
 	"\x05items\x18\x02 \x03(\v2-.infrastructure.rpc.cart.v1.model.v1.CartItemR\x05itemsJ\x04\b\x01\x10\x02\"\x12\n" +
 	"\n" +
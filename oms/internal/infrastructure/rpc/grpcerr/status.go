@@ -42,6 +42,9 @@ func ToStatus(ctx context.Context, log Logger, op string, err error) error {
 	case errors.Is(err, domain.ErrConflict), errors.Is(err, domain.ErrVersionConflict):
 		code = codes.Aborted
 		msg = err.Error()
+	case errors.Is(err, domain.ErrRateLimited):
+		code = codes.ResourceExhausted
+		msg = err.Error()
 	case errors.Is(err, domain.ErrUnavailable):
 		code = codes.Unavailable
 		msg = err.Error()
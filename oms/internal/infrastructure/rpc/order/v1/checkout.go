@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	orderDomain "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/dto"
 	v1 "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/model/v1"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/rpcmeta"
@@ -17,22 +18,40 @@ func (o *OrderRPC) Checkout(ctx context.Context, in *v1.CheckoutRequest) (*v1.Ch
 		return nil, fmt.Errorf("customer identity: %w", err)
 	}
 
-	// Convert proto delivery info to domain (can be nil for self-pickup)
+	// Convert proto delivery info to domain (nil for self-pickup)
 	deliveryInfo := dto.ProtoDeliveryInfoToDomain(in.GetDeliveryInfo())
 
 	// Create command and execute handler
-	cmd := create_order_from_cart.NewCommand(customerID, deliveryInfo)
+	cmd := create_order_from_cart.NewCommand(customerID, checkoutFulfillmentType(in), deliveryInfo)
+	cmd.TaxExemptionCode = in.GetTaxExemptionCode()
 
 	result, err := o.checkoutHandler.Handle(ctx, cmd)
 	if err != nil {
 		return nil, err
 	}
 
+	// The response has a single discount field; it covers item and order-level discounts alike
 	return &v1.CheckoutResponse{
-		OrderId:       result.Order.GetOrderID().String(),
-		Subtotal:      result.Subtotal.InexactFloat64(),
-		TotalDiscount: result.TotalDiscount.InexactFloat64(),
-		TotalTax:      result.TotalTax.InexactFloat64(),
-		FinalPrice:    result.FinalPrice.InexactFloat64(),
+		OrderId:          result.Order.GetOrderID().String(),
+		Subtotal:         result.Subtotal.InexactFloat64(),
+		TotalDiscount:    result.TotalDiscount.Add(result.OrderDiscount).InexactFloat64(),
+		TotalTax:         result.TotalTax.InexactFloat64(),
+		DeliveryFee:      result.DeliveryFee.InexactFloat64(),
+		FinalPrice:       result.FinalPrice.InexactFloat64(),
+		TaxExemptionCode: result.TaxExemptionCode,
 	}, nil
 }
+
+// checkoutFulfillmentType returns the requested fulfillment type. Clients that predate the field
+// send UNSPECIFIED; for them the documented contract applies: delivery info present means DELIVERY.
+func checkoutFulfillmentType(in *v1.CheckoutRequest) orderDomain.FulfillmentType {
+	if in.GetFulfillmentType() != orderDomain.FulfillmentType_FULFILLMENT_TYPE_UNSPECIFIED {
+		return in.GetFulfillmentType()
+	}
+
+	if in.GetDeliveryInfo() != nil {
+		return orderDomain.FulfillmentType_FULFILLMENT_TYPE_DELIVERY
+	}
+
+	return orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP
+}
@@ -18,9 +18,10 @@ func DomainToOrderState(in *v1.OrderState) *v2.OrderState {
 	items := make([]*v2.OrderItem, len(domainItems))
 	for i, item := range domainItems {
 		items[i] = &v2.OrderItem{
-			Id:       item.GetGoodId().String(),
-			Quantity: item.GetQuantity(),
-			Price:    item.GetPrice().InexactFloat64(),
+			Id:          item.GetGoodId().String(),
+			Quantity:    item.GetQuantity(),
+			Price:       item.GetPrice().InexactFloat64(),
+			TaxCategory: item.GetTaxCategory(),
 		}
 	}
 
@@ -39,14 +40,15 @@ func DomainToOrderState(in *v1.OrderState) *v2.OrderState {
 	}
 
 	return &v2.OrderState{
-		Id:             in.GetOrderID().String(),
-		CustomerId:     in.GetCustomerId().String(),
-		Items:          items,
-		Status:         in.GetStatus(),
-		DeliveryInfo:   deliveryInfo,
-		DeliveryStatus: in.GetDeliveryStatus(),
-		PackageId:      packageID,
-		RequestedAt:    requestedAt,
+		Id:              in.GetOrderID().String(),
+		CustomerId:      in.GetCustomerId().String(),
+		Items:           items,
+		Status:          in.GetStatus(),
+		DeliveryInfo:    deliveryInfo,
+		DeliveryStatus:  in.GetDeliveryStatus(),
+		PackageId:       packageID,
+		RequestedAt:     requestedAt,
+		FulfillmentType: in.GetFulfillmentType(),
 	}
 }
 
@@ -93,6 +95,9 @@ func domainDeliveryPeriodToProto(period v1.DeliveryPeriod) *commonv1.DeliveryPer
 func domainPackageInfoToProto(info v1.PackageInfo) *commonv1.PackageInfo {
 	return &commonv1.PackageInfo{
 		WeightKg: info.GetWeightKg(),
+		LengthCm: info.GetLengthCm(),
+		WidthCm:  info.GetWidthCm(),
+		HeightCm: info.GetHeightCm(),
 	}
 }
 
@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	v1 "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/model/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func OrderSearchResultToProto(in ports.OrderSearchDocument) *v1.OrderSearchResult {
+	goodIDs := make([]string, 0, len(in.GoodIDs))
+	for _, goodID := range in.GoodIDs {
+		goodIDs = append(goodIDs, goodID.String())
+	}
+
+	return &v1.OrderSearchResult{
+		OrderId:       in.OrderID.String(),
+		CustomerId:    in.CustomerID.String(),
+		CustomerEmail: in.CustomerEmail,
+		Status:        in.Status,
+		GoodIds:       goodIDs,
+		Total:         in.Total.InexactFloat64(),
+		CreatedAt:     timestamppb.New(in.CreatedAt.UTC()),
+		UpdatedAt:     timestamppb.New(in.UpdatedAt.UTC()),
+	}
+}
@@ -27,7 +27,8 @@ func ProtoDeliveryInfoToDomain(protoInfo *commonv1.DeliveryInfo) *orderDomain.De
 	)
 
 	// Convert package info
-	pkgInfo := orderDomain.NewPackageInfo(protoInfo.GetPackageInfo().GetWeightKg())
+	pkg := protoInfo.GetPackageInfo()
+	pkgInfo := orderDomain.NewPackageInfoWithDimensions(pkg.GetWeightKg(), pkg.GetLengthCm(), pkg.GetWidthCm(), pkg.GetHeightCm())
 
 	// Convert priority
 	priority := protoPriorityToDomain(protoInfo.GetPriority())
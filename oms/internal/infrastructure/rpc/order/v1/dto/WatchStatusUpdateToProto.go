@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v2 "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/model/v1"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/query/watch_status"
+)
+
+// WatchStatusUpdateToProto maps an observed order status to the streamed response.
+// The initial snapshot has no event, so event_type and occurred_at stay unset.
+func WatchStatusUpdateToProto(in watch_status.Update) *v2.WatchStatusResponse {
+	out := &v2.WatchStatusResponse{
+		OrderId:        in.OrderID,
+		Status:         in.Status,
+		DeliveryStatus: in.DeliveryStatus,
+		EventType:      in.EventType,
+	}
+
+	if !in.OccurredAt.IsZero() {
+		out.OccurredAt = timestamppb.New(in.OccurredAt)
+	}
+
+	return out
+}
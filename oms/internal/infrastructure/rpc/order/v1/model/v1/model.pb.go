@@ -47,9 +47,11 @@ type OrderState struct {
 	// Package ID returned by Delivery service
 	PackageId string `protobuf:"bytes,9,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
 	// Timestamp when OMS successfully requested delivery
-	RequestedAt   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	RequestedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	// How the order reaches the customer (delivery or self-pickup)
+	FulfillmentType common.FulfillmentType `protobuf:"varint,11,opt,name=fulfillment_type,json=fulfillmentType,proto3,enum=domain.order.common.v1.FulfillmentType" json:"fulfillment_type,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *OrderState) Reset() {
@@ -152,6 +154,13 @@ func (x *OrderState) GetRequestedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *OrderState) GetFulfillmentType() common.FulfillmentType {
+	if x != nil {
+		return x.FulfillmentType
+	}
+	return common.FulfillmentType(0)
+}
+
 // Define the OrderItem message
 type OrderItem struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -160,7 +169,9 @@ type OrderItem struct {
 	// Quantity of the item ordered
 	Quantity int32 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	// Price of a single item
-	Price         float64 `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Price float64 `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	// Tax category tax policies branch on, e.g. "food"; empty means the pricer's default
+	TaxCategory   string `protobuf:"bytes,4,opt,name=tax_category,json=taxCategory,proto3" json:"tax_category,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -216,6 +227,13 @@ func (x *OrderItem) GetPrice() float64 {
 	return 0
 }
 
+func (x *OrderItem) GetTaxCategory() string {
+	if x != nil {
+		return x.TaxCategory
+	}
+	return ""
+}
+
 // Request message for creating an order
 type CreateRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -771,10 +789,15 @@ func (x *UpdateDeliveryInfoRequest) GetDeliveryInfo() *common.DeliveryInfo {
 // Customer identity comes from request metadata (x-user-id set by Istio from JWT).
 type CheckoutRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Delivery information (optional, nil = self-pickup)
-	DeliveryInfo  *common.DeliveryInfo `protobuf:"bytes,2,opt,name=delivery_info,json=deliveryInfo,proto3" json:"delivery_info,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// Delivery information (required for DELIVERY, must be empty for PICKUP)
+	DeliveryInfo *common.DeliveryInfo `protobuf:"bytes,2,opt,name=delivery_info,json=deliveryInfo,proto3" json:"delivery_info,omitempty"`
+	// How the order reaches the customer. When unspecified, it is inferred from
+	// delivery_info (present = DELIVERY, absent = PICKUP) for clients that predate the field.
+	FulfillmentType common.FulfillmentType `protobuf:"varint,3,opt,name=fulfillment_type,json=fulfillmentType,proto3,enum=domain.order.common.v1.FulfillmentType" json:"fulfillment_type,omitempty"`
+	// Tax exemption code of the customer (e.g. B2B, charity); when set no tax is charged
+	TaxExemptionCode string `protobuf:"bytes,4,opt,name=tax_exemption_code,json=taxExemptionCode,proto3" json:"tax_exemption_code,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *CheckoutRequest) Reset() {
@@ -814,6 +837,20 @@ func (x *CheckoutRequest) GetDeliveryInfo() *common.DeliveryInfo {
 	return nil
 }
 
+func (x *CheckoutRequest) GetFulfillmentType() common.FulfillmentType {
+	if x != nil {
+		return x.FulfillmentType
+	}
+	return common.FulfillmentType(0)
+}
+
+func (x *CheckoutRequest) GetTaxExemptionCode() string {
+	if x != nil {
+		return x.TaxExemptionCode
+	}
+	return ""
+}
+
 // Response message for checkout
 type CheckoutResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -825,10 +862,14 @@ type CheckoutResponse struct {
 	TotalDiscount float64 `protobuf:"fixed64,3,opt,name=total_discount,json=totalDiscount,proto3" json:"total_discount,omitempty"`
 	// Total tax amount
 	TotalTax float64 `protobuf:"fixed64,4,opt,name=total_tax,json=totalTax,proto3" json:"total_tax,omitempty"`
-	// Final price (subtotal - discount + tax)
-	FinalPrice    float64 `protobuf:"fixed64,5,opt,name=final_price,json=finalPrice,proto3" json:"final_price,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// Final price (subtotal - discount + tax + delivery fee)
+	FinalPrice float64 `protobuf:"fixed64,5,opt,name=final_price,json=finalPrice,proto3" json:"final_price,omitempty"`
+	// Delivery fee; 0 for pickup orders and free shipping
+	DeliveryFee float64 `protobuf:"fixed64,6,opt,name=delivery_fee,json=deliveryFee,proto3" json:"delivery_fee,omitempty"`
+	// Set when the order was not taxed because the customer is tax-exempt
+	TaxExemptionCode string `protobuf:"bytes,7,opt,name=tax_exemption_code,json=taxExemptionCode,proto3" json:"tax_exemption_code,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *CheckoutResponse) Reset() {
@@ -896,31 +937,43 @@ func (x *CheckoutResponse) GetFinalPrice() float64 {
 	return 0
 }
 
-// Pagination info for list requests
-type Pagination struct {
+func (x *CheckoutResponse) GetDeliveryFee() float64 {
+	if x != nil {
+		return x.DeliveryFee
+	}
+	return 0
+}
+
+func (x *CheckoutResponse) GetTaxExemptionCode() string {
+	if x != nil {
+		return x.TaxExemptionCode
+	}
+	return ""
+}
+
+// Request message for watching the status of an order
+type WatchStatusRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Current page number (1-indexed)
-	Page int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	// Number of items per page
-	PageSize      int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// ID of the order to watch
+	OrderId       string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Pagination) Reset() {
-	*x = Pagination{}
+func (x *WatchStatusRequest) Reset() {
+	*x = WatchStatusRequest{}
 	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Pagination) String() string {
+func (x *WatchStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Pagination) ProtoMessage() {}
+func (*WatchStatusRequest) ProtoMessage() {}
 
-func (x *Pagination) ProtoReflect() protoreflect.Message {
+func (x *WatchStatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -932,52 +985,50 @@ func (x *Pagination) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
-func (*Pagination) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchStatusRequest.ProtoReflect.Descriptor instead.
+func (*WatchStatusRequest) Descriptor() ([]byte, []int) {
 	return file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *Pagination) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
-}
-
-func (x *Pagination) GetPageSize() int32 {
+func (x *WatchStatusRequest) GetOrderId() string {
 	if x != nil {
-		return x.PageSize
+		return x.OrderId
 	}
-	return 0
+	return ""
 }
 
-// Pagination info for list responses
-type PaginationResponse struct {
+// One status of a watched order. The first message is the current status,
+// each following one a transition caused by a domain event.
+type WatchStatusResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Current page number
-	CurrentPage int32 `protobuf:"varint,1,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
-	// Number of items per page
-	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	// Total number of pages
-	TotalPages    int32 `protobuf:"varint,3,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	// ID of the watched order
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Order lifecycle status
+	Status common.OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=domain.order.common.v1.OrderStatus" json:"status,omitempty"`
+	// Delivery lifecycle status
+	DeliveryStatus common.DeliveryStatus `protobuf:"varint,3,opt,name=delivery_status,json=deliveryStatus,proto3,enum=domain.order.common.v1.DeliveryStatus" json:"delivery_status,omitempty"`
+	// Domain event that caused the transition (empty for the current status)
+	EventType string `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// When the transition happened (unset for the current status)
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PaginationResponse) Reset() {
-	*x = PaginationResponse{}
+func (x *WatchStatusResponse) Reset() {
+	*x = WatchStatusResponse{}
 	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PaginationResponse) String() string {
+func (x *WatchStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PaginationResponse) ProtoMessage() {}
+func (*WatchStatusResponse) ProtoMessage() {}
 
-func (x *PaginationResponse) ProtoReflect() protoreflect.Message {
+func (x *WatchStatusResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -989,28 +1040,88 @@ func (x *PaginationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PaginationResponse.ProtoReflect.Descriptor instead.
-func (*PaginationResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchStatusResponse.ProtoReflect.Descriptor instead.
+func (*WatchStatusResponse) Descriptor() ([]byte, []int) {
 	return file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *PaginationResponse) GetCurrentPage() int32 {
+func (x *WatchStatusResponse) GetOrderId() string {
 	if x != nil {
-		return x.CurrentPage
+		return x.OrderId
 	}
-	return 0
+	return ""
 }
 
-func (x *PaginationResponse) GetPageSize() int32 {
+func (x *WatchStatusResponse) GetStatus() common.OrderStatus {
 	if x != nil {
-		return x.PageSize
+		return x.Status
 	}
-	return 0
+	return common.OrderStatus(0)
+}
+
+func (x *WatchStatusResponse) GetDeliveryStatus() common.DeliveryStatus {
+	if x != nil {
+		return x.DeliveryStatus
+	}
+	return common.DeliveryStatus(0)
+}
+
+func (x *WatchStatusResponse) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
 }
 
-func (x *PaginationResponse) GetTotalPages() int32 {
+func (x *WatchStatusResponse) GetOccurredAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.TotalPages
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// Pagination info for list requests
+type Pagination struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of items per page
+	PageSize      int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Pagination) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
 	}
 	return 0
 }
@@ -1022,14 +1133,20 @@ type ListRequest struct {
 	// Optional filter by order status
 	StatusFilter []common.OrderStatus `protobuf:"varint,2,rep,packed,name=status_filter,json=statusFilter,proto3,enum=domain.order.common.v1.OrderStatus" json:"status_filter,omitempty"`
 	// Pagination
-	Pagination    *Pagination `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Pagination *Pagination `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// Token of the page to return, from the previous response (empty for the first page)
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Optional inclusive lower bound of the order creation time
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	// Optional exclusive upper bound of the order creation time
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListRequest) Reset() {
 	*x = ListRequest{}
-	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[16]
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1041,7 +1158,7 @@ func (x *ListRequest) String() string {
 func (*ListRequest) ProtoMessage() {}
 
 func (x *ListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[16]
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1054,7 +1171,7 @@ func (x *ListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescGZIP(), []int{16}
+	return file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ListRequest) GetStatusFilter() []common.OrderStatus {
@@ -1071,22 +1188,41 @@ func (x *ListRequest) GetPagination() *Pagination {
 	return nil
 }
 
-// Response message for listing orders
+func (x *ListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+// Response message for listing orders, newest first
 type ListResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// List of orders
 	Orders []*OrderState `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	// Total count of orders matching the filter
-	TotalCount int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	// Pagination info
-	Pagination    *PaginationResponse `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// Token of the next page (empty when there are no more orders)
+	NextPageToken string `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListResponse) Reset() {
 	*x = ListResponse{}
-	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[17]
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1098,7 +1234,7 @@ func (x *ListResponse) String() string {
 func (*ListResponse) ProtoMessage() {}
 
 func (x *ListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[17]
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1111,7 +1247,7 @@ func (x *ListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescGZIP(), []int{17}
+	return file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ListResponse) GetOrders() []*OrderState {
@@ -1121,25 +1257,410 @@ func (x *ListResponse) GetOrders() []*OrderState {
 	return nil
 }
 
-func (x *ListResponse) GetTotalCount() int32 {
+func (x *ListResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.TotalCount
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Request message for searching orders in the order search read model (admin search)
+type SearchOrdersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matches a part of the customer email or the beginning of the order ID, case-insensitively
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// Matches the customer email exactly, case-insensitively
+	CustomerEmail string `protobuf:"bytes,2,opt,name=customer_email,json=customerEmail,proto3" json:"customer_email,omitempty"`
+	// Matches orders containing the good
+	GoodId string `protobuf:"bytes,3,opt,name=good_id,json=goodId,proto3" json:"good_id,omitempty"`
+	// Optional filter by order status
+	StatusFilter []common.OrderStatus `protobuf:"varint,4,rep,packed,name=status_filter,json=statusFilter,proto3,enum=domain.order.common.v1.OrderStatus" json:"status_filter,omitempty"`
+	// CREATED_AT_DESC (default), CREATED_AT_ASC, TOTAL_DESC or TOTAL_ASC
+	Sort string `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Maximum number of orders to return (default 20, at most 100)
+	PageSize int32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token returned by the previous page (empty for the first page)
+	PageToken     string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOrdersRequest) Reset() {
+	*x = SearchOrdersRequest{}
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOrdersRequest) ProtoMessage() {}
+
+func (x *SearchOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOrdersRequest.ProtoReflect.Descriptor instead.
+func (*SearchOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SearchOrdersRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetCustomerEmail() string {
+	if x != nil {
+		return x.CustomerEmail
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetGoodId() string {
+	if x != nil {
+		return x.GoodId
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetStatusFilter() []common.OrderStatus {
+	if x != nil {
+		return x.StatusFilter
+	}
+	return nil
+}
+
+func (x *SearchOrdersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
 	}
 	return 0
 }
 
-func (x *ListResponse) GetPagination() *PaginationResponse {
+func (x *SearchOrdersRequest) GetPageToken() string {
 	if x != nil {
-		return x.Pagination
+		return x.PageToken
+	}
+	return ""
+}
+
+// One order found by SearchOrders
+type OrderSearchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Order ID
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Customer ID
+	CustomerId string `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	// Recipient email of the order (empty when unknown)
+	CustomerEmail string `protobuf:"bytes,3,opt,name=customer_email,json=customerEmail,proto3" json:"customer_email,omitempty"`
+	// Order lifecycle status
+	Status common.OrderStatus `protobuf:"varint,4,opt,name=status,proto3,enum=domain.order.common.v1.OrderStatus" json:"status,omitempty"`
+	// Goods of the order items
+	GoodIds []string `protobuf:"bytes,5,rep,name=good_ids,json=goodIds,proto3" json:"good_ids,omitempty"`
+	// Sum of item price times quantity
+	Total float64 `protobuf:"fixed64,6,opt,name=total,proto3" json:"total,omitempty"`
+	// When the order was created
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// When the order last changed
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderSearchResult) Reset() {
+	*x = OrderSearchResult{}
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderSearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderSearchResult) ProtoMessage() {}
+
+func (x *OrderSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderSearchResult.ProtoReflect.Descriptor instead.
+func (*OrderSearchResult) Descriptor() ([]byte, []int) {
+	return file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *OrderSearchResult) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderSearchResult) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *OrderSearchResult) GetCustomerEmail() string {
+	if x != nil {
+		return x.CustomerEmail
+	}
+	return ""
+}
+
+func (x *OrderSearchResult) GetStatus() common.OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return common.OrderStatus(0)
+}
+
+func (x *OrderSearchResult) GetGoodIds() []string {
+	if x != nil {
+		return x.GoodIds
+	}
+	return nil
+}
+
+func (x *OrderSearchResult) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *OrderSearchResult) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OrderSearchResult) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Response message for searching orders
+type SearchOrdersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Orders on this page
+	Orders []*OrderSearchResult `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Token of the next page (empty when there are no more orders)
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOrdersResponse) Reset() {
+	*x = SearchOrdersResponse{}
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOrdersResponse) ProtoMessage() {}
+
+func (x *SearchOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOrdersResponse.ProtoReflect.Descriptor instead.
+func (*SearchOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SearchOrdersResponse) GetOrders() []*OrderSearchResult {
+	if x != nil {
+		return x.Orders
 	}
 	return nil
 }
 
+func (x *SearchOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Request message for changing the items of an order
+type UpdateItemsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the order to update
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Items to merge into the order: listed goods replace the order's, new goods are appended
+	Items         []*OrderItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateItemsRequest) Reset() {
+	*x = UpdateItemsRequest{}
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateItemsRequest) ProtoMessage() {}
+
+func (x *UpdateItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateItemsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateItemsRequest) Descriptor() ([]byte, []int) {
+	return file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateItemsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UpdateItemsRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Response message with the totals of the repriced order
+type UpdateItemsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Subtotal before discounts and taxes
+	Subtotal float64 `protobuf:"fixed64,1,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	// Total discount amount
+	TotalDiscount float64 `protobuf:"fixed64,2,opt,name=total_discount,json=totalDiscount,proto3" json:"total_discount,omitempty"`
+	// Total tax amount
+	TotalTax float64 `protobuf:"fixed64,3,opt,name=total_tax,json=totalTax,proto3" json:"total_tax,omitempty"`
+	// Final price (subtotal - discount + tax)
+	FinalPrice    float64 `protobuf:"fixed64,4,opt,name=final_price,json=finalPrice,proto3" json:"final_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateItemsResponse) Reset() {
+	*x = UpdateItemsResponse{}
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateItemsResponse) ProtoMessage() {}
+
+func (x *UpdateItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateItemsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateItemsResponse) Descriptor() ([]byte, []int) {
+	return file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateItemsResponse) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *UpdateItemsResponse) GetTotalDiscount() float64 {
+	if x != nil {
+		return x.TotalDiscount
+	}
+	return 0
+}
+
+func (x *UpdateItemsResponse) GetTotalTax() float64 {
+	if x != nil {
+		return x.TotalTax
+	}
+	return 0
+}
+
+func (x *UpdateItemsResponse) GetFinalPrice() float64 {
+	if x != nil {
+		return x.FinalPrice
+	}
+	return 0
+}
+
 var File_infrastructure_rpc_order_v1_model_v1_model_proto protoreflect.FileDescriptor
 
 const file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDesc = "" +
 	"\n" +
-	"0infrastructure/rpc/order/v1/model/v1/model.proto\x12$infrastructure.rpc.order.v1.model.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a#domain/order/v1/common/common.proto\"\xb1\x04\n" +
+	"0infrastructure/rpc/order/v1/model/v1/model.proto\x12$infrastructure.rpc.order.v1.model.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a#domain/order/v1/common/common.proto\"\x85\x05\n" +
 	"\n" +
 	"OrderState\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
@@ -1156,11 +1677,13 @@ const file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDesc = "" +
 	"\n" +
 	"package_id\x18\t \x01(\tR\tpackageId\x12=\n" +
 	"\frequested_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\"M\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x12R\n" +
+	"\x10fulfillment_type\x18\v \x01(\x0e2'.domain.order.common.v1.FulfillmentTypeR\x0ffulfillmentType\"p\n" +
 	"\tOrderItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\"\xa2\x01\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12!\n" +
+	"\ftax_category\x18\x04 \x01(\tR\vtaxCategory\"\xa2\x01\n" +
 	"\rCreateRequest\x12F\n" +
 	"\x05order\x18\x01 \x01(\v20.infrastructure.rpc.order.v1.model.v1.OrderStateR\x05order\x12I\n" +
 	"\rdelivery_info\x18\x02 \x01(\v2$.domain.order.common.v1.DeliveryInfoR\fdeliveryInfo\"\x1c\n" +
@@ -1194,37 +1717,79 @@ const file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\x81\x01\n" +
 	"\x19UpdateDeliveryInfoRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12I\n" +
-	"\rdelivery_info\x18\x02 \x01(\v2$.domain.order.common.v1.DeliveryInfoR\fdeliveryInfo\"b\n" +
+	"\rdelivery_info\x18\x02 \x01(\v2$.domain.order.common.v1.DeliveryInfoR\fdeliveryInfo\"\xe4\x01\n" +
 	"\x0fCheckoutRequest\x12I\n" +
-	"\rdelivery_info\x18\x02 \x01(\v2$.domain.order.common.v1.DeliveryInfoR\fdeliveryInfoJ\x04\b\x01\x10\x02\"\xae\x01\n" +
+	"\rdelivery_info\x18\x02 \x01(\v2$.domain.order.common.v1.DeliveryInfoR\fdeliveryInfo\x12R\n" +
+	"\x10fulfillment_type\x18\x03 \x01(\x0e2'.domain.order.common.v1.FulfillmentTypeR\x0ffulfillmentType\x12,\n" +
+	"\x12tax_exemption_code\x18\x04 \x01(\tR\x10taxExemptionCodeJ\x04\b\x01\x10\x02\"\xff\x01\n" +
 	"\x10CheckoutResponse\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1a\n" +
 	"\bsubtotal\x18\x02 \x01(\x01R\bsubtotal\x12%\n" +
 	"\x0etotal_discount\x18\x03 \x01(\x01R\rtotalDiscount\x12\x1b\n" +
 	"\ttotal_tax\x18\x04 \x01(\x01R\btotalTax\x12\x1f\n" +
 	"\vfinal_price\x18\x05 \x01(\x01R\n" +
-	"finalPrice\"=\n" +
+	"finalPrice\x12!\n" +
+	"\fdelivery_fee\x18\x06 \x01(\x01R\vdeliveryFee\x12,\n" +
+	"\x12tax_exemption_code\x18\a \x01(\tR\x10taxExemptionCode\"/\n" +
+	"\x12WatchStatusRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\x9a\x02\n" +
+	"\x13WatchStatusResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12;\n" +
+	"\x06status\x18\x02 \x01(\x0e2#.domain.order.common.v1.OrderStatusR\x06status\x12O\n" +
+	"\x0fdelivery_status\x18\x03 \x01(\x0e2&.domain.order.common.v1.DeliveryStatusR\x0edeliveryStatus\x12\x1d\n" +
 	"\n" +
-	"Pagination\x12\x12\n" +
-	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"u\n" +
-	"\x12PaginationResponse\x12!\n" +
-	"\fcurrent_page\x18\x01 \x01(\x05R\vcurrentPage\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1f\n" +
-	"\vtotal_pages\x18\x03 \x01(\x05R\n" +
-	"totalPages\"\xaf\x01\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"/\n" +
+	"\n" +
+	"Pagination\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSizeJ\x04\b\x01\x10\x02\"\xc8\x02\n" +
 	"\vListRequest\x12H\n" +
 	"\rstatus_filter\x18\x02 \x03(\x0e2#.domain.order.common.v1.OrderStatusR\fstatusFilter\x12P\n" +
 	"\n" +
 	"pagination\x18\x03 \x01(\v20.infrastructure.rpc.order.v1.model.v1.PaginationR\n" +
-	"paginationJ\x04\b\x01\x10\x02\"\xd3\x01\n" +
+	"pagination\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12=\n" +
+	"\fcreated_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedToJ\x04\b\x01\x10\x02\"\x8c\x01\n" +
 	"\fListResponse\x12H\n" +
-	"\x06orders\x18\x01 \x03(\v20.infrastructure.rpc.order.v1.model.v1.OrderStateR\x06orders\x12\x1f\n" +
-	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\x12X\n" +
+	"\x06orders\x18\x01 \x03(\v20.infrastructure.rpc.order.v1.model.v1.OrderStateR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageTokenJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04\"\x83\x02\n" +
+	"\x13SearchOrdersRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12%\n" +
+	"\x0ecustomer_email\x18\x02 \x01(\tR\rcustomerEmail\x12\x17\n" +
+	"\agood_id\x18\x03 \x01(\tR\x06goodId\x12H\n" +
+	"\rstatus_filter\x18\x04 \x03(\x0e2#.domain.order.common.v1.OrderStatusR\fstatusFilter\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"\xda\x02\n" +
+	"\x11OrderSearchResult\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12%\n" +
+	"\x0ecustomer_email\x18\x03 \x01(\tR\rcustomerEmail\x12;\n" +
+	"\x06status\x18\x04 \x01(\x0e2#.domain.order.common.v1.OrderStatusR\x06status\x12\x19\n" +
+	"\bgood_ids\x18\x05 \x03(\tR\agoodIds\x12\x14\n" +
+	"\x05total\x18\x06 \x01(\x01R\x05total\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"pagination\x18\x03 \x01(\v28.infrastructure.rpc.order.v1.model.v1.PaginationResponseR\n" +
-	"paginationB\xbe\x02\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x8f\x01\n" +
+	"\x14SearchOrdersResponse\x12O\n" +
+	"\x06orders\x18\x01 \x03(\v27.infrastructure.rpc.order.v1.model.v1.OrderSearchResultR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"v\n" +
+	"\x12UpdateItemsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12E\n" +
+	"\x05items\x18\x02 \x03(\v2/.infrastructure.rpc.order.v1.model.v1.OrderItemR\x05items\"\x96\x01\n" +
+	"\x13UpdateItemsResponse\x12\x1a\n" +
+	"\bsubtotal\x18\x01 \x01(\x01R\bsubtotal\x12%\n" +
+	"\x0etotal_discount\x18\x02 \x01(\x01R\rtotalDiscount\x12\x1b\n" +
+	"\ttotal_tax\x18\x03 \x01(\x01R\btotalTax\x12\x1f\n" +
+	"\vfinal_price\x18\x04 \x01(\x01R\n" +
+	"finalPriceB\xbe\x02\n" +
 	"(com.infrastructure.rpc.order.v1.model.v1B\n" +
 	"ModelProtoP\x01ZOgithub.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/model/v1\xa2\x02\x05IROVM\xaa\x02$Infrastructure.Rpc.Order.V1.Model.V1\xca\x02$Infrastructure\\Rpc\\Order\\V1\\Model\\V1\xe2\x020Infrastructure\\Rpc\\Order\\V1\\Model\\V1\\GPBMetadata\xea\x02)Infrastructure::Rpc::Order::V1::Model::V1b\x06proto3"
 
@@ -1240,7 +1805,7 @@ func file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescGZIP() []byte
 	return file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDescData
 }
 
-var file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_infrastructure_rpc_order_v1_model_v1_model_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_infrastructure_rpc_order_v1_model_v1_model_proto_goTypes = []any{
 	(*OrderState)(nil),                // 0: infrastructure.rpc.order.v1.model.v1.OrderState
 	(*OrderItem)(nil),                 // 1: infrastructure.rpc.order.v1.model.v1.OrderItem
@@ -1256,43 +1821,62 @@ var file_infrastructure_rpc_order_v1_model_v1_model_proto_goTypes = []any{
 	(*UpdateDeliveryInfoRequest)(nil), // 11: infrastructure.rpc.order.v1.model.v1.UpdateDeliveryInfoRequest
 	(*CheckoutRequest)(nil),           // 12: infrastructure.rpc.order.v1.model.v1.CheckoutRequest
 	(*CheckoutResponse)(nil),          // 13: infrastructure.rpc.order.v1.model.v1.CheckoutResponse
-	(*Pagination)(nil),                // 14: infrastructure.rpc.order.v1.model.v1.Pagination
-	(*PaginationResponse)(nil),        // 15: infrastructure.rpc.order.v1.model.v1.PaginationResponse
-	(*ListRequest)(nil),               // 16: infrastructure.rpc.order.v1.model.v1.ListRequest
-	(*ListResponse)(nil),              // 17: infrastructure.rpc.order.v1.model.v1.ListResponse
-	(common.OrderStatus)(0),           // 18: domain.order.common.v1.OrderStatus
-	(*timestamppb.Timestamp)(nil),     // 19: google.protobuf.Timestamp
-	(*common.DeliveryInfo)(nil),       // 20: domain.order.common.v1.DeliveryInfo
-	(common.DeliveryStatus)(0),        // 21: domain.order.common.v1.DeliveryStatus
-	(*fieldmaskpb.FieldMask)(nil),     // 22: google.protobuf.FieldMask
+	(*WatchStatusRequest)(nil),        // 14: infrastructure.rpc.order.v1.model.v1.WatchStatusRequest
+	(*WatchStatusResponse)(nil),       // 15: infrastructure.rpc.order.v1.model.v1.WatchStatusResponse
+	(*Pagination)(nil),                // 16: infrastructure.rpc.order.v1.model.v1.Pagination
+	(*ListRequest)(nil),               // 17: infrastructure.rpc.order.v1.model.v1.ListRequest
+	(*ListResponse)(nil),              // 18: infrastructure.rpc.order.v1.model.v1.ListResponse
+	(*SearchOrdersRequest)(nil),       // 19: infrastructure.rpc.order.v1.model.v1.SearchOrdersRequest
+	(*OrderSearchResult)(nil),         // 20: infrastructure.rpc.order.v1.model.v1.OrderSearchResult
+	(*SearchOrdersResponse)(nil),      // 21: infrastructure.rpc.order.v1.model.v1.SearchOrdersResponse
+	(*UpdateItemsRequest)(nil),        // 22: infrastructure.rpc.order.v1.model.v1.UpdateItemsRequest
+	(*UpdateItemsResponse)(nil),       // 23: infrastructure.rpc.order.v1.model.v1.UpdateItemsResponse
+	(common.OrderStatus)(0),           // 24: domain.order.common.v1.OrderStatus
+	(*timestamppb.Timestamp)(nil),     // 25: google.protobuf.Timestamp
+	(*common.DeliveryInfo)(nil),       // 26: domain.order.common.v1.DeliveryInfo
+	(common.DeliveryStatus)(0),        // 27: domain.order.common.v1.DeliveryStatus
+	(common.FulfillmentType)(0),       // 28: domain.order.common.v1.FulfillmentType
+	(*fieldmaskpb.FieldMask)(nil),     // 29: google.protobuf.FieldMask
 }
 var file_infrastructure_rpc_order_v1_model_v1_model_proto_depIdxs = []int32{
 	1,  // 0: infrastructure.rpc.order.v1.model.v1.OrderState.items:type_name -> infrastructure.rpc.order.v1.model.v1.OrderItem
-	18, // 1: infrastructure.rpc.order.v1.model.v1.OrderState.status:type_name -> domain.order.common.v1.OrderStatus
-	19, // 2: infrastructure.rpc.order.v1.model.v1.OrderState.created_at:type_name -> google.protobuf.Timestamp
-	19, // 3: infrastructure.rpc.order.v1.model.v1.OrderState.updated_at:type_name -> google.protobuf.Timestamp
-	20, // 4: infrastructure.rpc.order.v1.model.v1.OrderState.delivery_info:type_name -> domain.order.common.v1.DeliveryInfo
-	21, // 5: infrastructure.rpc.order.v1.model.v1.OrderState.delivery_status:type_name -> domain.order.common.v1.DeliveryStatus
-	19, // 6: infrastructure.rpc.order.v1.model.v1.OrderState.requested_at:type_name -> google.protobuf.Timestamp
-	0,  // 7: infrastructure.rpc.order.v1.model.v1.CreateRequest.order:type_name -> infrastructure.rpc.order.v1.model.v1.OrderState
-	20, // 8: infrastructure.rpc.order.v1.model.v1.CreateRequest.delivery_info:type_name -> domain.order.common.v1.DeliveryInfo
-	0,  // 9: infrastructure.rpc.order.v1.model.v1.GetResponse.order:type_name -> infrastructure.rpc.order.v1.model.v1.OrderState
-	19, // 10: infrastructure.rpc.order.v1.model.v1.GoodsLeaderboard.generated_at:type_name -> google.protobuf.Timestamp
-	5,  // 11: infrastructure.rpc.order.v1.model.v1.GoodsLeaderboard.entries:type_name -> infrastructure.rpc.order.v1.model.v1.LeaderboardEntry
-	6,  // 12: infrastructure.rpc.order.v1.model.v1.GetLeaderboardResponse.leaderboard:type_name -> infrastructure.rpc.order.v1.model.v1.GoodsLeaderboard
-	0,  // 13: infrastructure.rpc.order.v1.model.v1.UpdateRequest.order:type_name -> infrastructure.rpc.order.v1.model.v1.OrderState
-	22, // 14: infrastructure.rpc.order.v1.model.v1.UpdateRequest.update_mask:type_name -> google.protobuf.FieldMask
-	20, // 15: infrastructure.rpc.order.v1.model.v1.UpdateDeliveryInfoRequest.delivery_info:type_name -> domain.order.common.v1.DeliveryInfo
-	20, // 16: infrastructure.rpc.order.v1.model.v1.CheckoutRequest.delivery_info:type_name -> domain.order.common.v1.DeliveryInfo
-	18, // 17: infrastructure.rpc.order.v1.model.v1.ListRequest.status_filter:type_name -> domain.order.common.v1.OrderStatus
-	14, // 18: infrastructure.rpc.order.v1.model.v1.ListRequest.pagination:type_name -> infrastructure.rpc.order.v1.model.v1.Pagination
-	0,  // 19: infrastructure.rpc.order.v1.model.v1.ListResponse.orders:type_name -> infrastructure.rpc.order.v1.model.v1.OrderState
-	15, // 20: infrastructure.rpc.order.v1.model.v1.ListResponse.pagination:type_name -> infrastructure.rpc.order.v1.model.v1.PaginationResponse
-	21, // [21:21] is the sub-list for method output_type
-	21, // [21:21] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	24, // 1: infrastructure.rpc.order.v1.model.v1.OrderState.status:type_name -> domain.order.common.v1.OrderStatus
+	25, // 2: infrastructure.rpc.order.v1.model.v1.OrderState.created_at:type_name -> google.protobuf.Timestamp
+	25, // 3: infrastructure.rpc.order.v1.model.v1.OrderState.updated_at:type_name -> google.protobuf.Timestamp
+	26, // 4: infrastructure.rpc.order.v1.model.v1.OrderState.delivery_info:type_name -> domain.order.common.v1.DeliveryInfo
+	27, // 5: infrastructure.rpc.order.v1.model.v1.OrderState.delivery_status:type_name -> domain.order.common.v1.DeliveryStatus
+	25, // 6: infrastructure.rpc.order.v1.model.v1.OrderState.requested_at:type_name -> google.protobuf.Timestamp
+	28, // 7: infrastructure.rpc.order.v1.model.v1.OrderState.fulfillment_type:type_name -> domain.order.common.v1.FulfillmentType
+	0,  // 8: infrastructure.rpc.order.v1.model.v1.CreateRequest.order:type_name -> infrastructure.rpc.order.v1.model.v1.OrderState
+	26, // 9: infrastructure.rpc.order.v1.model.v1.CreateRequest.delivery_info:type_name -> domain.order.common.v1.DeliveryInfo
+	0,  // 10: infrastructure.rpc.order.v1.model.v1.GetResponse.order:type_name -> infrastructure.rpc.order.v1.model.v1.OrderState
+	25, // 11: infrastructure.rpc.order.v1.model.v1.GoodsLeaderboard.generated_at:type_name -> google.protobuf.Timestamp
+	5,  // 12: infrastructure.rpc.order.v1.model.v1.GoodsLeaderboard.entries:type_name -> infrastructure.rpc.order.v1.model.v1.LeaderboardEntry
+	6,  // 13: infrastructure.rpc.order.v1.model.v1.GetLeaderboardResponse.leaderboard:type_name -> infrastructure.rpc.order.v1.model.v1.GoodsLeaderboard
+	0,  // 14: infrastructure.rpc.order.v1.model.v1.UpdateRequest.order:type_name -> infrastructure.rpc.order.v1.model.v1.OrderState
+	29, // 15: infrastructure.rpc.order.v1.model.v1.UpdateRequest.update_mask:type_name -> google.protobuf.FieldMask
+	26, // 16: infrastructure.rpc.order.v1.model.v1.UpdateDeliveryInfoRequest.delivery_info:type_name -> domain.order.common.v1.DeliveryInfo
+	26, // 17: infrastructure.rpc.order.v1.model.v1.CheckoutRequest.delivery_info:type_name -> domain.order.common.v1.DeliveryInfo
+	28, // 18: infrastructure.rpc.order.v1.model.v1.CheckoutRequest.fulfillment_type:type_name -> domain.order.common.v1.FulfillmentType
+	24, // 19: infrastructure.rpc.order.v1.model.v1.WatchStatusResponse.status:type_name -> domain.order.common.v1.OrderStatus
+	27, // 20: infrastructure.rpc.order.v1.model.v1.WatchStatusResponse.delivery_status:type_name -> domain.order.common.v1.DeliveryStatus
+	25, // 21: infrastructure.rpc.order.v1.model.v1.WatchStatusResponse.occurred_at:type_name -> google.protobuf.Timestamp
+	24, // 22: infrastructure.rpc.order.v1.model.v1.ListRequest.status_filter:type_name -> domain.order.common.v1.OrderStatus
+	16, // 23: infrastructure.rpc.order.v1.model.v1.ListRequest.pagination:type_name -> infrastructure.rpc.order.v1.model.v1.Pagination
+	25, // 24: infrastructure.rpc.order.v1.model.v1.ListRequest.created_from:type_name -> google.protobuf.Timestamp
+	25, // 25: infrastructure.rpc.order.v1.model.v1.ListRequest.created_to:type_name -> google.protobuf.Timestamp
+	0,  // 26: infrastructure.rpc.order.v1.model.v1.ListResponse.orders:type_name -> infrastructure.rpc.order.v1.model.v1.OrderState
+	24, // 27: infrastructure.rpc.order.v1.model.v1.SearchOrdersRequest.status_filter:type_name -> domain.order.common.v1.OrderStatus
+	24, // 28: infrastructure.rpc.order.v1.model.v1.OrderSearchResult.status:type_name -> domain.order.common.v1.OrderStatus
+	25, // 29: infrastructure.rpc.order.v1.model.v1.OrderSearchResult.created_at:type_name -> google.protobuf.Timestamp
+	25, // 30: infrastructure.rpc.order.v1.model.v1.OrderSearchResult.updated_at:type_name -> google.protobuf.Timestamp
+	20, // 31: infrastructure.rpc.order.v1.model.v1.SearchOrdersResponse.orders:type_name -> infrastructure.rpc.order.v1.model.v1.OrderSearchResult
+	1,  // 32: infrastructure.rpc.order.v1.model.v1.UpdateItemsRequest.items:type_name -> infrastructure.rpc.order.v1.model.v1.OrderItem
+	33, // [33:33] is the sub-list for method output_type
+	33, // [33:33] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_infrastructure_rpc_order_v1_model_v1_model_proto_init() }
@@ -1306,7 +1890,7 @@ func file_infrastructure_rpc_order_v1_model_v1_model_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDesc), len(file_infrastructure_rpc_order_v1_model_v1_model_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -28,15 +28,18 @@ var File_infrastructure_rpc_order_v1_order_rpc_proto protoreflect.FileDescriptor
 
 const file_infrastructure_rpc_order_v1_order_rpc_proto_rawDesc = "" +
 	"\n" +
-	"+infrastructure/rpc/order/v1/order_rpc.proto\x12\x1binfrastructure.rpc.order.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a0infrastructure/rpc/order/v1/model/v1/model.proto2\x8f\x06\n" +
+	"+infrastructure/rpc/order/v1/order_rpc.proto\x12\x1binfrastructure.rpc.order.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a0infrastructure/rpc/order/v1/model/v1/model.proto2\xa3\t\n" +
 	"\fOrderService\x12U\n" +
 	"\x06Create\x123.infrastructure.rpc.order.v1.model.v1.CreateRequest\x1a\x16.google.protobuf.Empty\x12j\n" +
 	"\x03Get\x120.infrastructure.rpc.order.v1.model.v1.GetRequest\x1a1.infrastructure.rpc.order.v1.model.v1.GetResponse\x12\x8b\x01\n" +
 	"\x0eGetLeaderboard\x12;.infrastructure.rpc.order.v1.model.v1.GetLeaderboardRequest\x1a<.infrastructure.rpc.order.v1.model.v1.GetLeaderboardResponse\x12m\n" +
-	"\x04List\x121.infrastructure.rpc.order.v1.model.v1.ListRequest\x1a2.infrastructure.rpc.order.v1.model.v1.ListResponse\x12U\n" +
+	"\x04List\x121.infrastructure.rpc.order.v1.model.v1.ListRequest\x1a2.infrastructure.rpc.order.v1.model.v1.ListResponse\x12\x85\x01\n" +
+	"\fSearchOrders\x129.infrastructure.rpc.order.v1.model.v1.SearchOrdersRequest\x1a:.infrastructure.rpc.order.v1.model.v1.SearchOrdersResponse\x12U\n" +
 	"\x06Cancel\x123.infrastructure.rpc.order.v1.model.v1.CancelRequest\x1a\x16.google.protobuf.Empty\x12m\n" +
-	"\x12UpdateDeliveryInfo\x12?.infrastructure.rpc.order.v1.model.v1.UpdateDeliveryInfoRequest\x1a\x16.google.protobuf.Empty\x12y\n" +
-	"\bCheckout\x125.infrastructure.rpc.order.v1.model.v1.CheckoutRequest\x1a6.infrastructure.rpc.order.v1.model.v1.CheckoutResponseB\x87\x02\n" +
+	"\x12UpdateDeliveryInfo\x12?.infrastructure.rpc.order.v1.model.v1.UpdateDeliveryInfoRequest\x1a\x16.google.protobuf.Empty\x12\x82\x01\n" +
+	"\vUpdateItems\x128.infrastructure.rpc.order.v1.model.v1.UpdateItemsRequest\x1a9.infrastructure.rpc.order.v1.model.v1.UpdateItemsResponse\x12y\n" +
+	"\bCheckout\x125.infrastructure.rpc.order.v1.model.v1.CheckoutRequest\x1a6.infrastructure.rpc.order.v1.model.v1.CheckoutResponse\x12\x84\x01\n" +
+	"\vWatchStatus\x128.infrastructure.rpc.order.v1.model.v1.WatchStatusRequest\x1a9.infrastructure.rpc.order.v1.model.v1.WatchStatusResponse0\x01B\x87\x02\n" +
 	"\x1fcom.infrastructure.rpc.order.v1B\rOrderRpcProtoP\x01ZFgithub.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1\xa2\x02\x03IRO\xaa\x02\x1bInfrastructure.Rpc.Order.V1\xca\x02\x1bInfrastructure\\Rpc\\Order\\V1\xe2\x02'Infrastructure\\Rpc\\Order\\V1\\GPBMetadata\xea\x02\x1eInfrastructure::Rpc::Order::V1b\x06proto3"
 
 var file_infrastructure_rpc_order_v1_order_rpc_proto_goTypes = []any{
@@ -44,32 +47,44 @@ var file_infrastructure_rpc_order_v1_order_rpc_proto_goTypes = []any{
 	(*v1.GetRequest)(nil),                // 1: infrastructure.rpc.order.v1.model.v1.GetRequest
 	(*v1.GetLeaderboardRequest)(nil),     // 2: infrastructure.rpc.order.v1.model.v1.GetLeaderboardRequest
 	(*v1.ListRequest)(nil),               // 3: infrastructure.rpc.order.v1.model.v1.ListRequest
-	(*v1.CancelRequest)(nil),             // 4: infrastructure.rpc.order.v1.model.v1.CancelRequest
-	(*v1.UpdateDeliveryInfoRequest)(nil), // 5: infrastructure.rpc.order.v1.model.v1.UpdateDeliveryInfoRequest
-	(*v1.CheckoutRequest)(nil),           // 6: infrastructure.rpc.order.v1.model.v1.CheckoutRequest
-	(*emptypb.Empty)(nil),                // 7: google.protobuf.Empty
-	(*v1.GetResponse)(nil),               // 8: infrastructure.rpc.order.v1.model.v1.GetResponse
-	(*v1.GetLeaderboardResponse)(nil),    // 9: infrastructure.rpc.order.v1.model.v1.GetLeaderboardResponse
-	(*v1.ListResponse)(nil),              // 10: infrastructure.rpc.order.v1.model.v1.ListResponse
-	(*v1.CheckoutResponse)(nil),          // 11: infrastructure.rpc.order.v1.model.v1.CheckoutResponse
+	(*v1.SearchOrdersRequest)(nil),       // 4: infrastructure.rpc.order.v1.model.v1.SearchOrdersRequest
+	(*v1.CancelRequest)(nil),             // 5: infrastructure.rpc.order.v1.model.v1.CancelRequest
+	(*v1.UpdateDeliveryInfoRequest)(nil), // 6: infrastructure.rpc.order.v1.model.v1.UpdateDeliveryInfoRequest
+	(*v1.UpdateItemsRequest)(nil),        // 7: infrastructure.rpc.order.v1.model.v1.UpdateItemsRequest
+	(*v1.CheckoutRequest)(nil),           // 8: infrastructure.rpc.order.v1.model.v1.CheckoutRequest
+	(*v1.WatchStatusRequest)(nil),        // 9: infrastructure.rpc.order.v1.model.v1.WatchStatusRequest
+	(*emptypb.Empty)(nil),                // 10: google.protobuf.Empty
+	(*v1.GetResponse)(nil),               // 11: infrastructure.rpc.order.v1.model.v1.GetResponse
+	(*v1.GetLeaderboardResponse)(nil),    // 12: infrastructure.rpc.order.v1.model.v1.GetLeaderboardResponse
+	(*v1.ListResponse)(nil),              // 13: infrastructure.rpc.order.v1.model.v1.ListResponse
+	(*v1.SearchOrdersResponse)(nil),      // 14: infrastructure.rpc.order.v1.model.v1.SearchOrdersResponse
+	(*v1.UpdateItemsResponse)(nil),       // 15: infrastructure.rpc.order.v1.model.v1.UpdateItemsResponse
+	(*v1.CheckoutResponse)(nil),          // 16: infrastructure.rpc.order.v1.model.v1.CheckoutResponse
+	(*v1.WatchStatusResponse)(nil),       // 17: infrastructure.rpc.order.v1.model.v1.WatchStatusResponse
 }
 var file_infrastructure_rpc_order_v1_order_rpc_proto_depIdxs = []int32{
 	0,  // 0: infrastructure.rpc.order.v1.OrderService.Create:input_type -> infrastructure.rpc.order.v1.model.v1.CreateRequest
 	1,  // 1: infrastructure.rpc.order.v1.OrderService.Get:input_type -> infrastructure.rpc.order.v1.model.v1.GetRequest
 	2,  // 2: infrastructure.rpc.order.v1.OrderService.GetLeaderboard:input_type -> infrastructure.rpc.order.v1.model.v1.GetLeaderboardRequest
 	3,  // 3: infrastructure.rpc.order.v1.OrderService.List:input_type -> infrastructure.rpc.order.v1.model.v1.ListRequest
-	4,  // 4: infrastructure.rpc.order.v1.OrderService.Cancel:input_type -> infrastructure.rpc.order.v1.model.v1.CancelRequest
-	5,  // 5: infrastructure.rpc.order.v1.OrderService.UpdateDeliveryInfo:input_type -> infrastructure.rpc.order.v1.model.v1.UpdateDeliveryInfoRequest
-	6,  // 6: infrastructure.rpc.order.v1.OrderService.Checkout:input_type -> infrastructure.rpc.order.v1.model.v1.CheckoutRequest
-	7,  // 7: infrastructure.rpc.order.v1.OrderService.Create:output_type -> google.protobuf.Empty
-	8,  // 8: infrastructure.rpc.order.v1.OrderService.Get:output_type -> infrastructure.rpc.order.v1.model.v1.GetResponse
-	9,  // 9: infrastructure.rpc.order.v1.OrderService.GetLeaderboard:output_type -> infrastructure.rpc.order.v1.model.v1.GetLeaderboardResponse
-	10, // 10: infrastructure.rpc.order.v1.OrderService.List:output_type -> infrastructure.rpc.order.v1.model.v1.ListResponse
-	7,  // 11: infrastructure.rpc.order.v1.OrderService.Cancel:output_type -> google.protobuf.Empty
-	7,  // 12: infrastructure.rpc.order.v1.OrderService.UpdateDeliveryInfo:output_type -> google.protobuf.Empty
-	11, // 13: infrastructure.rpc.order.v1.OrderService.Checkout:output_type -> infrastructure.rpc.order.v1.model.v1.CheckoutResponse
-	7,  // [7:14] is the sub-list for method output_type
-	0,  // [0:7] is the sub-list for method input_type
+	4,  // 4: infrastructure.rpc.order.v1.OrderService.SearchOrders:input_type -> infrastructure.rpc.order.v1.model.v1.SearchOrdersRequest
+	5,  // 5: infrastructure.rpc.order.v1.OrderService.Cancel:input_type -> infrastructure.rpc.order.v1.model.v1.CancelRequest
+	6,  // 6: infrastructure.rpc.order.v1.OrderService.UpdateDeliveryInfo:input_type -> infrastructure.rpc.order.v1.model.v1.UpdateDeliveryInfoRequest
+	7,  // 7: infrastructure.rpc.order.v1.OrderService.UpdateItems:input_type -> infrastructure.rpc.order.v1.model.v1.UpdateItemsRequest
+	8,  // 8: infrastructure.rpc.order.v1.OrderService.Checkout:input_type -> infrastructure.rpc.order.v1.model.v1.CheckoutRequest
+	9,  // 9: infrastructure.rpc.order.v1.OrderService.WatchStatus:input_type -> infrastructure.rpc.order.v1.model.v1.WatchStatusRequest
+	10, // 10: infrastructure.rpc.order.v1.OrderService.Create:output_type -> google.protobuf.Empty
+	11, // 11: infrastructure.rpc.order.v1.OrderService.Get:output_type -> infrastructure.rpc.order.v1.model.v1.GetResponse
+	12, // 12: infrastructure.rpc.order.v1.OrderService.GetLeaderboard:output_type -> infrastructure.rpc.order.v1.model.v1.GetLeaderboardResponse
+	13, // 13: infrastructure.rpc.order.v1.OrderService.List:output_type -> infrastructure.rpc.order.v1.model.v1.ListResponse
+	14, // 14: infrastructure.rpc.order.v1.OrderService.SearchOrders:output_type -> infrastructure.rpc.order.v1.model.v1.SearchOrdersResponse
+	10, // 15: infrastructure.rpc.order.v1.OrderService.Cancel:output_type -> google.protobuf.Empty
+	10, // 16: infrastructure.rpc.order.v1.OrderService.UpdateDeliveryInfo:output_type -> google.protobuf.Empty
+	15, // 17: infrastructure.rpc.order.v1.OrderService.UpdateItems:output_type -> infrastructure.rpc.order.v1.model.v1.UpdateItemsResponse
+	16, // 18: infrastructure.rpc.order.v1.OrderService.Checkout:output_type -> infrastructure.rpc.order.v1.model.v1.CheckoutResponse
+	17, // 19: infrastructure.rpc.order.v1.OrderService.WatchStatus:output_type -> infrastructure.rpc.order.v1.model.v1.WatchStatusResponse
+	10, // [10:20] is the sub-list for method output_type
+	0,  // [0:10] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -25,9 +25,12 @@ const (
 	OrderService_Get_FullMethodName                = "/infrastructure.rpc.order.v1.OrderService/Get"
 	OrderService_GetLeaderboard_FullMethodName     = "/infrastructure.rpc.order.v1.OrderService/GetLeaderboard"
 	OrderService_List_FullMethodName               = "/infrastructure.rpc.order.v1.OrderService/List"
+	OrderService_SearchOrders_FullMethodName       = "/infrastructure.rpc.order.v1.OrderService/SearchOrders"
 	OrderService_Cancel_FullMethodName             = "/infrastructure.rpc.order.v1.OrderService/Cancel"
 	OrderService_UpdateDeliveryInfo_FullMethodName = "/infrastructure.rpc.order.v1.OrderService/UpdateDeliveryInfo"
+	OrderService_UpdateItems_FullMethodName        = "/infrastructure.rpc.order.v1.OrderService/UpdateItems"
 	OrderService_Checkout_FullMethodName           = "/infrastructure.rpc.order.v1.OrderService/Checkout"
+	OrderService_WatchStatus_FullMethodName        = "/infrastructure.rpc.order.v1.OrderService/WatchStatus"
 )
 
 // OrderServiceClient is the client API for OrderService service.
@@ -44,12 +47,20 @@ type OrderServiceClient interface {
 	GetLeaderboard(ctx context.Context, in *v1.GetLeaderboardRequest, opts ...grpc.CallOption) (*v1.GetLeaderboardResponse, error)
 	// List retrieves orders with filtering and pagination.
 	List(ctx context.Context, in *v1.ListRequest, opts ...grpc.CallOption) (*v1.ListResponse, error)
+	// SearchOrders searches orders of all customers by email, good or status (admin search).
+	// Results come from a read model fed by order events, so recent changes may show up with a delay.
+	SearchOrders(ctx context.Context, in *v1.SearchOrdersRequest, opts ...grpc.CallOption) (*v1.SearchOrdersResponse, error)
 	// Delete deletes an order by its ID.
 	Cancel(ctx context.Context, in *v1.CancelRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// UpdateDeliveryInfo updates delivery information for an order.
 	UpdateDeliveryInfo(ctx context.Context, in *v1.UpdateDeliveryInfoRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UpdateItems changes the items of an order and reprices it.
+	UpdateItems(ctx context.Context, in *v1.UpdateItemsRequest, opts ...grpc.CallOption) (*v1.UpdateItemsResponse, error)
 	// Checkout creates an order from customer's cart.
 	Checkout(ctx context.Context, in *v1.CheckoutRequest, opts ...grpc.CallOption) (*v1.CheckoutResponse, error)
+	// WatchStatus streams the current status of an order, then every status change,
+	// and ends once the order is completed or cancelled.
+	WatchStatus(ctx context.Context, in *v1.WatchStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v1.WatchStatusResponse], error)
 }
 
 type orderServiceClient struct {
@@ -100,6 +111,16 @@ func (c *orderServiceClient) List(ctx context.Context, in *v1.ListRequest, opts
 	return out, nil
 }
 
+func (c *orderServiceClient) SearchOrders(ctx context.Context, in *v1.SearchOrdersRequest, opts ...grpc.CallOption) (*v1.SearchOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.SearchOrdersResponse)
+	err := c.cc.Invoke(ctx, OrderService_SearchOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orderServiceClient) Cancel(ctx context.Context, in *v1.CancelRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -120,6 +141,16 @@ func (c *orderServiceClient) UpdateDeliveryInfo(ctx context.Context, in *v1.Upda
 	return out, nil
 }
 
+func (c *orderServiceClient) UpdateItems(ctx context.Context, in *v1.UpdateItemsRequest, opts ...grpc.CallOption) (*v1.UpdateItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.UpdateItemsResponse)
+	err := c.cc.Invoke(ctx, OrderService_UpdateItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orderServiceClient) Checkout(ctx context.Context, in *v1.CheckoutRequest, opts ...grpc.CallOption) (*v1.CheckoutResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(v1.CheckoutResponse)
@@ -130,6 +161,25 @@ func (c *orderServiceClient) Checkout(ctx context.Context, in *v1.CheckoutReques
 	return out, nil
 }
 
+func (c *orderServiceClient) WatchStatus(ctx context.Context, in *v1.WatchStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v1.WatchStatusResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrderService_ServiceDesc.Streams[0], OrderService_WatchStatus_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[v1.WatchStatusRequest, v1.WatchStatusResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrderService_WatchStatusClient = grpc.ServerStreamingClient[v1.WatchStatusResponse]
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility.
@@ -144,12 +194,20 @@ type OrderServiceServer interface {
 	GetLeaderboard(context.Context, *v1.GetLeaderboardRequest) (*v1.GetLeaderboardResponse, error)
 	// List retrieves orders with filtering and pagination.
 	List(context.Context, *v1.ListRequest) (*v1.ListResponse, error)
+	// SearchOrders searches orders of all customers by email, good or status (admin search).
+	// Results come from a read model fed by order events, so recent changes may show up with a delay.
+	SearchOrders(context.Context, *v1.SearchOrdersRequest) (*v1.SearchOrdersResponse, error)
 	// Delete deletes an order by its ID.
 	Cancel(context.Context, *v1.CancelRequest) (*emptypb.Empty, error)
 	// UpdateDeliveryInfo updates delivery information for an order.
 	UpdateDeliveryInfo(context.Context, *v1.UpdateDeliveryInfoRequest) (*emptypb.Empty, error)
+	// UpdateItems changes the items of an order and reprices it.
+	UpdateItems(context.Context, *v1.UpdateItemsRequest) (*v1.UpdateItemsResponse, error)
 	// Checkout creates an order from customer's cart.
 	Checkout(context.Context, *v1.CheckoutRequest) (*v1.CheckoutResponse, error)
+	// WatchStatus streams the current status of an order, then every status change,
+	// and ends once the order is completed or cancelled.
+	WatchStatus(*v1.WatchStatusRequest, grpc.ServerStreamingServer[v1.WatchStatusResponse]) error
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -172,15 +230,24 @@ func (UnimplementedOrderServiceServer) GetLeaderboard(context.Context, *v1.GetLe
 func (UnimplementedOrderServiceServer) List(context.Context, *v1.ListRequest) (*v1.ListResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedOrderServiceServer) SearchOrders(context.Context, *v1.SearchOrdersRequest) (*v1.SearchOrdersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchOrders not implemented")
+}
 func (UnimplementedOrderServiceServer) Cancel(context.Context, *v1.CancelRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method Cancel not implemented")
 }
 func (UnimplementedOrderServiceServer) UpdateDeliveryInfo(context.Context, *v1.UpdateDeliveryInfoRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateDeliveryInfo not implemented")
 }
+func (UnimplementedOrderServiceServer) UpdateItems(context.Context, *v1.UpdateItemsRequest) (*v1.UpdateItemsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateItems not implemented")
+}
 func (UnimplementedOrderServiceServer) Checkout(context.Context, *v1.CheckoutRequest) (*v1.CheckoutResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Checkout not implemented")
 }
+func (UnimplementedOrderServiceServer) WatchStatus(*v1.WatchStatusRequest, grpc.ServerStreamingServer[v1.WatchStatusResponse]) error {
+	return status.Error(codes.Unimplemented, "method WatchStatus not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 func (UnimplementedOrderServiceServer) testEmbeddedByValue()                      {}
 
@@ -274,6 +341,24 @@ func _OrderService_List_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_SearchOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.SearchOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).SearchOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_SearchOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).SearchOrders(ctx, req.(*v1.SearchOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrderService_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(v1.CancelRequest)
 	if err := dec(in); err != nil {
@@ -310,6 +395,24 @@ func _OrderService_UpdateDeliveryInfo_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_UpdateItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.UpdateItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).UpdateItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_UpdateItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).UpdateItems(ctx, req.(*v1.UpdateItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrderService_Checkout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(v1.CheckoutRequest)
 	if err := dec(in); err != nil {
@@ -328,6 +431,17 @@ func _OrderService_Checkout_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_WatchStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(v1.WatchStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderServiceServer).WatchStatus(m, &grpc.GenericServerStream[v1.WatchStatusRequest, v1.WatchStatusResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrderService_WatchStatusServer = grpc.ServerStreamingServer[v1.WatchStatusResponse]
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -351,6 +465,10 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _OrderService_List_Handler,
 		},
+		{
+			MethodName: "SearchOrders",
+			Handler:    _OrderService_SearchOrders_Handler,
+		},
 		{
 			MethodName: "Cancel",
 			Handler:    _OrderService_Cancel_Handler,
@@ -359,11 +477,21 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateDeliveryInfo",
 			Handler:    _OrderService_UpdateDeliveryInfo_Handler,
 		},
+		{
+			MethodName: "UpdateItems",
+			Handler:    _OrderService_UpdateItems_Handler,
+		},
 		{
 			MethodName: "Checkout",
 			Handler:    _OrderService_Checkout_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchStatus",
+			Handler:       _OrderService_WatchStatus_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "infrastructure/rpc/order/v1/order_rpc.proto",
 }
@@ -13,8 +13,11 @@ import (
 	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/create"
 	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/create_order_from_cart"
 	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/update_delivery_info"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/update_order_items"
 	"github.com/shortlink-org/shop/oms/internal/usecases/order/query/get"
-	"github.com/shortlink-org/shop/oms/internal/usecases/order/query/list"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/query/list_orders"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/query/search_orders"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/query/watch_status"
 )
 
 type OrderRPC struct {
@@ -27,12 +30,15 @@ type OrderRPC struct {
 	createHandler             *create.Handler
 	cancelHandler             *cancel.Handler
 	updateDeliveryInfoHandler *update_delivery_info.Handler
+	updateOrderItemsHandler   *update_order_items.Handler
 	checkoutHandler           *create_order_from_cart.Handler
 
 	// Query Handlers
-	getHandler         *get.Handler
-	listHandler        *list.Handler
-	leaderboardHandler *leaderboardGet.Handler
+	getHandler          *get.Handler
+	listOrdersHandler   *list_orders.Handler
+	searchOrdersHandler *search_orders.Handler
+	leaderboardHandler  *leaderboardGet.Handler
+	watchStatusHandler  *watch_status.Handler
 }
 
 func New(
@@ -41,10 +47,13 @@ func New(
 	createHandler *create.Handler,
 	cancelHandler *cancel.Handler,
 	updateDeliveryInfoHandler *update_delivery_info.Handler,
+	updateOrderItemsHandler *update_order_items.Handler,
 	checkoutHandler *create_order_from_cart.Handler,
 	getHandler *get.Handler,
-	listHandler *list.Handler,
+	listOrdersHandler *list_orders.Handler,
+	searchOrdersHandler *search_orders.Handler,
 	leaderboardHandler *leaderboardGet.Handler,
+	watchStatusHandler *watch_status.Handler,
 ) (*OrderRPC, error) {
 	server := &OrderRPC{
 		// Common
@@ -54,12 +63,15 @@ func New(
 		createHandler:             createHandler,
 		cancelHandler:             cancelHandler,
 		updateDeliveryInfoHandler: updateDeliveryInfoHandler,
+		updateOrderItemsHandler:   updateOrderItemsHandler,
 		checkoutHandler:           checkoutHandler,
 
 		// Query Handlers
-		getHandler:         getHandler,
-		listHandler:        listHandler,
-		leaderboardHandler: leaderboardHandler,
+		getHandler:          getHandler,
+		listOrdersHandler:   listOrdersHandler,
+		searchOrdersHandler: searchOrdersHandler,
+		leaderboardHandler:  leaderboardHandler,
+		watchStatusHandler:  watchStatusHandler,
 	}
 
 	// Register services
@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/dto"
+	v1 "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/model/v1"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/query/search_orders"
+)
+
+func (o *OrderRPC) SearchOrders(ctx context.Context, in *v1.SearchOrdersRequest) (*v1.SearchOrdersResponse, error) {
+	query := search_orders.NewQuery().
+		WithText(in.GetText()).
+		WithCustomerEmail(in.GetCustomerEmail()).
+		WithStatus(in.GetStatusFilter()...).
+		WithSort(ports.OrderSearchSort(in.GetSort())).
+		WithPage(in.GetPageSize(), in.GetPageToken())
+
+	if in.GetGoodId() != "" {
+		goodID, err := uuid.Parse(in.GetGoodId())
+		if err != nil {
+			return nil, err
+		}
+
+		query = query.WithGood(goodID)
+	}
+
+	result, err := o.searchOrdersHandler.Handle(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]*v1.OrderSearchResult, 0, len(result.Orders))
+	for _, document := range result.Orders {
+		orders = append(orders, dto.OrderSearchResultToProto(document))
+	}
+
+	return &v1.SearchOrdersResponse{
+		Orders:        orders,
+		NextPageToken: result.NextPageToken,
+	}, nil
+}
@@ -3,11 +3,12 @@ package v1
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/grpcerr"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/dto"
 	v1 "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/model/v1"
 	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/update_delivery_info"
@@ -19,19 +20,19 @@ func (o *OrderRPC) UpdateDeliveryInfo(ctx context.Context, in *v1.UpdateDelivery
 	// Parse order ID to UUID
 	orderID, err := uuid.Parse(in.GetOrderId())
 	if err != nil {
-		return nil, fmt.Errorf("invalid order ID: %w", err)
+		return nil, grpcerr.ToStatus(ctx, o.log, "Order.UpdateDeliveryInfo", domain.WrapValidation("parse order id", err))
 	}
 
 	// Convert proto delivery info to domain
 	deliveryInfo := dto.ProtoDeliveryInfoToDomain(in.GetDeliveryInfo())
 	if deliveryInfo == nil {
-		return nil, errDeliveryInfoRequired
+		return nil, grpcerr.ToStatus(ctx, o.log, "Order.UpdateDeliveryInfo", domain.WrapValidation("delivery info", errDeliveryInfoRequired))
 	}
 
-	// Create command and execute handler
+	// Create command and execute handler; locked delivery info maps to a conflict status
 	cmd := update_delivery_info.NewCommand(orderID, *deliveryInfo)
 	if err := o.updateDeliveryInfoHandler.Handle(ctx, cmd); err != nil {
-		return nil, err
+		return nil, grpcerr.ToStatus(ctx, o.log, "Order.UpdateDeliveryInfo", err)
 	}
 
 	return &emptypb.Empty{}, nil
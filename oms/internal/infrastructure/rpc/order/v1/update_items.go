@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	orderDomain "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/grpcerr"
+	v1 "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/model/v1"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/update_order_items"
+)
+
+// UpdateItems changes the items of an order and returns the totals it was repriced at.
+func (o *OrderRPC) UpdateItems(ctx context.Context, in *v1.UpdateItemsRequest) (*v1.UpdateItemsResponse, error) {
+	// Parse order ID to UUID
+	orderID, err := uuid.Parse(in.GetOrderId())
+	if err != nil {
+		return nil, grpcerr.ToStatus(ctx, o.log, "Order.UpdateItems", domain.WrapValidation("parse order id", err))
+	}
+
+	items := make(orderDomain.Items, 0, len(in.GetItems()))
+	for _, item := range in.GetItems() {
+		goodID, err := uuid.Parse(item.GetId())
+		if err != nil {
+			return nil, grpcerr.ToStatus(ctx, o.log, "Order.UpdateItems", domain.WrapValidation("parse good id", err))
+		}
+
+		items = append(items, orderDomain.NewItem(goodID, item.GetQuantity(), decimal.NewFromFloat(item.GetPrice())).
+			WithTaxCategory(item.GetTaxCategory()))
+	}
+
+	// Create command and execute handler; terminal orders and price discrepancies map to a conflict status
+	result, err := o.updateOrderItemsHandler.Handle(ctx, update_order_items.NewCommand(orderID, items))
+	if err != nil {
+		return nil, grpcerr.ToStatus(ctx, o.log, "Order.UpdateItems", err)
+	}
+
+	// The response has a single discount field; it covers item and order-level discounts alike
+	return &v1.UpdateItemsResponse{
+		Subtotal:      result.Subtotal.InexactFloat64(),
+		TotalDiscount: result.TotalDiscount.Add(result.OrderDiscount).InexactFloat64(),
+		TotalTax:      result.TotalTax.InexactFloat64(),
+		FinalPrice:    result.FinalPrice.InexactFloat64(),
+	}, nil
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/grpcerr"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/dto"
+	v1 "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/model/v1"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/rpcmeta"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/query/watch_status"
+)
+
+// WatchStatus streams the current status of an order, then every status change,
+// and ends once the order is completed or cancelled.
+func (o *OrderRPC) WatchStatus(in *v1.WatchStatusRequest, stream grpc.ServerStreamingServer[v1.WatchStatusResponse]) error {
+	ctx := stream.Context()
+
+	orderID, err := uuid.Parse(in.GetOrderId())
+	if err != nil {
+		return grpcerr.ToStatus(ctx, o.log, "Order.WatchStatus", domain.WrapValidation("parse order id", err))
+	}
+
+	customerID, err := rpcmeta.CustomerIDFromContext(ctx)
+	if err != nil {
+		return grpcerr.ToStatus(ctx, o.log, "Order.WatchStatus", fmt.Errorf("customer identity: %w", err))
+	}
+
+	query := watch_status.NewCustomerScopedQuery(orderID, customerID)
+
+	err = o.watchStatusHandler.Handle(ctx, query, func(update watch_status.Update) error {
+		return stream.Send(dto.WatchStatusUpdateToProto(update))
+	})
+	if err != nil {
+		return grpcerr.ToStatus(ctx, o.log, "Order.WatchStatus", err)
+	}
+
+	return nil
+}
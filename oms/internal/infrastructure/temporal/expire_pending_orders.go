@@ -0,0 +1,34 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/client"
+
+	queuev1 "github.com/shortlink-org/shop/oms/internal/domain/queue/v1"
+)
+
+const (
+	// ExpirePendingOrdersWorkflowName is the registered name of the pending-order expiry workflow.
+	ExpirePendingOrdersWorkflowName = "ExpirePendingOrdersWorkflow"
+	// ExpirePendingOrdersWorkflowID is shared by every replica so only one cron schedule runs.
+	ExpirePendingOrdersWorkflowID = "oms-expire-pending-orders"
+)
+
+// StartExpirePendingOrders starts the cron workflow that cancels PENDING orders older than maxAge.
+// Starting it while it already runs is a no-op; the running schedule keeps its original arguments.
+func StartExpirePendingOrders(ctx context.Context, temporalClient client.Client, cronSchedule string, maxAge time.Duration) error {
+	_, err := temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:            ExpirePendingOrdersWorkflowID,
+		TaskQueue:     GetQueueName(queuev1.OrderTaskQueue),
+		CronSchedule:  cronSchedule,
+		StaticSummary: "Expire PENDING orders older than " + maxAge.String(),
+	}, ExpirePendingOrdersWorkflowName, maxAge)
+	if err != nil {
+		return fmt.Errorf("start expire pending orders workflow: %w", err)
+	}
+
+	return nil
+}
@@ -70,7 +70,7 @@ func (s *OrderEventSubscriber) OnOrderCreated(ctx context.Context, event *events
 	items := order.GetItems()
 	requestDelivery := order.HasDeliveryInfo()
 
-	workflowID := "order-" + orderID.String()
+	workflowID := orderWorkflowID(orderID)
 
 	s.log.Info("Starting order workflow",
 		slog.String("workflow_id", workflowID),
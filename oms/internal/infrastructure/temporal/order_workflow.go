@@ -0,0 +1,47 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+// OrderWorkflowSignaler delivers signals to running order workflows.
+type OrderWorkflowSignaler struct {
+	temporalClient client.Client
+}
+
+// NewOrderWorkflowSignaler creates a new order workflow signaler.
+func NewOrderWorkflowSignaler(temporalClient client.Client) *OrderWorkflowSignaler {
+	return &OrderWorkflowSignaler{
+		temporalClient: temporalClient,
+	}
+}
+
+// SignalDeliveryStatus signals a delivery status update to the order workflow.
+// Temporal reports both a missing and a closed workflow as NotFound; both map to ports.ErrWorkflowNotRunning.
+func (s *OrderWorkflowSignaler) SignalDeliveryStatus(ctx context.Context, update orderv1.DeliveryStatusUpdate) error {
+	err := s.temporalClient.SignalWorkflow(ctx, orderWorkflowID(update.OrderID), "", orderv1.WorkflowSignalDeliveryStatus, update)
+	if err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			return fmt.Errorf("signal delivery status to order workflow: %w: %w", ports.ErrWorkflowNotRunning, err)
+		}
+
+		return fmt.Errorf("signal delivery status to order workflow: %w", err)
+	}
+
+	return nil
+}
+
+// orderWorkflowID returns the workflow ID OnOrderCreated starts the order workflow with.
+func orderWorkflowID(orderID uuid.UUID) string {
+	return "order-" + orderID.String()
+}
@@ -5,19 +5,22 @@ import (
 	"errors"
 	"log/slog"
 
+	"github.com/google/uuid"
 	"github.com/shortlink-org/go-sdk/logger"
 
 	"github.com/shortlink-org/shop/oms/internal/domain"
 	v1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
 )
 
 // Handler handles AddItem commands.
 type Handler struct {
-	log       logger.Logger
-	uow       ports.UnitOfWork
-	cartRepo  ports.CartRepository
-	publisher ports.EventPublisher
+	log        logger.Logger
+	uow        ports.UnitOfWork
+	cartRepo   ports.CartRepository
+	goodsIndex ports.CartGoodsIndex
+	publisher  ports.EventPublisher
 }
 
 // NewHandler creates a new AddItem handler.
@@ -25,69 +28,74 @@ func NewHandler(
 	log logger.Logger,
 	uow ports.UnitOfWork,
 	cartRepo ports.CartRepository,
+	goodsIndex ports.CartGoodsIndex,
 	publisher ports.EventPublisher,
 ) (*Handler, error) {
 	return &Handler{
-		log:       log,
-		uow:       uow,
-		cartRepo:  cartRepo,
-		publisher: publisher,
+		log:        log,
+		uow:        uow,
+		cartRepo:   cartRepo,
+		goodsIndex: goodsIndex,
+		publisher:  publisher,
 	}, nil
 }
 
 // Handle executes the AddItem command.
-// Pattern: Load -> Domain method -> Save
+// Pattern: Load -> Domain method -> Save, retried on optimistic-lock conflicts;
+// the goods demand index is updated after commit.
 func (h *Handler) Handle(ctx context.Context, cmd Command) error {
-	// Begin transaction
-	ctx, err := h.uow.Begin(ctx)
-	if err != nil {
-		return domain.MapInfraErr("uow.Begin", err)
-	}
-
-	defer func() {
-		rollbackErr := h.uow.Rollback(ctx)
-		if rollbackErr != nil {
-			h.log.Warn("transaction rollback failed", slog.Any("error", rollbackErr))
+	err := uow.RunWithRetry(ctx, h.uow, uow.DefaultMaxAttempts, domain.ErrVersionConflict, func(ctx context.Context) error {
+		// 1. Load aggregate (or create new if not found)
+		cart, err := h.cartRepo.Load(ctx, cmd.CustomerID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				cart = v1.New(cmd.CustomerID)
+			} else {
+				return domain.MapInfraErr("cartRepo.Load", err)
+			}
 		}
-	}()
 
-	// 1. Load aggregate (or create new if not found)
-	cart, err := h.cartRepo.Load(ctx, cmd.CustomerID)
-	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			cart = v1.New(cmd.CustomerID)
-		} else {
-			return domain.MapInfraErr("cartRepo.Load", err)
+		// 2. Call domain method (business logic)
+		err = cart.AddItem(cmd.Item)
+		if err != nil {
+			return domain.WrapValidation("cart.AddItem", err)
 		}
-	}
-
-	// 2. Call domain method (business logic)
-	err = cart.AddItem(cmd.Item)
-	if err != nil {
-		return domain.WrapValidation("cart.AddItem", err)
-	}
 
-	// 3. Save aggregate
-	err = h.cartRepo.Save(ctx, cart)
-	if err != nil {
-		return domain.MapInfraErr("cartRepo.Save", err)
-	}
-
-	// 4. Publish domain events to outbox (same transaction)
-	for _, event := range cart.GetDomainEvents() {
-		err = h.publisher.Publish(ctx, event)
+		// 3. Save aggregate
+		err = h.cartRepo.Save(ctx, cart)
 		if err != nil {
-			return domain.MapInfraErr("eventBus.Publish", err)
+			return domain.MapInfraErr("cartRepo.Save", err)
+		}
+
+		// 4. Publish domain events to outbox (same transaction)
+		for _, event := range cart.GetDomainEvents() {
+			err = h.publisher.Publish(ctx, event)
+			if err != nil {
+				return domain.MapInfraErr("eventBus.Publish", err)
+			}
 		}
-	}
 
-	cart.ClearDomainEvents()
+		cart.ClearDomainEvents()
 
-	// 5. Commit transaction
-	err = h.uow.Commit(ctx)
+		return nil
+	})
 	if err != nil {
-		return domain.MapInfraErr("uow.Commit", err)
+		return domain.MapInfraErr("uow.RunWithRetry", err)
+	}
+
+	// The index is a projection: a failure only skews the demand signal until the cart changes again.
+	indexErr := h.goodsIndex.IncrementGoodDemand(ctx, cmd.Item.GetGoodId(), cmd.CustomerID, int64(cmd.Item.GetQuantity()))
+	if indexErr != nil {
+		h.logIndexFailure(cmd.CustomerID, cmd.Item.GetGoodId(), indexErr)
 	}
 
 	return nil
 }
+
+// logIndexFailure reports a goods index update that did not apply.
+func (h *Handler) logIndexFailure(customerID, goodID uuid.UUID, err error) {
+	h.log.Warn("failed to update cart goods index",
+		slog.String("customer_id", customerID.String()),
+		slog.String("good_id", goodID.String()),
+		slog.Any("error", err))
+}
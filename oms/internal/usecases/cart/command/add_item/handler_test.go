@@ -0,0 +1,102 @@
+package add_item
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	cart "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(ctx context.Context) (context.Context, error) { return ctx, nil }
+func (stubUnitOfWork) Commit(context.Context) error                       { return nil }
+func (stubUnitOfWork) Rollback(context.Context) error                     { return nil }
+
+type stubCartRepository struct {
+	carts map[uuid.UUID]*cart.State
+}
+
+func (s *stubCartRepository) Load(_ context.Context, customerID uuid.UUID) (*cart.State, error) {
+	state, ok := s.carts[customerID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+
+	return state, nil
+}
+
+func (s *stubCartRepository) Save(_ context.Context, state *cart.State) error {
+	s.carts[state.GetCustomerId()] = state
+
+	return nil
+}
+
+// stubGoodsIndex records the demand change per good; other index calls are not expected.
+type stubGoodsIndex struct {
+	ports.CartGoodsIndex
+
+	demand map[uuid.UUID]int64
+}
+
+func (s *stubGoodsIndex) IncrementGoodDemand(_ context.Context, goodID, _ uuid.UUID, quantity int64) error {
+	s.demand[goodID] += quantity
+
+	return nil
+}
+
+func (s *stubGoodsIndex) DecrementGoodDemand(_ context.Context, goodID, _ uuid.UUID, quantity int64) error {
+	s.demand[goodID] -= quantity
+
+	return nil
+}
+
+type stubPublisher struct{}
+
+func (stubPublisher) Publish(context.Context, any) error { return nil }
+
+func TestHandler_Handle_IncrementsGoodDemand(t *testing.T) {
+	t.Parallel()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	customerID, goodID := uuid.New(), uuid.New()
+
+	repo := &stubCartRepository{carts: map[uuid.UUID]*cart.State{}}
+	index := &stubGoodsIndex{demand: map[uuid.UUID]int64{}}
+
+	handler, err := NewHandler(log, stubUnitOfWork{}, repo, index, stubPublisher{})
+	require.NoError(t, err)
+
+	item, err := itemv1.NewItem(goodID, 3)
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(context.Background(), NewCommand(customerID, item)))
+
+	require.Len(t, repo.carts[customerID].GetItems(), 1)
+	require.Equal(t, map[uuid.UUID]int64{goodID: 3}, index.demand)
+}
+
+func TestHandler_Handle_InvalidItemKeepsDemand(t *testing.T) {
+	t.Parallel()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	repo := &stubCartRepository{carts: map[uuid.UUID]*cart.State{}}
+	index := &stubGoodsIndex{demand: map[uuid.UUID]int64{}}
+
+	handler, err := NewHandler(log, stubUnitOfWork{}, repo, index, stubPublisher{})
+	require.NoError(t, err)
+
+	require.Error(t, handler.Handle(context.Background(), NewCommand(uuid.New(), itemv1.Item{})))
+	require.Empty(t, index.demand)
+}
@@ -10,6 +10,8 @@ import (
 type Command struct {
 	CustomerID uuid.UUID
 	Items      []itemv1.Item
+	// GiftMessage replaces the cart gift message when set; empty keeps the current one.
+	GiftMessage string
 }
 
 // NewCommand creates a new AddItems command.
@@ -19,3 +21,10 @@ func NewCommand(customerID uuid.UUID, items []itemv1.Item) Command {
 		Items:      items,
 	}
 }
+
+// WithGiftMessage returns a copy of the command that also sets the cart gift message.
+func (c Command) WithGiftMessage(message string) Command {
+	c.GiftMessage = message
+
+	return c
+}
@@ -5,19 +5,22 @@ import (
 	"errors"
 	"log/slog"
 
+	"github.com/google/uuid"
 	"github.com/shortlink-org/go-sdk/logger"
 
 	"github.com/shortlink-org/shop/oms/internal/domain"
 	v1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
 )
 
 // Handler handles AddItems commands.
 type Handler struct {
-	log       logger.Logger
-	uow       ports.UnitOfWork
-	cartRepo  ports.CartRepository
-	publisher ports.EventPublisher
+	log        logger.Logger
+	uow        ports.UnitOfWork
+	cartRepo   ports.CartRepository
+	goodsIndex ports.CartGoodsIndex
+	publisher  ports.EventPublisher
 }
 
 // NewHandler creates a new AddItems handler.
@@ -25,69 +28,81 @@ func NewHandler(
 	log logger.Logger,
 	uow ports.UnitOfWork,
 	cartRepo ports.CartRepository,
+	goodsIndex ports.CartGoodsIndex,
 	publisher ports.EventPublisher,
 ) (*Handler, error) {
 	return &Handler{
-		log:       log,
-		uow:       uow,
-		cartRepo:  cartRepo,
-		publisher: publisher,
+		log:        log,
+		uow:        uow,
+		cartRepo:   cartRepo,
+		goodsIndex: goodsIndex,
+		publisher:  publisher,
 	}, nil
 }
 
 // Handle executes the AddItems command.
-// Pattern: Load -> Domain method -> Save
+// Pattern: Load -> Domain method -> Save, retried on optimistic-lock conflicts;
+// the goods demand index is updated after commit.
 func (h *Handler) Handle(ctx context.Context, cmd Command) error {
-	// Begin transaction
-	ctx, err := h.uow.Begin(ctx)
-	if err != nil {
-		return domain.MapInfraErr("uow.Begin", err)
-	}
-
-	defer func() {
-		rollbackErr := h.uow.Rollback(ctx)
-		if rollbackErr != nil {
-			h.log.Warn("transaction rollback failed", slog.Any("error", rollbackErr))
+	err := uow.RunWithRetry(ctx, h.uow, uow.DefaultMaxAttempts, domain.ErrVersionConflict, func(ctx context.Context) error {
+		// 1. Load aggregate (or create new if not found)
+		cart, err := h.cartRepo.Load(ctx, cmd.CustomerID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				cart = v1.New(cmd.CustomerID)
+			} else {
+				return domain.MapInfraErr("cartRepo.Load", err)
+			}
 		}
-	}()
 
-	// 1. Load aggregate (or create new if not found)
-	cart, err := h.cartRepo.Load(ctx, cmd.CustomerID)
-	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			cart = v1.New(cmd.CustomerID)
-		} else {
-			return domain.MapInfraErr("cartRepo.Load", err)
+		// 2. Call domain method (all-or-nothing)
+		addErr := cart.AddItems(cmd.Items)
+		if addErr != nil {
+			return domain.WrapValidation("cart.AddItems", addErr)
 		}
-	}
 
-	// 2. Call domain method for each item
-	for _, item := range cmd.Items {
-		addErr := cart.AddItem(item)
-		if addErr != nil {
-			return domain.WrapValidation("cart.AddItem", addErr)
+		if cmd.GiftMessage != "" {
+			if err := cart.SetGiftMessage(cmd.GiftMessage); err != nil {
+				return domain.WrapValidation("cart.SetGiftMessage", err)
+			}
 		}
-	}
 
-	// 3. Save aggregate
-	if err := h.cartRepo.Save(ctx, cart); err != nil {
-		return domain.MapInfraErr("cartRepo.Save", err)
-	}
+		// 3. Save aggregate
+		if err := h.cartRepo.Save(ctx, cart); err != nil {
+			return domain.MapInfraErr("cartRepo.Save", err)
+		}
 
-	// 4. Publish domain events to outbox (same transaction)
-	for _, event := range cart.GetDomainEvents() {
-		pubErr := h.publisher.Publish(ctx, event)
-		if pubErr != nil {
-			return domain.MapInfraErr("eventBus.Publish", pubErr)
+		// 4. Publish domain events to outbox (same transaction)
+		for _, event := range cart.GetDomainEvents() {
+			pubErr := h.publisher.Publish(ctx, event)
+			if pubErr != nil {
+				return domain.MapInfraErr("eventBus.Publish", pubErr)
+			}
 		}
-	}
 
-	cart.ClearDomainEvents()
+		cart.ClearDomainEvents()
 
-	// 5. Commit transaction
-	if err := h.uow.Commit(ctx); err != nil {
-		return domain.MapInfraErr("uow.Commit", err)
+		return nil
+	})
+	if err != nil {
+		return domain.MapInfraErr("uow.RunWithRetry", err)
+	}
+
+	// The index is a projection: a failure only skews the demand signal until the cart changes again.
+	for _, item := range cmd.Items {
+		indexErr := h.goodsIndex.IncrementGoodDemand(ctx, item.GetGoodId(), cmd.CustomerID, int64(item.GetQuantity()))
+		if indexErr != nil {
+			h.logIndexFailure(cmd.CustomerID, item.GetGoodId(), indexErr)
+		}
 	}
 
 	return nil
 }
+
+// logIndexFailure reports a goods index update that did not apply.
+func (h *Handler) logIndexFailure(customerID, goodID uuid.UUID, err error) {
+	h.log.Warn("failed to update cart goods index",
+		slog.String("customer_id", customerID.String()),
+		slog.String("good_id", goodID.String()),
+		slog.Any("error", err))
+}
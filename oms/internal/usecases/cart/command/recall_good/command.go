@@ -0,0 +1,17 @@
+package recall_good
+
+import (
+	"github.com/google/uuid"
+)
+
+// Command represents a command to remove a recalled good from every cart that holds it.
+type Command struct {
+	GoodID uuid.UUID
+}
+
+// NewCommand creates a new RecallGood command.
+func NewCommand(goodID uuid.UUID) Command {
+	return Command{
+		GoodID: goodID,
+	}
+}
@@ -0,0 +1,180 @@
+package recall_good
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/shortlink-org/go-sdk/logger"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
+)
+
+// batchSize bounds the number of carts processed between cancellation checks.
+const batchSize = 100
+
+var errMissingGoodID = errors.New("good id is required")
+
+// Result reports the outcome of a recall.
+type Result struct {
+	// Removed is the number of carts the good was removed from
+	Removed int
+	// Failed lists the customers whose cart could not be updated
+	Failed []uuid.UUID
+}
+
+// Handler handles RecallGood commands.
+type Handler struct {
+	log        logger.Logger
+	uow        ports.UnitOfWork
+	cartRepo   ports.CartRepository
+	goodsIndex ports.CartGoodsIndex
+	publisher  ports.EventPublisher
+}
+
+// NewHandler creates a new RecallGood handler.
+func NewHandler(
+	log logger.Logger,
+	uow ports.UnitOfWork,
+	cartRepo ports.CartRepository,
+	goodsIndex ports.CartGoodsIndex,
+	publisher ports.EventPublisher,
+) (*Handler, error) {
+	return &Handler{
+		log:        log,
+		uow:        uow,
+		cartRepo:   cartRepo,
+		goodsIndex: goodsIndex,
+		publisher:  publisher,
+	}, nil
+}
+
+// Handle removes the good from every cart the index lists for it.
+// Carts are processed in batches, each cart in its own transaction retried on optimistic-lock
+// conflicts. A failing cart does not stop the recall: failures are collected in Result.Failed
+// and returned joined once every cart was attempted. Cancellation is checked between batches.
+func (h *Handler) Handle(ctx context.Context, cmd Command) (Result, error) {
+	if cmd.GoodID == uuid.Nil {
+		return Result{}, domain.WrapValidation("RecallGood", errMissingGoodID)
+	}
+
+	customerIDs, err := h.goodsIndex.GetCustomersWithGood(ctx, cmd.GoodID)
+	if err != nil {
+		return Result{}, domain.MapInfraErr("goodsIndex.GetCustomersWithGood", err)
+	}
+
+	var (
+		result Result
+		errs   []error
+	)
+
+	for batch := range slices.Chunk(customerIDs, batchSize) {
+		if err := ctx.Err(); err != nil {
+			return result, errors.Join(append(errs, err)...)
+		}
+
+		for _, customerID := range batch {
+			removed, err := h.recall(ctx, customerID, cmd.GoodID)
+			if err != nil {
+				result.Failed = append(result.Failed, customerID)
+				errs = append(errs, fmt.Errorf("recall good from cart %s: %w", customerID, err))
+
+				continue
+			}
+
+			if removed {
+				result.Removed++
+			}
+		}
+	}
+
+	h.log.Info("Recalled good from carts",
+		slog.String("good_id", cmd.GoodID.String()),
+		slog.Int("carts", len(customerIDs)),
+		slog.Int("removed", result.Removed),
+		slog.Int("failed", len(result.Failed)))
+
+	return result, errors.Join(errs...)
+}
+
+// recall removes the good from a single cart and reports whether the cart held it.
+// Pattern: Load -> Domain method -> Save -> Publish event, then the index is updated after commit.
+func (h *Handler) recall(ctx context.Context, customerID, goodID uuid.UUID) (bool, error) {
+	var removed bool
+
+	err := uow.RunWithRetry(ctx, h.uow, uow.DefaultMaxAttempts, domain.ErrVersionConflict, func(ctx context.Context) error {
+		removed = false
+
+		// 1. Load aggregate; a missing cart only leaves a stale index entry
+		cart, err := h.cartRepo.Load(ctx, customerID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil
+			}
+
+			return domain.MapInfraErr("cartRepo.Load", err)
+		}
+
+		var quantity int32
+
+		for _, item := range cart.GetItems() {
+			if item.GetGoodId() == goodID {
+				quantity = item.GetQuantity()
+
+				break
+			}
+		}
+
+		if quantity == 0 {
+			return nil
+		}
+
+		// 2. Call domain method: removing the full quantity drops the line
+		item, err := itemv1.NewItem(goodID, quantity)
+		if err != nil {
+			return fmt.Errorf("failed to construct cart item: %w", err)
+		}
+
+		if err := cart.RemoveItem(item); err != nil {
+			return domain.WrapValidation("cart.RemoveItem", err)
+		}
+
+		// 3. Save aggregate
+		if err := h.cartRepo.Save(ctx, cart); err != nil {
+			return domain.MapInfraErr("cartRepo.Save", err)
+		}
+
+		// 4. Publish domain events to outbox (same transaction)
+		for _, event := range cart.GetDomainEvents() {
+			if err := h.publisher.Publish(ctx, event); err != nil {
+				return domain.MapInfraErr("eventBus.Publish", err)
+			}
+		}
+
+		cart.ClearDomainEvents()
+
+		removed = true
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// The cart no longer holds the good (or never did): drop it from the index.
+	// A failure leaves a stale entry that the next recall or stock event cleans up.
+	if err := h.goodsIndex.RemoveGoodFromCart(ctx, goodID, customerID); err != nil {
+		h.log.Warn("failed to update cart goods index",
+			slog.String("customer_id", customerID.String()),
+			slog.String("good_id", goodID.String()),
+			slog.Any("error", err))
+	}
+
+	return removed, nil
+}
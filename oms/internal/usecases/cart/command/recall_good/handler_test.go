@@ -0,0 +1,176 @@
+package recall_good
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	cart "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+)
+
+var errSaveFailed = errors.New("save failed")
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(ctx context.Context) (context.Context, error) { return ctx, nil }
+func (stubUnitOfWork) Commit(context.Context) error                       { return nil }
+func (stubUnitOfWork) Rollback(context.Context) error                     { return nil }
+
+// stubCartRepository keeps carts in memory and fails Save for the customers in saveErrs.
+type stubCartRepository struct {
+	carts    map[uuid.UUID]*cart.State
+	saveErrs map[uuid.UUID]error
+}
+
+func (s *stubCartRepository) Load(_ context.Context, customerID uuid.UUID) (*cart.State, error) {
+	state, ok := s.carts[customerID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+
+	return state, nil
+}
+
+func (s *stubCartRepository) Save(_ context.Context, state *cart.State) error {
+	if err := s.saveErrs[state.GetCustomerId()]; err != nil {
+		return err
+	}
+
+	s.carts[state.GetCustomerId()] = state
+
+	return nil
+}
+
+// stubGoodsIndex maps goods to the customers holding them.
+type stubGoodsIndex struct {
+	customers map[uuid.UUID][]uuid.UUID
+}
+
+func (s *stubGoodsIndex) AddGoodToCart(context.Context, uuid.UUID, uuid.UUID) error { return nil }
+
+func (s *stubGoodsIndex) AddGoodsToCart(context.Context, []uuid.UUID, uuid.UUID) error { return nil }
+
+func (s *stubGoodsIndex) RemoveGoodFromCart(_ context.Context, goodID, customerID uuid.UUID) error {
+	customers := s.customers[goodID]
+	for i, id := range customers {
+		if id == customerID {
+			s.customers[goodID] = append(customers[:i], customers[i+1:]...)
+
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *stubGoodsIndex) GetCustomersWithGood(_ context.Context, goodID uuid.UUID) ([]uuid.UUID, error) {
+	return append([]uuid.UUID(nil), s.customers[goodID]...), nil
+}
+
+func (s *stubGoodsIndex) IncrementGoodDemand(context.Context, uuid.UUID, uuid.UUID, int64) error {
+	return nil
+}
+
+func (s *stubGoodsIndex) DecrementGoodDemand(context.Context, uuid.UUID, uuid.UUID, int64) error {
+	return nil
+}
+
+func (s *stubGoodsIndex) GetGoodDemand(context.Context, uuid.UUID) (int64, error) { return 0, nil }
+
+type recordingPublisher struct {
+	events []any
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event any) error {
+	p.events = append(p.events, event)
+
+	return nil
+}
+
+func newCart(t *testing.T, customerID uuid.UUID, goodIDs ...uuid.UUID) *cart.State {
+	t.Helper()
+
+	state := cart.New(customerID)
+
+	for _, goodID := range goodIDs {
+		item, err := itemv1.NewItem(goodID, 2)
+		require.NoError(t, err)
+		require.NoError(t, state.AddItem(item))
+	}
+
+	state.ClearDomainEvents()
+
+	return state
+}
+
+func hasGood(state *cart.State, goodID uuid.UUID) bool {
+	for _, item := range state.GetItems() {
+		if item.GetGoodId() == goodID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestHandler_Handle_RemovesGoodFromAllCarts(t *testing.T) {
+	t.Parallel()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	recalled, kept := uuid.New(), uuid.New()
+	alice, bob, carol, gone := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	repo := &stubCartRepository{
+		carts: map[uuid.UUID]*cart.State{
+			alice: newCart(t, alice, recalled, kept),
+			bob:   newCart(t, bob, recalled),
+			carol: newCart(t, carol, recalled),
+		},
+		saveErrs: map[uuid.UUID]error{carol: errSaveFailed},
+	}
+	index := &stubGoodsIndex{customers: map[uuid.UUID][]uuid.UUID{
+		// gone has a stale index entry but no cart
+		recalled: {alice, bob, carol, gone},
+	}}
+	publisher := &recordingPublisher{}
+
+	handler, err := NewHandler(log, stubUnitOfWork{}, repo, index, publisher)
+	require.NoError(t, err)
+
+	result, err := handler.Handle(context.Background(), NewCommand(recalled))
+
+	// The failing cart is reported without stopping the recall
+	require.ErrorIs(t, err, errSaveFailed)
+	require.ErrorContains(t, err, carol.String())
+	require.Equal(t, 2, result.Removed)
+	require.Equal(t, []uuid.UUID{carol}, result.Failed)
+
+	require.False(t, hasGood(repo.carts[alice], recalled))
+	require.True(t, hasGood(repo.carts[alice], kept), "other goods stay in the cart")
+	require.False(t, hasGood(repo.carts[bob], recalled))
+	require.Len(t, publisher.events, 2)
+
+	// Updated and stale entries leave the index; the failed cart stays for a retry
+	require.Equal(t, []uuid.UUID{carol}, index.customers[recalled])
+}
+
+func TestHandler_Handle_RequiresGoodID(t *testing.T) {
+	t.Parallel()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	handler, err := NewHandler(log, stubUnitOfWork{}, &stubCartRepository{}, &stubGoodsIndex{}, &recordingPublisher{})
+	require.NoError(t, err)
+
+	_, err = handler.Handle(context.Background(), NewCommand(uuid.Nil))
+	require.ErrorIs(t, err, errMissingGoodID)
+}
@@ -5,18 +5,21 @@ import (
 	"errors"
 	"log/slog"
 
+	"github.com/google/uuid"
 	"github.com/shortlink-org/go-sdk/logger"
 
 	"github.com/shortlink-org/shop/oms/internal/domain"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
 )
 
 // Handler handles RemoveItem commands.
 type Handler struct {
-	log       logger.Logger
-	uow       ports.UnitOfWork
-	cartRepo  ports.CartRepository
-	publisher ports.EventPublisher
+	log        logger.Logger
+	uow        ports.UnitOfWork
+	cartRepo   ports.CartRepository
+	goodsIndex ports.CartGoodsIndex
+	publisher  ports.EventPublisher
 }
 
 // NewHandler creates a new RemoveItem handler.
@@ -24,66 +27,83 @@ func NewHandler(
 	log logger.Logger,
 	uow ports.UnitOfWork,
 	cartRepo ports.CartRepository,
+	goodsIndex ports.CartGoodsIndex,
 	publisher ports.EventPublisher,
 ) (*Handler, error) {
 	return &Handler{
-		log:       log,
-		uow:       uow,
-		cartRepo:  cartRepo,
-		publisher: publisher,
+		log:        log,
+		uow:        uow,
+		cartRepo:   cartRepo,
+		goodsIndex: goodsIndex,
+		publisher:  publisher,
 	}, nil
 }
 
 // Handle executes the RemoveItem command.
-// Pattern: Load -> Domain method -> Save
+// Pattern: Load -> Domain method -> Save, retried on optimistic-lock conflicts;
+// the goods demand index is updated after commit.
 func (h *Handler) Handle(ctx context.Context, cmd Command) error {
-	// Begin transaction
-	ctx, err := h.uow.Begin(ctx)
-	if err != nil {
-		return domain.MapInfraErr("uow.Begin", err)
-	}
+	var removed bool
+
+	err := uow.RunWithRetry(ctx, h.uow, uow.DefaultMaxAttempts, domain.ErrVersionConflict, func(ctx context.Context) error {
+		removed = false
+
+		// 1. Load aggregate
+		cart, err := h.cartRepo.Load(ctx, cmd.CustomerID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil
+			}
 
-	defer func() {
-		rollbackErr := h.uow.Rollback(ctx)
-		if rollbackErr != nil {
-			h.log.Warn("transaction rollback failed", slog.Any("error", rollbackErr))
+			return domain.MapInfraErr("cartRepo.Load", err)
 		}
-	}()
 
-	// 1. Load aggregate
-	cart, err := h.cartRepo.Load(ctx, cmd.CustomerID)
-	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			return nil
+		// 2. Call domain method (business logic)
+		if err := cart.RemoveItem(cmd.Item); err != nil {
+			return domain.WrapValidation("cart.RemoveItem", err)
 		}
 
-		return domain.MapInfraErr("cartRepo.Load", err)
-	}
+		// 3. Save aggregate
+		if err := h.cartRepo.Save(ctx, cart); err != nil {
+			return domain.MapInfraErr("cartRepo.Save", err)
+		}
 
-	// 2. Call domain method (business logic)
-	if err := cart.RemoveItem(cmd.Item); err != nil {
-		return domain.WrapValidation("cart.RemoveItem", err)
-	}
+		// 4. Publish domain events to outbox (same transaction)
+		for _, event := range cart.GetDomainEvents() {
+			err := h.publisher.Publish(ctx, event)
+			if err != nil {
+				return domain.MapInfraErr("eventBus.Publish", err)
+			}
+		}
 
-	// 3. Save aggregate
-	if err := h.cartRepo.Save(ctx, cart); err != nil {
-		return domain.MapInfraErr("cartRepo.Save", err)
-	}
+		cart.ClearDomainEvents()
 
-	// 4. Publish domain events to outbox (same transaction)
-	for _, event := range cart.GetDomainEvents() {
-		err := h.publisher.Publish(ctx, event)
-		if err != nil {
-			return domain.MapInfraErr("eventBus.Publish", err)
-		}
+		removed = true
+
+		return nil
+	})
+	if err != nil {
+		return domain.MapInfraErr("uow.RunWithRetry", err)
 	}
 
-	cart.ClearDomainEvents()
+	if !removed {
+		return nil
+	}
 
-	// 5. Commit transaction
-	if err := h.uow.Commit(ctx); err != nil {
-		return domain.MapInfraErr("uow.Commit", err)
+	// The index caps the release at the units the customer holds, so removing more than
+	// the cart had does not skew the demand. A failure only skews it until the cart changes again.
+	indexErr := h.goodsIndex.DecrementGoodDemand(ctx, cmd.Item.GetGoodId(), cmd.CustomerID, int64(cmd.Item.GetQuantity()))
+	if indexErr != nil {
+		h.logIndexFailure(cmd.CustomerID, cmd.Item.GetGoodId(), indexErr)
 	}
 
 	return nil
 }
+
+// logIndexFailure reports a goods index update that did not apply.
+func (h *Handler) logIndexFailure(customerID, goodID uuid.UUID, err error) {
+	h.log.Warn("failed to update cart goods index",
+		slog.String("customer_id", customerID.String()),
+		slog.String("good_id", goodID.String()),
+		slog.Any("error", err))
+}
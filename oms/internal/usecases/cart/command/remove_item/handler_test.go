@@ -0,0 +1,111 @@
+package remove_item
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	cart "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(ctx context.Context) (context.Context, error) { return ctx, nil }
+func (stubUnitOfWork) Commit(context.Context) error                       { return nil }
+func (stubUnitOfWork) Rollback(context.Context) error                     { return nil }
+
+type stubCartRepository struct {
+	carts map[uuid.UUID]*cart.State
+}
+
+func (s *stubCartRepository) Load(_ context.Context, customerID uuid.UUID) (*cart.State, error) {
+	state, ok := s.carts[customerID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+
+	return state, nil
+}
+
+func (s *stubCartRepository) Save(_ context.Context, state *cart.State) error {
+	s.carts[state.GetCustomerId()] = state
+
+	return nil
+}
+
+// stubGoodsIndex records the demand change per good; other index calls are not expected.
+type stubGoodsIndex struct {
+	ports.CartGoodsIndex
+
+	demand map[uuid.UUID]int64
+}
+
+func (s *stubGoodsIndex) IncrementGoodDemand(_ context.Context, goodID, _ uuid.UUID, quantity int64) error {
+	s.demand[goodID] += quantity
+
+	return nil
+}
+
+func (s *stubGoodsIndex) DecrementGoodDemand(_ context.Context, goodID, _ uuid.UUID, quantity int64) error {
+	s.demand[goodID] -= quantity
+
+	return nil
+}
+
+type stubPublisher struct{}
+
+func (stubPublisher) Publish(context.Context, any) error { return nil }
+
+func TestHandler_Handle_ReleasesGoodDemand(t *testing.T) {
+	t.Parallel()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	customerID, goodID := uuid.New(), uuid.New()
+
+	state := cart.New(customerID)
+
+	item, err := itemv1.NewItem(goodID, 5)
+	require.NoError(t, err)
+	require.NoError(t, state.AddItem(item))
+
+	repo := &stubCartRepository{carts: map[uuid.UUID]*cart.State{customerID: state}}
+	index := &stubGoodsIndex{demand: map[uuid.UUID]int64{}}
+
+	handler, err := NewHandler(log, stubUnitOfWork{}, repo, index, stubPublisher{})
+	require.NoError(t, err)
+
+	removed, err := itemv1.NewItem(goodID, 2)
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(context.Background(), NewCommand(customerID, removed)))
+
+	require.Equal(t, int32(3), repo.carts[customerID].GetItems()[0].GetQuantity())
+	require.Equal(t, map[uuid.UUID]int64{goodID: -2}, index.demand)
+}
+
+func TestHandler_Handle_MissingCartKeepsDemand(t *testing.T) {
+	t.Parallel()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	repo := &stubCartRepository{carts: map[uuid.UUID]*cart.State{}}
+	index := &stubGoodsIndex{demand: map[uuid.UUID]int64{}}
+
+	handler, err := NewHandler(log, stubUnitOfWork{}, repo, index, stubPublisher{})
+	require.NoError(t, err)
+
+	item, err := itemv1.NewItem(uuid.New(), 1)
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(context.Background(), NewCommand(uuid.New(), item)))
+	require.Empty(t, index.demand)
+}
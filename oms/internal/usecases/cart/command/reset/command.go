@@ -2,11 +2,15 @@ package reset
 
 import (
 	"github.com/google/uuid"
+
+	cart "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
 )
 
 // Command represents a command to reset (clear) a cart.
 type Command struct {
 	CustomerID uuid.UUID
+	// Abandoned marks a reset by the session timeout rather than by the customer.
+	Abandoned bool
 }
 
 // NewCommand creates a new Reset command.
@@ -15,3 +19,21 @@ func NewCommand(customerID uuid.UUID) Command {
 		CustomerID: customerID,
 	}
 }
+
+// NewAbandonCommand creates a Reset command for a cart abandoned without checkout.
+func NewAbandonCommand(customerID uuid.UUID) Command {
+	return Command{
+		CustomerID: customerID,
+		Abandoned:  true,
+	}
+}
+
+// Reason returns the reset reason recorded on the cart: ABANDONED for the session timeout,
+// ADMIN for an explicit reset through the Cart API.
+func (c Command) Reason() cart.ResetReason {
+	if c.Abandoned {
+		return cart.ResetReasonAbandoned
+	}
+
+	return cart.ResetReasonAdmin
+}
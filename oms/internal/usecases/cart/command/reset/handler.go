@@ -8,15 +8,18 @@ import (
 	"github.com/shortlink-org/go-sdk/logger"
 
 	"github.com/shortlink-org/shop/oms/internal/domain"
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
 )
 
 // Handler handles Reset commands.
 type Handler struct {
-	log       logger.Logger
-	uow       ports.UnitOfWork
-	cartRepo  ports.CartRepository
-	publisher ports.EventPublisher
+	log        logger.Logger
+	uow        ports.UnitOfWork
+	cartRepo   ports.CartRepository
+	goodsIndex ports.CartGoodsIndex
+	publisher  ports.EventPublisher
 }
 
 // NewHandler creates a new Reset handler.
@@ -24,64 +27,75 @@ func NewHandler(
 	log logger.Logger,
 	uow ports.UnitOfWork,
 	cartRepo ports.CartRepository,
+	goodsIndex ports.CartGoodsIndex,
 	publisher ports.EventPublisher,
 ) (*Handler, error) {
 	return &Handler{
-		log:       log,
-		uow:       uow,
-		cartRepo:  cartRepo,
-		publisher: publisher,
+		log:        log,
+		uow:        uow,
+		cartRepo:   cartRepo,
+		goodsIndex: goodsIndex,
+		publisher:  publisher,
 	}, nil
 }
 
 // Handle executes the Reset command.
-// Pattern: Load -> Domain method -> Save
+// Pattern: Load -> Domain method -> Save, retried on optimistic-lock conflicts;
+// the goods demand index is updated after commit.
 func (h *Handler) Handle(ctx context.Context, cmd Command) error {
-	// Begin transaction
-	ctx, err := h.uow.Begin(ctx)
-	if err != nil {
-		return domain.MapInfraErr("uow.Begin", err)
-	}
+	var released []itemv1.Item
+
+	err := uow.RunWithRetry(ctx, h.uow, uow.DefaultMaxAttempts, domain.ErrVersionConflict, func(ctx context.Context) error {
+		released = nil
+
+		// 1. Load aggregate
+		cart, err := h.cartRepo.Load(ctx, cmd.CustomerID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				// Cart doesn't exist, nothing to reset
+				return nil
+			}
 
-	defer func() {
-		rollbackErr := h.uow.Rollback(ctx)
-		if rollbackErr != nil {
-			h.log.Warn("transaction rollback failed", slog.Any("error", rollbackErr))
+			return domain.MapInfraErr("cartRepo.Load", err)
 		}
-	}()
 
-	// 1. Load aggregate
-	cart, err := h.cartRepo.Load(ctx, cmd.CustomerID)
-	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			// Cart doesn't exist, nothing to reset
-			return nil
+		// 2. Call domain method (business logic); keep the items to release their demand
+		items := cart.GetItems()
+		cart.ResetWithReason(cmd.Reason())
+
+		// 3. Save aggregate
+		if err := h.cartRepo.Save(ctx, cart); err != nil {
+			return domain.MapInfraErr("cartRepo.Save", err)
 		}
 
-		return domain.MapInfraErr("cartRepo.Load", err)
-	}
+		// 4. Publish domain events to outbox (same transaction)
+		for _, event := range cart.GetDomainEvents() {
+			pubErr := h.publisher.Publish(ctx, event)
+			if pubErr != nil {
+				return domain.MapInfraErr("eventBus.Publish", pubErr)
+			}
+		}
 
-	// 2. Call domain method (business logic)
-	cart.Reset()
+		cart.ClearDomainEvents()
 
-	// 3. Save aggregate
-	if err := h.cartRepo.Save(ctx, cart); err != nil {
-		return domain.MapInfraErr("cartRepo.Save", err)
-	}
+		released = items
 
-	// 4. Publish domain events to outbox (same transaction)
-	for _, event := range cart.GetDomainEvents() {
-		pubErr := h.publisher.Publish(ctx, event)
-		if pubErr != nil {
-			return domain.MapInfraErr("eventBus.Publish", pubErr)
-		}
+		return nil
+	})
+	if err != nil {
+		return domain.MapInfraErr("uow.RunWithRetry", err)
 	}
 
-	cart.ClearDomainEvents()
-
-	// 5. Commit transaction
-	if err := h.uow.Commit(ctx); err != nil {
-		return domain.MapInfraErr("uow.Commit", err)
+	// Releasing the last units also drops the good from the cart index.
+	// A failure only skews the demand signal until the cart changes again.
+	for _, item := range released {
+		indexErr := h.goodsIndex.DecrementGoodDemand(ctx, item.GetGoodId(), cmd.CustomerID, int64(item.GetQuantity()))
+		if indexErr != nil {
+			h.log.Warn("failed to update cart goods index",
+				slog.String("customer_id", cmd.CustomerID.String()),
+				slog.String("good_id", item.GetGoodId().String()),
+				slog.Any("error", indexErr))
+		}
 	}
 
 	return nil
@@ -0,0 +1,98 @@
+package reset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	cart "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+)
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(ctx context.Context) (context.Context, error) { return ctx, nil }
+func (stubUnitOfWork) Commit(context.Context) error                       { return nil }
+func (stubUnitOfWork) Rollback(context.Context) error                     { return nil }
+
+type stubCartRepository struct {
+	carts map[uuid.UUID]*cart.State
+}
+
+func (s *stubCartRepository) Load(_ context.Context, customerID uuid.UUID) (*cart.State, error) {
+	state, ok := s.carts[customerID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+
+	return state, nil
+}
+
+func (s *stubCartRepository) Save(_ context.Context, state *cart.State) error {
+	s.carts[state.GetCustomerId()] = state
+
+	return nil
+}
+
+// stubGoodsIndex records the demand released per good.
+type stubGoodsIndex struct {
+	released map[uuid.UUID]int64
+}
+
+func (s *stubGoodsIndex) AddGoodToCart(context.Context, uuid.UUID, uuid.UUID) error { return nil }
+
+func (s *stubGoodsIndex) AddGoodsToCart(context.Context, []uuid.UUID, uuid.UUID) error { return nil }
+
+func (s *stubGoodsIndex) RemoveGoodFromCart(context.Context, uuid.UUID, uuid.UUID) error { return nil }
+
+func (s *stubGoodsIndex) GetCustomersWithGood(context.Context, uuid.UUID) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (s *stubGoodsIndex) IncrementGoodDemand(context.Context, uuid.UUID, uuid.UUID, int64) error {
+	return nil
+}
+
+func (s *stubGoodsIndex) DecrementGoodDemand(_ context.Context, goodID, _ uuid.UUID, quantity int64) error {
+	s.released[goodID] += quantity
+
+	return nil
+}
+
+func (s *stubGoodsIndex) GetGoodDemand(context.Context, uuid.UUID) (int64, error) { return 0, nil }
+
+type stubPublisher struct{}
+
+func (stubPublisher) Publish(context.Context, any) error { return nil }
+
+func TestHandler_Handle_ReleasesGoodDemand(t *testing.T) {
+	t.Parallel()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	customerID, goodA, goodB := uuid.New(), uuid.New(), uuid.New()
+
+	state := cart.New(customerID)
+
+	itemA, err := itemv1.NewItem(goodA, 2)
+	require.NoError(t, err)
+	itemB, err := itemv1.NewItem(goodB, 5)
+	require.NoError(t, err)
+	require.NoError(t, state.AddItems([]itemv1.Item{itemA, itemB}))
+
+	repo := &stubCartRepository{carts: map[uuid.UUID]*cart.State{customerID: state}}
+	index := &stubGoodsIndex{released: map[uuid.UUID]int64{}}
+
+	handler, err := NewHandler(log, stubUnitOfWork{}, repo, index, stubPublisher{})
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(context.Background(), NewCommand(customerID)))
+
+	require.Empty(t, repo.carts[customerID].GetItems())
+	require.Equal(t, map[uuid.UUID]int64{goodA: 2, goodB: 5}, index.released)
+}
@@ -0,0 +1,17 @@
+package complete
+
+import (
+	"github.com/google/uuid"
+)
+
+// Command represents a command to complete an order.
+type Command struct {
+	OrderID uuid.UUID
+}
+
+// NewCommand creates a new CompleteOrder command.
+func NewCommand(orderID uuid.UUID) Command {
+	return Command{
+		OrderID: orderID,
+	}
+}
@@ -0,0 +1,80 @@
+package complete
+
+import (
+	"context"
+
+	"github.com/shortlink-org/go-sdk/logger"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
+)
+
+// Handler handles CompleteOrder commands.
+type Handler struct {
+	log       logger.Logger
+	uow       ports.UnitOfWork
+	orderRepo ports.OrderRepository
+	publisher ports.EventPublisher
+}
+
+// NewHandler creates a new CompleteOrder handler.
+func NewHandler(
+	log logger.Logger,
+	uow ports.UnitOfWork,
+	orderRepo ports.OrderRepository,
+	publisher ports.EventPublisher,
+) (*Handler, error) {
+	return &Handler{
+		log:       log,
+		uow:       uow,
+		orderRepo: orderRepo,
+		publisher: publisher,
+	}, nil
+}
+
+// Handle executes the CompleteOrder command.
+// Pattern: Load -> Domain method -> Save -> Publish event, retried on optimistic-lock conflicts.
+func (h *Handler) Handle(ctx context.Context, cmd Command) error {
+	var order *orderv1.OrderState
+
+	err := uow.RunWithRetry(ctx, h.uow, uow.DefaultMaxAttempts, domain.ErrVersionConflict, func(ctx context.Context) error {
+		// 1. Load order aggregate
+		var err error
+
+		order, err = h.orderRepo.Load(ctx, cmd.OrderID)
+		if err != nil {
+			return domain.MapInfraErr("orderRepo.Load", err)
+		}
+
+		// 2. Apply business logic (complete order)
+		err = order.CompleteOrder()
+		if err != nil {
+			return err
+		}
+
+		// 3. Persist to database (version-checked)
+		err = h.orderRepo.Save(ctx, order)
+		if err != nil {
+			return domain.MapInfraErr("orderRepo.Save", err)
+		}
+
+		// 4. Publish domain events to outbox (same transaction)
+		for _, event := range order.GetDomainEvents() {
+			pubErr := h.publisher.Publish(ctx, event)
+			if pubErr != nil {
+				return domain.MapInfraErr("eventBus.Publish", pubErr)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	order.ClearDomainEvents()
+
+	return nil
+}
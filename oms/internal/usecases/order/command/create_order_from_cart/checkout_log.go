@@ -0,0 +1,49 @@
+package create_order_from_cart
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+// checkoutLog logs on behalf of one checkout. Every line carries the customer ID, the order ID once the
+// order exists, and the trace and span IDs the logger's WithContext methods take from ctx, so the lines
+// of one checkout can be correlated with each other and with its trace.
+type checkoutLog struct {
+	log        logger.Logger
+	customerID uuid.UUID
+	orderID    uuid.UUID
+}
+
+// logFor starts the checkout log of a customer.
+func (h *Handler) logFor(customerID uuid.UUID) checkoutLog {
+	return checkoutLog{log: h.log, customerID: customerID}
+}
+
+// withOrder returns the log with the order ID added to every line.
+func (l checkoutLog) withOrder(orderID uuid.UUID) checkoutLog {
+	l.orderID = orderID
+
+	return l
+}
+
+func (l checkoutLog) Info(ctx context.Context, msg string, fields ...slog.Attr) {
+	l.log.InfoWithContext(ctx, msg, l.enrich(fields)...)
+}
+
+func (l checkoutLog) Warn(ctx context.Context, msg string, fields ...slog.Attr) {
+	l.log.WarnWithContext(ctx, msg, l.enrich(fields)...)
+}
+
+func (l checkoutLog) enrich(fields []slog.Attr) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(fields)+2)
+	attrs = append(attrs, slog.String("customer_id", l.customerID.String()))
+
+	if l.orderID != uuid.Nil {
+		attrs = append(attrs, slog.String("order_id", l.orderID.String()))
+	}
+
+	return append(attrs, fields...)
+}
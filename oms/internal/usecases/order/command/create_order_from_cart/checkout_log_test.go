@@ -0,0 +1,130 @@
+package create_order_from_cart
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	cartv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+	itemsv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/items/v1"
+	orderDomain "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/create_order_from_cart/mocks"
+)
+
+type ctxKey struct{}
+
+// logLine is a line recorded by captureLogger; ctx is nil for lines logged without a context.
+type logLine struct {
+	ctx   context.Context //nolint:containedctx // recorded to assert the context reaches the logger
+	msg   string
+	attrs map[string]string
+}
+
+// captureLogger records every line logged through it.
+type captureLogger struct {
+	mu    sync.Mutex
+	lines []logLine
+}
+
+func (c *captureLogger) record(ctx context.Context, msg string, fields []slog.Attr) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	attrs := make(map[string]string, len(fields))
+	for _, field := range fields {
+		attrs[field.Key] = field.Value.String()
+	}
+
+	c.lines = append(c.lines, logLine{ctx: ctx, msg: msg, attrs: attrs})
+}
+
+func (c *captureLogger) Error(msg string, fields ...slog.Attr) { c.record(nil, msg, fields) }
+func (c *captureLogger) Warn(msg string, fields ...slog.Attr)  { c.record(nil, msg, fields) }
+func (c *captureLogger) Info(msg string, fields ...slog.Attr)  { c.record(nil, msg, fields) }
+func (c *captureLogger) Debug(msg string, fields ...slog.Attr) { c.record(nil, msg, fields) }
+
+func (c *captureLogger) ErrorWithContext(ctx context.Context, msg string, fields ...slog.Attr) {
+	c.record(ctx, msg, fields)
+}
+
+func (c *captureLogger) WarnWithContext(ctx context.Context, msg string, fields ...slog.Attr) {
+	c.record(ctx, msg, fields)
+}
+
+func (c *captureLogger) InfoWithContext(ctx context.Context, msg string, fields ...slog.Attr) {
+	c.record(ctx, msg, fields)
+}
+
+func (c *captureLogger) DebugWithContext(ctx context.Context, msg string, fields ...slog.Attr) {
+	c.record(ctx, msg, fields)
+}
+
+func (*captureLogger) Close() error { return nil }
+
+func TestHandler_Handle_LogsCheckoutContext(t *testing.T) {
+	log := &captureLogger{}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "checkout-request")
+	customerID := uuid.New()
+
+	item, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	mockUoW := mocks.NewMockUnitOfWork(t)
+	mockCartRepo := mocks.NewMockCartRepository(t)
+	mockOrderRepo := mocks.NewMockOrderRepository(t)
+	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
+	mockPricer := mocks.NewMockPricerClient(t)
+	mockLimiter := mocks.NewMockRateLimiter(t)
+
+	// Both degraded paths log a warning before the checkout succeeds.
+	mockLimiter.EXPECT().Allow(mock.Anything, customerID.String()).
+		Return(ports.RateLimitDecision{}, errors.New("redis is down"))
+	mockPricer.EXPECT().CalculateTotal(mock.Anything, mock.Anything).Return(nil, errors.New("pricer unavailable"))
+
+	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+	mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
+	mockCartRepo.EXPECT().Load(mock.Anything, customerID).
+		Return(cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, ""), nil)
+	mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil,
+		mockPricer, mockLimiter, testDeliveryFees, Config{PricerFailurePolicy: PricerFailurePolicyFallbackToCart})
+	require.NoError(t, err)
+
+	result, err := handler.Handle(ctx, NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+	require.NoError(t, err)
+	require.NotNil(t, result.Order)
+
+	require.Len(t, log.lines, 3)
+	assert.Equal(t, "checkout rate limiter unavailable, allowing checkout", log.lines[0].msg)
+	assert.Equal(t, "pricer unavailable, pricing checkout from cart prices without tax", log.lines[1].msg)
+	assert.Equal(t, "order created from cart", log.lines[2].msg)
+
+	for _, line := range log.lines {
+		// The context carries the trace the logger correlates the line with.
+		require.NotNil(t, line.ctx, line.msg)
+		assert.Equal(t, "checkout-request", line.ctx.Value(ctxKey{}), line.msg)
+		assert.Equal(t, customerID.String(), line.attrs["customer_id"], line.msg)
+	}
+
+	// The order ID is only known once the order exists.
+	assert.NotContains(t, log.lines[0].attrs, "order_id")
+	assert.NotContains(t, log.lines[1].attrs, "order_id")
+	assert.Equal(t, result.Order.GetOrderID().String(), log.lines[2].attrs["order_id"])
+	assert.Contains(t, log.lines[0].attrs, "error")
+}
@@ -7,15 +7,27 @@ import (
 )
 
 // Command represents a command to create an order from a cart.
+// DeliveryInfo is required for DELIVERY and must be nil for PICKUP.
 type Command struct {
-	CustomerID   uuid.UUID
-	DeliveryInfo *orderDomain.DeliveryInfo
+	CustomerID      uuid.UUID
+	FulfillmentType orderDomain.FulfillmentType
+	DeliveryInfo    *orderDomain.DeliveryInfo
+	// DryRun prices the cart, delivery fee included, without creating the order or clearing the cart.
+	DryRun bool
+	// TaxExemptionCode marks a tax-exempt customer (e.g. B2B or charity); no tax is charged
+	// when it is set. Empty means the order is taxed as usual.
+	TaxExemptionCode string
 }
 
 // NewCommand creates a new CreateOrderFromCart command.
-func NewCommand(customerID uuid.UUID, deliveryInfo *orderDomain.DeliveryInfo) Command {
+func NewCommand(
+	customerID uuid.UUID,
+	fulfillmentType orderDomain.FulfillmentType,
+	deliveryInfo *orderDomain.DeliveryInfo,
+) Command {
 	return Command{
-		CustomerID:   customerID,
-		DeliveryInfo: deliveryInfo,
+		CustomerID:      customerID,
+		FulfillmentType: fulfillmentType,
+		DeliveryInfo:    deliveryInfo,
 	}
 }
@@ -5,27 +5,53 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/shortlink-org/go-sdk/logger"
 
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	cartv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
 	cartItemsv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/items/v1"
 	orderDomain "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	pricing "github.com/shortlink-org/shop/oms/internal/domain/pricing"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/order_pricing"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
 )
 
 var (
-	errEmptyCart            = errors.New("cannot create order from empty cart")
-	errInvalidDeliveryInfo  = errors.New("invalid delivery info")
+	errEmptyCart           = errors.New("cannot create order from empty cart")
+	errInvalidDeliveryInfo = errors.New("invalid delivery info")
 )
 
 // Result represents the result of creating an order from a cart.
 type Result struct {
+	// Order is the created order; nil for a dry run
 	Order         *orderDomain.OrderState
+	Currency      pricing.Currency
 	Subtotal      decimal.Decimal
 	TotalDiscount decimal.Decimal
+	// OrderDiscount is the order-level promotion applied on top of the item discounts
+	OrderDiscount decimal.Decimal
 	TotalTax      decimal.Decimal
-	FinalPrice    decimal.Decimal
+	// DeliveryFee is the shipping charge; zero for pickup orders and free shipping
+	DeliveryFee decimal.Decimal
+	// FinalPrice is the amount due, delivery fee included
+	FinalPrice decimal.Decimal
+	// TaxExemptionCode is set when no tax was charged because the customer is tax-exempt
+	TaxExemptionCode string
+}
+
+// Config holds the checkout business rules.
+type Config struct {
+	// MinOrderValue is the smallest pricer subtotal accepted at checkout; zero means no minimum
+	MinOrderValue decimal.Decimal
+	// MaxOrderLineItems caps the distinct items of an order; zero keeps orderDomain.DefaultMaxOrderLineItems
+	MaxOrderLineItems int
+	// PricerFailurePolicy applies when the pricer errors; empty means PricerFailurePolicyFail
+	PricerFailurePolicy PricerFailurePolicy
 }
 
 // Handler handles CreateOrderFromCart commands.
@@ -35,7 +61,12 @@ type Handler struct {
 	cartRepo     ports.CartRepository
 	orderRepo    ports.OrderRepository
 	publisher    ports.EventPublisher
+	eventStore   ports.EventStore
+	goodsIndex   ports.CartGoodsIndex
 	pricerClient ports.PricerClient
+	rateLimiter  ports.RateLimiter
+	deliveryFees *orderDomain.DeliveryFeeCalculator
+	cfg          Config
 }
 
 // NewHandler creates a new CreateOrderFromCart handler.
@@ -45,7 +76,12 @@ func NewHandler(
 	cartRepo ports.CartRepository,
 	orderRepo ports.OrderRepository,
 	publisher ports.EventPublisher,
+	eventStore ports.EventStore,
+	goodsIndex ports.CartGoodsIndex,
 	pricerClient ports.PricerClient,
+	rateLimiter ports.RateLimiter,
+	deliveryFees *orderDomain.DeliveryFeeCalculator,
+	cfg Config,
 ) (*Handler, error) {
 	return &Handler{
 		log:          log,
@@ -53,130 +89,408 @@ func NewHandler(
 		cartRepo:     cartRepo,
 		orderRepo:    orderRepo,
 		publisher:    publisher,
+		eventStore:   eventStore,
+		goodsIndex:   goodsIndex,
 		pricerClient: pricerClient,
+		rateLimiter:  rateLimiter,
+		deliveryFees: deliveryFees,
+		cfg:          cfg,
 	}, nil
 }
 
 // Handle executes the CreateOrderFromCart command.
 // Atomically creates an order from cart and clears cart.
-//
-//nolint:maintidx // orchestration flow; splitting would obscure transaction scope
+// A concurrent cart update (version conflict) re-runs the whole checkout in a fresh transaction.
+// A dry run only returns the totals and locks the pricer's prices on the cart (see dryRun).
+// Checkouts are throttled per customer when a rate limiter is configured; dry runs are not.
+// After commit the checked-out units are released from the goods demand index; a nil index skips that.
 func (h *Handler) Handle(ctx context.Context, cmd Command) (Result, error) {
-	// 1. Begin transaction
-	ctx, err := h.uow.Begin(ctx)
+	err := validateFulfillment(cmd)
+	if err != nil {
+		return Result{}, domain.WrapValidation("checkout fulfillment", err)
+	}
+
+	if cmd.DryRun {
+		return h.dryRun(ctx, cmd)
+	}
+
+	err = h.checkRateLimit(ctx, cmd.CustomerID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var result Result
+
+	err = uow.RunWithRetry(ctx, h.uow, uow.DefaultMaxAttempts, domain.ErrVersionConflict, func(ctx context.Context) error {
+		var txErr error
+
+		result, txErr = h.checkout(ctx, cmd)
+
+		return txErr
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	result.Order.ClearDomainEvents()
+
+	h.releaseGoodDemand(ctx, cmd.CustomerID, result.Order.GetItems())
+
+	h.logFor(cmd.CustomerID).withOrder(result.Order.GetOrderID()).Info(ctx, "order created from cart",
+		slog.String("final_price", result.FinalPrice.String()),
+		slog.String("currency", string(result.Currency)),
+	)
+
+	return result, nil
+}
+
+// releaseGoodDemand drops the checked-out units from the goods demand index, as resetting the cart does.
+// The order items mirror the cart items, so they carry the released units.
+// The index is a projection: a failure only skews the demand signal until the cart changes again.
+func (h *Handler) releaseGoodDemand(ctx context.Context, customerID uuid.UUID, items orderDomain.Items) {
+	if h.goodsIndex == nil {
+		return
+	}
+
+	for _, item := range items {
+		indexErr := h.goodsIndex.DecrementGoodDemand(ctx, item.GetGoodId(), customerID, int64(item.GetQuantity()))
+		if indexErr != nil {
+			h.logFor(customerID).Warn(ctx, "failed to update cart goods index",
+				slog.String("good_id", item.GetGoodId().String()),
+				slog.Any("error", indexErr))
+		}
+	}
+}
+
+// checkRateLimit takes a checkout token for the customer, returning *domain.RateLimitedError when none is left.
+// A nil rate limiter disables throttling. A failing limiter lets the checkout through: it guards
+// against abuse and must not take checkout down with it.
+func (h *Handler) checkRateLimit(ctx context.Context, customerID uuid.UUID) error {
+	if h.rateLimiter == nil {
+		return nil
+	}
+
+	decision, err := h.rateLimiter.Allow(ctx, customerID.String())
+	if err != nil {
+		h.logFor(customerID).Warn(ctx, "checkout rate limiter unavailable, allowing checkout", slog.Any("error", err))
+
+		return nil
+	}
+
+	if !decision.Allowed {
+		return &domain.RateLimitedError{RetryAfter: decision.RetryAfter}
+	}
+
+	return nil
+}
+
+// dryRun is the review-order step: it prices the cart like checkout does but creates no order,
+// keeps the cart items and publishes no events. The quote is read in a transaction that is always
+// rolled back; a fresh pricer result is then locked on the cart, so checkout charges the reviewed
+// prices until cartv1.PricingSnapshotTTL runs out.
+func (h *Handler) dryRun(ctx context.Context, cmd Command) (Result, error) {
+	txCtx, err := h.uow.Begin(ctx)
+	if err != nil {
+		return Result{}, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	q, err := h.quote(txCtx, cmd)
+
+	rollbackErr := h.uow.Rollback(txCtx)
+	if rollbackErr != nil {
+		err = errors.Join(err, fmt.Errorf("rollback transaction: %w", rollbackErr))
+	}
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	// A dry run refuses a small cart just like checkout would, before any price lock is taken
+	err = checkMinimumOrderValue(q.pricing.Subtotal, h.cfg.MinOrderValue)
 	if err != nil {
-		return Result{}, fmt.Errorf("failed to begin transaction: %w", err)
+		return Result{}, err
+	}
+
+	// A tax-exempt quote is not locked: a later checkout without the exemption must be taxed.
+	if q.fresh && cmd.TaxExemptionCode == "" {
+		h.lockPrices(ctx, cmd.CustomerID, q, time.Now())
 	}
 
-	committed := false
-	defer func() {
-		if committed {
-			return
+	return q.result(nil), nil
+}
+
+// lockPrices stores the quote's pricer result on the cart as its price lock.
+// The lock is only taken while the cart still is the version that was priced; a failure is
+// logged, not returned: without the lock checkout simply asks the pricer again.
+func (h *Handler) lockPrices(ctx context.Context, customerID uuid.UUID, q checkoutQuote, now time.Time) {
+	err := uow.RunWithRetry(ctx, h.uow, uow.DefaultMaxAttempts, domain.ErrVersionConflict, func(ctx context.Context) error {
+		cart, err := h.cartRepo.Load(ctx, customerID)
+		if err != nil {
+			return fmt.Errorf("failed to load cart: %w", err)
+		}
+
+		// The cart changed after it was priced: the quote does not describe it anymore
+		if cart.GetVersion() != q.cart.GetVersion() {
+			return nil
 		}
 
-		rollbackErr := h.uow.Rollback(ctx)
-		if rollbackErr != nil {
-			h.log.Warn("rollback failed", slog.Any("error", rollbackErr))
+		cart.SnapshotPricing(pricingTotalsFromResponse(q.pricing), now)
+
+		err = h.cartRepo.Save(ctx, cart)
+		if err != nil {
+			return fmt.Errorf("failed to save cart: %w", err)
 		}
-	}()
 
-	// 2. Load cart (uses tx from ctx)
+		return nil
+	})
+	if err != nil {
+		h.logFor(customerID).Warn(ctx, "failed to lock reviewed cart prices", slog.Any("error", err))
+	}
+}
+
+// checkoutQuote is a priced cart, delivery fee included.
+type checkoutQuote struct {
+	cart             *cartv1.State
+	items            cartItemsv1.Items
+	pricing          ports.CalculateTotalResponse
+	deliveryFee      decimal.Decimal
+	taxExemptionCode string
+	// fresh is set when the pricer priced the cart for this quote (no lock reused, no fallback)
+	fresh bool
+}
+
+// result builds the checkout result for order (nil for a dry run).
+func (q checkoutQuote) result(order *orderDomain.OrderState) Result {
+	return Result{
+		Order:            order,
+		Currency:         q.pricing.Currency,
+		Subtotal:         q.pricing.Subtotal,
+		TotalDiscount:    q.pricing.TotalDiscount,
+		OrderDiscount:    q.pricing.OrderDiscount,
+		TotalTax:         q.pricing.TotalTax,
+		DeliveryFee:      q.deliveryFee,
+		FinalPrice:       q.pricing.FinalPrice.Add(q.deliveryFee),
+		TaxExemptionCode: q.taxExemptionCode,
+	}
+}
+
+// quote loads and prices the cart inside the transaction carried by ctx.
+func (h *Handler) quote(ctx context.Context, cmd Command) (checkoutQuote, error) {
+	// 1. Load cart (uses tx from ctx)
 	cart, err := h.cartRepo.Load(ctx, cmd.CustomerID)
 	if err != nil {
-		return Result{}, fmt.Errorf("failed to load cart: %w", err)
+		return checkoutQuote{}, fmt.Errorf("failed to load cart: %w", err)
 	}
 
-	// 3. Validate cart is not empty
+	// 2. Validate cart is not empty
 	cartItems := cart.GetItems()
 	if len(cartItems) == 0 {
-		return Result{}, errEmptyCart
+		return checkoutQuote{}, errEmptyCart
 	}
 
-	// 4. Validate delivery info if provided
+	// 3. Validate delivery info if provided
 	if cmd.DeliveryInfo != nil && !cmd.DeliveryInfo.IsValid() {
-		return Result{}, errInvalidDeliveryInfo
+		return checkoutQuote{}, errInvalidDeliveryInfo
+	}
+
+	// Money math must stay within one currency
+	currency, err := cartItems.Currency()
+	if err != nil {
+		return checkoutQuote{}, domain.WrapValidation("cart currency", err)
+	}
+
+	// A price lock taken at review time wins over re-pricing until it expires
+	pricingResp, fresh, err := h.priceCart(ctx, cmd, cart, cartItems, currency, time.Now())
+	if err != nil {
+		return checkoutQuote{}, err
+	}
+
+	// Exempt customers (B2B, charities) are not charged tax
+	if cmd.TaxExemptionCode != "" {
+		pricingResp = exemptFromTax(pricingResp)
+	}
+
+	// Order items copy cart prices, so they must agree with what the pricer charges
+	err = order_pricing.ReconcileItemPrices(pricingLines(cartItems), pricingResp.Items)
+	if err != nil {
+		return checkoutQuote{}, err
+	}
+
+	deliveryFee := decimal.Zero
+	if cmd.DeliveryInfo != nil {
+		deliveryFee = h.deliveryFees.Calculate(*cmd.DeliveryInfo, pricingResp.FinalPrice)
 	}
 
-	// TODO: replace local totals with pricer integration when the service is ready.
-	pricingResp := calculateOrderTotals(cartItems)
+	return checkoutQuote{
+		cart:             cart,
+		items:            cartItems,
+		pricing:          pricingResp,
+		deliveryFee:      deliveryFee,
+		taxExemptionCode: cmd.TaxExemptionCode,
+		fresh:            fresh,
+	}, nil
+}
 
-	// 6. Prepare neutral lines from cart (application-layer mapping)
-	lines := cartItemsToLines(cartItems)
+// checkout runs a single checkout attempt inside the transaction carried by ctx.
+func (h *Handler) checkout(ctx context.Context, cmd Command) (Result, error) {
+	q, err := h.quote(ctx, cmd)
+	if err != nil {
+		return Result{}, err
+	}
 
-	// 7. Create order from lines (domain keeps invariants)
-	order := orderDomain.NewOrderState(cmd.CustomerID)
+	cart := q.cart
+
+	// Small orders are refused before anything is created
+	err = checkMinimumOrderValue(q.pricing.Subtotal, h.cfg.MinOrderValue)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// 4. Prepare neutral lines from cart (application-layer mapping)
+	lines := cartItemsToLines(q.items)
+
+	// 5. Create order from lines (domain keeps invariants)
+	order := orderDomain.NewOrderState(cmd.CustomerID, orderDomain.WithMaxLineItems(h.cfg.MaxOrderLineItems))
 
 	err = order.CreateFromLines(ctx, lines)
 	if err != nil {
 		return Result{}, fmt.Errorf("failed to create order: %w", err)
 	}
 
-	// 8. Set delivery info if provided
+	// 6. Record fulfillment, priced delivery info for DELIVERY orders, the gift message and the order discount
 	if cmd.DeliveryInfo != nil {
-		setErr := order.SetDeliveryInfo(*cmd.DeliveryInfo)
+		deliveryInfo := *cmd.DeliveryInfo
+		deliveryInfo.SetDeliveryFee(q.deliveryFee)
+
+		setErr := order.SetDeliveryInfo(deliveryInfo)
 		if setErr != nil {
 			return Result{}, fmt.Errorf("failed to set delivery info: %w", setErr)
 		}
 	}
 
-	// 9. Clear cart
-	cart.Reset()
+	err = order.SetFulfillmentType(cmd.FulfillmentType)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to set fulfillment type: %w", err)
+	}
 
-	// 10. Save order (uses tx from ctx)
+	err = order.SetGiftMessage(cart.GetGiftMessage())
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to set gift message: %w", err)
+	}
+
+	err = order.SetOrderDiscount(q.pricing.OrderDiscount)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to set order discount: %w", err)
+	}
+
+	err = order.SetTotals(orderDomain.OrderTotals{
+		Subtotal:   q.pricing.Subtotal,
+		TotalTax:   q.pricing.TotalTax,
+		FinalPrice: q.pricing.FinalPrice,
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to set order totals: %w", err)
+	}
+
+	// 7. Clear cart
+	cart.ResetWithReason(cartv1.ResetReasonCheckout)
+
+	// 8. Save order (uses tx from ctx)
 	err = h.orderRepo.Save(ctx, order)
 	if err != nil {
 		return Result{}, fmt.Errorf("failed to save order: %w", err)
 	}
 
-	// 11. Save cart (uses tx from ctx)
+	// 9. Save cart (uses tx from ctx)
 	err = h.cartRepo.Save(ctx, cart)
 	if err != nil {
 		return Result{}, fmt.Errorf("failed to save cart: %w", err)
 	}
 
-	// 12. Publish domain events to outbox (same transaction).
+	// 10. Append domain events to the order's event stream (same transaction).
+	// A new order starts an empty stream, so the expected version is 0.
+	domainEvents := order.GetDomainEvents()
+
+	err = h.eventStore.Append(ctx, order.GetOrderID(), domainEvents, 0)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to append domain events: %w", err)
+	}
+
+	// 11. Publish domain events to outbox (same transaction).
 	// If outbox write fails, we must not commit — same as failing to save order/cart.
-	for _, event := range order.GetDomainEvents() {
+	for _, event := range domainEvents {
 		pubErr := h.publisher.Publish(ctx, event)
 		if pubErr != nil {
 			return Result{}, fmt.Errorf("failed to publish domain event to outbox: %w", pubErr)
 		}
 	}
 
-	// 13. Commit transaction
-	if err := h.uow.Commit(ctx); err != nil {
-		return Result{}, fmt.Errorf("failed to commit transaction: %w", err)
-	}
-	committed = true
+	// 12. Build result with pricing info
+	return q.result(order), nil
+}
 
-	order.ClearDomainEvents()
+// validateFulfillment enforces that DELIVERY carries delivery info and PICKUP does not.
+func validateFulfillment(cmd Command) error {
+	switch cmd.FulfillmentType {
+	case orderDomain.FulfillmentType_FULFILLMENT_TYPE_DELIVERY:
+		if cmd.DeliveryInfo == nil {
+			return orderDomain.ErrDeliveryInfoRequired
+		}
+	case orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP:
+		if cmd.DeliveryInfo != nil {
+			return orderDomain.ErrDeliveryInfoNotAllowedForPickup
+		}
+	default:
+		return orderDomain.ErrFulfillmentTypeRequired
+	}
 
-	// 14. Build result with pricing info
-	return Result{
-		Order:         order,
-		Subtotal:      pricingResp.Subtotal,
-		TotalDiscount: pricingResp.TotalDiscount,
-		TotalTax:      pricingResp.TotalTax,
-		FinalPrice:    pricingResp.FinalPrice,
-	}, nil
+	return nil
 }
 
-func calculateOrderTotals(cartItems cartItemsv1.Items) ports.CalculateTotalResponse {
-	subtotal := decimal.Zero
-	totalDiscount := decimal.Zero
-	totalTax := decimal.Zero
+// priceCart reuses the cart's price lock while it is valid at now and asks the pricer otherwise.
+// Without a pricer the cart's own prices, discounts and taxes are used.
+// A pricer error is handled according to Config.PricerFailurePolicy.
+// The bool reports whether the pricer priced the cart just now.
+func (h *Handler) priceCart(
+	ctx context.Context,
+	cmd Command,
+	cart *cartv1.State,
+	cartItems cartItemsv1.Items,
+	currency pricing.Currency,
+	now time.Time,
+) (ports.CalculateTotalResponse, bool, error) {
+	snapshot, ok := cart.ValidPricingSnapshot(now)
+	if ok {
+		return pricingResponseFromTotals(snapshot.GetTotals()), false, nil
+	}
+
+	if h.pricerClient == nil {
+		return order_pricing.CalculateTotals(pricingLines(cartItems), currency), false, nil
+	}
+
+	req := NewPricerRequestBuilder(cmd.CustomerID, cartItems).
+		WithCurrency(currency).
+		WithTaxExemption(cmd.TaxExemptionCode).
+		Build()
 
-	for _, item := range cartItems {
-		quantity := decimal.NewFromInt32(item.GetQuantity())
-		subtotal = subtotal.Add(item.GetPrice().Mul(quantity))
-		totalDiscount = totalDiscount.Add(item.GetDiscount().Mul(quantity))
-		totalTax = totalTax.Add(item.GetTax().Mul(quantity))
+	priced, err := h.pricerClient.CalculateTotal(ctx, req)
+	if err == nil {
+		return *priced, true, nil
 	}
 
-	return ports.CalculateTotalResponse{
-		Subtotal:      subtotal,
-		TotalDiscount: totalDiscount,
-		TotalTax:      totalTax,
-		FinalPrice:    subtotal.Sub(totalDiscount).Add(totalTax),
+	if h.cfg.PricerFailurePolicy != PricerFailurePolicyFallbackToCart {
+		return ports.CalculateTotalResponse{}, false, domain.WrapUnavailable("failed to calculate pricing", err)
 	}
+
+	h.logFor(cmd.CustomerID).Warn(ctx, "pricer unavailable, pricing checkout from cart prices without tax", slog.Any("error", err))
+
+	return exemptFromTax(order_pricing.CalculateTotals(pricingLines(cartItems), currency)), false, nil
+}
+
+// exemptFromTax removes the tax from a priced cart.
+func exemptFromTax(resp ports.CalculateTotalResponse) ports.CalculateTotalResponse {
+	resp.FinalPrice = resp.FinalPrice.Sub(resp.TotalTax)
+	resp.TotalTax = decimal.Zero
+
+	return resp
 }
@@ -0,0 +1,133 @@
+//go:build integration
+
+package create_order_from_cart
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill"
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	cqrsbus "github.com/shortlink-org/go-sdk/cqrs/bus"
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+	"github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/require"
+
+	cartv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
+	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	cartrepo "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/cart"
+	eventstore "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/event_store"
+	orderrepo "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/testhelpers"
+	uowpg "github.com/shortlink-org/shop/oms/pkg/uow/postgres"
+)
+
+var errBrokerDown = errors.New("broker is down")
+
+// failingPublisher simulates an unavailable message broker.
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(string, ...*wmmessage.Message) error { return errBrokerDown }
+
+func (failingPublisher) Close() error { return nil }
+
+// TestHandle_Integration_EventsLandInOutboxWhenBrokerFails verifies that checkout
+// writes OrderCreated to the outbox table in the same transaction as the order,
+// so the event survives a broker outage and is delivered later by the forwarder.
+func TestHandle_Integration_EventsLandInOutboxWhenBrokerFails(t *testing.T) {
+	ctx := context.Background()
+
+	pc := testhelpers.SetupPostgresContainer(t)
+
+	orderStore, err := orderrepo.New(ctx, pc.DB())
+	require.NoError(t, err)
+	t.Cleanup(orderStore.Close)
+
+	cartStore, err := cartrepo.New(ctx, pc.DB())
+	require.NoError(t, err)
+
+	eventStore, err := eventstore.New(ctx, pc.DB())
+	require.NoError(t, err)
+
+	logCfg := logger.Default()
+	logCfg.Writer = io.Discard
+	logCfg.Level = logger.WARN_LEVEL
+
+	log, err := logger.New(logCfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = log.Close() })
+
+	uow := uowpg.New(pc.Pool)
+
+	handler, err := NewHandler(log, uow, cartStore, orderStore, newOutboxEventBus(t, failingPublisher{}), eventStore, nil, nil, nil, testDeliveryFees, Config{})
+	require.NoError(t, err)
+
+	customerID := uuid.New()
+	cart := cartv1.New(customerID)
+	item, err := itemv1.NewItemWithPricing(uuid.New(), 2, decimal.NewFromFloat(19.99), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+	require.NoError(t, cart.AddItem(item))
+
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, cartStore.Save(txCtx, cart))
+	require.NoError(t, uow.Commit(txCtx))
+
+	require.Equal(t, int64(0), outboxCount(t, pc))
+
+	result, err := handler.Handle(ctx, NewCommand(customerID, orderv1.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+	require.NoError(t, err)
+	require.NotNil(t, result.Order)
+
+	require.Equal(t, int64(1), outboxCount(t, pc))
+
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, uow.Rollback(txCtx))
+	}()
+
+	saved, err := orderStore.Load(txCtx, result.Order.GetOrderID())
+	require.NoError(t, err)
+	require.Equal(t, orderv1.OrderStatus_ORDER_STATUS_PROCESSING, saved.GetStatus())
+	require.Len(t, saved.GetItems(), 1)
+
+	stream, err := eventStore.Load(txCtx, result.Order.GetOrderID())
+	require.NoError(t, err)
+	require.Len(t, stream, 1)
+
+	savedCart, err := cartStore.Load(txCtx, customerID)
+	require.NoError(t, err)
+	require.Empty(t, savedCart.GetItems())
+}
+
+func newOutboxEventBus(t *testing.T, realPublisher wmmessage.Publisher) ports.EventPublisher {
+	t.Helper()
+
+	namer := cqrsmessage.NewShortlinkNamer("oms")
+	publisherBus, err := cqrsbus.NewEventBusWithOptions(
+		realPublisher,
+		cqrsmessage.NewJSONMarshaler(namer),
+		namer,
+		cqrsbus.WithTxAwareOutbox("oms_outbox", watermill.NewStdLogger(false, false)),
+	)
+	require.NoError(t, err)
+
+	return cqrsbus.NewEventPublisher(publisherBus)
+}
+
+func outboxCount(t *testing.T, pc *testhelpers.PostgresContainer) int64 {
+	t.Helper()
+
+	var count int64
+	err := pc.Pool.QueryRow(context.Background(), `SELECT COUNT(*) FROM watermill_oms_outbox`).Scan(&count)
+	require.NoError(t, err)
+
+	return count
+}
@@ -2,7 +2,9 @@ package create_order_from_cart
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -11,12 +13,27 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/shortlink-org/shop/oms/internal/domain"
 	cartv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
 	itemv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/item/v1"
 	itemsv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/items/v1"
+	orderDomain "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	pricing "github.com/shortlink-org/shop/oms/internal/domain/pricing"
 	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/create_order_from_cart/mocks"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/order_pricing"
 )
 
+// testDeliveryFees charges the flat fee for the coordinate-less addresses used in these tests.
+var testDeliveryFees = orderDomain.NewDeliveryFeeCalculator(orderDomain.DeliveryFeePolicy{
+	BaseFee:               decimal.NewFromInt(5),
+	PerKm:                 decimal.NewFromInt(1),
+	PerKg:                 decimal.NewFromInt(1),
+	FlatFee:               decimal.NewFromInt(7),
+	FreeShippingThreshold: decimal.NewFromInt(1000),
+})
+
 func TestHandler_Handle_WithPricer(t *testing.T) {
 	log, err := logger.New(logger.Default())
 	require.NoError(t, err)
@@ -33,13 +50,14 @@ func TestHandler_Handle_WithPricer(t *testing.T) {
 	item, err := itemv1.NewItemWithPricing(goodID, 2, decimal.NewFromInt(50), decimal.Zero, decimal.Zero)
 	require.NoError(t, err)
 
-	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1)
+	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
 
 	// Create mocks
 	mockUoW := mocks.NewMockUnitOfWork(t)
 	mockCartRepo := mocks.NewMockCartRepository(t)
 	mockOrderRepo := mocks.NewMockOrderRepository(t)
 	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
 	// Setup expectations
 	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
 	mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
@@ -51,6 +69,8 @@ func TestHandler_Handle_WithPricer(t *testing.T) {
 
 	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
 
+	mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+
 	// Create handler
 	handler, err := NewHandler(
 		log,
@@ -58,12 +78,16 @@ func TestHandler_Handle_WithPricer(t *testing.T) {
 		mockCartRepo,
 		mockOrderRepo,
 		mockPublisher,
+		mockEventStore, nil,
 		nil,
+		nil,
+		testDeliveryFees,
+		Config{},
 	)
 	require.NoError(t, err)
 
 	// Execute
-	cmd := NewCommand(customerID, nil)
+	cmd := NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil)
 	result, err := handler.Handle(ctx, cmd)
 
 	// Assert
@@ -90,12 +114,13 @@ func TestHandler_Handle_WithoutPricer(t *testing.T) {
 	item, err := itemv1.NewItemWithPricing(goodID, 2, decimal.NewFromInt(50), decimal.Zero, decimal.Zero)
 	require.NoError(t, err)
 
-	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1)
+	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
 
 	mockUoW := mocks.NewMockUnitOfWork(t)
 	mockCartRepo := mocks.NewMockCartRepository(t)
 	mockOrderRepo := mocks.NewMockOrderRepository(t)
 	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
 
 	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
 	mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
@@ -103,6 +128,7 @@ func TestHandler_Handle_WithoutPricer(t *testing.T) {
 	mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
 	mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
 	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+	mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
 
 	handler, err := NewHandler(
 		log,
@@ -110,11 +136,15 @@ func TestHandler_Handle_WithoutPricer(t *testing.T) {
 		mockCartRepo,
 		mockOrderRepo,
 		mockPublisher,
+		mockEventStore, nil,
 		nil, // No pricer client
+		nil,
+		testDeliveryFees,
+		Config{},
 	)
 	require.NoError(t, err)
 
-	cmd := NewCommand(customerID, nil)
+	cmd := NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil)
 	result, err := handler.Handle(ctx, cmd)
 
 	assert.NoError(t, err)
@@ -126,6 +156,96 @@ func TestHandler_Handle_WithoutPricer(t *testing.T) {
 }
 
 func TestHandler_Handle_PricerError(t *testing.T) {
+	pricerErr := errors.New("pricer unavailable")
+
+	tests := []struct {
+		name   string
+		policy PricerFailurePolicy
+		// wantErr is nil when the checkout goes through on cart prices
+		wantErr error
+	}{
+		{"default policy fails", "", domain.ErrUnavailable},
+		{"fail", PricerFailurePolicyFail, domain.ErrUnavailable},
+		{"fallback to cart", PricerFailurePolicyFallbackToCart, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, err := logger.New(logger.Default())
+			require.NoError(t, err)
+
+			defer func() {
+				_ = log.Close() //nolint:errcheck // teardown; ignore close error
+			}()
+
+			ctx := context.Background()
+			customerID := uuid.New()
+
+			// 2 x (50 - 5 discount + 10 tax)
+			item, err := itemv1.NewItemWithPricing(uuid.New(), 2, decimal.NewFromInt(50), decimal.NewFromInt(5), decimal.NewFromInt(10))
+			require.NoError(t, err)
+
+			cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
+
+			mockUoW := mocks.NewMockUnitOfWork(t)
+			mockCartRepo := mocks.NewMockCartRepository(t)
+			mockOrderRepo := mocks.NewMockOrderRepository(t)
+			mockPublisher := mocks.NewMockEventPublisher(t)
+			mockEventStore := mocks.NewMockEventStore(t)
+			mockPricer := mocks.NewMockPricerClient(t)
+
+			mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+			mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+			mockPricer.EXPECT().CalculateTotal(mock.Anything, mock.Anything).Return(nil, pricerErr)
+
+			if tt.wantErr != nil {
+				mockUoW.EXPECT().Rollback(mock.Anything).Return(nil)
+			} else {
+				mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
+				mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+				mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+				mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+				mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+			}
+
+			handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil,
+				mockPricer, nil, testDeliveryFees, Config{PricerFailurePolicy: tt.policy})
+			require.NoError(t, err)
+
+			cmd := NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil)
+			result, err := handler.Handle(ctx, cmd)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.ErrorIs(t, err, pricerErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, result.Order)
+			assert.True(t, decimal.NewFromInt(100).Equal(result.Subtotal), "subtotal from cart prices, got %s", result.Subtotal)
+			assert.True(t, decimal.NewFromInt(10).Equal(result.TotalDiscount), "cart discounts kept, got %s", result.TotalDiscount)
+			assert.True(t, result.TotalTax.IsZero(), "no tax without the pricer, got %s", result.TotalTax)
+			assert.True(t, decimal.NewFromInt(90).Equal(result.FinalPrice), "final price, got %s", result.FinalPrice)
+		})
+	}
+}
+
+func TestParsePricerFailurePolicy(t *testing.T) {
+	policy, err := ParsePricerFailurePolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, PricerFailurePolicyFail, policy)
+
+	policy, err = ParsePricerFailurePolicy("FALLBACK_TO_CART")
+	require.NoError(t, err)
+	assert.Equal(t, PricerFailurePolicyFallbackToCart, policy)
+
+	_, err = ParsePricerFailurePolicy("IGNORE")
+	require.ErrorIs(t, err, ErrUnsupportedPricerFailurePolicy)
+}
+
+func TestHandler_Handle_OutboxWriteErrorRollsBack(t *testing.T) {
 	log, err := logger.New(logger.Default())
 	require.NoError(t, err)
 
@@ -135,45 +255,114 @@ func TestHandler_Handle_PricerError(t *testing.T) {
 
 	ctx := context.Background()
 	customerID := uuid.New()
-	goodID := uuid.New()
 
-	item, err := itemv1.NewItemWithPricing(goodID, 2, decimal.NewFromInt(50), decimal.Zero, decimal.Zero)
+	item, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
 	require.NoError(t, err)
 
-	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1)
+	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
+	outboxErr := errors.New("outbox insert failed")
 
 	mockUoW := mocks.NewMockUnitOfWork(t)
 	mockCartRepo := mocks.NewMockCartRepository(t)
 	mockOrderRepo := mocks.NewMockOrderRepository(t)
 	mockPublisher := mocks.NewMockEventPublisher(t)
-	mockPricer := mocks.NewMockPricerClient(t)
+	mockEventStore := mocks.NewMockEventStore(t)
 
 	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
-	mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
+	mockUoW.EXPECT().Rollback(mock.Anything).Return(nil)
 	mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
 	mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
 	mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
-	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(outboxErr)
+	mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
 
-	handler, err := NewHandler(
-		log,
-		mockUoW,
-		mockCartRepo,
-		mockOrderRepo,
-		mockPublisher,
-		mockPricer,
-	)
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, nil, testDeliveryFees, Config{})
 	require.NoError(t, err)
 
-	cmd := NewCommand(customerID, nil)
-	result, err := handler.Handle(ctx, cmd)
+	// Commit is not expected: the order must not be persisted without its events.
+	_, err = handler.Handle(ctx, NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+	require.ErrorIs(t, err, outboxErr)
+}
 
-	assert.NoError(t, err)
-	assert.NotNil(t, result.Order)
-	assert.Equal(t, decimal.NewFromInt(100), result.Subtotal)
-	assert.Equal(t, decimal.Zero, result.TotalDiscount)
-	assert.Equal(t, decimal.Zero, result.TotalTax)
-	assert.Equal(t, decimal.NewFromInt(100), result.FinalPrice)
+func TestHandler_Handle_EventStoreErrorRollsBack(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	defer func() {
+		_ = log.Close() //nolint:errcheck // teardown; ignore close error
+	}()
+
+	ctx := context.Background()
+	customerID := uuid.New()
+
+	item, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
+	storeErr := errors.New("event insert failed")
+
+	mockUoW := mocks.NewMockUnitOfWork(t)
+	mockCartRepo := mocks.NewMockCartRepository(t)
+	mockOrderRepo := mocks.NewMockOrderRepository(t)
+	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
+
+	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+	mockUoW.EXPECT().Rollback(mock.Anything).Return(nil)
+	mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+	mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(storeErr)
+
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, nil, testDeliveryFees, Config{})
+	require.NoError(t, err)
+
+	// Neither Commit nor Publish is expected: the order must not be persisted without its event stream.
+	_, err = handler.Handle(ctx, NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+	require.ErrorIs(t, err, storeErr)
+}
+
+func TestHandler_Handle_RetriesOnVersionConflict(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	defer func() {
+		_ = log.Close() //nolint:errcheck // teardown; ignore close error
+	}()
+
+	ctx := context.Background()
+	customerID := uuid.New()
+
+	item, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	mockUoW := mocks.NewMockUnitOfWork(t)
+	mockCartRepo := mocks.NewMockCartRepository(t)
+	mockOrderRepo := mocks.NewMockOrderRepository(t)
+	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
+
+	// Each attempt reloads the cart, so hand out a fresh aggregate every time.
+	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil).Times(2)
+	mockUoW.EXPECT().Rollback(mock.Anything).Return(nil).Once()
+	mockUoW.EXPECT().Commit(mock.Anything).Return(nil).Once()
+	mockCartRepo.EXPECT().Load(mock.Anything, customerID).
+		RunAndReturn(func(context.Context, uuid.UUID) (*cartv1.State, error) {
+			return cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, ""), nil
+		}).Times(2)
+	mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Times(2)
+	mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(ports.ErrVersionConflict).Once()
+	mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil).Once()
+	mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil).Once()
+
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, nil, testDeliveryFees, Config{})
+	require.NoError(t, err)
+
+	result, err := handler.Handle(ctx, NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+	require.NoError(t, err)
+	require.NotNil(t, result.Order)
+	require.Empty(t, result.Order.GetDomainEvents())
 }
 
 func TestHandler_Handle_EmptyCart(t *testing.T) {
@@ -196,6 +385,7 @@ func TestHandler_Handle_EmptyCart(t *testing.T) {
 	mockCartRepo := mocks.NewMockCartRepository(t)
 	mockOrderRepo := mocks.NewMockOrderRepository(t)
 	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
 
 	// Setup expectations
 	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
@@ -210,12 +400,16 @@ func TestHandler_Handle_EmptyCart(t *testing.T) {
 		mockCartRepo,
 		mockOrderRepo,
 		mockPublisher,
+		mockEventStore, nil,
 		nil,
+		nil,
+		testDeliveryFees,
+		Config{},
 	)
 	require.NoError(t, err)
 
 	// Execute
-	cmd := NewCommand(customerID, nil)
+	cmd := NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil)
 	result, err := handler.Handle(ctx, cmd)
 
 	// Assert - should fail with empty cart error
@@ -247,13 +441,14 @@ func TestHandler_Handle_MultipleItems(t *testing.T) {
 	item3, err := itemv1.NewItemWithPricing(goodID3, 3, decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
 	require.NoError(t, err)
 
-	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item1, item2, item3}, 1)
+	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item1, item2, item3}, 1, nil, "")
 
 	// Create mocks
 	mockUoW := mocks.NewMockUnitOfWork(t)
 	mockCartRepo := mocks.NewMockCartRepository(t)
 	mockOrderRepo := mocks.NewMockOrderRepository(t)
 	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
 	// Setup expectations
 	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
 	mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
@@ -265,6 +460,8 @@ func TestHandler_Handle_MultipleItems(t *testing.T) {
 
 	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
 
+	mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+
 	// Create handler
 	handler, err := NewHandler(
 		log,
@@ -272,12 +469,16 @@ func TestHandler_Handle_MultipleItems(t *testing.T) {
 		mockCartRepo,
 		mockOrderRepo,
 		mockPublisher,
+		mockEventStore, nil,
 		nil,
+		nil,
+		testDeliveryFees,
+		Config{},
 	)
 	require.NoError(t, err)
 
 	// Execute
-	cmd := NewCommand(customerID, nil)
+	cmd := NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil)
 	result, err := handler.Handle(ctx, cmd)
 
 	// Assert
@@ -288,3 +489,971 @@ func TestHandler_Handle_MultipleItems(t *testing.T) {
 	assert.Equal(t, decimal.Zero, result.TotalTax)
 	assert.Equal(t, decimal.NewFromInt(130), result.FinalPrice)
 }
+
+func TestHandler_Handle_SingleCurrency(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	defer func() {
+		_ = log.Close() //nolint:errcheck // teardown; ignore close error
+	}()
+
+	ctx := context.Background()
+	customerID := uuid.New()
+
+	item1, err := itemv1.NewItemWithPricing(uuid.New(), 2, decimal.NewFromInt(50), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	item2, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(30), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item1.WithCurrency("EUR"), item2.WithCurrency("EUR")}, 1, nil, "")
+
+	mockUoW := mocks.NewMockUnitOfWork(t)
+	mockCartRepo := mocks.NewMockCartRepository(t)
+	mockOrderRepo := mocks.NewMockOrderRepository(t)
+	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
+
+	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+	mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
+	mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+	mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+	mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, nil, testDeliveryFees, Config{})
+	require.NoError(t, err)
+
+	result, err := handler.Handle(ctx, NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, pricing.Currency("EUR"), result.Currency)
+	assert.Equal(t, decimal.NewFromInt(130), result.FinalPrice)
+
+	for _, orderItem := range result.Order.GetItems() {
+		assert.Equal(t, pricing.Currency("EUR"), orderItem.GetCurrency())
+	}
+}
+
+func TestHandler_Handle_MixedCurrencies(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	defer func() {
+		_ = log.Close() //nolint:errcheck // teardown; ignore close error
+	}()
+
+	ctx := context.Background()
+	customerID := uuid.New()
+
+	euroItem, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(50), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	dollarItem, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(50), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	cart := cartv1.Reconstitute(customerID, itemsv1.Items{euroItem.WithCurrency("EUR"), dollarItem.WithCurrency("USD")}, 1, nil, "")
+
+	mockUoW := mocks.NewMockUnitOfWork(t)
+	mockCartRepo := mocks.NewMockCartRepository(t)
+	mockOrderRepo := mocks.NewMockOrderRepository(t)
+	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
+
+	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+	mockUoW.EXPECT().Rollback(mock.Anything).Return(nil)
+	mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, nil, testDeliveryFees, Config{})
+	require.NoError(t, err)
+
+	result, err := handler.Handle(ctx, NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+	require.ErrorIs(t, err, pricing.ErrCurrencyMismatch)
+	require.ErrorIs(t, err, domain.ErrValidation)
+
+	var mismatch *pricing.CurrencyMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, pricing.Currency("EUR"), mismatch.Expected)
+	assert.Equal(t, pricing.Currency("USD"), mismatch.Got)
+	assert.Nil(t, result.Order)
+}
+
+func TestPricerRequestBuilder_CarriesCurrency(t *testing.T) {
+	item, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	req := NewPricerRequestBuilder(uuid.New(), itemsv1.Items{item.WithCurrency("EUR")}).
+		WithCurrency("EUR").
+		Build()
+
+	assert.Equal(t, pricing.Currency("EUR"), req.Currency)
+	require.Len(t, req.Cart.Items, 1)
+	assert.Equal(t, pricing.Currency("EUR"), req.Cart.Items[0].Currency)
+}
+
+func TestPricerRequestBuilder_CarriesTaxCategory(t *testing.T) {
+	food, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	other, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(20), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	items := itemsv1.Items{food.WithTaxCategory("food"), other}
+
+	req := NewPricerRequestBuilder(uuid.New(), items).Build()
+	require.Len(t, req.Cart.Items, 2)
+	assert.Equal(t, "food", req.Cart.Items[0].TaxCategory)
+	assert.Empty(t, req.Cart.Items[1].TaxCategory, "items without a category leave the default to the pricer")
+
+	// The category is carried over to the order items, so repricing keeps it
+	lines := cartItemsToLines(items)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "food", lines[0].TaxCategory)
+}
+
+func TestPricerRequestBuilder_CarriesTaxExemption(t *testing.T) {
+	item, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	req := NewPricerRequestBuilder(uuid.New(), itemsv1.Items{item}).WithTaxExemption("CHARITY").Build()
+	assert.Equal(t, map[string]string{TaxExemptionParam: "CHARITY"}, req.TaxParams)
+
+	req = NewPricerRequestBuilder(uuid.New(), itemsv1.Items{item}).WithTaxExemption("").Build()
+	assert.Nil(t, req.TaxParams, "an empty code leaves the request taxable")
+}
+
+func newCheckoutDeliveryInfo(t *testing.T) *orderDomain.DeliveryInfo {
+	t.Helper()
+
+	pickupAddr, err := address.NewAddress("123 Warehouse St", "Moscow", "101000", "Russia")
+	require.NoError(t, err)
+
+	deliveryAddr, err := address.NewAddress("456 Customer St", "Moscow", "102000", "Russia")
+	require.NoError(t, err)
+
+	start := time.Now().Add(24 * time.Hour)
+	info := orderDomain.NewDeliveryInfo(
+		pickupAddr,
+		deliveryAddr,
+		orderDomain.NewDeliveryPeriod(start, start.Add(2*time.Hour)),
+		orderDomain.NewPackageInfo(2.5),
+		orderDomain.DeliveryPriorityNormal,
+		nil,
+	)
+
+	return &info
+}
+
+func TestHandler_Handle_FulfillmentTypes(t *testing.T) {
+	tests := []struct {
+		name            string
+		fulfillmentType orderDomain.FulfillmentType
+		withDelivery    bool
+	}{
+		{"delivery with delivery info", orderDomain.FulfillmentType_FULFILLMENT_TYPE_DELIVERY, true},
+		{"pickup without delivery info", orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, err := logger.New(logger.Default())
+			require.NoError(t, err)
+
+			defer func() {
+				_ = log.Close() //nolint:errcheck // teardown; ignore close error
+			}()
+
+			ctx := context.Background()
+			customerID := uuid.New()
+
+			item, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
+			require.NoError(t, err)
+
+			cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
+
+			mockUoW := mocks.NewMockUnitOfWork(t)
+			mockCartRepo := mocks.NewMockCartRepository(t)
+			mockOrderRepo := mocks.NewMockOrderRepository(t)
+			mockPublisher := mocks.NewMockEventPublisher(t)
+			mockEventStore := mocks.NewMockEventStore(t)
+
+			mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+			mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
+			mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+			mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+			mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+			mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+			mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+
+			handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, nil, testDeliveryFees, Config{})
+			require.NoError(t, err)
+
+			var deliveryInfo *orderDomain.DeliveryInfo
+			if tt.withDelivery {
+				deliveryInfo = newCheckoutDeliveryInfo(t)
+			}
+
+			result, err := handler.Handle(ctx, NewCommand(customerID, tt.fulfillmentType, deliveryInfo))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.fulfillmentType, result.Order.GetFulfillmentType())
+			assert.Equal(t, tt.withDelivery, result.Order.HasDeliveryInfo())
+		})
+	}
+}
+
+func TestHandler_Handle_DeliveryFee(t *testing.T) {
+	tests := []struct {
+		name            string
+		fulfillmentType orderDomain.FulfillmentType
+		unitPrice       int64
+		wantFee         decimal.Decimal
+	}{
+		{"delivery below free-shipping threshold", orderDomain.FulfillmentType_FULFILLMENT_TYPE_DELIVERY, 10, decimal.NewFromInt(7)},
+		{"delivery at free-shipping threshold", orderDomain.FulfillmentType_FULFILLMENT_TYPE_DELIVERY, 1000, decimal.Zero},
+		{"pickup", orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, 10, decimal.Zero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, err := logger.New(logger.Default())
+			require.NoError(t, err)
+
+			defer func() {
+				_ = log.Close() //nolint:errcheck // teardown; ignore close error
+			}()
+
+			ctx := context.Background()
+			customerID := uuid.New()
+
+			item, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(tt.unitPrice), decimal.Zero, decimal.Zero)
+			require.NoError(t, err)
+
+			cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
+
+			mockUoW := mocks.NewMockUnitOfWork(t)
+			mockCartRepo := mocks.NewMockCartRepository(t)
+			mockOrderRepo := mocks.NewMockOrderRepository(t)
+			mockPublisher := mocks.NewMockEventPublisher(t)
+			mockEventStore := mocks.NewMockEventStore(t)
+
+			var saved *orderDomain.OrderState
+
+			mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+			mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
+			mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+			mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+			mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).
+				RunAndReturn(func(_ context.Context, order *orderDomain.OrderState) error {
+					saved = order
+					return nil
+				})
+			mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+			mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+
+			handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, nil, testDeliveryFees, Config{})
+			require.NoError(t, err)
+
+			var deliveryInfo *orderDomain.DeliveryInfo
+			if tt.fulfillmentType == orderDomain.FulfillmentType_FULFILLMENT_TYPE_DELIVERY {
+				deliveryInfo = newCheckoutDeliveryInfo(t)
+			}
+
+			result, err := handler.Handle(ctx, NewCommand(customerID, tt.fulfillmentType, deliveryInfo))
+			require.NoError(t, err)
+
+			assert.True(t, tt.wantFee.Equal(result.DeliveryFee), "delivery fee: want %s, got %s", tt.wantFee, result.DeliveryFee)
+			assert.True(t, result.Subtotal.Add(tt.wantFee).Equal(result.FinalPrice),
+				"final price %s must include the delivery fee", result.FinalPrice)
+
+			require.NotNil(t, saved)
+			if deliveryInfo != nil {
+				assert.True(t, tt.wantFee.Equal(saved.GetDeliveryInfo().GetDeliveryFee()), "persisted delivery fee")
+			}
+		})
+	}
+}
+
+func TestHandler_Handle_PricingSnapshot(t *testing.T) {
+	tests := []struct {
+		name      string
+		pricedAt  time.Time
+		wantTotal decimal.Decimal
+	}{
+		{"fresh snapshot is reused", time.Now(), decimal.NewFromInt(90)},
+		{"expired snapshot is ignored", time.Now().Add(-2 * cartv1.PricingSnapshotTTL), decimal.NewFromInt(100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, err := logger.New(logger.Default())
+			require.NoError(t, err)
+
+			defer func() {
+				_ = log.Close() //nolint:errcheck // teardown; ignore close error
+			}()
+
+			ctx := context.Background()
+			customerID := uuid.New()
+
+			item, err := itemv1.NewItemWithPricing(uuid.New(), 2, decimal.NewFromInt(50), decimal.Zero, decimal.Zero)
+			require.NoError(t, err)
+
+			cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
+			// Review-time price lock cheaper than the current cart prices
+			cart.SnapshotPricing(cartv1.PricingTotals{
+				Subtotal:   decimal.NewFromInt(90),
+				FinalPrice: decimal.NewFromInt(90),
+			}, tt.pricedAt)
+
+			mockUoW := mocks.NewMockUnitOfWork(t)
+			mockCartRepo := mocks.NewMockCartRepository(t)
+			mockOrderRepo := mocks.NewMockOrderRepository(t)
+			mockPublisher := mocks.NewMockEventPublisher(t)
+			mockEventStore := mocks.NewMockEventStore(t)
+
+			mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+			mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
+			mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+			mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+			mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+			mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+			mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+
+			handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, nil, testDeliveryFees, Config{})
+			require.NoError(t, err)
+
+			result, err := handler.Handle(ctx, NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+			require.NoError(t, err)
+
+			assert.True(t, tt.wantTotal.Equal(result.Subtotal), "subtotal: want %s, got %s", tt.wantTotal, result.Subtotal)
+			assert.True(t, tt.wantTotal.Equal(result.FinalPrice), "final price: want %s, got %s", tt.wantTotal, result.FinalPrice)
+			assert.Nil(t, cart.GetPricingSnapshot(), "checkout clears the cart and with it the price lock")
+		})
+	}
+}
+
+func TestHandler_Handle_DryRunLocksPricerPrices(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	defer func() {
+		_ = log.Close() //nolint:errcheck // teardown; ignore close error
+	}()
+
+	ctx := context.Background()
+	customerID := uuid.New()
+	goodID := uuid.New()
+
+	item, err := itemv1.NewItemWithPricing(goodID, 2, decimal.NewFromInt(50), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
+
+	mockUoW := mocks.NewMockUnitOfWork(t)
+	mockCartRepo := mocks.NewMockCartRepository(t)
+	mockPricer := mocks.NewMockPricerClient(t)
+
+	// The quote is read and rolled back, then the lock is written in its own transaction
+	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil).Times(2)
+	mockUoW.EXPECT().Rollback(mock.Anything).Return(nil).Once()
+	mockUoW.EXPECT().Commit(mock.Anything).Return(nil).Once()
+	mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil).Times(2)
+	mockCartRepo.EXPECT().Save(mock.Anything, cart).Return(nil).Once()
+	mockPricer.EXPECT().CalculateTotal(mock.Anything, mock.Anything).Return(&ports.CalculateTotalResponse{
+		Subtotal:   decimal.NewFromInt(100),
+		TotalTax:   decimal.NewFromInt(20),
+		FinalPrice: decimal.NewFromInt(120),
+		Currency:   pricing.Currency("USD"),
+		Items:      []ports.PricedItemData{{ProductID: goodID, UnitPrice: decimal.NewFromInt(50)}},
+	}, nil).Once()
+
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mocks.NewMockOrderRepository(t), mocks.NewMockEventPublisher(t),
+		mocks.NewMockEventStore(t), nil, mockPricer, nil, testDeliveryFees, Config{})
+	require.NoError(t, err)
+
+	cmd := NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil)
+	cmd.DryRun = true
+
+	result, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.Nil(t, result.Order)
+	assert.True(t, decimal.NewFromInt(120).Equal(result.FinalPrice), "final price %s", result.FinalPrice)
+
+	snapshot, ok := cart.ValidPricingSnapshot(time.Now())
+	require.True(t, ok, "the review locks the pricer result")
+	assert.True(t, decimal.NewFromInt(120).Equal(snapshot.GetTotals().FinalPrice))
+	require.Len(t, snapshot.GetTotals().Items, 1)
+	assert.Equal(t, goodID, snapshot.GetTotals().Items[0].GoodID)
+}
+
+func TestHandler_Handle_ReusedPricingSnapshotIsReconciled(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	defer func() {
+		_ = log.Close() //nolint:errcheck // teardown; ignore close error
+	}()
+
+	ctx := context.Background()
+	customerID := uuid.New()
+	goodID := uuid.New()
+
+	item, err := itemv1.NewItemWithPricing(goodID, 2, decimal.NewFromInt(50), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
+	// The lock priced the good lower than the cart records it
+	cart.SnapshotPricing(cartv1.PricingTotals{
+		Subtotal:   decimal.NewFromInt(90),
+		FinalPrice: decimal.NewFromInt(90),
+		Items:      []cartv1.PricedItem{{GoodID: goodID, UnitPrice: decimal.NewFromInt(45)}},
+	}, time.Now())
+
+	mockUoW := mocks.NewMockUnitOfWork(t)
+	mockCartRepo := mocks.NewMockCartRepository(t)
+
+	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+	mockUoW.EXPECT().Rollback(mock.Anything).Return(nil)
+	mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mocks.NewMockOrderRepository(t), mocks.NewMockEventPublisher(t),
+		mocks.NewMockEventStore(t), nil, nil, nil, testDeliveryFees, Config{})
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+	require.ErrorIs(t, err, order_pricing.ErrPriceDiscrepancy)
+}
+
+func TestHandler_Handle_CarriesNotes(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	defer func() {
+		_ = log.Close() //nolint:errcheck // teardown; ignore close error
+	}()
+
+	ctx := context.Background()
+	customerID := uuid.New()
+
+	item, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(50), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+	item, err = item.WithNote("wrap in blue paper")
+	require.NoError(t, err)
+
+	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "Happy birthday!")
+
+	mockUoW := mocks.NewMockUnitOfWork(t)
+	mockCartRepo := mocks.NewMockCartRepository(t)
+	mockOrderRepo := mocks.NewMockOrderRepository(t)
+	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
+
+	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+	mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
+	mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+	mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+	mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, nil, testDeliveryFees, Config{})
+	require.NoError(t, err)
+
+	result, err := handler.Handle(ctx, NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Happy birthday!", result.Order.GetGiftMessage())
+	require.Len(t, result.Order.GetItems(), 1)
+	assert.Equal(t, "wrap in blue paper", result.Order.GetItems()[0].GetNote())
+	assert.Empty(t, cart.GetGiftMessage(), "checkout clears the cart and with it the gift message")
+}
+
+// releasingGoodsIndex records the demand released per good; other index calls are not expected.
+type releasingGoodsIndex struct {
+	ports.CartGoodsIndex
+
+	released map[uuid.UUID]int64
+}
+
+func (s *releasingGoodsIndex) DecrementGoodDemand(_ context.Context, goodID, _ uuid.UUID, quantity int64) error {
+	s.released[goodID] += quantity
+
+	return nil
+}
+
+func TestHandler_Handle_ReleasesGoodDemand(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	defer func() {
+		_ = log.Close() //nolint:errcheck // teardown; ignore close error
+	}()
+
+	ctx := context.Background()
+	customerID, goodA, goodB := uuid.New(), uuid.New(), uuid.New()
+
+	itemA, err := itemv1.NewItemWithPricing(goodA, 2, decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+	itemB, err := itemv1.NewItemWithPricing(goodB, 5, decimal.NewFromInt(3), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	cart := cartv1.Reconstitute(customerID, itemsv1.Items{itemA, itemB}, 1, nil, "")
+
+	mockUoW := mocks.NewMockUnitOfWork(t)
+	mockCartRepo := mocks.NewMockCartRepository(t)
+	mockOrderRepo := mocks.NewMockOrderRepository(t)
+	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
+
+	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+	mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
+	mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+	mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+	mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+
+	index := &releasingGoodsIndex{released: map[uuid.UUID]int64{}}
+
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, index, nil, nil, testDeliveryFees, Config{})
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[uuid.UUID]int64{goodA: 2, goodB: 5}, index.released)
+}
+
+func TestHandler_Handle_MinimumOrderValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		unitPrice     int64
+		wantShortfall decimal.Decimal
+	}{
+		{"just below the minimum", 49, decimal.NewFromInt(2)},
+		{"exactly at the minimum", 50, decimal.Zero},
+		{"above the minimum", 60, decimal.Zero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, err := logger.New(logger.Default())
+			require.NoError(t, err)
+
+			defer func() {
+				_ = log.Close() //nolint:errcheck // teardown; ignore close error
+			}()
+
+			ctx := context.Background()
+			customerID := uuid.New()
+
+			item, err := itemv1.NewItemWithPricing(uuid.New(), 2, decimal.NewFromInt(tt.unitPrice), decimal.Zero, decimal.Zero)
+			require.NoError(t, err)
+
+			cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
+			below := tt.wantShortfall.IsPositive()
+
+			mockUoW := mocks.NewMockUnitOfWork(t)
+			mockCartRepo := mocks.NewMockCartRepository(t)
+			mockOrderRepo := mocks.NewMockOrderRepository(t)
+			mockPublisher := mocks.NewMockEventPublisher(t)
+			mockEventStore := mocks.NewMockEventStore(t)
+
+			mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+			mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+
+			if below {
+				mockUoW.EXPECT().Rollback(mock.Anything).Return(nil)
+			} else {
+				mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
+				mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+				mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+				mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+				mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+			}
+
+			handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, nil, testDeliveryFees,
+				Config{MinOrderValue: decimal.NewFromInt(100)})
+			require.NoError(t, err)
+
+			result, err := handler.Handle(ctx, NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+			if !below {
+				require.NoError(t, err)
+				assert.NotNil(t, result.Order)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrBelowMinimumOrder)
+			require.ErrorIs(t, err, domain.ErrValidation)
+
+			var belowErr *BelowMinimumOrderError
+			require.ErrorAs(t, err, &belowErr)
+			assert.True(t, tt.wantShortfall.Equal(belowErr.Shortfall), "shortfall: want %s, got %s", tt.wantShortfall, belowErr.Shortfall)
+			assert.NotEmpty(t, cart.GetItems(), "a refused checkout keeps the cart")
+		})
+	}
+}
+
+func TestHandler_Handle_DryRunMinimumOrderValue(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	defer func() {
+		_ = log.Close() //nolint:errcheck // teardown; ignore close error
+	}()
+
+	ctx := context.Background()
+	customerID := uuid.New()
+
+	item, err := itemv1.NewItemWithPricing(uuid.New(), 2, decimal.NewFromInt(49), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
+
+	// Strict mocks: any Save, Publish or Commit fails the test
+	mockUoW := mocks.NewMockUnitOfWork(t)
+	mockCartRepo := mocks.NewMockCartRepository(t)
+
+	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+	mockUoW.EXPECT().Rollback(mock.Anything).Return(nil)
+	mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mocks.NewMockOrderRepository(t), mocks.NewMockEventPublisher(t),
+		mocks.NewMockEventStore(t), nil, nil, nil, testDeliveryFees, Config{MinOrderValue: decimal.NewFromInt(100)})
+	require.NoError(t, err)
+
+	cmd := NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil)
+	cmd.DryRun = true
+
+	result, err := handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, ErrBelowMinimumOrder)
+
+	var belowErr *BelowMinimumOrderError
+	require.ErrorAs(t, err, &belowErr)
+	assert.True(t, decimal.NewFromInt(2).Equal(belowErr.Shortfall), "shortfall %s", belowErr.Shortfall)
+	assert.Nil(t, result.Order)
+	assert.Len(t, cart.GetItems(), 1, "a dry run must not clear the cart")
+}
+
+func TestHandler_Handle_TaxExemption(t *testing.T) {
+	tests := []struct {
+		name          string
+		exemptionCode string
+		wantTax       decimal.Decimal
+		wantFinal     decimal.Decimal
+	}{
+		// 2 x (100 - 10 discount + 20 tax)
+		{"taxable customer", "", decimal.NewFromInt(40), decimal.NewFromInt(220)},
+		{"exempt customer", "B2B", decimal.Zero, decimal.NewFromInt(180)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, err := logger.New(logger.Default())
+			require.NoError(t, err)
+
+			defer func() {
+				_ = log.Close() //nolint:errcheck // teardown; ignore close error
+			}()
+
+			ctx := context.Background()
+			customerID := uuid.New()
+
+			// Same cart for both customers
+			item, err := itemv1.NewItemWithPricing(uuid.New(), 2, decimal.NewFromInt(100), decimal.NewFromInt(10), decimal.NewFromInt(20))
+			require.NoError(t, err)
+
+			cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
+
+			mockUoW := mocks.NewMockUnitOfWork(t)
+			mockCartRepo := mocks.NewMockCartRepository(t)
+			mockOrderRepo := mocks.NewMockOrderRepository(t)
+			mockPublisher := mocks.NewMockEventPublisher(t)
+			mockEventStore := mocks.NewMockEventStore(t)
+
+			mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+			mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
+			mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+			mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+			mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+			mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+			mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+
+			handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, nil, testDeliveryFees, Config{})
+			require.NoError(t, err)
+
+			cmd := NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil)
+			cmd.TaxExemptionCode = tt.exemptionCode
+
+			result, err := handler.Handle(ctx, cmd)
+			require.NoError(t, err)
+
+			assert.True(t, tt.wantTax.Equal(result.TotalTax), "tax: want %s, got %s", tt.wantTax, result.TotalTax)
+			assert.True(t, tt.wantFinal.Equal(result.FinalPrice), "final price: want %s, got %s", tt.wantFinal, result.FinalPrice)
+			assert.True(t, decimal.NewFromInt(20).Equal(result.TotalDiscount), "exemption leaves discounts alone")
+			assert.Equal(t, tt.exemptionCode, result.TaxExemptionCode)
+		})
+	}
+}
+
+func TestHandler_Handle_OrderDiscount(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	defer func() {
+		_ = log.Close() //nolint:errcheck // teardown; ignore close error
+	}()
+
+	ctx := context.Background()
+	customerID := uuid.New()
+
+	item, err := itemv1.NewItemWithPricing(uuid.New(), 2, decimal.NewFromInt(60), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
+
+	mockUoW := mocks.NewMockUnitOfWork(t)
+	mockCartRepo := mocks.NewMockCartRepository(t)
+	mockOrderRepo := mocks.NewMockOrderRepository(t)
+	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
+	mockPricer := mocks.NewMockPricerClient(t)
+
+	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+	mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
+	mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+	mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+	mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+
+	// 120 - 5 item discount - 10 order discount + 6 tax
+	mockPricer.EXPECT().CalculateTotal(mock.Anything, mock.Anything).Return(&ports.CalculateTotalResponse{
+		Subtotal:      decimal.NewFromInt(120),
+		TotalDiscount: decimal.NewFromInt(5),
+		OrderDiscount: decimal.NewFromInt(10),
+		TotalTax:      decimal.NewFromInt(6),
+		FinalPrice:    decimal.NewFromInt(111),
+	}, nil)
+
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil,
+		mockPricer, nil, testDeliveryFees, Config{})
+	require.NoError(t, err)
+
+	result, err := handler.Handle(ctx, NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+	require.NoError(t, err)
+
+	assert.True(t, decimal.NewFromInt(5).Equal(result.TotalDiscount), "item discounts, got %s", result.TotalDiscount)
+	assert.True(t, decimal.NewFromInt(10).Equal(result.OrderDiscount), "order discount, got %s", result.OrderDiscount)
+	assert.True(t, result.Subtotal.Sub(result.TotalDiscount).Sub(result.OrderDiscount).Add(result.TotalTax).Equal(result.FinalPrice))
+	assert.True(t, decimal.NewFromInt(10).Equal(result.Order.GetOrderDiscount()), "the order discount is stored on the order")
+}
+
+func TestHandler_Handle_DryRun(t *testing.T) {
+	tests := []struct {
+		name            string
+		fulfillmentType orderDomain.FulfillmentType
+		wantFee         decimal.Decimal
+	}{
+		{"delivery", orderDomain.FulfillmentType_FULFILLMENT_TYPE_DELIVERY, decimal.NewFromInt(7)},
+		{"pickup", orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, decimal.Zero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, err := logger.New(logger.Default())
+			require.NoError(t, err)
+
+			defer func() {
+				_ = log.Close() //nolint:errcheck // teardown; ignore close error
+			}()
+
+			ctx := context.Background()
+			customerID := uuid.New()
+
+			item, err := itemv1.NewItemWithPricing(uuid.New(), 2, decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
+			require.NoError(t, err)
+
+			cart := cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, "")
+
+			// Strict mocks: any Save, Publish or Commit fails the test
+			mockUoW := mocks.NewMockUnitOfWork(t)
+			mockCartRepo := mocks.NewMockCartRepository(t)
+			mockOrderRepo := mocks.NewMockOrderRepository(t)
+			mockPublisher := mocks.NewMockEventPublisher(t)
+			mockEventStore := mocks.NewMockEventStore(t)
+
+			mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+			mockUoW.EXPECT().Rollback(mock.Anything).Return(nil)
+			mockCartRepo.EXPECT().Load(mock.Anything, customerID).Return(cart, nil)
+
+			handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, nil, testDeliveryFees, Config{})
+			require.NoError(t, err)
+
+			var deliveryInfo *orderDomain.DeliveryInfo
+			if tt.fulfillmentType == orderDomain.FulfillmentType_FULFILLMENT_TYPE_DELIVERY {
+				deliveryInfo = newCheckoutDeliveryInfo(t)
+			}
+
+			cmd := NewCommand(customerID, tt.fulfillmentType, deliveryInfo)
+			cmd.DryRun = true
+
+			result, err := handler.Handle(ctx, cmd)
+			require.NoError(t, err)
+
+			assert.Nil(t, result.Order, "a dry run must not create an order")
+			assert.True(t, decimal.NewFromInt(20).Equal(result.Subtotal), "subtotal %s", result.Subtotal)
+			assert.True(t, tt.wantFee.Equal(result.DeliveryFee), "delivery fee: want %s, got %s", tt.wantFee, result.DeliveryFee)
+			assert.True(t, result.Subtotal.Add(tt.wantFee).Equal(result.FinalPrice), "final price %s", result.FinalPrice)
+			assert.Len(t, cart.GetItems(), 1, "a dry run must not clear the cart")
+		})
+	}
+}
+
+func TestHandler_Handle_InvalidFulfillment(t *testing.T) {
+	tests := []struct {
+		name            string
+		fulfillmentType orderDomain.FulfillmentType
+		withDelivery    bool
+		wantErr         error
+	}{
+		{"delivery without delivery info", orderDomain.FulfillmentType_FULFILLMENT_TYPE_DELIVERY, false, orderDomain.ErrDeliveryInfoRequired},
+		{"pickup with delivery info", orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, true, orderDomain.ErrDeliveryInfoNotAllowedForPickup},
+		{"unspecified without delivery info", orderDomain.FulfillmentType_FULFILLMENT_TYPE_UNSPECIFIED, false, orderDomain.ErrFulfillmentTypeRequired},
+		{"unspecified with delivery info", orderDomain.FulfillmentType_FULFILLMENT_TYPE_UNSPECIFIED, true, orderDomain.ErrFulfillmentTypeRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, err := logger.New(logger.Default())
+			require.NoError(t, err)
+
+			defer func() {
+				_ = log.Close() //nolint:errcheck // teardown; ignore close error
+			}()
+
+			// Invalid combinations are rejected before any transaction or repository access.
+			handler, err := NewHandler(
+				log,
+				mocks.NewMockUnitOfWork(t),
+				mocks.NewMockCartRepository(t),
+				mocks.NewMockOrderRepository(t),
+				mocks.NewMockEventPublisher(t),
+				mocks.NewMockEventStore(t), nil,
+				nil,
+				nil,
+				testDeliveryFees,
+				Config{},
+			)
+			require.NoError(t, err)
+
+			var deliveryInfo *orderDomain.DeliveryInfo
+			if tt.withDelivery {
+				deliveryInfo = newCheckoutDeliveryInfo(t)
+			}
+
+			result, err := handler.Handle(context.Background(), NewCommand(uuid.New(), tt.fulfillmentType, deliveryInfo))
+			require.ErrorIs(t, err, tt.wantErr)
+			require.ErrorIs(t, err, domain.ErrValidation)
+			assert.Nil(t, result.Order)
+		})
+	}
+}
+
+func TestHandler_Handle_RateLimited(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	defer func() {
+		_ = log.Close() //nolint:errcheck // teardown; ignore close error
+	}()
+
+	ctx := context.Background()
+	customerID := uuid.New()
+
+	item, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	mockUoW := mocks.NewMockUnitOfWork(t)
+	mockCartRepo := mocks.NewMockCartRepository(t)
+	mockOrderRepo := mocks.NewMockOrderRepository(t)
+	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
+	mockLimiter := mocks.NewMockRateLimiter(t)
+
+	mockLimiter.EXPECT().Allow(mock.Anything, customerID.String()).
+		Return(ports.RateLimitDecision{Allowed: true}, nil).Once()
+	mockLimiter.EXPECT().Allow(mock.Anything, customerID.String()).
+		Return(ports.RateLimitDecision{RetryAfter: 6 * time.Second}, nil).Once()
+
+	// Repository work happens exactly once: for the allowed checkout only.
+	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil).Once()
+	mockUoW.EXPECT().Commit(mock.Anything).Return(nil).Once()
+	mockCartRepo.EXPECT().Load(mock.Anything, customerID).
+		Return(cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, ""), nil).Once()
+	mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+	mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+	mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil).Once()
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil).Once()
+
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, mockLimiter, testDeliveryFees, Config{})
+	require.NoError(t, err)
+
+	cmd := NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil)
+
+	result, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	require.NotNil(t, result.Order)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, domain.ErrRateLimited)
+
+	var limitErr *domain.RateLimitedError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, 6*time.Second, limitErr.RetryAfter)
+}
+
+func TestHandler_Handle_RateLimiterErrorAllowsCheckout(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	defer func() {
+		_ = log.Close() //nolint:errcheck // teardown; ignore close error
+	}()
+
+	ctx := context.Background()
+	customerID := uuid.New()
+
+	item, err := itemv1.NewItemWithPricing(uuid.New(), 1, decimal.NewFromInt(10), decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	mockUoW := mocks.NewMockUnitOfWork(t)
+	mockCartRepo := mocks.NewMockCartRepository(t)
+	mockOrderRepo := mocks.NewMockOrderRepository(t)
+	mockPublisher := mocks.NewMockEventPublisher(t)
+	mockEventStore := mocks.NewMockEventStore(t)
+	mockLimiter := mocks.NewMockRateLimiter(t)
+
+	mockLimiter.EXPECT().Allow(mock.Anything, customerID.String()).
+		Return(ports.RateLimitDecision{}, errors.New("redis is down"))
+
+	mockUoW.EXPECT().Begin(mock.Anything).Return(ctx, nil)
+	mockUoW.EXPECT().Commit(mock.Anything).Return(nil)
+	mockCartRepo.EXPECT().Load(mock.Anything, customerID).
+		Return(cartv1.Reconstitute(customerID, itemsv1.Items{item}, 1, nil, ""), nil)
+	mockCartRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockOrderRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	mockEventStore.EXPECT().Append(mock.Anything, mock.Anything, mock.Anything, 0).Return(nil)
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+
+	handler, err := NewHandler(log, mockUoW, mockCartRepo, mockOrderRepo, mockPublisher, mockEventStore, nil, nil, mockLimiter, testDeliveryFees, Config{})
+	require.NoError(t, err)
+
+	result, err := handler.Handle(ctx, NewCommand(customerID, orderDomain.FulfillmentType_FULFILLMENT_TYPE_PICKUP, nil))
+	require.NoError(t, err)
+	require.NotNil(t, result.Order)
+}
@@ -10,9 +10,12 @@ func cartItemsToLines(cartItems cartItemsv1.Items) []orderDomain.Line {
 	lines := make([]orderDomain.Line, 0, len(cartItems))
 	for _, item := range cartItems {
 		lines = append(lines, orderDomain.Line{
-			ProductID: item.GetGoodId(),
-			Qty:       item.GetQuantity(),
-			UnitPrice: item.GetPrice(),
+			ProductID:   item.GetGoodId(),
+			Qty:         item.GetQuantity(),
+			UnitPrice:   item.GetPrice(),
+			Currency:    item.GetCurrency(),
+			Note:        item.GetNote(),
+			TaxCategory: item.GetTaxCategory(),
 		})
 	}
 
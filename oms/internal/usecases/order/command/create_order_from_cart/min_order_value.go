@@ -0,0 +1,41 @@
+package create_order_from_cart
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+)
+
+// ErrBelowMinimumOrder is returned when the cart subtotal is below the configured minimum order value.
+var ErrBelowMinimumOrder = fmt.Errorf("%w: order is below the minimum order value", domain.ErrValidation)
+
+// BelowMinimumOrderError reports how far the cart subtotal falls short of the minimum order value.
+type BelowMinimumOrderError struct {
+	Subtotal  decimal.Decimal
+	Minimum   decimal.Decimal
+	Shortfall decimal.Decimal
+}
+
+func (e *BelowMinimumOrderError) Error() string {
+	return fmt.Sprintf("subtotal %s is below the minimum order value %s (short by %s)", e.Subtotal, e.Minimum, e.Shortfall)
+}
+
+// Unwrap lets callers match the error with errors.Is(err, ErrBelowMinimumOrder).
+func (e *BelowMinimumOrderError) Unwrap() error {
+	return ErrBelowMinimumOrder
+}
+
+// checkMinimumOrderValue rejects a subtotal below minimum; a zero minimum disables the check.
+func checkMinimumOrderValue(subtotal, minimum decimal.Decimal) error {
+	if !minimum.IsPositive() || subtotal.GreaterThanOrEqual(minimum) {
+		return nil
+	}
+
+	return &BelowMinimumOrderError{
+		Subtotal:  subtotal,
+		Minimum:   minimum,
+		Shortfall: minimum.Sub(subtotal),
+	}
+}
@@ -144,6 +144,65 @@ func (_c *MockOrderRepository_ListByCustomer_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// ListPage provides a mock function with given fields: ctx, filter
+func (_m *MockOrderRepository) ListPage(ctx context.Context, filter ports.ListPageFilter) (*ports.OrderPage, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPage")
+	}
+
+	var r0 *ports.OrderPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ports.ListPageFilter) (*ports.OrderPage, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ports.ListPageFilter) *ports.OrderPage); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ports.OrderPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ports.ListPageFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrderRepository_ListPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPage'
+type MockOrderRepository_ListPage_Call struct {
+	*mock.Call
+}
+
+// ListPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter ports.ListPageFilter
+func (_e *MockOrderRepository_Expecter) ListPage(ctx interface{}, filter interface{}) *MockOrderRepository_ListPage_Call {
+	return &MockOrderRepository_ListPage_Call{Call: _e.mock.On("ListPage", ctx, filter)}
+}
+
+func (_c *MockOrderRepository_ListPage_Call) Run(run func(ctx context.Context, filter ports.ListPageFilter)) *MockOrderRepository_ListPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ports.ListPageFilter))
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_ListPage_Call) Return(_a0 *ports.OrderPage, _a1 error) *MockOrderRepository_ListPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrderRepository_ListPage_Call) RunAndReturn(run func(context.Context, ports.ListPageFilter) (*ports.OrderPage, error)) *MockOrderRepository_ListPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Load provides a mock function with given fields: ctx, orderID
 func (_m *MockOrderRepository) Load(ctx context.Context, orderID uuid.UUID) (*v1.OrderState, error) {
 	ret := _m.Called(ctx, orderID)
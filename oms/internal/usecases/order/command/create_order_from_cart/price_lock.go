@@ -0,0 +1,45 @@
+package create_order_from_cart
+
+import (
+	cartv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+// pricingTotalsFromResponse converts a pricer result into the cart's price lock, per-item breakdown included.
+func pricingTotalsFromResponse(resp ports.CalculateTotalResponse) cartv1.PricingTotals {
+	items := make([]cartv1.PricedItem, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		items = append(items, cartv1.PricedItem{GoodID: item.ProductID, UnitPrice: item.UnitPrice})
+	}
+
+	return cartv1.PricingTotals{
+		Subtotal:      resp.Subtotal,
+		TotalDiscount: resp.TotalDiscount,
+		OrderDiscount: resp.OrderDiscount,
+		TotalTax:      resp.TotalTax,
+		FinalPrice:    resp.FinalPrice,
+		Currency:      resp.Currency,
+		Policies:      resp.Policies,
+		Items:         items,
+	}
+}
+
+// pricingResponseFromTotals converts a price lock back into the pricer result checkout works with,
+// so a reused lock is reconciled against the cart prices like a fresh pricer result.
+func pricingResponseFromTotals(totals cartv1.PricingTotals) ports.CalculateTotalResponse {
+	items := make([]ports.PricedItemData, 0, len(totals.Items))
+	for _, item := range totals.Items {
+		items = append(items, ports.PricedItemData{ProductID: item.GoodID, UnitPrice: item.UnitPrice})
+	}
+
+	return ports.CalculateTotalResponse{
+		Subtotal:      totals.Subtotal,
+		TotalDiscount: totals.TotalDiscount,
+		OrderDiscount: totals.OrderDiscount,
+		TotalTax:      totals.TotalTax,
+		FinalPrice:    totals.FinalPrice,
+		Policies:      totals.Policies,
+		Currency:      totals.Currency,
+		Items:         items,
+	}
+}
@@ -0,0 +1,22 @@
+package create_order_from_cart
+
+import (
+	cartItemsv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/items/v1"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/order_pricing"
+)
+
+// pricingLines converts cart items to the lines the shared pricing rules work on.
+func pricingLines(cartItems cartItemsv1.Items) []order_pricing.Line {
+	lines := make([]order_pricing.Line, 0, len(cartItems))
+	for _, item := range cartItems {
+		lines = append(lines, order_pricing.Line{
+			ProductID: item.GetGoodId(),
+			Quantity:  item.GetQuantity(),
+			UnitPrice: item.GetPrice(),
+			Discount:  item.GetDiscount(),
+			Tax:       item.GetTax(),
+		})
+	}
+
+	return lines
+}
@@ -0,0 +1,31 @@
+package create_order_from_cart
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedPricerFailurePolicy is returned for an unknown pricer failure policy.
+var ErrUnsupportedPricerFailurePolicy = errors.New("unsupported pricer failure policy")
+
+// PricerFailurePolicy decides what checkout does when the pricer cannot price the cart.
+type PricerFailurePolicy string
+
+const (
+	// PricerFailurePolicyFail refuses the checkout as unavailable (the default).
+	PricerFailurePolicyFail PricerFailurePolicy = "FAIL"
+	// PricerFailurePolicyFallbackToCart prices the order from the cart prices and discounts, without tax.
+	PricerFailurePolicyFallbackToCart PricerFailurePolicy = "FALLBACK_TO_CART"
+)
+
+// ParsePricerFailurePolicy parses a policy; an empty value selects PricerFailurePolicyFail.
+func ParsePricerFailurePolicy(raw string) (PricerFailurePolicy, error) {
+	switch PricerFailurePolicy(raw) {
+	case "":
+		return PricerFailurePolicyFail, nil
+	case PricerFailurePolicyFail, PricerFailurePolicyFallbackToCart:
+		return PricerFailurePolicy(raw), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedPricerFailurePolicy, raw)
+	}
+}
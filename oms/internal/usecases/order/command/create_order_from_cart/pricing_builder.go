@@ -5,8 +5,13 @@ import (
 
 	cartItemsv1 "github.com/shortlink-org/shop/oms/internal/domain/cart/v1/items/v1"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	pricing "github.com/shortlink-org/shop/oms/internal/domain/pricing"
 )
 
+// TaxExemptionParam is the pricer tax parameter carrying the customer's exemption code;
+// the pricer tax policies charge no tax while it is set.
+const TaxExemptionParam = "tax_exemption_code"
+
 // PricerRequestBuilder builds a CalculateTotalRequest with optional discount/tax params.
 type PricerRequestBuilder struct {
 	req ports.CalculateTotalRequest
@@ -17,9 +22,11 @@ func NewPricerRequestBuilder(customerID uuid.UUID, items cartItemsv1.Items) *Pri
 	cartItems := make([]ports.CartItemData, 0, len(items))
 	for _, item := range items {
 		cartItems = append(cartItems, ports.CartItemData{
-			ProductID: item.GetGoodId(),
-			Quantity:  item.GetQuantity(),
-			UnitPrice: item.GetPrice(),
+			ProductID:   item.GetGoodId(),
+			Quantity:    item.GetQuantity(),
+			UnitPrice:   item.GetPrice(),
+			Currency:    item.GetCurrency(),
+			TaxCategory: item.GetTaxCategory(),
 		})
 	}
 
@@ -35,6 +42,13 @@ func NewPricerRequestBuilder(customerID uuid.UUID, items cartItemsv1.Items) *Pri
 	}
 }
 
+// WithCurrency sets the cart currency the pricer must price in.
+func (b *PricerRequestBuilder) WithCurrency(currency pricing.Currency) *PricerRequestBuilder {
+	b.req.Currency = currency
+
+	return b
+}
+
 // WithDiscountParam adds a discount parameter (e.g. promo code, customer segment).
 func (b *PricerRequestBuilder) WithDiscountParam(k, v string) *PricerRequestBuilder {
 	if b.req.DiscountParams == nil {
@@ -57,6 +71,15 @@ func (b *PricerRequestBuilder) WithTaxParam(k, v string) *PricerRequestBuilder {
 	return b
 }
 
+// WithTaxExemption marks the request tax-exempt under code; an empty code leaves it taxable.
+func (b *PricerRequestBuilder) WithTaxExemption(code string) *PricerRequestBuilder {
+	if code == "" {
+		return b
+	}
+
+	return b.WithTaxParam(TaxExemptionParam, code)
+}
+
 // Build returns the built request.
 func (b *PricerRequestBuilder) Build() ports.CalculateTotalRequest {
 	return b.req
@@ -0,0 +1,18 @@
+package expire_pending_orders
+
+import (
+	"time"
+)
+
+// Command represents a command to cancel PENDING orders that never progressed.
+type Command struct {
+	// CreatedBefore is the exclusive upper bound of the creation time of expired orders
+	CreatedBefore time.Time
+}
+
+// NewCommand creates a new ExpirePendingOrders command for orders older than maxAge at now.
+func NewCommand(now time.Time, maxAge time.Duration) Command {
+	return Command{
+		CreatedBefore: now.Add(-maxAge),
+	}
+}
@@ -0,0 +1,191 @@
+package expire_pending_orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
+)
+
+const (
+	// ExpiredReason is the cancellation reason recorded on expired orders.
+	ExpiredReason = "EXPIRED"
+	// pageSize bounds the number of stale orders loaded per page.
+	pageSize int32 = 100
+)
+
+var errMissingCutoff = errors.New("created-before cutoff is required")
+
+// Result reports how many orders were expired.
+type Result struct {
+	Expired int
+}
+
+// Handler handles ExpirePendingOrders commands.
+type Handler struct {
+	uow       ports.UnitOfWork
+	orderRepo ports.OrderRepository
+	publisher ports.EventPublisher
+}
+
+// NewHandler creates a new ExpirePendingOrders handler.
+func NewHandler(
+	uow ports.UnitOfWork,
+	orderRepo ports.OrderRepository,
+	publisher ports.EventPublisher,
+) (*Handler, error) {
+	return &Handler{
+		uow:       uow,
+		orderRepo: orderRepo,
+		publisher: publisher,
+	}, nil
+}
+
+// Handle cancels every PENDING order created before the cutoff with reason EXPIRED.
+// Each order is cancelled in its own transaction, retried on optimistic-lock conflicts;
+// orders that left PENDING in the meantime are skipped. Failures do not stop the run
+// and are returned joined once every stale order was attempted.
+func (h *Handler) Handle(ctx context.Context, cmd Command) (Result, error) {
+	if cmd.CreatedBefore.IsZero() {
+		return Result{}, domain.WrapValidation("ExpirePendingOrders", errMissingCutoff)
+	}
+
+	orderIDs, err := h.listStale(ctx, cmd)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var (
+		result Result
+		errs   []error
+	)
+
+	for _, orderID := range orderIDs {
+		expired, err := h.expire(ctx, orderID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("expire order %s: %w", orderID, err))
+
+			continue
+		}
+
+		if expired {
+			result.Expired++
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// listStale collects the IDs of all PENDING orders created before the cutoff.
+// IDs are gathered before cancelling so status changes do not shift the pages being read.
+func (h *Handler) listStale(ctx context.Context, cmd Command) ([]uuid.UUID, error) {
+	ctx, err := h.uow.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		rollbackErr := h.uow.Rollback(ctx)
+		if rollbackErr != nil {
+			slog.Default().WarnContext(ctx, "transaction rollback failed", "error", rollbackErr)
+		}
+	}()
+
+	var (
+		orderIDs  []uuid.UUID
+		pageToken string
+	)
+
+	for {
+		page, err := h.orderRepo.ListPage(ctx, ports.ListPageFilter{
+			ListFilter: ports.ListFilter{
+				StatusFilter: []orderv1.OrderStatus{orderv1.OrderStatus_ORDER_STATUS_PENDING},
+			},
+			CreatedTo: &cmd.CreatedBefore,
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, domain.MapInfraErr("orderRepo.ListPage", err)
+		}
+
+		for _, order := range page.Orders {
+			orderIDs = append(orderIDs, order.GetOrderID())
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+
+		pageToken = page.NextPageToken
+	}
+
+	if err := h.uow.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return orderIDs, nil
+}
+
+// expire cancels a single order and reports whether it was still PENDING.
+// Pattern: Load -> Domain method -> Save -> Publish event
+func (h *Handler) expire(ctx context.Context, orderID uuid.UUID) (bool, error) {
+	var order *orderv1.OrderState
+
+	err := uow.RunWithRetry(ctx, h.uow, uow.DefaultMaxAttempts, domain.ErrVersionConflict, func(ctx context.Context) error {
+		// 1. Load order aggregate
+		var err error
+
+		order, err = h.orderRepo.Load(ctx, orderID)
+		if err != nil {
+			return domain.MapInfraErr("orderRepo.Load", err)
+		}
+
+		// The order progressed since it was listed: nothing to expire.
+		if order.GetStatus() != orderv1.OrderStatus_ORDER_STATUS_PENDING {
+			order = nil
+
+			return nil
+		}
+
+		// 2. Apply business logic (cancel order)
+		err = order.CancelOrderWithReason(ExpiredReason)
+		if err != nil {
+			return err
+		}
+
+		// 3. Persist to database (version-checked)
+		err = h.orderRepo.Save(ctx, order)
+		if err != nil {
+			return domain.MapInfraErr("orderRepo.Save", err)
+		}
+
+		// 4. Publish domain events to outbox (same transaction)
+		for _, event := range order.GetDomainEvents() {
+			pubErr := h.publisher.Publish(ctx, event)
+			if pubErr != nil {
+				return domain.MapInfraErr("eventBus.Publish", pubErr)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if order == nil {
+		return false, nil
+	}
+
+	order.ClearDomainEvents()
+
+	return true, nil
+}
@@ -0,0 +1,214 @@
+package expire_pending_orders
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	eventsv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(ctx context.Context) (context.Context, error) { return ctx, nil }
+func (stubUnitOfWork) Commit(context.Context) error                       { return nil }
+func (stubUnitOfWork) Rollback(context.Context) error                     { return nil }
+
+type storedOrder struct {
+	status    orderv1.OrderStatus
+	createdAt time.Time
+}
+
+// stubOrderRepository keeps orders with their creation time and applies the ListPage
+// status and created-at filters the way the PostgreSQL store does, one order per page.
+type stubOrderRepository struct {
+	orders     map[uuid.UUID]*storedOrder
+	order      []uuid.UUID
+	customerID uuid.UUID
+	saveErrs   []error
+	saved      []uuid.UUID
+}
+
+func newStubOrderRepository() *stubOrderRepository {
+	return &stubOrderRepository{
+		orders:     make(map[uuid.UUID]*storedOrder),
+		customerID: uuid.New(),
+	}
+}
+
+func (s *stubOrderRepository) add(status orderv1.OrderStatus, createdAt time.Time) uuid.UUID {
+	orderID := uuid.New()
+	s.orders[orderID] = &storedOrder{status: status, createdAt: createdAt}
+	s.order = append(s.order, orderID)
+
+	return orderID
+}
+
+func (s *stubOrderRepository) state(orderID uuid.UUID) *orderv1.OrderState {
+	return orderv1.NewOrderStateFromPersisted(
+		orderID,
+		s.customerID,
+		orderv1.Items{orderv1.NewItem(uuid.New(), 1, decimal.NewFromInt(10))},
+		s.orders[orderID].status,
+		1,
+		nil,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+		nil,
+	)
+}
+
+func (s *stubOrderRepository) Load(_ context.Context, orderID uuid.UUID) (*orderv1.OrderState, error) {
+	if _, ok := s.orders[orderID]; !ok {
+		return nil, ports.ErrNotFound
+	}
+
+	return s.state(orderID), nil
+}
+
+func (s *stubOrderRepository) LoadByPackageID(context.Context, uuid.UUID) (*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func (s *stubOrderRepository) Save(_ context.Context, order *orderv1.OrderState) error {
+	if len(s.saveErrs) > 0 {
+		err := s.saveErrs[0]
+		s.saveErrs = s.saveErrs[1:]
+
+		if err != nil {
+			return err
+		}
+	}
+
+	s.orders[order.GetOrderID()].status = order.GetStatus()
+	s.saved = append(s.saved, order.GetOrderID())
+
+	return nil
+}
+
+func (s *stubOrderRepository) List(context.Context, ports.ListFilter) ([]*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func (s *stubOrderRepository) ListPage(_ context.Context, filter ports.ListPageFilter) (*ports.OrderPage, error) {
+	var matching []uuid.UUID
+
+	for _, orderID := range s.order {
+		stored := s.orders[orderID]
+		if len(filter.StatusFilter) > 0 && stored.status != filter.StatusFilter[0] {
+			continue
+		}
+
+		if filter.CreatedTo != nil && !stored.createdAt.Before(*filter.CreatedTo) {
+			continue
+		}
+
+		matching = append(matching, orderID)
+	}
+
+	start := 0
+	if filter.PageToken != "" {
+		for i, orderID := range matching {
+			if orderID.String() == filter.PageToken {
+				start = i + 1
+			}
+		}
+	}
+
+	page := &ports.OrderPage{}
+	if start < len(matching) {
+		page.Orders = []*orderv1.OrderState{s.state(matching[start])}
+		if start+1 < len(matching) {
+			page.NextPageToken = matching[start].String()
+		}
+	}
+
+	return page, nil
+}
+
+func (s *stubOrderRepository) ListByCustomer(context.Context, uuid.UUID) ([]*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+type recordingPublisher struct {
+	events []any
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event any) error {
+	p.events = append(p.events, event)
+
+	return nil
+}
+
+func TestHandler_Handle_CancelsOnlyStaleOrders(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.March, 11, 10, 0, 0, 0, time.UTC)
+	repo := newStubOrderRepository()
+	staleA := repo.add(orderv1.OrderStatus_ORDER_STATUS_PENDING, now.Add(-48*time.Hour))
+	fresh := repo.add(orderv1.OrderStatus_ORDER_STATUS_PENDING, now.Add(-time.Hour))
+	staleB := repo.add(orderv1.OrderStatus_ORDER_STATUS_PENDING, now.Add(-25*time.Hour))
+	staleProcessing := repo.add(orderv1.OrderStatus_ORDER_STATUS_PROCESSING, now.Add(-72*time.Hour))
+
+	publisher := &recordingPublisher{}
+	handler, err := NewHandler(stubUnitOfWork{}, repo, publisher)
+	require.NoError(t, err)
+
+	result, err := handler.Handle(context.Background(), NewCommand(now, 24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Expired)
+	require.Equal(t, []uuid.UUID{staleA, staleB}, repo.saved)
+
+	require.Equal(t, orderv1.OrderStatus_ORDER_STATUS_CANCELED, repo.orders[staleA].status)
+	require.Equal(t, orderv1.OrderStatus_ORDER_STATUS_CANCELED, repo.orders[staleB].status)
+	require.Equal(t, orderv1.OrderStatus_ORDER_STATUS_PENDING, repo.orders[fresh].status)
+	require.Equal(t, orderv1.OrderStatus_ORDER_STATUS_PROCESSING, repo.orders[staleProcessing].status)
+
+	require.Len(t, publisher.events, 2)
+
+	for _, event := range publisher.events {
+		cancelled, ok := event.(*eventsv1.OrderCancelled)
+		require.True(t, ok, "unexpected event %T", event)
+		require.Equal(t, ExpiredReason, cancelled.GetReason())
+	}
+}
+
+func TestHandler_Handle_RetriesVersionConflictAndReportsFailures(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	repo := newStubOrderRepository()
+	retried := repo.add(orderv1.OrderStatus_ORDER_STATUS_PENDING, now.Add(-48*time.Hour))
+	failed := repo.add(orderv1.OrderStatus_ORDER_STATUS_PENDING, now.Add(-48*time.Hour))
+	expired := repo.add(orderv1.OrderStatus_ORDER_STATUS_PENDING, now.Add(-48*time.Hour))
+
+	unavailable := errors.New("connection reset")
+	repo.saveErrs = []error{ports.ErrVersionConflict, nil, unavailable}
+
+	handler, err := NewHandler(stubUnitOfWork{}, repo, &recordingPublisher{})
+	require.NoError(t, err)
+
+	result, err := handler.Handle(context.Background(), NewCommand(now, 24*time.Hour))
+	require.ErrorIs(t, err, domain.ErrUnavailable)
+	require.ErrorContains(t, err, failed.String())
+	require.Equal(t, 2, result.Expired)
+	require.Equal(t, []uuid.UUID{retried, expired}, repo.saved)
+}
+
+func TestHandler_Handle_RequiresCutoff(t *testing.T) {
+	t.Parallel()
+
+	handler, err := NewHandler(stubUnitOfWork{}, newStubOrderRepository(), &recordingPublisher{})
+	require.NoError(t, err)
+
+	_, err = handler.Handle(context.Background(), Command{})
+	require.ErrorIs(t, err, domain.ErrValidation)
+}
@@ -0,0 +1,18 @@
+package set_delivery_status
+
+import (
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+)
+
+// Command applies a delivery status update to an order.
+// It is issued by the order workflow for every delivery status signal it receives.
+type Command struct {
+	Update orderv1.DeliveryStatusUpdate
+}
+
+// NewCommand creates a new SetDeliveryStatus command.
+func NewCommand(update orderv1.DeliveryStatusUpdate) Command {
+	return Command{
+		Update: update,
+	}
+}
@@ -0,0 +1,223 @@
+package set_delivery_status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/shortlink-org/go-sdk/logger"
+
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/kafka"
+)
+
+// ErrUnsupportedDeliveryStatus is returned for an update the order cannot apply.
+var ErrUnsupportedDeliveryStatus = errors.New("unsupported delivery status")
+
+// Handler handles SetDeliveryStatus commands.
+type Handler struct {
+	log       logger.Logger
+	uow       ports.UnitOfWork
+	orderRepo ports.OrderRepository
+	inboxRepo ports.DeliveryInboxRepository
+	publisher ports.EventPublisher
+}
+
+// NewHandler creates a new SetDeliveryStatus handler.
+func NewHandler(
+	log logger.Logger,
+	uow ports.UnitOfWork,
+	orderRepo ports.OrderRepository,
+	inboxRepo ports.DeliveryInboxRepository,
+	publisher ports.EventPublisher,
+) (*Handler, error) {
+	return &Handler{
+		log:       log,
+		uow:       uow,
+		orderRepo: orderRepo,
+		inboxRepo: inboxRepo,
+		publisher: publisher,
+	}, nil
+}
+
+const deliveryInboxConsumerName = "oms.delivery-status"
+
+// Handle executes the SetDeliveryStatus command.
+// Pattern: Begin -> Record inbox -> Load -> Mutate -> Save -> Publish in tx -> Commit.
+// A replayed message or a stale status is committed as a no-op.
+func (h *Handler) Handle(ctx context.Context, cmd Command) error {
+	update := cmd.Update
+	if update.MessageID == "" {
+		return errors.New("delivery update message_id is required")
+	}
+
+	ctx, err := h.uow.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+
+		rollbackErr := h.uow.Rollback(ctx)
+		if rollbackErr != nil {
+			h.log.Warn("transaction rollback failed", slog.Any("error", rollbackErr))
+		}
+	}()
+
+	inserted, err := h.inboxRepo.TryRecord(ctx, deliveryInboxConsumerName, update.MessageID, kafka.TopicDeliveryPackageStatus)
+	if err != nil {
+		return fmt.Errorf("failed to record delivery inbox message: %w", err)
+	}
+
+	if !inserted {
+		h.log.Info("Ignoring duplicate delivery message by inbox",
+			slog.String("message_id", update.MessageID),
+			slog.String("order_id", update.OrderID.String()),
+			slog.String("delivery_status", update.Status.String()))
+
+		if err := h.uow.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to commit noop transaction: %w", err)
+		}
+		committed = true
+
+		return nil
+	}
+
+	order, err := h.orderRepo.Load(ctx, update.OrderID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return fmt.Errorf("order not found for delivery update: %w", err)
+		}
+
+		return fmt.Errorf("failed to load order: %w", err)
+	}
+
+	currentStatus := order.GetPackageDeliveryStatus(update.PackageID)
+	if isDuplicateOrStale(currentStatus, update.Status) {
+		h.log.Info("Ignoring duplicate or stale delivery update",
+			slog.String("order_id", order.GetOrderID().String()),
+			slog.String("package_id", update.PackageID.String()),
+			slog.String("delivery_status", update.Status.String()),
+			slog.String("current_delivery_status", currentStatus.String()))
+
+		if err := h.uow.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to commit noop transaction: %w", err)
+		}
+		committed = true
+
+		return nil
+	}
+
+	if err := applyDeliveryUpdate(order, update); err != nil {
+		return fmt.Errorf("failed to apply delivery update: %w", err)
+	}
+
+	if err := h.orderRepo.Save(ctx, order); err != nil {
+		return fmt.Errorf("failed to save order: %w", err)
+	}
+
+	for _, domainEvent := range order.GetDomainEvents() {
+		if err := h.publisher.Publish(ctx, domainEvent); err != nil {
+			return fmt.Errorf("failed to publish domain event to outbox: %w", err)
+		}
+	}
+
+	if err := h.uow.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+
+	order.ClearDomainEvents()
+
+	h.log.Info("Applied delivery status update",
+		slog.String("order_id", order.GetOrderID().String()),
+		slog.String("new_delivery_status", order.GetDeliveryStatus().String()))
+
+	return nil
+}
+
+// applyDeliveryUpdate routes a package of a split delivery to SetPackageDeliveryStatus;
+// an order shipping as a single package goes through the Apply* lifecycle methods.
+func applyDeliveryUpdate(order *orderv1.OrderState, update orderv1.DeliveryStatusUpdate) error {
+	if order.RoutesByPackage(update.PackageID) {
+		if _, ok := allowedDeliveryTransitionSources[update.Status]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnsupportedDeliveryStatus, update.Status)
+		}
+
+		return order.SetPackageDeliveryStatus(update.PackageID, update.Status, update.OccurredAt)
+	}
+
+	var packageID *uuid.UUID
+	if update.PackageID != uuid.Nil {
+		packageID = &update.PackageID
+	}
+
+	var courierID *uuid.UUID
+	if update.CourierID != uuid.Nil {
+		courierID = &update.CourierID
+	}
+
+	switch update.Status {
+	case commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED:
+		return order.ApplyDeliveryAccepted(packageID, update.OccurredAt)
+	case commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED:
+		return order.ApplyDeliveryAssigned(packageID, courierID, update.OccurredAt)
+	case commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT:
+		return order.ApplyDeliveryInTransit(packageID, courierID, update.OccurredAt)
+	case commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED:
+		return order.ApplyDeliveryDelivered(packageID, courierID, update.DeliveryLocation, update.OccurredAt)
+	case commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED:
+		return order.ApplyDeliveryFailed(packageID, courierID, update.NotDeliveredDetails, update.OccurredAt)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedDeliveryStatus, update.Status)
+	}
+}
+
+func isDuplicateOrStale(currentStatus, targetStatus commonv1.DeliveryStatus) bool {
+	if currentStatus == targetStatus {
+		return true
+	}
+
+	allowedSources, ok := allowedDeliveryTransitionSources[targetStatus]
+	if !ok {
+		return false
+	}
+
+	return !slices.Contains(allowedSources, currentStatus)
+}
+
+var allowedDeliveryTransitionSources = map[commonv1.DeliveryStatus][]commonv1.DeliveryStatus{
+	commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED: {
+		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+	},
+	commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED: {
+		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+	},
+	commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT: {
+		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+	},
+	commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED: {
+		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+	},
+	commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED: {
+		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+	},
+}
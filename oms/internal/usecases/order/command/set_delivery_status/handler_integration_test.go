@@ -1,6 +1,6 @@
 //go:build integration
 
-package on_delivery_status
+package set_delivery_status
 
 import (
 	"context"
@@ -17,36 +17,34 @@ import (
 
 	"github.com/shortlink-org/go-sdk/logger"
 
-	deliverycommon "github.com/shortlink-org/shop/oms/internal/domain/delivery/common/v1"
 	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
 	ordercommon "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
 	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
-	kafkaevent "github.com/shortlink-org/shop/oms/internal/infrastructure/kafka"
 	orderrepo "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/testhelpers"
 	requestdelivery "github.com/shortlink-org/shop/oms/internal/usecases/order/command/request_delivery"
 	uowpg "github.com/shortlink-org/shop/oms/pkg/uow/postgres"
 )
 
-func TestHandleDeliveryStatus_Integration(t *testing.T) {
+func TestHandle_Integration(t *testing.T) {
 	testCases := []struct {
 		name                   string
-		buildTerminalEvent     func(packageID, courierID uuid.UUID, occurredAt time.Time) kafkaevent.DeliveryStatusEvent
+		buildTerminalUpdate    func(orderID, packageID, courierID uuid.UUID, occurredAt time.Time) orderv1.DeliveryStatusUpdate
 		expectedOrderStatus    orderv1.OrderStatus
 		expectedDeliveryStatus ordercommon.DeliveryStatus
 	}{
 		{
 			name: "delivered completes order",
-			buildTerminalEvent: func(packageID, courierID uuid.UUID, occurredAt time.Time) kafkaevent.DeliveryStatusEvent {
-				return kafkaevent.DeliveryStatusEvent{
+			buildTerminalUpdate: func(orderID, packageID, courierID uuid.UUID, occurredAt time.Time) orderv1.DeliveryStatusUpdate {
+				return orderv1.DeliveryStatusUpdate{
 					MessageID:  uuid.NewString(),
+					OrderID:    orderID,
 					PackageID:  packageID,
 					CourierID:  courierID,
-					Status:     "PACKAGE_STATUS_DELIVERED",
-					EventType:  kafkaevent.EventTypePackageDelivered,
+					Status:     ordercommon.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
 					OccurredAt: occurredAt,
-					DeliveryLocation: &deliverycommon.Location{
+					DeliveryLocation: &ordercommon.DeliveryLocation{
 						Latitude:  55.751244,
 						Longitude: 37.618423,
 					},
@@ -57,16 +55,16 @@ func TestHandleDeliveryStatus_Integration(t *testing.T) {
 		},
 		{
 			name: "not delivered cancels order",
-			buildTerminalEvent: func(packageID, courierID uuid.UUID, occurredAt time.Time) kafkaevent.DeliveryStatusEvent {
-				return kafkaevent.DeliveryStatusEvent{
+			buildTerminalUpdate: func(orderID, packageID, courierID uuid.UUID, occurredAt time.Time) orderv1.DeliveryStatusUpdate {
+				return orderv1.DeliveryStatusUpdate{
 					MessageID:  uuid.NewString(),
+					OrderID:    orderID,
 					PackageID:  packageID,
 					CourierID:  courierID,
-					Status:     "PACKAGE_STATUS_NOT_DELIVERED",
-					EventType:  kafkaevent.EventTypePackageNotDelivered,
+					Status:     ordercommon.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED,
 					OccurredAt: occurredAt,
-					NotDeliveredDetails: &deliverycommon.NotDeliveredDetails{
-						Reason:      deliverycommon.NotDeliveredReason_NOT_DELIVERED_REASON_CUSTOMER_NOT_AVAILABLE,
+					NotDeliveredDetails: &ordercommon.NotDeliveredDetails{
+						Reason:      ordercommon.NotDeliveredReason_NOT_DELIVERED_REASON_CUSTOMER_NOT_AVAILABLE,
 						Description: "customer unavailable",
 					},
 				}
@@ -87,39 +85,38 @@ func TestHandleDeliveryStatus_Integration(t *testing.T) {
 
 			require.Equal(t, int64(1), env.outboxCount(t))
 
-			require.NoError(t, env.deliveryHandler.HandleDeliveryStatus(ctx, kafkaevent.DeliveryStatusEvent{
+			require.NoError(t, env.deliveryHandler.Handle(ctx, NewCommand(orderv1.DeliveryStatusUpdate{
 				MessageID:  uuid.NewString(),
 				OrderID:    orderID,
 				PackageID:  packageID,
-				Status:     "PACKAGE_STATUS_ACCEPTED",
-				EventType:  kafkaevent.EventTypePackageAccepted,
+				Status:     ordercommon.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
 				OccurredAt: time.Date(2026, time.March, 11, 10, 1, 0, 0, time.UTC),
-			}))
+			})))
 			require.Equal(t, int64(2), env.outboxCount(t))
 
-			require.NoError(t, env.deliveryHandler.HandleDeliveryStatus(ctx, kafkaevent.DeliveryStatusEvent{
+			require.NoError(t, env.deliveryHandler.Handle(ctx, NewCommand(orderv1.DeliveryStatusUpdate{
 				MessageID:  uuid.NewString(),
+				OrderID:    orderID,
 				PackageID:  packageID,
 				CourierID:  courierID,
-				Status:     "PACKAGE_STATUS_ASSIGNED",
-				EventType:  kafkaevent.EventTypePackageAssigned,
+				Status:     ordercommon.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
 				OccurredAt: time.Date(2026, time.March, 11, 10, 2, 0, 0, time.UTC),
-			}))
+			})))
 			require.Equal(t, int64(3), env.outboxCount(t))
 
-			require.NoError(t, env.deliveryHandler.HandleDeliveryStatus(ctx, kafkaevent.DeliveryStatusEvent{
+			require.NoError(t, env.deliveryHandler.Handle(ctx, NewCommand(orderv1.DeliveryStatusUpdate{
 				MessageID:  uuid.NewString(),
+				OrderID:    orderID,
 				PackageID:  packageID,
 				CourierID:  courierID,
-				Status:     "PACKAGE_STATUS_IN_TRANSIT",
-				EventType:  kafkaevent.EventTypePackageInTransit,
+				Status:     ordercommon.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
 				OccurredAt: time.Date(2026, time.March, 11, 10, 3, 0, 0, time.UTC),
-			}))
+			})))
 			require.Equal(t, int64(4), env.outboxCount(t))
 
-			require.NoError(t, env.deliveryHandler.HandleDeliveryStatus(
+			require.NoError(t, env.deliveryHandler.Handle(
 				ctx,
-				tc.buildTerminalEvent(packageID, courierID, time.Date(2026, time.March, 11, 10, 4, 0, 0, time.UTC)),
+				NewCommand(tc.buildTerminalUpdate(orderID, packageID, courierID, time.Date(2026, time.March, 11, 10, 4, 0, 0, time.UTC))),
 			))
 			require.Equal(t, int64(6), env.outboxCount(t))
 
@@ -180,32 +177,85 @@ func setupDeliveryLifecycleTestEnv(t *testing.T) *deliveryLifecycleTestEnv {
 	}
 }
 
-func TestHandleDeliveryStatus_Integration_DuplicateMessageIDIsIgnored(t *testing.T) {
+func TestHandle_Integration_DuplicateMessageIDIsIgnored(t *testing.T) {
 	env := setupDeliveryLifecycleTestEnv(t)
 	ctx := context.Background()
 
 	orderID, packageID := env.createOrderWithRequestedDelivery(t, ctx)
 	messageID := uuid.NewString()
-	event := kafkaevent.DeliveryStatusEvent{
+	cmd := NewCommand(orderv1.DeliveryStatusUpdate{
 		MessageID:  messageID,
 		OrderID:    orderID,
 		PackageID:  packageID,
-		Status:     "PACKAGE_STATUS_ACCEPTED",
-		EventType:  kafkaevent.EventTypePackageAccepted,
+		Status:     ordercommon.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
 		OccurredAt: time.Date(2026, time.March, 11, 10, 1, 0, 0, time.UTC),
-	}
+	})
 
 	require.Equal(t, int64(1), env.outboxCount(t))
-	require.NoError(t, env.deliveryHandler.HandleDeliveryStatus(ctx, event))
+	require.NoError(t, env.deliveryHandler.Handle(ctx, cmd))
 	require.Equal(t, int64(2), env.outboxCount(t))
 
-	require.NoError(t, env.deliveryHandler.HandleDeliveryStatus(ctx, event))
+	require.NoError(t, env.deliveryHandler.Handle(ctx, cmd))
 	require.Equal(t, int64(2), env.outboxCount(t))
 
 	order := env.loadOrder(t, orderID)
 	require.Equal(t, ordercommon.DeliveryStatus_DELIVERY_STATUS_ACCEPTED, order.GetDeliveryStatus())
 }
 
+func TestHandle_Integration_SplitDeliveryRoutesByPackage(t *testing.T) {
+	env := setupDeliveryLifecycleTestEnv(t)
+	ctx := context.Background()
+
+	orderID, firstPackageID := env.createOrderWithRequestedDelivery(t, ctx)
+	secondPackageID := uuid.New()
+	courierID := uuid.New()
+	minute := 0
+
+	advance := func(packageID uuid.UUID, statuses ...ordercommon.DeliveryStatus) {
+		t.Helper()
+
+		for _, status := range statuses {
+			minute++
+			require.NoError(t, env.deliveryHandler.Handle(ctx, NewCommand(orderv1.DeliveryStatusUpdate{
+				MessageID:  uuid.NewString(),
+				OrderID:    orderID,
+				PackageID:  packageID,
+				CourierID:  courierID,
+				Status:     status,
+				OccurredAt: time.Date(2026, time.March, 11, 10, minute, 0, 0, time.UTC),
+			})))
+		}
+	}
+
+	advance(firstPackageID,
+		ordercommon.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+		ordercommon.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+		ordercommon.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+	)
+	advance(secondPackageID,
+		ordercommon.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+		ordercommon.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+		ordercommon.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+	)
+	advance(firstPackageID, ordercommon.DeliveryStatus_DELIVERY_STATUS_DELIVERED)
+
+	order := env.loadOrder(t, orderID)
+	require.Equal(t, orderv1.OrderStatus_ORDER_STATUS_PROCESSING, order.GetStatus())
+	require.Equal(t, ordercommon.DeliveryStatus_DELIVERY_STATUS_PARTIALLY_DELIVERED, order.GetDeliveryStatus())
+	require.Equal(t, map[uuid.UUID]ordercommon.DeliveryStatus{
+		firstPackageID:  ordercommon.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+		secondPackageID: ordercommon.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+	}, order.GetPackageDeliveryStatuses())
+	require.Equal(t, orderID, env.loadOrderByPackageID(t, secondPackageID).GetOrderID())
+
+	advance(secondPackageID, ordercommon.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED)
+
+	order = env.loadOrder(t, orderID)
+	require.Equal(t, orderv1.OrderStatus_ORDER_STATUS_COMPLETED, order.GetStatus())
+	require.Equal(t, ordercommon.DeliveryStatus_DELIVERY_STATUS_PARTIALLY_DELIVERED, order.GetDeliveryStatus())
+	require.Equal(t, ordercommon.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED, order.GetPackageDeliveryStatus(secondPackageID))
+}
+
 func newTxAwareEventBus(t *testing.T) ports.EventPublisher {
 	t.Helper()
 
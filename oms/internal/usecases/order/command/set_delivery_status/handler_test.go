@@ -0,0 +1,72 @@
+package set_delivery_status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+)
+
+func TestIsDuplicateOrStale(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		currentStatus  commonv1.DeliveryStatus
+		targetStatus   commonv1.DeliveryStatus
+		expectedResult bool
+	}{
+		{
+			name:           "duplicate target is ignored",
+			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+			targetStatus:   commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+			expectedResult: true,
+		},
+		{
+			name:           "assigned is allowed when accepted was skipped",
+			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+			targetStatus:   commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+			expectedResult: false,
+		},
+		{
+			name:           "in transit is allowed when earlier statuses were skipped",
+			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+			targetStatus:   commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+			expectedResult: false,
+		},
+		{
+			name:           "assigned after in transit is stale",
+			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+			targetStatus:   commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+			expectedResult: true,
+		},
+		{
+			name:           "accepted after assigned is stale",
+			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+			targetStatus:   commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+			expectedResult: true,
+		},
+		{
+			name:           "delivered is allowed directly from assigned",
+			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+			targetStatus:   commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+			expectedResult: false,
+		},
+		{
+			name:           "terminal cross-over is stale",
+			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+			targetStatus:   commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED,
+			expectedResult: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expectedResult, isDuplicateOrStale(tc.currentStatus, tc.targetStatus))
+		})
+	}
+}
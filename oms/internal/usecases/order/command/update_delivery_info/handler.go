@@ -2,12 +2,22 @@ package update_delivery_info
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log/slog"
 
 	"github.com/shortlink-org/go-sdk/logger"
 
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
+)
+
+var (
+	// ErrDeliveryLocked is returned once delivery was requested or the package is assigned, in transit or finished.
+	ErrDeliveryLocked = fmt.Errorf("%w: delivery info can no longer be changed", domain.ErrConflict)
+	// ErrOrderTerminal is returned for COMPLETED and CANCELED orders.
+	ErrOrderTerminal = fmt.Errorf("%w: order is in a terminal state", domain.ErrConflict)
 )
 
 // Handler handles UpdateDeliveryInfo commands.
@@ -34,58 +44,65 @@ func NewHandler(
 }
 
 // Handle executes the UpdateDeliveryInfo command.
-// Pattern: Load -> Domain method -> Save -> Publish event
-// Returns error if order is in terminal state or delivery is already in progress.
+// Pattern: Load -> Domain method -> Save -> Publish event, retried on optimistic-lock conflicts.
+// Returns ErrOrderTerminal for COMPLETED/CANCELED orders and ErrDeliveryLocked once delivery is under way.
 func (h *Handler) Handle(ctx context.Context, cmd Command) error {
-	// Begin transaction
-	ctx, err := h.uow.Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
+	var order *orderv1.OrderState
 
-	committed := false
-	defer func() {
-		if committed {
-			return
-		}
+	err := uow.RunWithRetry(ctx, h.uow, uow.DefaultMaxAttempts, domain.ErrVersionConflict, func(ctx context.Context) error {
+		// 1. Load order aggregate
+		var err error
 
-		rollbackErr := h.uow.Rollback(ctx)
-		if rollbackErr != nil {
-			h.log.Warn("transaction rollback failed", slog.Any("error", rollbackErr))
+		order, err = h.orderRepo.Load(ctx, cmd.OrderID)
+		if err != nil {
+			return domain.MapInfraErr("orderRepo.Load", err)
 		}
-	}()
-
-	// 1. Load order aggregate
-	order, err := h.orderRepo.Load(ctx, cmd.OrderID)
-	if err != nil {
-		return fmt.Errorf("failed to load order: %w", err)
-	}
-
-	// 2. Apply business logic (update delivery info)
-	// This will fail if order is COMPLETED/CANCELED or if delivery is ASSIGNED/IN_TRANSIT/DELIVERED
-	if err := order.SetDeliveryInfo(cmd.DeliveryInfo); err != nil {
-		return fmt.Errorf("cannot update delivery info: %w", err)
-	}
 
-	// 3. Persist to database
-	if err := h.orderRepo.Save(ctx, order); err != nil {
-		return fmt.Errorf("failed to save order: %w", err)
-	}
+		// 2. Apply business logic (update delivery info)
+		err = order.SetDeliveryInfo(cmd.DeliveryInfo)
+		if err != nil {
+			return mapDeliveryInfoError(err)
+		}
 
-	// 4. Publish domain events to outbox (same transaction)
-	for _, event := range order.GetDomainEvents() {
-		err := h.publisher.Publish(ctx, event)
+		// 3. Persist to database (version-checked)
+		err = h.orderRepo.Save(ctx, order)
 		if err != nil {
-			return fmt.Errorf("failed to publish domain event to outbox: %w", err)
+			return domain.MapInfraErr("orderRepo.Save", err)
+		}
+
+		// 4. Publish domain events to outbox (same transaction)
+		for _, event := range order.GetDomainEvents() {
+			pubErr := h.publisher.Publish(ctx, event)
+			if pubErr != nil {
+				return domain.MapInfraErr("eventBus.Publish", pubErr)
+			}
 		}
-	}
 
-	if err := h.uow.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return nil
+	})
+	if err != nil {
+		return err
 	}
-	committed = true
 
 	order.ClearDomainEvents()
 
 	return nil
 }
+
+// mapDeliveryInfoError turns the SetDeliveryInfo guard errors into application errors.
+func mapDeliveryInfoError(err error) error {
+	var (
+		terminalErr   *orderv1.OrderTerminalStateError
+		inProgressErr *orderv1.DeliveryAlreadyInProgressError
+		requestedErr  *orderv1.DeliveryAlreadyRequestedError
+	)
+
+	switch {
+	case errors.As(err, &terminalErr):
+		return fmt.Errorf("%w: %w", ErrOrderTerminal, err)
+	case errors.As(err, &inProgressErr), errors.As(err, &requestedErr):
+		return fmt.Errorf("%w: %w", ErrDeliveryLocked, err)
+	default:
+		return domain.WrapValidation("SetDeliveryInfo", err)
+	}
+}
@@ -0,0 +1,228 @@
+package update_delivery_info
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(ctx context.Context) (context.Context, error) { return ctx, nil }
+func (stubUnitOfWork) Commit(context.Context) error                       { return nil }
+func (stubUnitOfWork) Rollback(context.Context) error                     { return nil }
+
+// stubOrderRepository loads a fresh order from newOrder on every call and records saves.
+type stubOrderRepository struct {
+	newOrder  func() *orderv1.OrderState
+	saveErrs  []error
+	loads     int
+	saved     *orderv1.OrderState
+	saveCalls int
+}
+
+func (s *stubOrderRepository) Load(context.Context, uuid.UUID) (*orderv1.OrderState, error) {
+	s.loads++
+
+	return s.newOrder(), nil
+}
+
+func (s *stubOrderRepository) LoadByPackageID(context.Context, uuid.UUID) (*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func (s *stubOrderRepository) Save(_ context.Context, order *orderv1.OrderState) error {
+	s.saveCalls++
+
+	if len(s.saveErrs) > 0 {
+		err := s.saveErrs[0]
+		s.saveErrs = s.saveErrs[1:]
+
+		return err
+	}
+
+	s.saved = order
+
+	return nil
+}
+
+func (s *stubOrderRepository) List(context.Context, ports.ListFilter) ([]*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func (s *stubOrderRepository) ListPage(context.Context, ports.ListPageFilter) (*ports.OrderPage, error) {
+	panic("unexpected call")
+}
+
+func (s *stubOrderRepository) ListByCustomer(context.Context, uuid.UUID) ([]*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+type stubPublisher struct{}
+
+func (stubPublisher) Publish(context.Context, any) error { return nil }
+
+func newTestDeliveryInfo(t *testing.T) orderv1.DeliveryInfo {
+	t.Helper()
+
+	pickupAddr, err := address.NewAddress("123 Warehouse St", "Moscow", "101000", "Russia")
+	require.NoError(t, err)
+
+	deliveryAddr, err := address.NewAddress("456 Customer St", "Moscow", "102000", "Russia")
+	require.NoError(t, err)
+
+	startTime := time.Now().Add(24 * time.Hour)
+	period := orderv1.NewDeliveryPeriod(startTime, startTime.Add(2*time.Hour))
+
+	return orderv1.NewDeliveryInfo(pickupAddr, deliveryAddr, period, orderv1.NewPackageInfo(2.5), orderv1.DeliveryPriorityNormal, nil)
+}
+
+func persistedOrder(status orderv1.OrderStatus, deliveryStatus commonv1.DeliveryStatus) func() *orderv1.OrderState {
+	orderID, customerID := uuid.New(), uuid.New()
+
+	return func() *orderv1.OrderState {
+		return orderv1.NewOrderStateFromPersisted(
+			orderID,
+			customerID,
+			orderv1.Items{orderv1.NewItem(uuid.New(), 1, decimal.NewFromInt(10))},
+			status,
+			1,
+			nil,
+			deliveryStatus,
+			nil,
+		)
+	}
+}
+
+func newTestHandler(t *testing.T, repo *stubOrderRepository) *Handler {
+	t.Helper()
+
+	handler, err := NewHandler(nil, stubUnitOfWork{}, repo, stubPublisher{})
+	require.NoError(t, err)
+
+	return handler
+}
+
+func TestHandler_Handle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		order   func() *orderv1.OrderState
+		wantErr error
+	}{
+		{
+			name:  "pending order",
+			order: persistedOrder(orderv1.OrderStatus_ORDER_STATUS_PENDING, commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED),
+		},
+		{
+			name:  "processing order",
+			order: persistedOrder(orderv1.OrderStatus_ORDER_STATUS_PROCESSING, commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED),
+		},
+		{
+			name:    "assigned package",
+			order:   persistedOrder(orderv1.OrderStatus_ORDER_STATUS_PROCESSING, commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED),
+			wantErr: ErrDeliveryLocked,
+		},
+		{
+			name:    "completed order",
+			order:   persistedOrder(orderv1.OrderStatus_ORDER_STATUS_COMPLETED, commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED),
+			wantErr: ErrOrderTerminal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &stubOrderRepository{newOrder: tt.order}
+			handler := newTestHandler(t, repo)
+
+			err := handler.Handle(context.Background(), NewCommand(uuid.New(), newTestDeliveryInfo(t)))
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.ErrorIs(t, err, domain.ErrConflict)
+				require.Zero(t, repo.saveCalls, "a locked order must not be saved")
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, repo.saved)
+			require.True(t, repo.saved.HasDeliveryInfo())
+		})
+	}
+}
+
+func TestHandler_Handle_RetriesVersionConflict(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubOrderRepository{
+		newOrder: persistedOrder(orderv1.OrderStatus_ORDER_STATUS_PROCESSING, commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED),
+		saveErrs: []error{domain.ErrVersionConflict},
+	}
+	handler := newTestHandler(t, repo)
+
+	err := handler.Handle(context.Background(), NewCommand(uuid.New(), newTestDeliveryInfo(t)))
+	require.NoError(t, err)
+	require.Equal(t, 2, repo.loads, "the conflicting attempt must reload the order")
+	require.Equal(t, 2, repo.saveCalls)
+}
+
+func TestHandler_Handle_KeepsDeliveryFee(t *testing.T) {
+	t.Parallel()
+
+	fee := decimal.RequireFromString("4.99")
+	orderID, customerID := uuid.New(), uuid.New()
+	repo := &stubOrderRepository{
+		newOrder: func() *orderv1.OrderState {
+			info := newTestDeliveryInfo(t)
+			info.SetDeliveryFee(fee)
+
+			return orderv1.NewOrderStateFromPersisted(
+				orderID,
+				customerID,
+				orderv1.Items{orderv1.NewItem(uuid.New(), 1, decimal.NewFromInt(10))},
+				orderv1.OrderStatus_ORDER_STATUS_PROCESSING,
+				1,
+				&info,
+				commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+				nil,
+			)
+		},
+	}
+	handler := newTestHandler(t, repo)
+
+	newAddr, err := address.NewAddress("789 New St", "Moscow", "103000", "Russia")
+	require.NoError(t, err)
+
+	current := newTestDeliveryInfo(t)
+	updated := orderv1.NewDeliveryInfo(
+		current.GetPickupAddress(),
+		newAddr,
+		current.GetDeliveryPeriod(),
+		current.GetPackageInfo(),
+		current.GetPriority(),
+		nil,
+	)
+
+	err = handler.Handle(context.Background(), NewCommand(orderID, updated))
+	require.NoError(t, err)
+	require.NotNil(t, repo.saved)
+
+	saved := repo.saved.GetDeliveryInfo()
+	require.NotNil(t, saved)
+	require.Equal(t, "789 New St", saved.GetDeliveryAddress().Street())
+	require.True(t, fee.Equal(saved.GetDeliveryFee()), "the address update must keep the checkout fee, got %s", saved.GetDeliveryFee())
+}
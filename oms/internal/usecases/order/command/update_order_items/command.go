@@ -0,0 +1,22 @@
+package update_order_items
+
+import (
+	"github.com/google/uuid"
+
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+)
+
+// Command represents a command to change the items of an existing order.
+// Items are merged by good ID: listed goods replace the order's, new goods are appended.
+type Command struct {
+	OrderID uuid.UUID
+	Items   orderv1.Items
+}
+
+// NewCommand creates a new UpdateOrderItems command.
+func NewCommand(orderID uuid.UUID, items orderv1.Items) Command {
+	return Command{
+		OrderID: orderID,
+		Items:   items,
+	}
+}
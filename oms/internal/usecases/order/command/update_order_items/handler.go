@@ -0,0 +1,214 @@
+package update_order_items
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/shortlink-org/go-sdk/logger"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	pricing "github.com/shortlink-org/shop/oms/internal/domain/pricing"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/order_pricing"
+	"github.com/shortlink-org/shop/oms/pkg/uow"
+)
+
+// ErrOrderTerminal is returned for COMPLETED and CANCELED orders.
+var ErrOrderTerminal = fmt.Errorf("%w: order is in a terminal state", domain.ErrConflict)
+
+// Result represents the repriced order after its items changed.
+type Result struct {
+	Order         *orderv1.OrderState
+	Currency      pricing.Currency
+	Subtotal      decimal.Decimal
+	TotalDiscount decimal.Decimal
+	OrderDiscount decimal.Decimal
+	TotalTax      decimal.Decimal
+	FinalPrice    decimal.Decimal
+}
+
+// Handler handles UpdateOrderItems commands.
+type Handler struct {
+	log          logger.Logger
+	uow          ports.UnitOfWork
+	orderRepo    ports.OrderRepository
+	publisher    ports.EventPublisher
+	pricerClient ports.PricerClient
+}
+
+// NewHandler creates a new UpdateOrderItems handler.
+// A nil pricerClient prices the order from its item prices, without discount or tax.
+func NewHandler(
+	log logger.Logger,
+	uow ports.UnitOfWork,
+	orderRepo ports.OrderRepository,
+	publisher ports.EventPublisher,
+	pricerClient ports.PricerClient,
+) (*Handler, error) {
+	return &Handler{
+		log:          log,
+		uow:          uow,
+		orderRepo:    orderRepo,
+		publisher:    publisher,
+		pricerClient: pricerClient,
+	}, nil
+}
+
+// Handle executes the UpdateOrderItems command.
+// Pattern: Load -> Domain method -> Reprice -> Save -> Publish event, retried on optimistic-lock conflicts.
+// Repricing checks the item prices against the pricer and records the new order discount and totals.
+// Returns ErrOrderTerminal for COMPLETED/CANCELED orders and order_pricing.ErrPriceDiscrepancy when the pricer disagrees.
+func (h *Handler) Handle(ctx context.Context, cmd Command) (Result, error) {
+	var (
+		order  *orderv1.OrderState
+		totals ports.CalculateTotalResponse
+	)
+
+	err := uow.RunWithRetry(ctx, h.uow, uow.DefaultMaxAttempts, domain.ErrVersionConflict, func(ctx context.Context) error {
+		// 1. Load order aggregate
+		var err error
+
+		order, err = h.orderRepo.Load(ctx, cmd.OrderID)
+		if err != nil {
+			return domain.MapInfraErr("orderRepo.Load", err)
+		}
+
+		// 2. Apply business logic (merge the items)
+		err = order.UpdateOrder(cmd.Items)
+		if err != nil {
+			return mapUpdateOrderError(err)
+		}
+
+		// 3. Reprice the new item set
+		totals, err = h.priceOrder(ctx, order)
+		if err != nil {
+			return err
+		}
+
+		// The new item set may gain or lose an order-level promotion
+		err = order.SetOrderDiscount(totals.OrderDiscount)
+		if err != nil {
+			return domain.WrapValidation("SetOrderDiscount", err)
+		}
+
+		err = order.SetTotals(orderv1.OrderTotals{
+			Subtotal:   totals.Subtotal,
+			TotalTax:   totals.TotalTax,
+			FinalPrice: totals.FinalPrice,
+		})
+		if err != nil {
+			return domain.WrapValidation("SetTotals", err)
+		}
+
+		// 4. Persist to database (version-checked)
+		err = h.orderRepo.Save(ctx, order)
+		if err != nil {
+			return domain.MapInfraErr("orderRepo.Save", err)
+		}
+
+		// 5. Publish domain events to outbox (same transaction)
+		for _, event := range order.GetDomainEvents() {
+			pubErr := h.publisher.Publish(ctx, event)
+			if pubErr != nil {
+				return domain.MapInfraErr("eventBus.Publish", pubErr)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	order.ClearDomainEvents()
+
+	return Result{
+		Order:         order,
+		Currency:      totals.Currency,
+		Subtotal:      totals.Subtotal,
+		TotalDiscount: totals.TotalDiscount,
+		OrderDiscount: totals.OrderDiscount,
+		TotalTax:      totals.TotalTax,
+		FinalPrice:    totals.FinalPrice,
+	}, nil
+}
+
+// priceOrder asks the pricer for the totals of the order's current items
+// and rejects item prices the pricer no longer agrees with.
+func (h *Handler) priceOrder(ctx context.Context, order *orderv1.OrderState) (ports.CalculateTotalResponse, error) {
+	items := order.GetItems()
+	currency := itemsCurrency(items)
+	lines := pricingLines(items)
+
+	if h.pricerClient == nil {
+		return order_pricing.CalculateTotals(lines, currency), nil
+	}
+
+	cartItems := make([]ports.CartItemData, 0, len(items))
+	for _, item := range items {
+		cartItems = append(cartItems, ports.CartItemData{
+			ProductID:   item.GetGoodId(),
+			Quantity:    item.GetQuantity(),
+			UnitPrice:   item.GetPrice(),
+			Currency:    item.GetCurrency(),
+			TaxCategory: item.GetTaxCategory(),
+		})
+	}
+
+	resp, err := h.pricerClient.CalculateTotal(ctx, ports.CalculateTotalRequest{
+		Cart: ports.CartData{
+			CustomerID: order.GetCustomerId(),
+			Items:      cartItems,
+		},
+		Currency: currency,
+	})
+	if err != nil {
+		return ports.CalculateTotalResponse{}, domain.WrapUnavailable("failed to calculate pricing", err)
+	}
+
+	err = order_pricing.ReconcileItemPrices(lines, resp.Items)
+	if err != nil {
+		return ports.CalculateTotalResponse{}, err
+	}
+
+	return *resp, nil
+}
+
+// pricingLines converts order items to the lines the shared pricing rules work on; order items carry no discount or tax.
+func pricingLines(items orderv1.Items) []order_pricing.Line {
+	lines := make([]order_pricing.Line, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, order_pricing.Line{
+			ProductID: item.GetGoodId(),
+			Quantity:  item.GetQuantity(),
+			UnitPrice: item.GetPrice(),
+		})
+	}
+
+	return lines
+}
+
+// itemsCurrency returns the currency of the first priced item; empty lets the pricer use its default.
+func itemsCurrency(items orderv1.Items) pricing.Currency {
+	for _, item := range items {
+		if item.GetCurrency() != "" {
+			return item.GetCurrency()
+		}
+	}
+
+	return ""
+}
+
+// mapUpdateOrderError turns the UpdateOrder guard errors into application errors.
+func mapUpdateOrderError(err error) error {
+	var terminalErr *orderv1.OrderTerminalStateError
+
+	if errors.As(err, &terminalErr) {
+		return fmt.Errorf("%w: %w", ErrOrderTerminal, err)
+	}
+
+	return domain.WrapValidation("UpdateOrder", err)
+}
@@ -0,0 +1,201 @@
+package update_order_items
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	eventsv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(ctx context.Context) (context.Context, error) { return ctx, nil }
+func (stubUnitOfWork) Commit(context.Context) error                       { return nil }
+func (stubUnitOfWork) Rollback(context.Context) error                     { return nil }
+
+// stubOrderRepository loads a fresh order from newOrder on every call and records saves.
+type stubOrderRepository struct {
+	newOrder  func() *orderv1.OrderState
+	saved     *orderv1.OrderState
+	saveCalls int
+}
+
+func (s *stubOrderRepository) Load(context.Context, uuid.UUID) (*orderv1.OrderState, error) {
+	return s.newOrder(), nil
+}
+
+func (s *stubOrderRepository) LoadByPackageID(context.Context, uuid.UUID) (*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func (s *stubOrderRepository) Save(_ context.Context, order *orderv1.OrderState) error {
+	s.saveCalls++
+	s.saved = order
+
+	return nil
+}
+
+func (s *stubOrderRepository) List(context.Context, ports.ListFilter) ([]*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func (s *stubOrderRepository) ListPage(context.Context, ports.ListPageFilter) (*ports.OrderPage, error) {
+	panic("unexpected call")
+}
+
+func (s *stubOrderRepository) ListByCustomer(context.Context, uuid.UUID) ([]*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+type stubPublisher struct {
+	events []any
+}
+
+func (s *stubPublisher) Publish(_ context.Context, event any) error {
+	s.events = append(s.events, event)
+
+	return nil
+}
+
+// stubPricer prices every item at its unit price and adds a flat tax rate, recording the requests.
+// With a positive orderDiscountThreshold, subtotals reaching it get orderDiscount off.
+type stubPricer struct {
+	taxRate                decimal.Decimal
+	orderDiscountThreshold decimal.Decimal
+	orderDiscount          decimal.Decimal
+	requests               []ports.CalculateTotalRequest
+}
+
+func (s *stubPricer) CalculateTotal(_ context.Context, req ports.CalculateTotalRequest) (*ports.CalculateTotalResponse, error) {
+	s.requests = append(s.requests, req)
+
+	subtotal := decimal.Zero
+	priced := make([]ports.PricedItemData, 0, len(req.Cart.Items))
+
+	for _, item := range req.Cart.Items {
+		subtotal = subtotal.Add(item.UnitPrice.Mul(decimal.NewFromInt32(item.Quantity)))
+		priced = append(priced, ports.PricedItemData{ProductID: item.ProductID, UnitPrice: item.UnitPrice})
+	}
+
+	tax := subtotal.Mul(s.taxRate)
+
+	orderDiscount := decimal.Zero
+	if s.orderDiscountThreshold.IsPositive() && subtotal.GreaterThanOrEqual(s.orderDiscountThreshold) {
+		orderDiscount = s.orderDiscount
+	}
+
+	return &ports.CalculateTotalResponse{
+		Subtotal:      subtotal,
+		TotalTax:      tax,
+		OrderDiscount: orderDiscount,
+		FinalPrice:    subtotal.Sub(orderDiscount).Add(tax),
+		Currency:      req.Currency,
+		Items:         priced,
+	}, nil
+}
+
+func persistedOrder(status orderv1.OrderStatus, goodID uuid.UUID) func() *orderv1.OrderState {
+	orderID, customerID := uuid.New(), uuid.New()
+
+	return func() *orderv1.OrderState {
+		return orderv1.NewOrderStateFromPersisted(
+			orderID,
+			customerID,
+			orderv1.Items{orderv1.NewItem(goodID, 1, decimal.NewFromInt(10))},
+			status,
+			1,
+			nil,
+			commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+			nil,
+		)
+	}
+}
+
+func TestHandler_Handle_RepricesQuantityIncrease(t *testing.T) {
+	t.Parallel()
+
+	goodID := uuid.New()
+	repo := &stubOrderRepository{newOrder: persistedOrder(orderv1.OrderStatus_ORDER_STATUS_PENDING, goodID)}
+	publisher := &stubPublisher{}
+	pricer := &stubPricer{taxRate: decimal.NewFromFloat(0.1)}
+
+	handler, err := NewHandler(nil, stubUnitOfWork{}, repo, publisher, pricer)
+	require.NoError(t, err)
+
+	result, err := handler.Handle(context.Background(), NewCommand(uuid.New(), orderv1.Items{
+		orderv1.NewItem(goodID, 3, decimal.NewFromInt(10)),
+	}))
+	require.NoError(t, err)
+
+	require.Len(t, pricer.requests, 1)
+	require.Len(t, pricer.requests[0].Cart.Items, 1)
+	require.Equal(t, int32(3), pricer.requests[0].Cart.Items[0].Quantity, "the pricer must see the new quantity")
+
+	require.True(t, decimal.NewFromInt(30).Equal(result.Subtotal), "subtotal %s", result.Subtotal)
+	require.True(t, decimal.NewFromInt(3).Equal(result.TotalTax), "tax %s", result.TotalTax)
+	require.True(t, decimal.NewFromInt(33).Equal(result.FinalPrice), "final price %s", result.FinalPrice)
+
+	require.Equal(t, 1, repo.saveCalls)
+	require.Equal(t, int32(3), repo.saved.GetItems()[0].GetQuantity())
+
+	savedTotals := repo.saved.GetTotals()
+	require.True(t, result.Subtotal.Equal(savedTotals.Subtotal), "the repriced subtotal must be persisted")
+	require.True(t, result.TotalTax.Equal(savedTotals.TotalTax), "the repriced tax must be persisted")
+	require.True(t, result.FinalPrice.Equal(savedTotals.FinalPrice), "the repriced final price must be persisted")
+
+	require.Len(t, publisher.events, 1)
+	require.IsType(t, &eventsv1.OrderItemsUpdated{}, publisher.events[0])
+	require.Empty(t, result.Order.GetDomainEvents())
+}
+
+func TestHandler_Handle_RecordsOrderDiscount(t *testing.T) {
+	t.Parallel()
+
+	goodID := uuid.New()
+	repo := &stubOrderRepository{newOrder: persistedOrder(orderv1.OrderStatus_ORDER_STATUS_PENDING, goodID)}
+	pricer := &stubPricer{orderDiscountThreshold: decimal.NewFromInt(100), orderDiscount: decimal.NewFromInt(10)}
+
+	handler, err := NewHandler(nil, stubUnitOfWork{}, repo, &stubPublisher{}, pricer)
+	require.NoError(t, err)
+
+	// 12 x 10 crosses the $100 threshold of the order-level promotion
+	result, err := handler.Handle(context.Background(), NewCommand(uuid.New(), orderv1.Items{
+		orderv1.NewItem(goodID, 12, decimal.NewFromInt(10)),
+	}))
+	require.NoError(t, err)
+
+	require.True(t, decimal.NewFromInt(10).Equal(result.OrderDiscount), "order discount %s", result.OrderDiscount)
+	require.True(t, decimal.NewFromInt(110).Equal(result.FinalPrice), "final price %s", result.FinalPrice)
+	require.True(t, result.Subtotal.Sub(result.TotalDiscount).Sub(result.OrderDiscount).Add(result.TotalTax).Equal(result.FinalPrice))
+	require.True(t, decimal.NewFromInt(10).Equal(repo.saved.GetOrderDiscount()), "the order discount must be persisted")
+}
+
+func TestHandler_Handle_RejectsCompletedOrder(t *testing.T) {
+	t.Parallel()
+
+	goodID := uuid.New()
+	repo := &stubOrderRepository{newOrder: persistedOrder(orderv1.OrderStatus_ORDER_STATUS_COMPLETED, goodID)}
+	publisher := &stubPublisher{}
+	pricer := &stubPricer{}
+
+	handler, err := NewHandler(nil, stubUnitOfWork{}, repo, publisher, pricer)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(context.Background(), NewCommand(uuid.New(), orderv1.Items{
+		orderv1.NewItem(goodID, 3, decimal.NewFromInt(10)),
+	}))
+	require.ErrorIs(t, err, ErrOrderTerminal)
+	require.ErrorIs(t, err, domain.ErrConflict)
+
+	require.Empty(t, pricer.requests, "a terminal order must not be repriced")
+	require.Zero(t, repo.saveCalls, "a terminal order must not be saved")
+	require.Empty(t, publisher.events)
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+	v3 "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/model/v1"
+)
+
+// OrderStateFromDomain converts a v1.OrderState to a v3.OrderState (reverse of OrderStateToDomain)
+func OrderStateFromDomain(in *v1.OrderState) *v3.OrderState {
+	items := make([]*v3.OrderItem, 0, len(in.GetItems()))
+	for _, item := range in.GetItems() {
+		items = append(items, &v3.OrderItem{
+			Id:          item.GetGoodId().String(),
+			Quantity:    item.GetQuantity(),
+			Price:       item.GetPrice().InexactFloat64(),
+			TaxCategory: item.GetTaxCategory(),
+		})
+	}
+
+	return &v3.OrderState{
+		Id:           in.GetOrderID().String(),
+		CustomerId:   in.GetCustomerId().String(),
+		Items:        items,
+		Status:       in.GetStatus(),
+		DeliveryInfo: deliveryInfoFromDomain(in.GetDeliveryInfo()),
+	}
+}
+
+// deliveryInfoFromDomain converts domain DeliveryInfo to proto DeliveryInfo.
+// Returns nil for orders without delivery info (self-pickup).
+func deliveryInfoFromDomain(info *v1.DeliveryInfo) *commonv1.DeliveryInfo {
+	if info == nil {
+		return nil
+	}
+
+	out := &commonv1.DeliveryInfo{
+		PickupAddress:   addressFromDomain(info.GetPickupAddress()),
+		DeliveryAddress: addressFromDomain(info.GetDeliveryAddress()),
+		DeliveryPeriod: &commonv1.DeliveryPeriod{
+			StartTime: timestamppb.New(info.GetDeliveryPeriod().GetStartTime()),
+			EndTime:   timestamppb.New(info.GetDeliveryPeriod().GetEndTime()),
+		},
+		PackageInfo: &commonv1.PackageInfo{
+			WeightKg: info.GetPackageInfo().GetWeightKg(),
+			LengthCm: info.GetPackageInfo().GetLengthCm(),
+			WidthCm:  info.GetPackageInfo().GetWidthCm(),
+			HeightCm: info.GetPackageInfo().GetHeightCm(),
+		},
+		Priority: priorityFromDomain(info.GetPriority()),
+	}
+
+	if rc := info.GetRecipientContacts(); rc != nil {
+		out.RecipientContacts = &commonv1.RecipientContacts{
+			RecipientName:  rc.GetName(),
+			RecipientPhone: rc.GetPhone(),
+			RecipientEmail: rc.GetEmail(),
+		}
+	}
+
+	return out
+}
+
+// addressFromDomain converts domain Address to proto DeliveryAddress.
+func addressFromDomain(addr address.Address) *commonv1.DeliveryAddress {
+	return &commonv1.DeliveryAddress{
+		Street:     addr.Street(),
+		City:       addr.City(),
+		PostalCode: addr.PostalCode(),
+		Country:    addr.Country(),
+		Latitude:   addr.Latitude(),
+		Longitude:  addr.Longitude(),
+	}
+}
+
+// priorityFromDomain converts domain DeliveryPriority to proto DeliveryPriority.
+func priorityFromDomain(priority v1.DeliveryPriority) commonv1.DeliveryPriority {
+	switch priority {
+	case v1.DeliveryPriorityNormal:
+		return commonv1.DeliveryPriority_DELIVERY_PRIORITY_NORMAL
+	case v1.DeliveryPriorityUrgent:
+		return commonv1.DeliveryPriority_DELIVERY_PRIORITY_URGENT
+	default:
+		return commonv1.DeliveryPriority_DELIVERY_PRIORITY_UNSPECIFIED
+	}
+}
@@ -8,13 +8,18 @@ import (
 	"github.com/shopspring/decimal"
 
 	v1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/location"
 	v3 "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/model/v1"
 )
 
 var (
-	errInvalidCustomerID = errors.New("invalid customer id")
-	errInvalidOrderID    = errors.New("invalid order id")
-	errInvalidItemID     = errors.New("invalid item id")
+	errInvalidCustomerID      = errors.New("invalid customer id")
+	errInvalidOrderID         = errors.New("invalid order id")
+	errInvalidItemID          = errors.New("invalid item id")
+	errInvalidPickupAddress   = errors.New("invalid pickup address")
+	errInvalidDeliveryAddress = errors.New("invalid delivery address")
 )
 
 // OrderStateToDomain converts a v3.OrderState to a v1.OrderState using the OrderStateBuilder
@@ -44,7 +49,17 @@ func OrderStateToDomain(in *v3.OrderState) (*v1.OrderState, error) {
 		}
 
 		price := decimal.NewFromFloat(item.GetPrice())
-		builder.AddItem(goodID, item.GetQuantity(), price)
+		builder.AddOrderItem(v1.NewItem(goodID, item.GetQuantity(), price).WithTaxCategory(item.GetTaxCategory()))
+	}
+
+	// Set delivery info before the status: terminal orders reject it (nil = self-pickup)
+	deliveryInfo, err := deliveryInfoToDomain(in.GetDeliveryInfo())
+	if err != nil {
+		return nil, err
+	}
+
+	if deliveryInfo != nil {
+		builder.SetDeliveryInfo(*deliveryInfo)
 	}
 
 	// Set the status by replaying events (preserves FSM invariants)
@@ -59,3 +74,73 @@ func OrderStateToDomain(in *v3.OrderState) (*v1.OrderState, error) {
 
 	return orderState, nil
 }
+
+// deliveryInfoToDomain converts proto DeliveryInfo to domain DeliveryInfo.
+// Returns nil if the order carries no delivery info.
+func deliveryInfoToDomain(in *commonv1.DeliveryInfo) (*v1.DeliveryInfo, error) {
+	if in == nil {
+		return nil, nil //nolint:nilnil // nil delivery info is valid for self-pickup orders
+	}
+
+	pickupAddr, err := addressToDomain(in.GetPickupAddress())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidPickupAddress, err)
+	}
+
+	deliveryAddr, err := addressToDomain(in.GetDeliveryAddress())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidDeliveryAddress, err)
+	}
+
+	var recipientContacts *v1.RecipientContacts
+
+	if rc := in.GetRecipientContacts(); rc != nil {
+		contacts := v1.NewRecipientContacts(rc.GetRecipientName(), rc.GetRecipientPhone(), rc.GetRecipientEmail())
+		recipientContacts = &contacts
+	}
+
+	info, err := v1.NewDeliveryInfoBuilder().
+		WithPickup(pickupAddr).
+		WithDelivery(deliveryAddr).
+		WithPeriod(in.GetDeliveryPeriod().GetStartTime().AsTime(), in.GetDeliveryPeriod().GetEndTime().AsTime()).
+		WithPackage(packageInfoToDomain(in.GetPackageInfo())).
+		WithPriority(priorityToDomain(in.GetPriority())).
+		WithRecipientContacts(recipientContacts).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("build delivery info: %w", err)
+	}
+
+	return &info, nil
+}
+
+// addressToDomain converts proto DeliveryAddress to domain Address, keeping coordinates when set.
+func addressToDomain(in *commonv1.DeliveryAddress) (address.Address, error) {
+	if in.GetLatitude() == 0 && in.GetLongitude() == 0 {
+		return address.NewAddress(in.GetStreet(), in.GetCity(), in.GetPostalCode(), in.GetCountry())
+	}
+
+	loc, err := location.NewLocation(in.GetLatitude(), in.GetLongitude())
+	if err != nil {
+		return address.Address{}, err
+	}
+
+	return address.NewAddressWithLocation(in.GetStreet(), in.GetCity(), in.GetPostalCode(), in.GetCountry(), loc)
+}
+
+// packageInfoToDomain converts proto PackageInfo to domain PackageInfo, keeping dimensions when set.
+func packageInfoToDomain(in *commonv1.PackageInfo) v1.PackageInfo {
+	return v1.NewPackageInfoWithDimensions(in.GetWeightKg(), in.GetLengthCm(), in.GetWidthCm(), in.GetHeightCm())
+}
+
+// priorityToDomain converts proto DeliveryPriority to domain DeliveryPriority.
+func priorityToDomain(priority commonv1.DeliveryPriority) v1.DeliveryPriority {
+	switch priority {
+	case commonv1.DeliveryPriority_DELIVERY_PRIORITY_NORMAL:
+		return v1.DeliveryPriorityNormal
+	case commonv1.DeliveryPriority_DELIVERY_PRIORITY_URGENT:
+		return v1.DeliveryPriorityUrgent
+	default:
+		return v1.DeliveryPriorityUnspecified
+	}
+}
@@ -0,0 +1,156 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	v3 "github.com/shortlink-org/shop/oms/internal/infrastructure/rpc/order/v1/model/v1"
+)
+
+func testProtoOrder(deliveryInfo *commonv1.DeliveryInfo) *v3.OrderState {
+	return &v3.OrderState{
+		Id:         uuid.NewString(),
+		CustomerId: uuid.NewString(),
+		Items: []*v3.OrderItem{
+			{Id: uuid.NewString(), Quantity: 2, Price: 19.99},
+		},
+		Status:       v1.OrderStatus_ORDER_STATUS_PROCESSING,
+		DeliveryInfo: deliveryInfo,
+	}
+}
+
+func TestOrderStateToDomain_MapsDeliveryInfo(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	end := start.Add(2 * time.Hour)
+
+	in := testProtoOrder(&commonv1.DeliveryInfo{
+		PickupAddress: &commonv1.DeliveryAddress{
+			Street:     "123 Warehouse St",
+			City:       "Moscow",
+			PostalCode: "101000",
+			Country:    "Russia",
+			Latitude:   55.7558,
+			Longitude:  37.6173,
+		},
+		DeliveryAddress: &commonv1.DeliveryAddress{
+			Street:     "456 Customer St",
+			City:       "Moscow",
+			PostalCode: "102000",
+			Country:    "Russia",
+		},
+		DeliveryPeriod: &commonv1.DeliveryPeriod{
+			StartTime: timestamppb.New(start),
+			EndTime:   timestamppb.New(end),
+		},
+		PackageInfo: &commonv1.PackageInfo{WeightKg: 2.5, LengthCm: 40, WidthCm: 30, HeightCm: 20},
+		Priority:    commonv1.DeliveryPriority_DELIVERY_PRIORITY_URGENT,
+		RecipientContacts: &commonv1.RecipientContacts{
+			RecipientName:  "Jane Doe",
+			RecipientPhone: "+79001234567",
+		},
+	})
+
+	order, err := OrderStateToDomain(in)
+	require.NoError(t, err)
+	require.Equal(t, v1.OrderStatus_ORDER_STATUS_PROCESSING, order.GetStatus())
+
+	info := order.GetDeliveryInfo()
+	require.NotNil(t, info)
+	require.Equal(t, "123 Warehouse St", info.GetPickupAddress().Street())
+	require.InDelta(t, 55.7558, info.GetPickupAddress().Latitude(), 1e-9)
+	require.Equal(t, "456 Customer St", info.GetDeliveryAddress().Street())
+	require.False(t, info.GetDeliveryAddress().HasCoordinates())
+	require.True(t, info.GetDeliveryPeriod().GetStartTime().Equal(start))
+	require.True(t, info.GetDeliveryPeriod().GetEndTime().Equal(end))
+	require.InDelta(t, 2.5, info.GetPackageInfo().GetWeightKg(), 1e-9)
+	require.True(t, info.GetPackageInfo().HasDimensions())
+	require.InDelta(t, 40, info.GetPackageInfo().GetLengthCm(), 1e-9)
+	require.Equal(t, v1.DeliveryPriorityUrgent, info.GetPriority())
+	require.Equal(t, "Jane Doe", info.GetRecipientContacts().GetName())
+
+	// Round trip back to proto keeps the delivery info
+	out := OrderStateFromDomain(order)
+	require.Equal(t, in.GetId(), out.GetId())
+	require.Equal(t, in.GetCustomerId(), out.GetCustomerId())
+	require.Equal(t, in.GetStatus(), out.GetStatus())
+	require.Len(t, out.GetItems(), 1)
+	require.Equal(t, in.GetDeliveryInfo().GetPickupAddress().GetStreet(), out.GetDeliveryInfo().GetPickupAddress().GetStreet())
+	require.InDelta(t, 37.6173, out.GetDeliveryInfo().GetPickupAddress().GetLongitude(), 1e-9)
+	require.Equal(t, in.GetDeliveryInfo().GetDeliveryAddress().GetPostalCode(), out.GetDeliveryInfo().GetDeliveryAddress().GetPostalCode())
+	require.True(t, out.GetDeliveryInfo().GetDeliveryPeriod().GetStartTime().AsTime().Equal(start))
+	require.True(t, out.GetDeliveryInfo().GetDeliveryPeriod().GetEndTime().AsTime().Equal(end))
+	require.InDelta(t, 2.5, out.GetDeliveryInfo().GetPackageInfo().GetWeightKg(), 1e-9)
+	require.InDelta(t, 30, out.GetDeliveryInfo().GetPackageInfo().GetWidthCm(), 1e-9)
+	require.InDelta(t, 20, out.GetDeliveryInfo().GetPackageInfo().GetHeightCm(), 1e-9)
+	require.Equal(t, commonv1.DeliveryPriority_DELIVERY_PRIORITY_URGENT, out.GetDeliveryInfo().GetPriority())
+	require.Equal(t, "+79001234567", out.GetDeliveryInfo().GetRecipientContacts().GetRecipientPhone())
+}
+
+func TestOrderStateToDomain_SelfPickupOrder(t *testing.T) {
+	t.Parallel()
+
+	order, err := OrderStateToDomain(testProtoOrder(nil))
+	require.NoError(t, err)
+	require.False(t, order.HasDeliveryInfo())
+	require.Nil(t, order.GetDeliveryInfo())
+
+	out := OrderStateFromDomain(order)
+	require.Nil(t, out.GetDeliveryInfo())
+}
+
+func TestOrderStateToDomain_MapsItemTaxCategory(t *testing.T) {
+	t.Parallel()
+
+	in := testProtoOrder(nil)
+	in.Items = append(in.Items, &v3.OrderItem{Id: uuid.NewString(), Quantity: 1, Price: 3.50, TaxCategory: "food"})
+
+	order, err := OrderStateToDomain(in)
+	require.NoError(t, err)
+	require.Len(t, order.GetItems(), 2)
+	require.Empty(t, order.GetItems()[0].GetTaxCategory())
+	require.Equal(t, "food", order.GetItems()[1].GetTaxCategory())
+
+	out := OrderStateFromDomain(order)
+	require.Empty(t, out.GetItems()[0].GetTaxCategory())
+	require.Equal(t, "food", out.GetItems()[1].GetTaxCategory())
+}
+
+func TestOrderStateToDomain_InvalidDeliveryAddress(t *testing.T) {
+	t.Parallel()
+
+	in := testProtoOrder(&commonv1.DeliveryInfo{
+		PickupAddress:   &commonv1.DeliveryAddress{Street: "123 Warehouse St", City: "Moscow", Country: "Russia"},
+		DeliveryAddress: &commonv1.DeliveryAddress{City: "Moscow", Country: "Russia"},
+	})
+
+	_, err := OrderStateToDomain(in)
+	require.ErrorIs(t, err, errInvalidDeliveryAddress)
+}
+
+func TestOrderStateToDomain_InvalidDeliveryFields(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now().Add(24 * time.Hour)
+
+	in := testProtoOrder(&commonv1.DeliveryInfo{
+		PickupAddress:   &commonv1.DeliveryAddress{Street: "123 Warehouse St", City: "Moscow", Country: "Russia"},
+		DeliveryAddress: &commonv1.DeliveryAddress{Street: "456 Customer St", City: "Moscow", Country: "Russia"},
+		DeliveryPeriod: &commonv1.DeliveryPeriod{
+			StartTime: timestamppb.New(start),
+			EndTime:   timestamppb.New(start.Add(-time.Hour)),
+		},
+		PackageInfo: &commonv1.PackageInfo{WeightKg: 0},
+	})
+
+	_, err := OrderStateToDomain(in)
+	require.ErrorIs(t, err, v1.ErrInvalidDeliveryPeriod)
+	require.ErrorIs(t, err, v1.ErrInvalidPackageInfo)
+}
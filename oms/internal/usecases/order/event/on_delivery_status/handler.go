@@ -14,15 +14,24 @@ import (
 	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/kafka"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/set_delivery_status"
 )
 
+// deliveryStatusSetter applies a delivery status update directly (allows mocks in tests).
+type deliveryStatusSetter interface {
+	Handle(ctx context.Context, cmd set_delivery_status.Command) error
+}
+
 // Handler handles delivery status events.
+// The update is signaled to the order workflow, which applies it through the SetDeliveryStatus activity.
+// Orders without a running workflow (created before it waited for delivery, or whose workflow
+// has finished or failed) get the update through the SetDeliveryStatus command instead.
 type Handler struct {
-	log       logger.Logger
-	uow       ports.UnitOfWork
-	orderRepo ports.OrderRepository
-	inboxRepo ports.DeliveryInboxRepository
-	publisher ports.EventPublisher
+	log               logger.Logger
+	uow               ports.UnitOfWork
+	orderRepo         ports.OrderRepository
+	orderWorkflow     ports.OrderWorkflow
+	setDeliveryStatus deliveryStatusSetter
 }
 
 // NewHandler creates a new delivery status event handler.
@@ -30,22 +39,20 @@ func NewHandler(
 	log logger.Logger,
 	uow ports.UnitOfWork,
 	orderRepo ports.OrderRepository,
-	inboxRepo ports.DeliveryInboxRepository,
-	publisher ports.EventPublisher,
+	orderWorkflow ports.OrderWorkflow,
+	setDeliveryStatus *set_delivery_status.Handler,
 ) (*Handler, error) {
 	return &Handler{
-		log:       log,
-		uow:       uow,
-		orderRepo: orderRepo,
-		inboxRepo: inboxRepo,
-		publisher: publisher,
+		log:               log,
+		uow:               uow,
+		orderRepo:         orderRepo,
+		orderWorkflow:     orderWorkflow,
+		setDeliveryStatus: setDeliveryStatus,
 	}, nil
 }
 
-const deliveryInboxConsumerName = "oms.delivery-status"
-
 // HandleDeliveryStatus processes a delivery status event.
-// Pattern: Begin -> Load -> Mutate -> Save -> Publish in tx -> Commit.
+// Pattern: Resolve order -> Signal order workflow, or apply the update directly when no workflow is running.
 func (h *Handler) HandleDeliveryStatus(ctx context.Context, event kafka.DeliveryStatusEvent) error {
 	if event.MessageID == "" {
 		return errors.New("delivery event message_id is required")
@@ -58,181 +65,90 @@ func (h *Handler) HandleDeliveryStatus(ctx context.Context, event kafka.Delivery
 		slog.String("status", event.Status),
 		slog.String("event_type", string(event.EventType)))
 
-	ctx, err := h.uow.Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-
-	committed := false
-	defer func() {
-		if committed {
-			return
-		}
-
-		rollbackErr := h.uow.Rollback(ctx)
-		if rollbackErr != nil {
-			h.log.Warn("transaction rollback failed", slog.Any("error", rollbackErr))
-		}
-	}()
-
-	inserted, err := h.inboxRepo.TryRecord(ctx, deliveryInboxConsumerName, event.MessageID, kafka.TopicDeliveryPackageStatus)
-	if err != nil {
-		return fmt.Errorf("failed to record delivery inbox message: %w", err)
-	}
-
-	if !inserted {
-		h.log.Info("Ignoring duplicate delivery message by inbox",
-			slog.String("message_id", event.MessageID),
-			slog.String("package_id", event.PackageID.String()),
-			slog.String("order_id", event.OrderID.String()),
-			slog.String("event_type", string(event.EventType)))
-
-		if err := h.uow.Commit(ctx); err != nil {
-			return fmt.Errorf("failed to commit noop transaction: %w", err)
-		}
-		committed = true
-
-		return nil
+	status, ok := targetDeliveryStatus(event.EventType)
+	if !ok {
+		return fmt.Errorf("unsupported delivery event type: %s", event.EventType)
 	}
 
-	order, err := h.loadOrderForEvent(ctx, event)
+	orderID, err := h.resolveOrderID(ctx, event)
 	if err != nil {
 		return err
 	}
 
-	if isDuplicateOrStale(order, event.EventType) {
-		h.log.Info("Ignoring duplicate or stale delivery event",
-			slog.String("package_id", event.PackageID.String()),
-			slog.String("order_id", order.GetOrderID().String()),
-			slog.String("event_type", string(event.EventType)),
-			slog.String("current_delivery_status", order.GetDeliveryStatus().String()))
-
-		if err := h.uow.Commit(ctx); err != nil {
-			return fmt.Errorf("failed to commit noop transaction: %w", err)
-		}
-		committed = true
-
-		return nil
+	update := orderv1.DeliveryStatusUpdate{
+		MessageID:           event.MessageID,
+		OrderID:             orderID,
+		PackageID:           event.PackageID,
+		CourierID:           event.CourierID,
+		Status:              status,
+		OccurredAt:          event.OccurredAt,
+		DeliveryLocation:    mapDeliveryLocation(event.DeliveryLocation),
+		NotDeliveredDetails: mapNotDeliveredDetails(event),
 	}
 
-	if err := applyDeliveryEvent(order, event); err != nil {
-		return fmt.Errorf("failed to apply delivery event: %w", err)
+	err = h.orderWorkflow.SignalDeliveryStatus(ctx, update)
+	if errors.Is(err, ports.ErrWorkflowNotRunning) {
+		return h.applyDirectly(ctx, update)
 	}
 
-	if err := h.orderRepo.Save(ctx, order); err != nil {
-		return fmt.Errorf("failed to save order: %w", err)
+	if err != nil {
+		return fmt.Errorf("failed to signal delivery status: %w", err)
 	}
 
-	for _, domainEvent := range order.GetDomainEvents() {
-		if err := h.publisher.Publish(ctx, domainEvent); err != nil {
-			return fmt.Errorf("failed to publish domain event to outbox: %w", err)
-		}
-	}
+	h.log.Info("Signaled delivery status to order workflow",
+		slog.String("order_id", orderID.String()),
+		slog.String("delivery_status", status.String()))
 
-	if err := h.uow.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-	committed = true
+	return nil
+}
 
-	order.ClearDomainEvents()
+// applyDirectly applies update through the SetDeliveryStatus command for an order without a running workflow.
+// The command's inbox keeps it idempotent, as it is for updates applied by the workflow.
+func (h *Handler) applyDirectly(ctx context.Context, update orderv1.DeliveryStatusUpdate) error {
+	err := h.setDeliveryStatus.Handle(ctx, set_delivery_status.NewCommand(update))
+	if err != nil {
+		return fmt.Errorf("failed to set delivery status without order workflow: %w", err)
+	}
 
-	h.log.Info("Successfully processed delivery status event",
-		slog.String("order_id", order.GetOrderID().String()),
-		slog.String("new_delivery_status", order.GetDeliveryStatus().String()))
+	h.log.Info("Applied delivery status without order workflow",
+		slog.String("order_id", update.OrderID.String()),
+		slog.String("delivery_status", update.Status.String()))
 
 	return nil
 }
 
-func (h *Handler) loadOrderForEvent(ctx context.Context, event kafka.DeliveryStatusEvent) (*orderv1.OrderState, error) {
+// resolveOrderID returns the event's order ID, looking it up by package ID when the event has none.
+func (h *Handler) resolveOrderID(ctx context.Context, event kafka.DeliveryStatusEvent) (uuid.UUID, error) {
 	if event.OrderID != uuid.Nil {
-		order, err := h.orderRepo.Load(ctx, event.OrderID)
-		if err != nil {
-			if errors.Is(err, ports.ErrNotFound) {
-				return nil, fmt.Errorf("order not found for delivery event: %w", err)
-			}
-
-			return nil, fmt.Errorf("failed to load order: %w", err)
-		}
-
-		return order, nil
+		return event.OrderID, nil
 	}
 
 	if event.PackageID == uuid.Nil {
-		return nil, errors.New("package_id is required")
+		return uuid.Nil, errors.New("package_id is required")
 	}
 
-	order, err := h.orderRepo.LoadByPackageID(ctx, event.PackageID)
+	ctx, err := h.uow.Begin(ctx)
 	if err != nil {
-		if errors.Is(err, ports.ErrNotFound) {
-			return nil, fmt.Errorf("order not found for package_id %s: %w", event.PackageID.String(), err)
-		}
-
-		return nil, fmt.Errorf("failed to load order by package_id: %w", err)
-	}
-
-	return order, nil
-}
-
-func applyDeliveryEvent(order *orderv1.OrderState, event kafka.DeliveryStatusEvent) error {
-	var packageID *uuid.UUID
-	if event.PackageID != uuid.Nil {
-		packageID = &event.PackageID
-	}
-
-	var courierID *uuid.UUID
-	if event.CourierID != uuid.Nil {
-		courierID = &event.CourierID
-	}
-
-	switch event.EventType {
-	case kafka.EventTypePackageAccepted:
-		return order.ApplyDeliveryAccepted(packageID, event.OccurredAt)
-	case kafka.EventTypePackageAssigned:
-		return order.ApplyDeliveryAssigned(packageID, courierID, event.OccurredAt)
-	case kafka.EventTypePackageInTransit:
-		return order.ApplyDeliveryInTransit(packageID, courierID, event.OccurredAt)
-	case kafka.EventTypePackageDelivered:
-		return order.ApplyDeliveryDelivered(
-			packageID,
-			courierID,
-			mapDeliveryLocation(event.DeliveryLocation),
-			event.OccurredAt,
-		)
-	case kafka.EventTypePackageNotDelivered:
-		return order.ApplyDeliveryFailed(
-			packageID,
-			courierID,
-			mapNotDeliveredDetails(event.NotDeliveredDetails),
-			event.OccurredAt,
-		)
-	default:
-		return fmt.Errorf("unsupported delivery event type: %s", event.EventType)
-	}
-}
-
-func isDuplicateOrStale(order *orderv1.OrderState, eventType kafka.DeliveryEventType) bool {
-	currentStatus := order.GetDeliveryStatus()
-	targetStatus, ok := targetDeliveryStatus(eventType)
-	if !ok {
-		return false
-	}
-
-	if currentStatus == targetStatus {
-		return true
+		return uuid.Nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	allowedSources, ok := allowedDeliveryTransitionSources[targetStatus]
-	if !ok {
-		return false
-	}
+	defer func() {
+		rollbackErr := h.uow.Rollback(ctx)
+		if rollbackErr != nil {
+			h.log.Warn("transaction rollback failed", slog.Any("error", rollbackErr))
+		}
+	}()
 
-	for _, allowedSource := range allowedSources {
-		if currentStatus == allowedSource {
-			return false
+	order, err := h.orderRepo.LoadByPackageID(ctx, event.PackageID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return uuid.Nil, fmt.Errorf("order not found for package_id %s: %w", event.PackageID.String(), err)
 		}
+
+		return uuid.Nil, fmt.Errorf("failed to load order by package_id: %w", err)
 	}
 
-	return true
+	return order.GetOrderID(), nil
 }
 
 func targetDeliveryStatus(eventType kafka.DeliveryEventType) (commonv1.DeliveryStatus, bool) {
@@ -252,33 +168,6 @@ func targetDeliveryStatus(eventType kafka.DeliveryEventType) (commonv1.DeliveryS
 	}
 }
 
-var allowedDeliveryTransitionSources = map[commonv1.DeliveryStatus][]commonv1.DeliveryStatus{
-	commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED: {
-		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
-	},
-	commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED: {
-		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
-		commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
-	},
-	commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT: {
-		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
-		commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
-		commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
-	},
-	commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED: {
-		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
-		commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
-		commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
-		commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
-	},
-	commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED: {
-		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
-		commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
-		commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
-		commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
-	},
-}
-
 func mapDeliveryLocation(location *deliverycommon.Location) *commonv1.DeliveryLocation {
 	if location == nil {
 		return nil
@@ -292,13 +181,14 @@ func mapDeliveryLocation(location *deliverycommon.Location) *commonv1.DeliveryLo
 	}
 }
 
-func mapNotDeliveredDetails(details *deliverycommon.NotDeliveredDetails) *commonv1.NotDeliveredDetails {
-	if details == nil {
+// mapNotDeliveredDetails uses the reason the consumer already mapped to the OMS enum.
+func mapNotDeliveredDetails(event kafka.DeliveryStatusEvent) *commonv1.NotDeliveredDetails {
+	if event.NotDeliveredDetails == nil {
 		return nil
 	}
 
 	return &commonv1.NotDeliveredDetails{
-		Reason:      commonv1.NotDeliveredReason(details.GetReason()),
-		Description: details.GetDescription(),
+		Reason:      event.NotDeliveredReason,
+		Description: event.NotDeliveredDetails.GetDescription(),
 	}
 }
@@ -1,95 +1,143 @@
 package on_delivery_status
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/shopspring/decimal"
+	"github.com/shortlink-org/go-sdk/logger"
 	"github.com/stretchr/testify/require"
 
 	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
 	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
 	"github.com/shortlink-org/shop/oms/internal/infrastructure/kafka"
+	"github.com/shortlink-org/shop/oms/internal/usecases/order/command/set_delivery_status"
 )
 
-func TestIsDuplicateOrStale(t *testing.T) {
-	t.Parallel()
-
-	testCases := []struct {
-		name           string
-		currentStatus  commonv1.DeliveryStatus
-		eventType      kafka.DeliveryEventType
-		expectedResult bool
-	}{
-		{
-			name:           "duplicate target is ignored",
-			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
-			eventType:      kafka.EventTypePackageAssigned,
-			expectedResult: true,
-		},
-		{
-			name:           "assigned is allowed when accepted was skipped",
-			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
-			eventType:      kafka.EventTypePackageAssigned,
-			expectedResult: false,
-		},
-		{
-			name:           "in transit is allowed when earlier statuses were skipped",
-			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
-			eventType:      kafka.EventTypePackageInTransit,
-			expectedResult: false,
-		},
-		{
-			name:           "assigned after in transit is stale",
-			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
-			eventType:      kafka.EventTypePackageAssigned,
-			expectedResult: true,
-		},
-		{
-			name:           "accepted after assigned is stale",
-			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
-			eventType:      kafka.EventTypePackageAccepted,
-			expectedResult: true,
-		},
-		{
-			name:           "delivered is allowed directly from assigned",
-			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
-			eventType:      kafka.EventTypePackageDelivered,
-			expectedResult: false,
-		},
-		{
-			name:           "terminal cross-over is stale",
-			currentStatus:  commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
-			eventType:      kafka.EventTypePackageNotDelivered,
-			expectedResult: true,
-		},
+// recordingOrderWorkflow records the delivery status updates signaled to it and fails with err when set.
+type recordingOrderWorkflow struct {
+	updates []orderv1.DeliveryStatusUpdate
+	err     error
+}
+
+func (w *recordingOrderWorkflow) SignalDeliveryStatus(_ context.Context, update orderv1.DeliveryStatusUpdate) error {
+	if w.err != nil {
+		return w.err
 	}
 
-	for _, tc := range testCases {
-		tc := tc
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+	w.updates = append(w.updates, update)
 
-			order := newOrderStateForDeliveryStatus(t, tc.currentStatus)
+	return nil
+}
 
-			require.Equal(t, tc.expectedResult, isDuplicateOrStale(order, tc.eventType))
-		})
-	}
+// recordingStatusSetter records the updates applied without the order workflow.
+type recordingStatusSetter struct {
+	updates []orderv1.DeliveryStatusUpdate
 }
 
-func newOrderStateForDeliveryStatus(t *testing.T, status commonv1.DeliveryStatus) *orderv1.OrderState {
+func (s *recordingStatusSetter) Handle(_ context.Context, cmd set_delivery_status.Command) error {
+	s.updates = append(s.updates, cmd.Update)
+
+	return nil
+}
+
+func newTestHandler(t *testing.T, orderWorkflow *recordingOrderWorkflow) *Handler {
 	t.Helper()
 
-	return orderv1.NewOrderStateFromPersisted(
-		uuid.New(),
-		uuid.New(),
-		orderv1.Items{
-			orderv1.NewItem(uuid.New(), 1, decimal.NewFromInt(10)),
-		},
-		orderv1.OrderStatus_ORDER_STATUS_PROCESSING,
-		1,
-		nil,
-		status,
-		nil,
-	)
+	return newTestHandlerWithSetter(t, orderWorkflow, &recordingStatusSetter{})
+}
+
+func newTestHandlerWithSetter(t *testing.T, orderWorkflow *recordingOrderWorkflow, setter *recordingStatusSetter) *Handler {
+	t.Helper()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	handler, err := NewHandler(log, nil, nil, orderWorkflow, nil)
+	require.NoError(t, err)
+
+	handler.setDeliveryStatus = setter
+
+	return handler
+}
+
+func TestHandleDeliveryStatus_SignalsOrderWorkflow(t *testing.T) {
+	orderWorkflow := &recordingOrderWorkflow{}
+	handler := newTestHandler(t, orderWorkflow)
+
+	event := kafka.DeliveryStatusEvent{
+		MessageID:  uuid.NewString(),
+		OrderID:    uuid.New(),
+		PackageID:  uuid.New(),
+		CourierID:  uuid.New(),
+		Status:     "PACKAGE_STATUS_IN_TRANSIT",
+		EventType:  kafka.EventTypePackageInTransit,
+		OccurredAt: time.Date(2026, time.March, 11, 10, 3, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, handler.HandleDeliveryStatus(context.Background(), event))
+
+	require.Equal(t, []orderv1.DeliveryStatusUpdate{{
+		MessageID:  event.MessageID,
+		OrderID:    event.OrderID,
+		PackageID:  event.PackageID,
+		CourierID:  event.CourierID,
+		Status:     commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+		OccurredAt: event.OccurredAt,
+	}}, orderWorkflow.updates)
+}
+
+func TestHandleDeliveryStatus_UnsupportedEventTypeIsNotSignaled(t *testing.T) {
+	orderWorkflow := &recordingOrderWorkflow{}
+	handler := newTestHandler(t, orderWorkflow)
+
+	err := handler.HandleDeliveryStatus(context.Background(), kafka.DeliveryStatusEvent{
+		MessageID: uuid.NewString(),
+		OrderID:   uuid.New(),
+		EventType: kafka.DeliveryEventType("PACKAGE_RETURNED"),
+	})
+
+	require.ErrorContains(t, err, "unsupported delivery event type")
+	require.Empty(t, orderWorkflow.updates)
+}
+
+func TestHandleDeliveryStatus_AppliesDirectlyWithoutRunningWorkflow(t *testing.T) {
+	orderWorkflow := &recordingOrderWorkflow{err: fmt.Errorf("signal: %w", ports.ErrWorkflowNotRunning)}
+	setter := &recordingStatusSetter{}
+	handler := newTestHandlerWithSetter(t, orderWorkflow, setter)
+
+	event := kafka.DeliveryStatusEvent{
+		MessageID:  uuid.NewString(),
+		OrderID:    uuid.New(),
+		PackageID:  uuid.New(),
+		Status:     "PACKAGE_STATUS_DELIVERED",
+		EventType:  kafka.EventTypePackageDelivered,
+		OccurredAt: time.Date(2026, time.March, 11, 10, 3, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, handler.HandleDeliveryStatus(context.Background(), event))
+
+	require.Len(t, setter.updates, 1)
+	require.Equal(t, event.MessageID, setter.updates[0].MessageID)
+	require.Equal(t, event.OrderID, setter.updates[0].OrderID)
+	require.Equal(t, commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED, setter.updates[0].Status)
+}
+
+func TestHandleDeliveryStatus_SignalFailureIsReturned(t *testing.T) {
+	signalErr := errors.New("temporal unavailable")
+	setter := &recordingStatusSetter{}
+	handler := newTestHandlerWithSetter(t, &recordingOrderWorkflow{err: signalErr}, setter)
+
+	err := handler.HandleDeliveryStatus(context.Background(), kafka.DeliveryStatusEvent{
+		MessageID: uuid.NewString(),
+		OrderID:   uuid.New(),
+		EventType: kafka.EventTypePackageInTransit,
+	})
+
+	require.ErrorIs(t, err, signalErr)
+	require.Empty(t, setter.updates, "a transient signal failure is retried by redelivery, not applied directly")
 }
@@ -0,0 +1,153 @@
+package on_order_changed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	logger "github.com/shortlink-org/go-sdk/logger"
+
+	order "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	orderevents "github.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+// Handler projects order events into the order search read model.
+// Every event re-reads the order, so the document always reflects the latest stored state.
+type Handler struct {
+	log        logger.Logger
+	uow        ports.UnitOfWork
+	orderRepo  ports.OrderRepository
+	searchRepo ports.OrderSearchRepository
+}
+
+// NewHandler creates a new order search projection handler.
+func NewHandler(
+	log logger.Logger,
+	uow ports.UnitOfWork,
+	orderRepo ports.OrderRepository,
+	searchRepo ports.OrderSearchRepository,
+) (*Handler, error) {
+	return &Handler{
+		log:        log,
+		uow:        uow,
+		orderRepo:  orderRepo,
+		searchRepo: searchRepo,
+	}, nil
+}
+
+// Handle upserts the search document of the order the event belongs to.
+// An order that is no longer loadable, i.e. archived, has its document removed.
+func (h *Handler) Handle(ctx context.Context, event ports.OrderEvent) error {
+	orderID, err := uuid.Parse(event.GetOrderId())
+	if err != nil {
+		return fmt.Errorf("parse order id: %w", err)
+	}
+
+	txCtx, err := h.uow.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		if rollbackErr := h.uow.Rollback(txCtx); rollbackErr != nil {
+			h.log.Warn("order search projection rollback failed", slog.String("error", rollbackErr.Error()))
+		}
+	}()
+
+	orderState, err := h.orderRepo.Load(txCtx, orderID)
+	if errors.Is(err, ports.ErrNotFound) {
+		// Archived orders are not loadable, so they must not stay searchable either.
+		if err := h.searchRepo.RemoveOrder(ctx, orderID); err != nil {
+			return fmt.Errorf("remove order search document: %w", err)
+		}
+
+		h.log.Info("order search projection removed order that is not found",
+			slog.String("order_id", event.GetOrderId()),
+			slog.String("event_type", event.EventType()))
+
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load order: %w", err)
+	}
+
+	if err := h.uow.Commit(txCtx); err != nil {
+		return fmt.Errorf("commit read transaction: %w", err)
+	}
+	committed = true
+
+	applied, err := h.searchRepo.ApplyOrder(ctx, NewDocument(orderState, event))
+	if err != nil {
+		return fmt.Errorf("apply order search projection: %w", err)
+	}
+
+	if !applied {
+		h.log.Info("order search projection ignored stale order event",
+			slog.String("order_id", event.GetOrderId()),
+			slog.String("event_type", event.EventType()),
+			slog.Int("version", orderState.GetVersion()))
+	}
+
+	return nil
+}
+
+// NewDocument builds the search document of an order as of event.
+// The total is the priced final price; an order that was never priced falls back to the sum of its items.
+// The creation time is only known from OrderCreated; for other events it falls back
+// to the event time, which matters only when the projection starts after the order was created.
+func NewDocument(orderState *order.OrderState, event ports.OrderEvent) ports.OrderSearchDocument {
+	updatedAt := time.Now().UTC()
+	if occurredAt := event.GetOccurredAt(); occurredAt != nil {
+		updatedAt = occurredAt.AsTime()
+	}
+
+	createdAt := updatedAt
+	if created, ok := event.(*orderevents.OrderCreated); ok && created.GetCreatedAt() != nil {
+		createdAt = created.GetCreatedAt().AsTime()
+	}
+
+	items := orderState.GetItems()
+	goodIDs := make([]uuid.UUID, 0, len(items))
+	itemsTotal := decimal.Zero
+
+	for _, item := range items {
+		goodIDs = append(goodIDs, item.GetGoodId())
+		itemsTotal = itemsTotal.Add(item.GetPrice().Mul(decimal.NewFromInt32(item.GetQuantity())))
+	}
+
+	total := orderState.GetTotals().FinalPrice
+	if total.IsZero() {
+		total = itemsTotal
+	}
+
+	return ports.OrderSearchDocument{
+		OrderID:          orderState.GetOrderID(),
+		CustomerID:       orderState.GetCustomerId(),
+		CustomerEmail:    customerEmail(orderState),
+		Status:           orderState.GetStatus(),
+		GoodIDs:          goodIDs,
+		Total:            total,
+		AggregateVersion: int32(orderState.GetVersion()), //nolint:gosec // order versions stay far below MaxInt32
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt,
+	}
+}
+
+// customerEmail returns the recipient email of the order's delivery info; empty when unknown.
+func customerEmail(orderState *order.OrderState) string {
+	info := orderState.GetDeliveryInfo()
+	if info == nil || info.GetRecipientContacts() == nil {
+		return ""
+	}
+
+	return info.GetRecipientContacts().GetEmail()
+}
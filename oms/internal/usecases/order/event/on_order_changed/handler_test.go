@@ -0,0 +1,242 @@
+package on_order_changed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	logger "github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	orderevents "github.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(ctx context.Context) (context.Context, error) { return ctx, nil }
+func (stubUnitOfWork) Commit(context.Context) error                       { return nil }
+func (stubUnitOfWork) Rollback(context.Context) error                     { return nil }
+
+// stubOrderRepository serves the latest state of each order.
+type stubOrderRepository struct {
+	orders map[uuid.UUID]*orderv1.OrderState
+}
+
+func (s *stubOrderRepository) Load(_ context.Context, orderID uuid.UUID) (*orderv1.OrderState, error) {
+	state, ok := s.orders[orderID]
+	if !ok {
+		return nil, ports.ErrNotFound
+	}
+
+	return state, nil
+}
+
+func (*stubOrderRepository) LoadByPackageID(context.Context, uuid.UUID) (*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func (*stubOrderRepository) Save(context.Context, *orderv1.OrderState) error {
+	panic("unexpected call")
+}
+
+func (*stubOrderRepository) List(context.Context, ports.ListFilter) ([]*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func (*stubOrderRepository) ListPage(context.Context, ports.ListPageFilter) (*ports.OrderPage, error) {
+	panic("unexpected call")
+}
+
+func (*stubOrderRepository) ListByCustomer(context.Context, uuid.UUID) ([]*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+// memorySearchRepository keeps documents in memory with the same version rule as the Postgres store.
+type memorySearchRepository struct {
+	documents map[uuid.UUID]ports.OrderSearchDocument
+}
+
+func (m *memorySearchRepository) ApplyOrder(_ context.Context, document ports.OrderSearchDocument) (bool, error) {
+	current, ok := m.documents[document.OrderID]
+	if ok && current.AggregateVersion >= document.AggregateVersion {
+		return false, nil
+	}
+
+	if ok {
+		document.CreatedAt = current.CreatedAt
+	}
+
+	m.documents[document.OrderID] = document
+
+	return true, nil
+}
+
+func (m *memorySearchRepository) RemoveOrder(_ context.Context, orderID uuid.UUID) error {
+	delete(m.documents, orderID)
+
+	return nil
+}
+
+func (*memorySearchRepository) Search(context.Context, ports.OrderSearchFilter) (*ports.OrderSearchPage, error) {
+	panic("unexpected call")
+}
+
+func newTestHandler(t *testing.T) (*Handler, *stubOrderRepository, *memorySearchRepository) {
+	t.Helper()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	orders := &stubOrderRepository{orders: make(map[uuid.UUID]*orderv1.OrderState)}
+	search := &memorySearchRepository{documents: make(map[uuid.UUID]ports.OrderSearchDocument)}
+
+	handler, err := NewHandler(log, stubUnitOfWork{}, orders, search)
+	require.NoError(t, err)
+
+	return handler, orders, search
+}
+
+func newDeliveryInfo(t *testing.T, email string) *orderv1.DeliveryInfo {
+	t.Helper()
+
+	pickupAddr, err := address.NewAddress("123 Warehouse St", "Moscow", "101000", "Russia")
+	require.NoError(t, err)
+	deliveryAddr, err := address.NewAddress("456 Customer St", "Moscow", "102000", "Russia")
+	require.NoError(t, err)
+
+	startTime := time.Now().Add(24 * time.Hour)
+	recipient := orderv1.NewRecipientContacts("Jane Doe", "+79001234567", email)
+	info := orderv1.NewDeliveryInfo(
+		pickupAddr, deliveryAddr, orderv1.NewDeliveryPeriod(startTime, startTime.Add(2*time.Hour)),
+		orderv1.NewPackageInfo(2.5), orderv1.DeliveryPriorityNormal, &recipient,
+	)
+
+	return &info
+}
+
+func TestHandle_ProjectsOrderEvents(t *testing.T) {
+	t.Parallel()
+
+	handler, orders, search := newTestHandler(t)
+
+	orderID, customerID := uuid.New(), uuid.New()
+	firstGood, secondGood := uuid.New(), uuid.New()
+	items := orderv1.Items{
+		orderv1.NewItem(firstGood, 2, decimal.NewFromFloat(10.50)),
+		orderv1.NewItem(secondGood, 1, decimal.NewFromInt(4)),
+	}
+	deliveryInfo := newDeliveryInfo(t, "jane@example.com")
+	createdAt := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	orders.orders[orderID] = orderv1.NewOrderStateFromPersisted(orderID, customerID, items,
+		orderv1.OrderStatus_ORDER_STATUS_PENDING, 1, deliveryInfo, commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED, nil)
+
+	require.NoError(t, handler.Handle(context.Background(), &orderevents.OrderCreated{
+		OrderId:    orderID.String(),
+		CreatedAt:  timestamppb.New(createdAt),
+		OccurredAt: timestamppb.New(createdAt),
+	}))
+
+	document := search.documents[orderID]
+	require.Equal(t, customerID, document.CustomerID)
+	require.Equal(t, "jane@example.com", document.CustomerEmail)
+	require.Equal(t, orderv1.OrderStatus_ORDER_STATUS_PENDING, document.Status)
+	require.Equal(t, []uuid.UUID{firstGood, secondGood}, document.GoodIDs)
+	require.Equal(t, "25", document.Total.String())
+	require.Equal(t, int32(1), document.AggregateVersion)
+	require.Equal(t, createdAt, document.CreatedAt)
+
+	// The order completes; the next event re-reads it and moves the document forward.
+	completedAt := createdAt.Add(time.Hour)
+	orders.orders[orderID] = orderv1.NewOrderStateFromPersisted(orderID, customerID, items,
+		orderv1.OrderStatus_ORDER_STATUS_COMPLETED, 2, deliveryInfo, commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED, nil)
+
+	completed := &orderevents.OrderCompleted{OrderId: orderID.String(), OccurredAt: timestamppb.New(completedAt)}
+	require.NoError(t, handler.Handle(context.Background(), completed))
+
+	document = search.documents[orderID]
+	require.Equal(t, orderv1.OrderStatus_ORDER_STATUS_COMPLETED, document.Status)
+	require.Equal(t, int32(2), document.AggregateVersion)
+	require.Equal(t, createdAt, document.CreatedAt)
+	require.Equal(t, completedAt, document.UpdatedAt)
+
+	// A redelivered event leaves the document as it is.
+	require.NoError(t, handler.Handle(context.Background(), completed))
+	require.Equal(t, document, search.documents[orderID])
+}
+
+func TestHandle_RemovesOrderNotFound(t *testing.T) {
+	t.Parallel()
+
+	handler, orders, search := newTestHandler(t)
+
+	orderID := uuid.New()
+	orders.orders[orderID] = orderv1.NewOrderStateFromPersisted(orderID, uuid.New(), nil,
+		orderv1.OrderStatus_ORDER_STATUS_CANCELED, 2, nil, commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED, nil)
+
+	cancelled := &orderevents.OrderCancelled{OrderId: orderID.String()}
+	require.NoError(t, handler.Handle(context.Background(), cancelled))
+	require.Contains(t, search.documents, orderID)
+
+	// The order is archived; a redelivered event takes it out of the search results.
+	delete(orders.orders, orderID)
+
+	require.NoError(t, handler.Handle(context.Background(), cancelled))
+	require.NotContains(t, search.documents, orderID)
+
+	// An order that never had a document is not an error either.
+	require.NoError(t, handler.Handle(context.Background(), &orderevents.OrderCancelled{OrderId: uuid.NewString()}))
+	require.Empty(t, search.documents)
+}
+
+func TestHandle_RejectsInvalidOrderID(t *testing.T) {
+	t.Parallel()
+
+	handler, _, _ := newTestHandler(t)
+
+	err := handler.Handle(context.Background(), &orderevents.OrderCancelled{OrderId: "not-a-uuid"})
+	require.Error(t, err)
+}
+
+func TestNewDocument_WithoutDeliveryInfo(t *testing.T) {
+	t.Parallel()
+
+	occurredAt := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	state := orderv1.NewOrderStateFromPersisted(uuid.New(), uuid.New(), nil,
+		orderv1.OrderStatus_ORDER_STATUS_PROCESSING, 3, nil, commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED, nil)
+
+	document := NewDocument(state, &orderevents.OrderDeliveryRequestedEvent{
+		OrderId:    state.GetOrderID().String(),
+		CreatedAt:  timestamppb.New(occurredAt.Add(-time.Hour)),
+		OccurredAt: timestamppb.New(occurredAt),
+	})
+
+	require.Empty(t, document.CustomerEmail)
+	require.Empty(t, document.GoodIDs)
+	require.True(t, document.Total.IsZero())
+	// Only OrderCreated carries the order creation time.
+	require.Equal(t, occurredAt, document.CreatedAt)
+}
+
+func TestNewDocument_TotalIsFinalPrice(t *testing.T) {
+	t.Parallel()
+
+	items := orderv1.Items{orderv1.NewItem(uuid.New(), 2, decimal.NewFromFloat(10.50))}
+	state := orderv1.NewOrderStateFromPersisted(uuid.New(), uuid.New(), items,
+		orderv1.OrderStatus_ORDER_STATUS_PENDING, 1, nil, commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED, nil,
+		orderv1.WithTotals(orderv1.OrderTotals{
+			Subtotal:   decimal.RequireFromString("21.00"),
+			TotalTax:   decimal.RequireFromString("1.68"),
+			FinalPrice: decimal.RequireFromString("19.68"),
+		}))
+
+	document := NewDocument(state, &orderevents.OrderCreated{OrderId: state.GetOrderID().String()})
+	require.Equal(t, "19.68", document.Total.String(), "discounts and tax are part of the total")
+}
@@ -0,0 +1,103 @@
+// Package order_pricing holds the pricing rules shared by the order usecases that price items:
+// checkout prices cart items, UpdateOrderItems reprices order items.
+package order_pricing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+	pricing "github.com/shortlink-org/shop/oms/internal/domain/pricing"
+)
+
+// ErrPriceDiscrepancy is returned when an item price and the pricer disagree on its unit price.
+var ErrPriceDiscrepancy = fmt.Errorf("%w: item price does not match pricer price", domain.ErrConflict)
+
+// priceTolerance is the largest per-unit difference accepted as rounding noise.
+var priceTolerance = decimal.New(1, -2)
+
+// PriceDiscrepancyError reports the item whose recorded and pricer unit prices differ beyond the tolerance.
+type PriceDiscrepancyError struct {
+	ProductID   uuid.UUID
+	ItemPrice   decimal.Decimal
+	PricerPrice decimal.Decimal
+}
+
+func (e *PriceDiscrepancyError) Error() string {
+	return fmt.Sprintf("item %s: item price %s, pricer price %s", e.ProductID, e.ItemPrice, e.PricerPrice)
+}
+
+// Unwrap lets callers match the discrepancy with errors.Is(err, ErrPriceDiscrepancy).
+func (e *PriceDiscrepancyError) Unwrap() error {
+	return ErrPriceDiscrepancy
+}
+
+// Line is a priced item of a cart or an order.
+type Line struct {
+	ProductID uuid.UUID
+	Quantity  int32
+	// UnitPrice, Discount and Tax are per unit; order items carry neither discount nor tax
+	UnitPrice decimal.Decimal
+	Discount  decimal.Decimal
+	Tax       decimal.Decimal
+}
+
+// ReconcileItemPrices compares the pricer's per-item breakdown against the item prices,
+// so an order never records a price the customer is not charged.
+// An empty breakdown (pricer reports totals only) has nothing to reconcile.
+// Items missing from the breakdown are not checked; every mismatch is reported.
+func ReconcileItemPrices(lines []Line, priced []ports.PricedItemData) error {
+	if len(priced) == 0 {
+		return nil
+	}
+
+	pricerPrices := make(map[uuid.UUID]decimal.Decimal, len(priced))
+	for _, item := range priced {
+		pricerPrices[item.ProductID] = item.UnitPrice
+	}
+
+	var errs []error
+
+	for _, line := range lines {
+		pricerPrice, ok := pricerPrices[line.ProductID]
+		if !ok {
+			continue
+		}
+
+		if line.UnitPrice.Sub(pricerPrice).Abs().GreaterThan(priceTolerance) {
+			errs = append(errs, &PriceDiscrepancyError{
+				ProductID:   line.ProductID,
+				ItemPrice:   line.UnitPrice,
+				PricerPrice: pricerPrice,
+			})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// CalculateTotals prices the lines from their own prices, discounts and taxes, for use without a pricer.
+func CalculateTotals(lines []Line, currency pricing.Currency) ports.CalculateTotalResponse {
+	subtotal := decimal.Zero
+	totalDiscount := decimal.Zero
+	totalTax := decimal.Zero
+
+	for _, line := range lines {
+		quantity := decimal.NewFromInt32(line.Quantity)
+		subtotal = subtotal.Add(line.UnitPrice.Mul(quantity))
+		totalDiscount = totalDiscount.Add(line.Discount.Mul(quantity))
+		totalTax = totalTax.Add(line.Tax.Mul(quantity))
+	}
+
+	return ports.CalculateTotalResponse{
+		Subtotal:      subtotal,
+		TotalDiscount: totalDiscount,
+		TotalTax:      totalTax,
+		FinalPrice:    subtotal.Sub(totalDiscount).Add(totalTax),
+		Currency:      currency,
+	}
+}
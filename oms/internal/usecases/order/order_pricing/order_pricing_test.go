@@ -0,0 +1,101 @@
+package order_pricing
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+func TestReconcileItemPrices(t *testing.T) {
+	t.Parallel()
+
+	goodID, otherGoodID := uuid.New(), uuid.New()
+
+	lines := []Line{
+		{ProductID: goodID, Quantity: 2, UnitPrice: decimal.NewFromInt(50)},
+		{ProductID: otherGoodID, Quantity: 1, UnitPrice: decimal.NewFromInt(20)},
+	}
+
+	tests := []struct {
+		name         string
+		priced       []ports.PricedItemData
+		mismatchedID []uuid.UUID
+	}{
+		{
+			name: "no breakdown",
+		},
+		{
+			name: "matching prices",
+			priced: []ports.PricedItemData{
+				{ProductID: goodID, UnitPrice: decimal.NewFromInt(50)},
+				{ProductID: otherGoodID, UnitPrice: decimal.NewFromInt(20)},
+			},
+		},
+		{
+			name: "within tolerance",
+			priced: []ports.PricedItemData{
+				{ProductID: goodID, UnitPrice: decimal.RequireFromString("50.01")},
+			},
+		},
+		{
+			name: "mismatched price",
+			priced: []ports.PricedItemData{
+				{ProductID: goodID, UnitPrice: decimal.NewFromInt(45)},
+				{ProductID: otherGoodID, UnitPrice: decimal.NewFromInt(20)},
+			},
+			mismatchedID: []uuid.UUID{goodID},
+		},
+		{
+			name: "every mismatch is reported",
+			priced: []ports.PricedItemData{
+				{ProductID: goodID, UnitPrice: decimal.NewFromInt(45)},
+				{ProductID: otherGoodID, UnitPrice: decimal.RequireFromString("20.02")},
+			},
+			mismatchedID: []uuid.UUID{goodID, otherGoodID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ReconcileItemPrices(lines, tt.priced)
+			if len(tt.mismatchedID) == 0 {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrPriceDiscrepancy)
+			require.ErrorIs(t, err, domain.ErrConflict)
+
+			var discrepancy *PriceDiscrepancyError
+			require.ErrorAs(t, err, &discrepancy)
+			require.Equal(t, tt.mismatchedID[0], discrepancy.ProductID)
+
+			for _, id := range tt.mismatchedID {
+				require.Contains(t, err.Error(), id.String())
+			}
+		})
+	}
+}
+
+func TestCalculateTotals(t *testing.T) {
+	t.Parallel()
+
+	totals := CalculateTotals([]Line{
+		{ProductID: uuid.New(), Quantity: 2, UnitPrice: decimal.NewFromInt(50), Discount: decimal.NewFromInt(5), Tax: decimal.NewFromInt(4)},
+		{ProductID: uuid.New(), Quantity: 1, UnitPrice: decimal.NewFromInt(20)},
+	}, "EUR")
+
+	require.True(t, decimal.NewFromInt(120).Equal(totals.Subtotal))
+	require.True(t, decimal.NewFromInt(10).Equal(totals.TotalDiscount))
+	require.True(t, decimal.NewFromInt(8).Equal(totals.TotalTax))
+	require.True(t, decimal.NewFromInt(118).Equal(totals.FinalPrice))
+	require.Equal(t, "EUR", totals.Currency.String())
+}
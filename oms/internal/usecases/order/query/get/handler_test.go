@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
 	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
 )
 
@@ -39,6 +42,10 @@ func (stubOrderRepository) List(context.Context, ports.ListFilter) ([]*orderv1.O
 	panic("unexpected call")
 }
 
+func (stubOrderRepository) ListPage(context.Context, ports.ListPageFilter) (*ports.OrderPage, error) {
+	panic("unexpected call")
+}
+
 func (stubOrderRepository) ListByCustomer(context.Context, uuid.UUID) ([]*orderv1.OrderState, error) {
 	panic("unexpected call")
 }
@@ -103,3 +110,81 @@ func TestHandleReturnsOrderForOwner(t *testing.T) {
 		t.Fatalf("expected order %s, got %s", expected.GetOrderID(), result.GetOrderID())
 	}
 }
+
+func TestHandleReturnsNotFoundWhenOrderMissing(t *testing.T) {
+	t.Parallel()
+
+	handler, err := NewHandler(stubUnitOfWork{}, stubOrderRepository{err: ports.ErrNotFound})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	result, err := handler.Handle(context.Background(), NewQuery(uuid.New()))
+	if !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if result != nil {
+		t.Fatalf("expected nil result, got %v", result)
+	}
+}
+
+func TestHandleReturnsItemsAndDeliveryInfo(t *testing.T) {
+	t.Parallel()
+
+	orderID := uuid.New()
+	goodID := uuid.New()
+	packageID := uuid.New()
+
+	pickupAddr, err := address.NewAddress("123 Warehouse St", "Moscow", "101000", "Russia")
+	if err != nil {
+		t.Fatalf("NewAddress returned error: %v", err)
+	}
+	deliveryAddr, err := address.NewAddress("456 Customer St", "Moscow", "102000", "Russia")
+	if err != nil {
+		t.Fatalf("NewAddress returned error: %v", err)
+	}
+
+	start := time.Now().Add(24 * time.Hour)
+	deliveryInfo := orderv1.NewDeliveryInfo(
+		pickupAddr,
+		deliveryAddr,
+		orderv1.NewDeliveryPeriod(start, start.Add(2*time.Hour)),
+		orderv1.NewPackageInfo(2.5),
+		orderv1.DeliveryPriorityNormal,
+		nil,
+	)
+	deliveryInfo.SetPackageId(packageID)
+
+	expected := orderv1.NewOrderStateFromPersisted(
+		orderID,
+		uuid.New(),
+		orderv1.Items{orderv1.NewItem(goodID, 2, decimal.NewFromInt(10))},
+		orderv1.OrderStatus_ORDER_STATUS_PROCESSING,
+		3,
+		&deliveryInfo,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+		nil,
+	)
+
+	handler, err := NewHandler(stubUnitOfWork{}, stubOrderRepository{order: expected})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	result, err := handler.Handle(context.Background(), NewQuery(orderID))
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	if result.GetStatus() != orderv1.OrderStatus_ORDER_STATUS_PROCESSING {
+		t.Fatalf("expected PROCESSING status, got %s", result.GetStatus())
+	}
+	if len(result.GetItems()) != 1 || result.GetItems()[0].GetGoodId() != goodID {
+		t.Fatalf("expected single item %s, got %v", goodID, result.GetItems())
+	}
+	if result.GetDeliveryStatus() != commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED {
+		t.Fatalf("expected ACCEPTED delivery status, got %s", result.GetDeliveryStatus())
+	}
+	if info := result.GetDeliveryInfo(); info == nil || info.GetPackageId() == nil || *info.GetPackageId() != packageID {
+		t.Fatalf("expected delivery info with package %s, got %v", packageID, info)
+	}
+}
@@ -0,0 +1,87 @@
+package list_orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	order "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+var errInvalidCreatedRange = errors.New("createdFrom must be before createdTo")
+
+// Result is the result of the ListOrders query.
+type Result struct {
+	Orders []*order.OrderState
+	// NextPageToken is empty when there are no more orders
+	NextPageToken string
+}
+
+// Handler handles ListOrders queries.
+type Handler struct {
+	uow       ports.UnitOfWork
+	orderRepo ports.OrderRepository
+}
+
+// NewHandler creates a new ListOrders handler.
+func NewHandler(
+	uow ports.UnitOfWork,
+	orderRepo ports.OrderRepository,
+) (*Handler, error) {
+	return &Handler{
+		uow:       uow,
+		orderRepo: orderRepo,
+	}, nil
+}
+
+// Handle executes the ListOrders query.
+func (h *Handler) Handle(ctx context.Context, q Query) (*Result, error) {
+	if q.CreatedFrom != nil && q.CreatedTo != nil && !q.CreatedFrom.Before(*q.CreatedTo) {
+		return nil, domain.WrapValidation("ListOrders", errInvalidCreatedRange)
+	}
+
+	pageSize := q.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	pageSize = min(pageSize, MaxPageSize)
+
+	ctx, err := h.uow.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		rollbackErr := h.uow.Rollback(ctx)
+		if rollbackErr != nil {
+			slog.Default().WarnContext(ctx, "transaction rollback failed", "error", rollbackErr)
+		}
+	}()
+
+	page, err := h.orderRepo.ListPage(ctx, ports.ListPageFilter{
+		ListFilter: ports.ListFilter{
+			CustomerID:   q.CustomerID,
+			StatusFilter: q.StatusFilter,
+		},
+		CreatedFrom: q.CreatedFrom,
+		CreatedTo:   q.CreatedTo,
+		PageSize:    pageSize,
+		PageToken:   q.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.uow.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &Result{
+		Orders:        page.Orders,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
@@ -0,0 +1,106 @@
+//go:build integration
+
+package list_orders
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	orderrepo "github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/order"
+	"github.com/shortlink-org/shop/oms/internal/infrastructure/repository/postgres/testhelpers"
+	uowpg "github.com/shortlink-org/shop/oms/pkg/uow/postgres"
+)
+
+func TestHandle_Integration(t *testing.T) {
+	pc := testhelpers.SetupPostgresContainer(t)
+	store, err := orderrepo.New(context.Background(), pc.DB())
+	require.NoError(t, err)
+	t.Cleanup(store.Close)
+
+	uow := uowpg.New(pc.Pool)
+	handler, err := NewHandler(uow, store)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	customerA := uuid.New()
+	customerB := uuid.New()
+	base := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	// Four orders: A/processing/day0, A/canceled/day1, B/processing/day2, B/processing/day3
+	fixtures := []struct {
+		customerID uuid.UUID
+		cancel     bool
+		createdAt  time.Time
+	}{
+		{customerID: customerA, createdAt: base},
+		{customerID: customerA, cancel: true, createdAt: base.AddDate(0, 0, 1)},
+		{customerID: customerB, createdAt: base.AddDate(0, 0, 2)},
+		{customerID: customerB, createdAt: base.AddDate(0, 0, 3)},
+	}
+
+	orderIDs := make([]uuid.UUID, len(fixtures))
+	for i, fixture := range fixtures {
+		state := orderv1.NewOrderState(fixture.customerID)
+		require.NoError(t, state.CreateOrder(ctx, orderv1.Items{
+			orderv1.NewItem(uuid.New(), 1, decimal.NewFromInt(10)),
+		}))
+
+		if fixture.cancel {
+			require.NoError(t, state.CancelOrder())
+		}
+
+		txCtx, err := uow.Begin(ctx)
+		require.NoError(t, err)
+		require.NoError(t, store.Save(txCtx, state))
+		require.NoError(t, uow.Commit(txCtx))
+
+		_, err = pc.Pool.Exec(ctx, `UPDATE oms.orders SET created_at = $2 WHERE id = $1`, state.GetOrderID(), fixture.createdAt)
+		require.NoError(t, err)
+
+		orderIDs[i] = state.GetOrderID()
+	}
+
+	idsOf := func(result *Result) []uuid.UUID {
+		ids := make([]uuid.UUID, 0, len(result.Orders))
+		for _, o := range result.Orders {
+			ids = append(ids, o.GetOrderID())
+		}
+
+		return ids
+	}
+
+	t.Run("empty filters page through all orders newest first", func(t *testing.T) {
+		first, err := handler.Handle(ctx, NewQuery().WithPage(3, ""))
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{orderIDs[3], orderIDs[2], orderIDs[1]}, idsOf(first))
+		require.NotEmpty(t, first.NextPageToken)
+
+		second, err := handler.Handle(ctx, NewQuery().WithPage(3, first.NextPageToken))
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{orderIDs[0]}, idsOf(second))
+		require.Empty(t, second.NextPageToken)
+	})
+
+	t.Run("customer and status", func(t *testing.T) {
+		result, err := handler.Handle(ctx, NewQuery().
+			WithCustomer(customerA).
+			WithStatus(orderv1.OrderStatus_ORDER_STATUS_CANCELED))
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{orderIDs[1]}, idsOf(result))
+	})
+
+	t.Run("created range", func(t *testing.T) {
+		from := base.AddDate(0, 0, 1)
+		to := base.AddDate(0, 0, 3)
+
+		result, err := handler.Handle(ctx, NewQuery().WithCreatedRange(&from, &to))
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{orderIDs[2], orderIDs[1]}, idsOf(result))
+	})
+}
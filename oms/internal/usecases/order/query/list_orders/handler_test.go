@@ -0,0 +1,214 @@
+package list_orders
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+type stubUnitOfWork struct {
+	commits int
+}
+
+func (*stubUnitOfWork) Begin(ctx context.Context) (context.Context, error) { return ctx, nil }
+func (s *stubUnitOfWork) Commit(context.Context) error                     { s.commits++; return nil }
+func (*stubUnitOfWork) Rollback(context.Context) error                     { return nil }
+
+type stubOrderRepository struct {
+	page   *ports.OrderPage
+	err    error
+	filter *ports.ListPageFilter
+}
+
+func (*stubOrderRepository) Load(context.Context, uuid.UUID) (*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func (*stubOrderRepository) LoadByPackageID(context.Context, uuid.UUID) (*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func (*stubOrderRepository) Save(context.Context, *orderv1.OrderState) error {
+	panic("unexpected call")
+}
+
+func (*stubOrderRepository) List(context.Context, ports.ListFilter) ([]*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func (s *stubOrderRepository) ListPage(_ context.Context, filter ports.ListPageFilter) (*ports.OrderPage, error) {
+	s.filter = &filter
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.page, nil
+}
+
+func (*stubOrderRepository) ListByCustomer(context.Context, uuid.UUID) ([]*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func newPersistedOrder(customerID uuid.UUID, status orderv1.OrderStatus) *orderv1.OrderState {
+	return orderv1.NewOrderStateFromPersisted(
+		uuid.New(),
+		customerID,
+		nil,
+		status,
+		1,
+		nil,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+		nil,
+	)
+}
+
+func TestHandle_FilterCombinations(t *testing.T) {
+	t.Parallel()
+
+	customerID := uuid.New()
+	from := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	testCases := []struct {
+		name     string
+		query    Query
+		expected ports.ListPageFilter
+	}{
+		{
+			name:     "no filters lists recent orders across customers",
+			query:    NewQuery(),
+			expected: ports.ListPageFilter{PageSize: DefaultPageSize},
+		},
+		{
+			name:  "customer only",
+			query: NewQuery().WithCustomer(customerID),
+			expected: ports.ListPageFilter{
+				ListFilter: ports.ListFilter{CustomerID: &customerID},
+				PageSize:   DefaultPageSize,
+			},
+		},
+		{
+			name:  "status only",
+			query: NewQuery().WithStatus(orderv1.OrderStatus_ORDER_STATUS_PENDING, orderv1.OrderStatus_ORDER_STATUS_PROCESSING),
+			expected: ports.ListPageFilter{
+				ListFilter: ports.ListFilter{StatusFilter: []orderv1.OrderStatus{
+					orderv1.OrderStatus_ORDER_STATUS_PENDING,
+					orderv1.OrderStatus_ORDER_STATUS_PROCESSING,
+				}},
+				PageSize: DefaultPageSize,
+			},
+		},
+		{
+			name:  "created from only",
+			query: NewQuery().WithCreatedRange(&from, nil),
+			expected: ports.ListPageFilter{
+				CreatedFrom: &from,
+				PageSize:    DefaultPageSize,
+			},
+		},
+		{
+			name:  "created to only",
+			query: NewQuery().WithCreatedRange(nil, &to),
+			expected: ports.ListPageFilter{
+				CreatedTo: &to,
+				PageSize:  DefaultPageSize,
+			},
+		},
+		{
+			name: "all filters with page",
+			query: NewQuery().
+				WithCustomer(customerID).
+				WithStatus(orderv1.OrderStatus_ORDER_STATUS_COMPLETED).
+				WithCreatedRange(&from, &to).
+				WithPage(5, "token"),
+			expected: ports.ListPageFilter{
+				ListFilter: ports.ListFilter{
+					CustomerID:   &customerID,
+					StatusFilter: []orderv1.OrderStatus{orderv1.OrderStatus_ORDER_STATUS_COMPLETED},
+				},
+				CreatedFrom: &from,
+				CreatedTo:   &to,
+				PageSize:    5,
+				PageToken:   "token",
+			},
+		},
+		{
+			name:     "page size is capped",
+			query:    NewQuery().WithPage(MaxPageSize+1, ""),
+			expected: ports.ListPageFilter{PageSize: MaxPageSize},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			uow := &stubUnitOfWork{}
+			repo := &stubOrderRepository{page: &ports.OrderPage{}}
+
+			handler, err := NewHandler(uow, repo)
+			require.NoError(t, err)
+
+			_, err = handler.Handle(context.Background(), tc.query)
+			require.NoError(t, err)
+			require.NotNil(t, repo.filter)
+			require.Equal(t, tc.expected, *repo.filter)
+			require.Equal(t, 1, uow.commits)
+		})
+	}
+}
+
+func TestHandle_ReturnsOrdersAndNextPageToken(t *testing.T) {
+	t.Parallel()
+
+	orders := []*orderv1.OrderState{
+		newPersistedOrder(uuid.New(), orderv1.OrderStatus_ORDER_STATUS_PENDING),
+		newPersistedOrder(uuid.New(), orderv1.OrderStatus_ORDER_STATUS_COMPLETED),
+	}
+	repo := &stubOrderRepository{page: &ports.OrderPage{Orders: orders, NextPageToken: "next"}}
+
+	handler, err := NewHandler(&stubUnitOfWork{}, repo)
+	require.NoError(t, err)
+
+	result, err := handler.Handle(context.Background(), NewQuery().WithPage(2, ""))
+	require.NoError(t, err)
+	require.Equal(t, orders, result.Orders)
+	require.Equal(t, "next", result.NextPageToken)
+}
+
+func TestHandle_RejectsInvalidCreatedRange(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+	repo := &stubOrderRepository{}
+
+	handler, err := NewHandler(&stubUnitOfWork{}, repo)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(context.Background(), NewQuery().WithCreatedRange(&from, &to))
+	require.ErrorIs(t, err, domain.ErrValidation)
+	require.Nil(t, repo.filter, "repository must not be called for invalid range")
+}
+
+func TestHandle_PropagatesRepositoryError(t *testing.T) {
+	t.Parallel()
+
+	uow := &stubUnitOfWork{}
+	repo := &stubOrderRepository{err: domain.WrapValidation("ListPage", ports.ErrValidation)}
+
+	handler, err := NewHandler(uow, repo)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(context.Background(), NewQuery().WithPage(10, "garbage"))
+	require.ErrorIs(t, err, ports.ErrValidation)
+	require.Zero(t, uow.commits)
+}
@@ -0,0 +1,62 @@
+package list_orders
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	order "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+)
+
+const (
+	// DefaultPageSize is used when the query does not specify a page size.
+	DefaultPageSize int32 = 20
+	// MaxPageSize bounds the number of orders returned in a single page.
+	MaxPageSize int32 = 100
+)
+
+// Query is a query to list orders across customers with optional filters (admin orders screen).
+type Query struct {
+	CustomerID   *uuid.UUID
+	StatusFilter []order.OrderStatus
+	// CreatedFrom is inclusive, CreatedTo is exclusive
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	PageSize    int32
+	PageToken   string
+}
+
+// NewQuery creates a new ListOrders query without filters (first page of recent orders).
+func NewQuery() Query {
+	return Query{}
+}
+
+// WithCustomer restricts the query to a single customer.
+func (q Query) WithCustomer(customerID uuid.UUID) Query {
+	q.CustomerID = &customerID
+
+	return q
+}
+
+// WithStatus restricts the query to the given order statuses.
+func (q Query) WithStatus(statuses ...order.OrderStatus) Query {
+	q.StatusFilter = statuses
+
+	return q
+}
+
+// WithCreatedRange restricts the query to orders created in [from, to). Either bound may be nil.
+func (q Query) WithCreatedRange(from, to *time.Time) Query {
+	q.CreatedFrom = from
+	q.CreatedTo = to
+
+	return q
+}
+
+// WithPage sets the page size and the token returned by the previous page.
+func (q Query) WithPage(pageSize int32, pageToken string) Query {
+	q.PageSize = pageSize
+	q.PageToken = pageToken
+
+	return q
+}
@@ -0,0 +1,61 @@
+package search_orders
+
+import (
+	"context"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+// Result is the result of the SearchOrders query.
+type Result struct {
+	Orders []ports.OrderSearchDocument
+	// NextPageToken is empty when there are no more orders
+	NextPageToken string
+}
+
+// Handler handles SearchOrders queries.
+// It reads the order search read model only, never the order write model.
+type Handler struct {
+	searchRepo ports.OrderSearchRepository
+}
+
+// NewHandler creates a new SearchOrders handler.
+func NewHandler(searchRepo ports.OrderSearchRepository) (*Handler, error) {
+	return &Handler{
+		searchRepo: searchRepo,
+	}, nil
+}
+
+// Handle executes the SearchOrders query.
+func (h *Handler) Handle(ctx context.Context, q Query) (*Result, error) {
+	sort, err := ports.ParseOrderSearchSort(string(q.Sort))
+	if err != nil {
+		return nil, domain.WrapValidation("SearchOrders", err)
+	}
+
+	pageSize := q.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	pageSize = min(pageSize, MaxPageSize)
+
+	page, err := h.searchRepo.Search(ctx, ports.OrderSearchFilter{
+		Text:          q.Text,
+		CustomerEmail: q.CustomerEmail,
+		GoodID:        q.GoodID,
+		StatusFilter:  q.StatusFilter,
+		Sort:          sort,
+		PageSize:      pageSize,
+		PageToken:     q.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Orders:        page.Orders,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package search_orders
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+type stubSearchRepository struct {
+	page   *ports.OrderSearchPage
+	filter *ports.OrderSearchFilter
+}
+
+func (*stubSearchRepository) ApplyOrder(context.Context, ports.OrderSearchDocument) (bool, error) {
+	panic("unexpected call")
+}
+
+func (*stubSearchRepository) RemoveOrder(context.Context, uuid.UUID) error {
+	panic("unexpected call")
+}
+
+func (s *stubSearchRepository) Search(_ context.Context, filter ports.OrderSearchFilter) (*ports.OrderSearchPage, error) {
+	s.filter = &filter
+
+	return s.page, nil
+}
+
+func TestHandle_PassesPredicatesToReadModel(t *testing.T) {
+	t.Parallel()
+
+	goodID := uuid.New()
+	document := ports.OrderSearchDocument{OrderID: uuid.New(), CustomerEmail: "jane@example.com"}
+	repo := &stubSearchRepository{page: &ports.OrderSearchPage{
+		Orders:        []ports.OrderSearchDocument{document},
+		NextPageToken: "next",
+	}}
+
+	handler, err := NewHandler(repo)
+	require.NoError(t, err)
+
+	result, err := handler.Handle(context.Background(), NewQuery().
+		WithText("jane").
+		WithCustomerEmail("Jane@Example.com").
+		WithGood(goodID).
+		WithStatus(orderv1.OrderStatus_ORDER_STATUS_COMPLETED).
+		WithSort(ports.OrderSearchSortTotalDesc).
+		WithPage(10, "token"))
+	require.NoError(t, err)
+
+	require.Equal(t, []ports.OrderSearchDocument{document}, result.Orders)
+	require.Equal(t, "next", result.NextPageToken)
+	require.Equal(t, ports.OrderSearchFilter{
+		Text:          "jane",
+		CustomerEmail: "Jane@Example.com",
+		GoodID:        &goodID,
+		StatusFilter:  []orderv1.OrderStatus{orderv1.OrderStatus_ORDER_STATUS_COMPLETED},
+		Sort:          ports.OrderSearchSortTotalDesc,
+		PageSize:      10,
+		PageToken:     "token",
+	}, *repo.filter)
+}
+
+func TestHandle_DefaultsAndBounds(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		pageSize     int32
+		wantPageSize int32
+	}{
+		{name: "default page size", pageSize: 0, wantPageSize: DefaultPageSize},
+		{name: "page size is capped", pageSize: MaxPageSize + 1, wantPageSize: MaxPageSize},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &stubSearchRepository{page: &ports.OrderSearchPage{}}
+			handler, err := NewHandler(repo)
+			require.NoError(t, err)
+
+			_, err = handler.Handle(context.Background(), NewQuery().WithPage(tc.pageSize, ""))
+			require.NoError(t, err)
+			require.Equal(t, tc.wantPageSize, repo.filter.PageSize)
+			require.Equal(t, ports.OrderSearchSortCreatedDesc, repo.filter.Sort)
+		})
+	}
+}
+
+func TestHandle_RejectsUnknownSort(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubSearchRepository{}
+	handler, err := NewHandler(repo)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(context.Background(), NewQuery().WithSort("RANDOM"))
+	require.ErrorIs(t, err, domain.ErrValidation)
+	require.ErrorIs(t, err, ports.ErrUnsupportedOrderSearchSort)
+	require.Nil(t, repo.filter)
+}
@@ -0,0 +1,75 @@
+package search_orders
+
+import (
+	"github.com/google/uuid"
+
+	order "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+const (
+	// DefaultPageSize is used when the query does not specify a page size.
+	DefaultPageSize int32 = 20
+	// MaxPageSize bounds the number of orders returned in a single page.
+	MaxPageSize int32 = 100
+)
+
+// Query is a query to search orders in the order search read model (admin search screen).
+type Query struct {
+	// Text matches a part of the customer email or the beginning of the order ID
+	Text          string
+	CustomerEmail string
+	GoodID        *uuid.UUID
+	StatusFilter  []order.OrderStatus
+	Sort          ports.OrderSearchSort
+	PageSize      int32
+	PageToken     string
+}
+
+// NewQuery creates a new SearchOrders query matching every order, newest first.
+func NewQuery() Query {
+	return Query{}
+}
+
+// WithText restricts the query to orders whose customer email contains text or whose ID starts with it.
+func (q Query) WithText(text string) Query {
+	q.Text = text
+
+	return q
+}
+
+// WithCustomerEmail restricts the query to orders of the customer with the given email.
+func (q Query) WithCustomerEmail(email string) Query {
+	q.CustomerEmail = email
+
+	return q
+}
+
+// WithGood restricts the query to orders containing the good.
+func (q Query) WithGood(goodID uuid.UUID) Query {
+	q.GoodID = &goodID
+
+	return q
+}
+
+// WithStatus restricts the query to the given order statuses.
+func (q Query) WithStatus(statuses ...order.OrderStatus) Query {
+	q.StatusFilter = statuses
+
+	return q
+}
+
+// WithSort sets the order of the results.
+func (q Query) WithSort(sort ports.OrderSearchSort) Query {
+	q.Sort = sort
+
+	return q
+}
+
+// WithPage sets the page size and the token returned by the previous page.
+func (q Query) WithPage(pageSize int32, pageToken string) Query {
+	q.PageSize = pageSize
+	q.PageToken = pageToken
+
+	return q
+}
@@ -0,0 +1,167 @@
+package watch_status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/shortlink-org/shop/oms/internal/domain"
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	eventsv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+// ErrEventStreamClosed is returned when the event stream shuts down before the order reached a terminal state.
+var ErrEventStreamClosed = errors.New("order event stream closed")
+
+// Update is one observed status of the watched order.
+type Update struct {
+	OrderID        string
+	Status         orderv1.OrderStatus
+	DeliveryStatus commonv1.DeliveryStatus
+	// EventType is the domain event that caused the update; empty for the initial snapshot.
+	EventType string
+	// OccurredAt is when the event happened; zero for the initial snapshot.
+	OccurredAt time.Time
+}
+
+// IsTerminal reports whether the order can no longer change status.
+func (u Update) IsTerminal() bool {
+	return u.Status == orderv1.OrderStatus_ORDER_STATUS_COMPLETED ||
+		u.Status == orderv1.OrderStatus_ORDER_STATUS_CANCELED
+}
+
+// Handler streams the status transitions of an order.
+type Handler struct {
+	uow       ports.UnitOfWork
+	orderRepo ports.OrderRepository
+	events    ports.OrderEventStream
+}
+
+// NewHandler creates a new WatchStatus handler.
+func NewHandler(
+	uow ports.UnitOfWork,
+	orderRepo ports.OrderRepository,
+	events ports.OrderEventStream,
+) (*Handler, error) {
+	return &Handler{
+		uow:       uow,
+		orderRepo: orderRepo,
+		events:    events,
+	}, nil
+}
+
+// Handle sends the current status first, then every status change, and returns
+// once the order reaches a terminal state, the context ends or send fails.
+func (h *Handler) Handle(ctx context.Context, q Query, send func(Update) error) error {
+	// Subscribe before loading so no transition between the snapshot and the first event is lost;
+	// events already reflected in the snapshot are dropped as no-op updates below.
+	events, unsubscribe := h.events.SubscribeOrder(q.OrderID)
+	defer unsubscribe()
+
+	current, err := h.loadSnapshot(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	if err := send(current); err != nil {
+		return err
+	}
+
+	for !current.IsTerminal() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return domain.WrapUnavailable("WatchStatus", ErrEventStreamClosed)
+			}
+
+			next, changed := apply(current, event)
+			if !changed {
+				continue
+			}
+
+			if err := send(next); err != nil {
+				return err
+			}
+
+			current = next
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) loadSnapshot(ctx context.Context, q Query) (Update, error) {
+	ctx, err := h.uow.Begin(ctx)
+	if err != nil {
+		return Update{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		rollbackErr := h.uow.Rollback(ctx)
+		if rollbackErr != nil {
+			slog.Default().WarnContext(ctx, "transaction rollback failed", "error", rollbackErr)
+		}
+	}()
+
+	order, err := h.orderRepo.Load(ctx, q.OrderID)
+	if err != nil {
+		return Update{}, err
+	}
+
+	if q.CustomerID != nil && order.GetCustomerId() != *q.CustomerID {
+		return Update{}, ports.ErrNotFound
+	}
+
+	if err := h.uow.Commit(ctx); err != nil {
+		return Update{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return Update{
+		OrderID:        order.GetOrderID().String(),
+		Status:         order.GetStatus(),
+		DeliveryStatus: order.GetDeliveryStatus(),
+	}, nil
+}
+
+// apply folds an order event into the current update and reports whether the status changed.
+func apply(current Update, event ports.Event) (Update, bool) {
+	next := current
+	next.EventType = event.EventType()
+
+	switch e := event.(type) {
+	case *eventsv1.OrderCreated:
+		next.Status = e.GetStatus()
+		next.OccurredAt = e.GetOccurredAt().AsTime()
+	case *eventsv1.OrderCancelled:
+		next.Status = orderv1.OrderStatus_ORDER_STATUS_CANCELED
+		next.OccurredAt = e.GetOccurredAt().AsTime()
+	case *eventsv1.OrderCompleted:
+		next.Status = orderv1.OrderStatus_ORDER_STATUS_COMPLETED
+		next.OccurredAt = e.GetOccurredAt().AsTime()
+	case *eventsv1.OrderDeliveryStatusUpdatedEvent:
+		next.DeliveryStatus = e.GetStatus()
+		next.OccurredAt = e.GetOccurredAt().AsTime()
+	case *eventsv1.OrderDeliveryStatusCorrectedEvent:
+		next.DeliveryStatus = e.GetStatus()
+		next.OccurredAt = e.GetOccurredAt().AsTime()
+	case *eventsv1.OrderDeliveryCompletedEvent:
+		next.DeliveryStatus = commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED
+		next.OccurredAt = e.GetOccurredAt().AsTime()
+	case *eventsv1.OrderDeliveryFailedEvent:
+		next.DeliveryStatus = commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED
+		next.OccurredAt = e.GetOccurredAt().AsTime()
+	default:
+		// Events such as delivery requests carry no status.
+		return current, false
+	}
+
+	changed := next.Status != current.Status || next.DeliveryStatus != current.DeliveryStatus
+
+	return next, changed
+}
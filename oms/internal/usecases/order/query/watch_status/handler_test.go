@@ -0,0 +1,259 @@
+package watch_status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
+	eventsv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/events/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/ports"
+)
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(ctx context.Context) (context.Context, error) { return ctx, nil }
+func (stubUnitOfWork) Commit(context.Context) error                       { return nil }
+func (stubUnitOfWork) Rollback(context.Context) error                     { return nil }
+
+type stubOrderRepository struct {
+	order *orderv1.OrderState
+}
+
+func (s stubOrderRepository) Load(context.Context, uuid.UUID) (*orderv1.OrderState, error) {
+	return s.order, nil
+}
+
+func (stubOrderRepository) LoadByPackageID(context.Context, uuid.UUID) (*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func (stubOrderRepository) Save(context.Context, *orderv1.OrderState) error {
+	panic("unexpected call")
+}
+
+func (stubOrderRepository) List(context.Context, ports.ListFilter) ([]*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+func (stubOrderRepository) ListPage(context.Context, ports.ListPageFilter) (*ports.OrderPage, error) {
+	panic("unexpected call")
+}
+
+func (stubOrderRepository) ListByCustomer(context.Context, uuid.UUID) ([]*orderv1.OrderState, error) {
+	panic("unexpected call")
+}
+
+// fakeEventStream hands out a pre-filled channel and records unsubscription.
+type fakeEventStream struct {
+	events       chan ports.Event
+	subscribedTo uuid.UUID
+	unsubscribed bool
+}
+
+func newFakeEventStream(events ...ports.Event) *fakeEventStream {
+	ch := make(chan ports.Event, len(events))
+	for _, event := range events {
+		ch <- event
+	}
+
+	return &fakeEventStream{events: ch}
+}
+
+func (f *fakeEventStream) SubscribeOrder(orderID uuid.UUID) (<-chan ports.Event, func()) {
+	f.subscribedTo = orderID
+
+	return f.events, func() { f.unsubscribed = true }
+}
+
+func newOrder(orderID, customerID uuid.UUID, status orderv1.OrderStatus, deliveryStatus commonv1.DeliveryStatus) *orderv1.OrderState {
+	return orderv1.NewOrderStateFromPersisted(orderID, customerID, nil, status, 1, nil, deliveryStatus, nil)
+}
+
+func collect(t *testing.T, handler *Handler, query Query) ([]Update, error) {
+	t.Helper()
+
+	var updates []Update
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := handler.Handle(ctx, query, func(update Update) error {
+		updates = append(updates, update)
+		return nil
+	})
+
+	return updates, err
+}
+
+func TestHandleStreamsSnapshotThenTransitionsUntilTerminal(t *testing.T) {
+	t.Parallel()
+
+	orderID := uuid.New()
+	customerID := uuid.New()
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	stream := newFakeEventStream(
+		// Already reflected in the snapshot; must not be re-sent.
+		&eventsv1.OrderDeliveryStatusUpdatedEvent{
+			OrderId:    orderID.String(),
+			Status:     commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+			OccurredAt: timestamppb.New(at),
+		},
+		&eventsv1.OrderDeliveryStatusUpdatedEvent{
+			OrderId:    orderID.String(),
+			Status:     commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+			OccurredAt: timestamppb.New(at.Add(time.Minute)),
+		},
+		// Carries no status.
+		&eventsv1.OrderDeliveryRequestedEvent{OrderId: orderID.String()},
+		&eventsv1.OrderDeliveryCompletedEvent{
+			OrderId:    orderID.String(),
+			OccurredAt: timestamppb.New(at.Add(2 * time.Minute)),
+		},
+		&eventsv1.OrderCompleted{
+			OrderId:    orderID.String(),
+			Status:     commonv1.OrderStatus_ORDER_STATUS_COMPLETED,
+			OccurredAt: timestamppb.New(at.Add(3 * time.Minute)),
+		},
+		// After the terminal state; must not be consumed.
+		&eventsv1.OrderCancelled{OrderId: orderID.String()},
+	)
+
+	handler, err := NewHandler(
+		stubUnitOfWork{},
+		stubOrderRepository{order: newOrder(orderID, customerID,
+			orderv1.OrderStatus_ORDER_STATUS_PROCESSING, commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED)},
+		stream,
+	)
+	if err != nil {
+		t.Fatalf("NewHandler returned error: %v", err)
+	}
+
+	updates, err := collect(t, handler, NewCustomerScopedQuery(orderID, customerID))
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	processing := orderv1.OrderStatus_ORDER_STATUS_PROCESSING
+	want := []Update{
+		{OrderID: orderID.String(), Status: processing, DeliveryStatus: commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED},
+		{
+			OrderID: orderID.String(), Status: processing, DeliveryStatus: commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+			EventType: "oms.order.delivery_status_updated.v1", OccurredAt: at.Add(time.Minute),
+		},
+		{
+			OrderID: orderID.String(), Status: processing, DeliveryStatus: commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+			EventType: "oms.order.delivery_completed.v1", OccurredAt: at.Add(2 * time.Minute),
+		},
+		{
+			OrderID: orderID.String(), Status: orderv1.OrderStatus_ORDER_STATUS_COMPLETED,
+			DeliveryStatus: commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+			EventType:      "oms.order.completed.v1", OccurredAt: at.Add(3 * time.Minute),
+		},
+	}
+
+	if len(updates) != len(want) {
+		t.Fatalf("expected %d updates, got %d: %+v", len(want), len(updates), updates)
+	}
+
+	for i := range want {
+		if updates[i] != want[i] {
+			t.Fatalf("update %d: expected %+v, got %+v", i, want[i], updates[i])
+		}
+	}
+
+	if stream.subscribedTo != orderID {
+		t.Fatalf("expected subscription to %s, got %s", orderID, stream.subscribedTo)
+	}
+
+	if !stream.unsubscribed {
+		t.Fatal("expected the handler to unsubscribe")
+	}
+
+	if len(stream.events) != 1 {
+		t.Fatalf("expected events after the terminal state to stay unconsumed, %d left", len(stream.events))
+	}
+}
+
+func TestHandleReturnsAfterSnapshotForTerminalOrder(t *testing.T) {
+	t.Parallel()
+
+	orderID := uuid.New()
+	customerID := uuid.New()
+	handler, err := NewHandler(
+		stubUnitOfWork{},
+		stubOrderRepository{order: newOrder(orderID, customerID,
+			orderv1.OrderStatus_ORDER_STATUS_CANCELED, commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED)},
+		newFakeEventStream(),
+	)
+	if err != nil {
+		t.Fatalf("NewHandler returned error: %v", err)
+	}
+
+	updates, err := collect(t, handler, NewCustomerScopedQuery(orderID, customerID))
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if len(updates) != 1 || updates[0].Status != orderv1.OrderStatus_ORDER_STATUS_CANCELED {
+		t.Fatalf("expected a single cancelled snapshot, got %+v", updates)
+	}
+}
+
+func TestHandleReturnsErrorWhenStreamCloses(t *testing.T) {
+	t.Parallel()
+
+	orderID := uuid.New()
+	customerID := uuid.New()
+	stream := newFakeEventStream()
+	close(stream.events)
+
+	handler, err := NewHandler(
+		stubUnitOfWork{},
+		stubOrderRepository{order: newOrder(orderID, customerID,
+			orderv1.OrderStatus_ORDER_STATUS_PENDING, commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED)},
+		stream,
+	)
+	if err != nil {
+		t.Fatalf("NewHandler returned error: %v", err)
+	}
+
+	updates, err := collect(t, handler, NewCustomerScopedQuery(orderID, customerID))
+	if !errors.Is(err, ErrEventStreamClosed) {
+		t.Fatalf("expected ErrEventStreamClosed, got %v", err)
+	}
+
+	if len(updates) != 1 {
+		t.Fatalf("expected only the snapshot, got %+v", updates)
+	}
+}
+
+func TestHandleReturnsNotFoundForDifferentCustomer(t *testing.T) {
+	t.Parallel()
+
+	orderID := uuid.New()
+	handler, err := NewHandler(
+		stubUnitOfWork{},
+		stubOrderRepository{order: newOrder(orderID, uuid.New(),
+			orderv1.OrderStatus_ORDER_STATUS_PENDING, commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED)},
+		newFakeEventStream(),
+	)
+	if err != nil {
+		t.Fatalf("NewHandler returned error: %v", err)
+	}
+
+	updates, err := collect(t, handler, NewCustomerScopedQuery(orderID, uuid.New()))
+	if !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if len(updates) != 0 {
+		t.Fatalf("expected no updates, got %+v", updates)
+	}
+}
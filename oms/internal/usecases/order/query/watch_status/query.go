@@ -0,0 +1,19 @@
+package watch_status
+
+import (
+	"github.com/google/uuid"
+)
+
+// Query represents a request to follow the status of one order.
+type Query struct {
+	OrderID    uuid.UUID
+	CustomerID *uuid.UUID
+}
+
+// NewCustomerScopedQuery creates a query constrained to a specific customer.
+func NewCustomerScopedQuery(orderID, customerID uuid.UUID) Query {
+	return Query{
+		OrderID:    orderID,
+		CustomerID: &customerID,
+	}
+}
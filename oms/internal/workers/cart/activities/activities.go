@@ -81,11 +81,17 @@ func (a *Activities) RemoveItem(ctx context.Context, req RemoveItemRequest) erro
 // ResetCartRequest represents the request for ResetCart activity.
 type ResetCartRequest struct {
 	CustomerID uuid.UUID
+	// Abandoned is set by the session timeout so the reset records a CartAbandoned event.
+	Abandoned bool
 }
 
 // ResetCart resets the cart.
 func (a *Activities) ResetCart(ctx context.Context, req ResetCartRequest) error {
 	cmd := reset.NewCommand(req.CustomerID)
+	if req.Abandoned {
+		cmd = reset.NewAbandonCommand(req.CustomerID)
+	}
+
 	return a.resetHandler.Handle(ctx, cmd)
 }
 
@@ -137,7 +137,7 @@ func Workflow(ctx workflow.Context, customerID uuid.UUID) error {
 				return
 			}
 
-			logger.Info("Cart session timed out, resetting cart", "customerID", customerID)
+			logger.Info("Cart session timed out, resetting abandoned cart", "customerID", customerID)
 
 			timeoutResetCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
 				StartToCloseTimeout: 10 * time.Second,
@@ -147,6 +147,7 @@ func Workflow(ctx workflow.Context, customerID uuid.UUID) error {
 			//nolint:errcheck // best-effort reset on timeout, ignore activity result
 			_ = workflow.ExecuteActivity(timeoutResetCtx, "ResetCart", activities.ResetCartRequest{
 				CustomerID: customerID,
+				Abandoned:  true,
 			}).Get(ctx, nil)
 
 			resetSessionTimeout()
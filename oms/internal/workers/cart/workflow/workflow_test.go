@@ -138,6 +138,7 @@ func (s *CartWorkflowTestSuite) Test_Workflow_SessionTimeout() {
 
 	s.env.OnActivity("ResetCart", mock.Anything, activities.ResetCartRequest{
 		CustomerID: testCustomerID,
+		Abandoned:  true,
 	}).Return(func(_ context.Context, _ activities.ResetCartRequest) error {
 		resetCalls.Add(1)
 		return nil
@@ -155,6 +156,25 @@ func (s *CartWorkflowTestSuite) Test_Workflow_SessionTimeout() {
 	s.EqualValues(1, resetCalls.Load())
 }
 
+// Test_Workflow_ResetSignalIsNotAbandonment verifies only the session timeout marks the cart abandoned.
+func (s *CartWorkflowTestSuite) Test_Workflow_ResetSignalIsNotAbandonment() {
+	s.env.OnActivity("ResetCart", mock.Anything, activities.ResetCartRequest{
+		CustomerID: testCustomerID,
+	}).Return(nil).Once()
+
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(v2.Event_EVENT_RESET.String(), nil)
+	}, time.Millisecond*10)
+
+	s.env.RegisterDelayedCallback(func() {
+		s.env.CancelWorkflow()
+	}, time.Millisecond*100)
+
+	s.env.ExecuteWorkflow(Workflow, testCustomerID)
+
+	s.True(s.env.IsWorkflowCompleted())
+}
+
 // Test_Workflow_SessionTimeoutResetsAfterActivity verifies timeout is based on inactivity, not workflow start time.
 func (s *CartWorkflowTestSuite) Test_Workflow_SessionTimeoutResetsAfterActivity() {
 	var resetCalled atomic.Bool
@@ -176,6 +196,7 @@ func (s *CartWorkflowTestSuite) Test_Workflow_SessionTimeoutResetsAfterActivity(
 	})).Return(nil).Once()
 	s.env.OnActivity("ResetCart", mock.Anything, activities.ResetCartRequest{
 		CustomerID: testCustomerID,
+		Abandoned:  true,
 	}).Return(func(_ context.Context, _ activities.ResetCartRequest) error {
 		resetCalled.Store(true)
 		return nil
@@ -12,10 +12,15 @@ import (
 	"google.golang.org/grpc/codes"
 	grpcstatus "google.golang.org/grpc/status"
 
+	"github.com/shortlink-org/shop/oms/internal/domain"
 	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
 	orderCancel "github.com/shortlink-org/shop/oms/internal/usecases/order/command/cancel"
+	orderComplete "github.com/shortlink-org/shop/oms/internal/usecases/order/command/complete"
+	orderExpirePending "github.com/shortlink-org/shop/oms/internal/usecases/order/command/expire_pending_orders"
 	orderRequestDelivery "github.com/shortlink-org/shop/oms/internal/usecases/order/command/request_delivery"
+	orderSetDeliveryStatus "github.com/shortlink-org/shop/oms/internal/usecases/order/command/set_delivery_status"
 	orderGet "github.com/shortlink-org/shop/oms/internal/usecases/order/query/get"
 	"github.com/shortlink-org/shop/oms/internal/workers/order/activities/dto"
 )
@@ -35,6 +40,21 @@ type requestDeliveryHandler interface {
 	Handle(ctx context.Context, cmd orderRequestDelivery.Command) error
 }
 
+// expirePendingOrdersHandler cancels stale PENDING orders.
+type expirePendingOrdersHandler interface {
+	Handle(ctx context.Context, cmd orderExpirePending.Command) (orderExpirePending.Result, error)
+}
+
+// completeHandler handles CompleteOrder commands (allows mocks in tests).
+type completeHandler interface {
+	Handle(ctx context.Context, cmd orderComplete.Command) error
+}
+
+// setDeliveryStatusHandler applies delivery status updates signaled to the workflow.
+type setDeliveryStatusHandler interface {
+	Handle(ctx context.Context, cmd orderSetDeliveryStatus.Command) error
+}
+
 // Activities wraps order command/query handlers for Temporal activities.
 // Activities are the bridge between Temporal workflows and application use cases.
 // Temporal workflows must never access repositories directly - only through activities.
@@ -46,7 +66,12 @@ type Activities struct {
 	cancelHandler          cancelHandler
 	getHandler             getHandler
 	requestDeliveryHandler requestDeliveryHandler
+	expirePendingHandler   expirePendingOrdersHandler
+	setDeliveryStatus      setDeliveryStatusHandler
+	completeHandler        completeHandler
 	deliveryClient         ports.DeliveryClient
+	// geocoder is optional; when nil, addresses are sent to Delivery with the coordinates they have.
+	geocoder ports.Geocoder
 }
 
 const (
@@ -54,6 +79,9 @@ const (
 	requestDeliveryConfigErrorType     = "OrderRequestDeliveryConfigError"
 	requestDeliveryContractErrorType   = "OrderRequestDeliveryContractError"
 	requestDeliveryHeartbeatInterval   = 2 * time.Second
+	expirePendingValidationErrorType   = "OrderExpirePendingValidationError"
+	setDeliveryStatusErrorType         = "OrderSetDeliveryStatusValidationError"
+	completeOrderValidationErrorType   = "OrderCompleteValidationError"
 )
 
 // New creates a new Activities instance.
@@ -61,13 +89,21 @@ func New(
 	cancelHandler cancelHandler,
 	getHandler getHandler,
 	requestDeliveryHandler requestDeliveryHandler,
+	expirePendingHandler expirePendingOrdersHandler,
+	setDeliveryStatus setDeliveryStatusHandler,
+	completeHandler completeHandler,
 	deliveryClient ports.DeliveryClient,
+	geocoder ports.Geocoder,
 ) *Activities {
 	return &Activities{
 		cancelHandler:          cancelHandler,
 		getHandler:             getHandler,
 		requestDeliveryHandler: requestDeliveryHandler,
+		expirePendingHandler:   expirePendingHandler,
+		setDeliveryStatus:      setDeliveryStatus,
+		completeHandler:        completeHandler,
 		deliveryClient:         deliveryClient,
+		geocoder:               geocoder,
 	}
 }
 
@@ -76,9 +112,22 @@ func NewWithHandlers(
 	cancelHandler *orderCancel.Handler,
 	getHandler *orderGet.Handler,
 	requestDeliveryHandler *orderRequestDelivery.Handler,
+	expirePendingHandler *orderExpirePending.Handler,
+	setDeliveryStatus *orderSetDeliveryStatus.Handler,
+	completeHandler *orderComplete.Handler,
 	deliveryClient ports.DeliveryClient,
+	geocoder ports.Geocoder,
 ) *Activities {
-	return New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	return New(
+		cancelHandler,
+		getHandler,
+		requestDeliveryHandler,
+		expirePendingHandler,
+		setDeliveryStatus,
+		completeHandler,
+		deliveryClient,
+		geocoder,
+	)
 }
 
 // CancelOrderRequest represents the request for CancelOrder activity.
@@ -102,6 +151,88 @@ func (a *Activities) CancelOrder(ctx context.Context, req CancelOrderRequest) er
 	return err
 }
 
+// CompleteOrderRequest represents the request for CompleteOrder activity.
+type CompleteOrderRequest struct {
+	OrderID uuid.UUID
+}
+
+// CompleteOrder moves a PROCESSING order to COMPLETED.
+// This is the final saga step; the workflow compensates by canceling the order when it fails.
+func (a *Activities) CompleteOrder(ctx context.Context, req CompleteOrderRequest) error {
+	err := a.completeHandler.Handle(ctx, orderComplete.NewCommand(req.OrderID))
+	if err == nil {
+		return nil
+	}
+
+	if isOrderValidationError(err) {
+		return temporal.NewNonRetryableApplicationError(err.Error(), completeOrderValidationErrorType, err)
+	}
+
+	return err
+}
+
+// ExpirePendingOrdersRequest represents the request for ExpirePendingOrders activity.
+type ExpirePendingOrdersRequest struct {
+	// CreatedBefore is the cutoff computed by the workflow; PENDING orders created earlier are cancelled
+	CreatedBefore time.Time
+}
+
+// ExpirePendingOrdersResponse represents the response from ExpirePendingOrders activity.
+type ExpirePendingOrdersResponse struct {
+	Expired int
+}
+
+// ExpirePendingOrders cancels PENDING orders created before the cutoff with reason EXPIRED.
+// Re-running it is safe: already expired orders are no longer PENDING.
+func (a *Activities) ExpirePendingOrders(ctx context.Context, req ExpirePendingOrdersRequest) (*ExpirePendingOrdersResponse, error) {
+	result, err := a.expirePendingHandler.Handle(ctx, orderExpirePending.Command{CreatedBefore: req.CreatedBefore})
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), expirePendingValidationErrorType, err)
+		}
+
+		return nil, err
+	}
+
+	return &ExpirePendingOrdersResponse{Expired: result.Expired}, nil
+}
+
+// SetDeliveryStatusRequest represents the request for SetDeliveryStatus activity.
+type SetDeliveryStatusRequest struct {
+	Update orderv1.DeliveryStatusUpdate
+}
+
+// SetDeliveryStatusResponse is the order after a SetDeliveryStatus activity.
+type SetDeliveryStatusResponse struct {
+	OrderStatus orderv1.OrderStatus
+	// DeliveryStatus is the order's delivery status, derived from all packages of a split delivery
+	DeliveryStatus orderv1.DeliveryStatus
+}
+
+// SetDeliveryStatus applies a delivery status update signaled to the order workflow and returns the
+// order's resulting status, so the workflow can tell when the whole delivery is over.
+// Re-running it is safe: an update whose message was already applied is a no-op.
+func (a *Activities) SetDeliveryStatus(ctx context.Context, req SetDeliveryStatusRequest) (*SetDeliveryStatusResponse, error) {
+	err := a.setDeliveryStatus.Handle(ctx, orderSetDeliveryStatus.NewCommand(req.Update))
+	if err != nil {
+		if errors.Is(err, orderSetDeliveryStatus.ErrUnsupportedDeliveryStatus) || isOrderValidationError(err) {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), setDeliveryStatusErrorType, err)
+		}
+
+		return nil, err
+	}
+
+	order, err := a.getHandler.Handle(ctx, orderGet.NewQuery(req.Update.OrderID))
+	if err != nil {
+		return nil, fmt.Errorf("load order after delivery status update: %w", err)
+	}
+
+	return &SetDeliveryStatusResponse{
+		OrderStatus:    order.GetStatus(),
+		DeliveryStatus: order.GetDeliveryStatus(),
+	}, nil
+}
+
 // GetOrderRequest represents the request for GetOrder activity.
 type GetOrderRequest struct {
 	OrderID uuid.UUID
@@ -148,6 +279,7 @@ var ErrInvalidPackageID = errors.New("delivery service returned invalid package_
 
 // RequestDelivery sends the order to the Delivery service for processing.
 // It loads the order (domain aggregate), maps delivery info to AcceptOrderRequest, and calls the Delivery client.
+// Addresses without coordinates are geocoded first when a geocoder is configured.
 func (a *Activities) RequestDelivery(ctx context.Context, req RequestDeliveryRequest) (*RequestDeliveryResponse, error) {
 	if a.deliveryClient == nil {
 		return nil, temporal.NewNonRetryableApplicationError(
@@ -184,6 +316,19 @@ func (a *Activities) RequestDelivery(ctx context.Context, req RequestDeliveryReq
 		return nil, wrappedErr
 	}
 
+	err = a.geocodeDeliveryRequest(ctx, order.GetDeliveryInfo(), &deliveryReq)
+	if err != nil {
+		if isOrderValidationError(err) {
+			return nil, temporal.NewNonRetryableApplicationError(
+				err.Error(),
+				requestDeliveryValidationErrorType,
+				err,
+			)
+		}
+
+		return nil, err
+	}
+
 	resp, err := a.acceptOrderWithHeartbeat(ctx, deliveryReq)
 	if err != nil {
 		if nonRetryableErr := classifyDeliveryAcceptOrderError(err); nonRetryableErr != nil {
@@ -226,6 +371,45 @@ func (a *Activities) RequestDelivery(ctx context.Context, req RequestDeliveryReq
 	}, nil
 }
 
+// geocodeDeliveryRequest fills in the coordinates of pickup and delivery addresses that have none.
+func (a *Activities) geocodeDeliveryRequest(
+	ctx context.Context,
+	info *orderv1.DeliveryInfo,
+	req *ports.AcceptOrderRequest,
+) error {
+	if a.geocoder == nil {
+		return nil
+	}
+
+	err := a.geocodeAddress(ctx, info.GetPickupAddress(), &req.PickupAddress)
+	if err != nil {
+		return fmt.Errorf("geocode pickup address: %w", err)
+	}
+
+	err = a.geocodeAddress(ctx, info.GetDeliveryAddress(), &req.DeliveryAddress)
+	if err != nil {
+		return fmt.Errorf("geocode delivery address: %w", err)
+	}
+
+	return nil
+}
+
+func (a *Activities) geocodeAddress(ctx context.Context, addr address.Address, dst *ports.DeliveryAddress) error {
+	if addr.HasCoordinates() {
+		return nil
+	}
+
+	loc, err := a.geocoder.Geocode(ctx, addr)
+	if err != nil {
+		return err
+	}
+
+	dst.Latitude = loc.Latitude()
+	dst.Longitude = loc.Longitude()
+
+	return nil
+}
+
 type acceptOrderResult struct {
 	response *ports.AcceptOrderResponse
 	err      error
@@ -309,6 +493,7 @@ func isOrderValidationError(err error) bool {
 		errors.Is(err, ErrInvalidPackageID) ||
 		errors.Is(err, dto.ErrNoDeliveryInfo) ||
 		errors.Is(err, dto.ErrUnsupportedDeliveryPriority) ||
+		errors.Is(err, ports.ErrAddressNotGeocoded) ||
 		errors.Is(err, orderv1.ErrInvalidDeliveryInfo) ||
 		errors.Is(err, orderv1.ErrDeliveryInfoRequired) ||
 		errors.Is(err, orderv1.ErrOrderInvalidStateTransition) ||
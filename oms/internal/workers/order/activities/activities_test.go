@@ -16,9 +16,12 @@ import (
 	orderv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
 	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
 	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/address"
+	"github.com/shortlink-org/shop/oms/internal/domain/order/v1/vo/location"
 	"github.com/shortlink-org/shop/oms/internal/domain/ports"
 	orderCancel "github.com/shortlink-org/shop/oms/internal/usecases/order/command/cancel"
+	orderComplete "github.com/shortlink-org/shop/oms/internal/usecases/order/command/complete"
 	orderRequestDelivery "github.com/shortlink-org/shop/oms/internal/usecases/order/command/request_delivery"
+	orderSetDeliveryStatus "github.com/shortlink-org/shop/oms/internal/usecases/order/command/set_delivery_status"
 	orderGet "github.com/shortlink-org/shop/oms/internal/usecases/order/query/get"
 )
 
@@ -32,6 +35,16 @@ func (m *mockRequestDeliveryHandler) Handle(ctx context.Context, cmd orderReques
 	return args.Error(0)
 }
 
+// mockSetDeliveryStatusHandler is a mock for SetDeliveryStatus command (used in SetDeliveryStatus activity).
+type mockSetDeliveryStatusHandler struct {
+	mock.Mock
+}
+
+func (m *mockSetDeliveryStatusHandler) Handle(ctx context.Context, cmd orderSetDeliveryStatus.Command) error {
+	args := m.Called(ctx, cmd)
+	return args.Error(0)
+}
+
 // mockDeliveryClient is a mock for ports.DeliveryClient (used in RequestDelivery activity).
 type mockDeliveryClient struct {
 	mock.Mock
@@ -65,6 +78,19 @@ func (m *mockDeliveryClient) AcceptOrder(ctx context.Context, req ports.AcceptOr
 	}
 }
 
+// mockGeocoder is a mock for ports.Geocoder (used in RequestDelivery activity).
+type mockGeocoder struct {
+	mock.Mock
+}
+
+func (m *mockGeocoder) Geocode(ctx context.Context, addr address.Address) (location.Location, error) {
+	args := m.Called(ctx, addr)
+
+	loc, _ := args.Get(0).(location.Location)
+
+	return loc, args.Error(1)
+}
+
 // mockCancelHandler is a mock implementation of CommandHandler for cancel command.
 type mockCancelHandler struct {
 	mock.Mock
@@ -75,6 +101,16 @@ func (m *mockCancelHandler) Handle(ctx context.Context, cmd orderCancel.Command)
 	return args.Error(0)
 }
 
+// mockCompleteHandler is a mock implementation of CommandHandler for complete command.
+type mockCompleteHandler struct {
+	mock.Mock
+}
+
+func (m *mockCompleteHandler) Handle(ctx context.Context, cmd orderComplete.Command) error {
+	args := m.Called(ctx, cmd)
+	return args.Error(0)
+}
+
 // mockGetHandler is a mock implementation of QueryHandler for get query.
 type mockGetHandler struct {
 	mock.Mock
@@ -140,7 +176,7 @@ func TestActivities_CancelOrder_Success(t *testing.T) {
 	deliveryClient := new(mockDeliveryClient)
 
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 
 	// Set up expectation
 	cancelHandler.On("Handle", mock.Anything, orderCancel.NewCommand(testOrderID)).Return(nil)
@@ -161,7 +197,7 @@ func TestActivities_CancelOrder_HandlerError(t *testing.T) {
 	deliveryClient := new(mockDeliveryClient)
 
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 
 	// Set up expectation with error
 	expectedErr := errors.New("order not found")
@@ -178,13 +214,47 @@ func TestActivities_CancelOrder_HandlerError(t *testing.T) {
 	cancelHandler.AssertExpectations(t)
 }
 
+func TestActivities_CompleteOrder_Success(t *testing.T) {
+	completeHandler := new(mockCompleteHandler)
+	activities := New(new(mockCancelHandler), new(mockGetHandler), new(mockRequestDeliveryHandler), nil, nil, completeHandler, nil, nil)
+
+	completeHandler.On("Handle", mock.Anything, orderComplete.NewCommand(testOrderID)).Return(nil).Once()
+
+	err := activities.CompleteOrder(context.Background(), CompleteOrderRequest{
+		OrderID: testOrderID,
+	})
+
+	require.NoError(t, err)
+	completeHandler.AssertExpectations(t)
+}
+
+func TestActivities_CompleteOrder_InvalidTransitionIsNonRetryable(t *testing.T) {
+	completeHandler := new(mockCompleteHandler)
+	activities := New(new(mockCancelHandler), new(mockGetHandler), new(mockRequestDeliveryHandler), nil, nil, completeHandler, nil, nil)
+
+	completeHandler.On("Handle", mock.Anything, orderComplete.NewCommand(testOrderID)).Return(&orderv1.InvalidOrderTransitionError{
+		From: orderv1.OrderStatus_ORDER_STATUS_CANCELED,
+		To:   orderv1.OrderStatus_ORDER_STATUS_COMPLETED,
+	}).Once()
+
+	err := activities.CompleteOrder(context.Background(), CompleteOrderRequest{
+		OrderID: testOrderID,
+	})
+
+	var appErr *temporal.ApplicationError
+	require.ErrorAs(t, err, &appErr)
+	require.True(t, appErr.NonRetryable())
+	require.Equal(t, completeOrderValidationErrorType, appErr.Type())
+	completeHandler.AssertExpectations(t)
+}
+
 func TestActivities_GetOrder_Success(t *testing.T) {
 	cancelHandler := new(mockCancelHandler)
 	getHandler := new(mockGetHandler)
 	deliveryClient := new(mockDeliveryClient)
 
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 
 	// Create expected order state
 	expectedOrder := orderv1.NewOrderState(testCustomerID)
@@ -211,7 +281,7 @@ func TestActivities_GetOrder_NotFound(t *testing.T) {
 	deliveryClient := new(mockDeliveryClient)
 
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 
 	// Set up expectation with error
 	expectedErr := errors.New("order not found")
@@ -235,7 +305,7 @@ func TestActivities_GetOrder_ContextCancelled(t *testing.T) {
 	deliveryClient := new(mockDeliveryClient)
 
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 
 	// Create canceled context
 	ctx, cancel := context.WithCancelCause(context.Background())
@@ -262,7 +332,7 @@ func TestActivities_New(t *testing.T) {
 	deliveryClient := new(mockDeliveryClient)
 
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 
 	require.NotNil(t, activities)
 }
@@ -271,7 +341,7 @@ func TestActivities_RequestDelivery_DeliveryClientNotConfigured(t *testing.T) {
 	cancelHandler := new(mockCancelHandler)
 	getHandler := new(mockGetHandler)
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, nil, nil)
 
 	response, err := activities.RequestDelivery(context.Background(), RequestDeliveryRequest{
 		OrderID: testOrderID,
@@ -291,7 +361,7 @@ func TestActivities_RequestDelivery_NoDeliveryInfo(t *testing.T) {
 	getHandler := new(mockGetHandler)
 	deliveryClient := new(mockDeliveryClient)
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 	order := orderv1.NewOrderState(testCustomerID)
 	order.SetID(testOrderID)
 
@@ -318,7 +388,7 @@ func TestActivities_RequestDelivery_AcceptOrderError(t *testing.T) {
 	getHandler := new(mockGetHandler)
 	deliveryClient := new(mockDeliveryClient)
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 	order := createOrderWithDeliveryInfo(t)
 	expectedErr := errors.New("delivery backend unavailable")
 
@@ -343,7 +413,7 @@ func TestActivities_RequestDelivery_AcceptOrderInvalidArgumentIsNonRetryable(t *
 	getHandler := new(mockGetHandler)
 	deliveryClient := new(mockDeliveryClient)
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 	order := createOrderWithDeliveryInfo(t)
 
 	getHandler.On("Handle", mock.Anything, orderGet.NewQuery(testOrderID)).Return(order, nil)
@@ -372,7 +442,7 @@ func TestActivities_RequestDelivery_AcceptOrderAlreadyExistsIsNonRetryable(t *te
 	getHandler := new(mockGetHandler)
 	deliveryClient := new(mockDeliveryClient)
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 	order := createOrderWithDeliveryInfo(t)
 
 	getHandler.On("Handle", mock.Anything, orderGet.NewQuery(testOrderID)).Return(order, nil)
@@ -401,7 +471,7 @@ func TestActivities_RequestDelivery_InvalidPackageID(t *testing.T) {
 	getHandler := new(mockGetHandler)
 	deliveryClient := new(mockDeliveryClient)
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 	order := createOrderWithDeliveryInfo(t)
 
 	getHandler.On("Handle", mock.Anything, orderGet.NewQuery(testOrderID)).Return(order, nil)
@@ -431,7 +501,7 @@ func TestActivities_RequestDelivery_ContextCancelled(t *testing.T) {
 	getHandler := new(mockGetHandler)
 	deliveryClient := new(mockDeliveryClient)
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 	order := createOrderWithDeliveryInfo(t)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -468,7 +538,7 @@ func TestActivities_RequestDelivery_PersistError(t *testing.T) {
 	getHandler := new(mockGetHandler)
 	deliveryClient := new(mockDeliveryClient)
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 	order := createOrderWithDeliveryInfo(t)
 	packageID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174777")
 	expectedErr := errors.New("cannot persist request")
@@ -500,7 +570,7 @@ func TestActivities_RequestDelivery_Success(t *testing.T) {
 	getHandler := new(mockGetHandler)
 	deliveryClient := new(mockDeliveryClient)
 	requestDeliveryHandler := new(mockRequestDeliveryHandler)
-	activities := New(cancelHandler, getHandler, requestDeliveryHandler, deliveryClient)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, nil)
 	order := createOrderWithDeliveryInfo(t)
 	packageID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174888")
 
@@ -525,3 +595,189 @@ func TestActivities_RequestDelivery_Success(t *testing.T) {
 	deliveryClient.AssertExpectations(t)
 	requestDeliveryHandler.AssertExpectations(t)
 }
+
+func TestActivities_RequestDelivery_GeocodesAddressesWithoutCoordinates(t *testing.T) {
+	cancelHandler := new(mockCancelHandler)
+	getHandler := new(mockGetHandler)
+	deliveryClient := new(mockDeliveryClient)
+	requestDeliveryHandler := new(mockRequestDeliveryHandler)
+	geocoder := new(mockGeocoder)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, geocoder)
+	order := createOrderWithDeliveryInfo(t)
+	info := order.GetDeliveryInfo()
+	packageID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174888")
+
+	pickupLoc, err := location.NewLocation(55.7558, 37.6173)
+	require.NoError(t, err)
+	deliveryLoc, err := location.NewLocation(55.7887, 37.5986)
+	require.NoError(t, err)
+
+	getHandler.On("Handle", mock.Anything, orderGet.NewQuery(testOrderID)).Return(order, nil)
+	geocoder.On("Geocode", mock.Anything, info.GetPickupAddress()).Return(pickupLoc, nil).Once()
+	geocoder.On("Geocode", mock.Anything, info.GetDeliveryAddress()).Return(deliveryLoc, nil).Once()
+	deliveryClient.On("AcceptOrder", mock.Anything, mock.MatchedBy(func(req ports.AcceptOrderRequest) bool {
+		return req.PickupAddress.Latitude == pickupLoc.Latitude() &&
+			req.PickupAddress.Longitude == pickupLoc.Longitude() &&
+			req.DeliveryAddress.Latitude == deliveryLoc.Latitude() &&
+			req.DeliveryAddress.Longitude == deliveryLoc.Longitude() &&
+			req.DeliveryAddress.Street == "456 Customer St"
+	})).Return(&ports.AcceptOrderResponse{
+		PackageID: packageID.String(),
+		Status:    "ACCEPTED",
+	}, nil)
+	requestDeliveryHandler.On("Handle", mock.Anything, mock.Anything).Return(nil)
+
+	response, err := activities.RequestDelivery(context.Background(), RequestDeliveryRequest{
+		OrderID: testOrderID,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, packageID.String(), response.PackageID)
+	getHandler.AssertExpectations(t)
+	geocoder.AssertExpectations(t)
+	deliveryClient.AssertExpectations(t)
+	requestDeliveryHandler.AssertExpectations(t)
+}
+
+func TestActivities_RequestDelivery_KeepsExistingCoordinates(t *testing.T) {
+	cancelHandler := new(mockCancelHandler)
+	getHandler := new(mockGetHandler)
+	deliveryClient := new(mockDeliveryClient)
+	requestDeliveryHandler := new(mockRequestDeliveryHandler)
+	geocoder := new(mockGeocoder)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, geocoder)
+	packageID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174888")
+
+	pickupLoc, err := location.NewLocation(55.7558, 37.6173)
+	require.NoError(t, err)
+	pickupAddr, err := address.NewAddressWithLocation("123 Warehouse St", "Moscow", "101000", "Russia", pickupLoc)
+	require.NoError(t, err)
+	deliveryAddr, err := address.NewAddress("456 Customer St", "Moscow", "102000", "Russia")
+	require.NoError(t, err)
+	deliveryLoc, err := location.NewLocation(55.7887, 37.5986)
+	require.NoError(t, err)
+
+	startTime := time.Now().Add(24 * time.Hour)
+	deliveryInfo := orderv1.NewDeliveryInfo(
+		pickupAddr,
+		deliveryAddr,
+		orderv1.NewDeliveryPeriod(startTime, startTime.Add(2*time.Hour)),
+		orderv1.NewPackageInfo(2.5),
+		orderv1.DeliveryPriorityNormal,
+		nil,
+	)
+	order := orderv1.NewOrderStateFromPersisted(
+		testOrderID,
+		testCustomerID,
+		nil,
+		orderv1.OrderStatus_ORDER_STATUS_PROCESSING,
+		0,
+		&deliveryInfo,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+		nil,
+	)
+
+	getHandler.On("Handle", mock.Anything, orderGet.NewQuery(testOrderID)).Return(order, nil)
+	geocoder.On("Geocode", mock.Anything, deliveryAddr).Return(deliveryLoc, nil).Once()
+	deliveryClient.On("AcceptOrder", mock.Anything, mock.MatchedBy(func(req ports.AcceptOrderRequest) bool {
+		return req.PickupAddress.Latitude == pickupLoc.Latitude() &&
+			req.DeliveryAddress.Latitude == deliveryLoc.Latitude()
+	})).Return(&ports.AcceptOrderResponse{
+		PackageID: packageID.String(),
+		Status:    "ACCEPTED",
+	}, nil)
+	requestDeliveryHandler.On("Handle", mock.Anything, mock.Anything).Return(nil)
+
+	_, err = activities.RequestDelivery(context.Background(), RequestDeliveryRequest{
+		OrderID: testOrderID,
+	})
+
+	require.NoError(t, err)
+	geocoder.AssertExpectations(t)
+	deliveryClient.AssertExpectations(t)
+}
+
+func TestActivities_RequestDelivery_UngeocodableAddressIsNonRetryable(t *testing.T) {
+	cancelHandler := new(mockCancelHandler)
+	getHandler := new(mockGetHandler)
+	deliveryClient := new(mockDeliveryClient)
+	requestDeliveryHandler := new(mockRequestDeliveryHandler)
+	geocoder := new(mockGeocoder)
+	activities := New(cancelHandler, getHandler, requestDeliveryHandler, nil, nil, nil, deliveryClient, geocoder)
+	order := createOrderWithDeliveryInfo(t)
+
+	getHandler.On("Handle", mock.Anything, orderGet.NewQuery(testOrderID)).Return(order, nil)
+	geocoder.On("Geocode", mock.Anything, mock.Anything).Return(nil, ports.ErrAddressNotGeocoded).Once()
+
+	response, err := activities.RequestDelivery(context.Background(), RequestDeliveryRequest{
+		OrderID: testOrderID,
+	})
+
+	require.Nil(t, response)
+	require.ErrorIs(t, err, ports.ErrAddressNotGeocoded)
+
+	var appErr *temporal.ApplicationError
+	require.ErrorAs(t, err, &appErr)
+	require.True(t, appErr.NonRetryable())
+	deliveryClient.AssertNotCalled(t, "AcceptOrder", mock.Anything, mock.Anything)
+	requestDeliveryHandler.AssertNotCalled(t, "Handle", mock.Anything, mock.Anything)
+}
+
+func TestActivities_SetDeliveryStatus_Success(t *testing.T) {
+	setDeliveryStatusHandler := new(mockSetDeliveryStatusHandler)
+	getHandler := new(mockGetHandler)
+	activities := New(new(mockCancelHandler), getHandler, new(mockRequestDeliveryHandler), nil, setDeliveryStatusHandler, nil, nil, nil)
+	update := orderv1.DeliveryStatusUpdate{
+		MessageID:  uuid.NewString(),
+		OrderID:    testOrderID,
+		Status:     commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+		OccurredAt: time.Date(2026, time.March, 11, 10, 3, 0, 0, time.UTC),
+	}
+
+	order := createOrderWithDeliveryInfo(t)
+	for _, status := range []commonv1.DeliveryStatus{
+		commonv1.DeliveryStatus_DELIVERY_STATUS_ACCEPTED,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_ASSIGNED,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+	} {
+		require.NoError(t, order.SetDeliveryStatus(status))
+	}
+
+	setDeliveryStatusHandler.On("Handle", mock.Anything, orderSetDeliveryStatus.NewCommand(update)).Return(nil).Once()
+	getHandler.On("Handle", mock.Anything, orderGet.NewQuery(testOrderID)).Return(order, nil).Once()
+
+	response, err := activities.SetDeliveryStatus(context.Background(), SetDeliveryStatusRequest{Update: update})
+
+	require.NoError(t, err)
+	require.Equal(t, orderv1.OrderStatus_ORDER_STATUS_PROCESSING, response.OrderStatus)
+	require.Equal(t, commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT, response.DeliveryStatus)
+	setDeliveryStatusHandler.AssertExpectations(t)
+	getHandler.AssertExpectations(t)
+}
+
+func TestActivities_SetDeliveryStatus_InvalidTransitionIsNonRetryable(t *testing.T) {
+	setDeliveryStatusHandler := new(mockSetDeliveryStatusHandler)
+	activities := New(new(mockCancelHandler), new(mockGetHandler), new(mockRequestDeliveryHandler), nil, setDeliveryStatusHandler, nil, nil, nil)
+	transitionErr := &orderv1.InvalidDeliveryStatusTransitionError{
+		From: commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+		To:   commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+	}
+
+	setDeliveryStatusHandler.On("Handle", mock.Anything, mock.Anything).Return(transitionErr).Once()
+
+	response, err := activities.SetDeliveryStatus(context.Background(), SetDeliveryStatusRequest{
+		Update: orderv1.DeliveryStatusUpdate{
+			MessageID: uuid.NewString(),
+			OrderID:   testOrderID,
+			Status:    commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+		},
+	})
+
+	require.Nil(t, response)
+
+	var appErr *temporal.ApplicationError
+	require.ErrorAs(t, err, &appErr)
+	require.True(t, appErr.NonRetryable())
+	require.Equal(t, setDeliveryStatusErrorType, appErr.Type())
+	setDeliveryStatusHandler.AssertExpectations(t)
+}
@@ -55,7 +55,11 @@ func AcceptOrderRequestFromOrder(order *orderv1.OrderState) (ports.AcceptOrderRe
 			EndTime:   period.GetEndTime(),
 		},
 		PackageInfo: ports.PackageInfoDTO{
-			WeightKg: pkg.GetWeightKg(),
+			WeightKg:           pkg.GetWeightKg(),
+			LengthCm:           pkg.GetLengthCm(),
+			WidthCm:            pkg.GetWidthCm(),
+			HeightCm:           pkg.GetHeightCm(),
+			ChargeableWeightKg: pkg.ChargeableWeightKg(),
 		},
 		Priority: priorityDTO,
 	}
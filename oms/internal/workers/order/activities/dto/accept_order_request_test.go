@@ -66,6 +66,41 @@ func TestAcceptOrderRequestFromOrder(t *testing.T) {
 	require.Equal(t, "jane@example.com", req.RecipientEmail)
 }
 
+func TestAcceptOrderRequestFromOrder_PackageDimensions(t *testing.T) {
+	pickupAddr, err := address.NewAddress("123 Warehouse St", "Moscow", "101000", "Russia")
+	require.NoError(t, err)
+	deliveryAddr, err := address.NewAddress("456 Customer St", "Moscow", "102000", "Russia")
+	require.NoError(t, err)
+
+	startTime := time.Now().Add(24 * time.Hour)
+	period := orderv1.NewDeliveryPeriod(startTime, startTime.Add(2*time.Hour))
+	pkgInfo := orderv1.NewPackageInfoWithDimensions(2.5, 50, 40, 30)
+	deliveryInfo := orderv1.NewDeliveryInfo(
+		pickupAddr, deliveryAddr, period, pkgInfo,
+		orderv1.DeliveryPriorityNormal, nil,
+	)
+
+	order := orderv1.NewOrderStateFromPersisted(
+		uuid.New(),
+		uuid.New(),
+		nil,
+		orderv1.OrderStatus_ORDER_STATUS_PROCESSING,
+		0,
+		&deliveryInfo,
+		commonv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+		nil,
+	)
+
+	req, err := dto.AcceptOrderRequestFromOrder(order)
+	require.NoError(t, err)
+
+	require.Equal(t, 2.5, req.PackageInfo.WeightKg)
+	require.Equal(t, 50.0, req.PackageInfo.LengthCm)
+	require.Equal(t, 40.0, req.PackageInfo.WidthCm)
+	require.Equal(t, 30.0, req.PackageInfo.HeightCm)
+	require.InDelta(t, 12.0, req.PackageInfo.ChargeableWeightKg, 1e-9)
+}
+
 func TestAcceptOrderRequestFromOrder_NoDeliveryInfo(t *testing.T) {
 	orderID := uuid.New()
 	customerID := uuid.New()
@@ -34,12 +34,18 @@ func NewWithActivities(ctx context.Context, c client.Client, log logger.Logger,
 	w.RegisterWorkflowWithOptions(order_workflow.Workflow, workflow.RegisterOptions{
 		Name: temporalInfra.OrderWorkflowName,
 	})
+	w.RegisterWorkflowWithOptions(order_workflow.ExpirePendingOrdersWorkflow, workflow.RegisterOptions{
+		Name: temporalInfra.ExpirePendingOrdersWorkflowName,
+	})
 
 	// Register activities (only if provided)
 	if acts != nil {
 		w.RegisterActivity(acts.CancelOrder)
+		w.RegisterActivity(acts.CompleteOrder)
 		w.RegisterActivity(acts.GetOrder)
 		w.RegisterActivity(acts.RequestDelivery)
+		w.RegisterActivity(acts.ExpirePendingOrders)
+		w.RegisterActivity(acts.SetDeliveryStatus)
 		log.Info("Order worker started with activities")
 	} else {
 		log.Info("Order worker started without activities (workflow-only mode)")
@@ -0,0 +1,43 @@
+package order_workflow
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/shortlink-org/shop/oms/internal/workers/order/activities"
+)
+
+// ExpirePendingOrdersWorkflow cancels PENDING orders older than maxAge.
+// It is started on a cron schedule; the cutoff is taken from workflow time so replays stay deterministic.
+func ExpirePendingOrdersWorkflow(ctx workflow.Context, maxAge time.Duration) (int, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute, //nolint:mnd // a run pages through every stale order
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0, //nolint:mnd // exponential backoff
+			MaximumInterval:    time.Minute,
+			MaximumAttempts:    3,
+		},
+	})
+
+	var expirePendingActivities *activities.Activities
+
+	var resp activities.ExpirePendingOrdersResponse
+
+	err := workflow.ExecuteActivity(ctx, expirePendingActivities.ExpirePendingOrders, activities.ExpirePendingOrdersRequest{
+		CreatedBefore: workflow.Now(ctx).Add(-maxAge),
+	}).Get(ctx, &resp)
+	if err != nil {
+		logger.Error("Failed to expire pending orders", "error", err)
+
+		return 0, err
+	}
+
+	logger.Info("Expired pending orders", "expired", resp.Expired)
+
+	return resp.Expired, nil
+}
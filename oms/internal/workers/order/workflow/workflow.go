@@ -1,7 +1,9 @@
 package order_workflow
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,11 +11,31 @@ import (
 	"go.temporal.io/sdk/workflow"
 
 	v2 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
 	"github.com/shortlink-org/shop/oms/internal/workers/order/activities"
 )
 
 const requestDeliveryHeartbeatTimeout = 10 * time.Second
 
+// deliveryStatusChangeID versions the wait for delivery status signals after the saga,
+// so histories recorded before it replay unchanged.
+const deliveryStatusChangeID = "await-delivery-status"
+
+// completeOrderChangeID versions the CompleteOrder activity at the end of the saga.
+const completeOrderChangeID = "complete-order-activity"
+
+// failOnDeliveryStatusErrorChangeID versions failing the workflow when a delivery status update cannot be applied;
+// histories recorded before it log the failure and keep waiting.
+const failOnDeliveryStatusErrorChangeID = "fail-on-delivery-status-error"
+
+// orderSummaryTerminationChangeID versions ending the delivery wait on the order's status instead of
+// the status of a single package, so a split delivery waits for all of its packages.
+const orderSummaryTerminationChangeID = "delivery-order-summary-termination"
+
+// setDeliveryStatusRetryTimeout bounds the retries of one SetDeliveryStatus activity, so a database outage
+// delays the status instead of losing it.
+const setDeliveryStatusRetryTimeout = time.Hour
+
 // WorkflowInput contains all inputs for the order workflow.
 type WorkflowInput struct {
 	OrderID         uuid.UUID
@@ -30,6 +52,9 @@ type WorkflowInput struct {
 // 4. Request delivery (if delivery info provided)
 // 5. Complete order
 //
+// When delivery was requested, the workflow then waits for delivery status signals
+// and applies each one through the SetDeliveryStatus activity until the delivery ends.
+//
 // On failure, compensation activities are executed to rollback changes.
 // The workflow is deterministic - all side effects go through activities.
 // Workflow is the main entry point for the order workflow (used by the event subscriber).
@@ -64,8 +89,9 @@ func WorkflowWithDelivery(ctx workflow.Context, input WorkflowInput) error {
 
 	// Track order status for queries
 	var (
-		orderStatus = "PROCESSING"
-		orderError  error
+		orderStatus   = "PROCESSING"
+		orderError    error
+		sagaSucceeded bool
 	)
 
 	// Set up query handler for getting order status
@@ -133,15 +159,173 @@ func WorkflowWithDelivery(ctx workflow.Context, input WorkflowInput) error {
 			logger.Error("Saga failed", "error", err)
 		} else {
 			logger.Info("Saga completed successfully")
+
+			sagaSucceeded = true
 		}
 	})
 
 	// Wait for first event
 	selector.Select(ctx)
 
+	if !sagaSucceeded || !input.RequestDelivery {
+		return orderError
+	}
+
+	if workflow.GetVersion(ctx, deliveryStatusChangeID, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		return orderError
+	}
+
+	orderStatus = "AWAITING_DELIVERY"
+
+	err = awaitDelivery(ctx, cancelChannel, completeChannel, func(status string) {
+		orderStatus = status
+	})
+	if err != nil {
+		orderStatus = "FAILED"
+		orderError = err
+	}
+
 	return orderError
 }
 
+// awaitDelivery applies delivery status signals until the order is completed or canceled, either by
+// its delivery or by signal.
+// The queryable status follows the order's delivery status, derived from all of its packages:
+// ACCEPTED, ASSIGNED, IN_TRANSIT, PARTIALLY_DELIVERED, then COMPLETED or CANCELED with the order.
+// An update still failing after setDeliveryStatusRetryTimeout fails the workflow; later updates of the
+// order are then applied without it (see on_delivery_status). An update the order rejects is skipped.
+func awaitDelivery(
+	ctx workflow.Context,
+	cancelChannel, completeChannel workflow.ReceiveChannel,
+	setStatus func(status string),
+) error {
+	logger := workflow.GetLogger(ctx)
+	deliveryStatusChannel := workflow.GetSignalChannel(ctx, v2.WorkflowSignalDeliveryStatus)
+	failOnError := workflow.GetVersion(ctx, failOnDeliveryStatusErrorChangeID, workflow.DefaultVersion, 1) == 1
+	followOrder := workflow.GetVersion(ctx, orderSummaryTerminationChangeID, workflow.DefaultVersion, 1) == 1
+	done := false
+
+	var failure error
+
+	setStatusCtx := ctx
+	if failOnError {
+		setStatusCtx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout:    30 * time.Second, //nolint:mnd // same as the saga activities
+			ScheduleToCloseTimeout: setDeliveryStatusRetryTimeout,
+			RetryPolicy: &temporal.RetryPolicy{
+				InitialInterval:    time.Second,
+				BackoffCoefficient: 2.0, //nolint:mnd // exponential backoff
+				MaximumInterval:    time.Minute,
+			},
+		})
+	}
+
+	selector := workflow.NewSelector(ctx)
+
+	selector.AddReceive(cancelChannel, func(c workflow.ReceiveChannel, _ bool) {
+		c.Receive(ctx, nil)
+		logger.Info("Order cancellation signal received while awaiting delivery")
+		setStatus("CANCELED")
+
+		done = true
+	})
+
+	selector.AddReceive(completeChannel, func(c workflow.ReceiveChannel, _ bool) {
+		c.Receive(ctx, nil)
+		logger.Info("Order completion signal received while awaiting delivery")
+
+		done = true
+	})
+
+	selector.AddReceive(deliveryStatusChannel, func(c workflow.ReceiveChannel, _ bool) {
+		var update v2.DeliveryStatusUpdate
+		c.Receive(ctx, &update)
+
+		workflow.SetCurrentDetails(ctx, fmt.Sprintf("**Delivery:** %s", update.Status))
+
+		var (
+			resp   activities.SetDeliveryStatusResponse
+			result any
+		)
+
+		if followOrder {
+			result = &resp
+		}
+
+		err := workflow.ExecuteActivity(setStatusCtx, "SetDeliveryStatus", activities.SetDeliveryStatusRequest{
+			Update: update,
+		}).Get(ctx, result)
+		if err != nil {
+			logger.Error("Failed to set delivery status", "error", err, "orderID", update.OrderID, "status", update.Status)
+
+			if failOnError && !isNonRetryable(err) {
+				failure = fmt.Errorf("set delivery status %s: %w", update.Status, err)
+				done = true
+			}
+
+			// Otherwise the order keeps its previous delivery status; a later update may still apply
+			return
+		}
+
+		var (
+			status   string
+			terminal bool
+		)
+
+		if followOrder {
+			status, terminal = orderWorkflowStatus(resp)
+		} else {
+			status, terminal = deliveryWorkflowStatus(update.Status)
+		}
+
+		setStatus(status)
+
+		done = terminal
+	})
+
+	for !done {
+		selector.Select(ctx)
+	}
+
+	return failure
+}
+
+// isNonRetryable reports whether an activity failed with an error retrying cannot fix, e.g. an update
+// the order rejects.
+func isNonRetryable(err error) bool {
+	var appErr *temporal.ApplicationError
+
+	return errors.As(err, &appErr) && appErr.NonRetryable()
+}
+
+// orderWorkflowStatus maps the order after a delivery status update to the queryable workflow status
+// and reports whether the order is over. One settled package of a split delivery leaves the order
+// PARTIALLY_DELIVERED and the workflow waiting for the others.
+func orderWorkflowStatus(resp activities.SetDeliveryStatusResponse) (string, bool) {
+	switch resp.OrderStatus {
+	case v2.OrderStatus_ORDER_STATUS_COMPLETED:
+		return "COMPLETED", true
+	case v2.OrderStatus_ORDER_STATUS_CANCELED:
+		return "CANCELED", true
+	default:
+		return strings.TrimPrefix(resp.DeliveryStatus.String(), "DELIVERY_STATUS_"), false
+	}
+}
+
+// deliveryWorkflowStatus maps a delivery status to the queryable workflow status
+// and reports whether the delivery is over. Histories recorded before orderSummaryTerminationChangeID
+// use it; it ends the wait on the first settled package.
+func deliveryWorkflowStatus(status commonv1.DeliveryStatus) (string, bool) {
+	switch status {
+	case commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED:
+		return "COMPLETED", true
+	case commonv1.DeliveryStatus_DELIVERY_STATUS_NOT_DELIVERED:
+		return "CANCELED", true
+	default:
+		return strings.TrimPrefix(status.String(), "DELIVERY_STATUS_"), false
+	}
+}
+
 // executeSaga executes the order processing saga (legacy version without delivery).
 // Returns error if any step fails (compensation should be handled).
 //
@@ -203,10 +387,29 @@ func executeSagaWithDelivery(ctx workflow.Context, requestDeliveryCtx workflow.C
 			"status", deliveryResp.Status)
 	}
 
-	// Final Step: Complete order
+	// Final Step: Complete order.
+	// A delivered order is completed by its DELIVERED status update instead (see awaitDelivery).
 	currentStep := totalSteps
 	workflow.SetCurrentDetails(ctx, fmt.Sprintf("**Step %d/%d:** Completing order...", currentStep, totalSteps))
-	// Note: For now, we just log. In a real implementation, you'd call an activity.
+
+	if !hasDelivery && workflow.GetVersion(ctx, completeOrderChangeID, workflow.DefaultVersion, 1) == 1 {
+		var completeActivities *activities.Activities
+
+		err := workflow.ExecuteActivity(ctx, completeActivities.CompleteOrder, activities.CompleteOrderRequest{
+			OrderID: input.OrderID,
+		}).Get(ctx, nil)
+		if err != nil {
+			workflow.SetCurrentDetails(ctx, "**Failed:** Order completion failed, compensating...")
+			logger.Error("Failed to complete order", "error", err, "orderID", input.OrderID)
+			// Compensation: cancel order (stock release would also be needed if implemented)
+			var cancelActivities *activities.Activities
+
+			_ = workflow.ExecuteActivity(ctx, cancelActivities.CancelOrder, activities.CancelOrderRequest{OrderID: input.OrderID}).Get(ctx, nil) //nolint:errcheck // best-effort compensation
+
+			return err
+		}
+	}
+
 	logger.Info("Order processing completed", "orderID", input.OrderID)
 
 	workflow.SetCurrentDetails(ctx, "**Completed:** Order processed successfully ✓")
@@ -15,6 +15,7 @@ import (
 	"go.temporal.io/sdk/testsuite"
 
 	v2 "github.com/shortlink-org/shop/oms/internal/domain/order/v1"
+	commonv1 "github.com/shortlink-org/shop/oms/internal/domain/order/v1/common"
 	"github.com/shortlink-org/shop/oms/internal/workers/order/activities"
 )
 
@@ -41,6 +42,24 @@ func (s *OrderWorkflowTestSuite) SetupTest() {
 		},
 		activity.RegisterOptions{Name: "CancelOrder"},
 	)
+	s.env.RegisterActivityWithOptions(
+		func(context.Context, activities.CompleteOrderRequest) error {
+			return nil
+		},
+		activity.RegisterOptions{Name: "CompleteOrder"},
+	)
+	s.env.RegisterActivityWithOptions(
+		func(context.Context, activities.ExpirePendingOrdersRequest) (*activities.ExpirePendingOrdersResponse, error) {
+			return nil, nil
+		},
+		activity.RegisterOptions{Name: "ExpirePendingOrders"},
+	)
+	s.env.RegisterActivityWithOptions(
+		func(context.Context, activities.SetDeliveryStatusRequest) (*activities.SetDeliveryStatusResponse, error) {
+			return nil, nil
+		},
+		activity.RegisterOptions{Name: "SetDeliveryStatus"},
+	)
 }
 
 // AfterTest asserts that all mocks were called as expected.
@@ -73,6 +92,54 @@ func (s *OrderWorkflowTestSuite) Test_Workflow_Success() {
 	s.NoError(s.env.GetWorkflowError())
 }
 
+// Test_Workflow_CompleteOrder verifies the final saga step completes the order through the CompleteOrder activity.
+func (s *OrderWorkflowTestSuite) Test_Workflow_CompleteOrder() {
+	orderID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	customerID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174100")
+	items := createTestItems()
+
+	s.env.OnActivity(new(activities.Activities).CompleteOrder, mock.Anything, activities.CompleteOrderRequest{
+		OrderID: orderID,
+	}).Return(nil).Once()
+	s.env.OnActivity(new(activities.Activities).CancelOrder, mock.Anything, mock.Anything).Never()
+
+	s.env.ExecuteWorkflow(Workflow, orderID, customerID, items, false)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+
+	res, err := s.env.QueryWorkflow(v2.WorkflowQueryGet)
+	s.NoError(err)
+
+	var status string
+	err = res.Get(&status)
+	s.NoError(err)
+	s.Equal("COMPLETED", status)
+}
+
+// Test_Workflow_CompleteOrderFailure verifies the order is canceled when it cannot be completed.
+func (s *OrderWorkflowTestSuite) Test_Workflow_CompleteOrderFailure() {
+	orderID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	customerID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174100")
+	items := createTestItems()
+
+	s.env.OnActivity(new(activities.Activities).CompleteOrder, mock.Anything, activities.CompleteOrderRequest{
+		OrderID: orderID,
+	}).Return(temporal.NewNonRetryableApplicationError("invalid transition", "OrderCompleteValidationError", nil)).Once()
+	s.env.OnActivity(new(activities.Activities).CancelOrder, mock.Anything, activities.CancelOrderRequest{
+		OrderID: orderID,
+	}).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(Workflow, orderID, customerID, items, false)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.ErrorContains(s.env.GetWorkflowError(), "invalid transition")
+
+	res, err := s.env.QueryWorkflow(v2.WorkflowQueryGet)
+	s.Error(err)
+	s.Nil(res)
+}
+
 // Test_Workflow_WithDelivery_Success verifies the delivery branch completes without compensation.
 func (s *OrderWorkflowTestSuite) Test_Workflow_WithDelivery_Success() {
 	orderID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
@@ -86,6 +153,133 @@ func (s *OrderWorkflowTestSuite) Test_Workflow_WithDelivery_Success() {
 		Status:    "ACCEPTED",
 	}, nil).Once()
 	s.env.OnActivity(new(activities.Activities).CancelOrder, mock.Anything, mock.Anything).Never()
+	s.env.OnActivity("SetDeliveryStatus", mock.Anything, mock.Anything).Return(&activities.SetDeliveryStatusResponse{
+		OrderStatus:    v2.OrderStatus_ORDER_STATUS_COMPLETED,
+		DeliveryStatus: commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+	}, nil).Once()
+
+	// The workflow waits for the delivery to end after requesting it
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(v2.WorkflowSignalDeliveryStatus, v2.DeliveryStatusUpdate{
+			MessageID: uuid.NewString(),
+			OrderID:   orderID,
+			Status:    commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+		})
+	}, time.Minute)
+
+	s.env.ExecuteWorkflow(Workflow, orderID, customerID, items, true)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+
+	res, err := s.env.QueryWorkflow(v2.WorkflowQueryGet)
+	s.NoError(err)
+
+	var status string
+	err = res.Get(&status)
+	s.NoError(err)
+	s.Equal("COMPLETED", status)
+}
+
+// Test_Workflow_DeliveryStatusSignal verifies a delivery status signal is applied and reflected by the status query.
+func (s *OrderWorkflowTestSuite) Test_Workflow_DeliveryStatusSignal() {
+	orderID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	customerID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174100")
+	items := createTestItems()
+	inTransit := v2.DeliveryStatusUpdate{
+		MessageID:  uuid.NewString(),
+		OrderID:    orderID,
+		PackageID:  uuid.MustParse("123e4567-e89b-12d3-a456-426614174999"),
+		CourierID:  uuid.MustParse("123e4567-e89b-12d3-a456-426614174888"),
+		Status:     commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+		OccurredAt: time.Date(2026, time.March, 11, 10, 3, 0, 0, time.UTC),
+	}
+
+	s.env.OnActivity("RequestDelivery", mock.Anything, mock.Anything).Return(&activities.RequestDeliveryResponse{
+		PackageID: inTransit.PackageID.String(),
+		Status:    "ACCEPTED",
+	}, nil).Once()
+	s.env.OnActivity("SetDeliveryStatus", mock.Anything, activities.SetDeliveryStatusRequest{
+		Update: inTransit,
+	}).Return(&activities.SetDeliveryStatusResponse{
+		OrderStatus:    v2.OrderStatus_ORDER_STATUS_PROCESSING,
+		DeliveryStatus: commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+	}, nil).Once()
+
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(v2.WorkflowSignalDeliveryStatus, inTransit)
+	}, time.Minute)
+
+	s.env.RegisterDelayedCallback(func() {
+		res, err := s.env.QueryWorkflow(v2.WorkflowQueryGet)
+		s.NoError(err)
+
+		var status string
+		err = res.Get(&status)
+		s.NoError(err)
+		s.Equal("IN_TRANSIT", status)
+
+		s.env.SignalWorkflow(v2.WorkflowSignalComplete, nil)
+	}, 2*time.Minute)
+
+	s.env.ExecuteWorkflow(Workflow, orderID, customerID, items, true)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
+
+// Test_Workflow_SplitDeliveryWaitsForAllPackages verifies one delivered package of a split delivery
+// does not end the workflow; it ends when the order is completed by the last package.
+func (s *OrderWorkflowTestSuite) Test_Workflow_SplitDeliveryWaitsForAllPackages() {
+	orderID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	customerID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174100")
+	items := createTestItems()
+	firstPackage := v2.DeliveryStatusUpdate{
+		MessageID: uuid.NewString(),
+		OrderID:   orderID,
+		PackageID: uuid.MustParse("123e4567-e89b-12d3-a456-426614174998"),
+		Status:    commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+	}
+	secondPackage := v2.DeliveryStatusUpdate{
+		MessageID: uuid.NewString(),
+		OrderID:   orderID,
+		PackageID: uuid.MustParse("123e4567-e89b-12d3-a456-426614174999"),
+		Status:    commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+	}
+
+	s.env.OnActivity("RequestDelivery", mock.Anything, mock.Anything).Return(&activities.RequestDeliveryResponse{
+		Status: "ACCEPTED",
+	}, nil).Once()
+	s.env.OnActivity("SetDeliveryStatus", mock.Anything, activities.SetDeliveryStatusRequest{
+		Update: firstPackage,
+	}).Return(&activities.SetDeliveryStatusResponse{
+		OrderStatus:    v2.OrderStatus_ORDER_STATUS_PROCESSING,
+		DeliveryStatus: commonv1.DeliveryStatus_DELIVERY_STATUS_PARTIALLY_DELIVERED,
+	}, nil).Once()
+	s.env.OnActivity("SetDeliveryStatus", mock.Anything, activities.SetDeliveryStatusRequest{
+		Update: secondPackage,
+	}).Return(&activities.SetDeliveryStatusResponse{
+		OrderStatus:    v2.OrderStatus_ORDER_STATUS_COMPLETED,
+		DeliveryStatus: commonv1.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+	}, nil).Once()
+
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(v2.WorkflowSignalDeliveryStatus, firstPackage)
+	}, time.Minute)
+
+	s.env.RegisterDelayedCallback(func() {
+		s.False(s.env.IsWorkflowCompleted())
+
+		res, err := s.env.QueryWorkflow(v2.WorkflowQueryGet)
+		s.NoError(err)
+
+		var status string
+		err = res.Get(&status)
+		s.NoError(err)
+		s.Equal("PARTIALLY_DELIVERED", status)
+
+		s.env.SignalWorkflow(v2.WorkflowSignalDeliveryStatus, secondPackage)
+	}, 2*time.Minute)
 
 	s.env.ExecuteWorkflow(Workflow, orderID, customerID, items, true)
 
@@ -101,6 +295,32 @@ func (s *OrderWorkflowTestSuite) Test_Workflow_WithDelivery_Success() {
 	s.Equal("COMPLETED", status)
 }
 
+// Test_Workflow_DeliveryStatusFailureFailsWorkflow verifies an update that keeps failing fails the workflow
+// instead of being dropped, so later updates are applied without it.
+func (s *OrderWorkflowTestSuite) Test_Workflow_DeliveryStatusFailureFailsWorkflow() {
+	orderID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	customerID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174100")
+	items := createTestItems()
+
+	s.env.OnActivity("RequestDelivery", mock.Anything, mock.Anything).Return(&activities.RequestDeliveryResponse{
+		Status: "ACCEPTED",
+	}, nil).Once()
+	s.env.OnActivity("SetDeliveryStatus", mock.Anything, mock.Anything).Return(nil, errors.New("database unavailable"))
+
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(v2.WorkflowSignalDeliveryStatus, v2.DeliveryStatusUpdate{
+			MessageID: uuid.NewString(),
+			OrderID:   orderID,
+			Status:    commonv1.DeliveryStatus_DELIVERY_STATUS_IN_TRANSIT,
+		})
+	}, time.Minute)
+
+	s.env.ExecuteWorkflow(Workflow, orderID, customerID, items, true)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.ErrorContains(s.env.GetWorkflowError(), "database unavailable")
+}
+
 // Test_Workflow_WithDelivery_RequestDeliveryFailure verifies compensation is executed after retries.
 func (s *OrderWorkflowTestSuite) Test_Workflow_WithDelivery_RequestDeliveryFailure() {
 	orderID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
@@ -296,6 +516,25 @@ func (s *OrderWorkflowTestSuite) Test_Workflow_MultipleSignals() {
 
 	err = res.Get(&status)
 	s.NoError(err)
-	// Status should be COMPLETED as the saga finishes successfully
-	s.Equal("COMPLETED", status)
+	// The complete signal may end the workflow before the CompleteOrder activity returns
+	s.Contains([]string{"PROCESSING", "COMPLETED"}, status)
+}
+
+// Test_ExpirePendingOrdersWorkflow_UsesWorkflowTimeCutoff tests that the cutoff is workflow time minus maxAge.
+func (s *OrderWorkflowTestSuite) Test_ExpirePendingOrdersWorkflow_UsesWorkflowTimeCutoff() {
+	start := time.Date(2026, time.March, 11, 10, 0, 0, 0, time.UTC)
+	s.env.SetStartTime(start)
+
+	s.env.OnActivity(new(activities.Activities).ExpirePendingOrders, mock.Anything, activities.ExpirePendingOrdersRequest{
+		CreatedBefore: start.Add(-24 * time.Hour),
+	}).Return(&activities.ExpirePendingOrdersResponse{Expired: 2}, nil).Once()
+
+	s.env.ExecuteWorkflow(ExpirePendingOrdersWorkflow, 24*time.Hour)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+
+	var expired int
+	s.NoError(s.env.GetWorkflowResult(&expired))
+	s.Equal(2, expired)
 }
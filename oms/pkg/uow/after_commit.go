@@ -0,0 +1,54 @@
+package uow
+
+import (
+	"context"
+	"sync"
+)
+
+type afterCommitKey struct{}
+
+// afterCommitHooks collects the callbacks registered during one transaction.
+type afterCommitHooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+// WithAfterCommitHooks returns a context that collects AfterCommit callbacks
+// for the transaction it carries. UnitOfWork implementations call it in Begin.
+func WithAfterCommitHooks(ctx context.Context) context.Context {
+	return context.WithValue(ctx, afterCommitKey{}, &afterCommitHooks{})
+}
+
+// AfterCommit registers fn to run once the transaction in ctx commits; it is dropped on rollback.
+// Without a unit of work collecting hooks, fn runs immediately.
+func AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks)
+	if !ok {
+		fn()
+
+		return
+	}
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+
+	hooks.fns = append(hooks.fns, fn)
+}
+
+// RunAfterCommitHooks runs and forgets the callbacks registered in ctx.
+// UnitOfWork implementations call it after a successful Commit.
+func RunAfterCommitHooks(ctx context.Context) {
+	hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks)
+	if !ok {
+		return
+	}
+
+	hooks.mu.Lock()
+	fns := hooks.fns
+	hooks.fns = nil
+	hooks.mu.Unlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
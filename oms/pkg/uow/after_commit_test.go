@@ -0,0 +1,29 @@
+package uow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAfterCommit_RunsOnceAfterCommit(t *testing.T) {
+	ctx := WithAfterCommitHooks(context.Background())
+	calls := 0
+
+	AfterCommit(ctx, func() { calls++ })
+	require.Zero(t, calls, "hook must wait for the commit")
+
+	RunAfterCommitHooks(ctx)
+	require.Equal(t, 1, calls)
+
+	RunAfterCommitHooks(ctx)
+	require.Equal(t, 1, calls, "hooks run only once")
+}
+
+func TestAfterCommit_RunsImmediatelyWithoutHooks(t *testing.T) {
+	calls := 0
+
+	AfterCommit(context.Background(), func() { calls++ })
+	require.Equal(t, 1, calls)
+}
@@ -28,17 +28,23 @@ func (u *UoW) Begin(ctx context.Context) (context.Context, error) {
 		return ctx, fmt.Errorf("begin tx: %w", err)
 	}
 
-	return uow.WithTx(ctx, pgxTx), nil
+	return uow.WithAfterCommitHooks(uow.WithTx(ctx, pgxTx)), nil
 }
 
-// Commit commits the transaction from context.
+// Commit commits the transaction from context and then runs its AfterCommit hooks.
 func (u *UoW) Commit(ctx context.Context) error {
 	pgxTx := uow.FromContext(ctx)
 	if pgxTx == nil {
 		return nil // no-op if no transaction
 	}
 
-	return pgxTx.Commit(ctx)
+	if err := pgxTx.Commit(ctx); err != nil {
+		return err
+	}
+
+	uow.RunAfterCommitHooks(ctx)
+
+	return nil
 }
 
 // Rollback rolls back the transaction from context.
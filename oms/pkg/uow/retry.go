@@ -0,0 +1,78 @@
+package uow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultMaxAttempts is the attempt budget used by command handlers for RunWithRetry.
+const DefaultMaxAttempts = 3
+
+// retryBaseBackoff is the delay before the second attempt; it doubles on every further attempt.
+const retryBaseBackoff = 10 * time.Millisecond
+
+// Transactor is the transaction lifecycle driven by RunWithRetry (ports.UnitOfWork satisfies it).
+type Transactor interface {
+	Begin(ctx context.Context) (context.Context, error)
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
+
+// RunWithRetry runs fn inside a transaction and commits it.
+// If fn or Commit fails with an error matching retryOn (errors.Is), e.g. the caller's optimistic-lock
+// conflict, the transaction is rolled back and fn is retried in a fresh transaction with exponential
+// backoff, up to maxAttempts in total.
+// fn must reload aggregates on every call so a retry sees the latest version.
+// Any other error is returned after rollback without retrying.
+func RunWithRetry(ctx context.Context, tx Transactor, maxAttempts int, retryOn error, fn func(ctx context.Context) error) error {
+	maxAttempts = max(maxAttempts, 1)
+
+	var err error
+
+	for attempt := range maxAttempts {
+		if attempt > 0 {
+			timer := time.NewTimer(retryBaseBackoff << (attempt - 1))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+
+				return errors.Join(err, ctx.Err())
+			case <-timer.C:
+			}
+		}
+
+		err = runOnce(ctx, tx, fn)
+		if err == nil || !errors.Is(err, retryOn) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("gave up after %d attempts: %w", maxAttempts, err)
+}
+
+func runOnce(ctx context.Context, tx Transactor, fn func(ctx context.Context) error) error {
+	txCtx, err := tx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	err = fn(txCtx)
+	if err == nil {
+		err = tx.Commit(txCtx)
+		if err == nil {
+			return nil
+		}
+
+		err = fmt.Errorf("commit transaction: %w", err)
+	}
+
+	rollbackErr := tx.Rollback(txCtx)
+	if rollbackErr != nil {
+		return errors.Join(err, fmt.Errorf("rollback transaction: %w", rollbackErr))
+	}
+
+	return err
+}
@@ -0,0 +1,100 @@
+package uow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errConflict = errors.New("conflict")
+
+type countingTransactor struct {
+	begins    int
+	commits   int
+	rollbacks int
+}
+
+func (c *countingTransactor) Begin(ctx context.Context) (context.Context, error) {
+	c.begins++
+	return ctx, nil
+}
+
+func (c *countingTransactor) Commit(context.Context) error {
+	c.commits++
+	return nil
+}
+
+func (c *countingTransactor) Rollback(context.Context) error {
+	c.rollbacks++
+	return nil
+}
+
+func TestRunWithRetry_RetriesOnRetryableError(t *testing.T) {
+	tx := &countingTransactor{}
+	attempts := 0
+
+	err := RunWithRetry(context.Background(), tx, 3, errConflict, func(context.Context) error {
+		attempts++
+		if attempts == 1 {
+			return errConflict
+		}
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+	require.Equal(t, 2, tx.begins)
+	require.Equal(t, 1, tx.rollbacks)
+	require.Equal(t, 1, tx.commits)
+}
+
+func TestRunWithRetry_DoesNotRetryOtherErrors(t *testing.T) {
+	tx := &countingTransactor{}
+	errBoom := errors.New("boom")
+	attempts := 0
+
+	err := RunWithRetry(context.Background(), tx, 3, errConflict, func(context.Context) error {
+		attempts++
+		return errBoom
+	})
+
+	require.ErrorIs(t, err, errBoom)
+	require.Equal(t, 1, attempts)
+	require.Equal(t, 1, tx.rollbacks)
+	require.Zero(t, tx.commits)
+}
+
+func TestRunWithRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	tx := &countingTransactor{}
+	attempts := 0
+
+	err := RunWithRetry(context.Background(), tx, 3, errConflict, func(context.Context) error {
+		attempts++
+		return errConflict
+	})
+
+	require.ErrorIs(t, err, errConflict)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, 3, tx.rollbacks)
+	require.Zero(t, tx.commits)
+}
+
+func TestRunWithRetry_StopsWhenContextIsCanceled(t *testing.T) {
+	tx := &countingTransactor{}
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+
+	err := RunWithRetry(ctx, tx, 3, errConflict, func(context.Context) error {
+		attempts++
+		cancel()
+
+		return errConflict
+	})
+
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, err, errConflict)
+	require.Equal(t, 1, attempts)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/shortlink-org/shop/pricer/internal/di"
+	"github.com/shortlink-org/shop/pricer/internal/infrastructure/cli"
 )
 
 func main() {
@@ -42,12 +43,12 @@ func main() {
 			taxParams = make(map[string]any)
 		}
 
-		for _, cartFile := range cartFiles {
-			err := service.CLIHandler.Run(cartFile, discountParams, taxParams)
-			if err != nil {
-				service.Log.Error("CLI processing failed", slog.String("cart_file", cartFile), slog.Any("error", err))
-			}
-		}
+		err := service.CLIHandler.RunAll(cartFiles, discountParams, taxParams)
+
+		cleanup()
+
+		// CLI mode is a batch job: exit once every cart file has been processed.
+		os.Exit(cli.ExitCode(err)) //nolint:gocritic // cleanup already ran
 	}
 
 	// Handle SIGINT, SIGQUIT and SIGTERM.
@@ -11,6 +11,8 @@ package di
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/google/wire"
 	"github.com/spf13/viper"
@@ -27,6 +29,7 @@ import (
 	"github.com/shortlink-org/shop/pricer/internal/domain/pricing"
 	"github.com/shortlink-org/shop/pricer/internal/infrastructure/cli"
 	"github.com/shortlink-org/shop/pricer/internal/infrastructure/policy_evaluator"
+	adminv1 "github.com/shortlink-org/shop/pricer/internal/infrastructure/rpc/admin/v1"
 	cartv1 "github.com/shortlink-org/shop/pricer/internal/infrastructure/rpc/cart/v1"
 	"github.com/shortlink-org/shop/pricer/internal/infrastructure/rpc/run"
 	"github.com/shortlink-org/shop/pricer/internal/usecases/cart/command/calculate_total"
@@ -81,6 +84,7 @@ var PricerSet = wire.NewSet(
 	// Repository
 	newDiscountPolicy,
 	newTaxPolicy,
+	newOrderDiscountPolicy,
 	newPolicyNames,
 
 	// Delivery
@@ -98,9 +102,30 @@ func newGoSDKConfig() (*config.Config, error) {
 	return config.New()
 }
 
-// newGoSDKLogger creates a go-sdk logger instance for observability
+// newGoSDKLogger creates a go-sdk logger instance for observability.
+// When CLI results go to stdout, logs are moved to stderr so the output stays pipeable.
 func newGoSDKLogger(ctx context.Context, cfg *config.Config) (logger.Logger, func(), error) {
-	return logger.NewDefault(ctx, cfg)
+	if viper.GetString("output_dir") != cli.StdoutOutputDir {
+		return logger.NewDefault(ctx, cfg)
+	}
+
+	cfg.SetDefault("LOG_LEVEL", logger.INFO_LEVEL)
+	cfg.SetDefault("LOG_TIME_FORMAT", time.RFC3339Nano)
+
+	log, err := logger.New(logger.Configuration{
+		Writer:     os.Stderr,
+		Level:      cfg.GetInt("LOG_LEVEL"),
+		TimeFormat: cfg.GetString("LOG_TIME_FORMAT"),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanup := func() {
+		_ = log.Close() //nolint:errcheck // flushes buffer, nothing to handle on exit
+	}
+
+	return log, cleanup, nil
 }
 
 // newGoSDKTracer creates a tracer using go-sdk observability
@@ -118,8 +143,9 @@ func newGoSDKProfiling(ctx context.Context, log logger.Logger, tracer trace.Trac
 	return profiling.New(ctx, log, tracer, cfg)
 }
 
-// newGRPCServerWithHandler creates gRPC server and registers CartService handler
-func newGRPCServerWithHandler(ctx context.Context, log logger.Logger, tracer trace.TracerProvider, monitoring *metrics.Monitoring, cfg *config.Config, calculateTotalHandler *calculate_total.Handler) (*grpc.Server, error) {
+// newGRPCServerWithHandler creates gRPC server and registers CartService handler.
+// PolicyAdminService is registered only when an admin token is configured.
+func newGRPCServerWithHandler(ctx context.Context, log logger.Logger, tracer trace.TracerProvider, monitoring *metrics.Monitoring, cfg *config.Config, calculateTotalHandler *calculate_total.Handler, discountPolicy *pricing.DiscountPolicy, taxPolicy *pricing.TaxPolicy, orderDiscountPolicy *pricing.OrderDiscountPolicy) (*grpc.Server, error) {
 	promRegistry := monitoring.Prometheus
 	server, err := grpc.InitServer(ctx, log, tracer, promRegistry, nil, cfg)
 	if err != nil {
@@ -128,6 +154,17 @@ func newGRPCServerWithHandler(ctx context.Context, log logger.Logger, tracer tra
 	if server != nil {
 		handler := cartv1.NewCartHandler(calculateTotalHandler)
 		cartv1.RegisterCartServiceServer(server.Server, handler)
+
+		if adminToken := viper.GetString("admin_token"); adminToken != "" {
+			policyDirs := []string{viper.GetString("policies.discounts"), viper.GetString("policies.taxes")}
+			reloaders := []adminv1.PolicyReloader{discountPolicy.Evaluator, taxPolicy.Evaluator}
+			if orderDiscountPolicy != nil {
+				policyDirs = append(policyDirs, viper.GetString("policies.order_discounts"))
+				reloaders = append(reloaders, orderDiscountPolicy.Evaluator)
+			}
+			adminHandler := adminv1.NewAdminHandler(adminToken, policyDirs, reloaders...)
+			adminv1.RegisterPolicyAdminServiceServer(server.Server, adminHandler)
+		}
 	}
 	return server, nil
 }
@@ -140,8 +177,9 @@ func NewRunRPCServer(runRPCServer *grpc.Server) (*run.Response, error) {
 func newDiscountPolicy(ctx context.Context, log logger.Logger, cfg *pkg_di.Config) (*pricing.DiscountPolicy, error) {
 	discountPolicyPath := viper.GetString("policies.discounts")
 	discountQuery := viper.GetString("queries.discounts")
+	discountPolicyQueries := viper.GetStringSlice("queries.discount_policies")
 
-	evaluator, err := policy_evaluator.NewOPAEvaluator(log, discountPolicyPath, discountQuery)
+	evaluator, err := policy_evaluator.NewOPAEvaluatorWithCache(log, newEvaluatorCacheConfig(), discountPolicyPath, discountQuery, discountPolicyQueries...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize discount policy evaluator: %w", err)
 	}
@@ -153,8 +191,9 @@ func newDiscountPolicy(ctx context.Context, log logger.Logger, cfg *pkg_di.Confi
 func newTaxPolicy(ctx context.Context, log logger.Logger, cfg *pkg_di.Config) (*pricing.TaxPolicy, error) {
 	taxPolicyPath := viper.GetString("policies.taxes")
 	taxQuery := viper.GetString("queries.taxes")
+	taxPolicyQueries := viper.GetStringSlice("queries.tax_policies")
 
-	evaluator, err := policy_evaluator.NewOPAEvaluator(log, taxPolicyPath, taxQuery)
+	evaluator, err := policy_evaluator.NewOPAEvaluatorWithCache(log, newEvaluatorCacheConfig(), taxPolicyPath, taxQuery, taxPolicyQueries...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize tax policy evaluator: %w", err)
 	}
@@ -162,18 +201,63 @@ func newTaxPolicy(ctx context.Context, log logger.Logger, cfg *pkg_di.Config) (*
 	return &pricing.TaxPolicy{Evaluator: evaluator}, nil
 }
 
+// newOrderDiscountPolicy creates a new order discount policy; nil when no policy directory is configured
+func newOrderDiscountPolicy(ctx context.Context, log logger.Logger, cfg *pkg_di.Config) (*pricing.OrderDiscountPolicy, error) {
+	orderDiscountPolicyPath := viper.GetString("policies.order_discounts")
+	if orderDiscountPolicyPath == "" {
+		return nil, nil //nolint:nilnil // order discounts are optional
+	}
+
+	orderDiscountQuery := viper.GetString("queries.order_discounts")
+	orderDiscountPolicyQueries := viper.GetStringSlice("queries.order_discount_policies")
+
+	evaluator, err := policy_evaluator.NewOPAEvaluatorWithCache(log, newEvaluatorCacheConfig(), orderDiscountPolicyPath, orderDiscountQuery, orderDiscountPolicyQueries...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize order discount policy evaluator: %w", err)
+	}
+
+	return &pricing.OrderDiscountPolicy{Evaluator: evaluator}, nil
+}
+
+// newEvaluatorCacheConfig reads the sizing of the OPA evaluation cache; unset keys keep the defaults
+func newEvaluatorCacheConfig() policy_evaluator.CacheConfig {
+	defaults := policy_evaluator.DefaultCacheConfig()
+	viper.SetDefault("cache.num_counters", defaults.NumCounters)
+	viper.SetDefault("cache.max_cost", defaults.MaxCost)
+	viper.SetDefault("cache.ttl", defaults.TTL)
+
+	return policy_evaluator.CacheConfig{
+		NumCounters: viper.GetInt64("cache.num_counters"),
+		MaxCost:     viper.GetInt64("cache.max_cost"),
+		TTL:         viper.GetDuration("cache.ttl"),
+	}
+}
+
 // newPolicyNames retrieves policy names
 func newPolicyNames(cfg *pkg_di.Config) ([]string, error) {
 	discountPolicyPath := viper.GetString("policies.discounts")
 	taxPolicyPath := viper.GetString("policies.taxes")
+	policyPaths := []string{discountPolicyPath, taxPolicyPath}
+
+	if orderDiscountPolicyPath := viper.GetString("policies.order_discounts"); orderDiscountPolicyPath != "" {
+		policyPaths = append(policyPaths, orderDiscountPolicyPath)
+	}
 
-	return policy_evaluator.GetPolicyNames(discountPolicyPath, taxPolicyPath)
+	return policy_evaluator.GetPolicyNames(policyPaths...)
 }
 
 // newCLIHandler creates a new CLIHandler (does not run processing - use Run() explicitly for CLI mode)
-func newCLIHandler(calculateTotalHandler *calculate_total.Handler, cfg *pkg_di.Config) *cli.CLIHandler {
+func newCLIHandler(calculateTotalHandler *calculate_total.Handler, cfg *pkg_di.Config) (*cli.CLIHandler, error) {
 	outputDir := viper.GetString("output_dir")
-	return cli.NewCLIHandler(calculateTotalHandler, outputDir)
+
+	viper.SetDefault("output_format", string(cli.OutputFormatJSON))
+
+	format, err := cli.ParseOutputFormat(viper.GetString("output_format"))
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.NewCLIHandler(calculateTotalHandler, outputDir, format), nil
 }
 
 func NewPricerService(
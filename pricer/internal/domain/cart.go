@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"fmt"
+
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
@@ -9,13 +11,46 @@ type CartItem struct {
 	GoodID   uuid.UUID       `json:"productId"`
 	Quantity int32           `json:"quantity"`
 	Price    decimal.Decimal `json:"price"`
+	// Currency of Price; empty means the cart currency.
+	Currency Currency `json:"currency,omitempty"`
+	// TaxCategory tax policies branch on; empty means DefaultTaxCategory.
+	TaxCategory TaxCategory `json:"taxCategory,omitempty"`
 }
 
 type Cart struct {
 	Items      []CartItem `json:"items"`
 	CustomerID uuid.UUID  `json:"customerId"`
+	// Currency all amounts are priced in; empty means it is taken from the items.
+	Currency Currency `json:"currency,omitempty"`
 }
 
 func (c *Cart) AddItem(item CartItem) {
 	c.Items = append(c.Items, item)
 }
+
+// ResolveCurrency returns the single currency of the cart so money math stays within it.
+// Items without a currency inherit the cart's; any disagreement is a *CurrencyMismatchError.
+// The result is empty only when neither the cart nor any item specifies a currency.
+func (c *Cart) ResolveCurrency() (Currency, error) {
+	currency, err := ParseCurrency(string(c.Currency))
+	if err != nil {
+		return "", fmt.Errorf("cart currency: %w", err)
+	}
+
+	for _, item := range c.Items {
+		itemCurrency, err := ParseCurrency(string(item.Currency))
+		if err != nil {
+			return "", fmt.Errorf("item %s currency: %w", item.GoodID, err)
+		}
+
+		switch {
+		case itemCurrency == "" || itemCurrency == currency:
+		case currency == "":
+			currency = itemCurrency
+		default:
+			return "", &CurrencyMismatchError{Expected: currency, Got: itemCurrency, GoodID: item.GoodID}
+		}
+	}
+
+	return currency, nil
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCart_ResolveCurrency(t *testing.T) {
+	euroItem := CartItem{GoodID: uuid.New(), Currency: "EUR"}
+	dollarItem := CartItem{GoodID: uuid.New(), Currency: "usd"}
+	inheritingItem := CartItem{GoodID: uuid.New()}
+
+	tests := []struct {
+		name string
+		cart Cart
+		want Currency
+	}{
+		{"no currency anywhere", Cart{Items: []CartItem{inheritingItem}}, ""},
+		{"items inherit the cart currency", Cart{Currency: "EUR", Items: []CartItem{inheritingItem, euroItem}}, "EUR"},
+		{"cart currency taken from items", Cart{Items: []CartItem{inheritingItem, dollarItem}}, "USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cart.ResolveCurrency()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCart_ResolveCurrency_Mixed(t *testing.T) {
+	dollarItem := CartItem{GoodID: uuid.New(), Currency: "USD"}
+	cart := Cart{Items: []CartItem{{GoodID: uuid.New(), Currency: "EUR"}, dollarItem}}
+
+	_, err := cart.ResolveCurrency()
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	var mismatch *CurrencyMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, Currency("EUR"), mismatch.Expected)
+	assert.Equal(t, Currency("USD"), mismatch.Got)
+	assert.Equal(t, dollarItem.GoodID, mismatch.GoodID)
+}
+
+func TestParseCurrency(t *testing.T) {
+	currency, err := ParseCurrency(" eur ")
+	require.NoError(t, err)
+	assert.Equal(t, Currency("EUR"), currency)
+
+	for _, code := range []string{"EURO", "E1R", "€"} {
+		_, err = ParseCurrency(code)
+		require.ErrorIs(t, err, ErrInvalidCurrency, code)
+	}
+}
+
+func TestCart_Validate(t *testing.T) {
+	goodID := uuid.New()
+	item := func(quantity int32, price string) CartItem {
+		return CartItem{GoodID: goodID, Quantity: quantity, Price: decimal.RequireFromString(price)}
+	}
+
+	tests := []struct {
+		name    string
+		cart    Cart
+		wantErr error
+	}{
+		{"valid cart", Cart{Items: []CartItem{item(2, "10.50"), item(1, "0")}}, nil},
+		{"empty cart", Cart{}, ErrEmptyCart},
+		{"zero quantity", Cart{Items: []CartItem{item(0, "10")}}, ErrNonPositiveQuantity},
+		{"negative quantity", Cart{Items: []CartItem{item(-1, "10")}}, ErrNonPositiveQuantity},
+		{"negative price", Cart{Items: []CartItem{item(1, "-0.01")}}, ErrNegativePrice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cart.Validate()
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, tt.wantErr)
+			require.ErrorIs(t, err, ErrInvalidCart)
+		})
+	}
+}
+
+func TestCart_Validate_ReportsItem(t *testing.T) {
+	badItem := CartItem{GoodID: uuid.New(), Quantity: -3, Price: decimal.NewFromInt(1)}
+	cart := Cart{Items: []CartItem{{GoodID: uuid.New(), Quantity: 1, Price: decimal.NewFromInt(1)}, badItem}}
+
+	var itemErr *InvalidCartItemError
+	require.ErrorAs(t, cart.Validate(), &itemErr)
+	assert.Equal(t, badItem.GoodID, itemErr.GoodID)
+}
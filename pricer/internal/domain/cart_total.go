@@ -2,9 +2,28 @@ package domain
 
 import "github.com/shopspring/decimal"
 
+// PolicyAmount is the amount one pricing policy contributed to a cart total.
+type PolicyAmount struct {
+	Policy string          `json:"policy"`
+	Amount decimal.Decimal `json:"amount"`
+}
+
+// CartTotal is the priced cart. FinalPrice = Subtotal - TotalDiscount - OrderDiscount + TotalTax.
 type CartTotal struct {
-	TotalTax      decimal.Decimal `json:"totalTax"`
+	// Currency all amounts are in; empty when the cart did not specify one.
+	Currency  Currency        `json:"currency,omitempty"`
+	Subtotal  decimal.Decimal `json:"subtotal"`
+	Discounts []PolicyAmount  `json:"discounts"`
+	Taxes     []PolicyAmount  `json:"taxes"`
+	TotalTax  decimal.Decimal `json:"totalTax"`
+	// TotalDiscount is the sum of the per-item discounts.
 	TotalDiscount decimal.Decimal `json:"totalDiscount"`
+	// OrderDiscounts are the order-level promotions applied on top of the item discounts.
+	OrderDiscounts []PolicyAmount `json:"orderDiscounts"`
+	// OrderDiscount is the sum of OrderDiscounts.
+	OrderDiscount decimal.Decimal `json:"orderDiscount"`
 	FinalPrice    decimal.Decimal `json:"finalPrice"`
 	Policies      []string        `json:"policies"`
+	// TaxExemptionCode is set when no tax was charged because the cart is tax-exempt.
+	TaxExemptionCode string `json:"taxExemptionCode,omitempty"`
 }
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// InvalidCartItemError reports the first cart item that failed validation.
+// It matches its Reason (and therefore ErrInvalidCart) with errors.Is.
+type InvalidCartItemError struct {
+	GoodID uuid.UUID
+	Reason error
+}
+
+func (e *InvalidCartItemError) Error() string {
+	return fmt.Sprintf("item %s: %v", e.GoodID, e.Reason)
+}
+
+func (e *InvalidCartItemError) Unwrap() error {
+	return e.Reason
+}
+
+// Validate rejects carts that cannot be priced: no items, non-positive quantities or negative prices.
+// It runs before policy evaluation so bad input fails loudly instead of pricing to zero.
+func (c *Cart) Validate() error {
+	if len(c.Items) == 0 {
+		return ErrEmptyCart
+	}
+
+	for _, item := range c.Items {
+		if item.Quantity <= 0 {
+			return &InvalidCartItemError{GoodID: item.GoodID, Reason: ErrNonPositiveQuantity}
+		}
+
+		if item.Price.IsNegative() {
+			return &InvalidCartItemError{GoodID: item.GoodID, Reason: ErrNegativePrice}
+		}
+	}
+
+	return nil
+}
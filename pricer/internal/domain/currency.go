@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const currencyCodeLength = 3
+
+var (
+	// ErrInvalidCurrency is returned for codes that are not three-letter ISO-4217 codes.
+	ErrInvalidCurrency = errors.New("invalid ISO-4217 currency code")
+	// ErrCurrencyMismatch is returned when a cart mixes currencies; match it with errors.Is
+	// and use errors.As with *CurrencyMismatchError for the details.
+	ErrCurrencyMismatch = errors.New("cart mixes currencies")
+)
+
+// Currency is an ISO-4217 currency code such as "USD". The zero value means "not specified".
+type Currency string
+
+// ParseCurrency normalizes and validates an ISO-4217 code. An empty code yields the zero Currency.
+func ParseCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return "", nil
+	}
+
+	if len(code) != currencyCodeLength || strings.IndexFunc(code, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+	}
+
+	return Currency(code), nil
+}
+
+// CurrencyMismatchError reports the first item whose currency differs from the cart currency.
+type CurrencyMismatchError struct {
+	Expected Currency
+	Got      Currency
+	GoodID   uuid.UUID
+}
+
+func (e *CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("%s: item %s is priced in %s, cart in %s", ErrCurrencyMismatch, e.GoodID, e.Got, e.Expected)
+}
+
+// Is makes errors.Is(err, ErrCurrencyMismatch) match.
+func (e *CurrencyMismatchError) Is(target error) bool {
+	return target == ErrCurrencyMismatch
+}
@@ -1,9 +1,18 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Domain-level errors for pricer. Use errors.Is/As when mapping to gRPC/HTTP.
 var (
 	// ErrInvalidCart is returned when cart data is invalid (e.g. malformed IDs or prices).
 	ErrInvalidCart = errors.New("invalid cart")
+	// ErrEmptyCart is returned when a cart has no items to price.
+	ErrEmptyCart = fmt.Errorf("%w: cart has no items", ErrInvalidCart)
+	// ErrNonPositiveQuantity is returned when an item quantity is zero or negative.
+	ErrNonPositiveQuantity = fmt.Errorf("%w: item quantity must be positive", ErrInvalidCart)
+	// ErrNegativePrice is returned when an item price is below zero.
+	ErrNegativePrice = fmt.Errorf("%w: item price must not be negative", ErrInvalidCart)
 )
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"maps"
+
+	"github.com/shopspring/decimal"
+)
+
+// DiscountedSubtotalParam is the order discount parameter carrying the cart subtotal left after item discounts.
+// Order-level promotions (e.g. "$10 off orders over $100") are decided on it rather than on the raw subtotal.
+const DiscountedSubtotalParam = "discounted_subtotal"
+
+// OrderDiscountParams returns a copy of the discount params with the discounted subtotal set.
+func OrderDiscountParams(params map[string]any, discountedSubtotal decimal.Decimal) map[string]any {
+	orderParams := make(map[string]any, len(params)+1)
+	maps.Copy(orderParams, params)
+	orderParams[DiscountedSubtotalParam] = discountedSubtotal.InexactFloat64()
+
+	return orderParams
+}
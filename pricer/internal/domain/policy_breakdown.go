@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrPolicyMismatch is returned when per-policy amounts do not add up to the policy total;
+// match it with errors.Is and use errors.As with *PolicyMismatchError for the details.
+var ErrPolicyMismatch = errors.New("policy amounts do not add up to the policy total")
+
+// reconcileTolerance absorbs float64 noise from OPA when comparing the total with its parts.
+var reconcileTolerance = decimal.New(1, -6) //nolint:mnd // 0.000001
+
+// PolicyBreakdown is the result of evaluating one policy kind (discounts or taxes):
+// the total reported by its query and the amount each contributing policy added to it.
+type PolicyBreakdown struct {
+	Total   decimal.Decimal
+	Amounts []PolicyAmount
+}
+
+// Sum adds up the per-policy amounts.
+func (b PolicyBreakdown) Sum() decimal.Decimal {
+	sum := decimal.Zero
+	for _, amount := range b.Amounts {
+		sum = sum.Add(amount.Amount)
+	}
+
+	return sum
+}
+
+// Reconcile returns the sum of the per-policy amounts, or a *PolicyMismatchError when it
+// differs from Total, which means a policy is missing from (or extra in) the breakdown.
+func (b PolicyBreakdown) Reconcile() (decimal.Decimal, error) {
+	sum := b.Sum()
+	if sum.Sub(b.Total).Abs().GreaterThan(reconcileTolerance) {
+		return decimal.Zero, &PolicyMismatchError{Total: b.Total, Sum: sum}
+	}
+
+	return sum, nil
+}
+
+// CapAt returns the amounts trimmed in order so that they add up to at most limit.
+// Policies past the limit are kept with a zero amount so the breakdown lists every policy.
+func (b PolicyBreakdown) CapAt(limit decimal.Decimal) []PolicyAmount {
+	capped := make([]PolicyAmount, 0, len(b.Amounts))
+	remaining := limit
+
+	for _, amount := range b.Amounts {
+		amount.Amount = decimal.Min(amount.Amount, remaining)
+		remaining = remaining.Sub(amount.Amount)
+		capped = append(capped, amount)
+	}
+
+	return capped
+}
+
+// PolicyMismatchError reports a breakdown whose per-policy amounts disagree with the total.
+type PolicyMismatchError struct {
+	Total decimal.Decimal
+	Sum   decimal.Decimal
+}
+
+func (e *PolicyMismatchError) Error() string {
+	return fmt.Sprintf("%s: total %s, policies sum to %s", ErrPolicyMismatch, e.Total, e.Sum)
+}
+
+// Is makes errors.Is(err, ErrPolicyMismatch) match.
+func (e *PolicyMismatchError) Is(target error) bool {
+	return target == ErrPolicyMismatch
+}
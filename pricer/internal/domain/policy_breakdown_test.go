@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyBreakdown_Reconcile(t *testing.T) {
+	breakdown := PolicyBreakdown{
+		Total: decimal.RequireFromString("12.5"),
+		Amounts: []PolicyAmount{
+			{Policy: "quantity", Amount: decimal.RequireFromString("10")},
+			{Policy: "combination", Amount: decimal.RequireFromString("2.5")},
+		},
+	}
+
+	sum, err := breakdown.Reconcile()
+	require.NoError(t, err)
+	assert.True(t, sum.Equal(breakdown.Total))
+
+	breakdown.Amounts = breakdown.Amounts[:1]
+
+	_, err = breakdown.Reconcile()
+	require.ErrorIs(t, err, ErrPolicyMismatch)
+
+	var mismatch *PolicyMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, "10", mismatch.Sum.String())
+}
+
+func TestPolicyBreakdown_CapAt(t *testing.T) {
+	breakdown := PolicyBreakdown{Amounts: []PolicyAmount{
+		{Policy: "quantity", Amount: decimal.NewFromInt(30)},
+		{Policy: "combination", Amount: decimal.NewFromInt(20)},
+	}}
+
+	capped := breakdown.CapAt(decimal.NewFromInt(40))
+	require.Len(t, capped, 2)
+	assert.Equal(t, "30", capped[0].Amount.String())
+	assert.Equal(t, "10", capped[1].Amount.String())
+	assert.Equal(t, "40", PolicyBreakdown{Amounts: capped}.Sum().String())
+}
@@ -13,11 +13,11 @@ type DiscountPolicy struct {
 	Evaluator policy_evaluator.PolicyEvaluator
 }
 
-// Evaluate evaluates the discount policy.
-func (p *DiscountPolicy) Evaluate(ctx context.Context, cart *domain.Cart, params map[string]any) (float64, error) {
+// Evaluate evaluates the discount policy and returns the amount of each discount.
+func (p *DiscountPolicy) Evaluate(ctx context.Context, cart *domain.Cart, params map[string]any) (domain.PolicyBreakdown, error) {
 	v, err := p.Evaluator.Evaluate(ctx, cart, params)
 	if err != nil {
-		return 0, fmt.Errorf("discount policy: %w", err)
+		return domain.PolicyBreakdown{}, fmt.Errorf("discount policy: %w", err)
 	}
 
 	return v, nil
@@ -28,11 +28,31 @@ type TaxPolicy struct {
 	Evaluator policy_evaluator.PolicyEvaluator
 }
 
-// Evaluate evaluates the tax policy.
-func (p *TaxPolicy) Evaluate(ctx context.Context, cart *domain.Cart, params map[string]any) (float64, error) {
+// Evaluate evaluates the tax policy and returns the amount of each tax.
+func (p *TaxPolicy) Evaluate(ctx context.Context, cart *domain.Cart, params map[string]any) (domain.PolicyBreakdown, error) {
 	v, err := p.Evaluator.Evaluate(ctx, cart, params)
 	if err != nil {
-		return 0, fmt.Errorf("tax policy: %w", err)
+		return domain.PolicyBreakdown{}, fmt.Errorf("tax policy: %w", err)
+	}
+
+	return v, nil
+}
+
+// OrderDiscountPolicy wraps a policy evaluator for order-level discounts.
+// A nil OrderDiscountPolicy applies no order discount.
+type OrderDiscountPolicy struct {
+	Evaluator policy_evaluator.PolicyEvaluator
+}
+
+// Evaluate evaluates the order discount policy and returns the amount of each order discount.
+func (p *OrderDiscountPolicy) Evaluate(ctx context.Context, cart *domain.Cart, params map[string]any) (domain.PolicyBreakdown, error) {
+	if p == nil {
+		return domain.PolicyBreakdown{}, nil
+	}
+
+	v, err := p.Evaluator.Evaluate(ctx, cart, params)
+	if err != nil {
+		return domain.PolicyBreakdown{}, fmt.Errorf("order discount policy: %w", err)
 	}
 
 	return v, nil
@@ -0,0 +1,24 @@
+package domain
+
+import "strings"
+
+// TaxCategory is the product category tax policies branch on, e.g. "food" or "electronics".
+// The zero value means "not specified".
+type TaxCategory string
+
+// DefaultTaxCategory is the category of items that do not specify one.
+const DefaultTaxCategory TaxCategory = "standard"
+
+// ParseTaxCategory normalizes a category name to lower case. An empty name yields the zero TaxCategory.
+func ParseTaxCategory(name string) TaxCategory {
+	return TaxCategory(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// OrDefault returns the category, or DefaultTaxCategory when it is not specified.
+func (c TaxCategory) OrDefault() TaxCategory {
+	if c == "" {
+		return DefaultTaxCategory
+	}
+
+	return c
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "errors"
+
+// TaxExemptionParam is the tax parameter carrying a customer's exemption code (e.g. "B2B", "CHARITY").
+// Tax policies charge no tax while it is set.
+const TaxExemptionParam = "tax_exemption_code"
+
+// ErrExemptCartTaxed is returned when a tax policy charged tax although the cart is tax-exempt.
+var ErrExemptCartTaxed = errors.New("tax charged on a tax-exempt cart")
+
+// TaxExemptionCode returns the exemption code in tax params, or "" when the cart is taxable.
+func TaxExemptionCode(params map[string]any) string {
+	code, _ := params[TaxExemptionParam].(string) //nolint:errcheck // a missing or non-string code means taxable
+
+	return code
+}
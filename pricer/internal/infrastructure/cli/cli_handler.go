@@ -3,7 +3,10 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -21,23 +24,51 @@ const (
 // CLIHandler handles command-line interactions
 type CLIHandler struct {
 	calculateTotalHandler *calculate_total.Handler
-	OutputDir             string
+	// OutputDir receives one result file per cart; StdoutOutputDir writes to Stdout instead.
+	OutputDir    string
+	OutputFormat OutputFormat
+	// Stdout is where results go when OutputDir is StdoutOutputDir.
+	Stdout io.Writer
 }
 
 // NewCLIHandler creates a new CLIHandler
-func NewCLIHandler(calculateTotalHandler *calculate_total.Handler, outputDir string) *CLIHandler {
+func NewCLIHandler(calculateTotalHandler *calculate_total.Handler, outputDir string, format OutputFormat) *CLIHandler {
 	return &CLIHandler{
 		calculateTotalHandler: calculateTotalHandler,
 		OutputDir:             outputDir,
+		OutputFormat:          format,
+		Stdout:                os.Stdout,
 	}
 }
 
-// Run processes a single cart file with provided parameters
+// RunAll processes every cart file, continuing past failures.
+// It returns the failures joined; pass the result to ExitCode.
+func (h *CLIHandler) RunAll(cartFiles []string, discountParams, taxParams map[string]any) error {
+	var errs []error
+
+	for _, cartFile := range cartFiles {
+		err := h.Run(cartFile, discountParams, taxParams)
+		if err != nil {
+			slog.Error("CLI processing failed", slog.String("cart_file", cartFile), slog.Any("error", err))
+
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run processes a single cart file with provided parameters.
+// Failures are returned as *CartError.
 func (h *CLIHandler) Run(cartFile string, discountParams, taxParams map[string]any) error {
 	// Load the cart
 	cart, err := loadCart(cartFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return newCartError(cartFile, ErrCartFileNotFound, err)
+	}
+
 	if err != nil {
-		return fmt.Errorf("failed to load cart %s: %w", cartFile, err)
+		return newCartError(cartFile, ErrCartParse, err)
 	}
 
 	// Calculate totals
@@ -45,24 +76,26 @@ func (h *CLIHandler) Run(cartFile string, discountParams, taxParams map[string]a
 
 	total, err := h.calculateTotalHandler.Handle(context.Background(), cmd)
 	if err != nil {
-		return fmt.Errorf("failed to calculate total for cart %s: %w", cartFile, err)
+		return newCartError(cartFile, ErrEvaluation, err)
 	}
 
-	// Prepare the result map
-	result := map[string]any{
-		"customerId":    cart.CustomerID.String(),
-		"totalTax":      total.TotalTax.StringFixed(decimalPlaces),
-		"totalDiscount": total.TotalDiscount.StringFixed(decimalPlaces),
-		"finalPrice":    total.FinalPrice.StringFixed(decimalPlaces),
-		"policies":      total.Policies,
+	result := newCartResult(&cart, &total)
+
+	if h.OutputDir == StdoutOutputDir {
+		err = writeResult(h.Stdout, &result, h.OutputFormat, false)
+		if err != nil {
+			return newCartError(cartFile, ErrWriteResult, err)
+		}
+
+		return nil
 	}
 
 	// Save the result
-	filename := fmt.Sprintf("cart_result_%s.json", cart.CustomerID.String())
+	filename := resultFilename(result.CustomerID, h.OutputFormat)
 
-	err = saveResultToFile(result, h.OutputDir, filename)
+	err = saveResultToFile(&result, h.OutputFormat, h.OutputDir, filename)
 	if err != nil {
-		return fmt.Errorf("failed to save result for cart %s: %w", cartFile, err)
+		return newCartError(cartFile, ErrWriteResult, err)
 	}
 
 	slog.Info("Final result saved", slog.String("path", filepath.Join(h.OutputDir, filename)))
@@ -87,25 +120,3 @@ func loadCart(filePath string) (domain.Cart, error) {
 
 	return cart, nil
 }
-
-// saveResultToFile marshals the result to JSON and writes it to a file
-func saveResultToFile(result map[string]any, outDir, filename string) error {
-	err := os.MkdirAll(outDir, dirMode)
-	if err != nil {
-		return fmt.Errorf("create output dir: %w", err)
-	}
-
-	outputFile := filepath.Join(outDir, filename)
-
-	data, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal result: %w", err)
-	}
-
-	err = os.WriteFile(outputFile, data, outputFileMode)
-	if err != nil {
-		return fmt.Errorf("write result file: %w", err)
-	}
-
-	return nil
-}
@@ -0,0 +1,284 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	logger "github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/pricer/internal/domain"
+	"github.com/shortlink-org/shop/pricer/internal/domain/pricing"
+	"github.com/shortlink-org/shop/pricer/internal/usecases/cart/command/calculate_total"
+)
+
+var (
+	errPolicyBroken = errors.New("policy broken")
+
+	// unpriceableCustomer makes stubEvaluator fail, simulating a policy evaluation error.
+	unpriceableCustomer = uuid.MustParse("00000000-0000-0000-0000-00000000dead")
+)
+
+// stubEvaluator returns a fixed amount for a single policy, or errPolicyBroken for unpriceableCustomer.
+type stubEvaluator struct {
+	policy string
+	amount int64
+}
+
+func (e stubEvaluator) Evaluate(_ context.Context, cart *domain.Cart, _ map[string]any) (domain.PolicyBreakdown, error) {
+	if cart.CustomerID == unpriceableCustomer {
+		return domain.PolicyBreakdown{}, errPolicyBroken
+	}
+
+	amount := decimal.NewFromInt(e.amount)
+
+	return domain.PolicyBreakdown{Total: amount, Amounts: []domain.PolicyAmount{{Policy: e.policy, Amount: amount}}}, nil
+}
+
+func (stubEvaluator) Reload(context.Context) error { return nil }
+
+func (stubEvaluator) Close() {}
+
+func newTestCLIHandler(t *testing.T, outputDir string) *CLIHandler {
+	t.Helper()
+
+	return newTestCLIHandlerWithFormat(t, outputDir, OutputFormatJSON)
+}
+
+func newTestCLIHandlerWithFormat(t *testing.T, outputDir string, format OutputFormat) *CLIHandler {
+	t.Helper()
+
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	handler, err := calculate_total.NewHandler(
+		log,
+		&pricing.DiscountPolicy{Evaluator: stubEvaluator{policy: "pricing.discount.total_discount", amount: 10}},
+		&pricing.TaxPolicy{Evaluator: stubEvaluator{policy: "pricing.tax.total_markup", amount: 5}},
+		nil,
+		[]string{"stub"},
+	)
+	require.NoError(t, err)
+
+	return NewCLIHandler(handler, outputDir, format)
+}
+
+func writeCartFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestCLIHandler_RunAll_MixedCartFiles(t *testing.T) {
+	inputDir := t.TempDir()
+	outputDir := t.TempDir()
+	handler := newTestCLIHandler(t, outputDir)
+
+	valid := writeCartFile(t, inputDir, "valid.json", `{
+		"customerId": "8e539bb4-5800-4b9d-88d1-9d7df0a8a5a0",
+		"items": [{"productId": "cfd8f5e0-5897-474b-b5d4-bef2a8c9cf87", "quantity": 2, "price": 50}]
+	}`)
+	malformed := writeCartFile(t, inputDir, "malformed.json", `{"customerId": `)
+	unpriceable := writeCartFile(t, inputDir, "unpriceable.json", `{
+		"customerId": "`+unpriceableCustomer.String()+`",
+		"items": [{"productId": "cfd8f5e0-5897-474b-b5d4-bef2a8c9cf87", "quantity": 1, "price": 10}]
+	}`)
+	missing := filepath.Join(inputDir, "missing.json")
+
+	err := handler.RunAll([]string{valid, missing, malformed, unpriceable}, nil, nil)
+	require.Error(t, err)
+
+	// The valid cart is still processed.
+	assert.FileExists(t, filepath.Join(outputDir, "cart_result_8e539bb4-5800-4b9d-88d1-9d7df0a8a5a0.json"))
+
+	require.ErrorIs(t, err, ErrCartFileNotFound)
+	require.ErrorIs(t, err, ErrCartParse)
+	require.ErrorIs(t, err, ErrEvaluation)
+	require.ErrorIs(t, err, errPolicyBroken)
+	assert.NotErrorIs(t, err, ErrWriteResult)
+
+	assert.Equal(t, exitCodeFileNotFound|exitCodeParse|exitCodeEvaluation, ExitCode(err))
+}
+
+func TestCLIHandler_Run_TypedErrors(t *testing.T) {
+	inputDir := t.TempDir()
+	handler := newTestCLIHandler(t, t.TempDir())
+
+	tests := []struct {
+		name     string
+		cartFile string
+		wantKind error
+		wantCode int
+	}{
+		{"missing file", filepath.Join(inputDir, "missing.json"), ErrCartFileNotFound, exitCodeFileNotFound},
+		{"invalid JSON", writeCartFile(t, inputDir, "invalid.json", `[]`), ErrCartParse, exitCodeParse},
+		{"evaluation error", writeCartFile(t, inputDir, "unpriceable.json", `{"customerId": "`+unpriceableCustomer.String()+`"}`), ErrEvaluation, exitCodeEvaluation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler.Run(tt.cartFile, nil, nil)
+			require.ErrorIs(t, err, tt.wantKind)
+
+			var cartErr *CartError
+			require.ErrorAs(t, err, &cartErr)
+			assert.Equal(t, tt.cartFile, cartErr.CartFile)
+			assert.Equal(t, tt.wantCode, ExitCode(err))
+		})
+	}
+}
+
+func TestCLIHandler_RunAll_AllValid(t *testing.T) {
+	inputDir := t.TempDir()
+	handler := newTestCLIHandler(t, t.TempDir())
+
+	valid := writeCartFile(t, inputDir, "valid.json", `{
+		"customerId": "8e539bb4-5800-4b9d-88d1-9d7df0a8a5a0",
+		"items": [{"productId": "cfd8f5e0-5897-474b-b5d4-bef2a8c9cf87", "quantity": 1, "price": 20}]
+	}`)
+
+	err := handler.RunAll([]string{valid}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ExitCodeOK, ExitCode(err))
+}
+
+func TestExitCode_UnclassifiedError(t *testing.T) {
+	assert.Equal(t, exitCodeUnknown, ExitCode(errors.New("boom")))
+}
+
+const validCart = `{
+	"customerId": "8e539bb4-5800-4b9d-88d1-9d7df0a8a5a0",
+	"items": [{"productId": "cfd8f5e0-5897-474b-b5d4-bef2a8c9cf87", "quantity": 2, "price": 50}]
+}`
+
+func TestCLIHandler_Run_JSONFileRoundTrips(t *testing.T) {
+	outputDir := t.TempDir()
+	handler := newTestCLIHandler(t, outputDir)
+
+	cartFile := writeCartFile(t, t.TempDir(), "valid.json", validCart)
+	require.NoError(t, handler.Run(cartFile, nil, nil))
+
+	data, err := os.ReadFile(filepath.Join(outputDir, "cart_result_8e539bb4-5800-4b9d-88d1-9d7df0a8a5a0.json"))
+	require.NoError(t, err)
+
+	var result CartResult
+	require.NoError(t, json.Unmarshal(data, &result))
+
+	assert.Equal(t, CartResult{
+		CustomerID:    "8e539bb4-5800-4b9d-88d1-9d7df0a8a5a0",
+		Subtotal:      "100.00",
+		Discounts:     []PolicyAmountResult{{Policy: "pricing.discount.total_discount", Amount: "10.00"}},
+		Taxes:         []PolicyAmountResult{{Policy: "pricing.tax.total_markup", Amount: "5.00"}},
+		TotalDiscount: "10.00",
+		OrderDiscount: "0.00",
+		TotalTax:      "5.00",
+		FinalPrice:    "95.00",
+		Policies:      []string{"stub"},
+	}, result)
+
+	// Amounts are decimal strings, not JSON numbers.
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.IsType(t, "", raw["subtotal"])
+	assert.IsType(t, "", raw["finalPrice"])
+}
+
+func TestCLIHandler_Run_StdoutDoesNotCreateFiles(t *testing.T) {
+	workDir := t.TempDir()
+	t.Chdir(workDir)
+
+	handler := newTestCLIHandler(t, StdoutOutputDir)
+
+	var stdout bytes.Buffer
+	handler.Stdout = &stdout
+
+	cartFile := writeCartFile(t, t.TempDir(), "valid.json", validCart)
+	require.NoError(t, handler.RunAll([]string{cartFile, cartFile}, nil, nil))
+
+	entries, err := os.ReadDir(workDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "stdout mode must not create files")
+
+	// One compact JSON object per cart, so the output can be piped line by line.
+	decoder := json.NewDecoder(&stdout)
+
+	for range 2 {
+		var result CartResult
+		require.NoError(t, decoder.Decode(&result))
+		assert.Equal(t, "95.00", result.FinalPrice)
+	}
+
+	assert.False(t, decoder.More())
+}
+
+func TestCLIHandler_Run_TextFormat(t *testing.T) {
+	handler := newTestCLIHandlerWithFormat(t, StdoutOutputDir, OutputFormatText)
+
+	var stdout bytes.Buffer
+	handler.Stdout = &stdout
+
+	cartFile := writeCartFile(t, t.TempDir(), "valid.json", validCart)
+	require.NoError(t, handler.Run(cartFile, nil, nil))
+
+	assert.Contains(t, stdout.String(), "subtotal:       100.00")
+	assert.Contains(t, stdout.String(), "-10.00 (pricing.discount.total_discount)")
+	assert.Contains(t, stdout.String(), "final price:    95.00")
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	format, err := ParseOutputFormat(" JSON ")
+	require.NoError(t, err)
+	assert.Equal(t, OutputFormatJSON, format)
+
+	format, err = ParseOutputFormat("text")
+	require.NoError(t, err)
+	assert.Equal(t, OutputFormatText, format)
+
+	_, err = ParseOutputFormat("yaml")
+	require.ErrorIs(t, err, ErrUnknownOutputFormat)
+}
+
+func TestCLIHandler_Run_EchoesCurrency(t *testing.T) {
+	handler := newTestCLIHandler(t, StdoutOutputDir)
+
+	var stdout bytes.Buffer
+	handler.Stdout = &stdout
+
+	cartFile := writeCartFile(t, t.TempDir(), "eur.json", `{
+		"customerId": "8e539bb4-5800-4b9d-88d1-9d7df0a8a5a0",
+		"currency": "EUR",
+		"items": [{"productId": "cfd8f5e0-5897-474b-b5d4-bef2a8c9cf87", "quantity": 2, "price": 50}]
+	}`)
+	require.NoError(t, handler.Run(cartFile, nil, nil))
+
+	var result CartResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	assert.Equal(t, "EUR", result.Currency)
+}
+
+func TestCLIHandler_Run_MixedCurrencies(t *testing.T) {
+	handler := newTestCLIHandler(t, t.TempDir())
+
+	cartFile := writeCartFile(t, t.TempDir(), "mixed.json", `{
+		"customerId": "8e539bb4-5800-4b9d-88d1-9d7df0a8a5a0",
+		"items": [
+			{"productId": "cfd8f5e0-5897-474b-b5d4-bef2a8c9cf87", "quantity": 1, "price": 10, "currency": "EUR"},
+			{"productId": "0b5bd5e4-8b7a-4d8e-9f6c-91ab6a0a7b2d", "quantity": 1, "price": 10, "currency": "USD"}
+		]
+	}`)
+
+	err := handler.Run(cartFile, nil, nil)
+	require.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+	require.ErrorIs(t, err, ErrEvaluation)
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Failure classes of CLIHandler.Run. Use errors.Is to tell them apart and errors.As with *CartError
+// to get the failing cart file.
+var (
+	ErrCartFileNotFound = errors.New("cart file not found")
+	ErrCartParse        = errors.New("cart file could not be parsed")
+	ErrEvaluation       = errors.New("cart total could not be evaluated")
+	ErrWriteResult      = errors.New("cart result could not be written")
+)
+
+// Exit code bits per failure class. ExitCode ORs the bits of every class present,
+// so a script can tell e.g. "some files missing" from "some files malformed".
+const (
+	ExitCodeOK           = 0
+	exitCodeUnknown      = 1
+	exitCodeFileNotFound = 1 << 1
+	exitCodeParse        = 1 << 2
+	exitCodeEvaluation   = 1 << 3
+	exitCodeWriteResult  = 1 << 4
+)
+
+// CartError is returned by CLIHandler.Run when processing a cart file fails.
+// It matches its failure class and the underlying cause with errors.Is.
+type CartError struct {
+	CartFile string
+	Kind     error
+	Err      error
+}
+
+func newCartError(cartFile string, kind, err error) *CartError {
+	return &CartError{CartFile: cartFile, Kind: kind, Err: err}
+}
+
+func (e *CartError) Error() string {
+	return fmt.Sprintf("%s: %s: %v", e.CartFile, e.Kind, e.Err)
+}
+
+func (e *CartError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// ExitCode maps an (aggregated) error from CLIHandler.Run or RunAll to a process exit code.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeOK
+	}
+
+	code := 0
+
+	for kind, bit := range map[error]int{
+		ErrCartFileNotFound: exitCodeFileNotFound,
+		ErrCartParse:        exitCodeParse,
+		ErrEvaluation:       exitCodeEvaluation,
+		ErrWriteResult:      exitCodeWriteResult,
+	} {
+		if errors.Is(err, kind) {
+			code |= bit
+		}
+	}
+
+	if code == 0 {
+		return exitCodeUnknown
+	}
+
+	return code
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shortlink-org/shop/pricer/internal/domain"
+)
+
+// StdoutOutputDir is the OutputDir sentinel that writes results to stdout instead of files,
+// so the CLI can be piped.
+const StdoutOutputDir = "-"
+
+// OutputFormat selects how cart results are rendered.
+type OutputFormat string
+
+const (
+	// OutputFormatJSON renders a CartResult as JSON (indented in files, one object per line on stdout).
+	OutputFormatJSON OutputFormat = "json"
+	// OutputFormatText renders a human-readable summary.
+	OutputFormatText OutputFormat = "text"
+)
+
+// ErrUnknownOutputFormat is returned by ParseOutputFormat for anything but "json" or "text".
+var ErrUnknownOutputFormat = errors.New("unknown output format")
+
+// ParseOutputFormat validates a configured output format.
+func ParseOutputFormat(value string) (OutputFormat, error) {
+	switch format := OutputFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case OutputFormatJSON, OutputFormatText:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w: %q (want %q or %q)", ErrUnknownOutputFormat, value, OutputFormatJSON, OutputFormatText)
+	}
+}
+
+// PolicyAmountResult is one policy's contribution, with the amount as a decimal string.
+type PolicyAmountResult struct {
+	Policy string `json:"policy"`
+	Amount string `json:"amount"`
+}
+
+// CartResult is the machine-readable result of pricing one cart. Amounts are decimal strings
+// rounded to two places so consumers never see float rounding artifacts.
+type CartResult struct {
+	CustomerID    string               `json:"customerId"`
+	Currency      string               `json:"currency,omitempty"`
+	Subtotal      string               `json:"subtotal"`
+	Discounts     []PolicyAmountResult `json:"discounts"`
+	Taxes         []PolicyAmountResult `json:"taxes"`
+	TotalDiscount string               `json:"totalDiscount"`
+	// OrderDiscounts are the order-level promotions, applied after the item discounts.
+	OrderDiscounts []PolicyAmountResult `json:"orderDiscounts,omitempty"`
+	OrderDiscount  string               `json:"orderDiscount"`
+	TotalTax       string               `json:"totalTax"`
+	FinalPrice     string               `json:"finalPrice"`
+	Policies       []string             `json:"policies"`
+	// TaxExemptionCode is set when no tax was charged because the cart is tax-exempt.
+	TaxExemptionCode string `json:"taxExemptionCode,omitempty"`
+}
+
+func newCartResult(cart *domain.Cart, total *domain.CartTotal) CartResult {
+	return CartResult{
+		CustomerID:       cart.CustomerID.String(),
+		Currency:         string(total.Currency),
+		Subtotal:         total.Subtotal.StringFixed(decimalPlaces),
+		Discounts:        newPolicyAmountResults(total.Discounts),
+		Taxes:            newPolicyAmountResults(total.Taxes),
+		TotalDiscount:    total.TotalDiscount.StringFixed(decimalPlaces),
+		OrderDiscounts:   newOrderDiscountResults(total.OrderDiscounts),
+		OrderDiscount:    total.OrderDiscount.StringFixed(decimalPlaces),
+		TotalTax:         total.TotalTax.StringFixed(decimalPlaces),
+		FinalPrice:       total.FinalPrice.StringFixed(decimalPlaces),
+		Policies:         total.Policies,
+		TaxExemptionCode: total.TaxExemptionCode,
+	}
+}
+
+func newPolicyAmountResults(amounts []domain.PolicyAmount) []PolicyAmountResult {
+	results := make([]PolicyAmountResult, 0, len(amounts))
+
+	for _, amount := range amounts {
+		results = append(results, PolicyAmountResult{
+			Policy: amount.Policy,
+			Amount: amount.Amount.StringFixed(decimalPlaces),
+		})
+	}
+
+	return results
+}
+
+// newOrderDiscountResults converts order discounts; nil when none applied, so they are left out of the output.
+func newOrderDiscountResults(amounts []domain.PolicyAmount) []PolicyAmountResult {
+	if len(amounts) == 0 {
+		return nil
+	}
+
+	return newPolicyAmountResults(amounts)
+}
+
+// writeResult renders the result to w in the given format.
+// Indented JSON is used for files; compact JSON keeps stdout output one object per line.
+func writeResult(w io.Writer, result *CartResult, format OutputFormat, indent bool) error {
+	if format == OutputFormatText {
+		return writeTextResult(w, result)
+	}
+
+	encoder := json.NewEncoder(w)
+	if indent {
+		encoder.SetIndent("", "  ")
+	}
+
+	err := encoder.Encode(result)
+	if err != nil {
+		return fmt.Errorf("marshal result: %w", err)
+	}
+
+	return nil
+}
+
+func writeTextResult(w io.Writer, result *CartResult) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "customer: %s\n", result.CustomerID)
+
+	if result.Currency != "" {
+		fmt.Fprintf(&b, "  currency:       %s\n", result.Currency)
+	}
+
+	fmt.Fprintf(&b, "  subtotal:       %s\n", result.Subtotal)
+
+	for _, discount := range result.Discounts {
+		fmt.Fprintf(&b, "  discount:       -%s (%s)\n", discount.Amount, discount.Policy)
+	}
+
+	for _, discount := range result.OrderDiscounts {
+		fmt.Fprintf(&b, "  order discount: -%s (%s)\n", discount.Amount, discount.Policy)
+	}
+
+	for _, tax := range result.Taxes {
+		fmt.Fprintf(&b, "  tax:            +%s (%s)\n", tax.Amount, tax.Policy)
+	}
+
+	fmt.Fprintf(&b, "  total discount: %s\n", result.TotalDiscount)
+
+	fmt.Fprintf(&b, "  total tax:      %s\n", result.TotalTax)
+
+	if result.TaxExemptionCode != "" {
+		fmt.Fprintf(&b, "  tax exempt:     %s\n", result.TaxExemptionCode)
+	}
+
+	fmt.Fprintf(&b, "  final price:    %s\n", result.FinalPrice)
+
+	_, err := io.WriteString(w, b.String())
+	if err != nil {
+		return fmt.Errorf("write text result: %w", err)
+	}
+
+	return nil
+}
+
+// resultFilename is the per-customer result file name in OutputDir.
+func resultFilename(customerID string, format OutputFormat) string {
+	extension := "json"
+	if format == OutputFormatText {
+		extension = "txt"
+	}
+
+	return fmt.Sprintf("cart_result_%s.%s", customerID, extension)
+}
+
+// saveResultToFile renders the result and writes it to outDir/filename.
+func saveResultToFile(result *CartResult, format OutputFormat, outDir, filename string) error {
+	err := os.MkdirAll(outDir, dirMode)
+	if err != nil {
+		return fmt.Errorf("create output dir: %w", err)
+	}
+
+	var b strings.Builder
+
+	err = writeResult(&b, result, format, true)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filepath.Join(outDir, filename), []byte(b.String()), outputFileMode)
+	if err != nil {
+		return fmt.Errorf("write result file: %w", err)
+	}
+
+	return nil
+}
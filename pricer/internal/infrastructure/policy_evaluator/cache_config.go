@@ -0,0 +1,36 @@
+package policy_evaluator
+
+import (
+	"fmt"
+	"time"
+)
+
+// CacheConfig sizes the L1 cache of evaluation results.
+type CacheConfig struct {
+	NumCounters int64         // keys tracked for admission, ~10x the expected number of entries
+	MaxCost     int64         // total cost the cache may hold; every result costs 1
+	TTL         time.Duration // how long a result is served before the policies are evaluated again
+}
+
+// DefaultCacheConfig returns the cache sizing used when none is configured.
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		NumCounters: cacheNumCounters,
+		MaxCost:     cacheMaxCost,
+		TTL:         cacheTTL,
+	}
+}
+
+// Validate checks that every setting is positive.
+func (c CacheConfig) Validate() error {
+	switch {
+	case c.NumCounters <= 0:
+		return fmt.Errorf("%w: num counters must be positive, got %d", ErrInvalidCacheConfig, c.NumCounters)
+	case c.MaxCost <= 0:
+		return fmt.Errorf("%w: max cost must be positive, got %d", ErrInvalidCacheConfig, c.MaxCost)
+	case c.TTL <= 0:
+		return fmt.Errorf("%w: ttl must be positive, got %s", ErrInvalidCacheConfig, c.TTL)
+	default:
+		return nil
+	}
+}
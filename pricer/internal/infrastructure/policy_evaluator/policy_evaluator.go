@@ -10,12 +10,14 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
-	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/ristretto/v2"
 	"github.com/open-policy-agent/opa/rego" //nolint:staticcheck // SA1019: legacy OPA API for v0.x compatibility
+	"github.com/shopspring/decimal"
 	logger "github.com/shortlink-org/go-sdk/logger"
 
 	"github.com/shortlink-org/shop/pricer/internal/domain"
@@ -28,12 +30,13 @@ var (
 	ErrOPAResultInvalidNum     = errors.New("invalid json.Number format for OPA result")
 	ErrOPAResultUnexpectedType = errors.New("unexpected type for OPA result")
 	ErrListRegoFiles           = errors.New("failed to list .rego files")
+	ErrInvalidCacheConfig      = errors.New("invalid evaluation cache config")
 )
 
 const (
-	// Cache configuration for OPA evaluation results
+	// Default cache configuration for OPA evaluation results (see CacheConfig)
 	cacheNumCounters = 10_000    // track 10k evaluations
-	cacheMaxCost     = 1_000_000 // ~1MB (results are small float64 values)
+	cacheMaxCost     = 1_000_000 // ~1MB (results are small per-policy breakdowns)
 	cacheBufferItems = 64
 	cacheTTL         = 30 * time.Minute // pricing rules don't change frequently
 )
@@ -42,24 +45,54 @@ const (
 //
 //nolint:iface // interface is implemented by OPAEvaluator and used by DI
 type PolicyEvaluator interface {
-	Evaluate(ctx context.Context, cart *domain.Cart, params map[string]any) (float64, error)
+	Evaluate(ctx context.Context, cart *domain.Cart, params map[string]any) (domain.PolicyBreakdown, error)
+	Reload(ctx context.Context) error
 	Close()
 }
 
 // OPAEvaluator implements the PolicyEvaluator interface using OPA's rego package
 // with L1 Ristretto cache for evaluation results.
 type OPAEvaluator struct {
+	// mu guards preparedQuery, policies and the cache contents, which Reload replaces
+	mu            sync.RWMutex
 	preparedQuery rego.PreparedEvalQuery
 	query         string
+	policyQueries []string
+	policies      []preparedPolicy
 	policyPath    string
-	cache         *ristretto.Cache[string, float64]
+	cache         *ristretto.Cache[string, domain.PolicyBreakdown]
+	cacheTTL      time.Duration
 }
 
-func NewOPAEvaluator(log logger.Logger, policyPath, query string) (*OPAEvaluator, error) {
+// preparedPolicy is one per-policy query contributing to the evaluator's total.
+type preparedPolicy struct {
+	name  string
+	query rego.PreparedEvalQuery
+}
+
+// NewOPAEvaluator prepares query for evaluation. policyQueries are the per-policy queries whose
+// amounts make up the result of query; without them query is reported as a single policy.
+// Results are cached with DefaultCacheConfig.
+func NewOPAEvaluator(log logger.Logger, policyPath, query string, policyQueries ...string) (*OPAEvaluator, error) {
+	return NewOPAEvaluatorWithCache(log, DefaultCacheConfig(), policyPath, query, policyQueries...)
+}
+
+// NewOPAEvaluatorWithCache is NewOPAEvaluator with the result cache sized by cacheConfig.
+func NewOPAEvaluatorWithCache(
+	log logger.Logger,
+	cacheConfig CacheConfig,
+	policyPath, query string,
+	policyQueries ...string,
+) (*OPAEvaluator, error) { //nolint:whitespace // multi-line signature; gofumpt prefers no blank after brace
+	if err := cacheConfig.Validate(); err != nil {
+		return nil, err
+	}
+
 	// Log the policy path and query
 	log.Info("Initializing OPA evaluator",
 		slog.String("policy_path", policyPath),
 		slog.String("query", query),
+		slog.Any("policy_queries", policyQueries),
 	)
 
 	// Check if the policy directory exists
@@ -68,21 +101,15 @@ func NewOPAEvaluator(log logger.Logger, policyPath, query string) (*OPAEvaluator
 		return nil, fmt.Errorf("%s: %w", policyPath, ErrPolicyDirNotExist)
 	}
 
-	// Prepare the query
-	r := rego.New(
-		rego.Query(query),
-		rego.Load([]string{policyPath}, nil),
-	)
-
-	preparedQuery, err := r.PrepareForEval(context.Background())
+	preparedQuery, policies, err := preparePolicies(context.Background(), policyPath, query, policyQueries)
 	if err != nil {
-		return nil, fmt.Errorf("failed to prepare OPA query: %w", err)
+		return nil, err
 	}
 
 	// Initialize L1 cache
-	cache, err := ristretto.NewCache(&ristretto.Config[string, float64]{
-		NumCounters: cacheNumCounters,
-		MaxCost:     cacheMaxCost,
+	cache, err := ristretto.NewCache(&ristretto.Config[string, domain.PolicyBreakdown]{
+		NumCounters: cacheConfig.NumCounters,
+		MaxCost:     cacheConfig.MaxCost,
 		BufferItems: cacheBufferItems,
 	})
 	if err != nil {
@@ -92,11 +119,70 @@ func NewOPAEvaluator(log logger.Logger, policyPath, query string) (*OPAEvaluator
 	return &OPAEvaluator{
 		preparedQuery: preparedQuery,
 		query:         query,
+		policyQueries: policyQueries,
+		policies:      policies,
 		policyPath:    policyPath,
 		cache:         cache,
+		cacheTTL:      cacheConfig.TTL,
 	}, nil
 }
 
+// preparePolicies loads the policy directory and prepares query and the per-policy queries.
+func preparePolicies(ctx context.Context, policyPath, query string, policyQueries []string) (rego.PreparedEvalQuery, []preparedPolicy, error) {
+	preparedQuery, err := prepareQuery(ctx, policyPath, query)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, nil, err
+	}
+
+	policies := make([]preparedPolicy, 0, len(policyQueries))
+	for _, policyQuery := range policyQueries {
+		prepared, err := prepareQuery(ctx, policyPath, policyQuery)
+		if err != nil {
+			return rego.PreparedEvalQuery{}, nil, err
+		}
+
+		policies = append(policies, preparedPolicy{name: PolicyName(policyQuery), query: prepared})
+	}
+
+	return preparedQuery, policies, nil
+}
+
+func prepareQuery(ctx context.Context, policyPath, query string) (rego.PreparedEvalQuery, error) {
+	r := rego.New(
+		rego.Query(query),
+		rego.Load([]string{policyPath}, nil),
+	)
+
+	preparedQuery, err := r.PrepareForEval(ctx)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("failed to prepare OPA query %q: %w", query, err)
+	}
+
+	return preparedQuery, nil
+}
+
+// PolicyName derives a breakdown name from an OPA query ("data.pricing.tax.total_markup" -> "pricing.tax.total_markup").
+func PolicyName(query string) string {
+	return strings.TrimPrefix(query, "data.")
+}
+
+// Reload re-reads the policy directory and re-prepares every query. On error the policies
+// loaded before keep serving; on success cached results of the old policies are dropped.
+func (e *OPAEvaluator) Reload(ctx context.Context) error {
+	preparedQuery, policies, err := preparePolicies(ctx, e.policyPath, e.query, e.policyQueries)
+	if err != nil {
+		return fmt.Errorf("reload %s: %w", e.policyPath, err)
+	}
+
+	e.mu.Lock()
+	e.preparedQuery = preparedQuery
+	e.policies = policies
+	e.cache.Clear()
+	e.mu.Unlock()
+
+	return nil
+}
+
 // Close closes the evaluator and releases resources.
 func (e *OPAEvaluator) Close() {
 	if e.cache != nil {
@@ -104,9 +190,18 @@ func (e *OPAEvaluator) Close() {
 	}
 }
 
-// Evaluate executes the OPA policy against the provided cart and parameters.
-// Uses L1 cache to avoid re-evaluating identical inputs.
-func (e *OPAEvaluator) Evaluate(ctx context.Context, cart *domain.Cart, params map[string]any) (float64, error) {
+// Evaluate executes the OPA policy against the provided cart and parameters and returns
+// the total together with the amount of each policy. Uses L1 cache to avoid re-evaluating identical inputs.
+// A cancelled or expired ctx aborts the evaluation with an error wrapping ctx.Err().
+func (e *OPAEvaluator) Evaluate(ctx context.Context, cart *domain.Cart, params map[string]any) (domain.PolicyBreakdown, error) {
+	if err := contextError(ctx); err != nil {
+		return domain.PolicyBreakdown{}, err
+	}
+
+	// Held until the result is cached so a concurrent Reload cannot leave results of old policies behind
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	// Generate cache key from cart and params
 	cacheKey := e.generateCacheKey(cart, params)
 
@@ -118,8 +213,45 @@ func (e *OPAEvaluator) Evaluate(ctx context.Context, cart *domain.Cart, params m
 	// Cache miss - evaluate the policy
 	input := transformCartToInput(cart, params)
 
-	resultSet, err := e.preparedQuery.Eval(ctx, rego.EvalInput(input))
+	total, err := evalQuery(ctx, e.preparedQuery, input)
+	if err != nil {
+		return domain.PolicyBreakdown{}, err
+	}
+
+	result := domain.PolicyBreakdown{Total: decimal.NewFromFloat(total)}
+
+	if len(e.policies) == 0 {
+		result.Amounts = []domain.PolicyAmount{{Policy: PolicyName(e.query), Amount: result.Total}}
+	}
+
+	for _, policy := range e.policies {
+		if err := contextError(ctx); err != nil {
+			return domain.PolicyBreakdown{}, err
+		}
+
+		amount, err := evalQuery(ctx, policy.query, input)
+		if err != nil {
+			return domain.PolicyBreakdown{}, fmt.Errorf("%s: %w", policy.name, err)
+		}
+
+		result.Amounts = append(result.Amounts, domain.PolicyAmount{Policy: policy.name, Amount: decimal.NewFromFloat(amount)})
+	}
+
+	// Store in L1 cache (cost=1 since a breakdown is small)
+	e.cache.SetWithTTL(cacheKey, result, 1, e.cacheTTL)
+
+	return result, nil
+}
+
+// evalQuery evaluates a prepared query that yields a single number; no result counts as 0.
+func evalQuery(ctx context.Context, query rego.PreparedEvalQuery, input map[string]any) (float64, error) {
+	resultSet, err := query.Eval(ctx, rego.EvalInput(input))
 	if err != nil {
+		// OPA reports a cancelled evaluation with its own error type; surface the context error instead
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			return 0.0, ctxErr
+		}
+
 		return 0.0, fmt.Errorf("OPA evaluation error: %w", err)
 	}
 
@@ -128,17 +260,16 @@ func (e *OPAEvaluator) Evaluate(ctx context.Context, cart *domain.Cart, params m
 	}
 
 	// Assuming the policy returns a single value
-	expr := resultSet[0].Expressions[0].Value
+	return parseOPAResult(resultSet[0].Expressions[0].Value)
+}
 
-	result, err := parseOPAResult(expr)
-	if err != nil {
-		return 0.0, err
+// contextError wraps the error of a cancelled or expired ctx; nil while ctx is live.
+func contextError(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("policy evaluation aborted: %w", err)
 	}
 
-	// Store in L1 cache (cost=1 since float64 is small)
-	e.cache.SetWithTTL(cacheKey, result, 1, cacheTTL)
-
-	return result, nil
+	return nil
 }
 
 // generateCacheKey creates a deterministic hash key from cart and params.
@@ -154,24 +285,26 @@ func (e *OPAEvaluator) generateCacheKey(cart *domain.Cart, params map[string]any
 		_, _ = hasher.Write([]byte(item.GoodID.String()))
 		_, _ = fmt.Fprintf(hasher, "%d", item.Quantity) //nolint:errcheck // hash write best-effort
 		_, _ = hasher.Write([]byte(item.Price.String()))
+		_, _ = hasher.Write([]byte(item.TaxCategory.OrDefault()))
 	}
 
-	// Hash params in sorted order for determinism
+	// Hash params as canonical JSON: map keys are sorted at every nesting level
 	if params != nil {
-		keys := make([]string, 0, len(params))
-		for k := range params {
-			keys = append(keys, k)
-		}
+		_, _ = hasher.Write(canonicalParams(params))
+	}
 
-		sort.Strings(keys)
+	return hex.EncodeToString(hasher.Sum(nil))
+}
 
-		for _, k := range keys {
-			_, _ = hasher.Write([]byte(k))
-			_, _ = fmt.Fprintf(hasher, "%v", params[k]) //nolint:errcheck // hash write best-effort
-		}
+// canonicalParams serializes params deterministically. encoding/json sorts map keys recursively;
+// values JSON cannot encode fall back to fmt, which also prints maps in sorted key order.
+func canonicalParams(params map[string]any) []byte {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Appendf(nil, "%#v", params)
 	}
 
-	return hex.EncodeToString(hasher.Sum(nil))
+	return encoded
 }
 
 // transformCartToInput converts the domain.Cart to the input format expected by OPA
@@ -179,9 +312,10 @@ func transformCartToInput(cart *domain.Cart, params map[string]any) map[string]a
 	items := make([]map[string]any, 0, len(cart.Items))
 	for _, item := range cart.Items {
 		items = append(items, map[string]any{
-			"productId": item.GoodID.String(),
-			"quantity":  item.Quantity,
-			"price":     item.Price.InexactFloat64(),
+			"productId":   item.GoodID.String(),
+			"quantity":    item.Quantity,
+			"price":       item.Price.InexactFloat64(),
+			"taxCategory": string(item.TaxCategory.OrDefault()),
 		})
 	}
 
@@ -240,3 +374,47 @@ func GetPolicyNames(dirs ...string) ([]string, error) {
 
 	return policyNames, nil
 }
+
+// PolicyFile describes a loaded .rego file so operators can tell which revision is active.
+type PolicyFile struct {
+	Name    string
+	Path    string
+	SHA256  string
+	ModTime time.Time
+}
+
+// GetPolicyFiles returns the .rego files of the specified directories with their content hash and modification time.
+func GetPolicyFiles(dirs ...string) ([]PolicyFile, error) {
+	var policyFiles []PolicyFile
+
+	for _, dir := range dirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.rego"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w: %w", dir, ErrListRegoFiles, err)
+		}
+
+		for _, file := range files {
+			info, err := os.Stat(file)
+			if err != nil {
+				return nil, fmt.Errorf("stat policy file: %w", err)
+			}
+
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("read policy file: %w", err)
+			}
+
+			sum := sha256.Sum256(content)
+			base := filepath.Base(file)
+
+			policyFiles = append(policyFiles, PolicyFile{
+				Name:    base[:len(base)-len(filepath.Ext(base))],
+				Path:    file,
+				SHA256:  hex.EncodeToString(sum[:]),
+				ModTime: info.ModTime(),
+			})
+		}
+	}
+
+	return policyFiles, nil
+}
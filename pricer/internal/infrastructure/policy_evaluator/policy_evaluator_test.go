@@ -0,0 +1,223 @@
+package policy_evaluator
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	logger "github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/pricer/internal/domain"
+)
+
+const discountPolicies = "../../../policies/discounts"
+
+func testCart() *domain.Cart {
+	return &domain.Cart{
+		CustomerID: uuid.New(),
+		Items: []domain.CartItem{
+			{GoodID: uuid.New(), Quantity: 3, Price: decimal.NewFromInt(100)},
+			{GoodID: uuid.New(), Quantity: 1, Price: decimal.NewFromInt(50)},
+		},
+	}
+}
+
+func TestOPAEvaluator_Evaluate_PolicyBreakdown(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	evaluator, err := NewOPAEvaluator(log, discountPolicies, "data.pricing.discount.total_discount",
+		"data.pricing.discount.total_quantity_discount",
+		"data.pricing.discount.total_combination_discount",
+	)
+	require.NoError(t, err)
+	t.Cleanup(evaluator.Close)
+
+	params := map[string]any{"min_quantity_for_discount": 3, "combination_discount_percent": 0.1}
+
+	breakdown, err := evaluator.Evaluate(context.Background(), testCart(), params)
+	require.NoError(t, err)
+
+	// 3-for-2 on the first item (100) plus 10% of the 350 subtotal (35)
+	require.Len(t, breakdown.Amounts, 2)
+	assert.Equal(t, "pricing.discount.total_quantity_discount", breakdown.Amounts[0].Policy)
+	assert.Equal(t, "100", breakdown.Amounts[0].Amount.String())
+	assert.Equal(t, "pricing.discount.total_combination_discount", breakdown.Amounts[1].Policy)
+	assert.Equal(t, "35", breakdown.Amounts[1].Amount.String())
+
+	sum, err := breakdown.Reconcile()
+	require.NoError(t, err)
+	assert.True(t, sum.Equal(breakdown.Total), "per-policy amounts %s must add up to the total %s", sum, breakdown.Total)
+}
+
+func TestOPAEvaluator_Evaluate_SinglePolicy(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	evaluator, err := NewOPAEvaluator(log, discountPolicies, "data.pricing.discount.total_discount")
+	require.NoError(t, err)
+	t.Cleanup(evaluator.Close)
+
+	params := map[string]any{"min_quantity_for_discount": 3, "combination_discount_percent": 0.1}
+
+	breakdown, err := evaluator.Evaluate(context.Background(), testCart(), params)
+	require.NoError(t, err)
+
+	require.Len(t, breakdown.Amounts, 1)
+	assert.Equal(t, "pricing.discount.total_discount", breakdown.Amounts[0].Policy)
+	assert.Equal(t, "135", breakdown.Total.String())
+	assert.True(t, breakdown.Amounts[0].Amount.Equal(breakdown.Total))
+}
+
+func TestOPAEvaluator_Evaluate_TaxExemption(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	evaluator, err := NewOPAEvaluator(log, "../../../policies/taxes", "data.pricing.tax.total_markup")
+	require.NoError(t, err)
+	t.Cleanup(evaluator.Close)
+
+	cart := testCart()
+
+	taxable, err := evaluator.Evaluate(context.Background(), cart, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "7.5", taxable.Total.String(), "5% of the 100 + 50 unit prices")
+
+	exempt, err := evaluator.Evaluate(context.Background(), cart, map[string]any{domain.TaxExemptionParam: "B2B"})
+	require.NoError(t, err)
+	assert.True(t, exempt.Total.IsZero(), "exempt carts pay no tax, got %s", exempt.Total)
+}
+
+func TestOPAEvaluator_CacheKey_NestedParams(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	evaluator, err := NewOPAEvaluator(log, discountPolicies, "data.pricing.discount.total_discount")
+	require.NoError(t, err)
+	t.Cleanup(evaluator.Close)
+
+	// Equal params built in a different insertion order at every level
+	first := map[string]any{
+		"min_quantity_for_discount":    3,
+		"combination_discount_percent": 0.1,
+		"tiers":                        map[string]any{"gold": map[string]any{"a": 1, "b": 2}, "silver": 1},
+	}
+	second := map[string]any{
+		"tiers":                        map[string]any{"silver": 1, "gold": map[string]any{"b": 2, "a": 1}},
+		"combination_discount_percent": 0.1,
+		"min_quantity_for_discount":    3,
+	}
+
+	cart := testCart()
+	key := evaluator.generateCacheKey(cart, first)
+	assert.Equal(t, key, evaluator.generateCacheKey(cart, second))
+
+	// Keys and values must not run together across entries
+	assert.NotEqual(t,
+		evaluator.generateCacheKey(cart, map[string]any{"a": "bc"}),
+		evaluator.generateCacheKey(cart, map[string]any{"ab": "c"}),
+	)
+
+	// Items of different tax categories are priced by different policy branches
+	categorized := &domain.Cart{CustomerID: cart.CustomerID, Items: slices.Clone(cart.Items)}
+	categorized.Items[0].TaxCategory = "food"
+	assert.NotEqual(t, key, evaluator.generateCacheKey(categorized, first))
+
+	// An item without a category is keyed like one of the default category
+	categorized.Items[0].TaxCategory = domain.DefaultTaxCategory
+	assert.Equal(t, key, evaluator.generateCacheKey(categorized, first))
+
+	_, err = evaluator.Evaluate(context.Background(), cart, first)
+	require.NoError(t, err)
+	evaluator.cache.Wait()
+
+	_, found := evaluator.cache.Get(evaluator.generateCacheKey(cart, second))
+	assert.True(t, found, "equal nested params must hit the cached result")
+}
+
+func TestNewOPAEvaluatorWithCache_AppliesConfig(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	cacheConfig := CacheConfig{NumCounters: 100, MaxCost: 10, TTL: time.Minute}
+
+	evaluator, err := NewOPAEvaluatorWithCache(log, cacheConfig, discountPolicies, "data.pricing.discount.total_discount")
+	require.NoError(t, err)
+	t.Cleanup(evaluator.Close)
+
+	assert.Equal(t, int64(10), evaluator.cache.MaxCost())
+	assert.Equal(t, time.Minute, evaluator.cacheTTL)
+
+	defaults, err := NewOPAEvaluator(log, discountPolicies, "data.pricing.discount.total_discount")
+	require.NoError(t, err)
+	t.Cleanup(defaults.Close)
+
+	assert.Equal(t, int64(cacheMaxCost), defaults.cache.MaxCost())
+	assert.Equal(t, cacheTTL, defaults.cacheTTL)
+}
+
+func TestNewOPAEvaluatorWithCache_RejectsInvalidConfig(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		mutate func(*CacheConfig)
+	}{
+		{name: "zero num counters", mutate: func(c *CacheConfig) { c.NumCounters = 0 }},
+		{name: "negative num counters", mutate: func(c *CacheConfig) { c.NumCounters = -1 }},
+		{name: "zero max cost", mutate: func(c *CacheConfig) { c.MaxCost = 0 }},
+		{name: "negative max cost", mutate: func(c *CacheConfig) { c.MaxCost = -1 }},
+		{name: "zero ttl", mutate: func(c *CacheConfig) { c.TTL = 0 }},
+		{name: "negative ttl", mutate: func(c *CacheConfig) { c.TTL = -time.Second }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheConfig := DefaultCacheConfig()
+			tc.mutate(&cacheConfig)
+
+			_, err := NewOPAEvaluatorWithCache(log, cacheConfig, discountPolicies, "data.pricing.discount.total_discount")
+			assert.ErrorIs(t, err, ErrInvalidCacheConfig)
+		})
+	}
+}
+
+func TestOPAEvaluator_Evaluate_CancelledContext(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	evaluator, err := NewOPAEvaluator(log, discountPolicies, "data.pricing.discount.total_discount",
+		"data.pricing.discount.total_quantity_discount",
+		"data.pricing.discount.total_combination_discount",
+	)
+	require.NoError(t, err)
+	t.Cleanup(evaluator.Close)
+
+	cart := testCart()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	_, err = evaluator.Evaluate(ctx, cart, nil)
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 50*time.Millisecond, "a cancelled evaluation must return without running the queries")
+
+	// Nothing was evaluated, so nothing was cached for a live caller to pick up
+	evaluator.cache.Wait()
+	_, found := evaluator.cache.Get(evaluator.generateCacheKey(cart, nil))
+	assert.False(t, found)
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	_, err = evaluator.Evaluate(expired, cart, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,358 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: infrastructure/rpc/admin/v1/admin.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PolicyFile describes one loaded .rego file
+type PolicyFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                               // File name without extension, as listed in CartTotal.policies
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`                               // Path relative to the pricer working directory
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`                           // Hex-encoded SHA-256 of the file contents
+	ModifiedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"` // File modification time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyFile) Reset() {
+	*x = PolicyFile{}
+	mi := &file_infrastructure_rpc_admin_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyFile) ProtoMessage() {}
+
+func (x *PolicyFile) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_rpc_admin_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyFile.ProtoReflect.Descriptor instead.
+func (*PolicyFile) Descriptor() ([]byte, []int) {
+	return file_infrastructure_rpc_admin_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PolicyFile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PolicyFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *PolicyFile) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *PolicyFile) GetModifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModifiedAt
+	}
+	return nil
+}
+
+// ReloadPoliciesRequest is the request message for reloading policies
+type ReloadPoliciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadPoliciesRequest) Reset() {
+	*x = ReloadPoliciesRequest{}
+	mi := &file_infrastructure_rpc_admin_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadPoliciesRequest) ProtoMessage() {}
+
+func (x *ReloadPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_rpc_admin_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadPoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ReloadPoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_infrastructure_rpc_admin_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+// ReloadPoliciesResponse lists the policies active after the reload
+type ReloadPoliciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policies      []string               `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	Files         []*PolicyFile          `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadPoliciesResponse) Reset() {
+	*x = ReloadPoliciesResponse{}
+	mi := &file_infrastructure_rpc_admin_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadPoliciesResponse) ProtoMessage() {}
+
+func (x *ReloadPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_rpc_admin_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ReloadPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_infrastructure_rpc_admin_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReloadPoliciesResponse) GetPolicies() []string {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *ReloadPoliciesResponse) GetFiles() []*PolicyFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+// GetActivePoliciesRequest is the request message for listing active policies
+type GetActivePoliciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivePoliciesRequest) Reset() {
+	*x = GetActivePoliciesRequest{}
+	mi := &file_infrastructure_rpc_admin_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivePoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivePoliciesRequest) ProtoMessage() {}
+
+func (x *GetActivePoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_rpc_admin_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivePoliciesRequest.ProtoReflect.Descriptor instead.
+func (*GetActivePoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_infrastructure_rpc_admin_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+// GetActivePoliciesResponse lists the active policies
+type GetActivePoliciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policies      []string               `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	Files         []*PolicyFile          `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivePoliciesResponse) Reset() {
+	*x = GetActivePoliciesResponse{}
+	mi := &file_infrastructure_rpc_admin_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivePoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivePoliciesResponse) ProtoMessage() {}
+
+func (x *GetActivePoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_rpc_admin_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivePoliciesResponse.ProtoReflect.Descriptor instead.
+func (*GetActivePoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_infrastructure_rpc_admin_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetActivePoliciesResponse) GetPolicies() []string {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *GetActivePoliciesResponse) GetFiles() []*PolicyFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+var File_infrastructure_rpc_admin_v1_admin_proto protoreflect.FileDescriptor
+
+const file_infrastructure_rpc_admin_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"'infrastructure/rpc/admin/v1/admin.proto\x12\x05admin\x1a\x1fgoogle/protobuf/timestamp.proto\"\x89\x01\n" +
+	"\n" +
+	"PolicyFile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\x12;\n" +
+	"\vmodified_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"modifiedAt\"\x17\n" +
+	"\x15ReloadPoliciesRequest\"]\n" +
+	"\x16ReloadPoliciesResponse\x12\x1a\n" +
+	"\bpolicies\x18\x01 \x03(\tR\bpolicies\x12'\n" +
+	"\x05files\x18\x02 \x03(\v2\x11.admin.PolicyFileR\x05files\"\x1a\n" +
+	"\x18GetActivePoliciesRequest\"`\n" +
+	"\x19GetActivePoliciesResponse\x12\x1a\n" +
+	"\bpolicies\x18\x01 \x03(\tR\bpolicies\x12'\n" +
+	"\x05files\x18\x02 \x03(\v2\x11.admin.PolicyFileR\x05files2\xbb\x01\n" +
+	"\x12PolicyAdminService\x12M\n" +
+	"\x0eReloadPolicies\x12\x1c.admin.ReloadPoliciesRequest\x1a\x1d.admin.ReloadPoliciesResponse\x12V\n" +
+	"\x11GetActivePolicies\x12\x1f.admin.GetActivePoliciesRequest\x1a .admin.GetActivePoliciesResponseB\x96\x01\n" +
+	"\tcom.adminB\n" +
+	"AdminProtoP\x01ZIgithub.com/shortlink-org/shop/pricer/internal/infrastructure/rpc/admin/v1\xa2\x02\x03AXX\xaa\x02\x05Admin\xca\x02\x05Admin\xe2\x02\x11Admin\\GPBMetadata\xea\x02\x05Adminb\x06proto3"
+
+var (
+	file_infrastructure_rpc_admin_v1_admin_proto_rawDescOnce sync.Once
+	file_infrastructure_rpc_admin_v1_admin_proto_rawDescData []byte
+)
+
+func file_infrastructure_rpc_admin_v1_admin_proto_rawDescGZIP() []byte {
+	file_infrastructure_rpc_admin_v1_admin_proto_rawDescOnce.Do(func() {
+		file_infrastructure_rpc_admin_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_infrastructure_rpc_admin_v1_admin_proto_rawDesc), len(file_infrastructure_rpc_admin_v1_admin_proto_rawDesc)))
+	})
+	return file_infrastructure_rpc_admin_v1_admin_proto_rawDescData
+}
+
+var file_infrastructure_rpc_admin_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_infrastructure_rpc_admin_v1_admin_proto_goTypes = []any{
+	(*PolicyFile)(nil),                // 0: admin.PolicyFile
+	(*ReloadPoliciesRequest)(nil),     // 1: admin.ReloadPoliciesRequest
+	(*ReloadPoliciesResponse)(nil),    // 2: admin.ReloadPoliciesResponse
+	(*GetActivePoliciesRequest)(nil),  // 3: admin.GetActivePoliciesRequest
+	(*GetActivePoliciesResponse)(nil), // 4: admin.GetActivePoliciesResponse
+	(*timestamppb.Timestamp)(nil),     // 5: google.protobuf.Timestamp
+}
+var file_infrastructure_rpc_admin_v1_admin_proto_depIdxs = []int32{
+	5, // 0: admin.PolicyFile.modified_at:type_name -> google.protobuf.Timestamp
+	0, // 1: admin.ReloadPoliciesResponse.files:type_name -> admin.PolicyFile
+	0, // 2: admin.GetActivePoliciesResponse.files:type_name -> admin.PolicyFile
+	1, // 3: admin.PolicyAdminService.ReloadPolicies:input_type -> admin.ReloadPoliciesRequest
+	3, // 4: admin.PolicyAdminService.GetActivePolicies:input_type -> admin.GetActivePoliciesRequest
+	2, // 5: admin.PolicyAdminService.ReloadPolicies:output_type -> admin.ReloadPoliciesResponse
+	4, // 6: admin.PolicyAdminService.GetActivePolicies:output_type -> admin.GetActivePoliciesResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_infrastructure_rpc_admin_v1_admin_proto_init() }
+func file_infrastructure_rpc_admin_v1_admin_proto_init() {
+	if File_infrastructure_rpc_admin_v1_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_infrastructure_rpc_admin_v1_admin_proto_rawDesc), len(file_infrastructure_rpc_admin_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_infrastructure_rpc_admin_v1_admin_proto_goTypes,
+		DependencyIndexes: file_infrastructure_rpc_admin_v1_admin_proto_depIdxs,
+		MessageInfos:      file_infrastructure_rpc_admin_v1_admin_proto_msgTypes,
+	}.Build()
+	File_infrastructure_rpc_admin_v1_admin_proto = out.File
+	file_infrastructure_rpc_admin_v1_admin_proto_goTypes = nil
+	file_infrastructure_rpc_admin_v1_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/shortlink-org/shop/pricer/internal/infrastructure/policy_evaluator"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "Bearer "
+)
+
+// PolicyReloader re-reads the policies an evaluator serves.
+type PolicyReloader interface {
+	Reload(ctx context.Context) error
+}
+
+// AdminHandler implements PolicyAdminServiceServer
+type AdminHandler struct {
+	UnimplementedPolicyAdminServiceServer
+
+	token      string
+	policyDirs []string
+	reloaders  []PolicyReloader
+}
+
+// NewAdminHandler creates a new AdminHandler. Calls must carry token as a bearer token;
+// policyDirs are listed by GetActivePolicies and reloaders are reloaded by ReloadPolicies.
+func NewAdminHandler(token string, policyDirs []string, reloaders ...PolicyReloader) *AdminHandler {
+	return &AdminHandler{
+		token:      token,
+		policyDirs: policyDirs,
+		reloaders:  reloaders,
+	}
+}
+
+// ReloadPolicies reloads every evaluator and returns the policies active afterwards
+func (h *AdminHandler) ReloadPolicies(ctx context.Context, _ *ReloadPoliciesRequest) (*ReloadPoliciesResponse, error) {
+	if err := h.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, reloader := range h.reloaders {
+		if err := reloader.Reload(ctx); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("reload policies: %v", err))
+		}
+	}
+
+	names, files, err := h.activePolicies()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReloadPoliciesResponse{Policies: names, Files: files}, nil
+}
+
+// GetActivePolicies returns the policy names with the hash and modification time of each file
+func (h *AdminHandler) GetActivePolicies(ctx context.Context, _ *GetActivePoliciesRequest) (*GetActivePoliciesResponse, error) {
+	if err := h.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	names, files, err := h.activePolicies()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetActivePoliciesResponse{Policies: names, Files: files}, nil
+}
+
+func (h *AdminHandler) activePolicies() ([]string, []*PolicyFile, error) {
+	names, err := policy_evaluator.GetPolicyNames(h.policyDirs...)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+
+	policyFiles, err := policy_evaluator.GetPolicyFiles(h.policyDirs...)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+
+	files := make([]*PolicyFile, 0, len(policyFiles))
+	for _, file := range policyFiles {
+		files = append(files, &PolicyFile{
+			Name:       file.Name,
+			Path:       file.Path,
+			Sha256:     file.SHA256,
+			ModifiedAt: timestamppb.New(file.ModTime),
+		})
+	}
+
+	return names, files, nil
+}
+
+// authorize checks the bearer token; with no token configured every call is rejected.
+func (h *AdminHandler) authorize(ctx context.Context) error {
+	if h.token == "" {
+		return status.Error(codes.PermissionDenied, "admin API is disabled: no admin token configured")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(authorizationHeader) {
+		token, ok := strings.CutPrefix(value, bearerPrefix)
+		if ok && subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "invalid or missing admin token")
+}
@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	logger "github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/shortlink-org/shop/pricer/internal/domain"
+	"github.com/shortlink-org/shop/pricer/internal/infrastructure/policy_evaluator"
+)
+
+const testToken = "s3cret"
+
+func writePolicy(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
+
+func withToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, bearerPrefix+token))
+}
+
+func TestAdminHandler_ReloadPolicies(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writePolicy(t, dir, "discount.rego", "package pricing.discount\n\ntotal_discount := 10\n")
+
+	evaluator, err := policy_evaluator.NewOPAEvaluator(log, dir, "data.pricing.discount.total_discount")
+	require.NoError(t, err)
+	t.Cleanup(evaluator.Close)
+
+	handler := NewAdminHandler(testToken, []string{dir}, evaluator)
+
+	active, err := handler.GetActivePolicies(withToken(testToken), &GetActivePoliciesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"discount"}, active.GetPolicies())
+	require.Len(t, active.GetFiles(), 1)
+	oldHash := active.GetFiles()[0].GetSha256()
+
+	// Change the directory: edit the existing policy and add a new one
+	writePolicy(t, dir, "discount.rego", "package pricing.discount\n\ntotal_discount := 20\n")
+	writePolicy(t, dir, "loyalty.rego", "package pricing.loyalty\n\nbonus := 1\n")
+
+	reloaded, err := handler.ReloadPolicies(withToken(testToken), &ReloadPoliciesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"discount", "loyalty"}, reloaded.GetPolicies())
+	require.Len(t, reloaded.GetFiles(), 2)
+	assert.Equal(t, "discount", reloaded.GetFiles()[0].GetName())
+	assert.NotEqual(t, oldHash, reloaded.GetFiles()[0].GetSha256())
+	assert.NotNil(t, reloaded.GetFiles()[1].GetModifiedAt())
+
+	// The evaluator serves the reloaded policy
+	breakdown, err := evaluator.Evaluate(context.Background(), &domain.Cart{
+		CustomerID: uuid.New(),
+		Items:      []domain.CartItem{{GoodID: uuid.New(), Quantity: 1, Price: decimal.NewFromInt(100)}},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "20", breakdown.Total.String())
+}
+
+func TestAdminHandler_ReloadPolicies_KeepsPoliciesOnError(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writePolicy(t, dir, "discount.rego", "package pricing.discount\n\ntotal_discount := 10\n")
+
+	evaluator, err := policy_evaluator.NewOPAEvaluator(log, dir, "data.pricing.discount.total_discount")
+	require.NoError(t, err)
+	t.Cleanup(evaluator.Close)
+
+	writePolicy(t, dir, "discount.rego", "package pricing.discount\n\ntotal_discount := \n")
+
+	_, err = NewAdminHandler(testToken, []string{dir}, evaluator).ReloadPolicies(withToken(testToken), &ReloadPoliciesRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	breakdown, err := evaluator.Evaluate(context.Background(), &domain.Cart{
+		CustomerID: uuid.New(),
+		Items:      []domain.CartItem{{GoodID: uuid.New(), Quantity: 1, Price: decimal.NewFromInt(100)}},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "10", breakdown.Total.String())
+}
+
+func TestAdminHandler_RequiresToken(t *testing.T) {
+	dir := t.TempDir()
+
+	testCases := []struct {
+		name     string
+		token    string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{name: "missing token", token: testToken, ctx: context.Background(), wantCode: codes.Unauthenticated},
+		{name: "wrong token", token: testToken, ctx: withToken("guess"), wantCode: codes.Unauthenticated},
+		{name: "no token configured", token: "", ctx: withToken(""), wantCode: codes.PermissionDenied},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewAdminHandler(tc.token, []string{dir})
+
+			_, err := handler.GetActivePolicies(tc.ctx, &GetActivePoliciesRequest{})
+			assert.Equal(t, tc.wantCode, status.Code(err))
+
+			_, err = handler.ReloadPolicies(tc.ctx, &ReloadPoliciesRequest{})
+			assert.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+}
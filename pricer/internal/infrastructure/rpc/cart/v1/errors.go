@@ -0,0 +1,22 @@
+package v1
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/shortlink-org/shop/pricer/internal/domain"
+)
+
+// toStatus maps domain errors to gRPC status errors so clients can tell bad input from server faults.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCart),
+		errors.Is(err, domain.ErrInvalidCurrency),
+		errors.Is(err, domain.ErrCurrencyMismatch):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -31,9 +32,14 @@ func (h *CartHandler) CalculateTotal(ctx context.Context, req *CalculateTotalReq
 		return &CalculateTotalResponse{}, nil //nolint:nilnil // empty request is valid, return empty response
 	}
 
-	cart, err := protoToDomainCart(req.GetCart())
+	cart, err := protoToDomainCart(req.GetCart(), req.GetCurrency())
 	if err != nil {
-		return nil, fmt.Errorf("invalid cart request: %w", err)
+		return nil, toStatus(fmt.Errorf("invalid cart request: %w", err))
+	}
+
+	// Validate at the boundary so invalid carts never reach policy evaluation
+	if err := cart.Validate(); err != nil {
+		return nil, toStatus(fmt.Errorf("invalid cart request: %w", err))
 	}
 
 	discountParams := stringMapToInterface(req.GetDiscountParams())
@@ -43,7 +49,7 @@ func (h *CartHandler) CalculateTotal(ctx context.Context, req *CalculateTotalReq
 
 	total, err := h.calculateTotalHandler.Handle(ctx, cmd)
 	if err != nil {
-		return nil, fmt.Errorf("calculate total: %w", err)
+		return nil, toStatus(fmt.Errorf("calculate total: %w", err))
 	}
 
 	return &CalculateTotalResponse{
@@ -51,7 +57,7 @@ func (h *CartHandler) CalculateTotal(ctx context.Context, req *CalculateTotalReq
 	}, nil
 }
 
-func protoToDomainCart(protoCart *Cart) (*domain.Cart, error) {
+func protoToDomainCart(protoCart *Cart, currency string) (*domain.Cart, error) {
 	if protoCart == nil {
 		return nil, nil //nolint:nilnil // nil cart is valid for empty request
 	}
@@ -60,29 +66,42 @@ func protoToDomainCart(protoCart *Cart) (*domain.Cart, error) {
 	for _, item := range protoCart.GetItems() {
 		goodID, err := uuid.Parse(item.GetProductId())
 		if err != nil {
-			return nil, fmt.Errorf("item product_id: %w", err)
+			return nil, fmt.Errorf("%w: item product_id: %w", domain.ErrInvalidCart, err)
 		}
 
 		price, err := decimal.NewFromString(item.GetPrice())
 		if err != nil {
-			return nil, fmt.Errorf("item price: %w", err)
+			return nil, fmt.Errorf("%w: item price: %w", domain.ErrInvalidCart, err)
+		}
+
+		itemCurrency, err := domain.ParseCurrency(item.GetCurrency())
+		if err != nil {
+			return nil, fmt.Errorf("item currency: %w", err)
 		}
 
 		items = append(items, domain.CartItem{
-			GoodID:   goodID,
-			Quantity: item.GetQuantity(),
-			Price:    price,
+			GoodID:      goodID,
+			Quantity:    item.GetQuantity(),
+			Price:       price,
+			Currency:    itemCurrency,
+			TaxCategory: domain.ParseTaxCategory(item.GetTaxCategory()),
 		})
 	}
 
 	customerID, err := uuid.Parse(protoCart.GetCustomerId())
 	if err != nil {
-		return nil, fmt.Errorf("customer_id: %w", err)
+		return nil, fmt.Errorf("%w: customer_id: %w", domain.ErrInvalidCart, err)
+	}
+
+	cartCurrency, err := domain.ParseCurrency(currency)
+	if err != nil {
+		return nil, fmt.Errorf("currency: %w", err)
 	}
 
 	return &domain.Cart{
 		Items:      items,
 		CustomerID: customerID,
+		Currency:   cartCurrency,
 	}, nil
 }
 
@@ -92,11 +111,32 @@ func domainToProtoCartTotal(total *domain.CartTotal) *CartTotal {
 	}
 
 	return &CartTotal{
-		TotalTax:      total.TotalTax.String(),
-		TotalDiscount: total.TotalDiscount.String(),
-		FinalPrice:    total.FinalPrice.String(),
-		Policies:      total.Policies,
+		TotalTax:         total.TotalTax.String(),
+		TotalDiscount:    total.TotalDiscount.String(),
+		OrderDiscount:    total.OrderDiscount.String(),
+		FinalPrice:       total.FinalPrice.String(),
+		Policies:         total.Policies,
+		Currency:         string(total.Currency),
+		TaxExemptionCode: total.TaxExemptionCode,
+		PolicyResults: slices.Concat(
+			domainToProtoPolicyResults(total.Discounts, PolicyKind_POLICY_KIND_DISCOUNT),
+			domainToProtoPolicyResults(total.OrderDiscounts, PolicyKind_POLICY_KIND_ORDER_DISCOUNT),
+			domainToProtoPolicyResults(total.Taxes, PolicyKind_POLICY_KIND_TAX),
+		),
+	}
+}
+
+func domainToProtoPolicyResults(amounts []domain.PolicyAmount, kind PolicyKind) []*PolicyResult {
+	results := make([]*PolicyResult, 0, len(amounts))
+	for _, amount := range amounts {
+		results = append(results, &PolicyResult{
+			Name:   amount.Policy,
+			Kind:   kind,
+			Amount: amount.Amount.String(),
+		})
 	}
+
+	return results
 }
 
 func stringMapToInterface(m map[string]string) map[string]any {
@@ -0,0 +1,277 @@
+package v1
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	logger "github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/shortlink-org/shop/pricer/internal/domain"
+	"github.com/shortlink-org/shop/pricer/internal/domain/pricing"
+	"github.com/shortlink-org/shop/pricer/internal/infrastructure/policy_evaluator"
+	"github.com/shortlink-org/shop/pricer/internal/usecases/cart/command/calculate_total"
+)
+
+// fixedEvaluator returns the same per-policy amounts for every cart.
+type fixedEvaluator map[string]int64
+
+func (e fixedEvaluator) Evaluate(context.Context, *domain.Cart, map[string]any) (domain.PolicyBreakdown, error) {
+	var breakdown domain.PolicyBreakdown
+
+	for _, policy := range slices.Sorted(maps.Keys(e)) {
+		amount := decimal.NewFromInt(e[policy])
+		breakdown.Total = breakdown.Total.Add(amount)
+		breakdown.Amounts = append(breakdown.Amounts, domain.PolicyAmount{Policy: policy, Amount: amount})
+	}
+
+	return breakdown, nil
+}
+
+func (fixedEvaluator) Reload(context.Context) error { return nil }
+
+func (fixedEvaluator) Close() {}
+
+func TestCartHandler_CalculateTotal(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	calculateTotal, err := calculate_total.NewHandler(
+		log,
+		&pricing.DiscountPolicy{Evaluator: fixedEvaluator{"combination": 3, "quantity": 7}},
+		&pricing.TaxPolicy{Evaluator: fixedEvaluator{"vat": 5}},
+		nil,
+		[]string{"stub"},
+	)
+	require.NoError(t, err)
+
+	resp, err := NewCartHandler(calculateTotal).CalculateTotal(context.Background(), &CalculateTotalRequest{
+		Cart: &Cart{
+			CustomerId: uuid.NewString(),
+			Items:      []*CartItem{{ProductId: uuid.NewString(), Quantity: 2, Price: "50"}},
+		},
+	})
+	require.NoError(t, err)
+
+	total := resp.GetTotal()
+	assert.Equal(t, "95", total.GetFinalPrice())
+
+	// The per-policy results add up to the totals of their kind
+	sums := map[PolicyKind]decimal.Decimal{}
+	for _, result := range total.GetPolicyResults() {
+		sums[result.GetKind()] = sums[result.GetKind()].Add(decimal.RequireFromString(result.GetAmount()))
+	}
+
+	assert.Equal(t, []*PolicyResult{
+		{Name: "combination", Kind: PolicyKind_POLICY_KIND_DISCOUNT, Amount: "3"},
+		{Name: "quantity", Kind: PolicyKind_POLICY_KIND_DISCOUNT, Amount: "7"},
+		{Name: "vat", Kind: PolicyKind_POLICY_KIND_TAX, Amount: "5"},
+	}, total.GetPolicyResults())
+	assert.Equal(t, total.GetTotalDiscount(), sums[PolicyKind_POLICY_KIND_DISCOUNT].String())
+	assert.Equal(t, total.GetTotalTax(), sums[PolicyKind_POLICY_KIND_TAX].String())
+}
+
+func TestCartHandler_CalculateTotal_OrderDiscount(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	orderDiscounts, err := policy_evaluator.NewOPAEvaluator(log, "../../../../../policies/order_discounts",
+		"data.pricing.order_discount.total_order_discount", "data.pricing.order_discount.total_threshold_discount")
+	require.NoError(t, err)
+	t.Cleanup(orderDiscounts.Close)
+
+	calculateTotal, err := calculate_total.NewHandler(
+		log,
+		&pricing.DiscountPolicy{Evaluator: fixedEvaluator{"quantity": 15}},
+		&pricing.TaxPolicy{Evaluator: fixedEvaluator{"vat": 5}},
+		&pricing.OrderDiscountPolicy{Evaluator: orderDiscounts},
+		[]string{"stub"},
+	)
+	require.NoError(t, err)
+
+	// $10 off orders over $100; params arrive as strings over gRPC
+	discountParams := map[string]string{"order_discount_threshold": "100", "order_discount_amount": "10"}
+
+	tests := []struct {
+		name              string
+		price             string
+		wantOrderDiscount string
+		wantFinal         string
+	}{
+		// 120 - 15 item discount = 105 reaches the threshold
+		{"over threshold", "60", "10", "100"},
+		// 110 - 15 item discount = 95 misses it, although the subtotal alone would not
+		{"under threshold after item discounts", "55", "0", "100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := NewCartHandler(calculateTotal).CalculateTotal(context.Background(), &CalculateTotalRequest{
+				Cart: &Cart{
+					CustomerId: uuid.NewString(),
+					Items:      []*CartItem{{ProductId: uuid.NewString(), Quantity: 2, Price: tt.price}},
+				},
+				DiscountParams: discountParams,
+			})
+			require.NoError(t, err)
+
+			total := resp.GetTotal()
+			assert.Equal(t, "15", total.GetTotalDiscount())
+			assert.Equal(t, tt.wantOrderDiscount, total.GetOrderDiscount())
+			assert.Equal(t, tt.wantFinal, total.GetFinalPrice())
+
+			// final_price = subtotal - item discounts - order discount + tax
+			subtotal := decimal.RequireFromString(tt.price).Mul(decimal.NewFromInt(2))
+			want := subtotal.
+				Sub(decimal.RequireFromString(total.GetTotalDiscount())).
+				Sub(decimal.RequireFromString(total.GetOrderDiscount())).
+				Add(decimal.RequireFromString(total.GetTotalTax()))
+			assert.True(t, want.Equal(decimal.RequireFromString(total.GetFinalPrice())))
+
+			// Order discounts are reported separately from item discounts
+			orderDiscount := decimal.Zero
+			for _, result := range total.GetPolicyResults() {
+				if result.GetKind() == PolicyKind_POLICY_KIND_ORDER_DISCOUNT {
+					orderDiscount = orderDiscount.Add(decimal.RequireFromString(result.GetAmount()))
+				}
+			}
+			assert.Equal(t, total.GetOrderDiscount(), orderDiscount.String())
+		})
+	}
+}
+
+func TestCartHandler_CalculateTotal_TaxExemption(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	taxes, err := policy_evaluator.NewOPAEvaluator(log, "../../../../../policies/taxes", "data.pricing.tax.total_markup")
+	require.NoError(t, err)
+	t.Cleanup(taxes.Close)
+
+	calculateTotal, err := calculate_total.NewHandler(
+		log,
+		&pricing.DiscountPolicy{Evaluator: fixedEvaluator{}},
+		&pricing.TaxPolicy{Evaluator: taxes},
+		nil,
+		[]string{"stub"},
+	)
+	require.NoError(t, err)
+
+	// Same cart for both customers
+	cart := &Cart{
+		CustomerId: uuid.NewString(),
+		Items:      []*CartItem{{ProductId: uuid.NewString(), Quantity: 1, Price: "100"}},
+	}
+
+	tests := []struct {
+		name          string
+		taxParams     map[string]string
+		wantTax       string
+		wantFinal     string
+		wantExemption string
+	}{
+		{"taxable customer", nil, "5", "105", ""},
+		{"exempt customer", map[string]string{domain.TaxExemptionParam: "CHARITY"}, "0", "100", "CHARITY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := NewCartHandler(calculateTotal).CalculateTotal(context.Background(), &CalculateTotalRequest{
+				Cart:      cart,
+				TaxParams: tt.taxParams,
+			})
+			require.NoError(t, err)
+
+			total := resp.GetTotal()
+			assert.Equal(t, tt.wantTax, total.GetTotalTax())
+			assert.Equal(t, tt.wantFinal, total.GetFinalPrice())
+			assert.Equal(t, tt.wantExemption, total.GetTaxExemptionCode())
+		})
+	}
+}
+
+func TestCartHandler_CalculateTotal_TaxCategories(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	taxes, err := policy_evaluator.NewOPAEvaluator(log, "../../../../../policies/taxes", "data.pricing.tax.total_markup")
+	require.NoError(t, err)
+	t.Cleanup(taxes.Close)
+
+	calculateTotal, err := calculate_total.NewHandler(
+		log,
+		&pricing.DiscountPolicy{Evaluator: fixedEvaluator{}},
+		&pricing.TaxPolicy{Evaluator: taxes},
+		nil,
+		[]string{"stub"},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		categories []string
+		wantTax    string
+		wantFinal  string
+	}{
+		// 2% food markup on the first item, 8% electronics on the second
+		{"different categories", []string{"food", "electronics"}, "10", "210"},
+		// Items without a category fall back to the 5% standard markup
+		{"without categories", []string{"", ""}, "10", "210"},
+		{"one without a category", []string{"FOOD", ""}, "7", "207"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := make([]*CartItem, 0, len(tt.categories))
+			for _, category := range tt.categories {
+				items = append(items, &CartItem{ProductId: uuid.NewString(), Quantity: 1, Price: "100", TaxCategory: category})
+			}
+
+			resp, err := NewCartHandler(calculateTotal).CalculateTotal(context.Background(), &CalculateTotalRequest{
+				Cart: &Cart{CustomerId: uuid.NewString(), Items: items},
+			})
+			require.NoError(t, err)
+
+			total := resp.GetTotal()
+			assert.Equal(t, tt.wantTax, total.GetTotalTax())
+			assert.Equal(t, tt.wantFinal, total.GetFinalPrice())
+		})
+	}
+}
+
+func TestCartHandler_CalculateTotal_InvalidArgument(t *testing.T) {
+	customerID := uuid.NewString()
+	item := func(productID string, quantity int32, price string) *CartItem {
+		return &CartItem{ProductId: productID, Quantity: quantity, Price: price}
+	}
+
+	tests := []struct {
+		name string
+		cart *Cart
+	}{
+		{"empty cart", &Cart{CustomerId: customerID}},
+		{"zero quantity", &Cart{CustomerId: customerID, Items: []*CartItem{item(uuid.NewString(), 0, "10")}}},
+		{"negative quantity", &Cart{CustomerId: customerID, Items: []*CartItem{item(uuid.NewString(), -2, "10")}}},
+		{"negative price", &Cart{CustomerId: customerID, Items: []*CartItem{item(uuid.NewString(), 1, "-5")}}},
+		{"malformed price", &Cart{CustomerId: customerID, Items: []*CartItem{item(uuid.NewString(), 1, "NaN")}}},
+		{"malformed product id", &Cart{CustomerId: customerID, Items: []*CartItem{item("not-a-uuid", 1, "10")}}},
+	}
+
+	// Invalid carts are rejected before the use case runs, so no handler is wired in.
+	handler := NewCartHandler(nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.CalculateTotal(context.Background(), &CalculateTotalRequest{Cart: tt.cart})
+			require.Error(t, err)
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
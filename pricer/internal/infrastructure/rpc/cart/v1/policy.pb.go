@@ -21,12 +21,67 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// PolicyKind tells whether a policy lowers or raises the cart total
+type PolicyKind int32
+
+const (
+	PolicyKind_POLICY_KIND_UNSPECIFIED    PolicyKind = 0
+	PolicyKind_POLICY_KIND_DISCOUNT       PolicyKind = 1
+	PolicyKind_POLICY_KIND_TAX            PolicyKind = 2
+	PolicyKind_POLICY_KIND_ORDER_DISCOUNT PolicyKind = 3
+)
+
+// Enum value maps for PolicyKind.
+var (
+	PolicyKind_name = map[int32]string{
+		0: "POLICY_KIND_UNSPECIFIED",
+		1: "POLICY_KIND_DISCOUNT",
+		2: "POLICY_KIND_TAX",
+		3: "POLICY_KIND_ORDER_DISCOUNT",
+	}
+	PolicyKind_value = map[string]int32{
+		"POLICY_KIND_UNSPECIFIED":    0,
+		"POLICY_KIND_DISCOUNT":       1,
+		"POLICY_KIND_TAX":            2,
+		"POLICY_KIND_ORDER_DISCOUNT": 3,
+	}
+)
+
+func (x PolicyKind) Enum() *PolicyKind {
+	p := new(PolicyKind)
+	*p = x
+	return p
+}
+
+func (x PolicyKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PolicyKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_infrastructure_rpc_cart_v1_policy_proto_enumTypes[0].Descriptor()
+}
+
+func (PolicyKind) Type() protoreflect.EnumType {
+	return &file_infrastructure_rpc_cart_v1_policy_proto_enumTypes[0]
+}
+
+func (x PolicyKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PolicyKind.Descriptor instead.
+func (PolicyKind) EnumDescriptor() ([]byte, []int) {
+	return file_infrastructure_rpc_cart_v1_policy_proto_rawDescGZIP(), []int{0}
+}
+
 // CartItem represents an item in the shopping cart
 type CartItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // UUID as a string
 	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"` // Decimal as a string to preserve precision
+	Price         string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`                                // Decimal as a string to preserve precision
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`                          // ISO-4217 code of price; empty means the request currency
+	TaxCategory   string                 `protobuf:"bytes,5,opt,name=tax_category,json=taxCategory,proto3" json:"tax_category,omitempty"` // Category tax policies branch on, e.g. "food"; empty means "standard"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,6 +137,20 @@ func (x *CartItem) GetPrice() string {
 	return ""
 }
 
+func (x *CartItem) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CartItem) GetTaxCategory() string {
+	if x != nil {
+		return x.TaxCategory
+	}
+	return ""
+}
+
 // Cart represents a customer's shopping cart
 type Cart struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -135,20 +204,85 @@ func (x *Cart) GetCustomerId() string {
 	return ""
 }
 
-// CartTotal represents the calculated totals for the cart
-type CartTotal struct {
+// PolicyResult is the amount one evaluated policy contributed to the cart total
+type PolicyResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TotalTax      string                 `protobuf:"bytes,1,opt,name=total_tax,json=totalTax,proto3" json:"total_tax,omitempty"`                // Decimal as a string
-	TotalDiscount string                 `protobuf:"bytes,2,opt,name=total_discount,json=totalDiscount,proto3" json:"total_discount,omitempty"` // Decimal as a string
-	FinalPrice    string                 `protobuf:"bytes,3,opt,name=final_price,json=finalPrice,proto3" json:"final_price,omitempty"`          // Decimal as a string
-	Policies      []string               `protobuf:"bytes,4,rep,name=policies,proto3" json:"policies,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Policy name, e.g. "pricing.discount.total_quantity_discount"
+	Kind          PolicyKind             `protobuf:"varint,2,opt,name=kind,proto3,enum=cart.PolicyKind" json:"kind,omitempty"`
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"` // Decimal as a string
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *PolicyResult) Reset() {
+	*x = PolicyResult{}
+	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyResult) ProtoMessage() {}
+
+func (x *PolicyResult) ProtoReflect() protoreflect.Message {
+	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyResult.ProtoReflect.Descriptor instead.
+func (*PolicyResult) Descriptor() ([]byte, []int) {
+	return file_infrastructure_rpc_cart_v1_policy_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PolicyResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PolicyResult) GetKind() PolicyKind {
+	if x != nil {
+		return x.Kind
+	}
+	return PolicyKind_POLICY_KIND_UNSPECIFIED
+}
+
+func (x *PolicyResult) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+// CartTotal represents the calculated totals for the cart
+type CartTotal struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TotalTax         string                 `protobuf:"bytes,1,opt,name=total_tax,json=totalTax,proto3" json:"total_tax,omitempty"`                // Decimal as a string
+	TotalDiscount    string                 `protobuf:"bytes,2,opt,name=total_discount,json=totalDiscount,proto3" json:"total_discount,omitempty"` // Decimal as a string
+	FinalPrice       string                 `protobuf:"bytes,3,opt,name=final_price,json=finalPrice,proto3" json:"final_price,omitempty"`          // Decimal as a string
+	Policies         []string               `protobuf:"bytes,4,rep,name=policies,proto3" json:"policies,omitempty"`
+	Currency         string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`                                           // ISO-4217 code all amounts are in
+	PolicyResults    []*PolicyResult        `protobuf:"bytes,6,rep,name=policy_results,json=policyResults,proto3" json:"policy_results,omitempty"`            // Per-policy amounts; discounts sum to total_discount, order discounts to order_discount, taxes to total_tax
+	TaxExemptionCode string                 `protobuf:"bytes,7,opt,name=tax_exemption_code,json=taxExemptionCode,proto3" json:"tax_exemption_code,omitempty"` // Set when no tax was charged because the cart is tax-exempt
+	OrderDiscount    string                 `protobuf:"bytes,8,opt,name=order_discount,json=orderDiscount,proto3" json:"order_discount,omitempty"`            // Decimal as a string; order-level promotions applied after item discounts
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
 func (x *CartTotal) Reset() {
 	*x = CartTotal{}
-	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[2]
+	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -160,7 +294,7 @@ func (x *CartTotal) String() string {
 func (*CartTotal) ProtoMessage() {}
 
 func (x *CartTotal) ProtoReflect() protoreflect.Message {
-	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[2]
+	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -173,7 +307,7 @@ func (x *CartTotal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CartTotal.ProtoReflect.Descriptor instead.
 func (*CartTotal) Descriptor() ([]byte, []int) {
-	return file_infrastructure_rpc_cart_v1_policy_proto_rawDescGZIP(), []int{2}
+	return file_infrastructure_rpc_cart_v1_policy_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CartTotal) GetTotalTax() string {
@@ -204,19 +338,48 @@ func (x *CartTotal) GetPolicies() []string {
 	return nil
 }
 
+func (x *CartTotal) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CartTotal) GetPolicyResults() []*PolicyResult {
+	if x != nil {
+		return x.PolicyResults
+	}
+	return nil
+}
+
+func (x *CartTotal) GetTaxExemptionCode() string {
+	if x != nil {
+		return x.TaxExemptionCode
+	}
+	return ""
+}
+
+func (x *CartTotal) GetOrderDiscount() string {
+	if x != nil {
+		return x.OrderDiscount
+	}
+	return ""
+}
+
 // CalculateTotalRequest is the request message for calculating cart totals
 type CalculateTotalRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Cart           *Cart                  `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
 	DiscountParams map[string]string      `protobuf:"bytes,2,rep,name=discount_params,json=discountParams,proto3" json:"discount_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Using string for simplicity
-	TaxParams      map[string]string      `protobuf:"bytes,3,rep,name=tax_params,json=taxParams,proto3" json:"tax_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                // Using string for simplicity
+	TaxParams      map[string]string      `protobuf:"bytes,3,rep,name=tax_params,json=taxParams,proto3" json:"tax_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                // Using string for simplicity; "tax_exemption_code" makes the cart tax-exempt
+	Currency       string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`                                                                                                             // ISO-4217 code of the cart; items must not mix currencies
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CalculateTotalRequest) Reset() {
 	*x = CalculateTotalRequest{}
-	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[3]
+	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -228,7 +391,7 @@ func (x *CalculateTotalRequest) String() string {
 func (*CalculateTotalRequest) ProtoMessage() {}
 
 func (x *CalculateTotalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[3]
+	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -241,7 +404,7 @@ func (x *CalculateTotalRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CalculateTotalRequest.ProtoReflect.Descriptor instead.
 func (*CalculateTotalRequest) Descriptor() ([]byte, []int) {
-	return file_infrastructure_rpc_cart_v1_policy_proto_rawDescGZIP(), []int{3}
+	return file_infrastructure_rpc_cart_v1_policy_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CalculateTotalRequest) GetCart() *Cart {
@@ -265,6 +428,13 @@ func (x *CalculateTotalRequest) GetTaxParams() map[string]string {
 	return nil
 }
 
+func (x *CalculateTotalRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 // CalculateTotalResponse is the response message after calculating totals
 type CalculateTotalResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -275,7 +445,7 @@ type CalculateTotalResponse struct {
 
 func (x *CalculateTotalResponse) Reset() {
 	*x = CalculateTotalResponse{}
-	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[4]
+	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -287,7 +457,7 @@ func (x *CalculateTotalResponse) String() string {
 func (*CalculateTotalResponse) ProtoMessage() {}
 
 func (x *CalculateTotalResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[4]
+	mi := &file_infrastructure_rpc_cart_v1_policy_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -300,7 +470,7 @@ func (x *CalculateTotalResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CalculateTotalResponse.ProtoReflect.Descriptor instead.
 func (*CalculateTotalResponse) Descriptor() ([]byte, []int) {
-	return file_infrastructure_rpc_cart_v1_policy_proto_rawDescGZIP(), []int{4}
+	return file_infrastructure_rpc_cart_v1_policy_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CalculateTotalResponse) GetTotal() *CartTotal {
@@ -314,28 +484,39 @@ var File_infrastructure_rpc_cart_v1_policy_proto protoreflect.FileDescriptor
 
 const file_infrastructure_rpc_cart_v1_policy_proto_rawDesc = "" +
 	"\n" +
-	"'infrastructure/rpc/cart/v1/policy.proto\x12\x04cart\"[\n" +
+	"'infrastructure/rpc/cart/v1/policy.proto\x12\x04cart\"\x9a\x01\n" +
 	"\bCartItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\tR\x05price\"M\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12!\n" +
+	"\ftax_category\x18\x05 \x01(\tR\vtaxCategory\"M\n" +
 	"\x04Cart\x12$\n" +
 	"\x05items\x18\x01 \x03(\v2\x0e.cart.CartItemR\x05items\x12\x1f\n" +
 	"\vcustomer_id\x18\x02 \x01(\tR\n" +
-	"customerId\"\x8c\x01\n" +
+	"customerId\"`\n" +
+	"\fPolicyResult\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12$\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x10.cart.PolicyKindR\x04kind\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\"\xb8\x02\n" +
 	"\tCartTotal\x12\x1b\n" +
 	"\ttotal_tax\x18\x01 \x01(\tR\btotalTax\x12%\n" +
 	"\x0etotal_discount\x18\x02 \x01(\tR\rtotalDiscount\x12\x1f\n" +
 	"\vfinal_price\x18\x03 \x01(\tR\n" +
 	"finalPrice\x12\x1a\n" +
-	"\bpolicies\x18\x04 \x03(\tR\bpolicies\"\xdd\x02\n" +
+	"\bpolicies\x18\x04 \x03(\tR\bpolicies\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x129\n" +
+	"\x0epolicy_results\x18\x06 \x03(\v2\x12.cart.PolicyResultR\rpolicyResults\x12,\n" +
+	"\x12tax_exemption_code\x18\a \x01(\tR\x10taxExemptionCode\x12%\n" +
+	"\x0eorder_discount\x18\b \x01(\tR\rorderDiscount\"\xf9\x02\n" +
 	"\x15CalculateTotalRequest\x12\x1e\n" +
 	"\x04cart\x18\x01 \x01(\v2\n" +
 	".cart.CartR\x04cart\x12X\n" +
 	"\x0fdiscount_params\x18\x02 \x03(\v2/.cart.CalculateTotalRequest.DiscountParamsEntryR\x0ediscountParams\x12I\n" +
 	"\n" +
-	"tax_params\x18\x03 \x03(\v2*.cart.CalculateTotalRequest.TaxParamsEntryR\ttaxParams\x1aA\n" +
+	"tax_params\x18\x03 \x03(\v2*.cart.CalculateTotalRequest.TaxParamsEntryR\ttaxParams\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x1aA\n" +
 	"\x13DiscountParamsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a<\n" +
@@ -343,7 +524,13 @@ const file_infrastructure_rpc_cart_v1_policy_proto_rawDesc = "" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"?\n" +
 	"\x16CalculateTotalResponse\x12%\n" +
-	"\x05total\x18\x01 \x01(\v2\x0f.cart.CartTotalR\x05total2Z\n" +
+	"\x05total\x18\x01 \x01(\v2\x0f.cart.CartTotalR\x05total*x\n" +
+	"\n" +
+	"PolicyKind\x12\x1b\n" +
+	"\x17POLICY_KIND_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14POLICY_KIND_DISCOUNT\x10\x01\x12\x13\n" +
+	"\x0fPOLICY_KIND_TAX\x10\x02\x12\x1e\n" +
+	"\x1aPOLICY_KIND_ORDER_DISCOUNT\x10\x032Z\n" +
 	"\vCartService\x12K\n" +
 	"\x0eCalculateTotal\x12\x1b.cart.CalculateTotalRequest\x1a\x1c.cart.CalculateTotalResponseB\x91\x01\n" +
 	"\bcom.cartB\vPolicyProtoP\x01ZHgithub.com/shortlink-org/shop/pricer/internal/infrastructure/rpc/cart/v1\xa2\x02\x03CXX\xaa\x02\x04Cart\xca\x02\x04Cart\xe2\x02\x10Cart\\GPBMetadata\xea\x02\x04Cartb\x06proto3"
@@ -360,29 +547,34 @@ func file_infrastructure_rpc_cart_v1_policy_proto_rawDescGZIP() []byte {
 	return file_infrastructure_rpc_cart_v1_policy_proto_rawDescData
 }
 
-var file_infrastructure_rpc_cart_v1_policy_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_infrastructure_rpc_cart_v1_policy_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_infrastructure_rpc_cart_v1_policy_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_infrastructure_rpc_cart_v1_policy_proto_goTypes = []any{
-	(*CartItem)(nil),               // 0: cart.CartItem
-	(*Cart)(nil),                   // 1: cart.Cart
-	(*CartTotal)(nil),              // 2: cart.CartTotal
-	(*CalculateTotalRequest)(nil),  // 3: cart.CalculateTotalRequest
-	(*CalculateTotalResponse)(nil), // 4: cart.CalculateTotalResponse
-	nil,                            // 5: cart.CalculateTotalRequest.DiscountParamsEntry
-	nil,                            // 6: cart.CalculateTotalRequest.TaxParamsEntry
+	(PolicyKind)(0),                // 0: cart.PolicyKind
+	(*CartItem)(nil),               // 1: cart.CartItem
+	(*Cart)(nil),                   // 2: cart.Cart
+	(*PolicyResult)(nil),           // 3: cart.PolicyResult
+	(*CartTotal)(nil),              // 4: cart.CartTotal
+	(*CalculateTotalRequest)(nil),  // 5: cart.CalculateTotalRequest
+	(*CalculateTotalResponse)(nil), // 6: cart.CalculateTotalResponse
+	nil,                            // 7: cart.CalculateTotalRequest.DiscountParamsEntry
+	nil,                            // 8: cart.CalculateTotalRequest.TaxParamsEntry
 }
 var file_infrastructure_rpc_cart_v1_policy_proto_depIdxs = []int32{
-	0, // 0: cart.Cart.items:type_name -> cart.CartItem
-	1, // 1: cart.CalculateTotalRequest.cart:type_name -> cart.Cart
-	5, // 2: cart.CalculateTotalRequest.discount_params:type_name -> cart.CalculateTotalRequest.DiscountParamsEntry
-	6, // 3: cart.CalculateTotalRequest.tax_params:type_name -> cart.CalculateTotalRequest.TaxParamsEntry
-	2, // 4: cart.CalculateTotalResponse.total:type_name -> cart.CartTotal
-	3, // 5: cart.CartService.CalculateTotal:input_type -> cart.CalculateTotalRequest
-	4, // 6: cart.CartService.CalculateTotal:output_type -> cart.CalculateTotalResponse
-	6, // [6:7] is the sub-list for method output_type
-	5, // [5:6] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	1, // 0: cart.Cart.items:type_name -> cart.CartItem
+	0, // 1: cart.PolicyResult.kind:type_name -> cart.PolicyKind
+	3, // 2: cart.CartTotal.policy_results:type_name -> cart.PolicyResult
+	2, // 3: cart.CalculateTotalRequest.cart:type_name -> cart.Cart
+	7, // 4: cart.CalculateTotalRequest.discount_params:type_name -> cart.CalculateTotalRequest.DiscountParamsEntry
+	8, // 5: cart.CalculateTotalRequest.tax_params:type_name -> cart.CalculateTotalRequest.TaxParamsEntry
+	4, // 6: cart.CalculateTotalResponse.total:type_name -> cart.CartTotal
+	5, // 7: cart.CartService.CalculateTotal:input_type -> cart.CalculateTotalRequest
+	6, // 8: cart.CartService.CalculateTotal:output_type -> cart.CalculateTotalResponse
+	8, // [8:9] is the sub-list for method output_type
+	7, // [7:8] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_infrastructure_rpc_cart_v1_policy_proto_init() }
@@ -395,13 +587,14 @@ func file_infrastructure_rpc_cart_v1_policy_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_infrastructure_rpc_cart_v1_policy_proto_rawDesc), len(file_infrastructure_rpc_cart_v1_policy_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      1,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_infrastructure_rpc_cart_v1_policy_proto_goTypes,
 		DependencyIndexes: file_infrastructure_rpc_cart_v1_policy_proto_depIdxs,
+		EnumInfos:         file_infrastructure_rpc_cart_v1_policy_proto_enumTypes,
 		MessageInfos:      file_infrastructure_rpc_cart_v1_policy_proto_msgTypes,
 	}.Build()
 	File_infrastructure_rpc_cart_v1_policy_proto = out.File
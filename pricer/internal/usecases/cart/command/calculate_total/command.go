@@ -5,6 +5,7 @@ import (
 )
 
 // Command represents a command to calculate cart totals (discount + tax).
+// DiscountParams are passed to both the item and the order discount policies.
 type Command struct {
 	Cart           *domain.Cart
 	DiscountParams map[string]any
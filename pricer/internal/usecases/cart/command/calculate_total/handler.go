@@ -15,24 +15,28 @@ import (
 
 // Handler handles CalculateTotal commands.
 type Handler struct {
-	log            logger.Logger
-	discountPolicy *pricing.DiscountPolicy
-	taxPolicy      *pricing.TaxPolicy
-	policyNames    []string
+	log                 logger.Logger
+	discountPolicy      *pricing.DiscountPolicy
+	taxPolicy           *pricing.TaxPolicy
+	orderDiscountPolicy *pricing.OrderDiscountPolicy
+	policyNames         []string
 }
 
 // NewHandler creates a new CalculateTotal handler.
+// orderDiscountPolicy may be nil, in which case no order-level discount is applied.
 func NewHandler(
 	log logger.Logger,
 	discountPolicy *pricing.DiscountPolicy,
 	taxPolicy *pricing.TaxPolicy,
+	orderDiscountPolicy *pricing.OrderDiscountPolicy,
 	policyNames []string,
 ) (*Handler, error) { //nolint:whitespace // multi-line signature; gofumpt prefers no blank after brace
 	return &Handler{
-		log:            log,
-		discountPolicy: discountPolicy,
-		taxPolicy:      taxPolicy,
-		policyNames:    policyNames,
+		log:                 log,
+		discountPolicy:      discountPolicy,
+		taxPolicy:           taxPolicy,
+		orderDiscountPolicy: orderDiscountPolicy,
+		policyNames:         policyNames,
 	}, nil
 }
 
@@ -44,27 +48,56 @@ func (h *Handler) Handle(ctx context.Context, cmd Command) (domain.CartTotal, er
 		return total, nil
 	}
 
+	// Reject carts that would otherwise silently price to zero
+	if err := cmd.Cart.Validate(); err != nil {
+		return total, fmt.Errorf("validate cart: %w", err)
+	}
+
+	// Money math must stay within one currency
+	currency, err := cmd.Cart.ResolveCurrency()
+	if err != nil {
+		return total, fmt.Errorf("resolve cart currency: %w", err)
+	}
+
 	// Evaluate Discount Policy
 	h.log.InfoWithContext(ctx, "Evaluating discount policy", slog.Any("customer_id", cmd.Cart.CustomerID))
 
-	totalDiscountFloat, err := h.discountPolicy.Evaluate(ctx, cmd.Cart, cmd.DiscountParams)
+	discounts, err := h.discountPolicy.Evaluate(ctx, cmd.Cart, cmd.DiscountParams)
 	if err != nil {
 		return total, fmt.Errorf("failed to evaluate discount policy: %w", err)
 	}
 
-	h.log.InfoWithContext(ctx, "Discount calculated", slog.Float64("total_discount", totalDiscountFloat))
-	totalDiscount := decimal.NewFromFloat(totalDiscountFloat)
+	// The per-policy amounts must add up to what the policy reports as its total
+	totalDiscount, err := discounts.Reconcile()
+	if err != nil {
+		return total, fmt.Errorf("discount policy breakdown: %w", err)
+	}
+
+	h.log.InfoWithContext(ctx, "Discount calculated", slog.String("total_discount", totalDiscount.String()))
 
 	// Evaluate Tax Policy
 	h.log.InfoWithContext(ctx, "Evaluating tax policy", slog.Any("customer_id", cmd.Cart.CustomerID))
 
-	totalTaxFloat, err := h.taxPolicy.Evaluate(ctx, cmd.Cart, cmd.TaxParams)
+	taxes, err := h.taxPolicy.Evaluate(ctx, cmd.Cart, cmd.TaxParams)
 	if err != nil {
 		return total, fmt.Errorf("failed to evaluate tax policy: %w", err)
 	}
 
-	h.log.InfoWithContext(ctx, "Tax calculated", slog.Float64("total_tax", totalTaxFloat))
-	totalTax := decimal.NewFromFloat(totalTaxFloat)
+	totalTax, err := taxes.Reconcile()
+	if err != nil {
+		return total, fmt.Errorf("tax policy breakdown: %w", err)
+	}
+
+	// Exempt carts are marked as such; a policy that still taxes them is misconfigured
+	exemptionCode := domain.TaxExemptionCode(cmd.TaxParams)
+	if exemptionCode != "" && !totalTax.IsZero() {
+		return total, fmt.Errorf("%w: exemption %q, tax %s", domain.ErrExemptCartTaxed, exemptionCode, totalTax)
+	}
+
+	h.log.InfoWithContext(ctx, "Tax calculated",
+		slog.String("total_tax", totalTax.String()),
+		slog.String("tax_exemption_code", exemptionCode),
+	)
 
 	// Calculate subtotal
 	h.log.InfoWithContext(ctx, "Calculating final price", slog.Any("customer_id", cmd.Cart.CustomerID))
@@ -80,18 +113,49 @@ func (h *Handler) Handle(ctx context.Context, cmd Command) (domain.CartTotal, er
 		)
 	}
 
-	// Cap discount at subtotal to avoid negative final price
+	// Cap discount at subtotal to avoid negative final price; trim the breakdown to match
+	discountAmounts := discounts.Amounts
 	if totalDiscount.GreaterThan(subtotal) {
 		totalDiscount = subtotal
+		discountAmounts = discounts.CapAt(subtotal)
+	}
+
+	// Order-level promotions apply to what is left after the item discounts, and never beyond it
+	discountedSubtotal := subtotal.Sub(totalDiscount)
+
+	orderDiscounts, err := h.orderDiscountPolicy.Evaluate(ctx, cmd.Cart,
+		domain.OrderDiscountParams(cmd.DiscountParams, discountedSubtotal))
+	if err != nil {
+		return total, fmt.Errorf("failed to evaluate order discount policy: %w", err)
 	}
 
-	finalPrice := subtotal.Sub(totalDiscount).Add(totalTax)
+	orderDiscount, err := orderDiscounts.Reconcile()
+	if err != nil {
+		return total, fmt.Errorf("order discount policy breakdown: %w", err)
+	}
+
+	orderDiscountAmounts := orderDiscounts.Amounts
+	if orderDiscount.GreaterThan(discountedSubtotal) {
+		orderDiscount = discountedSubtotal
+		orderDiscountAmounts = orderDiscounts.CapAt(discountedSubtotal)
+	}
+
+	h.log.InfoWithContext(ctx, "Order discount calculated", slog.String("order_discount", orderDiscount.String()))
+
+	finalPrice := discountedSubtotal.Sub(orderDiscount).Add(totalTax)
 
 	total = domain.CartTotal{
-		TotalTax:      totalTax,
-		TotalDiscount: totalDiscount,
-		FinalPrice:    finalPrice,
-		Policies:      h.policyNames,
+		Currency:         currency,
+		Subtotal:         subtotal,
+		Discounts:        discountAmounts,
+		Taxes:            taxes.Amounts,
+		TotalTax:         totalTax,
+		TotalDiscount:    totalDiscount,
+		OrderDiscounts:   orderDiscountAmounts,
+		OrderDiscount:    orderDiscount,
+		FinalPrice:       finalPrice,
+		Policies:         h.policyNames,
+		TaxExemptionCode: exemptionCode,
 	}
 
 	h.log.InfoWithContext(ctx, "Final price calculated",
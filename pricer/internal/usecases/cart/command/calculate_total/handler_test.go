@@ -0,0 +1,117 @@
+package calculate_total
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	logger "github.com/shortlink-org/go-sdk/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/shop/pricer/internal/domain"
+	"github.com/shortlink-org/shop/pricer/internal/domain/pricing"
+)
+
+// stubEvaluator reports a single policy with a fixed amount and records the params it was called with.
+type stubEvaluator struct {
+	policy string
+	amount decimal.Decimal
+	params *map[string]any
+}
+
+func (e stubEvaluator) Evaluate(_ context.Context, _ *domain.Cart, params map[string]any) (domain.PolicyBreakdown, error) {
+	if e.params != nil {
+		*e.params = params
+	}
+
+	return domain.PolicyBreakdown{Total: e.amount, Amounts: []domain.PolicyAmount{{Policy: e.policy, Amount: e.amount}}}, nil
+}
+
+func (stubEvaluator) Reload(context.Context) error { return nil }
+
+func (stubEvaluator) Close() {}
+
+func TestHandler_Handle_OrderDiscount(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	cart := &domain.Cart{
+		CustomerID: uuid.New(),
+		Items:      []domain.CartItem{{GoodID: uuid.New(), Quantity: 2, Price: decimal.NewFromInt(60)}},
+	}
+
+	tests := []struct {
+		name              string
+		orderDiscount     int64
+		wantOrderDiscount int64
+		wantFinal         int64
+	}{
+		// 120 subtotal - 20 item discount - 10 order discount + 6 tax
+		{"order discount", 10, 10, 96},
+		// the order discount is capped at the 100 left after item discounts
+		{"order discount capped", 150, 100, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var orderParams map[string]any
+
+			handler, err := NewHandler(
+				log,
+				&pricing.DiscountPolicy{Evaluator: stubEvaluator{policy: "quantity", amount: decimal.NewFromInt(20)}},
+				&pricing.TaxPolicy{Evaluator: stubEvaluator{policy: "vat", amount: decimal.NewFromInt(6)}},
+				&pricing.OrderDiscountPolicy{Evaluator: stubEvaluator{
+					policy: "threshold", amount: decimal.NewFromInt(tt.orderDiscount), params: &orderParams,
+				}},
+				nil,
+			)
+			require.NoError(t, err)
+
+			discountParams := map[string]any{"order_discount_threshold": 100}
+
+			total, err := handler.Handle(context.Background(), NewCommand(cart, discountParams, nil))
+			require.NoError(t, err)
+
+			// The order discount policy sees the subtotal after item discounts; the caller's params stay untouched
+			assert.InDelta(t, 100.0, orderParams[domain.DiscountedSubtotalParam], 0)
+			assert.Equal(t, 100, orderParams["order_discount_threshold"])
+			assert.NotContains(t, discountParams, domain.DiscountedSubtotalParam)
+
+			assert.True(t, decimal.NewFromInt(20).Equal(total.TotalDiscount))
+			assert.True(t, decimal.NewFromInt(tt.wantOrderDiscount).Equal(total.OrderDiscount))
+			assert.True(t, decimal.NewFromInt(tt.wantFinal).Equal(total.FinalPrice))
+
+			// Reconciliation invariant
+			assert.True(t, total.Subtotal.Sub(total.TotalDiscount).Sub(total.OrderDiscount).Add(total.TotalTax).Equal(total.FinalPrice))
+			assert.True(t, domain.PolicyBreakdown{Total: total.OrderDiscount, Amounts: total.OrderDiscounts}.Sum().Equal(total.OrderDiscount))
+		})
+	}
+}
+
+func TestHandler_Handle_WithoutOrderDiscountPolicy(t *testing.T) {
+	log, err := logger.New(logger.Default())
+	require.NoError(t, err)
+
+	handler, err := NewHandler(
+		log,
+		&pricing.DiscountPolicy{Evaluator: stubEvaluator{policy: "quantity", amount: decimal.NewFromInt(20)}},
+		&pricing.TaxPolicy{Evaluator: stubEvaluator{policy: "vat", amount: decimal.NewFromInt(6)}},
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+
+	cart := &domain.Cart{
+		CustomerID: uuid.New(),
+		Items:      []domain.CartItem{{GoodID: uuid.New(), Quantity: 2, Price: decimal.NewFromInt(60)}},
+	}
+
+	total, err := handler.Handle(context.Background(), NewCommand(cart, nil, nil))
+	require.NoError(t, err)
+
+	assert.True(t, total.OrderDiscount.IsZero())
+	assert.Empty(t, total.OrderDiscounts)
+	assert.True(t, decimal.NewFromInt(106).Equal(total.FinalPrice))
+}
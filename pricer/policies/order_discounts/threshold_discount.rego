@@ -0,0 +1,12 @@
+package pricing.order_discount
+
+# Threshold discount: a fixed amount off orders whose subtotal after item discounts
+# reaches the threshold, e.g. $10 off orders over $100.
+# Params may arrive as strings over gRPC, hence to_number.
+default total_threshold_discount = 0
+
+total_threshold_discount = discount {
+	threshold := to_number(input.params.order_discount_threshold)
+	input.params.discounted_subtotal >= threshold
+	discount := to_number(input.params.order_discount_amount)
+}
@@ -0,0 +1,50 @@
+package pricing.order_discount_test
+
+import data.pricing.order_discount
+
+# Test 1: Orders reaching the threshold get the fixed amount off
+test_threshold_reached {
+    input := {
+        "items": [{"productId": "item1", "price": 120, "quantity": 1}],
+        "params": {
+            "order_discount_threshold": 100,
+            "order_discount_amount": 10,
+            "discounted_subtotal": 120
+        }
+    }
+
+    total := order_discount.total_order_discount with input as input
+
+    # Assertion
+    total == 10
+}
+
+# Test 2: The threshold is checked against the subtotal after item discounts
+test_threshold_missed_after_item_discounts {
+    input := {
+        "items": [{"productId": "item1", "price": 120, "quantity": 1}],
+        "params": {
+            "order_discount_threshold": 100,
+            "order_discount_amount": 10,
+            "discounted_subtotal": 90
+        }
+    }
+
+    total := order_discount.total_order_discount with input as input
+
+    # Assertion
+    total == 0
+}
+
+# Test 3: Without a configured promotion no order discount applies
+test_no_promotion {
+    input := {
+        "items": [{"productId": "item1", "price": 120, "quantity": 1}],
+        "params": {"discounted_subtotal": 120}
+    }
+
+    total := order_discount.total_order_discount with input as input
+
+    # Assertion
+    total == 0
+}
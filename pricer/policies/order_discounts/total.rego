@@ -0,0 +1,4 @@
+package pricing.order_discount
+
+# Total order discount = threshold-based
+total_order_discount := total_threshold_discount
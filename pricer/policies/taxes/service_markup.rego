@@ -1,12 +1,35 @@
 package pricing.tax
 
-# Calculate the 5% markup for each item
+# Tax-exempt customers (B2B, charities) pass an exemption code and pay no markup
+exempt {
+    input.params.tax_exemption_code != ""
+}
+
+# Markup rate per tax category; the pricer sends "standard" for items without a category
+markup_rates = {
+    "standard": 0.05,
+    "food": 0.02,
+    "electronics": 0.08,
+}
+
+# Items without a category or of an unknown one pay the standard rate
+markup_rate(item) = rate {
+    rate := object.get(markup_rates, object.get(item, "taxCategory", "standard"), markup_rates.standard)
+}
+
+# Calculate the markup for each item at its category rate
 service_markup[item_id] = tax {
+    not exempt
     some i
     item := input.items[i]
-    tax := item.price * 0.05
+    tax := item.price * markup_rate(item)
     item_id := item.productId
 }
 
+default total_markup = 0
+
 # Calculate the total markup for all items
-total_markup = sum([tax | some i; item := input.items[i]; tax := item.price * 0.05])
+total_markup = total {
+    not exempt
+    total := sum([tax | some i; item := input.items[i]; tax := item.price * markup_rate(item)])
+}
@@ -41,3 +41,55 @@ test_total_markup {
     # Assertion
     total == expected_total_markup
 }
+
+# Test 3: Tax-exempt customers pay no markup
+test_exempt_total_markup {
+    input := {
+        "items": [
+            {"productId": "item1", "price": 100},
+            {"productId": "item2", "price": 200}
+        ],
+        "params": {"tax_exemption_code": "B2B"}
+    }
+
+    total := tax.total_markup with input as input
+    markups := tax.service_markup with input as input
+
+    # Assertions
+    total == 0
+    count(markups) == 0
+}
+
+# Test 4: Items are marked up at the rate of their tax category
+test_markup_by_tax_category {
+    input := {
+        "items": [
+            {"productId": "item1", "price": 100, "taxCategory": "food"},
+            {"productId": "item2", "price": 100, "taxCategory": "electronics"},
+            {"productId": "item3", "price": 100, "taxCategory": "standard"}
+        ]
+    }
+
+    markups := tax.service_markup with input as input
+    total := tax.total_markup with input as input
+
+    # Assertions
+    markups["item1"] == 2.0    # 2% food
+    markups["item2"] == 8.0    # 8% electronics
+    markups["item3"] == 5.0    # 5% standard
+    total == 15.0
+}
+
+# Test 5: Items of an unknown tax category pay the standard markup
+test_unknown_tax_category_markup {
+    input := {
+        "items": [
+            {"productId": "item1", "price": 100, "taxCategory": "furniture"}
+        ]
+    }
+
+    markup := tax.service_markup["item1"] with input as input
+
+    # Assertion
+    markup == 5.0
+}
@@ -1,12 +1,35 @@
 package pricing.vat
 
-# Calculate the VAT (20%) for each item
+# Tax-exempt customers (B2B, charities) pass an exemption code and pay no VAT
+exempt {
+    input.params.tax_exemption_code != ""
+}
+
+# VAT rate per tax category; the pricer sends "standard" for items without a category
+vat_rates = {
+    "standard": 0.20,
+    "food": 0.10,
+    "electronics": 0.20,
+}
+
+# Items without a category or of an unknown one pay the standard rate
+vat_rate(item) = rate {
+    rate := object.get(vat_rates, object.get(item, "taxCategory", "standard"), vat_rates.standard)
+}
+
+# Calculate the VAT for each item at its category rate
 vat[item_id] = tax {
+    not exempt
     some i
     item := input.items[i]
-    tax := item.price * 0.20
+    tax := item.price * vat_rate(item)
     item_id := item.productId
 }
 
+default total_vat = 0
+
 # Calculate the total VAT for all items
-total_vat = sum([tax | some i; item := input.items[i]; tax := item.price * 0.20])
+total_vat = total {
+    not exempt
+    total := sum([tax | some i; item := input.items[i]; tax := item.price * vat_rate(item)])
+}
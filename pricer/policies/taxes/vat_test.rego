@@ -41,3 +41,39 @@ test_total_vat {
     # Assertion
     total == expected_total_vat
 }
+
+# Test 3: Tax-exempt customers pay no VAT
+test_exempt_total_vat {
+    input := {
+        "items": [
+            {"productId": "item1", "price": 100},
+            {"productId": "item2", "price": 200}
+        ],
+        "params": {"tax_exemption_code": "CHARITY"}
+    }
+
+    total := vat.total_vat with input as input
+
+    # Assertion
+    total == 0
+}
+
+# Test 4: Items pay the VAT rate of their tax category
+test_vat_by_tax_category {
+    input := {
+        "items": [
+            {"productId": "item1", "price": 100, "taxCategory": "food"},
+            {"productId": "item2", "price": 100, "taxCategory": "electronics"},
+            {"productId": "item3", "price": 100, "taxCategory": "furniture"}
+        ]
+    }
+
+    vats := vat.vat with input as input
+    total := vat.total_vat with input as input
+
+    # Assertions
+    vats["item1"] == 10.0   # 10% food
+    vats["item2"] == 20.0   # 20% electronics
+    vats["item3"] == 20.0   # unknown category, standard 20%
+    total == 50.0
+}